@@ -7,15 +7,31 @@
 // The library is organized into focused, domain-specific modules:
 //
 //   - colors.go      - Color types and color management
+//   - colorparse.go  - Hex and CSS color strings
 //   - geometry.go    - Geometric primitives (rectangles, points)
 //   - transforms.go  - 2D transformations and viewport operations
+//   - units.go       - Physical units (points, millimeters) and resolution
 //   - gradients.go   - Gradient creation and management
 //   - images.go      - Image loading, manipulation, and rendering
+//   - imageio.go     - QOI and WebP support, saving by file extension
+//   - paletted.go    - Indexed-color conversion and GIF export
 //   - text.go        - Text rendering and typography
+//   - font.go        - Glyph outlines read from font files
 //   - stroke.go      - Stroke attributes and line styling
+//   - bspline.go     - Spline interpolation for curves and lookup tables
 //   - blending.go    - Blend modes and alpha compositing
 //   - fill_rules.go  - Fill rule constants (even-odd, non-zero winding)
 //   - context.go     - Main rendering context (primary interface)
+//   - stats.go       - Optional rendering pipeline counters
+//   - spanalloc.go   - Reusable color span buffers for fills
+//   - banded.go      - Band-by-band rendering for streaming output
+//   - measure.go     - Dry-run drawing for layout and damage bounds
+//   - resize.go      - Resizing a context with or without its content
+//   - snapshot.go    - Tiled framebuffer snapshots for undo
+//   - stamp.go       - Marker stamps repeated along a path
+//   - shapes.go      - Regular polygons, stars and superellipses
+//   - density.go     - Coverage accumulation for heatmaps
+//   - hitregions.go  - Shape picking for interactive scenes
 //
 // Basic usage:
 //
@@ -33,6 +49,7 @@ package agg
 
 import (
 	"fmt"
+	"image"
 	"math"
 	"os"
 
@@ -95,6 +112,7 @@ type (
 	LineCap       = agg2d.LineCap
 	LineJoin      = agg2d.LineJoin
 	ImageResample = agg2d.ImageResample
+	ImageEdge     = agg2d.ImageEdge
 	TextAlignment = agg2d.TextAlignment
 )
 
@@ -105,11 +123,27 @@ const (
 	CapRound  LineCap = agg2d.CapRound
 )
 
-// LineJoin constants
+// LineJoin constants. JoinMiter clips miters at the miter limit, like SVG 2
+// miter-clip; JoinMiterRevert falls back to a bevel as SVG 1.1 and canvas do.
 const (
-	JoinMiter LineJoin = agg2d.JoinMiter
-	JoinRound LineJoin = agg2d.JoinRound
-	JoinBevel LineJoin = agg2d.JoinBevel
+	JoinMiter       LineJoin = agg2d.JoinMiter
+	JoinMiterRevert LineJoin = agg2d.JoinMiterRevert
+	JoinRound       LineJoin = agg2d.JoinRound
+	JoinBevel       LineJoin = agg2d.JoinBevel
+	JoinMiterRound  LineJoin = agg2d.JoinMiterRound
+	JoinMiterClip   LineJoin = agg2d.JoinMiterClip
+	JoinArcs        LineJoin = agg2d.JoinArcs
+)
+
+// InnerJoin selects how the inside of stroke corners is joined.
+type InnerJoin = agg2d.InnerJoin
+
+// InnerJoin constants
+const (
+	InnerBevel InnerJoin = agg2d.InnerBevel
+	InnerMiter InnerJoin = agg2d.InnerMiter
+	InnerJag   InnerJoin = agg2d.InnerJag
+	InnerRound InnerJoin = agg2d.InnerRound
 )
 
 // Backward-compatible aliases kept for the old agg.go API naming.
@@ -119,6 +153,23 @@ const (
 	ResampleBicubic  ImageResample = agg2d.ResampleOnZoomOut
 )
 
+// ImageEdge constants select what image drawing samples beyond the edges of
+// the source image.
+const (
+	// EdgeClamp repeats the edge pixels. It is the default.
+	EdgeClamp ImageEdge = agg2d.EdgeClamp
+	// EdgeTransparent treats everything outside the source rectangle as
+	// transparent, so filtered edges fade out instead of smearing.
+	EdgeTransparent ImageEdge = agg2d.EdgeTransparent
+	// EdgeRepeat tiles the source rectangle.
+	EdgeRepeat ImageEdge = agg2d.EdgeRepeat
+	// EdgeReflect tiles the source rectangle, mirroring every other copy.
+	EdgeReflect ImageEdge = agg2d.EdgeReflect
+	// EdgeColor fills everything outside the source rectangle with the image
+	// background color.
+	EdgeColor ImageEdge = agg2d.EdgeColor
+)
+
 // TextAlignment constants
 const (
 	AlignLeft   TextAlignment = agg2d.AlignLeft
@@ -142,6 +193,7 @@ type Agg2D struct {
 	attachedWidth  int
 	attachedHeight int
 	attachedStride int
+	attachedFormat agg2d.TargetFormat
 }
 
 // NewAgg2D creates a new AGG2D rendering context.
@@ -153,11 +205,17 @@ func NewAgg2D() *Agg2D {
 
 // Attach attaches a rendering buffer to the AGG2D context.
 func (a *Agg2D) Attach(buf []uint8, width, height, stride int) {
-	a.impl.Attach(buf, width, height, stride)
+	a.attach(buf, width, height, stride, agg2d.TargetRGBA32)
+}
+
+// attach is Attach for a buffer with pixels in format f.
+func (a *Agg2D) attach(buf []uint8, width, height, stride int, f agg2d.TargetFormat) {
+	a.impl.AttachFormat(buf, width, height, stride, f)
 	a.attachedBuffer = buf
 	a.attachedWidth = width
 	a.attachedHeight = height
 	a.attachedStride = stride
+	a.attachedFormat = f
 }
 
 // AttachImage attaches the rendering context to an existing Image.
@@ -166,11 +224,40 @@ func (a *Agg2D) Attach(buf []uint8, width, height, stride int) {
 //	void Agg2D::attach(Image& img) {
 //	    attach(img.renBuf.buf(), img.renBuf.width(), img.renBuf.height(), img.renBuf.stride());
 //	}
+//
+// The image is drawn into in its own pixel format: RGBA and BGRA images
+// directly, RGB, Gray8 and RGB565 images by converting the pixels each
+// drawing operation touches. Gray16 images and channel views are ignored.
 func (a *Agg2D) AttachImage(img *Image) {
 	if img == nil || img.renBuf == nil {
 		return
 	}
-	a.Attach(img.Data, img.width, img.height, img.renBuf.Stride())
+	if f, ok := img.targetFormat(); ok {
+		a.attach(img.Data, img.width, img.height, img.renBuf.Stride(), f)
+	}
+}
+
+// AttachRGBA attaches the rendering context to the pixels of img, without
+// copying. The stride of img is honoured, and for a sub-image the origin of
+// the context is img.Rect.Min. Like image.RGBA, the buffer holds
+// premultiplied colors, which is what Agg2D's blending produces, so img can
+// be handed to image/draw or an encoder as is.
+func (a *Agg2D) AttachRGBA(img *image.RGBA) {
+	if img == nil {
+		return
+	}
+	pix, w, h := rgbaPixels(img)
+	a.Attach(pix, w, h, img.Stride)
+}
+
+// rgbaPixels returns the pixels of img starting at img.Rect.Min, with the
+// size of img.Rect.
+func rgbaPixels(img *image.RGBA) (pix []uint8, width, height int) {
+	r := img.Rect
+	if r.Empty() {
+		return nil, 0, 0
+	}
+	return img.Pix[img.PixOffset(r.Min.X, r.Min.Y):], r.Dx(), r.Dy()
 }
 
 // ClipBox sets the clipping rectangle.
@@ -227,6 +314,20 @@ func (a *Agg2D) InBox(worldX, worldY float64) bool {
 	return a.impl.InBox(worldX, worldY)
 }
 
+// FillRects fills a batch of rectangles, each with its own color; see
+// Context.FillRects.
+func (a *Agg2D) FillRects(rects []RectColor) {
+	batch := make([]agg2d.RectColor, len(rects))
+	for i, r := range rects {
+		batch[i] = agg2d.RectColor{
+			X: r.X, Y: r.Y, Width: r.Width, Height: r.Height,
+			Radius: r.Radius,
+			Color:  [4]uint8{r.Color.R, r.Color.G, r.Color.B, r.Color.A},
+		}
+	}
+	a.impl.FillRects(batch)
+}
+
 // FillColor sets the fill color.
 func (a *Agg2D) FillColor(c Color) {
 	internalColor := [4]uint8{c.R, c.G, c.B, c.A}
@@ -269,6 +370,16 @@ func (a *Agg2D) LineJoin(join LineJoin) {
 	a.impl.LineJoin(int(join))
 }
 
+// InnerJoin sets the inner join style.
+func (a *Agg2D) InnerJoin(join InnerJoin) {
+	a.impl.InnerJoin(join)
+}
+
+// GetInnerJoin returns the inner join style.
+func (a *Agg2D) GetInnerJoin() InnerJoin {
+	return a.impl.GetInnerJoin()
+}
+
 // FillLinearGradient sets up a linear gradient for fill operations.
 func (a *Agg2D) FillLinearGradient(x1, y1, x2, y2 float64, c1, c2 Color, profile float64) {
 	internalC1 := [4]uint8{c1.R, c1.G, c1.B, c1.A}
@@ -313,6 +424,86 @@ func (a *Agg2D) LineRadialGradientMultiStop(x, y, r float64, c1, c2, c3 Color) {
 	a.impl.LineRadialGradientMultiStop(x, y, r, internalC1, internalC2, internalC3)
 }
 
+// SetFillGradient installs a reusable gradient for fill operations; nil
+// selects a solid fill.
+func (a *Agg2D) SetFillGradient(g *GradientPaint) {
+	if g == nil {
+		a.impl.SetFillGradient(nil)
+		return
+	}
+	a.impl.SetFillGradient(g.impl)
+}
+
+// SetLineGradient installs a reusable gradient for line/stroke operations;
+// nil selects a solid line.
+func (a *Agg2D) SetLineGradient(g *GradientPaint) {
+	if g == nil {
+		a.impl.SetLineGradient(nil)
+		return
+	}
+	a.impl.SetLineGradient(g.impl)
+}
+
+// GradientDithering turns ordered dithering on or off for all gradients.
+func (a *Agg2D) GradientDithering(on bool) {
+	a.impl.SetGradientDithering(on)
+}
+
+// GetGradientDithering reports whether all gradients are dithered.
+func (a *Agg2D) GetGradientDithering() bool {
+	return a.impl.GradientDithering()
+}
+
+// SetFillPattern installs an image pattern for fill operations; nil selects
+// a solid fill.
+func (a *Agg2D) SetFillPattern(p *PatternPaint) {
+	if p == nil {
+		a.impl.SetFillPattern(nil)
+		return
+	}
+	a.impl.SetFillPattern(p.impl)
+}
+
+// SetLinePattern installs an image pattern for line/stroke operations; nil
+// selects a solid line.
+func (a *Agg2D) SetLinePattern(p *PatternPaint) {
+	if p == nil {
+		a.impl.SetLinePattern(nil)
+		return
+	}
+	a.impl.SetLinePattern(p.impl)
+}
+
+// SetFillPaint installs any Paint, with its opacity and blend mode, for fill
+// operations; nil selects a solid fill.
+func (a *Agg2D) SetFillPaint(p Paint) {
+	if p == nil {
+		a.impl.SetFillGradient(nil)
+		return
+	}
+	p.setFill(a)
+}
+
+// SetLinePaint installs any Paint for line/stroke operations; nil selects a
+// solid line.
+func (a *Agg2D) SetLinePaint(p Paint) {
+	if p == nil {
+		a.impl.SetLineGradient(nil)
+		return
+	}
+	p.setStroke(a)
+}
+
+// SetFillAlphaGradient installs an opacity mask over fill operations; nil
+// removes it.
+func (a *Agg2D) SetFillAlphaGradient(g *GradientPaint) {
+	if g == nil {
+		a.impl.SetFillAlphaGradient(nil)
+		return
+	}
+	a.impl.SetFillAlphaGradient(g.impl)
+}
+
 // FillGradientFlag returns the current fill gradient type.
 func (a *Agg2D) FillGradientFlag() int {
 	return a.impl.FillGradientFlag()
@@ -363,6 +554,12 @@ func (a *Agg2D) TransformImageParallelogramSimple(img *Image, parallelogram []fl
 	return a.impl.TransformImageParallelogramSimple(img.ToInternalImage(), parallelogram)
 }
 
+// TransformImageRotated renders the whole image centered on (cx, cy), scaled
+// and rotated about its center, sampled with filter for this call only.
+func (a *Agg2D) TransformImageRotated(img *Image, cx, cy, angle, scale float64, filter ImageFilter) error {
+	return a.impl.TransformImageRotated(img.ToInternalImage(), cx, cy, angle, scale, filter)
+}
+
 // ResetTransformations resets the transformation matrix to identity.
 func (a *Agg2D) ResetTransformations() {
 	a.impl.ResetTransformations()
@@ -413,6 +610,21 @@ func (a *Agg2D) PopTransform() bool {
 	return a.impl.PopTransform()
 }
 
+// State is an opaque snapshot of the Agg2D drawing state, see Agg2D.State.
+type State = agg2d.State
+
+// State captures the drawing state: paints, transform, clip box, blend modes,
+// master alpha, gamma, stroke, fill rule, image and text attributes. Put it
+// back with SetState, for example around callbacks into user code.
+func (a *Agg2D) State() State {
+	return a.impl.State()
+}
+
+// SetState re-applies a state captured with State.
+func (a *Agg2D) SetState(s State) {
+	a.impl.SetState(s)
+}
+
 // WorldToScreenDistance converts a scalar distance using the current transform scale.
 func (a *Agg2D) WorldToScreenDistance(worldDistance float64) float64 {
 	return a.impl.WorldToScreenDistance(worldDistance)
@@ -508,6 +720,30 @@ func (a *Agg2D) ImageResample(r ImageResample) {
 	a.impl.ImageResample(int(r))
 }
 
+// ImageEdge sets what image drawing samples beyond the source rectangle.
+// Besides filtered edges, it decides how TransformImagePath fills a path
+// larger than the image: EdgeRepeat and EdgeReflect tile it.
+func (a *Agg2D) ImageEdge(e ImageEdge) {
+	a.impl.ImageEdge(int(e))
+}
+
+// GetImageEdge returns the current image edge mode.
+func (a *Agg2D) GetImageEdge() ImageEdge {
+	return ImageEdge(a.impl.GetImageEdge())
+}
+
+// ImageBackgroundColor sets the color the EdgeColor edge mode shows outside
+// the source rectangle.
+func (a *Agg2D) ImageBackgroundColor(c Color) {
+	a.impl.ImageBackgroundColor([4]uint8{c.R, c.G, c.B, c.A})
+}
+
+// GetImageBackgroundColor returns the image background color.
+func (a *Agg2D) GetImageBackgroundColor() Color {
+	c := a.impl.GetImageBackgroundColor()
+	return Color{R: c[0], G: c[1], B: c[2], A: c[3]}
+}
+
 // GetImageFilter returns the current image filtering method.
 func (a *Agg2D) GetImageFilter() ImageFilter {
 	return ImageFilter(a.impl.GetImageFilter())
@@ -718,11 +954,28 @@ func (a *Agg2D) Viewport(worldX1, worldY1, worldX2, worldY2, screenX1, screenY1,
 	a.impl.Viewport(worldX1, worldY1, worldX2, worldY2, screenX1, screenY1, screenX2, screenY2, int(opt))
 }
 
-// Font loads and activates a font file for subsequent text rendering.
+// Font loads and activates a font file for subsequent text rendering. Glyph
+// options set by an earlier FontWithOptions call are reset to their defaults.
 func (a *Agg2D) Font(fontName string, height float64, bold, italic bool, cacheType FontCacheType, angle float64) error {
 	return a.impl.Font(fontName, height, bold, italic, cacheType, angle)
 }
 
+// FontWithOptions loads a font like Font, with explicit control over the glyph
+// rendering mode (gray8, mono, LCD or outline), hinting and LCD stripe order.
+func (a *Agg2D) FontWithOptions(fontName string, height float64, opts FontOptions) error {
+	return a.impl.FontWithOptions(fontName, height, opts)
+}
+
+// GetGlyphRendering returns the glyph rendering mode of the current font.
+func (a *Agg2D) GetGlyphRendering() GlyphRendering {
+	return a.impl.GetGlyphRendering()
+}
+
+// GetHintingMode returns the hinting mode of the current font.
+func (a *Agg2D) GetHintingMode() HintingMode {
+	return a.impl.GetHintingMode()
+}
+
 // FontHeight returns the configured font height in world units.
 func (a *Agg2D) FontHeight() float64 {
 	return a.impl.FontHeight()
@@ -737,6 +990,12 @@ func (a *Agg2D) FontGSV(height float64) {
 	a.impl.FontGSV(height)
 }
 
+// BitmapFont selects the embedded raster font Text uses while no font file
+// is loaded and FontGSV is not active. nil restores BitmapFontGSE8x16.
+func (a *Agg2D) BitmapFont(font BitmapFont) {
+	a.impl.BitmapFont(font)
+}
+
 // FlipText toggles the AGG text-direction convention used by the font engine.
 func (a *Agg2D) FlipText(flip bool) {
 	a.impl.FlipText(flip)
@@ -752,6 +1011,60 @@ func (a *Agg2D) GetTextHints() bool {
 	return a.impl.GetTextHints()
 }
 
+// TextKerning enables or disables pair kerning from the font engine.
+func (a *Agg2D) TextKerning(kerning bool) {
+	a.impl.TextKerning(kerning)
+}
+
+// GetTextKerning reports whether pair kerning is applied to text.
+func (a *Agg2D) GetTextKerning() bool {
+	return a.impl.GetTextKerning()
+}
+
+// TextLayout returns the cluster, caret and hit-test geometry of text laid
+// out with the current font settings.
+func (a *Agg2D) TextLayout(text string) *TextLayout {
+	return a.impl.TextLayout(text)
+}
+
+// TextLigatures enables or disables standard ligatures (fi, fl, ff, ffi, ffl)
+// for fonts that provide the precomposed glyphs.
+func (a *Agg2D) TextLigatures(ligatures bool) {
+	a.impl.TextLigatures(ligatures)
+}
+
+// GetTextLigatures reports whether standard ligatures are substituted.
+func (a *Agg2D) GetTextLigatures() bool {
+	return a.impl.GetTextLigatures()
+}
+
+// SetTextShaper sets the shaper that orders and selects glyphs for text. nil
+// restores the default SimpleShaper.
+func (a *Agg2D) SetTextShaper(shaper TextShaper) {
+	a.impl.SetTextShaper(shaper)
+}
+
+// GetTextShaper returns the installed shaper, or nil for the default.
+func (a *Agg2D) GetTextShaper() TextShaper {
+	return a.impl.GetTextShaper()
+}
+
+// AddFallbackFont appends a font to the fallback chain used for characters the
+// primary font has no glyph for.
+func (a *Agg2D) AddFallbackFont(fileName string) error {
+	return a.impl.AddFallbackFont(fileName)
+}
+
+// ClearFallbackFonts removes all fallback fonts.
+func (a *Agg2D) ClearFallbackFonts() {
+	a.impl.ClearFallbackFonts()
+}
+
+// FallbackFonts returns the fallback font files in lookup order.
+func (a *Agg2D) FallbackFonts() []string {
+	return a.impl.FallbackFonts()
+}
+
 // MiterLimit sets the stroke miter limit.
 func (a *Agg2D) MiterLimit(ml float64) {
 	a.impl.MiterLimit(ml)
@@ -762,6 +1075,52 @@ func (a *Agg2D) GetMiterLimit() float64 {
 	return a.impl.GetMiterLimit()
 }
 
+// ApproximationScale sets the curve and round join/cap approximation factor.
+// It multiplies the scale of the current transform, so zoomed-in round joins
+// and caps stay smooth without adjusting it by hand.
+func (a *Agg2D) ApproximationScale(scale float64) {
+	a.impl.ApproximationScale(scale)
+}
+
+// GetApproximationScale returns the factor set with ApproximationScale.
+func (a *Agg2D) GetApproximationScale() float64 {
+	return a.impl.GetApproximationScale()
+}
+
+// GetEffectiveApproximationScale returns the scale strokes and curves are
+// flattened with: the approximation factor times the largest stretch of the
+// current transform.
+func (a *Agg2D) GetEffectiveApproximationScale() float64 {
+	return a.impl.EffectiveApproximationScale()
+}
+
+// CellOverflowFunc is called when a path needs more rasterizer cells than
+// the cell block limit allows; limit is the limit that was hit.
+type CellOverflowFunc = agg2d.CellOverflowFunc
+
+// DefaultCellBlockLimit is the rasterizer cell block limit of a new Agg2D,
+// about 64 MiB of cells.
+const DefaultCellBlockLimit = agg2d.DefaultCellBlockLimit
+
+// SetCellBlockLimit caps the rasterizer memory of a single path at limit
+// blocks of 4096 cells; 0 selects DefaultCellBlockLimit. Raise it for huge
+// paths, lower it to bound memory use. Paths beyond the limit render
+// truncated.
+func (a *Agg2D) SetCellBlockLimit(limit uint32) {
+	a.impl.SetCellBlockLimit(limit)
+}
+
+// CellBlockLimit returns the rasterizer cell block limit.
+func (a *Agg2D) CellBlockLimit() uint32 {
+	return a.impl.CellBlockLimit()
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit, or removes it when fn is nil.
+func (a *Agg2D) SetOverflowHandler(fn CellOverflowFunc) {
+	a.impl.SetOverflowHandler(fn)
+}
+
 // AddDash appends one dash-gap pair to the current dash pattern.
 func (a *Agg2D) AddDash(dashLen, gapLen float64) {
 	a.impl.AddDash(dashLen, gapLen)
@@ -782,6 +1141,51 @@ func (a *Agg2D) GetDashStart() float64 {
 	return a.impl.GetDashStart()
 }
 
+// SetDashPattern replaces the dash pattern with SVG stroke-dasharray values.
+// Odd-length patterns are repeated; invalid patterns give a solid stroke.
+func (a *Agg2D) SetDashPattern(pattern []float64) {
+	a.impl.SetDashPattern(pattern)
+}
+
+// AdvanceDashStart moves the dash-phase offset by delta, wrapping around the
+// pattern length. Call it once per frame for marching-ants selections.
+func (a *Agg2D) AdvanceDashStart(delta float64) {
+	a.impl.AdvanceDashStart(delta)
+}
+
+// DashPatternLength returns the length of one dash cycle.
+func (a *Agg2D) DashPatternLength() float64 {
+	return a.impl.DashPatternLength()
+}
+
+// DashCap sets the cap drawn at each dash end, overriding LineCap for dashed
+// strokes.
+func (a *Agg2D) DashCap(lineCap LineCap) {
+	a.impl.DashCap(lineCap)
+}
+
+// ClearDashCap makes dashes use the line cap again.
+func (a *Agg2D) ClearDashCap() {
+	a.impl.ClearDashCap()
+}
+
+// GetDashCap returns the cap used for dash ends.
+func (a *Agg2D) GetDashCap() LineCap {
+	return a.impl.GetDashCap()
+}
+
+// StrokeWithWidths strokes the current path with per-vertex widths, such as
+// pen pressure samples, producing a tapered outline.
+func (a *Agg2D) StrokeWithWidths(widths []float64) {
+	a.impl.StrokeWithWidths(widths)
+}
+
+// StrokeWithWidthFunc strokes the current path with the width f(t) at each
+// relative position t in [0, 1] along every sub-path.
+func (a *Agg2D) StrokeWithWidthFunc(f func(t float64) float64) {
+	a.impl.StrokeWithWidthFunc(f)
+}
+
 // NoDashes disables dashed stroke rendering.
 func (a *Agg2D) NoDashes() {
 	a.impl.NoDashes()
@@ -807,6 +1211,18 @@ func (a *Agg2D) IsNonZeroFillRule() bool {
 	return a.impl.IsNonZeroFillRule()
 }
 
+// SetAutoClose selects whether the rasterizer closes open subpaths of fills
+// with a straight line back to their start (the default) or adds no closing
+// edge.
+func (a *Agg2D) SetAutoClose(on bool) {
+	a.impl.SetAutoClose(on)
+}
+
+// GetAutoClose reports whether fills close open subpaths.
+func (a *Agg2D) GetAutoClose() bool {
+	return a.impl.GetAutoClose()
+}
+
 // FillRuleDescription returns a human-readable description of the active fill rule.
 func (a *Agg2D) FillRuleDescription() string {
 	return a.impl.FillRuleDescription()
@@ -822,6 +1238,22 @@ func (a *Agg2D) TextWidth(str string) float64 {
 	return a.impl.TextWidth(str)
 }
 
+// SetGlyphRunCacheSize sets how many laid-out strings are kept for reuse by
+// Text and TextWidth. Zero disables the glyph-run cache.
+func (a *Agg2D) SetGlyphRunCacheSize(n int) {
+	a.impl.SetGlyphRunCacheSize(n)
+}
+
+// GlyphRunCacheStats returns usage statistics of the glyph-run cache.
+func (a *Agg2D) GlyphRunCacheStats() GlyphRunCacheStats {
+	return a.impl.GlyphRunCacheStats()
+}
+
+// ClearGlyphRunCache drops all cached glyph runs and resets the statistics.
+func (a *Agg2D) ClearGlyphRunCache() {
+	a.impl.ClearGlyphRunCache()
+}
+
 // Blend mode methods
 func (a *Agg2D) BlendMode(mode BlendMode) {
 	a.impl.SetBlendMode(mode)
@@ -864,11 +1296,47 @@ func (a *Agg2D) GetMasterAlpha() float64 {
 	return a.impl.GetMasterAlpha()
 }
 
-// AntiAliasGamma sets the gamma applied to scanline coverage values.
+// AntiAliasGamma sets the gamma applied to edge coverage of fills, strokes
+// and, unless disabled with SetTextGamma, text. Values above 1 give bolder,
+// softer edges; values below 1 give thinner, crisper ones. The value is
+// clamped to [0.1, 3].
 func (a *Agg2D) AntiAliasGamma(gamma float64) {
 	a.impl.SetAntiAliasGamma(gamma)
 }
 
+// SetTextGamma sets whether the anti-alias gamma also applies to text
+// (enabled by default).
+func (a *Agg2D) SetTextGamma(enabled bool) {
+	a.impl.SetTextGamma(enabled)
+}
+
+// GetTextGamma reports whether the anti-alias gamma applies to text.
+func (a *Agg2D) GetTextGamma() bool {
+	return a.impl.GetTextGamma()
+}
+
+// SetPixelSnapping enables fitting horizontal and vertical edges and stroke
+// centerlines to the pixel grid, for crisp 1px UI lines.
+func (a *Agg2D) SetPixelSnapping(on bool) {
+	a.impl.SetPixelSnapping(on)
+}
+
+// GetPixelSnapping reports whether pixel snapping is enabled.
+func (a *Agg2D) GetPixelSnapping() bool {
+	return a.impl.GetPixelSnapping()
+}
+
+// SetPixelAccurateLines enables drawing solid strokes up to one device pixel
+// wide as aliased integer Bresenham lines.
+func (a *Agg2D) SetPixelAccurateLines(on bool) {
+	a.impl.SetPixelAccurateLines(on)
+}
+
+// GetPixelAccurateLines reports whether the pixel accuracy mode is enabled.
+func (a *Agg2D) GetPixelAccurateLines() bool {
+	return a.impl.GetPixelAccurateLines()
+}
+
 // GetAntiAliasGamma returns the current anti-alias gamma value.
 func (a *Agg2D) GetAntiAliasGamma() float64 {
 	return a.impl.GetAntiAliasGamma()
@@ -890,7 +1358,7 @@ func (a *Agg2D) ResetStyle() {
 	a.impl.ResetStyle()
 }
 
-// SaveImagePPM writes the currently attached RGBA buffer as a binary PPM file.
+// SaveImagePPM writes the currently attached buffer as a binary PPM file.
 func (a *Agg2D) SaveImagePPM(filename string) error {
 	if a.attachedBuffer == nil || a.attachedWidth <= 0 || a.attachedHeight <= 0 || a.attachedStride <= 0 {
 		return fmt.Errorf("no attached RGBA buffer")
@@ -906,18 +1374,17 @@ func (a *Agg2D) SaveImagePPM(filename string) error {
 		return err
 	}
 
+	bpp := a.attachedFormat.BytesPerPixel()
 	rgbRow := make([]byte, a.attachedWidth*3)
 	for y := 0; y < a.attachedHeight; y++ {
 		rowStart := y * a.attachedStride
 		for x := 0; x < a.attachedWidth; x++ {
-			src := rowStart + x*4
-			dst := x * 3
-			if src+2 >= len(a.attachedBuffer) {
+			src := rowStart + x*bpp
+			if src+bpp > len(a.attachedBuffer) {
 				return fmt.Errorf("attached buffer too small for %dx%d image", a.attachedWidth, a.attachedHeight)
 			}
-			rgbRow[dst] = a.attachedBuffer[src]
-			rgbRow[dst+1] = a.attachedBuffer[src+1]
-			rgbRow[dst+2] = a.attachedBuffer[src+2]
+			c := a.attachedFormat.Decode(a.attachedBuffer[src:])
+			copy(rgbRow[x*3:], c[:3])
 		}
 		if _, err := file.Write(rgbRow); err != nil {
 			return err
@@ -968,8 +1435,15 @@ func (a *Agg2D) CopyImageSimple(img *Image, dstX, dstY float64) error {
 	return a.impl.CopyImageSimple(img.ToInternalImage(), dstX, dstY)
 }
 
+// InternalRasterizer is the type of the rasterizer behind an Agg2D. It clips
+// edges to the clip box before they reach the cells, so unlike earlier
+// versions, which used an unclipped RasterizerSlNoClip rasterizer, it is
+// instantiated with RasterizerSlClip. Name it through this alias rather than
+// spelling out the instantiation.
+type InternalRasterizer = rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlClip[int, rasterizer.RasConvInt]]
+
 // GetInternalRasterizer returns the underlying rasterizer for advanced usage.
-func (a *Agg2D) GetInternalRasterizer() *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip] {
+func (a *Agg2D) GetInternalRasterizer() *InternalRasterizer {
 	return a.impl.GetInternalRasterizer()
 }
 
@@ -980,14 +1454,14 @@ func (a *Agg2D) RenderRasterizerWithColor(c Color) {
 }
 
 // ScanlineRender renders the current rasterizer data using a custom renderer.
-func (a *Agg2D) ScanlineRender(ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip], renderer renscan.RendererInterface[color.RGBA8[color.Linear]]) {
+func (a *Agg2D) ScanlineRender(ras *InternalRasterizer, renderer renscan.RendererInterface[color.RGBA8[color.Linear]]) {
 	a.impl.ScanlineRender(ras, renderer)
 }
 
 // RenderScanlinesAAWithSpanGen renders the rasterizer using a custom span generator.
 // This enables advanced effects such as combining color gradients with alpha gradients.
 func (a *Agg2D) RenderScanlinesAAWithSpanGen(
-	ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip],
+	ras *InternalRasterizer,
 	spanGen renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]],
 ) {
 	a.impl.RenderScanlinesAAWithSpanGen(ras, spanGen)
@@ -1,7 +1,11 @@
 package agg
 
 import (
+	"image"
+	"image/color"
+	"image/draw"
 	"math"
+	"slices"
 	"testing"
 )
 
@@ -121,3 +125,241 @@ func TestAgg2DPublicWrappers(t *testing.T) {
 		t.Fatalf("Parallelogram() = %#v, want %#v", got, want)
 	}
 }
+
+func TestRenderStats(t *testing.T) {
+	defer DisableStats()
+	ctx := NewContext(64, 64)
+	ctx.SetColor(Red)
+
+	EnableStats()
+	ResetStats()
+	if !StatsEnabled() {
+		t.Fatal("StatsEnabled() = false after EnableStats()")
+	}
+	ctx.FillCircle(32, 32, 20)
+	s := Stats()
+	if s.CellsAllocated == 0 || s.ScanlinesSwept == 0 || s.SpansBlended == 0 {
+		t.Fatalf("Stats() after FillCircle = %+v, want non-zero counters", s)
+	}
+	// The circle covers about pi*20^2 pixels plus its anti-aliased edge.
+	if s.PixelsTouched < 1200 || s.PixelsTouched > 1500 {
+		t.Errorf("PixelsTouched = %d, want about 1257", s.PixelsTouched)
+	}
+
+	ctx.FillCircle(32, 32, 20)
+	if d := Stats().Sub(s); d != s {
+		t.Errorf("second frame = %+v, want %+v", d, s)
+	}
+
+	DisableStats()
+	ResetStats()
+	ctx.FillCircle(32, 32, 20)
+	if s := Stats(); s != (RenderStats{}) {
+		t.Errorf("Stats() while disabled = %+v, want zero", s)
+	}
+}
+
+func TestContextSpanAllocator(t *testing.T) {
+	ctx := NewContext(300, 40)
+	sa := NewSpanAllocator()
+	sa.Reserve(ctx.Width())
+	ctx.SetSpanAllocator(sa)
+	if ctx.SpanAllocator() != sa {
+		t.Fatal("SpanAllocator() should return the installed allocator")
+	}
+
+	ctx.SetFillGradient(NewLinearGradient(0, 0, 300, 0, GradientStop{Position: 0, Color: Red}, GradientStop{Position: 1, Color: Blue}))
+	ctx.FillRectangle(0, 0, 300, 40)
+	ctx.FillRectangle(0, 0, 300, 40)
+
+	s := sa.Stats()
+	if s.Grows != 1 || s.Capacity != 512 {
+		t.Errorf("reserved allocator should not grow while rendering, got %+v", s)
+	}
+	if s.Allocations < 80 || s.MaxSpan < 299 {
+		t.Errorf("expected one span per scanline and frame, got %+v", s)
+	}
+
+	ctx.SetSpanAllocator(nil)
+	if ctx.SpanAllocator() == nil || ctx.SpanAllocator() == sa {
+		t.Error("SetSpanAllocator(nil) should install a fresh allocator")
+	}
+}
+
+func TestContextSnapshotRestore(t *testing.T) {
+	ctx := NewContext(150, 100)
+	ctx.Clear(White)
+	base := ctx.Snapshot()
+
+	ctx.SetColor(Red)
+	ctx.FillRectangle(10, 10, 20, 20)
+	edit := ctx.Snapshot()
+
+	// Only the top-left tile changed; all others are shared with base.
+	shared := 0
+	for i := range edit.tiles {
+		if &edit.tiles[i][0] == &base.tiles[i][0] {
+			shared++
+		}
+	}
+	if want := len(edit.tiles) - 1; shared != want {
+		t.Fatalf("shared tiles = %d, want %d", shared, want)
+	}
+
+	pixel := func() []uint8 {
+		off := 20*150*4 + 20*4
+		return ctx.GetImage().Data[off : off+4]
+	}
+	ctx.Restore(base)
+	if p := pixel(); p[0] != 255 || p[1] != 255 || p[2] != 255 {
+		t.Fatalf("after restore(base) pixel = %v, want white", p)
+	}
+	ctx.Restore(edit)
+	if p := pixel(); p[0] != 255 || p[1] != 0 || p[2] != 0 {
+		t.Fatalf("after restore(edit) pixel = %v, want red", p)
+	}
+
+	ctx.Resize(40, 40, ResizeDiscard)
+	ctx.Restore(base)
+	if ctx.Width() != 150 || ctx.Height() != 100 {
+		t.Fatalf("restore did not resize: %dx%d", ctx.Width(), ctx.Height())
+	}
+	if p := pixel(); p[0] != 255 || p[3] != 255 {
+		t.Fatalf("after resize and restore pixel = %v, want white", p)
+	}
+
+	ctx.Restore(Snapshot{})
+	if p := pixel(); p[0] != 255 || p[3] != 255 {
+		t.Fatalf("restoring an empty snapshot changed pixels: %v", p)
+	}
+}
+
+func TestImageDataPixels(t *testing.T) {
+	// Premultiplied BGRA: (R 100, G 50, B 0, A 128) and opaque blue.
+	bgra := NewBGRAImage([]uint8{0, 25, 50, 128, 255, 0, 0, 255}, 2, 1, 8)
+	want := []uint8{100, 50, 0, 128, 0, 0, 255, 255}
+	if got := imageDataPixels(bgra); string(got) != string(want) {
+		t.Errorf("BGRA pixels = %v, want %v", got, want)
+	}
+
+	rgba := CreateImage(2, 1)
+	if err := rgba.Demultiply(); err != nil {
+		t.Fatal(err)
+	}
+	if got := imageDataPixels(rgba); &got[0] != &rgba.Data[0] {
+		t.Error("straight tight RGBA8 image was copied")
+	}
+
+	gray := NewGrayImage([]uint8{7, 9}, 2, 1, 2)
+	if got := imageDataPixels(gray); string(got) != string([]uint8{7, 7, 7, 255, 9, 9, 9, 255}) {
+		t.Errorf("gray pixels = %v", got)
+	}
+}
+
+func TestImageDrawInterface(t *testing.T) {
+	img := NewBGRAImage(make([]uint8, 4*2*4), 4, 2, 4*4)
+	draw.Draw(img, image.Rect(1, 0, 3, 2), image.NewUniform(color.NRGBA{R: 255, A: 128}), image.Point{}, draw.Src)
+	if got := img.At(1, 1); got != (color.RGBA{R: 128, A: 128}) {
+		t.Errorf("At(1, 1) = %v, want premultiplied red", got)
+	}
+	if got := img.Data[4:8]; string(got) != string([]uint8{0, 0, 128, 128}) {
+		t.Errorf("BGRA bytes = %v", got)
+	}
+	if got := img.At(0, 0); got != (color.RGBA{}) {
+		t.Errorf("untouched pixel = %v", got)
+	}
+	img.Set(9, 9, color.White) // ignored
+	if got := img.At(9, 9); got != (color.RGBA{}) {
+		t.Errorf("At outside bounds = %v", got)
+	}
+
+	if err := img.Demultiply(); err != nil {
+		t.Fatal(err)
+	}
+	if img.ColorModel() != color.NRGBAModel {
+		t.Error("straight image does not use NRGBAModel")
+	}
+	if got := img.At(1, 0); got != (color.NRGBA{R: 255, A: 128}) {
+		t.Errorf("straight At(1, 0) = %v", got)
+	}
+
+	gray := CreateGrayImage(2, 1)
+	gray.Set(1, 0, color.White)
+	if gray.Data[1] != 255 || gray.At(1, 0) != (color.Gray{Y: 255}) {
+		t.Errorf("gray pixel = %v", gray.Data[1])
+	}
+
+	rgb := CreateImageWithFormat(1, 1, ImageRGB8)
+	rgb.Set(0, 0, color.RGBA{R: 10, G: 20, B: 30, A: 255})
+	if got := rgb.At(0, 0); got != (color.RGBA{R: 10, G: 20, B: 30, A: 255}) {
+		t.Errorf("RGB8 At = %v", got)
+	}
+}
+
+func TestContextTextLayout(t *testing.T) {
+	ctx := NewContext(200, 40)
+	text := "hello"
+	l := ctx.TextLayout(text)
+	if got, want := l.Width(), ctx.GetTextWidth(text); got != want {
+		t.Fatalf("layout width %v, TextWidth %v", got, want)
+	}
+	if n := len(l.Clusters()); n != 5 {
+		t.Fatalf("got %d clusters, want 5", n)
+	}
+	// The bitmap font is monospaced: carets step by one cell.
+	cell := l.CaretX(1)
+	if cell <= 0 || l.CaretX(3) != 3*cell || l.CaretX(len(text)) != l.Width() {
+		t.Fatalf("carets 1=%v 3=%v end=%v width=%v", cell, l.CaretX(3), l.CaretX(len(text)), l.Width())
+	}
+	if got := l.HitTest(3.6 * cell); got != 4 {
+		t.Fatalf("HitTest=%d, want 4", got)
+	}
+	// Bitmap text is drawn y-down, so the ascent is above the baseline.
+	if b := l.Bounds(); b.Y1 >= 0 || b.Y2 < 0 {
+		t.Fatalf("bounds %+v should rise above the baseline", b)
+	}
+}
+
+func TestContextDrawRichText(t *testing.T) {
+	ctx := NewContext(64, 24)
+	ctx.Clear(White)
+	ctx.SetColor(Black)
+	rt := NewRichText().Color(Red).Add("II").Color(Blue).Underline(true).Add("II")
+	width, ascent, _, err := ctx.MeasureRichText(rt)
+	if err != nil || width != ctx.GetTextWidth("IIII") || ascent <= 0 {
+		t.Fatalf("MeasureRichText = %v, %v, %v", width, ascent, err)
+	}
+	if err := ctx.DrawRichText(0, 20, rt); err != nil {
+		t.Fatal(err)
+	}
+	// Each run is drawn in its own color on the same baseline.
+	count := func(x1, x2 int, c Color) int {
+		n := 0
+		want := color.RGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+		for y := 0; y < 24; y++ {
+			for x := x1; x < x2; x++ {
+				if ctx.GetImage().At(x, y) == want {
+					n++
+				}
+			}
+		}
+		return n
+	}
+	half := int(width / 2)
+	if count(0, half, Red) == 0 || count(half, 64, Blue) == 0 || count(0, half, Blue) != 0 {
+		t.Fatal("runs not drawn in their own colors")
+	}
+	before := slices.Clone(ctx.GetImage().Data)
+	if err := ctx.DrawRichText(0, 20, NewRichText()); err != nil {
+		t.Fatalf("empty paragraph: %v", err)
+	}
+	if err := ctx.DrawRichText(0, 20, nil); err != nil {
+		t.Fatalf("nil paragraph: %v", err)
+	}
+	if !slices.Equal(before, ctx.GetImage().Data) {
+		t.Fatal("empty paragraph drew pixels")
+	}
+	if w, a, d, err := ctx.MeasureRichText(nil); w != 0 || a != 0 || d != 0 || err != nil {
+		t.Fatalf("MeasureRichText(nil) = %v, %v, %v, %v", w, a, d, err)
+	}
+}
@@ -0,0 +1,45 @@
+package agg
+
+import "errors"
+
+// RenderBanded rasterizes scene in horizontal bands of bandHeight rows and
+// hands the finished image to emit one row at a time, top to bottom. Only a
+// width x bandHeight buffer is held in memory, so large pages can be streamed
+// straight into an encoder or a network connection.
+//
+// Each band replays the whole recording, shifted so that only its own rows
+// land in the buffer; smaller bands save memory at the cost of more passes.
+// A bandHeight of zero or less renders the page as a single band. row holds
+// width RGBA pixels in the same format as Context.GetImage and is only valid
+// during the call to emit. A non-nil error from emit stops the rendering and
+// is returned.
+func RenderBanded(scene *Recording, bandHeight int, emit func(y int, row []byte) error) error {
+	if scene == nil {
+		return errors.New("scene is nil")
+	}
+	if emit == nil {
+		return errors.New("emit is nil")
+	}
+	width, height := scene.Width(), scene.Height()
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	if bandHeight <= 0 || bandHeight > height {
+		bandHeight = height
+	}
+
+	band := NewContext(width, bandHeight)
+	buf := band.GetImage().renBuf
+	for y0 := 0; y0 < height; y0 += bandHeight {
+		band.agg2d.ClearAll(Transparent)
+		if err := scene.ReplayTransformed(band, NewTransformationsFromValues(1, 0, 0, 1, 0, -float64(y0))); err != nil {
+			return err
+		}
+		for y := 0; y < bandHeight && y0+y < height; y++ {
+			if err := emit(y0+y, buf.RowPtr(0, y, width*4)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
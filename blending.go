@@ -34,6 +34,24 @@ const (
 	BlendSoftLight  = ia.BlendSoftLight
 	BlendDifference = ia.BlendDifference
 	BlendExclusion  = ia.BlendExclusion
+
+	// BlendInherit, as the blend mode of a paint, draws the paint with the
+	// context blend mode. It is the default for every paint.
+	BlendInherit = ia.BlendInherit
+)
+
+// ChannelMask selects color channels; see Context.SetChannelMask and
+// Image.SetChannel.
+type ChannelMask = ia.ChannelMask
+
+// Channel mask constants (re-exported from internal).
+const (
+	ChannelRed   = ia.ChannelRed
+	ChannelGreen = ia.ChannelGreen
+	ChannelBlue  = ia.ChannelBlue
+	ChannelAlpha = ia.ChannelAlpha
+	ChannelRGB   = ia.ChannelRGB
+	ChannelAll   = ia.ChannelAll
 )
 
 // Blend mode operations
@@ -44,6 +62,18 @@ func (ctx *Context) SetBlendMode(mode BlendMode) { ctx.agg2d.impl.SetBlendMode(m
 // GetBlendMode returns the current blending mode.
 func (ctx *Context) GetBlendMode() BlendMode { return ctx.agg2d.impl.GetBlendMode() }
 
+// SetChannelMask restricts subsequent drawing, including images and
+// clearing, to the given channels of the target; the other channels keep
+// their values. With ChannelAlpha alone, shapes are painted into the alpha
+// channel only, for example to assemble a mask. The default is ChannelAll.
+// The mask applies to 32-bit RGBA contexts; contexts in other formats, such
+// as RGB24, Gray8 and RGB565, ignore it. Effects that write the image
+// directly, such as blurs and flood fills, ignore it as well.
+func (ctx *Context) SetChannelMask(m ChannelMask) { ctx.agg2d.impl.SetChannelMask(m) }
+
+// GetChannelMask returns the channels drawing writes to.
+func (ctx *Context) GetChannelMask() ChannelMask { return ctx.agg2d.impl.ChannelMask() }
+
 // Alpha operations
 
 // SetGlobalAlpha sets the global alpha by updating current fill/stroke colors.
@@ -83,6 +113,57 @@ func (ctx *Context) SetMasterAlpha(alpha float64) { ctx.agg2d.impl.SetMasterAlph
 // GetMasterAlpha returns the context-wide alpha multiplier.
 func (ctx *Context) GetMasterAlpha() float64 { return ctx.agg2d.impl.GetMasterAlpha() }
 
+// SetAntiAliasGamma sets the gamma applied to anti-aliased edge coverage.
+// Values above 1 thicken edges, values below 1 make them crisper.
+func (ctx *Context) SetAntiAliasGamma(gamma float64) { ctx.agg2d.impl.SetAntiAliasGamma(gamma) }
+
+// GetAntiAliasGamma returns the anti-alias gamma.
+func (ctx *Context) GetAntiAliasGamma() float64 { return ctx.agg2d.impl.GetAntiAliasGamma() }
+
+// SetTextGamma sets whether the anti-alias gamma also applies to text.
+func (ctx *Context) SetTextGamma(enabled bool) { ctx.agg2d.impl.SetTextGamma(enabled) }
+
+// SetPixelSnapping enables grid fitting: horizontal and vertical fill edges
+// snap to whole pixels and horizontal and vertical stroke centerlines to pixel
+// centers (for odd device widths), so 1px lines and rectangle borders render
+// sharp instead of blurred over two pixels.
+func (ctx *Context) SetPixelSnapping(on bool) { ctx.agg2d.impl.SetPixelSnapping(on) }
+
+// GetPixelSnapping reports whether pixel snapping is enabled.
+func (ctx *Context) GetPixelSnapping() bool { return ctx.agg2d.impl.GetPixelSnapping() }
+
+// SetAutoClose selects how fills treat open subpaths. On, the default, they
+// are filled as if closed by a straight line back to their start, like the
+// canvas fill rule. Off, the rasterizer adds no closing edge, as AGG's
+// auto_close(false): only paths whose subpaths all return to their start
+// fill as expected.
+func (ctx *Context) SetAutoClose(on bool) { ctx.agg2d.impl.SetAutoClose(on) }
+
+// GetAutoClose reports whether fills close open subpaths.
+func (ctx *Context) GetAutoClose() bool { return ctx.agg2d.impl.GetAutoClose() }
+
+// SetPixelAccurateLines enables the pixel accuracy mode: solid-color strokes
+// at most one device pixel wide are drawn aliased, with exactly the pixels of
+// the classic integer Bresenham algorithm between the pixels containing the
+// path's vertices. Output of legacy software drawing 1px lines can so be
+// reproduced and diffed bit for bit. Caps and joins do not apply; wider or
+// gradient strokes are anti-aliased as usual.
+func (ctx *Context) SetPixelAccurateLines(on bool) { ctx.agg2d.impl.SetPixelAccurateLines(on) }
+
+// GetPixelAccurateLines reports whether the pixel accuracy mode is enabled.
+func (ctx *Context) GetPixelAccurateLines() bool { return ctx.agg2d.impl.GetPixelAccurateLines() }
+
+// SetCellBlockLimit caps the rasterizer memory of a single path; see
+// Agg2D.SetCellBlockLimit.
+func (ctx *Context) SetCellBlockLimit(limit uint32) { ctx.agg2d.SetCellBlockLimit(limit) }
+
+// GetCellBlockLimit returns the rasterizer cell block limit.
+func (ctx *Context) GetCellBlockLimit() uint32 { return ctx.agg2d.CellBlockLimit() }
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit and renders truncated, or removes it when fn is nil.
+func (ctx *Context) SetOverflowHandler(fn CellOverflowFunc) { ctx.agg2d.SetOverflowHandler(fn) }
+
 // SetBlendNormal selects the standard source-over blend mode.
 func (ctx *Context) SetBlendNormal() { ctx.SetBlendMode(BlendSrcOver) }
 
@@ -0,0 +1,87 @@
+package agg
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/effects"
+)
+
+// GaussianBlur blurs the image with a Gaussian of standard deviation sigma
+// pixels, sampling the kernel directly rather than approximating it with a
+// stack blur. Images with 8-bit channels (RGBA in any order, RGB and Gray8)
+// are blurred; Gray16, RGB565 and channel views are left alone. Colors are
+// treated as premultiplied. A sigma that is not positive and finite does
+// nothing.
+func (img *Image) GaussianBlur(sigma float64) {
+	if n := img.blurChannels(); n > 0 {
+		effects.GaussianBlur(img.Data, img.width, img.height, img.renBuf.Stride(), n, img.bounds(), sigma)
+	}
+}
+
+// MotionBlur smears the image along the direction angle (radians) over
+// distance pixels, as if it moved during exposure. It blurs the same formats
+// as GaussianBlur.
+func (img *Image) MotionBlur(angle, distance float64) {
+	if n := img.blurChannels(); n > 0 {
+		effects.MotionBlur(img.Data, img.width, img.height, img.renBuf.Stride(), n, img.bounds(), angle, distance)
+	}
+}
+
+// GaussianBlur blurs the Context's image like Image.GaussianBlur, changing
+// only the pixels inside the current clip box. The blur still reads the
+// pixels around the clip box, so the blurred region blends into them; set a
+// clip box around a region to blur it for emphasis. Inside MeasureDraw it
+// reports the clip box instead.
+func (ctx *Context) GaussianBlur(sigma float64) {
+	img := ctx.image
+	if n := img.blurChannels(); n > 0 && !ctx.measureClipBox() {
+		effects.GaussianBlur(img.Data, img.width, img.height, img.renBuf.Stride(), n, ctx.clipBounds(), sigma)
+	}
+}
+
+// MotionBlur smears the Context's image like Image.MotionBlur, changing only
+// the pixels inside the current clip box. Inside MeasureDraw it reports the
+// clip box instead.
+func (ctx *Context) MotionBlur(angle, distance float64) {
+	img := ctx.image
+	if n := img.blurChannels(); n > 0 && !ctx.measureClipBox() {
+		effects.MotionBlur(img.Data, img.width, img.height, img.renBuf.Stride(), n, ctx.clipBounds(), angle, distance)
+	}
+}
+
+// blurChannels returns the number of 8-bit channels per pixel the blurs
+// work on, or 0 for images they cannot blur: packed and 16-bit formats and
+// channel views.
+func (img *Image) blurChannels() int {
+	if img == nil || img.pixelStep() != img.format.BytesPerPixel() {
+		return 0
+	}
+	switch img.format {
+	case ImageGray16, ImageRGB565:
+		return 0
+	}
+	return img.format.BytesPerPixel()
+}
+
+// bounds returns the inclusive pixel rectangle of the whole image.
+func (img *Image) bounds() basics.RectI {
+	return basics.RectI{X1: 0, Y1: 0, X2: img.width - 1, Y2: img.height - 1}
+}
+
+// clipBounds returns the current clip box in device pixels, inclusive as for
+// the base renderer.
+func (ctx *Context) clipBounds() basics.RectI {
+	x1, y1, x2, y2 := ctx.agg2d.impl.GetClipBox()
+	return basics.RectI{X1: int(x1), Y1: int(y1), X2: int(x2), Y2: int(y2)}
+}
+
+// measureClipBox adds the clip box to the bounds of a dry run in progress
+// and reports whether there was one.
+func (ctx *Context) measureClipBox() bool {
+	impl := ctx.agg2d.impl
+	if !impl.Measuring() {
+		return false
+	}
+	b := ctx.clipBounds()
+	impl.MeasureRect(b.X1, b.Y1, b.X2+1, b.Y2+1)
+	return true
+}
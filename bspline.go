@@ -0,0 +1,30 @@
+package agg
+
+import (
+	"errors"
+
+	"github.com/MeKo-Christian/agg_go/internal/curves"
+)
+
+// BSpline is a bi-cubic spline y = f(x) through a set of points, AGG's
+// bspline. It is the curve behind the spline control, usable on its own for
+// alpha curves, color ramps or easing functions.
+//
+// The x coordinates must be in ascending order and at least three points are
+// needed; with fewer, Get returns 0. Outside the x range the curve continues
+// linearly. Get searches for the interval on every call; GetStateful starts
+// from the interval of the previous call, which is faster when x moves in
+// small steps, such as when filling a lookup table.
+//
+// A spline can also be built point by point with Init, AddPoint and Prepare;
+// the zero value is ready for that after Init.
+type BSpline = curves.BSpline
+
+// NewBSpline returns the spline through the points (x[i], y[i]). It fails
+// when x and y differ in length.
+func NewBSpline(x, y []float64) (*BSpline, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("x and y differ in length")
+	}
+	return curves.NewBSplineFromPoints(x, y), nil
+}
@@ -0,0 +1,59 @@
+package agg
+
+// Canvas is the drawing surface shared by Context and Recording.
+//
+// Code written against Canvas can render immediately into pixels or be
+// captured as a display list and replayed later, e.g. for retained-mode UI
+// layers, thumbnails or PDF export.
+type Canvas interface {
+	Width() int
+	Height() int
+
+	Clear(color Color)
+	SetColor(color Color)
+	SetLineWidth(width float64)
+	SetLineCap(lineCap LineCap)
+	SetLineJoin(join LineJoin)
+	SetMiterLimit(limit float64)
+	SetDashPattern(pattern []float64)
+	ClearDashes()
+	SetDashOffset(offset float64)
+
+	BeginPath()
+	MoveTo(x, y float64)
+	LineTo(x, y float64)
+	ClosePath()
+	Fill()
+	Stroke()
+
+	DrawLine(x1, y1, x2, y2 float64)
+	DrawRectangle(x, y, width, height float64)
+	FillRectangle(x, y, width, height float64)
+	DrawCircle(cx, cy, radius float64)
+	FillCircle(cx, cy, radius float64)
+	DrawEllipse(cx, cy, rx, ry float64)
+	FillEllipse(cx, cy, rx, ry float64)
+	DrawRoundedRectangle(x, y, width, height, radius float64)
+	FillRoundedRectangle(x, y, width, height, radius float64)
+
+	GetTransform() *Transformations
+	SetTransform(tr *Transformations)
+	ResetTransform()
+	Transform(tr *Transformations)
+	Translate(tx, ty float64)
+	Rotate(angle float64)
+	Scale(sx, sy float64)
+	Skew(sx, sy float64)
+	PushTransform()
+	PopTransform() bool
+
+	Font(fileName string, height float64, bold, italic bool, cacheType FontCacheType, angle float64) error
+	DrawText(text string, x, y float64) error
+	DrawImage(img *Image, x, y float64) error
+	DrawImageScaled(img *Image, x, y, width, height float64) error
+}
+
+var (
+	_ Canvas = (*Context)(nil)
+	_ Canvas = (*Recording)(nil)
+)
@@ -0,0 +1,277 @@
+// Package charts draws simple charts on an agg.Context: axes with nice ticks,
+// labels and grid lines, polyline and filled area series, and bars. It is
+// immediate mode, like the Context shape helpers, and built only on the
+// public agg API:
+//
+//	plot := charts.NewPlot(50, 20, 500, 300)
+//	lo, hi, _ := charts.NiceRange(minY, maxY, 5)
+//	plot.SetRange(0, float64(len(ys)-1), lo, hi)
+//	plot.DrawAxes(ctx, charts.DefaultAxisStyle())
+//	plot.Line(ctx, xs, ys, agg.Blue, 1.5)
+//
+// Long series are decimated to a few points per pixel column before drawing,
+// so plotting a million samples costs about as much as plotting the width of
+// the plot.
+package charts
+
+import (
+	"math"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// Plot maps a data range onto a rectangle of the drawing surface. Data y
+// grows upward, so YMin is at the bottom edge of the area.
+type Plot struct {
+	// X, Y, Width and Height are the plot area in user coordinates.
+	X, Y, Width, Height float64
+	// The data range shown in the area.
+	XMin, XMax, YMin, YMax float64
+}
+
+// NewPlot returns a plot over the given area showing the unit square.
+func NewPlot(x, y, width, height float64) *Plot {
+	return &Plot{X: x, Y: y, Width: width, Height: height, XMax: 1, YMax: 1}
+}
+
+// SetRange sets the data range shown in the plot area.
+func (p *Plot) SetRange(xmin, xmax, ymin, ymax float64) {
+	p.XMin, p.XMax, p.YMin, p.YMax = xmin, xmax, ymin, ymax
+}
+
+// MapX returns the user x coordinate of data value v.
+func (p *Plot) MapX(v float64) float64 {
+	if p.XMax == p.XMin {
+		return p.X
+	}
+	return p.X + (v-p.XMin)/(p.XMax-p.XMin)*p.Width
+}
+
+// MapY returns the user y coordinate of data value v.
+func (p *Plot) MapY(v float64) float64 {
+	if p.YMax == p.YMin {
+		return p.Y + p.Height
+	}
+	return p.Y + p.Height - (v-p.YMin)/(p.YMax-p.YMin)*p.Height
+}
+
+// Map returns the user coordinates of data point (x, y).
+func (p *Plot) Map(x, y float64) (float64, float64) {
+	return p.MapX(x), p.MapY(y)
+}
+
+// AxisStyle controls how DrawAxes draws the axes.
+type AxisStyle struct {
+	Color     agg.Color // axis lines and ticks
+	TextColor agg.Color // tick labels
+	// GridColor draws grid lines at the ticks; leave it transparent for none.
+	GridColor  agg.Color
+	LineWidth  float64
+	TickLength float64
+	// XTicks and YTicks are the approximate number of ticks per axis; zero
+	// leaves that axis without ticks and labels.
+	XTicks, YTicks int
+	// Font is the bitmap font for the labels, used while the Context has no
+	// font file loaded.
+	Font agg.BitmapFont
+	// FormatX and FormatY format tick values; nil uses FormatTick.
+	FormatX, FormatY func(v, step float64) string
+}
+
+// DefaultAxisStyle returns dark gray axes with about five ticks each, light
+// grid lines and labels in a small bitmap font.
+func DefaultAxisStyle() AxisStyle {
+	return AxisStyle{
+		Color:      agg.NewColor(60, 60, 60, 255),
+		TextColor:  agg.NewColor(40, 40, 40, 255),
+		GridColor:  agg.NewColor(0, 0, 0, 24),
+		LineWidth:  1,
+		TickLength: 4,
+		XTicks:     5,
+		YTicks:     5,
+		Font:       agg.BitmapFontGSE6x12,
+	}
+}
+
+// DrawAxes draws the x axis along the bottom and the y axis along the left
+// edge of the plot area, with ticks and labels outside the area and grid
+// lines across it.
+func (p *Plot) DrawAxes(ctx *agg.Context, s AxisStyle) {
+	defer saveStyle(ctx)()
+	ctx.SetStrokeWidth(s.LineWidth)
+	left, bottom := p.X, p.Y+p.Height
+	// Thin lines look crisp on pixel centers when the area is on whole pixels.
+	off := 0.0
+	if math.Mod(s.LineWidth, 2) == 1 {
+		off = 0.5
+	}
+
+	var xTicks, yTicks []float64
+	var xStep, yStep float64
+	if s.XTicks > 0 {
+		xTicks, xStep = ticks(p.XMin, p.XMax, s.XTicks)
+	}
+	if s.YTicks > 0 {
+		yTicks, yStep = ticks(p.YMin, p.YMax, s.YTicks)
+	}
+
+	if s.GridColor.A != 0 {
+		ctx.SetColor(s.GridColor)
+		ctx.BeginPath()
+		for _, v := range xTicks {
+			x := snap(p.MapX(v), off)
+			ctx.MoveTo(x, p.Y)
+			ctx.LineTo(x, bottom)
+		}
+		for _, v := range yTicks {
+			y := snap(p.MapY(v), off)
+			ctx.MoveTo(left, y)
+			ctx.LineTo(left+p.Width, y)
+		}
+		ctx.Stroke()
+	}
+
+	ctx.SetColor(s.Color)
+	ctx.BeginPath()
+	ctx.MoveTo(snap(left, off), p.Y)
+	ctx.LineTo(snap(left, off), snap(bottom, off))
+	ctx.LineTo(left+p.Width, snap(bottom, off))
+	for _, v := range xTicks {
+		x := snap(p.MapX(v), off)
+		ctx.MoveTo(x, bottom)
+		ctx.LineTo(x, bottom+s.TickLength)
+	}
+	for _, v := range yTicks {
+		y := snap(p.MapY(v), off)
+		ctx.MoveTo(left, y)
+		ctx.LineTo(left-s.TickLength, y)
+	}
+	ctx.Stroke()
+
+	ctx.SetColor(s.TextColor)
+	pad := s.TickLength + 2
+	for _, v := range xTicks {
+		label := format(s.FormatX, v, xStep)
+		_ = ctx.DrawTextWithOptions(p.MapX(v), bottom+pad, label, agg.DrawTextOptions{
+			BitmapFont: s.Font, AlignX: agg.AlignCenter, AlignY: agg.AlignTop,
+		})
+	}
+	for _, v := range yTicks {
+		label := format(s.FormatY, v, yStep)
+		_ = ctx.DrawTextWithOptions(left-pad, p.MapY(v), label, agg.DrawTextOptions{
+			BitmapFont: s.Font, AlignX: agg.AlignRight, AlignY: agg.AlignCenter,
+		})
+	}
+}
+
+// Line strokes the series (xs[i], ys[i]) as a polyline with round joins.
+// xs must be ascending; the shorter slice sets the number of points.
+func (p *Plot) Line(ctx *agg.Context, xs, ys []float64, c agg.Color, width float64) {
+	defer saveStyle(ctx)()
+	ctx.SetColor(c)
+	ctx.SetStrokeWidth(width)
+	ctx.SetLineJoin(agg.JoinRound)
+	ctx.BeginPath()
+	first := true
+	p.eachPoint(xs, ys, func(x, y float64) {
+		if first {
+			ctx.MoveTo(x, y)
+			first = false
+			return
+		}
+		ctx.LineTo(x, y)
+	})
+	ctx.Stroke()
+}
+
+// Area fills the region between the series and the horizontal line at data
+// value baseline. xs must be ascending.
+func (p *Plot) Area(ctx *agg.Context, xs, ys []float64, baseline float64, c agg.Color) {
+	n := min(len(xs), len(ys))
+	if n == 0 {
+		return
+	}
+	defer saveStyle(ctx)()
+	ctx.SetColor(c)
+	ctx.BeginPath()
+	base := p.MapY(baseline)
+	ctx.MoveTo(p.MapX(xs[0]), base)
+	p.eachPoint(xs, ys, ctx.LineTo)
+	ctx.LineTo(p.MapX(xs[n-1]), base)
+	ctx.ClosePath()
+	ctx.Fill()
+}
+
+// Bars fills a bar from baseline to ys[i] centered on each xs[i]. barWidth is
+// in data units along x.
+func (p *Plot) Bars(ctx *agg.Context, xs, ys []float64, barWidth, baseline float64, c agg.Color) {
+	n := min(len(xs), len(ys))
+	if n == 0 {
+		return
+	}
+	defer saveStyle(ctx)()
+	ctx.SetColor(c)
+	ctx.BeginPath()
+	base := p.MapY(baseline)
+	for i := 0; i < n; i++ {
+		x1, x2 := p.MapX(xs[i]-barWidth/2), p.MapX(xs[i]+barWidth/2)
+		y1, y2 := p.MapY(ys[i]), base
+		// Keep every bar in the same orientation so touching bars of opposite
+		// sign do not cancel under the non-zero fill rule.
+		x1, x2 = min(x1, x2), max(x1, x2)
+		y1, y2 = min(y1, y2), max(y1, y2)
+		ctx.MoveTo(x1, y1)
+		ctx.LineTo(x2, y1)
+		ctx.LineTo(x2, y2)
+		ctx.LineTo(x1, y2)
+		ctx.ClosePath()
+	}
+	ctx.Fill()
+}
+
+// eachPoint calls f with the user coordinates of the series, decimated to
+// the plot width.
+func (p *Plot) eachPoint(xs, ys []float64, f func(x, y float64)) {
+	if keep := Decimate(xs, ys, p.XMin, p.XMax, int(math.Ceil(math.Abs(p.Width)))); keep != nil {
+		for _, i := range keep {
+			f(p.Map(xs[i], ys[i]))
+		}
+		return
+	}
+	for i := range min(len(xs), len(ys)) {
+		f(p.Map(xs[i], ys[i]))
+	}
+}
+
+// saveStyle returns a function restoring the colors, line width and line
+// join changed by the drawing helpers.
+func saveStyle(ctx *agg.Context) func() {
+	a := ctx.GetAgg2D()
+	fill, line := a.GetFillColor(), a.GetLineColor()
+	width, join := ctx.GetLineWidth(), ctx.GetLineJoin()
+	return func() {
+		a.FillColor(fill)
+		a.LineColor(line)
+		ctx.SetStrokeWidth(width)
+		ctx.SetLineJoin(join)
+	}
+}
+
+func ticks(lo, hi float64, n int) ([]float64, float64) {
+	_, _, step := NiceRange(lo, hi, n)
+	return NiceTicks(lo, hi, n), step
+}
+
+func format(f func(v, step float64) string, v, step float64) string {
+	if f == nil {
+		return FormatTick(v, step)
+	}
+	return f(v, step)
+}
+
+func snap(v, off float64) float64 {
+	if off == 0 {
+		return v
+	}
+	return math.Floor(v) + off
+}
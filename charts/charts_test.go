@@ -0,0 +1,120 @@
+package charts
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+func TestNiceTicks(t *testing.T) {
+	for _, tc := range []struct {
+		lo, hi float64
+		n      int
+		want   []float64
+	}{
+		{0, 10, 6, []float64{0, 2, 4, 6, 8, 10}},
+		{0.13, 0.92, 5, []float64{0.2, 0.4, 0.6, 0.8}},
+		{-3, 7, 3, []float64{0, 5}},
+		{2, 2, 5, []float64{2}},
+	} {
+		got := NiceTicks(tc.lo, tc.hi, tc.n)
+		if len(got) != len(tc.want) {
+			t.Errorf("NiceTicks(%v, %v, %d) = %v, want %v", tc.lo, tc.hi, tc.n, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if math.Abs(got[i]-tc.want[i]) > 1e-12 {
+				t.Errorf("NiceTicks(%v, %v, %d) = %v, want %v", tc.lo, tc.hi, tc.n, got, tc.want)
+				break
+			}
+		}
+	}
+
+	lo, hi, step := NiceRange(0.13, 0.92, 5)
+	if lo != 0 || math.Abs(hi-1) > 1e-12 || math.Abs(step-0.2) > 1e-12 {
+		t.Errorf("NiceRange = %v, %v, %v", lo, hi, step)
+	}
+
+	if s := FormatTick(0.4, 0.2); s != "0.4" {
+		t.Errorf("FormatTick(0.4, 0.2) = %q", s)
+	}
+	if s := FormatTick(0.25, 0.05); s != "0.25" {
+		t.Errorf("FormatTick(0.25, 0.05) = %q", s)
+	}
+	if s := FormatTick(2000, 500); s != "2000" {
+		t.Errorf("FormatTick(2000, 500) = %q", s)
+	}
+}
+
+func TestDecimate(t *testing.T) {
+	const n = 10000
+	xs, ys := make([]float64, n), make([]float64, n)
+	for i := range xs {
+		xs[i] = float64(i)
+		ys[i] = math.Sin(float64(i) * 0.37)
+	}
+	ys[5000] = 10 // a spike must survive
+
+	keep := Decimate(xs, ys, 0, n, 100)
+	if len(keep) == 0 || len(keep) > 400 {
+		t.Fatalf("kept %d points, want 1..400", len(keep))
+	}
+	if !slices.IsSorted(keep) || keep[0] != 0 || keep[len(keep)-1] != n-1 {
+		t.Errorf("indices not sorted or ends missing: first %d, last %d", keep[0], keep[len(keep)-1])
+	}
+	if !slices.Contains(keep, 5000) {
+		t.Error("spike was dropped")
+	}
+
+	if Decimate(xs[:300], ys[:300], 0, 300, 100) != nil {
+		t.Error("short series was decimated")
+	}
+}
+
+func TestPlotDrawing(t *testing.T) {
+	ctx := agg.NewContext(200, 120)
+	ctx.Clear(agg.White)
+	ctx.SetColor(agg.Red)
+	ctx.SetStrokeWidth(3)
+
+	plot := NewPlot(30, 10, 160, 90)
+	plot.SetRange(0, 10, 0, 10)
+	if x, y := plot.Map(5, 5); x != 110 || y != 55 {
+		t.Errorf("Map(5, 5) = %v, %v", x, y)
+	}
+
+	plot.DrawAxes(ctx, DefaultAxisStyle())
+	plot.Bars(ctx, []float64{2, 4}, []float64{5, -1}, 1, 0, agg.Blue)
+	plot.Area(ctx, []float64{6, 8, 10}, []float64{0, 8, 0}, 0, agg.Green)
+	xs, ys := make([]float64, 5000), make([]float64, 5000)
+	for i := range xs {
+		xs[i] = float64(i) / 500
+		ys[i] = 9
+	}
+	plot.Line(ctx, xs, ys, agg.Black, 2)
+
+	a := ctx.GetAgg2D()
+	if a.GetFillColor() != agg.Red || a.GetLineColor() != agg.Red || ctx.GetLineWidth() != 3 {
+		t.Error("drawing helpers did not restore the context style")
+	}
+
+	for _, tc := range []struct {
+		x, y int
+		want agg.Color
+	}{
+		{int(plot.MapX(2)), int(plot.MapY(3)), agg.Blue},
+		{int(plot.MapX(8)), int(plot.MapY(2)), agg.Green},
+		{int(plot.MapX(5)), int(plot.MapY(9)), agg.Black},
+		{int(plot.MapX(5)), int(plot.MapY(5)), agg.White},
+	} {
+		if got := ctx.GetPixel(tc.x, tc.y); got != tc.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", tc.x, tc.y, got, tc.want)
+		}
+	}
+	// The y axis is drawn just left of the area.
+	if got := ctx.GetPixel(30, 50); got == agg.White {
+		t.Error("y axis not drawn")
+	}
+}
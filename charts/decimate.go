@@ -0,0 +1,45 @@
+package charts
+
+// Decimate picks the points of a series worth drawing at a resolution of
+// buckets columns over [x0, x1]. For every column it keeps the first, last,
+// lowest and highest point (the M4 scheme), so a polyline through the result
+// looks the same as one through all points while having at most four points
+// per column. Points left or right of the range fall into the first or last
+// column. xs must be ascending.
+//
+// Decimate returns the indices of the kept points in ascending order, or nil
+// when there is nothing to reduce.
+func Decimate(xs, ys []float64, x0, x1 float64, buckets int) []int {
+	n := min(len(xs), len(ys))
+	if buckets < 1 || n <= 4*buckets || x1 <= x0 {
+		return nil
+	}
+
+	scale := float64(buckets) / (x1 - x0)
+	bucket := func(x float64) int {
+		return min(max(int((x-x0)*scale), 0), buckets-1)
+	}
+
+	keep := make([]int, 0, 4*buckets)
+	for i := 0; i < n; {
+		b := bucket(xs[i])
+		first, lo, hi, last := i, i, i, i
+		for i++; i < n && bucket(xs[i]) == b; i++ {
+			if ys[i] < ys[lo] {
+				lo = i
+			}
+			if ys[i] > ys[hi] {
+				hi = i
+			}
+			last = i
+		}
+		// Emit in index order, dropping duplicates.
+		a, c := min(lo, hi), max(lo, hi)
+		for _, j := range [4]int{first, a, c, last} {
+			if len(keep) == 0 || keep[len(keep)-1] < j {
+				keep = append(keep, j)
+			}
+		}
+	}
+	return keep
+}
@@ -0,0 +1,85 @@
+package charts
+
+import (
+	"math"
+	"strconv"
+)
+
+// niceNum returns a "nice" number (1, 2, 5 or 10 times a power of ten) close
+// to x, rounding to the nearest one or, with round false, the next larger.
+func niceNum(x float64, round bool) float64 {
+	exp := math.Floor(math.Log10(x))
+	f := x / math.Pow(10, exp)
+	var nf float64
+	switch {
+	case round && f < 1.5:
+		nf = 1
+	case round && f < 3:
+		nf = 2
+	case round && f < 7:
+		nf = 5
+	case round:
+		nf = 10
+	case f <= 1:
+		nf = 1
+	case f <= 2:
+		nf = 2
+	case f <= 5:
+		nf = 5
+	default:
+		nf = 10
+	}
+	return nf * math.Pow(10, exp)
+}
+
+// NiceRange widens [lo, hi] to multiples of a nice tick step, so that about
+// n ticks span it. It is the usual way to pick an axis range from data:
+//
+//	lo, hi, _ := charts.NiceRange(minY, maxY, 5)
+//	plot.YMin, plot.YMax = lo, hi
+//
+// An empty or inverted range is widened around lo.
+func NiceRange(lo, hi float64, n int) (niceLo, niceHi, step float64) {
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	if hi == lo {
+		d := math.Max(math.Abs(lo)*0.1, 1)
+		lo, hi = lo-d, hi+d
+	}
+	n = max(n, 2)
+	step = niceNum(niceNum(hi-lo, false)/float64(n-1), true)
+	return math.Floor(lo/step) * step, math.Ceil(hi/step) * step, step
+}
+
+// NiceTicks returns the tick values for an axis over [lo, hi]: multiples of
+// a nice step, about n of them, all inside the range.
+func NiceTicks(lo, hi float64, n int) []float64 {
+	if hi < lo {
+		lo, hi = hi, lo
+	}
+	if hi == lo || math.IsInf(hi-lo, 0) || math.IsNaN(hi-lo) {
+		return []float64{lo}
+	}
+	_, _, step := NiceRange(lo, hi, n)
+	eps := step * 1e-9
+	var ticks []float64
+	for i := math.Ceil((lo - eps) / step); i*step <= hi+eps; i++ {
+		v := i * step
+		if math.Abs(v) < eps {
+			v = 0 // avoid -0
+		}
+		ticks = append(ticks, v)
+	}
+	return ticks
+}
+
+// FormatTick formats a tick value with just enough decimals for ticks step
+// apart.
+func FormatTick(v, step float64) string {
+	decimals := 0
+	if step > 0 && step < 1 {
+		decimals = int(math.Ceil(-math.Log10(step) - 1e-9))
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
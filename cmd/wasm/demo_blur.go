@@ -9,7 +9,7 @@ import (
 
 var (
 	blurRadius = 15.0
-	blurMethod = 0 // 0: Stack blur, 1: Recursive blur
+	blurMethod = 0 // 0: Stack blur, 1: Recursive blur, 2: Gaussian blur
 )
 
 func drawBlurDemo() {
@@ -87,6 +87,11 @@ func blurImage(img *agg.Image, radius float64, method int) {
 	if radius <= 0 {
 		return
 	}
+	if method == 2 {
+		// The Gaussian kernel reaches 3 sigma, as far as the other blurs.
+		img.GaussianBlur(radius / 3)
+		return
+	}
 
 	w, h := img.Width(), img.Height()
 	data := img.Data
@@ -6,7 +6,6 @@ import (
 	"math/rand"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/internal/curves"
 	"github.com/MeKo-Christian/agg_go/internal/shapes"
 )
 
@@ -17,9 +16,9 @@ type scatterPoint struct {
 
 var (
 	circlesPoints []scatterPoint
-	splineR       *curves.BSpline
-	splineG       *curves.BSpline
-	splineB       *curves.BSpline
+	splineR       *agg.BSpline
+	splineG       *agg.BSpline
+	splineB       *agg.BSpline
 	numPoints     = 10000
 
 	// Sliders
@@ -46,9 +45,9 @@ func initCircles() {
 	splineBX := []float64{0.000000, 0.055045, 0.143034, 0.433082, 0.764859, 1.000000}
 	splineBY := []float64{0.385480, 0.128493, 0.021416, 0.271507, 0.713974, 1.000000}
 
-	splineR = curves.NewBSplineFromPoints(splineRX, splineRY)
-	splineG = curves.NewBSplineFromPoints(splineGX, splineGY)
-	splineB = curves.NewBSplineFromPoints(splineBX, splineBY)
+	splineR = mustBSpline(splineRX, splineRY)
+	splineG = mustBSpline(splineGX, splineGY)
+	splineB = mustBSpline(splineBX, splineBY)
 
 	circlesEllipse = shapes.NewEllipse()
 	circlesAdapter = &ellipseVS{circlesEllipse}
@@ -131,3 +130,13 @@ func drawCirclesScatterDemo() {
 		}
 	}
 }
+
+// mustBSpline builds one of the color splines, whose tables have matching
+// lengths.
+func mustBSpline(x, y []float64) *agg.BSpline {
+	s, err := agg.NewBSpline(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
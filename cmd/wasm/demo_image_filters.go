@@ -37,47 +37,13 @@ func drawImageFiltersDemo() {
 	// Draw rotated and scaled versions
 	agg2d.ResetTransformations()
 
-	// Center rotation
-	imgW, imgH := float64(testImage.Width()), float64(testImage.Height())
-
-	// We'll draw 3 versions at different scales
+	// We'll draw 3 versions at different scales, rotated about their centers
+	angleRad := imgFilterAngle * math.Pi / 180.0
 	scales := []float64{0.5, 1.0, 2.0}
 	for i, s := range scales {
 		x := 350.0 + float64(i)*150.0
 		y := 150.0
-
-		// Map source image to destination parallelogram
-		// This is what original AGG demo effectively does via its transform_image
-
-		// Simple rotation + scale around image center
-		angleRad := imgFilterAngle * math.Pi / 180.0
-
-		// Destination points
-		// We'll use TransformImageParallelogram for maximum flexibility
-		// although Agg2D has simpler methods, this showcases AGG's power.
-
-		halfW := (imgW * s) * 0.5
-		halfH := (imgH * s) * 0.5
-
-		cosA := math.Cos(angleRad)
-		sinA := math.Sin(angleRad)
-
-		// Parallelogram: x1,y1, x2,y2, x3,y3 (3 corners)
-		para := make([]float64, 6)
-
-		// Corner 1: top-left
-		para[0] = x - halfW*cosA + halfH*sinA
-		para[1] = y - halfW*sinA - halfH*cosA
-
-		// Corner 2: top-right
-		para[2] = x + halfW*cosA + halfH*sinA
-		para[3] = y + halfW*sinA - halfH*cosA
-
-		// Corner 3: bottom-right
-		para[4] = x + halfW*cosA - halfH*sinA
-		para[5] = y + halfW*sinA + halfH*cosA
-
-		agg2d.TransformImageParallelogram(testImage, 0, 0, int(imgW), int(imgH), para)
+		ctx.DrawImageRotated(testImage, x, y, angleRad, s, imgFilterType)
 	}
 }
 
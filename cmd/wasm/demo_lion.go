@@ -1,6 +1,8 @@
 // Port of AGG C++ lion.cpp – classic lion demo with alpha, rotate/scale, skew.
 //
-// Left-drag rotates and scales; right-drag applies shear.
+// Left-drag rotates and scales; right-drag applies shear. The wheel or a
+// pinch zooms, a double-click resets the view. While the Go animation loop
+// runs, the arrow keys rotate and zoom, and 0 resets the view.
 // An alpha slider controls global opacity of all paths.
 //
 // Note on coordinate systems: AGG's original example uses flip_y=true (y-up
@@ -115,6 +117,42 @@ func handleLionMouseUp() {
 	lionFillRightDragging = false
 }
 
+// handleLionMouseWheel zooms by 10% per notch.
+func handleLionMouseWheel(_, _, dy float64) bool {
+	if dy == 0 {
+		return false
+	}
+	lionFillScale = math.Max(0.01, lionFillScale*math.Pow(1.1, dy))
+	return true
+}
+
+// handleLionDoubleClick restores the initial view.
+func handleLionDoubleClick() bool {
+	lionFillAngle, lionFillScale = 0, 1
+	lionFillSkewX, lionFillSkewY = 0, 0
+	return true
+}
+
+// handleLionKeyDown rotates by 5 degrees with the left and right arrows and
+// zooms with the up and down arrows, + and -.
+func handleLionKeyDown(key string) bool {
+	switch key {
+	case "ArrowLeft":
+		lionFillAngle -= math.Pi / 36
+	case "ArrowRight":
+		lionFillAngle += math.Pi / 36
+	case "ArrowUp", "+":
+		return handleLionMouseWheel(0, 0, 1)
+	case "ArrowDown", "-":
+		return handleLionMouseWheel(0, 0, -1)
+	case "0":
+		return handleLionDoubleClick()
+	default:
+		return false
+	}
+	return true
+}
+
 func applyLionFillTransform(x, y float64) {
 	dx := x - float64(width)*0.5
 	dy := y - float64(height)*0.5
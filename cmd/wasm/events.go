@@ -0,0 +1,196 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Go-side animation loop and DOM event handling.
+//
+// startAnimation(name) renders the demo on every requestAnimationFrame and
+// draws it straight onto the canvas. While it runs, Go also listens for the
+// canvas mouse and keyboard events itself: the listeners are registered on
+// window in the capture phase and stop propagation of the events they
+// consume, so the page's own handlers do not process them a second time.
+// stopAnimation() cancels the frame request and removes the listeners.
+package main
+
+import "syscall/js"
+
+const canvasID = "aggCanvas"
+
+// animation is the state of the running loop. The zero value is stopped.
+type animation struct {
+	demo      string
+	frameID   js.Value // pending requestAnimationFrame handle
+	frame     js.Func
+	listeners []domListener
+	dragging  bool
+
+	canvas   js.Value
+	canvas2D js.Value
+}
+
+// domListener is a registered event handler, kept to remove it again.
+type domListener struct {
+	target js.Value
+	event  string
+	fn     js.Func
+}
+
+var anim animation
+
+func startAnimationJS(this js.Value, args []js.Value) interface{} {
+	if len(args) < 1 {
+		return nil
+	}
+	anim.start(args[0].String())
+	return nil
+}
+
+func stopAnimationJS(this js.Value, args []js.Value) interface{} {
+	anim.stop()
+	return nil
+}
+
+func (a *animation) running() bool { return a.demo != "" }
+
+// start runs the loop for demo, switching demos if another one is running.
+func (a *animation) start(demo string) {
+	if a.demo == demo {
+		return
+	}
+	a.stop()
+	doc := js.Global().Get("document")
+	a.canvas = doc.Call("getElementById", canvasID)
+	if a.canvas.IsNull() {
+		return
+	}
+	a.canvas2D = a.canvas.Call("getContext", "2d")
+	a.demo = demo
+
+	a.frame = js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if !a.running() {
+			return nil
+		}
+		a.draw()
+		a.frameID = js.Global().Call("requestAnimationFrame", a.frame)
+		return nil
+	})
+	a.listen()
+	a.frameID = js.Global().Call("requestAnimationFrame", a.frame)
+}
+
+// stop cancels the pending frame and removes the event listeners.
+func (a *animation) stop() {
+	if !a.running() {
+		return
+	}
+	if a.dragging {
+		onMouseUp(js.Undefined(), []js.Value{js.ValueOf(a.demo)})
+	}
+	js.Global().Call("cancelAnimationFrame", a.frameID)
+	a.frame.Release()
+	for _, l := range a.listeners {
+		l.target.Call("removeEventListener", l.event, l.fn, true)
+		l.fn.Release()
+	}
+	*a = animation{}
+}
+
+// draw renders the demo and puts it on the canvas.
+func (a *animation) draw() {
+	renderDemo(js.Undefined(), []js.Value{js.ValueOf(a.demo)})
+	a.canvas2D.Call("putImageData", frames.image(), 0, 0)
+}
+
+// listen registers the capture-phase listeners on window.
+func (a *animation) listen() {
+	a.on("mousedown", func(e js.Value) bool {
+		if !e.Get("target").Equal(a.canvas) {
+			return false
+		}
+		x, y := a.canvasPoint(e)
+		right := e.Get("button").Int() == 2
+		a.dragging = dispatchBool(onMouseDown, a.demo, x, y, right)
+		return a.dragging
+	})
+	a.on("mousemove", func(e js.Value) bool {
+		if !a.dragging {
+			return false
+		}
+		x, y := a.canvasPoint(e)
+		right := e.Get("buttons").Int()&2 != 0
+		dispatchBool(onMouseMove, a.demo, x, y, right)
+		return true
+	})
+	a.on("mouseup", func(e js.Value) bool {
+		if !a.dragging {
+			return false
+		}
+		a.dragging = false
+		onMouseUp(js.Undefined(), []js.Value{js.ValueOf(a.demo)})
+		return true
+	})
+	a.on("wheel", func(e js.Value) bool {
+		if !e.Get("target").Equal(a.canvas) {
+			return false
+		}
+		x, y := a.canvasPoint(e)
+		// Pixels or lines to notches, positive away from the user.
+		scale := 1.0 / 3
+		if e.Get("deltaMode").Int() == 0 {
+			scale = 1.0 / 100
+		}
+		return handleMouseWheel(a.demo, x, y, e.Get("deltaX").Float()*scale, -e.Get("deltaY").Float()*scale)
+	})
+	a.on("dblclick", func(e js.Value) bool {
+		if !e.Get("target").Equal(a.canvas) {
+			return false
+		}
+		x, y := a.canvasPoint(e)
+		return handleDoubleClick(a.demo, x, y)
+	})
+	a.on("keydown", func(e js.Value) bool {
+		tag := e.Get("target").Get("tagName")
+		if tag.Truthy() && (tag.String() == "INPUT" || tag.String() == "SELECT" || tag.String() == "TEXTAREA") {
+			return false
+		}
+		return handleKeyDown(a.demo, e.Get("key").String())
+	})
+}
+
+// on adds a window listener for event. When handle consumes the event, its
+// propagation and default action are stopped.
+func (a *animation) on(event string, handle func(e js.Value) bool) {
+	fn := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+		if len(args) < 1 {
+			return nil
+		}
+		e := args[0]
+		if handle(e) {
+			e.Call("stopPropagation")
+			if e.Get("cancelable").Bool() {
+				e.Call("preventDefault")
+			}
+		}
+		return nil
+	})
+	window := js.Global()
+	window.Call("addEventListener", event, fn, map[string]interface{}{"capture": true, "passive": false})
+	a.listeners = append(a.listeners, domListener{target: window, event: event, fn: fn})
+}
+
+// canvasPoint returns the canvas pixel under a mouse event.
+func (a *animation) canvasPoint(e js.Value) (x, y float64) {
+	rect := a.canvas.Call("getBoundingClientRect")
+	sx := float64(width) / rect.Get("width").Float()
+	sy := float64(height) / rect.Get("height").Float()
+	return (e.Get("clientX").Float() - rect.Get("left").Float()) * sx,
+		(e.Get("clientY").Float() - rect.Get("top").Float()) * sy
+}
+
+// dispatchBool calls one of the onMouse* entry points and reports whether
+// the demo handled the event.
+func dispatchBool(fn func(js.Value, []js.Value) interface{}, demo string, x, y float64, right bool) bool {
+	handled, _ := fn(js.Undefined(), []js.Value{
+		js.ValueOf(demo), js.ValueOf(x), js.ValueOf(y), js.ValueOf(right),
+	}).(bool)
+	return handled
+}
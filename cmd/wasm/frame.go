@@ -0,0 +1,65 @@
+//go:build js && wasm
+// +build js,wasm
+
+// Frame hand-off to the canvas.
+//
+// By default every frame is copied into a JavaScript ImageData with
+// CopyBytesToJS. Once the page passes the instance's WebAssembly.Memory to
+// useSharedFrames, the ImageData is instead a view of canvasBuf inside the
+// Go heap, so putImageData reads the rendered pixels in place and the
+// per-frame copy goes away. Go never moves heap objects, so the view stays
+// valid until the memory grows; a grown memory detaches its old buffer and
+// the view is rebuilt on the next frame.
+package main
+
+import (
+	"syscall/js"
+	"unsafe"
+)
+
+// frameTarget is the ImageData handed to the canvas.
+type frameTarget struct {
+	memory    js.Value // WebAssembly.Memory of this instance, undefined in copy mode
+	buffer    js.Value // memory.buffer the view was built on
+	imageData js.Value
+	shared    bool // imageData is a view of canvasBuf
+}
+
+var frames frameTarget
+
+// useSharedFrames(memory) switches to zero-copy frames over memory, the
+// exports.mem of this instance; null or undefined switches back to copying.
+// It reports whether shared frames are in use.
+func useSharedFramesJS(this js.Value, args []js.Value) interface{} {
+	mem := js.Undefined()
+	if len(args) > 0 && args[0].Truthy() {
+		mem = args[0]
+	}
+	frames = frameTarget{memory: mem}
+	return mem.Truthy()
+}
+
+// getFrameImageData() returns an ImageData holding the last rendered frame.
+func getFrameImageData(this js.Value, args []js.Value) interface{} {
+	return frames.image()
+}
+
+// image returns the current frame as an ImageData, rebuilding the shared
+// view when needed and copying the pixels otherwise.
+func (f *frameTarget) image() js.Value {
+	if f.memory.Truthy() {
+		buf := f.memory.Get("buffer")
+		if !f.shared || !buf.Equal(f.buffer) {
+			ptr := uintptr(unsafe.Pointer(unsafe.SliceData(canvasBuf)))
+			view := js.Global().Get("Uint8ClampedArray").New(buf, int(ptr), len(canvasBuf))
+			f.imageData = js.Global().Get("ImageData").New(view, width, height)
+			f.buffer, f.shared = buf, true
+		}
+		return f.imageData
+	}
+	if f.imageData.IsUndefined() {
+		f.imageData = js.Global().Get("ImageData").New(width, height)
+	}
+	js.CopyBytesToJS(f.imageData.Get("data"), canvasBuf)
+	return f.imageData
+}
@@ -6,8 +6,10 @@ package main
 import (
 	"fmt"
 	"syscall/js"
+	"time"
 
 	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/examples/shared/debughud"
 	liondemo "github.com/MeKo-Christian/agg_go/internal/demo/lion"
 )
 
@@ -16,6 +18,9 @@ var (
 	ctx           *agg.Context
 	canvasBuf     []uint8
 	lionData      *liondemo.LionData
+
+	hud     = debughud.New()
+	showHUD bool
 )
 
 func main() {
@@ -31,6 +36,21 @@ func main() {
 	js.Global().Set("onMouseDown", js.FuncOf(onMouseDown))
 	js.Global().Set("onMouseMove", js.FuncOf(onMouseMove))
 	js.Global().Set("onMouseUp", js.FuncOf(onMouseUp))
+	js.Global().Set("onMouseWheel", js.FuncOf(onMouseWheel))
+	js.Global().Set("onDoubleClick", js.FuncOf(onDoubleClick))
+	js.Global().Set("onTouchDown", js.FuncOf(onTouchDown))
+	js.Global().Set("onTouchMove", js.FuncOf(onTouchMove))
+	js.Global().Set("onTouchUp", js.FuncOf(onTouchUp))
+	js.Global().Set("startAnimation", js.FuncOf(startAnimationJS))
+	js.Global().Set("stopAnimation", js.FuncOf(stopAnimationJS))
+	js.Global().Set("useSharedFrames", js.FuncOf(useSharedFramesJS))
+	js.Global().Set("getFrameImageData", js.FuncOf(getFrameImageData))
+	js.Global().Set("setShowHUD", js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
+		if len(args) > 0 {
+			showHUD = args[0].Bool()
+		}
+		return nil
+	}))
 	js.Global().Set("setAAZoom", js.FuncOf(setAAZoom))
 	js.Global().Set("setAANodes", js.FuncOf(setAANodes))
 	js.Global().Set("getAANodes", js.FuncOf(getAANodes))
@@ -657,12 +677,6 @@ func main() {
 		}
 		return nil
 	}))
-	js.Global().Set("applyFlash2Wheel", js.FuncOf(func(_ js.Value, args []js.Value) interface{} {
-		if len(args) >= 3 {
-			applyFlash2Wheel(args[0].Float(), args[1].Float(), args[2].Float())
-		}
-		return nil
-	}))
 
 	// Keep the Go program running
 	select {}
@@ -1655,6 +1669,7 @@ func renderDemo(this js.Value, args []js.Value) interface{} {
 
 	ctx.Clear(agg.White)
 	ctx.GetAgg2D().ResetStyle()
+	start := time.Now()
 
 	switch demoType {
 	case "agg2d":
@@ -1792,6 +1807,13 @@ func renderDemo(this js.Value, args []js.Value) interface{} {
 		return nil
 	}
 
+	hud.Tick(time.Now())
+	if showHUD {
+		hud.Set("demo", demoType)
+		hud.Setf("render", "%.2f ms", float64(time.Since(start))/float64(time.Millisecond))
+		hud.Draw(ctx)
+	}
+
 	// Copy the rendered buffer to the JavaScript Uint8ClampedArray
 	if len(args) >= 2 {
 		jsBuf := args[1]
@@ -1876,3 +1898,49 @@ func drawHandle(x, y float64) {
 	ctx.SetColor(agg.Black)
 	ctx.DrawCircle(x, y, 5)
 }
+
+// onMouseWheel(demo, x, y, dx, dy) reports whether the demo needs a redraw.
+func onMouseWheel(this js.Value, args []js.Value) interface{} {
+	if len(args) < 5 {
+		return nil
+	}
+	return handleMouseWheel(args[0].String(), args[1].Float(), args[2].Float(), args[3].Float(), args[4].Float())
+}
+
+// onDoubleClick(demo, x, y) reports whether the demo needs a redraw.
+func onDoubleClick(this js.Value, args []js.Value) interface{} {
+	if len(args) < 3 {
+		return nil
+	}
+	return handleDoubleClick(args[0].String(), args[1].Float(), args[2].Float())
+}
+
+// onTouchDown(demo, id, x, y) starts tracking a finger.
+func onTouchDown(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return nil
+	}
+	touches.down(int64(args[1].Int()), args[2].Float(), args[3].Float())
+	return false
+}
+
+// onTouchMove(demo, id, x, y) reports whether a pinch zoomed the demo.
+func onTouchMove(this js.Value, args []js.Value) interface{} {
+	if len(args) < 4 {
+		return nil
+	}
+	cx, cy, dy, ok := touches.move(int64(args[1].Int()), args[2].Float(), args[3].Float())
+	if !ok {
+		return false
+	}
+	return handleMouseWheel(args[0].String(), cx, cy, 0, dy)
+}
+
+// onTouchUp(demo, id) stops tracking a finger.
+func onTouchUp(this js.Value, args []js.Value) interface{} {
+	if len(args) < 2 {
+		return nil
+	}
+	touches.up(int64(args[1].Int()))
+	return false
+}
@@ -0,0 +1,90 @@
+// Wheel, double-click, keyboard and touch dispatch shared by the browser glue.
+//
+// Conventions follow internal/platform: dy > 0 scrolls away from the user
+// (zoom in), one wheel notch is 1.
+package main
+
+import "math"
+
+// handleMouseWheel routes a scroll at (x, y) to the demo and reports whether
+// it needs a redraw.
+func handleMouseWheel(demoType string, x, y, dx, dy float64) bool {
+	switch demoType {
+	case "flash_rasterizer2":
+		applyFlash2Wheel(x, y, -dy)
+		return true
+	case "lion":
+		return handleLionMouseWheel(x, y, dy)
+	}
+	return false
+}
+
+// handleDoubleClick routes a double-click to the demo.
+func handleDoubleClick(demoType string, x, y float64) bool {
+	if demoType == "lion" {
+		return handleLionDoubleClick()
+	}
+	return false
+}
+
+// handleKeyDown routes a key press, named as in KeyboardEvent.key, to the
+// demo and reports whether it needs a redraw.
+func handleKeyDown(demoType, key string) bool {
+	if demoType == "lion" {
+		return handleLionKeyDown(key)
+	}
+	return false
+}
+
+// touchTracker turns two-finger pinches into wheel steps, so every demo with
+// wheel zoom can be zoomed on touch screens. Single-finger drags reach the
+// demos as mouse events.
+type touchTracker struct {
+	points map[int64][2]float64
+	dist   float64 // finger distance at the last zoom step, 0 when not pinching
+}
+
+var touches = touchTracker{points: make(map[int64][2]float64)}
+
+func (t *touchTracker) down(id int64, x, y float64) {
+	t.points[id] = [2]float64{x, y}
+	t.dist = t.pinchDistance()
+}
+
+// move updates a finger and returns the wheel delta of the pinch since the
+// last call, with the pinch centre.
+func (t *touchTracker) move(id int64, x, y float64) (cx, cy, dy float64, ok bool) {
+	if _, tracked := t.points[id]; !tracked {
+		return 0, 0, 0, false
+	}
+	t.points[id] = [2]float64{x, y}
+	d := t.pinchDistance()
+	if d == 0 || t.dist == 0 {
+		return 0, 0, 0, false
+	}
+	// One notch zooms by 10% in the demos.
+	dy = math.Log(d/t.dist) / math.Log(1.1)
+	t.dist = d
+	for _, p := range t.points {
+		cx += p[0] / 2
+		cy += p[1] / 2
+	}
+	return cx, cy, dy, true
+}
+
+func (t *touchTracker) up(id int64) {
+	delete(t.points, id)
+	t.dist = t.pinchDistance()
+}
+
+// pinchDistance is the distance of exactly two fingers, or 0.
+func (t *touchTracker) pinchDistance() float64 {
+	if len(t.points) != 2 {
+		return 0
+	}
+	var p [][2]float64
+	for _, v := range t.points {
+		p = append(p, v)
+	}
+	return math.Hypot(p[1][0]-p[0][0], p[1][1]-p[0][1])
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTouchTrackerPinch(t *testing.T) {
+	tr := touchTracker{points: make(map[int64][2]float64)}
+	tr.down(1, 100, 100)
+	if _, _, _, ok := tr.move(1, 110, 100); ok {
+		t.Fatal("single finger must not pinch")
+	}
+	tr.down(2, 210, 100) // 100 px apart
+	cx, cy, dy, ok := tr.move(2, 220, 100)
+	if !ok {
+		t.Fatal("two fingers did not pinch")
+	}
+	if math.Abs(dy-1) > 1e-9 {
+		t.Errorf("spreading by 10%% gave dy=%v, want 1", dy)
+	}
+	if cx != 165 || cy != 100 {
+		t.Errorf("pinch centre = (%v, %v), want (165, 100)", cx, cy)
+	}
+	tr.up(2)
+	if _, _, _, ok := tr.move(1, 120, 100); ok {
+		t.Error("pinch continued after a finger was lifted")
+	}
+	if _, _, _, ok := tr.move(3, 0, 0); ok {
+		t.Error("untracked finger moved")
+	}
+}
+
+func TestLionKeys(t *testing.T) {
+	defer handleLionDoubleClick()
+	handleLionDoubleClick()
+	if !handleKeyDown("lion", "ArrowRight") || math.Abs(lionFillAngle-math.Pi/36) > 1e-12 {
+		t.Errorf("ArrowRight: angle = %v, want %v", lionFillAngle, math.Pi/36)
+	}
+	if !handleKeyDown("lion", "ArrowUp") || math.Abs(lionFillScale-1.1) > 1e-12 {
+		t.Errorf("ArrowUp: scale = %v, want 1.1", lionFillScale)
+	}
+	if !handleKeyDown("lion", "0") || lionFillAngle != 0 || lionFillScale != 1 {
+		t.Errorf("0 did not reset the view: angle %v scale %v", lionFillAngle, lionFillScale)
+	}
+	if handleKeyDown("lion", "x") || handleKeyDown("aa", "ArrowUp") {
+		t.Error("unhandled keys reported a redraw")
+	}
+}
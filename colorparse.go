@@ -0,0 +1,228 @@
+package agg
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ColorFromHex parses a hex color in the forms "#rgb", "#rgba", "#rrggbb"
+// and "#rrggbbaa", with or without the leading '#'. Short forms repeat each
+// digit, so "#f80" is "#ff8800".
+func ColorFromHex(s string) (Color, error) {
+	h := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	v, err := strconv.ParseUint(h, 16, 32)
+	if err != nil {
+		return Color{}, fmt.Errorf("invalid hex color %q", s)
+	}
+	switch len(h) {
+	case 3, 4:
+		if len(h) == 3 {
+			v = v<<4 | 0xf
+		}
+		r, g, b, a := uint8(v>>12&0xf), uint8(v>>8&0xf), uint8(v>>4&0xf), uint8(v&0xf)
+		return NewColor(r*17, g*17, b*17, a*17), nil
+	case 6, 8:
+		if len(h) == 6 {
+			v = v<<8 | 0xff
+		}
+		return NewColor(uint8(v>>24), uint8(v>>16), uint8(v>>8), uint8(v)), nil
+	}
+	return Color{}, fmt.Errorf("invalid hex color %q", s)
+}
+
+// ParseColor parses a CSS color: a hex color (see ColorFromHex), a named
+// color such as "steelblue" or "transparent", or one of the functions
+// rgb(), rgba(), hsl() and hsla(). Function arguments may be separated by
+// commas or by spaces with a "/" before the alpha, so both
+// "rgba(255, 0, 0, 0.5)" and "rgb(100% 0% 0% / 50%)" work. Names and
+// function names are case-insensitive.
+func ParseColor(s string) (Color, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(str, "#") {
+		return ColorFromHex(str)
+	}
+	if v, ok := cssNamedColors[str]; ok {
+		if str == "transparent" {
+			return Transparent, nil
+		}
+		return NewColorRGB(uint8(v>>16), uint8(v>>8), uint8(v)), nil
+	}
+
+	open := strings.IndexByte(str, '(')
+	if open < 0 || !strings.HasSuffix(str, ")") {
+		return Color{}, fmt.Errorf("invalid color %q", s)
+	}
+	name := strings.TrimSpace(str[:open])
+	args, ok := splitColorArgs(str[open+1 : len(str)-1])
+	if !ok || len(args) < 3 || len(args) > 4 {
+		return Color{}, fmt.Errorf("invalid color %q", s)
+	}
+
+	alpha := 1.0
+	if len(args) == 4 {
+		a, ok := parseColorNumber(args[3], 1)
+		if !ok {
+			return Color{}, fmt.Errorf("invalid alpha in color %q", s)
+		}
+		alpha = a
+	}
+
+	var r, g, b float64
+	switch name {
+	case "rgb", "rgba":
+		var c [3]float64
+		for i := range c {
+			v, ok := parseColorNumber(args[i], 255)
+			if !ok {
+				return Color{}, fmt.Errorf("invalid channel in color %q", s)
+			}
+			c[i] = v / 255
+		}
+		r, g, b = c[0], c[1], c[2]
+	case "hsl", "hsla":
+		h, ok1 := parseHue(args[0])
+		sat, ok2 := parseColorNumber(args[1], 1)
+		l, ok3 := parseColorNumber(args[2], 1)
+		if !ok1 || !ok2 || !ok3 || !strings.HasSuffix(args[1], "%") || !strings.HasSuffix(args[2], "%") {
+			return Color{}, fmt.Errorf("invalid hsl color %q", s)
+		}
+		r, g, b = hslToRGB(h, sat, l)
+	default:
+		return Color{}, fmt.Errorf("unknown color function %q", name)
+	}
+
+	return NewColor(unitToByte(r), unitToByte(g), unitToByte(b), unitToByte(alpha)), nil
+}
+
+// splitColorArgs splits the arguments of a color function written either
+// comma-separated or space-separated with an optional "/ alpha".
+func splitColorArgs(s string) ([]string, bool) {
+	if strings.Contains(s, ",") {
+		if strings.Contains(s, "/") {
+			return nil, false
+		}
+		args := strings.Split(s, ",")
+		for i := range args {
+			args[i] = strings.TrimSpace(args[i])
+		}
+		return args, true
+	}
+	main, alpha, hasAlpha := strings.Cut(s, "/")
+	args := strings.Fields(main)
+	if hasAlpha {
+		a := strings.TrimSpace(alpha)
+		if a == "" || len(args) != 3 {
+			return nil, false
+		}
+		args = append(args, a)
+	}
+	return args, true
+}
+
+// parseColorNumber parses a number or a percentage of full, clamped to
+// [0, full].
+func parseColorNumber(s string, full float64) (float64, bool) {
+	pct := strings.HasSuffix(s, "%")
+	v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil || math.IsNaN(v) {
+		return 0, false
+	}
+	if pct {
+		v = v / 100 * full
+	}
+	return math.Max(0, math.Min(full, v)), true
+}
+
+// parseHue parses a hue in degrees, optionally with a deg, rad or turn unit.
+func parseHue(s string) (float64, bool) {
+	scale := 1.0
+	switch {
+	case strings.HasSuffix(s, "deg"):
+		s = strings.TrimSuffix(s, "deg")
+	case strings.HasSuffix(s, "rad"):
+		s, scale = strings.TrimSuffix(s, "rad"), 180/math.Pi
+	case strings.HasSuffix(s, "turn"):
+		s, scale = strings.TrimSuffix(s, "turn"), 360
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v * scale, true
+}
+
+// hslToRGB converts hue in degrees and saturation and lightness in [0, 1] to
+// RGB in [0, 1].
+func hslToRGB(h, s, l float64) (r, g, b float64) {
+	h = math.Mod(h, 360)
+	if h < 0 {
+		h += 360
+	}
+	f := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		a := s * math.Min(l, 1-l)
+		return l - a*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1))
+	}
+	return f(0), f(8), f(4)
+}
+
+// unitToByte rounds a [0, 1] channel to 0..255.
+func unitToByte(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+// cssNamedColors holds the CSS Color Module Level 4 named colors as 0xRRGGBB.
+var cssNamedColors = map[string]uint32{
+	"aliceblue": 0xf0f8ff, "antiquewhite": 0xfaebd7, "aqua": 0x00ffff,
+	"aquamarine": 0x7fffd4, "azure": 0xf0ffff, "beige": 0xf5f5dc,
+	"bisque": 0xffe4c4, "black": 0x000000, "blanchedalmond": 0xffebcd,
+	"blue": 0x0000ff, "blueviolet": 0x8a2be2, "brown": 0xa52a2a,
+	"burlywood": 0xdeb887, "cadetblue": 0x5f9ea0, "chartreuse": 0x7fff00,
+	"chocolate": 0xd2691e, "coral": 0xff7f50, "cornflowerblue": 0x6495ed,
+	"cornsilk": 0xfff8dc, "crimson": 0xdc143c, "cyan": 0x00ffff,
+	"darkblue": 0x00008b, "darkcyan": 0x008b8b, "darkgoldenrod": 0xb8860b,
+	"darkgray": 0xa9a9a9, "darkgreen": 0x006400, "darkgrey": 0xa9a9a9,
+	"darkkhaki": 0xbdb76b, "darkmagenta": 0x8b008b, "darkolivegreen": 0x556b2f,
+	"darkorange": 0xff8c00, "darkorchid": 0x9932cc, "darkred": 0x8b0000,
+	"darksalmon": 0xe9967a, "darkseagreen": 0x8fbc8f, "darkslateblue": 0x483d8b,
+	"darkslategray": 0x2f4f4f, "darkslategrey": 0x2f4f4f, "darkturquoise": 0x00ced1,
+	"darkviolet": 0x9400d3, "deeppink": 0xff1493, "deepskyblue": 0x00bfff,
+	"dimgray": 0x696969, "dimgrey": 0x696969, "dodgerblue": 0x1e90ff,
+	"firebrick": 0xb22222, "floralwhite": 0xfffaf0, "forestgreen": 0x228b22,
+	"fuchsia": 0xff00ff, "gainsboro": 0xdcdcdc, "ghostwhite": 0xf8f8ff,
+	"gold": 0xffd700, "goldenrod": 0xdaa520, "gray": 0x808080,
+	"green": 0x008000, "greenyellow": 0xadff2f, "grey": 0x808080,
+	"honeydew": 0xf0fff0, "hotpink": 0xff69b4, "indianred": 0xcd5c5c,
+	"indigo": 0x4b0082, "ivory": 0xfffff0, "khaki": 0xf0e68c,
+	"lavender": 0xe6e6fa, "lavenderblush": 0xfff0f5, "lawngreen": 0x7cfc00,
+	"lemonchiffon": 0xfffacd, "lightblue": 0xadd8e6, "lightcoral": 0xf08080,
+	"lightcyan": 0xe0ffff, "lightgoldenrodyellow": 0xfafad2, "lightgray": 0xd3d3d3,
+	"lightgreen": 0x90ee90, "lightgrey": 0xd3d3d3, "lightpink": 0xffb6c1,
+	"lightsalmon": 0xffa07a, "lightseagreen": 0x20b2aa, "lightskyblue": 0x87cefa,
+	"lightslategray": 0x778899, "lightslategrey": 0x778899, "lightsteelblue": 0xb0c4de,
+	"lightyellow": 0xffffe0, "lime": 0x00ff00, "limegreen": 0x32cd32,
+	"linen": 0xfaf0e6, "magenta": 0xff00ff, "maroon": 0x800000,
+	"mediumaquamarine": 0x66cdaa, "mediumblue": 0x0000cd, "mediumorchid": 0xba55d3,
+	"mediumpurple": 0x9370db, "mediumseagreen": 0x3cb371, "mediumslateblue": 0x7b68ee,
+	"mediumspringgreen": 0x00fa9a, "mediumturquoise": 0x48d1cc, "mediumvioletred": 0xc71585,
+	"midnightblue": 0x191970, "mintcream": 0xf5fffa, "mistyrose": 0xffe4e1,
+	"moccasin": 0xffe4b5, "navajowhite": 0xffdead, "navy": 0x000080,
+	"oldlace": 0xfdf5e6, "olive": 0x808000, "olivedrab": 0x6b8e23,
+	"orange": 0xffa500, "orangered": 0xff4500, "orchid": 0xda70d6,
+	"palegoldenrod": 0xeee8aa, "palegreen": 0x98fb98, "paleturquoise": 0xafeeee,
+	"palevioletred": 0xdb7093, "papayawhip": 0xffefd5, "peachpuff": 0xffdab9,
+	"peru": 0xcd853f, "pink": 0xffc0cb, "plum": 0xdda0dd,
+	"powderblue": 0xb0e0e6, "purple": 0x800080, "rebeccapurple": 0x663399,
+	"red": 0xff0000, "rosybrown": 0xbc8f8f, "royalblue": 0x4169e1,
+	"saddlebrown": 0x8b4513, "salmon": 0xfa8072, "sandybrown": 0xf4a460,
+	"seagreen": 0x2e8b57, "seashell": 0xfff5ee, "sienna": 0xa0522d,
+	"silver": 0xc0c0c0, "skyblue": 0x87ceeb, "slateblue": 0x6a5acd,
+	"slategray": 0x708090, "slategrey": 0x708090, "snow": 0xfffafa,
+	"springgreen": 0x00ff7f, "steelblue": 0x4682b4, "tan": 0xd2b48c,
+	"teal": 0x008080, "thistle": 0xd8bfd8, "tomato": 0xff6347,
+	"transparent": 0x000000, "turquoise": 0x40e0d0, "violet": 0xee82ee,
+	"wheat": 0xf5deb3, "white": 0xffffff, "whitesmoke": 0xf5f5f5,
+	"yellow": 0xffff00, "yellowgreen": 0x9acd32,
+}
@@ -0,0 +1,45 @@
+package agg
+
+import "testing"
+
+func TestParseColor(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Color
+	}{
+		{"#f80", NewColor(255, 136, 0, 255)},
+		{"#f808", NewColor(255, 136, 0, 136)},
+		{"#ff880080", NewColor(255, 136, 0, 128)},
+		{"SteelBlue", NewColor(70, 130, 180, 255)},
+		{"transparent", Transparent},
+		{"rgb(255, 0, 128)", NewColor(255, 0, 128, 255)},
+		{"rgba(255, 0, 0, 0.5)", NewColor(255, 0, 0, 128)},
+		{"rgb(100% 0% 50% / 25%)", NewColor(255, 0, 128, 64)},
+		{"rgb(300, -5, 0)", NewColor(255, 0, 0, 255)},
+		{"hsl(120, 100%, 50%)", NewColor(0, 255, 0, 255)},
+		{"hsla(240deg 100% 25% / 0.5)", NewColor(0, 0, 128, 128)},
+		{"hsl(0.5turn, 100%, 50%)", NewColor(0, 255, 255, 255)},
+	}
+	for _, tt := range tests {
+		got, err := ParseColor(tt.in)
+		if err != nil {
+			t.Errorf("ParseColor(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColor(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "#12", "#ggg", "notacolor", "rgb(1, 2)", "rgb(1, 2, 3 / 4)", "hsl(0, 1, 1)", "cmyk(0, 0, 0, 0)"} {
+		if _, err := ParseColor(bad); err == nil {
+			t.Errorf("ParseColor(%q) succeeded, want error", bad)
+		}
+	}
+	if c, err := ColorFromHex("FF8800"); err != nil || c != NewColor(255, 136, 0, 255) {
+		t.Errorf(`ColorFromHex("FF8800") = %v, %v`, c, err)
+	}
+	if _, err := ColorFromHex("red"); err == nil {
+		t.Error(`ColorFromHex("red") succeeded, want error`)
+	}
+}
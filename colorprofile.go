@@ -0,0 +1,387 @@
+package agg
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/MeKo-Christian/agg_go/internal/color"
+)
+
+// ColorProfile is an ICC color profile to tag exported images with, so
+// viewers with color management show the intended colors.
+type ColorProfile struct {
+	name string
+	data []byte
+	srgb bool
+}
+
+var (
+	srgbProfile     *ColorProfile
+	srgbProfileOnce sync.Once
+)
+
+// SRGBProfile returns the sRGB IEC 61966-2.1 profile, the color space the
+// library draws in. PNG output tagged with it gets the compact sRGB chunk
+// instead of an embedded profile.
+func SRGBProfile() *ColorProfile {
+	srgbProfileOnce.Do(func() {
+		srgbProfile = &ColorProfile{name: "sRGB", data: buildSRGBProfile(), srgb: true}
+	})
+	return srgbProfile
+}
+
+// NewColorProfile wraps the ICC profile data, e.g. read from an .icc file.
+// name labels the profile in PNG files. Only the profile header is checked.
+func NewColorProfile(name string, data []byte) (*ColorProfile, error) {
+	if len(data) < 132 || string(data[36:40]) != "acsp" {
+		return nil, errors.New("not an ICC profile")
+	}
+	if size := binary.BigEndian.Uint32(data); size < 132 || int(size) > len(data) {
+		return nil, errors.New("ICC profile is truncated")
+	}
+	if name == "" {
+		name = "ICC profile"
+	}
+	return &ColorProfile{name: name, data: data[:binary.BigEndian.Uint32(data)]}, nil
+}
+
+// LoadColorProfile reads an ICC profile from a file, named after the file.
+func LoadColorProfile(filename string) (*ColorProfile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewColorProfile(filename, data)
+}
+
+// Name returns the profile name.
+func (p *ColorProfile) Name() string { return p.name }
+
+// Data returns the ICC profile data. It must not be modified.
+func (p *ColorProfile) Data() []byte { return p.data }
+
+// EncodeOptions configures EncodePNG and EncodeJPEG.
+type EncodeOptions struct {
+	// Profile tags the output with a color profile. nil writes no color
+	// information, which viewers usually take as sRGB.
+	Profile *ColorProfile
+	// FromLinear converts the pixels from linear light to the sRGB transfer
+	// curve before encoding, for images rendered in a linear working space.
+	// Images flagged linear, see Image.IsLinear, are always converted.
+	// Alpha is left as is.
+	FromLinear bool
+	// Quality is the JPEG quality (1-100). Zero uses jpeg.DefaultQuality.
+	Quality int
+	// Progressive asks for a progressive JPEG. image/jpeg only writes
+	// baseline JPEG, so EncodeJPEG rejects it.
+	Progressive bool
+	// Subsampling is the JPEG chroma subsampling. EncodeJPEG rejects
+	// anything but Subsample420, the only one image/jpeg writes.
+	Subsampling ChromaSubsampling
+}
+
+// ChromaSubsampling is the resolution of the JPEG color channels relative to
+// luma.
+type ChromaSubsampling int
+
+const (
+	// Subsample420 halves the chroma resolution in both directions.
+	Subsample420 ChromaSubsampling = iota
+	// Subsample422 halves the chroma resolution horizontally.
+	Subsample422
+	// Subsample444 keeps the full chroma resolution.
+	Subsample444
+)
+
+// EncodePNG writes the image to w as PNG according to opts.
+func (img *Image) EncodePNG(w io.Writer, opts EncodeOptions) error {
+	stdImg, err := img.exportImage(opts.FromLinear)
+	if err != nil {
+		return err
+	}
+	if opts.Profile == nil {
+		return png.Encode(w, stdImg)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, stdImg); err != nil {
+		return err
+	}
+	// The color chunks must precede the image data; put them right after
+	// the signature and IHDR, which the encoder always writes first.
+	const ihdrEnd = 8 + 8 + 13 + 4
+	out := buf.Bytes()
+	if _, err := w.Write(out[:ihdrEnd]); err != nil {
+		return err
+	}
+	if err := writePNGColorChunks(w, opts.Profile); err != nil {
+		return err
+	}
+	_, err = w.Write(out[ihdrEnd:])
+	return err
+}
+
+// EncodeJPEG writes the image to w as JPEG according to opts. The output is
+// baseline JPEG with 4:2:0 chroma subsampling, as written by image/jpeg;
+// alpha is dropped. Options asking for anything else return an error before
+// anything is written.
+func (img *Image) EncodeJPEG(w io.Writer, opts EncodeOptions) error {
+	if opts.Progressive {
+		return errors.New("progressive JPEG encoding is not supported")
+	}
+	if opts.Subsampling != Subsample420 {
+		return errors.New("only 4:2:0 JPEG chroma subsampling is supported")
+	}
+	stdImg, err := img.exportImage(opts.FromLinear)
+	if err != nil {
+		return err
+	}
+	quality := opts.Quality
+	if quality == 0 {
+		quality = jpeg.DefaultQuality
+	}
+	if opts.Profile == nil {
+		return jpeg.Encode(w, stdImg, &jpeg.Options{Quality: quality})
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, stdImg, &jpeg.Options{Quality: quality}); err != nil {
+		return err
+	}
+	// The profile goes in APP2 segments right after the SOI marker.
+	out := buf.Bytes()
+	if _, err := w.Write(out[:2]); err != nil {
+		return err
+	}
+	if err := writeJPEGProfile(w, opts.Profile.data); err != nil {
+		return err
+	}
+	_, err = w.Write(out[2:])
+	return err
+}
+
+// SaveToPNGWithOptions saves the image to a PNG file according to opts.
+func (img *Image) SaveToPNGWithOptions(filename string, opts EncodeOptions) error {
+	return saveEncoded(filename, func(w io.Writer) error { return img.EncodePNG(w, opts) })
+}
+
+// SaveToJPEGWithOptions saves the image to a JPEG file according to opts.
+func (img *Image) SaveToJPEGWithOptions(filename string, opts EncodeOptions) error {
+	return saveEncoded(filename, func(w io.Writer) error { return img.EncodeJPEG(w, opts) })
+}
+
+func saveEncoded(filename string, encode func(io.Writer) error) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := encode(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// exportImage converts the image for encoding, applying the sRGB transfer
+// curve when fromLinear is set or the image is flagged linear. Converted
+// translucent images come back as straight-alpha image.NRGBA.
+func (img *Image) exportImage(fromLinear bool) (image.Image, error) {
+	stdImg, err := img.ToStandardImage()
+	if err != nil || !(fromLinear || img.linear) {
+		return stdImg, err
+	}
+
+	var lut [256]uint8
+	for i := range lut {
+		lut[i] = uint8(color.ConvertGray8LinearToSRGB(color.Gray8[color.Linear]{V: uint8(i)}).V)
+	}
+	rgb := func(pix []uint8) {
+		for i := 0; i < len(pix); i += 4 {
+			pix[i] = lut[pix[i]]
+			pix[i+1] = lut[pix[i+1]]
+			pix[i+2] = lut[pix[i+2]]
+		}
+	}
+	switch m := stdImg.(type) {
+	case *image.RGBA:
+		// The curve applies to straight colors; premultiplied ones would
+		// shift the hue of translucent pixels.
+		n := &image.NRGBA{Pix: m.Pix, Stride: m.Stride, Rect: m.Rect}
+		demultiplyPixels(n.Pix, m.Rect.Dx(), m.Rect.Dy(), n.Stride, color.OrderRGBA)
+		rgb(n.Pix)
+		stdImg = n
+	case *image.NRGBA:
+		rgb(m.Pix)
+	case *image.Gray:
+		for i, v := range m.Pix {
+			m.Pix[i] = lut[v]
+		}
+	case *image.Gray16:
+		for i := 0; i < len(m.Pix); i += 2 {
+			v := float64(binary.BigEndian.Uint16(m.Pix[i:])) / 65535
+			binary.BigEndian.PutUint16(m.Pix[i:], uint16(math.Round(linearToSRGB(v)*65535)))
+		}
+	}
+	return stdImg, nil
+}
+
+func linearToSRGB(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// writePNGColorChunks writes sRGB, gAMA and cHRM for the sRGB profile and an
+// iCCP chunk otherwise.
+func writePNGColorChunks(w io.Writer, p *ColorProfile) error {
+	if p.srgb {
+		var gama, chrm [4]byte
+		binary.BigEndian.PutUint32(gama[:], 45455)
+		chrmData := make([]byte, 0, 32)
+		for _, v := range []uint32{31270, 32900, 64000, 33000, 30000, 60000, 15000, 6000} {
+			binary.BigEndian.PutUint32(chrm[:], v)
+			chrmData = append(chrmData, chrm[:]...)
+		}
+		if err := writePNGChunk(w, "sRGB", []byte{0}); err != nil { // perceptual intent
+			return err
+		}
+		if err := writePNGChunk(w, "gAMA", gama[:]); err != nil {
+			return err
+		}
+		return writePNGChunk(w, "cHRM", chrmData)
+	}
+
+	// The profile name is 1-79 Latin-1 characters.
+	name := []byte(p.name)
+	if len(name) > 79 {
+		name = name[:79]
+	}
+	var data bytes.Buffer
+	data.Write(name)
+	data.Write([]byte{0, 0}) // terminator, zlib compression
+	zw := zlib.NewWriter(&data)
+	if _, err := zw.Write(p.data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	return writePNGChunk(w, "iCCP", data.Bytes())
+}
+
+func writePNGChunk(w io.Writer, typ string, data []byte) error {
+	chunk := make([]byte, 0, 12+len(data))
+	chunk = binary.BigEndian.AppendUint32(chunk, uint32(len(data)))
+	chunk = append(chunk, typ...)
+	chunk = append(chunk, data...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	_, err := w.Write(chunk)
+	return err
+}
+
+// writeJPEGProfile writes data as a sequence of APP2 ICC_PROFILE segments.
+func writeJPEGProfile(w io.Writer, data []byte) error {
+	const maxChunk = 65535 - 2 - 14 // length field and ICC_PROFILE header
+	n := (len(data) + maxChunk - 1) / maxChunk
+	if n > 255 {
+		return errors.New("ICC profile too large for JPEG")
+	}
+	for i := 0; i < n; i++ {
+		chunk := data[i*maxChunk : min((i+1)*maxChunk, len(data))]
+		seg := []byte{0xFF, 0xE2}
+		seg = binary.BigEndian.AppendUint16(seg, uint16(2+14+len(chunk)))
+		seg = append(seg, "ICC_PROFILE\x00"...)
+		seg = append(seg, byte(i+1), byte(n))
+		seg = append(seg, chunk...)
+		if _, err := w.Write(seg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildSRGBProfile returns an ICC v2 display profile for sRGB: the D50
+// adapted sRGB primaries and the sRGB transfer curve as a 1024 entry table.
+func buildSRGBProfile() []byte {
+	s15 := func(b []byte, vs ...float64) []byte {
+		for _, v := range vs {
+			b = binary.BigEndian.AppendUint32(b, uint32(int32(math.Round(v*65536))))
+		}
+		return b
+	}
+	xyz := func(x, y, z float64) []byte { return s15([]byte("XYZ \x00\x00\x00\x00"), x, y, z) }
+
+	desc := []byte("desc\x00\x00\x00\x00")
+	const name = "sRGB IEC61966-2.1"
+	desc = binary.BigEndian.AppendUint32(desc, uint32(len(name)+1))
+	desc = append(desc, name+"\x00"...)
+	desc = append(desc, make([]byte, 4+4+2+1+67)...) // empty Unicode and ScriptCode descriptions
+
+	curv := []byte("curv\x00\x00\x00\x00")
+	const entries = 1024
+	curv = binary.BigEndian.AppendUint32(curv, entries)
+	for i := 0; i < entries; i++ {
+		v := srgbToLinear(float64(i) / (entries - 1))
+		curv = binary.BigEndian.AppendUint16(curv, uint16(math.Round(v*65535)))
+	}
+
+	tags := []struct {
+		sig  string
+		data []byte
+	}{
+		{"desc", desc},
+		{"cprt", []byte("text\x00\x00\x00\x00No copyright, use freely\x00")},
+		{"wtpt", xyz(0.9642, 1.0, 0.8249)},
+		{"rXYZ", xyz(0.4361, 0.2225, 0.0139)},
+		{"gXYZ", xyz(0.3851, 0.7169, 0.0971)},
+		{"bXYZ", xyz(0.1431, 0.0606, 0.7141)},
+		{"rTRC", curv},
+		{"gTRC", nil}, // share the red curve
+		{"bTRC", nil},
+	}
+
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // version 2.1
+	copy(header[12:], "mntrRGB XYZ ")
+	copy(header[36:], "acsp")
+	copy(header[68:], s15(nil, 0.9642, 1.0, 0.8249)) // PCS illuminant D50
+
+	table := binary.BigEndian.AppendUint32(nil, uint32(len(tags)))
+	offset := uint32(128 + 4 + 12*len(tags))
+	var body []byte
+	var lastOff, lastSize uint32
+	for _, t := range tags {
+		if t.data != nil {
+			lastOff, lastSize = offset+uint32(len(body)), uint32(len(t.data))
+			body = append(body, t.data...)
+			for len(body)%4 != 0 {
+				body = append(body, 0)
+			}
+		}
+		table = append(table, t.sig...)
+		table = binary.BigEndian.AppendUint32(table, lastOff)
+		table = binary.BigEndian.AppendUint32(table, lastSize)
+	}
+
+	profile := append(append(header, table...), body...)
+	binary.BigEndian.PutUint32(profile, uint32(len(profile)))
+	return profile
+}
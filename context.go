@@ -1,25 +1,42 @@
 package agg
 
 import (
+	"fmt"
+	"image"
+
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/shapes"
 )
 
 // Context provides the main high-level drawing API for typical Go callers.
 //
-// It manages an RGBA backing buffer, exposes immediate-mode helpers such as
+// It manages an RGBA backing buffer, or one of another pixel format created
+// with NewContextWithFormat, exposes immediate-mode helpers such as
 // DrawRectangle and FillCircle, and also allows explicit path construction via
 // BeginPath, MoveTo, LineTo, Fill, and Stroke.
 //
 // The Context keeps the underlying Agg2D instance available through GetAgg2D
 // for advanced use cases that need closer parity with the original C++ AGG2D
 // interface.
+//
+// A Context is not safe for concurrent use. Render from several goroutines
+// with one Context each, for example via ContextPool.
 type Context struct {
 	agg2d     *Agg2D
 	image     *Image
 	width     int
 	height    int
 	lineWidth float64 // Default stroke width used by convenience helpers.
+
+	hitRegions *HitRegionIndex // see SetHitRegions
+	hitID      int
+
+	unit Unit // see SetUnits
+	dpi  float64
+
+	lastSnapshot Snapshot // tiles shared by the next Snapshot call
+
+	defaults *contextDefaults // the new Context, restored by reset
 }
 
 // NewContext allocates a new RGBA image buffer and attaches a fresh Agg2D
@@ -53,20 +70,36 @@ func NewContext(width, height int) *Context {
 	// Set reasonable defaults
 	ctx.SetColor(Black)
 	ctx.agg2d.LineWidth(ctx.lineWidth)
+	ctx.saveDefaults()
 
 	return ctx
 }
 
+// NewContextWithFormat is NewContext for a backing image in format, so the
+// pixels can go to a framebuffer or API of that layout without conversion.
+// Every format is blended in place by its native pixel format. RGB8, Gray8
+// and RGB565 have no alpha and store colors opaque; with a compositing blend
+// mode they convert the pixels each drawing operation touches. Gray16 cannot
+// be rendered into.
+func NewContextWithFormat(width, height int, format ImageFormat) (*Context, error) {
+	if _, ok := imageTargetFormats[format]; !ok {
+		return nil, fmt.Errorf("cannot render into image format %d", format)
+	}
+	return NewContextForImage(CreateImageWithFormat(width, height, format)), nil
+}
+
 // NewContextForImage creates a Context that renders into an existing Image.
 //
 // Use this when image allocation is managed elsewhere but you still want the
-// higher-level Context API on top of that buffer.
+// higher-level Context API on top of that buffer. The image is rendered into
+// in its own pixel format, see NewContextWithFormat; drawing into a Gray16
+// image or a channel view does nothing.
 func NewContextForImage(img *Image) *Context {
 	if img == nil {
 		return nil
 	}
 	agg2d := NewAgg2D()
-	agg2d.Attach(img.Data, img.width, img.height, img.renBuf.Stride())
+	agg2d.AttachImage(img)
 
 	ctx := &Context{
 		agg2d:     agg2d,
@@ -78,10 +111,23 @@ func NewContextForImage(img *Image) *Context {
 
 	ctx.SetColor(Black)
 	ctx.agg2d.LineWidth(ctx.lineWidth)
+	ctx.saveDefaults()
 
 	return ctx
 }
 
+// NewContextForRGBA creates a Context that renders straight into the pixels
+// of a standard library image, without copying; see Agg2D.AttachRGBA. The
+// context's coordinates start at img.Rect.Min, and GetImage returns an Image
+// sharing the same memory.
+func NewContextForRGBA(img *image.RGBA) *Context {
+	if img == nil {
+		return nil
+	}
+	pix, w, h := rgbaPixels(img)
+	return NewContextForImage(NewImage(pix, w, h, img.Stride))
+}
+
 // Height returns the context height in pixels.
 func (ctx *Context) Height() int {
 	return ctx.height
@@ -126,26 +172,50 @@ func (ctx *Context) DrawThickLine(x1, y1, x2, y2, width float64) {
 //
 // Unlike the path API, this helper does not require a later Stroke call.
 func (ctx *Context) DrawRectangle(x, y, width, height float64) {
-	ctx.agg2d.ResetPath()
-	ctx.agg2d.MoveTo(x, y)
-	ctx.agg2d.LineTo(x+width, y)
-	ctx.agg2d.LineTo(x+width, y+height)
-	ctx.agg2d.LineTo(x, y+height)
-	ctx.agg2d.ClosePolygon()
-	ctx.agg2d.DrawPath(StrokeOnly)
+	ctx.rectangle(x, y, width, height, StrokeOnly)
 }
 
 // FillRectangle renders a filled rectangle immediately.
 //
 // Unlike the path API, this helper does not require a later Fill call.
 func (ctx *Context) FillRectangle(x, y, width, height float64) {
+	ctx.rectangle(x, y, width, height, FillOnly)
+}
+
+// FillAndStrokeRectangle fills a rectangle and strokes its outline in one
+// call, like the FillAndStroke path mode: the outline is built once and the
+// stroke is drawn over the fill of the very same path, so a semi-transparent
+// stroke shows the fill edge exactly along its center line.
+func (ctx *Context) FillAndStrokeRectangle(x, y, width, height float64) {
+	ctx.rectangle(x, y, width, height, FillAndStroke)
+}
+
+// rectangle replaces the current path with a rectangle and draws it.
+func (ctx *Context) rectangle(x, y, width, height float64, flag DrawPathFlag) {
 	ctx.agg2d.ResetPath()
 	ctx.agg2d.MoveTo(x, y)
 	ctx.agg2d.LineTo(x+width, y)
 	ctx.agg2d.LineTo(x+width, y+height)
 	ctx.agg2d.LineTo(x, y+height)
 	ctx.agg2d.ClosePolygon()
-	ctx.agg2d.DrawPath(FillOnly)
+	ctx.agg2d.DrawPath(flag)
+}
+
+// RectColor is one rectangle of a FillRects batch.
+type RectColor struct {
+	X, Y, Width, Height float64
+	Radius              float64 // corner radius; zero gives sharp corners
+	Color               Color
+}
+
+// FillRects fills many rectangles in one call, the fast path for UI panels,
+// buttons and list rows. Sharp rectangles under an axis-aligned transform are
+// blended directly as spans without rasterizer setup; rounded ones share one
+// rasterizer pass per color. Rectangles are grouped by color, so overlapping
+// rectangles of different colors may not be painted in slice order. The
+// current path and fill state are left unchanged.
+func (ctx *Context) FillRects(rects []RectColor) {
+	ctx.agg2d.FillRects(rects)
 }
 
 // DrawCircle renders a stroked circle immediately.
@@ -162,6 +232,12 @@ func (ctx *Context) FillCircle(cx, cy, radius float64) {
 	ctx.agg2d.DrawPath(FillOnly)
 }
 
+// FillAndStrokeCircle fills and strokes a circle in one call. See
+// FillAndStrokeRectangle.
+func (ctx *Context) FillAndStrokeCircle(cx, cy, radius float64) {
+	ctx.FillAndStrokeEllipse(cx, cy, radius, radius)
+}
+
 // DrawEllipse renders a stroked ellipse immediately.
 func (ctx *Context) DrawEllipse(cx, cy, rx, ry float64) {
 	ctx.agg2d.ResetPath()
@@ -176,12 +252,20 @@ func (ctx *Context) FillEllipse(cx, cy, rx, ry float64) {
 	ctx.agg2d.DrawPath(FillOnly)
 }
 
+// FillAndStrokeEllipse fills and strokes an ellipse in one call. See
+// FillAndStrokeRectangle.
+func (ctx *Context) FillAndStrokeEllipse(cx, cy, rx, ry float64) {
+	ctx.agg2d.ResetPath()
+	ctx.agg2d.AddEllipse(cx, cy, rx, ry, CCW)
+	ctx.agg2d.DrawPath(FillAndStroke)
+}
+
 // DrawRoundedRectangle renders a stroked rounded rectangle immediately.
 func (ctx *Context) DrawRoundedRectangle(x, y, width, height, radius float64) {
 	x2 := x + width
 	y2 := y + height
 	ctx.agg2d.ResetPath()
-	ctx.drawRoundedRectPath(x, y, x2, y2, radius)
+	addRoundedRectPath(ctx.agg2d, x, y, x2, y2, radius)
 	ctx.agg2d.DrawPath(StrokeOnly)
 }
 
@@ -190,16 +274,26 @@ func (ctx *Context) FillRoundedRectangle(x, y, width, height, radius float64) {
 	x2 := x + width
 	y2 := y + height
 	ctx.agg2d.ResetPath()
-	ctx.drawRoundedRectPath(x, y, x2, y2, radius)
+	addRoundedRectPath(ctx.agg2d, x, y, x2, y2, radius)
 	ctx.agg2d.DrawPath(FillOnly)
 }
 
-// drawRoundedRectPath appends a rounded-rectangle outline to the current path.
-func (ctx *Context) drawRoundedRectPath(x1, y1, x2, y2, radius float64) {
+// FillAndStrokeRoundedRectangle fills and strokes a rounded rectangle in one
+// call. See FillAndStrokeRectangle.
+func (ctx *Context) FillAndStrokeRoundedRectangle(x, y, width, height, radius float64) {
+	ctx.agg2d.ResetPath()
+	addRoundedRectPath(ctx.agg2d, x, y, x+width, y+height, radius)
+	ctx.agg2d.DrawPath(FillAndStroke)
+}
+
+// addRoundedRectPath appends a rounded-rectangle outline to the current path
+// of a.
+func addRoundedRectPath(a *Agg2D, x1, y1, x2, y2, radius float64) {
 	roundedRect := shapes.NewRoundedRectEmpty()
 	roundedRect.SetRect(x1, y1, x2, y2)
 	roundedRect.SetRadius(radius)
 	roundedRect.NormalizeRadius()
+	roundedRect.SetApproximationScale(a.impl.EffectiveApproximationScale())
 	roundedRect.Rewind(0)
 
 	first := true
@@ -211,16 +305,16 @@ func (ctx *Context) drawRoundedRectPath(x1, y1, x2, y2, radius float64) {
 		}
 
 		if first {
-			ctx.agg2d.MoveTo(x, y)
+			a.MoveTo(x, y)
 			first = false
 			continue
 		}
 		if cmd == basics.PathCmdLineTo {
-			ctx.agg2d.LineTo(x, y)
+			a.LineTo(x, y)
 			continue
 		}
 		if cmd&basics.PathCmdMask == basics.PathCmdEndPoly {
-			ctx.agg2d.ClosePolygon()
+			a.ClosePolygon()
 		}
 	}
 }
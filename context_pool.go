@@ -0,0 +1,152 @@
+package agg
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/MeKo-Christian/agg_go/internal/font/freetype"
+)
+
+// ContextPool hands out a fixed set of equally sized Contexts to concurrent
+// callers.
+//
+// Context and Agg2D are not safe for concurrent use: each one owns its
+// rasterizer, scanline and font engine state. The supported way to render
+// from several goroutines is therefore one Context per goroutine, which is
+// what ContextPool manages. Read-only resources such as source Images may be
+// shared between pooled contexts as long as nobody writes to them while they
+// are in use.
+//
+// The font registered with SetFont is read once and shared by all pooled
+// contexts: each Context opens its own face on the same font data, with its
+// own engine and glyph cache, so no font state is touched from two
+// goroutines.
+type ContextPool struct {
+	width    int
+	height   int
+	contexts chan *Context
+
+	fontMu sync.Mutex
+	font   *poolFont
+}
+
+type poolFont struct {
+	fileName  string
+	data      *freetype.FontData
+	height    float64
+	bold      bool
+	italic    bool
+	cacheType FontCacheType
+	angle     float64
+}
+
+// NewContextPool allocates n Contexts of width x height pixels.
+func NewContextPool(width, height, n int) *ContextPool {
+	if n < 1 {
+		n = 1
+	}
+	p := &ContextPool{
+		width:    width,
+		height:   height,
+		contexts: make(chan *Context, n),
+	}
+	for i := 0; i < n; i++ {
+		p.contexts <- NewContext(width, height)
+	}
+	return p
+}
+
+// Size returns the number of contexts managed by the pool.
+func (p *ContextPool) Size() int { return cap(p.contexts) }
+
+// Width returns the width of the pooled contexts.
+func (p *ContextPool) Width() int { return p.width }
+
+// Height returns the height of the pooled contexts.
+func (p *ContextPool) Height() int { return p.height }
+
+// SetFont registers the font that every pooled Context starts with. The font
+// file is read once here; each Context selects the font the next time it is
+// taken from the pool.
+func (p *ContextPool) SetFont(fileName string, height float64, bold, italic bool, cacheType FontCacheType, angle float64) error {
+	if fileName == "" {
+		return errors.New("font file name is empty")
+	}
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	p.fontMu.Lock()
+	defer p.fontMu.Unlock()
+	p.font = &poolFont{fileName, freetype.NewFontData(b), height, bold, italic, cacheType, angle}
+	return nil
+}
+
+// Get takes a Context from the pool, blocking until one is available.
+//
+// The Context is cleared to transparent and reset to the state it was
+// created with, with an empty transform stack and the pool font selected.
+// Return it with Put when done.
+func (p *ContextPool) Get() (*Context, error) {
+	ctx := <-p.contexts
+	ctx.reset()
+	if err := p.applyFont(ctx); err != nil {
+		p.contexts <- ctx
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// Put returns ctx to the pool. The caller must not use ctx afterwards.
+func (p *ContextPool) Put(ctx *Context) {
+	if ctx == nil {
+		return
+	}
+	p.contexts <- ctx
+}
+
+// Do runs draw with a pooled Context and returns the Context to the pool
+// afterwards. The rendered pixels are only valid inside draw; copy them (for
+// example with CloneImage or by encoding) before returning.
+func (p *ContextPool) Do(draw func(ctx *Context) error) error {
+	ctx, err := p.Get()
+	if err != nil {
+		return err
+	}
+	defer p.Put(ctx)
+	return draw(ctx)
+}
+
+func (p *ContextPool) applyFont(ctx *Context) error {
+	p.fontMu.Lock()
+	f := p.font
+	p.fontMu.Unlock()
+	if f == nil {
+		return nil
+	}
+	ctx.agg2d.impl.ShareFontData(f.fileName, f.data)
+	return ctx.Font(f.fileName, f.height, f.bold, f.italic, f.cacheType, f.angle)
+}
+
+// contextDefaults is a Context as it was created, with its drawing state.
+type contextDefaults struct {
+	ctx   Context
+	state State
+}
+
+// saveDefaults records the state of a new Context for reset.
+func (ctx *Context) saveDefaults() {
+	ctx.defaults = &contextDefaults{ctx: *ctx, state: ctx.agg2d.State()}
+}
+
+// reset restores the Context to the state it was created in and clears the
+// image to transparent. The backing image and the font file are kept.
+func (ctx *Context) reset() {
+	d, img, width, height := ctx.defaults, ctx.image, ctx.width, ctx.height
+	*ctx = d.ctx
+	ctx.defaults, ctx.image, ctx.width, ctx.height = d, img, width, height
+	ctx.agg2d.SetState(d.state)
+	ctx.agg2d.impl.ClearTransformStack()
+	ctx.agg2d.ClearAll(Transparent)
+}
@@ -0,0 +1,42 @@
+package agg
+
+import (
+	"errors"
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/effects"
+)
+
+// Predefined 3x3 convolution kernels for Image.Convolve, listed row by row.
+// Pass them with a divisor of 0 (the kernel sum) and a bias of 0; edge
+// detection kernels sum to zero and give black where the image is flat.
+var (
+	KernelSharpen    = []float64{0, -1, 0, -1, 5, -1, 0, -1, 0}
+	KernelEmboss     = []float64{-2, -1, 0, -1, 1, 1, 0, 1, 2}
+	KernelEdgeDetect = []float64{-1, -1, -1, -1, 8, -1, -1, -1, -1}
+)
+
+// Convolve filters the image with a square convolution kernel of odd size,
+// typically 3x3 or 5x5, given row by row. Each color channel becomes the
+// weighted sum of its neighborhood divided by divisor, plus bias; a divisor
+// of 0 uses the sum of the kernel, or 1 when that is 0. Pixels beyond the
+// edge repeat the edge pixels. Alpha is kept, and colors are clamped to it
+// so premultiplied pixels stay valid. Rows are filtered in parallel. Only
+// RGBA images are filtered.
+func (img *Image) Convolve(kernel []float64, divisor, bias float64) error {
+	size := int(math.Sqrt(float64(len(kernel))))
+	if size*size != len(kernel) || size%2 == 0 {
+		return errors.New("kernel must be a square of odd size")
+	}
+	if img == nil || img.format != ImageRGBA8 {
+		return errors.New("convolution needs an RGBA image")
+	}
+	if divisor == 0 {
+		for _, k := range kernel {
+			divisor += k
+		}
+	}
+	src := append([]uint8(nil), img.Data...)
+	effects.Convolve(img.Data, src, img.width, img.height, img.renBuf.Stride(), 4, 3, kernel, size, divisor, bias)
+	return nil
+}
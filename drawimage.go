@@ -0,0 +1,116 @@
+package agg
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+var _ draw.Image = (*Image)(nil)
+
+// ColorModel returns the color model of the image's pixels, which At
+// returns and Set converts to:
+//
+//   - 32-bit formats with premultiplied alpha (the default) use
+//     color.RGBAModel, premultiplied like image.RGBA.
+//   - 32-bit formats with straight alpha (see Demultiply and DecodeOptions)
+//     use color.NRGBAModel, like image.NRGBA.
+//   - ImageGray8 and ImageGray16 use color.GrayModel and color.Gray16Model.
+//   - ImageRGB8 and ImageRGB565 have no alpha. They report color.RGBAModel,
+//     and Set stores the premultiplied channels of a translucent color, as
+//     if it were drawn over black.
+func (img *Image) ColorModel() color.Model {
+	switch img.format {
+	case ImageGray8:
+		return color.GrayModel
+	case ImageGray16:
+		return color.Gray16Model
+	}
+	if _, ok := img.format.channelOrder(); ok && img.alpha == AlphaStraight {
+		return color.NRGBAModel
+	}
+	return color.RGBAModel
+}
+
+// Bounds returns the image rectangle, with its origin at (0, 0).
+func (img *Image) Bounds() image.Rectangle {
+	return image.Rect(0, 0, img.width, img.height)
+}
+
+// pixOffset returns the offset of pixel (x, y) in Data, or -1 when the point
+// lies outside the image.
+func (img *Image) pixOffset(x, y int) int {
+	if x < 0 || y < 0 || x >= img.width || y >= img.height || img.renBuf == nil {
+		return -1
+	}
+	return y*img.renBuf.Stride() + x*img.pixelStep()
+}
+
+// At returns the color of pixel (x, y) in the image's color model, or the
+// model's zero color outside Bounds.
+func (img *Image) At(x, y int) color.Color {
+	i := img.pixOffset(x, y)
+	switch img.format {
+	case ImageGray8:
+		if i < 0 {
+			return color.Gray{}
+		}
+		return color.Gray{Y: img.Data[i]}
+	case ImageGray16:
+		if i < 0 {
+			return color.Gray16{}
+		}
+		return color.Gray16{Y: uint16(img.Data[i])<<8 | uint16(img.Data[i+1])}
+	}
+	if o, ok := img.format.channelOrder(); ok {
+		straight := img.alpha == AlphaStraight
+		if i < 0 {
+			if straight {
+				return color.NRGBA{}
+			}
+			return color.RGBA{}
+		}
+		p := img.Data[i : i+4]
+		if straight {
+			return color.NRGBA{R: p[o.R], G: p[o.G], B: p[o.B], A: p[o.A]}
+		}
+		return color.RGBA{R: p[o.R], G: p[o.G], B: p[o.B], A: p[o.A]}
+	}
+	if i < 0 {
+		return color.RGBA{}
+	}
+	c := imageTargetFormats[img.format].Decode(img.Data[i:])
+	return color.RGBA{R: c[0], G: c[1], B: c[2], A: 255}
+}
+
+// Set replaces pixel (x, y) with c, converted to the image's color model,
+// like Set on the standard images; draw with a Context or image/draw to
+// blend instead. Points outside Bounds are ignored.
+func (img *Image) Set(x, y int, c color.Color) {
+	i := img.pixOffset(x, y)
+	if i < 0 {
+		return
+	}
+	switch img.format {
+	case ImageGray8:
+		img.Data[i] = color.GrayModel.Convert(c).(color.Gray).Y
+		return
+	case ImageGray16:
+		v := color.Gray16Model.Convert(c).(color.Gray16).Y
+		img.Data[i], img.Data[i+1] = uint8(v>>8), uint8(v)
+		return
+	}
+	if o, ok := img.format.channelOrder(); ok {
+		p := img.Data[i : i+4]
+		if img.alpha == AlphaStraight {
+			n := color.NRGBAModel.Convert(c).(color.NRGBA)
+			p[o.R], p[o.G], p[o.B], p[o.A] = n.R, n.G, n.B, n.A
+			return
+		}
+		r := color.RGBAModel.Convert(c).(color.RGBA)
+		p[o.R], p[o.G], p[o.B], p[o.A] = r.R, r.G, r.B, r.A
+		return
+	}
+	r := color.RGBAModel.Convert(c).(color.RGBA)
+	imageTargetFormats[img.format].Encode(img.Data[i:], [4]uint8{r.R, r.G, r.B, 255})
+}
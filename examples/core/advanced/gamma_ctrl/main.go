@@ -21,7 +21,18 @@ const (
 	gammaCtrlHeight = 400
 )
 
-type demo struct{}
+// demo keeps the gamma control between frames so the curve can be edited by
+// dragging its two control points.
+type demo struct {
+	gc *gammactrl.GammaCtrl
+}
+
+func newDemo() *demo {
+	gc := gammactrl.NewGammaCtrl(10, 10, 300, 200, false)
+	gc.SetTextSize(10.0, 12.0)
+	gc.Values(1.0, 1.0, 1.0, 1.0)
+	return &demo{gc: gc}
+}
 
 type rasterVertexSourceAdapter struct {
 	src simpleVertexSource
@@ -138,13 +149,10 @@ func (d *demo) Render(img *agg.Image) {
 	a := ctx.GetAgg2D()
 	a.ResetTransformations()
 
-	gc := gammactrl.NewGammaCtrl(10, 10, 300, 200, false)
-	gc.SetTextSize(10.0, 12.0)
-	gc.Values(1.0, 1.0, 1.0, 1.0)
-	renderCtrl(a, gc)
-
-	ras := a.GetInternalRasterizer()
-	ras.SetGamma(gc.Y)
+	// The control itself is drawn with linear coverage; everything after it
+	// goes through the curve it defines, as ras.gamma(m_g_ctrl) does in AGG.
+	renderCtrl(a, d.gc)
+	a.GetInternalRasterizer().SetGammaTable(d.gc.Gamma())
 
 	eWidth := float64(gammaCtrlWidth)/2.0 - 10.0
 	eCenter := float64(gammaCtrlWidth) / 2.0
@@ -182,11 +190,42 @@ func (d *demo) Render(img *agg.Image) {
 	}
 }
 
+func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+	return btn.Left && d.gc.OnMouseButtonDown(float64(x), float64(y))
+}
+
+func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+	return d.gc.OnMouseMove(float64(x), float64(y), btn.Left)
+}
+
+func (d *demo) OnMouseUp(x, y int, _ lowlevelrunner.Buttons) bool {
+	return d.gc.OnMouseButtonUp(float64(x), float64(y))
+}
+
+// OnKey moves the active control point with the WASD keys; the runner does
+// not forward arrow keys. Space switches between the two points.
+func (d *demo) OnKey(key rune) bool {
+	switch key {
+	case ' ':
+		d.gc.ChangeActivePoint()
+		return true
+	case 'a':
+		return d.gc.OnArrowKeys(true, false, false, false)
+	case 'd':
+		return d.gc.OnArrowKeys(false, true, false, false)
+	case 's':
+		return d.gc.OnArrowKeys(false, false, true, false)
+	case 'w':
+		return d.gc.OnArrowKeys(false, false, false, true)
+	}
+	return false
+}
+
 func main() {
 	lowlevelrunner.Run(lowlevelrunner.Config{
 		Title:  "Anti-Aliasing Gamma Correction",
 		Width:  gammaCtrlWidth,
 		Height: gammaCtrlHeight,
 		FlipY:  true,
-	}, &demo{})
+	}, newDemo())
 }
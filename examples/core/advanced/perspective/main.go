@@ -9,7 +9,7 @@ import (
 	"math"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	liondemo "github.com/MeKo-Christian/agg_go/internal/demo/lion"
 	"github.com/MeKo-Christian/agg_go/internal/transform"
@@ -70,8 +70,7 @@ func newDemo() *demo {
 	}
 }
 
-func (d *demo) Render(img *agg.Image) {
-	ctx := agg.NewContextForImage(img)
+func (d *demo) Draw(ctx *agg.Context) {
 	ctx.Clear(agg.RGBA(0.95, 0.95, 0.85, 1.0))
 
 	a := ctx.GetAgg2D()
@@ -138,7 +137,7 @@ func (d *demo) Render(img *agg.Image) {
 	}
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if !btn.Left {
 		return false
 	}
@@ -154,7 +153,7 @@ func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseUp(x, y int, btn app.Buttons) bool {
 	if d.dragIdx >= 0 {
 		d.dragIdx = -1
 		return true
@@ -162,7 +161,7 @@ func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	if d.dragIdx < 0 || !btn.Left {
 		return false
 	}
@@ -172,9 +171,9 @@ func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
 }
 
 func main() {
-	lowlevelrunner.Run(lowlevelrunner.Config{
+	app.New(app.Config{
 		Title:  "Perspective",
 		Width:  600,
 		Height: 600,
-	}, newDemo())
+	}, newDemo()).Run()
 }
@@ -0,0 +1,50 @@
+// Package main demonstrates the charts helpers: axes with nice ticks, bars,
+// a filled area and a long line series decimated to the plot width.
+package main
+
+import (
+	"math"
+
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/charts"
+	"github.com/MeKo-Christian/agg_go/examples/shared/demorunner"
+)
+
+type demo struct{}
+
+func (d *demo) Render(ctx *agg.Context) {
+	ctx.Clear(agg.White)
+	W, H := float64(ctx.Width()), float64(ctx.Height())
+
+	// 100k samples of a noisy signal.
+	const n = 100000
+	xs, ys := make([]float64, n), make([]float64, n)
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for i := range xs {
+		x := float64(i) / n * 12
+		xs[i] = x
+		ys[i] = 3*math.Sin(x) + 0.6*math.Sin(x*37) + 0.3*math.Sin(x*611)
+		minY, maxY = min(minY, ys[i]), max(maxY, ys[i])
+	}
+
+	plot := charts.NewPlot(50, 20, W-70, H-60)
+	lo, hi, _ := charts.NiceRange(minY, maxY, 6)
+	plot.SetRange(0, 12, lo, hi)
+
+	style := charts.DefaultAxisStyle()
+	style.XTicks, style.YTicks = 7, 6
+	plot.DrawAxes(ctx, style)
+
+	bx, by := make([]float64, 12), make([]float64, 12)
+	for i := range bx {
+		bx[i] = float64(i) + 0.5
+		by[i] = 2 * math.Cos(bx[i]*0.8)
+	}
+	plot.Bars(ctx, bx, by, 0.6, 0, agg.NewColor(240, 170, 60, 160))
+	plot.Area(ctx, xs, ys, lo, agg.NewColor(60, 120, 220, 50))
+	plot.Line(ctx, xs, ys, agg.NewColor(30, 80, 180, 255), 1.2)
+}
+
+func main() {
+	demorunner.Run(demorunner.Config{Title: "Charts", Width: 640, Height: 400}, &demo{})
+}
@@ -15,7 +15,6 @@ import (
 	ctrlbase "github.com/MeKo-Christian/agg_go/internal/ctrl"
 	scalectrl "github.com/MeKo-Christian/agg_go/internal/ctrl/scale"
 	sliderctrl "github.com/MeKo-Christian/agg_go/internal/ctrl/slider"
-	"github.com/MeKo-Christian/agg_go/internal/curves"
 	"github.com/MeKo-Christian/agg_go/internal/gsv"
 	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
 	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
@@ -109,7 +108,7 @@ func toRGBA8(c icol.RGBA) icol.RGBA8[icol.Linear] {
 	}
 }
 
-func generatePoints(w, h float64, spR, spG, spB *curves.BSpline, rng *clibcRand) []scatterPoint {
+func generatePoints(w, h float64, spR, spG, spB *agg.BSpline, rng *clibcRand) []scatterPoint {
 	rx, ry := w/3.5, h/3.5
 	pts := make([]scatterPoint, defaultNPoints)
 	for i := range pts {
@@ -201,9 +200,9 @@ func (r *bgr24Renderer) BlendColorHspan(x, y, length int, colors []icol.RGBA8[ic
 
 type demo struct {
 	rng         *clibcRand
-	splineR     *curves.BSpline
-	splineG     *curves.BSpline
-	splineB     *curves.BSpline
+	splineR     *agg.BSpline
+	splineG     *agg.BSpline
+	splineB     *agg.BSpline
 	points      []scatterPoint
 	scaleCtrl   *scalectrl.ScaleCtrl
 	selCtrl     *sliderctrl.SliderCtrl
@@ -303,9 +302,9 @@ func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
 
 func (d *demo) prepareState() {
 	if d.splineR == nil {
-		d.splineR = curves.NewBSplineFromPoints(splineRX, splineRY)
-		d.splineG = curves.NewBSplineFromPoints(splineGX, splineGY)
-		d.splineB = curves.NewBSplineFromPoints(splineBX, splineBY)
+		d.splineR = mustBSpline(splineRX, splineRY)
+		d.splineG = mustBSpline(splineGX, splineGY)
+		d.splineB = mustBSpline(splineBX, splineBY)
 	}
 	if d.rng == nil {
 		d.rng = newClibcRand()
@@ -467,3 +466,13 @@ func main() {
 		Height: startHeight,
 	}, &demo{})
 }
+
+// mustBSpline builds one of the color splines, whose tables have matching
+// lengths.
+func mustBSpline(x, y []float64) *agg.BSpline {
+	s, err := agg.NewBSpline(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
@@ -10,7 +10,7 @@ import (
 	"math"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/buffer"
 	"github.com/MeKo-Christian/agg_go/internal/color"
@@ -90,7 +90,8 @@ func newDemo() *demo {
 	}
 }
 
-func (d *demo) Render(img *agg.Image) {
+func (d *demo) Draw(ctx *agg.Context) {
+	img := ctx.GetImage()
 	rbuf := buffer.NewRenderingBufferU8()
 	rbuf.Attach(img.Data, img.Width(), img.Height(), img.Width()*4)
 	pixFmt := pixfmt.NewPixFmtRGBA32[color.Linear](rbuf)
@@ -126,7 +127,7 @@ func (d *demo) Render(img *agg.Image) {
 	renscan.RenderScanlinesAASolid[color.RGBA8[color.Linear]](ras, sl, rb, black)
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if !btn.Left {
 		return false
 	}
@@ -142,7 +143,7 @@ func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	if btn.Left && d.idx >= 0 {
 		d.x[d.idx] = float64(x) - d.dx
 		d.y[d.idx] = float64(y) - d.dy
@@ -154,15 +155,15 @@ func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseUp(_, _ int, _ lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseUp(_, _ int, _ app.Buttons) bool {
 	d.idx = -1
 	return false
 }
 
 func main() {
-	lowlevelrunner.Run(lowlevelrunner.Config{
+	app.New(app.Config{
 		Title:  "Rounded Rectangle",
 		Width:  demoWidth,
 		Height: demoHeight,
-	}, newDemo())
+	}, newDemo()).Run()
 }
@@ -7,7 +7,7 @@ package main
 
 import (
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	liondemo "github.com/MeKo-Christian/agg_go/internal/demo/lion"
 )
@@ -16,8 +16,8 @@ type demo struct {
 	cx, cy float64
 }
 
-func (d *demo) Render(img *agg.Image) {
-	ctx := agg.NewContextForImage(img)
+func (d *demo) Draw(ctx *agg.Context) {
+	img := ctx.GetImage()
 	ctx.Clear(agg.White)
 
 	agg2d := ctx.GetAgg2D()
@@ -45,7 +45,7 @@ func (d *demo) Render(img *agg.Image) {
 	applyBlurInsideEllipse(img, bgImg, d.cx, d.cy, rx, ry)
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if btn.Left {
 		d.cx = float64(x)
 		d.cy = float64(y)
@@ -54,7 +54,7 @@ func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	if btn.Left {
 		d.cx = float64(x)
 		d.cy = float64(y)
@@ -63,7 +63,7 @@ func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseUp(_, _ int, _ lowlevelrunner.Buttons) bool { return false }
+func (d *demo) OnMouseUp(_, _ int, _ app.Buttons) bool { return false }
 
 // drawLion renders the AGG lion demo into agg2d, centered in the canvas.
 func drawLion(agg2d *agg.Agg2D, width, height int) {
@@ -147,8 +147,8 @@ func applyBlurInsideEllipse(dst, src *agg.Image, cx, cy, rx, ry float64) {
 }
 
 func main() {
-	lowlevelrunner.Run(lowlevelrunner.Config{Title: "Simple Blur", Width: 512, Height: 400}, &demo{
+	app.New(app.Config{Title: "Simple Blur", Width: 512, Height: 400}, &demo{
 		cx: 100,
 		cy: 102,
-	})
+	}).Run()
 }
@@ -1,5 +1,7 @@
 // Package main demonstrates the gamma correction control widget.
-// This example shows how to use the GammaCtrl for interactive gamma curve editing.
+// Drag the two control points of the curve, or press 1-8 for the presets: the
+// curve is applied to the sample image on the right and, through the
+// rasterizer's gamma table, to the anti-aliased circles below the control.
 package main
 
 import (
@@ -8,10 +10,32 @@ import (
 	"image/color"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/demorunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/ctrl/gamma"
 )
 
+const (
+	frameWidth   = 600
+	frameHeight  = 400
+	sampleWidth  = 270
+	sampleHeight = 180
+)
+
+// presets are the selectable gamma curves, as kx1, ky1, kx2, ky2 values.
+var presets = []struct {
+	name               string
+	kx1, ky1, kx2, ky2 float64
+}{
+	{"Identity", 1.0, 1.0, 1.0, 1.0},
+	{"Brighten", 0.5, 1.5, 0.5, 1.5},
+	{"Darken", 1.5, 0.5, 1.5, 0.5},
+	{"High Contrast", 0.3, 1.8, 0.3, 1.8},
+	{"Low Contrast", 1.8, 0.3, 1.8, 0.3},
+	{"Custom 1", 0.8, 1.2, 1.2, 0.8},
+	{"Extreme Bright", 0.1, 1.9, 0.1, 1.9},
+	{"sRGB-like", 1.1, 0.9, 0.9, 1.1},
+}
+
 // createSampleImage creates a test image with gradients for gamma correction demonstration.
 func createSampleImage(width, height int) *image.RGBA {
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
@@ -79,51 +103,68 @@ func applyGammaCorrection(img *image.RGBA, gammaCtrl *gamma.GammaCtrl) *image.RG
 	return corrected
 }
 
-type demo struct{}
+type demo struct {
+	gc     *gamma.GammaCtrl
+	sample *image.RGBA
+	preset int
+}
 
-func (d *demo) Render(ctx *agg.Context) {
+func newDemo() *demo {
+	d := &demo{
+		gc:     gamma.NewGammaCtrl(10, 10, 300, 200, true),
+		sample: createSampleImage(sampleWidth, sampleHeight),
+	}
+	d.gc.SetTextSize(8.0, 0)
+	d.selectPreset(0)
+	return d
+}
+
+func (d *demo) selectPreset(i int) {
+	p := presets[i]
+	d.preset = i
+	d.gc.Values(p.kx1, p.ky1, p.kx2, p.ky2)
+}
+
+func (d *demo) Draw(ctx *agg.Context) {
 	ctx.Clear(agg.White)
+	a := ctx.GetAgg2D()
 
-	// Create a gamma control widget
-	gammaCtrl := gamma.NewGammaCtrl(10, 10, 300, 200, false)
-
-	// Create sample image
-	sampleImg := createSampleImage(400, 300)
-
-	// Test different gamma curves
-	testCases := []struct {
-		name               string
-		kx1, ky1, kx2, ky2 float64
-	}{
-		{"Identity", 1.0, 1.0, 1.0, 1.0},
-		{"Brighten", 0.5, 1.5, 0.5, 1.5},
-		{"Darken", 1.5, 0.5, 1.5, 0.5},
-		{"High Contrast", 0.3, 1.8, 0.3, 1.8},
-		{"Low Contrast", 1.8, 0.3, 1.8, 0.3},
-		{"Custom 1", 0.8, 1.2, 1.2, 0.8},
-		{"Extreme Bright", 0.1, 1.9, 0.1, 1.9},
-		{"sRGB-like", 1.1, 0.9, 0.9, 1.1},
+	if img, err := agg.NewImageFromStandardImage(applyGammaCorrection(d.sample, d.gc)); err == nil {
+		_ = ctx.DrawImage(img, 320, 10)
 	}
 
-	img := ctx.GetAgg2D()
-	img.FillColor(agg.Black)
-	img.TextAlignment(agg.AlignLeft, agg.AlignTop)
-
-	for i, test := range testCases {
-		gammaCtrl.Values(test.kx1, test.ky1, test.kx2, test.ky2)
-		corrected := applyGammaCorrection(sampleImg, gammaCtrl)
-		_ = corrected
+	kx1, ky1, kx2, ky2 := d.gc.GetValues()
+	ctx.SetColor(agg.Black)
+	_ = ctx.DrawText(fmt.Sprintf("%d. %s  (%.2f, %.2f, %.2f, %.2f)", d.preset+1, presets[d.preset].name, kx1, ky1, kx2, ky2), 10, 215)
+	_ = ctx.DrawText("Drag the curve points, 1-8 selects a preset", 10, 390)
+
+	// Thin circles show the curve acting on anti-aliasing coverage.
+	ras := a.GetInternalRasterizer()
+	ras.SetGammaTable(d.gc.Gamma())
+	// Reapplying the context gamma restores the rasterizer table afterwards.
+	defer a.AntiAliasGamma(a.GetAntiAliasGamma())
+	ctx.SetColor(agg.NewColor(0, 0, 0x66, 255))
+	for i, width := range []float64{2, 1, 0.5, 0.25} {
+		ctx.SetLineWidth(width)
+		ctx.DrawCircle(60+float64(i)*140, 300, 50)
+	}
+}
 
-		// Display test case info as text
-		text := fmt.Sprintf("%d. %s (%.1f,%.1f,%.1f,%.1f)", i+1, test.name, test.kx1, test.ky1, test.kx2, test.ky2)
-		img.Text(10, float64(20+i*18), text, false, 0, 0)
+func (d *demo) OnKey(key rune) bool {
+	if key >= '1' && int(key-'1') < len(presets) {
+		d.selectPreset(int(key - '1'))
+		return true
 	}
+	return false
 }
 
 func main() {
-	demorunner.Run(demorunner.Config{
+	d := newDemo()
+	a := app.New(app.Config{
 		Title:  "Gamma Correction Control",
-		Width:  600,
-		Height: 400,
-	}, &demo{})
+		Width:  frameWidth,
+		Height: frameHeight,
+	}, d)
+	a.AddControl(d.gc)
+	a.Run()
 }
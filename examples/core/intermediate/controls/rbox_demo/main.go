@@ -1,51 +1,35 @@
 // Radio Button Group (Rbox) Control Demo
 //
-// This example demonstrates the AGG radio button group control.
+// This example demonstrates the AGG radio button group control. Click an
+// item, or hover a group and use the arrow keys, to change the selection.
 package main
 
 import (
 	"fmt"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/demorunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/color"
 	"github.com/MeKo-Christian/agg_go/internal/ctrl/rbox"
 )
 
-type demo struct{}
+type demo struct {
+	colorRbox, qualityRbox *rbox.RboxCtrl[color.RGBA]
+}
 
-func (d *demo) Render(ctx *agg.Context) {
+func (d *demo) Draw(ctx *agg.Context) {
 	ctx.Clear(agg.White)
 
 	a := ctx.GetAgg2D()
 	a.FillColor(agg.Black)
 	a.TextAlignment(agg.AlignLeft, agg.AlignTop)
 
-	// Create some rbox controls and display their state
-	colorRbox := rbox.NewDefaultRboxCtrl(10, 10, 150, 120, false)
-	colorRbox.AddItem("Red")
-	colorRbox.AddItem("Green")
-	colorRbox.AddItem("Blue")
-	colorRbox.AddItem("Yellow")
-	colorRbox.SetCurItem(0)
-
-	qualityRbox := rbox.NewDefaultRboxCtrl(170, 10, 300, 100, false)
-	qualityRbox.AddItem("Low Quality")
-	qualityRbox.AddItem("Medium Quality")
-	qualityRbox.AddItem("High Quality")
-	qualityRbox.SetCurItem(1)
-	qualityRbox.SetBackgroundColor(color.NewRGBA(0.95, 0.95, 1.0, 1.0))
-	qualityRbox.SetBorderColor(color.NewRGBA(0.2, 0.2, 0.6, 1.0))
-	qualityRbox.SetTextColor(color.NewRGBA(0.1, 0.1, 0.4, 1.0))
-	qualityRbox.SetInactiveColor(color.NewRGBA(0.5, 0.5, 0.7, 1.0))
-	qualityRbox.SetActiveColor(color.NewRGBA(0.8, 0.2, 0.2, 1.0))
-
 	// Display info about the rboxes
 	lines := []string{
 		"Radio Button Group Demo",
 		"",
-		fmt.Sprintf("Color Group: %d items, selected: %d", colorRbox.NumItems(), colorRbox.CurItem()),
-		fmt.Sprintf("Quality Group: %d items, selected: %d", qualityRbox.NumItems(), qualityRbox.CurItem()),
+		fmt.Sprintf("Color Group: %d items, selected: %d", d.colorRbox.NumItems(), d.colorRbox.CurItem()),
+		fmt.Sprintf("Quality Group: %d items, selected: %d", d.qualityRbox.NumItems(), d.qualityRbox.CurItem()),
 	}
 
 	for i, line := range lines {
@@ -54,9 +38,32 @@ func (d *demo) Render(ctx *agg.Context) {
 }
 
 func main() {
-	demorunner.Run(demorunner.Config{
+	d := &demo{}
+	a := app.New(app.Config{
 		Title:  "Radio Button (Rbox) Demo",
 		Width:  400,
 		Height: 300,
-	}, &demo{})
+	}, d)
+
+	d.colorRbox = rbox.NewDefaultRboxCtrl(10, 10, 150, 120, true)
+	d.colorRbox.AddItem("Red")
+	d.colorRbox.AddItem("Green")
+	d.colorRbox.AddItem("Blue")
+	d.colorRbox.AddItem("Yellow")
+	d.colorRbox.SetCurItem(0)
+	a.AddControl(d.colorRbox)
+
+	d.qualityRbox = rbox.NewDefaultRboxCtrl(170, 10, 300, 100, true)
+	d.qualityRbox.AddItem("Low Quality")
+	d.qualityRbox.AddItem("Medium Quality")
+	d.qualityRbox.AddItem("High Quality")
+	d.qualityRbox.SetCurItem(1)
+	d.qualityRbox.SetBackgroundColor(color.NewRGBA(0.95, 0.95, 1.0, 1.0))
+	d.qualityRbox.SetBorderColor(color.NewRGBA(0.2, 0.2, 0.6, 1.0))
+	d.qualityRbox.SetTextColor(color.NewRGBA(0.1, 0.1, 0.4, 1.0))
+	d.qualityRbox.SetInactiveColor(color.NewRGBA(0.5, 0.5, 0.7, 1.0))
+	d.qualityRbox.SetActiveColor(color.NewRGBA(0.8, 0.2, 0.2, 1.0))
+	a.AddControl(d.qualityRbox)
+
+	a.Run()
 }
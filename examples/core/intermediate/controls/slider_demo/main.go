@@ -1,52 +1,37 @@
 // Slider Control Demo
 //
 // This example demonstrates the AGG slider control with various configurations.
+// The sliders are live: drag a pointer, or hover a slider and use the arrow
+// keys.
 package main
 
 import (
 	"fmt"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/demorunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/color"
 	"github.com/MeKo-Christian/agg_go/internal/ctrl/slider"
 )
 
-type demo struct{}
+type demo struct {
+	basic, temp, volume *slider.SliderCtrl
+}
 
-func (d *demo) Render(ctx *agg.Context) {
+func (d *demo) Draw(ctx *agg.Context) {
 	ctx.Clear(agg.White)
 
 	a := ctx.GetAgg2D()
 	a.FillColor(agg.Black)
 	a.TextAlignment(agg.AlignLeft, agg.AlignTop)
 
-	// Create slider configurations
-	basic := slider.NewSliderCtrl(10, 10, 210, 30, false)
-	basic.SetRange(0, 100)
-	basic.SetValue(50)
-	basic.SetLabel("Basic: %.0f")
-
-	temp := slider.NewSliderCtrl(10, 40, 210, 60, false)
-	temp.SetRange(-10, 40)
-	temp.SetValue(20)
-	temp.SetLabel("Temperature: %.1f°C")
-	temp.SetPointerColor(color.NewRGBA(0.8, 0.3, 0.3, 1.0))
-
-	volume := slider.NewSliderCtrl(10, 70, 210, 90, false)
-	volume.SetRange(0, 10)
-	volume.SetNumSteps(10)
-	volume.SetValue(7)
-	volume.SetLabel("Volume: %.0f")
-	volume.SetPointerColor(color.NewRGBA(0.3, 0.8, 0.3, 1.0))
-
 	// Display info
 	lines := []string{
 		"Slider Control Demo",
 		"",
-		fmt.Sprintf("Basic slider: value=%.1f, paths=%d", basic.Value(), basic.NumPaths()),
-		fmt.Sprintf("Temperature slider: value=%.1f", temp.Value()),
-		fmt.Sprintf("Volume slider: value=%.1f, steps=%d", volume.Value(), 10),
+		fmt.Sprintf("Basic slider: value=%.1f, paths=%d", d.basic.Value(), d.basic.NumPaths()),
+		fmt.Sprintf("Temperature slider: value=%.1f", d.temp.Value()),
+		fmt.Sprintf("Volume slider: value=%.1f, steps=%d", d.volume.Value(), 10),
 	}
 
 	for i, line := range lines {
@@ -55,9 +40,19 @@ func (d *demo) Render(ctx *agg.Context) {
 }
 
 func main() {
-	demorunner.Run(demorunner.Config{
+	d := &demo{}
+	a := app.New(app.Config{
 		Title:  "Slider Demo",
 		Width:  400,
 		Height: 300,
-	}, &demo{})
+	}, d)
+
+	d.basic = a.AddSlider(10, 10, 210, 30, "Basic: %.0f", 0, 100, 50)
+	d.temp = a.AddSlider(10, 40, 210, 60, "Temperature: %.1f°C", -10, 40, 20)
+	d.temp.SetPointerColor(color.NewRGBA(0.8, 0.3, 0.3, 1.0))
+	d.volume = a.AddSlider(10, 70, 210, 90, "Volume: %.0f", 0, 10, 7)
+	d.volume.SetNumSteps(10)
+	d.volume.SetPointerColor(color.NewRGBA(0.3, 0.8, 0.3, 1.0))
+
+	a.Run()
 }
@@ -1,34 +1,24 @@
 // Package main demonstrates the spline control implementation.
-// This example shows how to create and interact with a spline curve editor.
+// Drag the control points to edit the curve; the sampled values below
+// follow the edit.
 package main
 
 import (
 	"fmt"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/demorunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/color"
 	"github.com/MeKo-Christian/agg_go/internal/ctrl/spline"
 )
 
-type demo struct{}
+type demo struct {
+	ctrl *spline.SplineCtrl[color.RGBA]
+}
 
-func (d *demo) Render(ctx *agg.Context) {
+func (d *demo) Draw(ctx *agg.Context) {
 	ctx.Clear(agg.White)
 
-	// Create a spline control with RGBA colors
-	ctrl := spline.NewSplineCtrlRGBA(10, 10, 300, 200, 6, false)
-
-	// Set up some initial control points for a nice curve
-	ctrl.SetPoint(1, 0.2, 0.8) // High peak near start
-	ctrl.SetPoint(2, 0.4, 0.3) // Dip in middle
-	ctrl.SetPoint(3, 0.6, 0.7) // Another peak
-	ctrl.SetPoint(4, 0.8, 0.2) // Low end
-
-	// Demonstrate color customization
-	ctrl.SetCurveColor(color.NewRGBA(0.0, 0.8, 0.0, 1.0))       // Green curve
-	ctrl.SetActivePointColor(color.NewRGBA(0.8, 0.4, 0.0, 1.0)) // Orange active point
-
 	// Display info
 	a := ctx.GetAgg2D()
 	a.FillColor(agg.Black)
@@ -36,7 +26,7 @@ func (d *demo) Render(ctx *agg.Context) {
 
 	lines := []string{
 		"Spline Control Demo",
-		fmt.Sprintf("Control bounds: (%.0f,%.0f) to (%.0f,%.0f)", ctrl.X1(), ctrl.Y1(), ctrl.X2(), ctrl.Y2()),
+		fmt.Sprintf("Control bounds: (%.0f,%.0f) to (%.0f,%.0f)", d.ctrl.X1(), d.ctrl.Y1(), d.ctrl.X2(), d.ctrl.Y2()),
 		"Spline values:",
 	}
 	for i, line := range lines {
@@ -45,15 +35,30 @@ func (d *demo) Render(ctx *agg.Context) {
 
 	for i := 0; i <= 5; i++ {
 		x := float64(i) / 5.0
-		y := ctrl.Value(x)
+		y := d.ctrl.Value(x)
 		a.Text(10, float64(220+len(lines)*18+i*18), fmt.Sprintf("  x=%.1f -> y=%.3f", x, y), false, 0, 0)
 	}
 }
 
 func main() {
-	demorunner.Run(demorunner.Config{
+	// Create a spline control with RGBA colors
+	ctrl := spline.NewSplineCtrlRGBA(10, 10, 300, 200, 6, true)
+
+	// Set up some initial control points for a nice curve
+	ctrl.SetPoint(1, 0.2, 0.8) // High peak near start
+	ctrl.SetPoint(2, 0.4, 0.3) // Dip in middle
+	ctrl.SetPoint(3, 0.6, 0.7) // Another peak
+	ctrl.SetPoint(4, 0.8, 0.2) // Low end
+
+	// Demonstrate color customization
+	ctrl.SetCurveColor(color.NewRGBA(0.0, 0.8, 0.0, 1.0))       // Green curve
+	ctrl.SetActivePointColor(color.NewRGBA(0.8, 0.4, 0.0, 1.0)) // Orange active point
+
+	a := app.New(app.Config{
 		Title:  "Spline Demo",
 		Width:  400,
 		Height: 400,
-	}, &demo{})
+	}, &demo{ctrl: ctrl})
+	a.AddControl(ctrl)
+	a.Run()
 }
@@ -5,7 +5,7 @@ import (
 	"math"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/demo/gpctest"
 )
 
@@ -14,8 +14,7 @@ type demo struct {
 	cy float64
 }
 
-func (d *demo) Render(img *agg.Image) {
-	ctx := agg.NewContextForImage(img)
+func (d *demo) Draw(ctx *agg.Context) {
 	gpctest.Draw(ctx, gpctest.Config{
 		Scene:     3,
 		Operation: 2,
@@ -24,7 +23,7 @@ func (d *demo) Render(img *agg.Image) {
 	})
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if !btn.Left {
 		return false
 	}
@@ -32,7 +31,7 @@ func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
 	return true
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	if !btn.Left {
 		return false
 	}
@@ -40,15 +39,15 @@ func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
 	return true
 }
 
-func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseUp(x, y int, btn app.Buttons) bool {
 	return false
 }
 
 func main() {
 	d := &demo{cx: math.NaN(), cy: math.NaN()}
-	lowlevelrunner.Run(lowlevelrunner.Config{
+	app.New(app.Config{
 		Title:  "GPC Test",
 		Width:  640,
 		Height: 520,
-	}, d)
+	}, d).Run()
 }
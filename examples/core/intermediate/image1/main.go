@@ -6,12 +6,8 @@
 package main
 
 import (
-	"bytes"
-	"errors"
 	"math"
-	"os"
 	"path/filepath"
-	"strconv"
 
 	agg "github.com/MeKo-Christian/agg_go"
 	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
@@ -22,6 +18,7 @@ import (
 	ctrlbase "github.com/MeKo-Christian/agg_go/internal/ctrl"
 	sliderctrl "github.com/MeKo-Christian/agg_go/internal/ctrl/slider"
 	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
+	"github.com/MeKo-Christian/agg_go/internal/platform"
 	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
 	"github.com/MeKo-Christian/agg_go/internal/renderer"
 	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
@@ -33,87 +30,15 @@ import (
 
 const defaultImageName = "spheres"
 
-func loadPPMImage(filename string) (*agg.Image, error) {
-	data, err := os.ReadFile(filename)
-	if err != nil {
-		return nil, err
-	}
-	if len(data) < 2 || data[0] != 'P' || data[1] != '6' {
-		return nil, errors.New("unsupported ppm format: expected P6")
-	}
-
-	i := 2
-	readToken := func() (string, error) {
-		for i < len(data) {
-			b := data[i]
-			if b == '#' {
-				for i < len(data) && data[i] != '\n' {
-					i++
-				}
-				continue
-			}
-			if bytes.IndexByte([]byte{' ', '\t', '\n', '\r'}, b) >= 0 {
-				i++
-				continue
-			}
-			break
-		}
-		if i >= len(data) {
-			return "", errors.New("unexpected end of ppm header")
-		}
-		start := i
-		for i < len(data) {
-			b := data[i]
-			if bytes.IndexByte([]byte{' ', '\t', '\n', '\r', '#'}, b) >= 0 {
-				break
-			}
-			i++
-		}
-		return string(data[start:i]), nil
-	}
-
-	wTok, err := readToken()
-	if err != nil {
-		return nil, err
-	}
-	hTok, err := readToken()
-	if err != nil {
-		return nil, err
-	}
-	maxTok, err := readToken()
-	if err != nil {
+// loadImage loads an image through image slot 0 of the platform layer, like
+// app.load_img(0, "spheres") in the C++ demo.
+func loadImage(filename string) (*agg.Image, error) {
+	ps := platform.NewPlatformSupport(platform.PixelFormatRGBA32, false)
+	if err := ps.LoadImageFile(0, filename); err != nil {
 		return nil, err
 	}
-	w, err := strconv.Atoi(wTok)
-	if err != nil || w <= 0 {
-		return nil, errors.New("invalid ppm width")
-	}
-	h, err := strconv.Atoi(hTok)
-	if err != nil || h <= 0 {
-		return nil, errors.New("invalid ppm height")
-	}
-	maxV, err := strconv.Atoi(maxTok)
-	if err != nil || maxV != 255 {
-		return nil, errors.New("unsupported ppm max value")
-	}
-
-	for i < len(data) && (data[i] == ' ' || data[i] == '\t' || data[i] == '\n' || data[i] == '\r') {
-		i++
-	}
-	rgb := data[i:]
-	if len(rgb) < w*h*3 {
-		return nil, errors.New("ppm pixel data too short")
-	}
-
-	buf := make([]uint8, w*h*4)
-	for p := 0; p < w*h; p++ {
-		buf[p*4+0] = rgb[p*3+0]
-		buf[p*4+1] = rgb[p*3+1]
-		buf[p*4+2] = rgb[p*3+2]
-		buf[p*4+3] = 255
-	}
-
-	return agg.NewImage(buf, w, h, w*4), nil
+	rbuf := ps.ImageBuffer(0)
+	return agg.NewImage(rbuf.Buf(), rbuf.Width(), rbuf.Height(), rbuf.Stride()), nil
 }
 
 type demo struct {
@@ -318,7 +243,7 @@ func main() {
 	// Paths to look for spheres.ppm
 	srcPath := filepath.Join("examples", "shared", "art", defaultImageName+".ppm")
 
-	srcImg, err := loadPPMImage(srcPath)
+	srcImg, err := loadImage(srcPath)
 	if err != nil {
 		panic(err)
 	}
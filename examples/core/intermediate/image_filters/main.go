@@ -387,7 +387,7 @@ func drawControls(ctx *agg.Context, st filterState) {
 	renderCtrl(a, ras, refresh)
 }
 
-func renderCtrl(a *agg.Agg2D, ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip], c ctrlbase.Ctrl[icol.RGBA]) {
+func renderCtrl(a *agg.Agg2D, ras *agg.InternalRasterizer, c ctrlbase.Ctrl[icol.RGBA]) {
 	for pathID := uint(0); pathID < c.NumPaths(); pathID++ {
 		ras.Reset()
 		ras.AddPath(&ctrlVertexSource{ctrl: c}, uint32(pathID))
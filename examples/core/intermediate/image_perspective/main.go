@@ -6,7 +6,7 @@ import (
 	"math"
 
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/demo/imageperspective"
 )
 
@@ -18,15 +18,14 @@ type demo struct {
 
 const handleRadius = 8.0
 
-func (d *demo) Render(img *agg.Image) {
-	ctx := agg.NewContextForImage(img)
+func (d *demo) Draw(ctx *agg.Context) {
 	imageperspective.Draw(ctx, imageperspective.Config{
 		Mode: d.mode,
 		Quad: d.quad,
 	})
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if !btn.Left {
 		return false
 	}
@@ -42,7 +41,7 @@ func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseUp(x, y int, btn app.Buttons) bool {
 	if d.dragIdx >= 0 {
 		d.dragIdx = -1
 		return true
@@ -50,7 +49,7 @@ func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
 	return false
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	if d.dragIdx < 0 || !btn.Left {
 		return false
 	}
@@ -68,9 +67,9 @@ func main() {
 		dragIdx: -1,
 	}
 
-	lowlevelrunner.Run(lowlevelrunner.Config{
+	app.New(app.Config{
 		Title:  "Image Perspective",
 		Width:  600,
 		Height: 600,
-	}, d)
+	}, d).Run()
 }
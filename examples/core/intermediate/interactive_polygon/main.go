@@ -3,7 +3,7 @@ package main
 
 import (
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/demo/interactivepolygon"
 )
 
@@ -11,23 +11,22 @@ type demo struct {
 	state *interactivepolygon.State
 }
 
-func (d *demo) Render(img *agg.Image) {
-	ctx := agg.NewContextForImage(img)
+func (d *demo) Draw(ctx *agg.Context) {
 	d.state.Draw(ctx)
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if !btn.Left {
 		return false
 	}
 	return d.state.MouseDown(float64(x), float64(y))
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	return d.state.MouseMove(float64(x), float64(y), btn.Left)
 }
 
-func (d *demo) OnMouseUp(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseUp(x, y int, btn app.Buttons) bool {
 	return d.state.MouseUp(float64(x), float64(y))
 }
 
@@ -38,9 +37,9 @@ func main() {
 	)
 
 	d := &demo{state: interactivepolygon.NewState(w, h)}
-	lowlevelrunner.Run(lowlevelrunner.Config{
+	app.New(app.Config{
 		Title:  "Interactive Polygon",
 		Width:  w,
 		Height: h,
-	}, d)
+	}, d).Run()
 }
@@ -3,7 +3,7 @@ package main
 
 import (
 	agg "github.com/MeKo-Christian/agg_go"
-	"github.com/MeKo-Christian/agg_go/examples/shared/lowlevelrunner"
+	"github.com/MeKo-Christian/agg_go/examples/shared/app"
 	"github.com/MeKo-Christian/agg_go/internal/demo/molview"
 )
 
@@ -16,13 +16,17 @@ func newDemo() *demo {
 	return &demo{state: molview.DefaultState()}
 }
 
-func (d *demo) Render(img *agg.Image) {
-	ctx := agg.NewContextForImage(img)
-	d.state.Advance()
+func (d *demo) Draw(ctx *agg.Context) {
 	molview.Draw(ctx, d.state)
 }
 
-func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
+// Update turns the molecule by one step per frame, like AGG's on_idle.
+func (d *demo) Update(float64) bool {
+	d.state.Advance()
+	return d.state.AutoRotate
+}
+
+func (d *demo) OnMouseDown(x, y int, btn app.Buttons) bool {
 	if !btn.Left && !btn.Right {
 		return false
 	}
@@ -30,14 +34,14 @@ func (d *demo) OnMouseDown(x, y int, btn lowlevelrunner.Buttons) bool {
 	return true
 }
 
-func (d *demo) OnMouseMove(x, y int, btn lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseMove(x, y int, btn app.Buttons) bool {
 	if !btn.Left && !btn.Right {
 		return false
 	}
 	return molview.UpdateDrag(&d.state, &d.drag, float64(x), float64(y), btn.Right)
 }
 
-func (d *demo) OnMouseUp(_, _ int, _ lowlevelrunner.Buttons) bool {
+func (d *demo) OnMouseUp(_, _ int, _ app.Buttons) bool {
 	molview.EndDrag(&d.drag)
 	return true
 }
@@ -56,14 +60,10 @@ func (d *demo) OnKey(key rune) bool {
 	return true
 }
 
-func (d *demo) IsAnimated() bool {
-	return d.state.AutoRotate
-}
-
 func main() {
-	lowlevelrunner.Run(lowlevelrunner.Config{
+	app.New(app.Config{
 		Title:  "Mol View",
 		Width:  400,
 		Height: 400,
-	}, newDemo())
+	}, newDemo()).Run()
 }
@@ -57,12 +57,36 @@ func loadSystemFont(agg2d *agg.Agg2D) error {
 			continue
 		}
 		fmt.Printf("Successfully loaded font: %s\n", filepath.Base(fontPath))
+		addFallbackFonts(agg2d)
 		return nil
 	}
 
 	return fmt.Errorf("no suitable fonts found in common system locations")
 }
 
+// addFallbackFonts registers CJK and emoji fonts, when installed, so the
+// Unicode examples render glyphs the primary font does not cover.
+func addFallbackFonts(agg2d *agg.Agg2D) {
+	fallbackPaths := []string{
+		"/usr/share/fonts/opentype/noto/NotoSansCJK-Regular.ttc",     // Debian/Ubuntu
+		"/usr/share/fonts/noto-cjk/NotoSansCJK-Regular.ttc",          // Arch Linux
+		"/System/Library/Fonts/Hiragino Sans GB.ttc",                 // macOS
+		"C:\\Windows\\Fonts\\msyh.ttc",                               // Windows
+		"/usr/share/fonts/truetype/noto/NotoEmoji-Regular.ttf",       // Linux
+		"/usr/share/fonts/truetype/ancient-scripts/Symbola_hint.ttf", // Linux
+	}
+	for _, fontPath := range fallbackPaths {
+		if _, err := os.Stat(fontPath); err != nil {
+			continue
+		}
+		if err := agg2d.AddFallbackFont(fontPath); err != nil {
+			fmt.Printf("Failed to add fallback font %s: %v\n", fontPath, err)
+			continue
+		}
+		fmt.Printf("Added fallback font: %s\n", filepath.Base(fontPath))
+	}
+}
+
 // renderTextExamples renders various text examples to demonstrate functionality.
 func renderTextExamples(agg2d *agg.Agg2D, width, height int) {
 	// Set text color to black
@@ -0,0 +1,286 @@
+// Package app provides the standard AGG demo shell: a window with the
+// scene, a panel of AGG controls (sliders, checkboxes, ...), an optional debug
+// overlay and a screenshot key, with the backend picked by command-line flags.
+//
+// A demo describes its scene and controls and hands them to Run:
+//
+//	a := app.New(app.Config{Title: "Gamma", Width: 400, Height: 300}, scene)
+//	scene.gamma = a.AddSlider(10, 10, 390, 20, "Gamma=%.2f", 0.1, 3, 1)
+//	a.Run()
+//
+// Controls receive mouse and arrow-key input before the scene, so they are
+// interactive without any code in the demo. The scene only implements Draw
+// plus whichever optional handler interfaces it needs.
+//
+// Flags (parsed by Run unless the program parsed them already):
+//
+//	-backend auto|sdl2|x11|headless  window backend (default auto)
+//	-o file.png                      headless output file
+//	-fps                             show the frame rate
+//
+// Without a windowing backend in the build (no x11/sdl2 tag) or with
+// -backend=headless, Run renders one frame to a PNG and exits. In a window,
+// S saves a screenshot, F11 toggles fullscreen and Escape quits.
+package app
+
+import (
+	"strings"
+	"time"
+
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/examples/shared/debughud"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/ctrl"
+	"github.com/MeKo-Christian/agg_go/internal/ctrl/checkbox"
+	"github.com/MeKo-Christian/agg_go/internal/ctrl/slider"
+)
+
+// Config holds the window title and initial size.
+type Config struct {
+	Title  string
+	Width  int
+	Height int
+}
+
+// Scene is the part of a demo every shell needs.
+type Scene interface {
+	// Draw renders one complete frame into ctx. ctx is reused across frames
+	// and is recreated on resize; the scene clears it on each call. Controls
+	// are drawn on top afterwards.
+	Draw(ctx *agg.Context)
+}
+
+// InitHandler is an optional extension for scenes that need one-time setup.
+type InitHandler interface {
+	OnInit()
+}
+
+// Updater is an optional extension for animated scenes. Update is called
+// between frames with the elapsed time in seconds; returning true requests
+// another frame. Scenes that return false are redrawn on input only.
+type Updater interface {
+	Update(dt float64) bool
+}
+
+// Buttons reports which mouse buttons are held.
+type Buttons struct {
+	Left, Right bool
+}
+
+// MouseHandler is an optional extension for scenes that respond to the
+// mouse. Events a control consumed are not forwarded. Return true to redraw.
+type MouseHandler interface {
+	OnMouseMove(x, y int, btn Buttons) bool
+	OnMouseDown(x, y int, btn Buttons) bool
+	OnMouseUp(x, y int, btn Buttons) bool
+}
+
+// WheelHandler is an optional extension for scenes that zoom or scroll. dy
+// is positive when scrolling away from the user; one notch is 1. Return true
+// to redraw.
+type WheelHandler interface {
+	OnMouseWheel(x, y int, dx, dy float64) bool
+}
+
+// DoubleClickHandler is an optional extension for scenes that respond to
+// double-clicks, e.g. to reset the view. Return true to redraw.
+type DoubleClickHandler interface {
+	OnDoubleClick(x, y int, btn Buttons) bool
+}
+
+// KeyHandler is an optional extension for scenes that respond to keys. The
+// shell's own keys (Escape, S, F11) and arrow keys used by a control are not
+// forwarded. Return true to redraw.
+type KeyHandler interface {
+	OnKey(key rune) bool
+}
+
+// ControlChangeHandler is an optional extension called after user input
+// changed a control, like on_ctrl_change in AGG's platform_support.
+type ControlChangeHandler interface {
+	OnControlChange()
+}
+
+// Control is an AGG control the shell can draw and route input to.
+type Control = ctrl.Ctrl[color.RGBA]
+
+// App is a demo shell. Create it with New, add controls, then call Run.
+type App struct {
+	cfg      Config
+	scene    Scene
+	ctx      *agg.Context
+	controls []Control
+	hud      *debughud.HUD
+	showFPS  bool
+	last     time.Time
+}
+
+// New creates a shell for scene with an initial canvas of cfg's size.
+func New(cfg Config, scene Scene) *App {
+	return &App{
+		cfg:   cfg,
+		scene: scene,
+		ctx:   agg.NewContext(cfg.Width, cfg.Height),
+	}
+}
+
+// Context returns the canvas the scene draws into.
+func (a *App) Context() *agg.Context {
+	return a.ctx
+}
+
+// AddControl adds c to the control panel. Controls are drawn in the order
+// they were added and take input in reverse order, so the topmost wins.
+func (a *App) AddControl(c Control) {
+	a.controls = append(a.controls, c)
+}
+
+// AddSlider adds a slider spanning (x1, y1)-(x2, y2) in window coordinates.
+// label is a printf format for the value, e.g. "Gamma=%.2f".
+//
+// Controls are created with flipY set: AGG lays them out y-up, and flipping
+// inside the control box keeps their text upright on the y-down canvas.
+func (a *App) AddSlider(x1, y1, x2, y2 float64, label string, minValue, maxValue, value float64) *slider.SliderCtrl {
+	s := slider.NewSliderCtrl(x1, y1, x2, y2, true)
+	s.SetRange(minValue, maxValue)
+	s.SetValue(value)
+	s.SetLabel(label)
+	a.AddControl(s)
+	return s
+}
+
+// AddCheckbox adds a checkbox with its top-left corner at (x, y).
+func (a *App) AddCheckbox(x, y float64, label string, checked bool) *checkbox.CheckboxCtrl[color.RGBA] {
+	c := checkbox.NewDefaultCheckboxCtrl(x, y, label, true)
+	c.SetChecked(checked)
+	a.AddControl(c)
+	return c
+}
+
+// resize replaces the canvas after the window changed size.
+func (a *App) resize(width, height int) {
+	a.ctx = agg.NewContext(width, height)
+}
+
+// update advances an animated scene and reports whether it wants another
+// frame.
+func (a *App) update(now time.Time) bool {
+	u, ok := a.scene.(Updater)
+	if !ok {
+		return false
+	}
+	dt := 0.0
+	if !a.last.IsZero() {
+		dt = now.Sub(a.last).Seconds()
+	}
+	a.last = now
+	return u.Update(dt)
+}
+
+// render draws one frame: the scene, the controls and the FPS overlay.
+func (a *App) render(now time.Time) {
+	a.scene.Draw(a.ctx)
+	for _, c := range a.controls {
+		drawControl(a.ctx, c)
+	}
+	if a.showFPS {
+		if a.hud == nil {
+			a.hud = debughud.New()
+		}
+		a.hud.Tick(now)
+		w, _ := a.hud.Size()
+		a.hud.X = float64(a.ctx.Width()) - w - 8
+		a.hud.Draw(a.ctx)
+	}
+}
+
+// mouseDown offers a press to the controls, then to the scene, and reports
+// whether a redraw is needed.
+func (a *App) mouseDown(x, y int, btn Buttons) bool {
+	if btn.Left {
+		for i := len(a.controls) - 1; i >= 0; i-- {
+			if a.controls[i].OnMouseButtonDown(float64(x), float64(y)) {
+				a.controlChanged()
+				return true
+			}
+		}
+	}
+	if m, ok := a.scene.(MouseHandler); ok {
+		return m.OnMouseDown(x, y, btn)
+	}
+	return false
+}
+
+func (a *App) mouseMove(x, y int, btn Buttons) bool {
+	for i := len(a.controls) - 1; i >= 0; i-- {
+		if a.controls[i].OnMouseMove(float64(x), float64(y), btn.Left) {
+			a.controlChanged()
+			return true
+		}
+	}
+	if m, ok := a.scene.(MouseHandler); ok {
+		return m.OnMouseMove(x, y, btn)
+	}
+	return false
+}
+
+func (a *App) mouseUp(x, y int, btn Buttons) bool {
+	redraw := false
+	for _, c := range a.controls {
+		if c.OnMouseButtonUp(float64(x), float64(y)) {
+			redraw = true
+		}
+	}
+	if redraw {
+		a.controlChanged()
+	}
+	if m, ok := a.scene.(MouseHandler); ok && m.OnMouseUp(x, y, btn) {
+		redraw = true
+	}
+	return redraw
+}
+
+func (a *App) wheel(x, y int, dx, dy float64) bool {
+	if w, ok := a.scene.(WheelHandler); ok {
+		return w.OnMouseWheel(x, y, dx, dy)
+	}
+	return false
+}
+
+func (a *App) doubleClick(x, y int, btn Buttons) bool {
+	if d, ok := a.scene.(DoubleClickHandler); ok {
+		return d.OnDoubleClick(x, y, btn)
+	}
+	return false
+}
+
+// arrowKeys offers an arrow key to the controls under the mouse pointer, as
+// AGG's ctrl_container does, and reports whether one used it.
+func (a *App) arrowKeys(mouseX, mouseY int, left, right, down, up bool) bool {
+	for i := len(a.controls) - 1; i >= 0; i-- {
+		c := a.controls[i]
+		if c.InRect(float64(mouseX), float64(mouseY)) && c.OnArrowKeys(left, right, down, up) {
+			a.controlChanged()
+			return true
+		}
+	}
+	return false
+}
+
+func (a *App) key(r rune) bool {
+	if k, ok := a.scene.(KeyHandler); ok {
+		return k.OnKey(r)
+	}
+	return false
+}
+
+func (a *App) controlChanged() {
+	if h, ok := a.scene.(ControlChangeHandler); ok {
+		h.OnControlChange()
+	}
+}
+
+// screenshotName derives the PNG file name from the title.
+func (a *App) screenshotName() string {
+	return strings.ReplaceAll(strings.ToLower(a.cfg.Title), " ", "_") + ".png"
+}
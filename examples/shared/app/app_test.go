@@ -0,0 +1,165 @@
+package app
+
+import (
+	"flag"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/internal/platform"
+)
+
+// recordScene counts what the shell routes to it.
+type recordScene struct {
+	fill                     agg.Color
+	draws, downs, moves, ups int
+	changes                  int
+	lastDown                 Buttons
+	keys                     []rune
+}
+
+func (s *recordScene) Draw(ctx *agg.Context) {
+	s.draws++
+	ctx.Clear(s.fill)
+}
+
+func (s *recordScene) OnMouseDown(_, _ int, btn Buttons) bool {
+	s.downs++
+	s.lastDown = btn
+	return true
+}
+
+func (s *recordScene) OnMouseMove(_, _ int, _ Buttons) bool {
+	s.moves++
+	return true
+}
+
+func (s *recordScene) OnMouseUp(_, _ int, _ Buttons) bool {
+	s.ups++
+	return true
+}
+
+func (s *recordScene) OnKey(key rune) bool {
+	s.keys = append(s.keys, key)
+	return true
+}
+
+func (s *recordScene) OnControlChange() { s.changes++ }
+
+// newTestHandler opens a on the mock backend, which draws synchronously on
+// every redraw request.
+func newTestHandler(t *testing.T, a *App) *windowHandler {
+	t.Helper()
+	backend := platform.NewMockBackend(platform.PixelFormatRGBA32, false)
+	h, err := newWindowHandler(a, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = backend.Destroy() })
+	return h
+}
+
+func TestWindowMouseRouting(t *testing.T) {
+	scene := &recordScene{fill: agg.White}
+	a := New(Config{Title: "Routing", Width: 200, Height: 100}, scene)
+	s := a.AddSlider(10, 10, 190, 30, "%.0f", 0, 100, 50)
+	h := newTestHandler(t, a)
+
+	// Dragging the slider pointer is consumed by the control.
+	draws := scene.draws
+	h.OnMouseButtonDown(100, 20, platform.MouseLeft)
+	h.OnMouseMove(190, 20, platform.MouseLeft)
+	if scene.downs != 0 || scene.moves != 0 {
+		t.Errorf("scene got %d downs and %d moves on the slider, want none", scene.downs, scene.moves)
+	}
+	h.OnMouseButtonUp(190, 20, 0)
+	if s.Value() != 100 {
+		t.Errorf("slider value %v after drag, want 100", s.Value())
+	}
+	if scene.changes != 3 {
+		t.Errorf("%d control changes, want 3", scene.changes)
+	}
+	if scene.draws != draws+3 {
+		t.Errorf("%d redraws for the drag, want 3", scene.draws-draws)
+	}
+
+	// Releases always reach the scene too.
+	if scene.ups != 1 {
+		t.Errorf("scene got %d ups, want 1", scene.ups)
+	}
+
+	// Presses outside the controls go to the scene.
+	h.OnMouseButtonDown(100, 80, platform.MouseLeft)
+	h.OnMouseMove(110, 80, platform.MouseLeft)
+	h.OnMouseButtonUp(110, 80, 0)
+	if scene.downs != 1 || scene.moves != 1 || scene.ups != 2 {
+		t.Errorf("scene got %d/%d/%d down/move/up, want 1/1/2", scene.downs, scene.moves, scene.ups)
+	}
+
+	// Controls only take the left button.
+	h.OnMouseButtonDown(100, 20, platform.MouseRight)
+	if scene.downs != 2 || !scene.lastDown.Right {
+		t.Errorf("right press on the slider not forwarded to the scene")
+	}
+}
+
+func TestWindowKeys(t *testing.T) {
+	t.Chdir(t.TempDir())
+	scene := &recordScene{fill: agg.White}
+	a := New(Config{Title: "Key Test", Width: 40, Height: 30}, scene)
+	h := newTestHandler(t, a)
+
+	h.OnKey(0, 0, platform.KeyCode('s'), 0)
+	if _, err := os.Stat("key_test.png"); err != nil {
+		t.Errorf("S did not save a screenshot: %v", err)
+	}
+
+	h.OnKey(0, 0, platform.KeyCode('x'), 0)
+	if len(scene.keys) != 1 || scene.keys[0] != 'x' {
+		t.Errorf("scene got keys %q, want only 'x'", scene.keys)
+	}
+
+	h.OnKey(0, 0, platform.KeyEscape, 0)
+	if h.running {
+		t.Error("Escape did not stop the event loop")
+	}
+	if len(scene.keys) != 1 {
+		t.Errorf("shell keys forwarded to the scene: %q", scene.keys)
+	}
+}
+
+func TestRunHeadlessOutput(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "frame.png")
+	for name, value := range map[string]string{"backend": "headless", "o": out} {
+		old := flag.Lookup(name).Value.String()
+		if err := flag.Set(name, value); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = flag.Set(name, old) })
+	}
+
+	scene := &recordScene{fill: agg.Red}
+	a := New(Config{Title: "Headless", Width: 32, Height: 24}, scene)
+	a.Run()
+
+	if scene.draws != 1 {
+		t.Errorf("%d frames rendered, want 1", scene.draws)
+	}
+	f, err := os.Open(out)
+	if err != nil {
+		t.Fatalf("-o file not written: %v", err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 32 || b.Dy() != 24 {
+		t.Errorf("output is %v, want 32x24", b)
+	}
+	if r, g, b, _ := img.At(16, 12).RGBA(); r>>8 != 255 || g>>8 != 0 || b>>8 != 0 {
+		t.Errorf("output pixel is (%d, %d, %d), want red", r>>8, g>>8, b>>8)
+	}
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+)
+
+// vertexSource is what AGG controls and the GSV text renderer emit.
+type vertexSource interface {
+	Rewind(pathID uint)
+	Vertex() (x, y float64, cmd basics.PathCommand)
+}
+
+// fillVertexSource fills path pathID of vs in device coordinates, ignoring
+// the scene's transform, with the non-zero rule.
+func fillVertexSource(ctx *agg.Context, vs vertexSource, pathID uint, c agg.Color) {
+	a := ctx.GetAgg2D()
+	a.ResetPath()
+	vs.Rewind(pathID)
+	for {
+		x, y, cmd := vs.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		// Controls mark closed polygons either with an end_poly command or
+		// with the close flag on their last line_to.
+		switch base := cmd & basics.PathCommand(basics.PathCmdMask); {
+		case basics.IsMoveTo(base):
+			a.MoveTo(x, y)
+		case basics.IsVertex(base):
+			a.LineTo(x, y)
+		}
+		if basics.IsClosed(uint32(cmd)) {
+			a.ClosePolygon()
+		}
+	}
+	a.FillEvenOdd(false)
+	a.FillColor(c)
+	a.NoLine()
+	a.DrawPathNoTransform(agg.FillOnly)
+}
+
+// drawControl draws every path of c in its own color.
+func drawControl(ctx *agg.Context, c Control) {
+	for i := uint(0); i < c.NumPaths(); i++ {
+		fillVertexSource(ctx, c, i, toColor(c.Color(i)))
+	}
+}
+
+func toColor(c color.RGBA) agg.Color {
+	c8 := color.ConvertFromRGBA[color.Linear](c)
+	return agg.Color{R: c8.R, G: c8.G, B: c8.B, A: c8.A}
+}
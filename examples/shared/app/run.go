@@ -0,0 +1,242 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/MeKo-Christian/agg_go/internal/platform"
+)
+
+var (
+	backendFlag = flag.String("backend", "auto", "window backend: auto, sdl2, x11 or headless")
+	outFlag     = flag.String("o", "", "headless output PNG (default: derived from the title)")
+	fpsFlag     = flag.Bool("fps", false, "show the frame rate")
+)
+
+// Run parses the flags, if the program has not, and runs the demo until the
+// window is closed, or renders one frame to a PNG when headless.
+func (a *App) Run() {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+	a.showFPS = *fpsFlag
+
+	backendType, err := selectBackend(*backendFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "app: %v\n", err)
+		os.Exit(2)
+	}
+	if backendType == platform.BackendMock {
+		if err := a.runHeadless(*outFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "app: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err := a.runWindow(backendType); err != nil {
+		fmt.Fprintf(os.Stderr, "app: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// selectBackend maps the -backend flag to a backend of this build. "auto"
+// picks the factory default, which is headless when the binary was built
+// without x11 or sdl2.
+func selectBackend(name string) (platform.BackendType, error) {
+	factory := platform.GetBackendFactory()
+	var want platform.BackendType
+	switch strings.ToLower(name) {
+	case "auto", "":
+		return factory.GetDefaultBackend(), nil
+	case "headless":
+		return platform.BackendMock, nil
+	case "sdl2":
+		want = platform.BackendSDL2
+	case "x11":
+		want = platform.BackendX11
+	default:
+		return 0, fmt.Errorf("unknown backend %q", name)
+	}
+	for _, b := range factory.GetAvailableBackends() {
+		if b == want {
+			return want, nil
+		}
+	}
+	return 0, fmt.Errorf("backend %s is not built in; rebuild with -tags %s", want, strings.ToLower(want.String()))
+}
+
+// runHeadless renders a single frame and saves it as filename.
+func (a *App) runHeadless(filename string) error {
+	if init, ok := a.scene.(InitHandler); ok {
+		init.OnInit()
+	}
+	a.update(time.Now())
+	a.render(time.Now())
+	if filename == "" {
+		filename = a.screenshotName()
+	}
+	if err := a.ctx.GetImage().SaveToPNG(filename); err != nil {
+		return fmt.Errorf("save PNG: %w", err)
+	}
+	fmt.Printf("saved %s\n", filename)
+	return nil
+}
+
+func (a *App) runWindow(backendType platform.BackendType) error {
+	backend, err := platform.GetBackendFactory().CreateBackend(backendType, platform.PixelFormatRGBA32, false)
+	if err != nil {
+		return fmt.Errorf("create backend: %w", err)
+	}
+	h, err := newWindowHandler(a, backend)
+	if err != nil {
+		return err
+	}
+	defer backend.Destroy()
+
+	for h.running {
+		if !backend.PollEvents() {
+			break
+		}
+		h.onIdle()
+	}
+	return nil
+}
+
+// newWindowHandler opens the window on backend and routes its events to a.
+func newWindowHandler(a *App, backend platform.PlatformBackend) (*windowHandler, error) {
+	h := &windowHandler{
+		app:     a,
+		backend: backend,
+		ps:      platform.NewPlatformSupport(platform.PixelFormatRGBA32, false),
+		running: true,
+	}
+	h.ps.SetBackend(backend)
+	h.ps.Caption(a.cfg.Title)
+
+	if setter, ok := backend.(platform.EventCallbackSetter); ok {
+		setter.SetEventCallback(h)
+	}
+	if err := h.ps.Init(a.cfg.Width, a.cfg.Height, platform.WindowResize); err != nil {
+		return nil, fmt.Errorf("platform support init: %w", err)
+	}
+	if err := backend.Init(a.cfg.Width, a.cfg.Height, platform.WindowResize); err != nil {
+		return nil, fmt.Errorf("backend init: %w", err)
+	}
+	return h, nil
+}
+
+// windowHandler bridges platform events to the App.
+type windowHandler struct {
+	platform.BaseEventHandler
+	app            *App
+	backend        platform.PlatformBackend
+	ps             *platform.PlatformSupport
+	mouseX, mouseY int
+	running        bool
+}
+
+func (h *windowHandler) OnInit() {
+	if init, ok := h.app.scene.(InitHandler); ok {
+		init.OnInit()
+	}
+	h.backend.ForceRedraw()
+}
+
+func (h *windowHandler) OnDraw() {
+	h.app.render(time.Now())
+	h.blit()
+}
+
+func (h *windowHandler) OnResize(width, height int) {
+	h.app.resize(width, height)
+	h.backend.ForceRedraw()
+}
+
+func (h *windowHandler) OnMouseMove(x, y int, flags platform.InputFlags) {
+	h.mouseX, h.mouseY = x, y
+	h.redrawIf(h.app.mouseMove(x, y, toButtons(flags)))
+}
+
+func (h *windowHandler) OnMouseButtonDown(x, y int, flags platform.InputFlags) {
+	h.mouseX, h.mouseY = x, y
+	h.redrawIf(h.app.mouseDown(x, y, toButtons(flags)))
+}
+
+func (h *windowHandler) OnMouseButtonUp(x, y int, flags platform.InputFlags) {
+	h.redrawIf(h.app.mouseUp(x, y, toButtons(flags)))
+}
+
+func (h *windowHandler) OnMouseWheel(x, y int, dx, dy float64, _ platform.InputFlags) {
+	h.redrawIf(h.app.wheel(x, y, dx, dy))
+}
+
+func (h *windowHandler) OnDoubleClick(x, y int, flags platform.InputFlags) {
+	h.redrawIf(h.app.doubleClick(x, y, toButtons(flags)))
+}
+
+func (h *windowHandler) OnKey(_, _ int, key platform.KeyCode, _ platform.InputFlags) {
+	switch key {
+	case platform.KeyEscape:
+		h.running = false
+	case platform.KeyCode('s'), platform.KeyCode('S'):
+		h.saveScreenshot()
+	case platform.KeyF11:
+		if err := h.ps.SetFullscreen(!h.ps.Fullscreen()); err != nil {
+			fmt.Fprintf(os.Stderr, "fullscreen: %v\n", err)
+		}
+	case platform.KeyLeft, platform.KeyRight, platform.KeyDown, platform.KeyUp:
+		used := h.app.arrowKeys(h.mouseX, h.mouseY,
+			key == platform.KeyLeft, key == platform.KeyRight, key == platform.KeyDown, key == platform.KeyUp)
+		h.redrawIf(used)
+	default:
+		h.redrawIf(h.app.key(rune(key)))
+	}
+}
+
+func (h *windowHandler) OnDestroy() { h.running = false }
+
+func (h *windowHandler) onIdle() {
+	// The FPS counter needs frames to count.
+	if h.app.update(time.Now()) || h.app.showFPS {
+		h.backend.ForceRedraw()
+		h.backend.Delay(16)
+	}
+}
+
+func (h *windowHandler) redrawIf(redraw bool) {
+	if redraw {
+		h.backend.ForceRedraw()
+	}
+}
+
+// blit copies the canvas into the platform window buffer and presents it.
+func (h *windowHandler) blit() {
+	img := h.app.ctx.GetImage()
+	winBuf := h.ps.WindowBuffer()
+	dst := winBuf.Buf()
+	dstStride := winBuf.Stride()
+	if dstStride < 0 {
+		dstStride = -dstStride
+	}
+	w := min(winBuf.Width(), img.Width()) * 4
+	for y := range min(winBuf.Height(), img.Height()) {
+		copy(dst[y*dstStride:y*dstStride+w], img.Data[y*img.Stride():y*img.Stride()+w])
+	}
+	_ = h.backend.UpdateWindow(winBuf)
+}
+
+func (h *windowHandler) saveScreenshot() {
+	filename := h.app.screenshotName()
+	if err := h.app.ctx.GetImage().SaveToPNG(filename); err != nil {
+		fmt.Fprintf(os.Stderr, "screenshot: %v\n", err)
+		return
+	}
+	fmt.Printf("screenshot saved to %s\n", filename)
+}
+
+func toButtons(flags platform.InputFlags) Buttons {
+	return Buttons{Left: flags.HasMouseLeft(), Right: flags.HasMouseRight()}
+}
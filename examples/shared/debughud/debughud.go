@@ -0,0 +1,226 @@
+// Package debughud draws a debug overlay for the demos: the frame rate, a
+// frame-time graph and arbitrary key/value lines, rendered onto any
+// agg.Context with the embedded bitmap fonts.
+//
+// Call Tick once per frame, update values with Set, and Draw after the scene:
+//
+//	hud := debughud.New()
+//	for each frame {
+//		hud.Tick(time.Now())
+//		hud.Setf("shapes", "%d", len(shapes))
+//		drawScene(ctx)
+//		hud.Draw(ctx)
+//	}
+package debughud
+
+import (
+	"fmt"
+	"time"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// GraphSamples is the number of frames shown in the frame-time graph, one
+// pixel column each.
+const GraphSamples = 120
+
+const (
+	padding     = 4
+	graphHeight = 32
+	// Frame times are drawn against at least this range, so a steady 60 fps
+	// sits halfway up the graph.
+	minGraphRange = 2 * time.Second / 60
+)
+
+// HUD is a debug overlay. The zero value is not ready for use; create one
+// with New. The exported fields may be changed between frames.
+type HUD struct {
+	// X and Y place the top-left corner of the panel in device pixels.
+	X, Y float64
+	// Font is the bitmap font for the text lines.
+	Font agg.BitmapFont
+
+	TextColor  agg.Color
+	Background agg.Color
+	GraphColor agg.Color
+	// TargetColor marks the 60 fps frame time in the graph.
+	TargetColor agg.Color
+
+	last        time.Time
+	frameTimes  [GraphSamples]time.Duration
+	next, count int
+
+	windowStart  time.Time
+	windowFrames int
+	fps          float64
+
+	keys   []string
+	values map[string]string
+}
+
+// New returns a HUD in the top-left corner with light text on a
+// translucent dark panel.
+func New() *HUD {
+	return &HUD{
+		X:           8,
+		Y:           8,
+		Font:        agg.BitmapFontGSE6x12,
+		TextColor:   agg.NewColor(235, 235, 235, 255),
+		Background:  agg.NewColor(0, 0, 0, 160),
+		GraphColor:  agg.NewColor(80, 200, 120, 255),
+		TargetColor: agg.NewColor(220, 90, 60, 255),
+		values:      make(map[string]string),
+	}
+}
+
+// Tick records a frame finished at now. The frame rate is averaged over
+// one-second windows; the graph shows the time between consecutive ticks.
+func (h *HUD) Tick(now time.Time) {
+	if !h.last.IsZero() {
+		h.frameTimes[h.next] = now.Sub(h.last)
+		h.next = (h.next + 1) % GraphSamples
+		h.count = min(h.count+1, GraphSamples)
+	}
+	h.last = now
+
+	if h.windowStart.IsZero() {
+		h.windowStart = now
+	}
+	h.windowFrames++
+	if d := now.Sub(h.windowStart); d >= time.Second {
+		h.fps = float64(h.windowFrames) / d.Seconds()
+		h.windowStart, h.windowFrames = now, 0
+	}
+}
+
+// FPS returns the frame rate measured over the last full second.
+func (h *HUD) FPS() float64 {
+	return h.fps
+}
+
+// FrameTime returns the time between the last two ticks.
+func (h *HUD) FrameTime() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return h.frameTimes[(h.next+GraphSamples-1)%GraphSamples]
+}
+
+// Set shows value next to key. Keys are listed in the order they were
+// first set.
+func (h *HUD) Set(key, value string) {
+	if _, ok := h.values[key]; !ok {
+		h.keys = append(h.keys, key)
+	}
+	h.values[key] = value
+}
+
+// Setf is Set with a formatted value.
+func (h *HUD) Setf(key, format string, args ...any) {
+	h.Set(key, fmt.Sprintf(format, args...))
+}
+
+// Delete removes key from the overlay.
+func (h *HUD) Delete(key string) {
+	if _, ok := h.values[key]; !ok {
+		return
+	}
+	delete(h.values, key)
+	for i, k := range h.keys {
+		if k == key {
+			h.keys = append(h.keys[:i], h.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// lines returns the text lines of the panel.
+func (h *HUD) lines() []string {
+	lines := make([]string, 0, len(h.keys)+1)
+	lines = append(lines, fmt.Sprintf("%5.1f fps %6.2f ms", h.fps, float64(h.FrameTime())/float64(time.Millisecond)))
+	for _, k := range h.keys {
+		lines = append(lines, k+": "+h.values[k])
+	}
+	return lines
+}
+
+func (h *HUD) font() agg.BitmapFont {
+	if h.Font == nil {
+		return agg.BitmapFontGSE6x12
+	}
+	return h.Font
+}
+
+// Size returns the panel size for the current lines, e.g. to anchor the HUD
+// to another corner.
+func (h *HUD) Size() (width, height float64) {
+	return h.size(h.lines())
+}
+
+func (h *HUD) size(lines []string) (width, height float64) {
+	font := h.font()
+	width = float64(GraphSamples)
+	for _, l := range lines {
+		width = max(width, font.Width(l))
+	}
+	width += 2 * padding
+	height = 2*padding + float64(len(lines))*(font.Height()+2) + padding + graphHeight
+	return width, height
+}
+
+// Draw renders the overlay onto ctx in device coordinates, ignoring the
+// current transform and units. The transform and colors of ctx are left
+// unchanged.
+// Text uses Font while ctx has no font file loaded.
+func (h *HUD) Draw(ctx *agg.Context) {
+	font := h.font()
+	lineHeight := font.Height() + 2
+	lines := h.lines()
+	width, height := h.size(lines)
+
+	a := ctx.GetAgg2D()
+	fill, line := a.GetFillColor(), a.GetLineColor()
+	ctx.PushTransform()
+	ctx.SetTransform(agg.NewTransformationsFromValues(1, 0, 0, 1, 0, 0))
+	defer func() {
+		ctx.PopTransform()
+		a.FillColor(fill)
+		a.LineColor(line)
+	}()
+
+	// FillRects groups by color, so the panel goes first in its own batch.
+	ctx.FillRects([]agg.RectColor{{X: h.X, Y: h.Y, Width: width, Height: height, Color: h.Background}})
+	ctx.FillRects(h.graph(h.X+padding, h.Y+height-padding-graphHeight))
+
+	a.FillColor(h.TextColor)
+	y := h.Y + padding + lineHeight - 2
+	for _, l := range lines {
+		_ = ctx.DrawTextWithOptions(h.X+padding, y, l, agg.DrawTextOptions{BitmapFont: font})
+		y += lineHeight
+	}
+}
+
+// graph returns the frame-time bars, oldest on the left, with the graph's
+// bottom-left corner at (x, y+graphHeight).
+func (h *HUD) graph(x, y float64) []agg.RectColor {
+	scale := minGraphRange
+	for i := 0; i < h.count; i++ {
+		scale = max(scale, h.frameTimes[i])
+	}
+
+	rects := make([]agg.RectColor, 0, h.count+1)
+	start := (h.next + GraphSamples - h.count) % GraphSamples
+	for i := 0; i < h.count; i++ {
+		d := h.frameTimes[(start+i)%GraphSamples]
+		bar := graphHeight * float64(d) / float64(scale)
+		rects = append(rects, agg.RectColor{
+			X: x + float64(GraphSamples-h.count+i), Y: y + graphHeight - bar,
+			Width: 1, Height: bar, Color: h.GraphColor,
+		})
+	}
+	target := graphHeight * float64(time.Second/60) / float64(scale)
+	rects = append(rects, agg.RectColor{
+		X: x, Y: y + graphHeight - target, Width: GraphSamples, Height: 1, Color: h.TargetColor,
+	})
+	return rects
+}
@@ -7,6 +7,8 @@ import (
 	"math"
 	"time"
 
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/examples/shared/debughud"
 	"github.com/MeKo-Christian/agg_go/internal/platform"
 )
 
@@ -33,6 +35,12 @@ type App struct {
 	shapes      []shape
 	currentTime time.Time
 	running     bool
+
+	// The debug overlay draws through an agg.Context over the window buffer.
+	hud     *debughud.HUD
+	showHUD bool
+	hudCtx  *agg.Context
+	hudBuf  []uint8
 }
 
 const (
@@ -47,6 +55,8 @@ func New(backend platform.PlatformBackend) *App {
 		currentTime: time.Now(),
 		shapes:      make([]shape, 0, 200),
 		running:     true,
+		hud:         debughud.New(),
+		showHUD:     true,
 	}
 	app.ps = platform.NewPlatformSupport(platform.PixelFormatRGBA32, false)
 	app.ps.Caption("AGG Interactive Demo")
@@ -82,6 +92,7 @@ func (app *App) OnInit() {
 	fmt.Println("  Right click         remove nearby shapes")
 	fmt.Println("  c                   clear screen")
 	fmt.Println("  r                   reset shapes")
+	fmt.Println("  h                   toggle debug overlay")
 	fmt.Println("  ESC                 exit")
 }
 
@@ -151,6 +162,8 @@ func (app *App) OnKey(x, y int, key platform.KeyCode, flags platform.InputFlags)
 	case platform.KeyCode('r'), platform.KeyCode('R'):
 		app.initShapes()
 		fmt.Println("Shapes reset")
+	case platform.KeyCode('h'), platform.KeyCode('H'):
+		app.showHUD = !app.showHUD
 	}
 }
 
@@ -168,10 +181,32 @@ func (app *App) OnDraw() {
 	app.updateShapes()
 	app.drawShapes()
 	app.drawCrosshair()
+	app.drawHUD()
 
 	_ = app.backend.UpdateWindow(app.ps.WindowBuffer())
 }
 
+// drawHUD draws the debug overlay. The Context is rebuilt whenever the
+// window buffer is reallocated.
+func (app *App) drawHUD() {
+	app.hud.Tick(time.Now())
+	if !app.showHUD {
+		return
+	}
+	wb := app.ps.WindowBuffer()
+	buf := wb.Buf()
+	if len(buf) == 0 {
+		return
+	}
+	if app.hudCtx == nil || app.hudCtx.Width() != wb.Width() || len(app.hudBuf) != len(buf) || &app.hudBuf[0] != &buf[0] {
+		app.hudCtx = agg.NewContextForImage(agg.NewImage(buf, wb.Width(), wb.Height(), wb.Stride()))
+		app.hudBuf = buf
+	}
+	app.hud.Setf("shapes", "%d", len(app.shapes))
+	app.hud.Setf("mouse", "%d,%d", app.mouseX, app.mouseY)
+	app.hud.Draw(app.hudCtx)
+}
+
 // OnIdle drives animation at ~60 fps.
 func (app *App) OnIdle() {
 	if app.running {
@@ -2,6 +2,17 @@ package agg
 
 import "github.com/MeKo-Christian/agg_go/internal/agg2d"
 
+// FillRule selects how overlapping and self-intersecting sub-paths are
+// filled.
+type FillRule int
+
+const (
+	// FillNonZero fills points with a non-zero winding number.
+	FillNonZero FillRule = iota
+	// FillEvenOdd fills points crossed an odd number of times.
+	FillEvenOdd
+)
+
 // FillRuleExamples re-exports the helper examples for the supported fill rules.
 type FillRuleExamples = agg2d.FillRuleExamples
 
@@ -0,0 +1,106 @@
+package agg
+
+// FloodFill replaces the color of the 4-connected region around (x, y) with
+// c, like a paint-bucket tool. A pixel joins the region when each of its RGBA
+// channels differs from the seed pixel by at most tolerance. Pixels are
+// overwritten, not blended. It returns the number of pixels filled; seeds
+// outside the image fill nothing. Only RGBA images are filled.
+func (img *Image) FloodFill(x, y int, c Color, tolerance uint8) int {
+	if img == nil || img.format != ImageRGBA8 {
+		return 0
+	}
+	return img.floodFill(x, y, c, tolerance, 0, 0, img.width-1, img.height-1, nil)
+}
+
+// FloodFill flood-fills the Context's image from device pixel (x, y) like
+// Image.FloodFill, without spreading past the current clip box. Only RGBA
+// contexts are filled. Inside MeasureDraw it reports the bounds of the region
+// and leaves its pixels alone.
+func (ctx *Context) FloodFill(x, y int, c Color, tolerance uint8) int {
+	if ctx.image.format != ImageRGBA8 {
+		return 0
+	}
+	// The clip box is inclusive in whole pixels, as for the base renderer.
+	cx1, cy1, cx2, cy2 := ctx.agg2d.impl.GetClipBox()
+	x1, y1 := max(int(cx1), 0), max(int(cy1), 0)
+	x2, y2 := min(int(cx2), ctx.image.width-1), min(int(cy2), ctx.image.height-1)
+	var measure func(x1, y1, x2, y2 int)
+	if impl := ctx.agg2d.impl; impl.Measuring() {
+		measure = impl.MeasureRect
+	}
+	return ctx.image.floodFill(x, y, c, tolerance, x1, y1, x2, y2, measure)
+}
+
+// floodFill fills within the inclusive box (x1, y1)-(x2, y2) using a stack of
+// seed points, filling one horizontal run per seed and pushing the runs
+// above and below. A non-nil measure receives each run, as [x1, x2) x
+// [y1, y2), in place of painting it.
+func (img *Image) floodFill(x, y int, c Color, tolerance uint8, x1, y1, x2, y2 int, measure func(x1, y1, x2, y2 int)) int {
+	if x < x1 || x > x2 || y < y1 || y > y2 {
+		return 0
+	}
+	stride := img.renBuf.Stride()
+	pixel := func(px, py int) []uint8 {
+		i := py*stride + px*4
+		return img.Data[i : i+4]
+	}
+	seed := [4]uint8(pixel(x, y))
+	tol := int(tolerance)
+	w := x2 - x1 + 1
+	filled := make([]bool, w*(y2-y1+1))
+	matches := func(px, py int) bool {
+		if filled[(py-y1)*w+px-x1] {
+			return false
+		}
+		p := pixel(px, py)
+		for i := range 4 {
+			if d := int(p[i]) - int(seed[i]); d > tol || d < -tol {
+				return false
+			}
+		}
+		return true
+	}
+
+	count := 0
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		sx, sy := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+		if !matches(sx, sy) {
+			continue
+		}
+		left, right := sx, sx
+		for left > x1 && matches(left-1, sy) {
+			left--
+		}
+		for right < x2 && matches(right+1, sy) {
+			right++
+		}
+		for px := left; px <= right; px++ {
+			if measure == nil {
+				copy(pixel(px, sy), []uint8{c.R, c.G, c.B, c.A})
+			}
+			filled[(sy-y1)*w+px-x1] = true
+		}
+		if measure != nil {
+			measure(left, sy, right+1, sy+1)
+		}
+		count += right - left + 1
+
+		// Push one seed per matching run on the neighboring rows.
+		for _, ny := range [2]int{sy - 1, sy + 1} {
+			if ny < y1 || ny > y2 {
+				continue
+			}
+			inRun := false
+			for px := left; px <= right; px++ {
+				m := matches(px, ny)
+				if m && !inRun {
+					stack = append(stack, [2]int{px, ny})
+				}
+				inRun = m
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,110 @@
+package agg
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/MeKo-Christian/agg_go/internal/font/freetype"
+)
+
+// Font is a font file opened for reading glyph outlines, independent of any
+// Context. Use it for effects the text pipeline does not offer, such as
+// extruding, warping or combining glyphs with other shapes. Reading outlines
+// needs a build with the freetype tag; otherwise OpenFont fails.
+//
+// A Font may be used from several goroutines.
+type Font struct {
+	mu     sync.Mutex
+	engine *freetype.FontEngineFreetype
+	height float64
+}
+
+// GlyphMetrics describes a glyph returned by Font.GlyphPath.
+type GlyphMetrics struct {
+	// AdvanceX and AdvanceY move the pen to the origin of the next glyph.
+	AdvanceX, AdvanceY float64
+	// Bounds is the bounding box of the outline, rounded out to whole
+	// units. It is empty for glyphs without ink, such as a space.
+	Bounds Rect
+}
+
+// OpenFont opens a TrueType or OpenType font file with glyphs height units
+// tall (the em size).
+func OpenFont(filename string, height float64) (*Font, error) {
+	if height <= 0 {
+		return nil, errors.New("font height must be positive")
+	}
+	engine, err := freetype.NewFontEngineFreetype(false, 1)
+	if err != nil {
+		return nil, err
+	}
+	if err := engine.LoadFont(filename, 0, freetype.GlyphRenderingOutline, nil); err != nil {
+		_ = engine.Close()
+		return nil, err
+	}
+	// Raw outlines: no grid fitting, and y pointing down like Context
+	// coordinates.
+	engine.SetHinting(false)
+	engine.SetFlipY(true)
+	engine.SetHeight(height)
+	return &Font{engine: engine, height: height}, nil
+}
+
+// Close releases the font file. The paths already returned stay valid.
+func (f *Font) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.engine == nil {
+		return nil
+	}
+	err := f.engine.Close()
+	f.engine = nil
+	return err
+}
+
+// Height returns the em size the font was opened with.
+func (f *Font) Height() float64 { return f.height }
+
+// Ascender returns the distance from the baseline to the top of the tallest
+// glyphs, as stored in the font.
+func (f *Font) Ascender() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.engine == nil {
+		return 0
+	}
+	return f.engine.GetAscender()
+}
+
+// Descender returns the distance from the baseline to the bottom of the
+// lowest glyphs; it is usually negative.
+func (f *Font) Descender() float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.engine == nil {
+		return 0
+	}
+	return f.engine.GetDescender()
+}
+
+// GlyphPath returns the outline of the glyph for r with its origin on the
+// baseline at (0, 0). As in Context coordinates y points down, so the glyph
+// body has negative y. The path holds the quadratic and cubic curves of the
+// font; draw it at a position with Context.Translate and AppendPath, or walk
+// it with Segments. It returns nil and zero metrics when the font has no
+// glyph for r or is closed.
+func (f *Font) GlyphPath(r rune) (*Path, GlyphMetrics) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.engine == nil || !f.engine.PrepareGlyph(uint(r)) {
+		return nil, GlyphMetrics{}
+	}
+
+	p := NewPath()
+	p.ps.ConcatPath(f.engine.PathAdaptor(), 0)
+	m := GlyphMetrics{AdvanceX: f.engine.AdvanceX(), AdvanceY: f.engine.AdvanceY()}
+	if b := f.engine.Bounds(); p.ps.TotalVertices() > 0 {
+		m.Bounds = Rect{X1: b.X1, Y1: b.Y1, X2: b.X2, Y2: b.Y2}
+	}
+	return p, m
+}
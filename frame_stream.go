@@ -0,0 +1,239 @@
+package agg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// FrameFormat selects the raw pixel layout written by FrameStreamer.
+type FrameFormat int
+
+const (
+	// FrameRGBA writes 4 bytes per pixel in R, G, B, A order (ffmpeg "rgba").
+	FrameRGBA FrameFormat = iota
+	// FrameBGRA writes 4 bytes per pixel in B, G, R, A order (ffmpeg "bgra").
+	FrameBGRA
+	// FrameRGB24 writes 3 bytes per pixel in R, G, B order and drops alpha
+	// (ffmpeg "rgb24").
+	FrameRGB24
+	// FrameYUV420P writes planar BT.601 limited-range Y, U, V planes with 2x2
+	// chroma subsampling (ffmpeg "yuv420p").
+	FrameYUV420P
+)
+
+// PixFmt returns the ffmpeg -pix_fmt name matching the format, so callers can
+// build a rawvideo input command line without hard-coding the mapping.
+func (f FrameFormat) PixFmt() string {
+	switch f {
+	case FrameRGBA:
+		return "rgba"
+	case FrameBGRA:
+		return "bgra"
+	case FrameRGB24:
+		return "rgb24"
+	case FrameYUV420P:
+		return "yuv420p"
+	default:
+		return ""
+	}
+}
+
+// FrameSize returns the number of bytes one width x height frame occupies in
+// format f.
+func (f FrameFormat) FrameSize(width, height int) int {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+	switch f {
+	case FrameRGBA, FrameBGRA:
+		return width * height * 4
+	case FrameRGB24:
+		return width * height * 3
+	case FrameYUV420P:
+		cw, ch := (width+1)/2, (height+1)/2
+		return width*height + 2*cw*ch
+	default:
+		return 0
+	}
+}
+
+// FrameStreamer writes successive frames as headerless raw video, suitable for
+// piping into encoders such as `ffmpeg -f rawvideo -pix_fmt <fmt> -s WxH -i -`.
+//
+// All frames written to one streamer must share the dimensions of the first
+// frame, because raw video carries no per-frame header.
+type FrameStreamer struct {
+	w      io.Writer
+	format FrameFormat
+	flipY  bool
+
+	width  int
+	height int
+	frames int
+	buf    []byte
+}
+
+// NewFrameStreamer creates a streamer that writes frames in format to w.
+func NewFrameStreamer(w io.Writer, format FrameFormat) *FrameStreamer {
+	return &FrameStreamer{w: w, format: format}
+}
+
+// SetFlipY controls whether rows are written bottom-up. Enable it when the
+// consumer expects OpenGL-style origin at the bottom-left corner.
+func (s *FrameStreamer) SetFlipY(flip bool) {
+	s.flipY = flip
+}
+
+// FlipY reports whether rows are written bottom-up.
+func (s *FrameStreamer) FlipY() bool {
+	return s.flipY
+}
+
+// Format returns the output pixel format.
+func (s *FrameStreamer) Format() FrameFormat {
+	return s.format
+}
+
+// Size returns the frame dimensions locked in by the first written frame.
+func (s *FrameStreamer) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// FrameCount returns the number of frames written so far.
+func (s *FrameStreamer) FrameCount() int {
+	return s.frames
+}
+
+// WriteContext writes the current contents of ctx as one frame.
+func (s *FrameStreamer) WriteContext(ctx *Context) error {
+	if ctx == nil {
+		return errors.New("context is nil")
+	}
+	return s.WriteFrame(ctx.GetImage())
+}
+
+// WriteFrame converts img to the streamer format and writes it as one frame.
+func (s *FrameStreamer) WriteFrame(img *Image) error {
+	if s.w == nil {
+		return errors.New("frame streamer has no writer")
+	}
+	if img == nil || img.renBuf == nil {
+		return errors.New("image is nil")
+	}
+	w, h := img.Width(), img.Height()
+	if w <= 0 || h <= 0 {
+		return fmt.Errorf("invalid frame size %dx%d", w, h)
+	}
+	if s.frames == 0 {
+		s.width, s.height = w, h
+	} else if w != s.width || h != s.height {
+		return fmt.Errorf("frame size %dx%d does not match stream size %dx%d", w, h, s.width, s.height)
+	}
+
+	for y := 0; y < h; y++ {
+		if len(img.renBuf.RowPtr(0, y, w*4)) < w*4 {
+			return fmt.Errorf("image buffer too small for %dx%d frame", w, h)
+		}
+	}
+
+	size := s.format.FrameSize(w, h)
+	if size == 0 {
+		return fmt.Errorf("unsupported frame format %d", s.format)
+	}
+	if cap(s.buf) < size {
+		s.buf = make([]byte, size)
+	}
+	s.buf = s.buf[:size]
+
+	if s.format == FrameYUV420P {
+		s.convertYUV420P(img)
+	} else {
+		s.convertPacked(img)
+	}
+
+	if _, err := s.w.Write(s.buf); err != nil {
+		return err
+	}
+	s.frames++
+	return nil
+}
+
+// srcRow returns the RGBA bytes of output row y, honoring the Y-flip setting.
+func (s *FrameStreamer) srcRow(img *Image, y int) []uint8 {
+	if s.flipY {
+		y = s.height - 1 - y
+	}
+	return img.renBuf.RowPtr(0, y, s.width*4)
+}
+
+func (s *FrameStreamer) convertPacked(img *Image) {
+	switch s.format {
+	case FrameRGBA:
+		rowLen := s.width * 4
+		for y := 0; y < s.height; y++ {
+			copy(s.buf[y*rowLen:(y+1)*rowLen], s.srcRow(img, y))
+		}
+	case FrameBGRA:
+		rowLen := s.width * 4
+		for y := 0; y < s.height; y++ {
+			src := s.srcRow(img, y)
+			dst := s.buf[y*rowLen : (y+1)*rowLen]
+			for x := 0; x < rowLen; x += 4 {
+				dst[x] = src[x+2]
+				dst[x+1] = src[x+1]
+				dst[x+2] = src[x]
+				dst[x+3] = src[x+3]
+			}
+		}
+	case FrameRGB24:
+		rowLen := s.width * 3
+		for y := 0; y < s.height; y++ {
+			src := s.srcRow(img, y)
+			dst := s.buf[y*rowLen : (y+1)*rowLen]
+			for x, d := 0, 0; d < rowLen; x, d = x+4, d+3 {
+				dst[d] = src[x]
+				dst[d+1] = src[x+1]
+				dst[d+2] = src[x+2]
+			}
+		}
+	}
+}
+
+// convertYUV420P converts to planar BT.601 limited range. Chroma samples are
+// the average of each 2x2 block (clamped at the right and bottom edges).
+func (s *FrameStreamer) convertYUV420P(img *Image) {
+	w, h := s.width, s.height
+	cw, ch := (w+1)/2, (h+1)/2
+	yPlane := s.buf[:w*h]
+	uPlane := s.buf[w*h : w*h+cw*ch]
+	vPlane := s.buf[w*h+cw*ch:]
+
+	for y := 0; y < h; y++ {
+		src := s.srcRow(img, y)
+		for x := 0; x < w; x++ {
+			r, g, b := int(src[x*4]), int(src[x*4+1]), int(src[x*4+2])
+			yPlane[y*w+x] = uint8((66*r+129*g+25*b+128)>>8 + 16)
+		}
+	}
+
+	for cy := 0; cy < ch; cy++ {
+		row0 := s.srcRow(img, cy*2)
+		row1 := row0
+		if cy*2+1 < h {
+			row1 = s.srcRow(img, cy*2+1)
+		}
+		for cx := 0; cx < cw; cx++ {
+			x0 := cx * 2 * 4
+			x1 := x0
+			if cx*2+1 < w {
+				x1 = x0 + 4
+			}
+			r := (int(row0[x0]) + int(row0[x1]) + int(row1[x0]) + int(row1[x1]) + 2) >> 2
+			g := (int(row0[x0+1]) + int(row0[x1+1]) + int(row1[x0+1]) + int(row1[x1+1]) + 2) >> 2
+			b := (int(row0[x0+2]) + int(row0[x1+2]) + int(row1[x0+2]) + int(row1[x1+2]) + 2) >> 2
+			uPlane[cy*cw+cx] = uint8((-38*r-74*g+112*b+128)>>8 + 128)
+			vPlane[cy*cw+cx] = uint8((112*r-94*g-18*b+128)>>8 + 128)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package agg
 
-import "github.com/MeKo-Christian/agg_go/internal/transform"
+import (
+	"github.com/MeKo-Christian/agg_go/internal/array"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
 
 // Rect represents an integer rectangle.
 type Rect struct {
@@ -123,6 +126,28 @@ func Angle(x1, y1, x2, y2 float64) float64 {
 	return geomAtan2(y2-y1, x2-x1)
 }
 
+// VertexDist is a vertex of a VertexSequence. Dist is the distance to the
+// next vertex, filled in as vertices are added (AGG vertex_dist).
+type VertexDist = array.VertexDist
+
+// VertexSequence is AGG's vertex_sequence of VertexDist: adding a vertex
+// that coincides with the previous one replaces it, so the sequence never
+// holds zero-length segments.
+type VertexSequence = array.VertexDistSequence
+
+// NewVertexSequence returns an empty VertexSequence.
+func NewVertexSequence() *VertexSequence {
+	return array.NewVertexDistSequence()
+}
+
+// ShortenPath trims length s off the end of vs, dropping whole vertices as
+// needed (AGG shorten_path). This is the gap left for an arrowhead; the
+// stroke and dash converters apply it through StrokeOptions.Shorten and
+// Context.SetPathShorten.
+func ShortenPath(vs *VertexSequence, s float64, closed bool) {
+	array.ShortenPath(vs, s, closed)
+}
+
 // Helper functions (prefixed to avoid conflicts)
 func geomSqrt(x float64) float64 {
 	// Simple square root approximation - in practice would use math.Sqrt
@@ -4,5 +4,6 @@ go 1.24.0
 
 require (
 	github.com/veandco/go-sdl2 v0.4.40
+	golang.org/x/image v0.36.0
 	golang.org/x/sys v0.40.0
 )
@@ -2,6 +2,8 @@ package agg
 
 import (
 	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/agg2d"
 )
 
 // Gradient identifies the underlying Agg2D gradient mode.
@@ -14,6 +16,10 @@ const (
 	Linear
 	// Radial selects a radial gradient.
 	Radial
+	// Conic selects a conic (sweep) gradient.
+	Conic
+	// Pattern selects an image pattern.
+	Pattern
 )
 
 // GradientType identifies the higher-level gradient configuration on Context.
@@ -26,6 +32,17 @@ const (
 	LinearGradient GradientType = 1
 	// RadialGradient means a radial gradient is active.
 	RadialGradient GradientType = 2
+	// ConicGradient means a conic (sweep) gradient is active.
+	ConicGradient GradientType = 3
+	// PatternGradient means an image pattern is active instead of a
+	// gradient.
+	PatternGradient GradientType = 4
+	// ContourGradient means a gradient shaded by the distance to a path's
+	// outline is active.
+	ContourGradient GradientType = 5
+	// ImageGradient means a gradient read from the levels of an image is
+	// active.
+	ImageGradient GradientType = 6
 )
 
 // GradientStop represents a color stop in a gradient
@@ -50,8 +67,166 @@ type RadialGradientSpec struct {
 	Profile float64        // Gradient profile (sharpness)
 }
 
+// GradientPaint is a reusable gradient. Its color table is computed once by
+// NewLinearGradient or NewRadialGradient, so installing it with
+// Context.SetFillGradient or SetStrokeGradient costs no rebuild. A paint may
+// be shared by several contexts.
+type GradientPaint struct {
+	impl *agg2d.GradientPaint
+	paintStyle
+}
+
+// NewLinearGradient returns a linear gradient from (x1, y1) to (x2, y2) in
+// user coordinates. Stops need not be sorted; with no stops the gradient is
+// transparent.
+func NewLinearGradient(x1, y1, x2, y2 float64, stops ...GradientStop) *GradientPaint {
+	return &GradientPaint{impl: agg2d.NewLinearGradientPaint(x1, y1, x2, y2, internalStops(stops))}
+}
+
+// NewRadialGradient returns a radial gradient centered at (cx, cy) with the
+// given radius in user coordinates.
+func NewRadialGradient(cx, cy, radius float64, stops ...GradientStop) *GradientPaint {
+	return &GradientPaint{impl: agg2d.NewRadialGradientPaint(cx, cy, radius, internalStops(stops))}
+}
+
+// NewConicGradient returns a conic (sweep) gradient around (cx, cy), like CSS
+// conic-gradient: stop positions run clockwise through one full turn,
+// starting at startAngle radians from 12 o'clock.
+func NewConicGradient(cx, cy, startAngle float64, stops ...GradientStop) *GradientPaint {
+	return &GradientPaint{impl: agg2d.NewConicGradientPaint(cx, cy, startAngle, internalStops(stops))}
+}
+
+// NewContourGradient returns a gradient shaded by the distance to the
+// outline of p, as AGG's gradient_contour: position 0 lies on the outline and
+// position 1 at the point farthest from it, inside or outside, within the
+// bounding box of p grown by 10 pixels. Filling p itself gives a bevel or
+// inner glow. The distance map is computed once, here, so p may be changed or
+// reused afterwards. A nil p counts as an empty path.
+func NewContourGradient(p *Path, stops ...GradientStop) *GradientPaint {
+	if p == nil {
+		p = NewPath()
+	}
+	return &GradientPaint{impl: agg2d.NewContourGradientPaint(p.ps, internalStops(stops))}
+}
+
+// NewImageGradient returns a gradient whose positions are read from img, with
+// its top-left corner at (x, y) in user coordinates: a level of v picks the
+// color at position v/255. Gray images use their gray level, 32-bit RGBA
+// images their alpha, as ApplyMask does; outside img the nearest edge pixel
+// counts. Fed with a distance field from GenerateSDF or a blurred mask, it
+// shades fills by the distance to a shape's border, for glows and inner
+// shadows. img is read when drawing, not copied.
+func NewImageGradient(img *Image, x, y float64, stops ...GradientStop) *GradientPaint {
+	if img == nil {
+		return &GradientPaint{impl: agg2d.NewFieldGradientPaint(nil, 0, 0, 0, 0, x, y, internalStops(stops))}
+	}
+	pix := img.Data
+	if o, ok := img.format.channelOrder(); ok && len(pix) > 3 {
+		pix = pix[o.A:]
+	}
+	return &GradientPaint{impl: agg2d.NewFieldGradientPaint(pix, img.width, img.height, img.renBuf.Stride(), img.pixelStep(), x, y, internalStops(stops))}
+}
+
+// Type returns LinearGradient, RadialGradient, ConicGradient,
+// ContourGradient or ImageGradient.
+func (g *GradientPaint) Type() GradientType {
+	return GradientType(g.impl.Kind())
+}
+
+// ColorAt returns the gradient color at position t (0.0 to 1.0).
+func (g *GradientPaint) ColorAt(t float64) Color {
+	c := g.impl.ColorAt(t)
+	return Color{R: c[0], G: c[1], B: c[2], A: c[3]}
+}
+
+// SetTransform sets the gradient transform, like SVG gradientTransform: it
+// maps gradient coordinates to user space, so the gradient can be rotated,
+// scaled or positioned independently of the shape it fills. A nil m resets
+// it. The transform takes effect the next time the paint is installed.
+func (g *GradientPaint) SetTransform(m *Transformations) {
+	g.impl.SetTransform(toInternalTransformations(m))
+}
+
+// Transform returns the gradient transform.
+func (g *GradientPaint) Transform() *Transformations {
+	return fromInternalTransformations(g.impl.Transform())
+}
+
+// SetDithering turns ordered dithering on or off for this gradient. Dithered
+// gradients are computed at more than 8 bits and the rounding spread over a
+// Bayer pattern, which removes the visible bands of large, smooth
+// gradients. Use Context.SetGradientDithering to dither every gradient.
+func (g *GradientPaint) SetDithering(on bool) {
+	g.impl.SetDithering(on)
+}
+
+// Dithering reports whether the gradient is dithered.
+func (g *GradientPaint) Dithering() bool {
+	return g.impl.Dithering()
+}
+
+// AlphaStop returns a stop for an alpha gradient: only alpha (0.0 to 1.0)
+// matters for SetFillAlphaGradient, so the color channels are left black.
+func AlphaStop(position, alpha float64) GradientStop {
+	return GradientStop{Position: position, Color: Color{A: uint8(math.Round(math.Max(0, math.Min(1, alpha)) * 255))}}
+}
+
+func internalStops(stops []GradientStop) []agg2d.GradientStop {
+	out := make([]agg2d.GradientStop, len(stops))
+	for i, s := range stops {
+		out[i] = agg2d.GradientStop{Offset: s.Position, Color: [4]uint8{s.Color.R, s.Color.G, s.Color.B, s.Color.A}}
+	}
+	return out
+}
+
 // Context gradient methods
 
+// SetFillGradient uses g for subsequent fills. The gradient geometry is
+// placed with the transform current at the time of the call. A nil g
+// switches back to a solid fill.
+func (ctx *Context) SetFillGradient(g *GradientPaint) {
+	ctx.agg2d.SetFillGradient(g)
+}
+
+// SetConicGradient sets a conic (sweep) gradient for fill operations. See
+// NewConicGradient for the geometry; for repeated use, build the gradient
+// once and install it with SetFillGradient.
+func (ctx *Context) SetConicGradient(cx, cy, startAngle float64, stops []GradientStop) {
+	ctx.SetFillGradient(NewConicGradient(cx, cy, startAngle, stops...))
+}
+
+// SetFillAlphaGradient uses g as an opacity mask over subsequent fills: the
+// alpha of every filled pixel, from the fill color, a fill gradient or a
+// transformed image, is multiplied by the alpha of g at that point. Only
+// the alpha of the stops is used (see AlphaStop). The geometry is placed
+// with the current transform. A nil g removes the mask.
+func (ctx *Context) SetFillAlphaGradient(g *GradientPaint) {
+	ctx.agg2d.SetFillAlphaGradient(g)
+}
+
+// SetGradientDithering turns ordered dithering on or off for all gradient
+// fills and strokes, including the ones set with SetLinearGradient and
+// friends. See GradientPaint.SetDithering.
+func (ctx *Context) SetGradientDithering(on bool) {
+	ctx.agg2d.GradientDithering(on)
+}
+
+// GetGradientDithering reports whether all gradients are dithered.
+func (ctx *Context) GetGradientDithering() bool {
+	return ctx.agg2d.GetGradientDithering()
+}
+
+// SetStrokeGradient uses g for subsequent strokes. See SetFillGradient.
+func (ctx *Context) SetStrokeGradient(g *GradientPaint) {
+	ctx.agg2d.SetLineGradient(g)
+}
+
+// SetStrokeConicGradient sets a conic (sweep) gradient for stroke
+// operations. See NewConicGradient for the geometry.
+func (ctx *Context) SetStrokeConicGradient(cx, cy, startAngle float64, stops []GradientStop) {
+	ctx.SetStrokeGradient(NewConicGradient(cx, cy, startAngle, stops...))
+}
+
 // SetLinearGradient sets a linear gradient for fill operations.
 func (ctx *Context) SetLinearGradient(x1, y1, x2, y2 float64, c1, c2 Color) {
 	ctx.agg2d.FillLinearGradient(x1, y1, x2, y2, c1, c2, 1.0)
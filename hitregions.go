@@ -0,0 +1,147 @@
+package agg
+
+import "math"
+
+// HitRegionIndex records which shape covers each device pixel, for object
+// picking in interactive scenes. Attach it to a Context with SetHitRegions,
+// tag shapes with SetHitID while drawing, and ask TopShapeAt where the mouse
+// is:
+//
+//	hits := agg.NewHitRegionIndex(ctx.Width(), ctx.Height())
+//	ctx.SetHitRegions(hits)
+//	for i, s := range shapes {
+//		ctx.SetHitID(i + 1)
+//		s.Draw(ctx)
+//	}
+//	ctx.SetHitID(0)
+//	...
+//	if id, ok := hits.TopShapeAt(mouseX, mouseY); ok {
+//		selected = shapes[id-1]
+//	}
+//
+// Fills, strokes and outline text are recorded with the coverage they are
+// rasterized with, so curves, transforms, dashes and the clip box are taken
+// into account exactly. Later shapes replace earlier ones, as in painting.
+type HitRegionIndex struct {
+	width, height int
+	ids           []int
+	bounds        map[int]Rect
+	minCover      uint8
+}
+
+// NewHitRegionIndex returns an empty index for a width x height device
+// area. A pixel counts as part of a shape when the shape covers at least
+// half of it; see SetMinCoverage.
+func NewHitRegionIndex(width, height int) *HitRegionIndex {
+	width, height = max(width, 0), max(height, 0)
+	return &HitRegionIndex{
+		width:    width,
+		height:   height,
+		ids:      make([]int, width*height),
+		bounds:   make(map[int]Rect),
+		minCover: 128,
+	}
+}
+
+// Width returns the width of the index in pixels.
+func (h *HitRegionIndex) Width() int { return h.width }
+
+// Height returns the height of the index in pixels.
+func (h *HitRegionIndex) Height() int { return h.height }
+
+// SetMinCoverage sets the coverage (1-255) a shape needs on a pixel to claim
+// it. Lower values make thin strokes and small shapes easier to hit.
+func (h *HitRegionIndex) SetMinCoverage(cover uint8) {
+	h.minCover = max(cover, 1)
+}
+
+// MinCoverage returns the coverage threshold.
+func (h *HitRegionIndex) MinCoverage() uint8 {
+	return h.minCover
+}
+
+// Clear forgets all shapes, typically before redrawing a frame.
+func (h *HitRegionIndex) Clear() {
+	clear(h.ids)
+	clear(h.bounds)
+}
+
+// TopShapeAt returns the ID of the topmost shape at device point (x, y), or
+// false when no shape covers it.
+func (h *HitRegionIndex) TopShapeAt(x, y float64) (id int, ok bool) {
+	px, py := int(math.Floor(x)), int(math.Floor(y))
+	if px < 0 || py < 0 || px >= h.width || py >= h.height {
+		return 0, false
+	}
+	id = h.ids[py*h.width+px]
+	return id, id != 0
+}
+
+// Bounds returns the device-pixel bounding box (X2 and Y2 exclusive) of the
+// pixels recorded for id, including those later covered by other shapes.
+func (h *HitRegionIndex) Bounds(id int) (Rect, bool) {
+	r, ok := h.bounds[id]
+	return r, ok
+}
+
+// record marks the pixels of a span that id covers sufficiently.
+func (h *HitRegionIndex) record(id, x, y int, covers []uint8) {
+	if y < 0 || y >= h.height {
+		return
+	}
+	row := h.ids[y*h.width : (y+1)*h.width]
+	x1, x2 := math.MaxInt, math.MinInt
+	for i, c := range covers {
+		px := x + i
+		if c < h.minCover || px < 0 || px >= h.width {
+			continue
+		}
+		row[px] = id
+		x1, x2 = min(x1, px), px
+	}
+	if x1 > x2 {
+		return
+	}
+	span := Rect{X1: x1, Y1: y, X2: x2 + 1, Y2: y + 1}
+	if r, ok := h.bounds[id]; ok {
+		span = Rect{X1: min(r.X1, span.X1), Y1: min(r.Y1, span.Y1), X2: max(r.X2, span.X2), Y2: max(r.Y2, span.Y2)}
+	}
+	h.bounds[id] = span
+}
+
+// SetHitRegions records subsequent drawing tagged with SetHitID into h. A
+// nil h stops recording.
+func (ctx *Context) SetHitRegions(h *HitRegionIndex) {
+	ctx.hitRegions = h
+	ctx.updateHitRecording()
+}
+
+// GetHitRegions returns the hit region index drawing is recorded into, or
+// nil.
+func (ctx *Context) GetHitRegions() *HitRegionIndex {
+	return ctx.hitRegions
+}
+
+// SetHitID tags subsequent fills, strokes and outline text with id in the
+// hit region index. IDs must be non-zero; 0, the default, draws without
+// recording.
+func (ctx *Context) SetHitID(id int) {
+	ctx.hitID = id
+	ctx.updateHitRecording()
+}
+
+// GetHitID returns the ID subsequent drawing is tagged with.
+func (ctx *Context) GetHitID() int {
+	return ctx.hitID
+}
+
+func (ctx *Context) updateHitRecording() {
+	h, id := ctx.hitRegions, ctx.hitID
+	if h == nil || id == 0 {
+		ctx.agg2d.impl.SetCoverageFunc(nil)
+		return
+	}
+	ctx.agg2d.impl.SetCoverageFunc(func(x, y int, covers []uint8) {
+		h.record(id, x, y, covers)
+	})
+}
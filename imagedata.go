@@ -0,0 +1,35 @@
+package agg
+
+// imageDataPixels returns the pixels of img in the layout of an HTML canvas
+// ImageData: tightly packed rows of straight-alpha RGBA bytes. A tightly
+// packed straight-alpha RGBA8 image is returned without copying; every other
+// image is converted into a new slice, with premultiplied colors divided by
+// their alpha.
+func imageDataPixels(img *Image) []uint8 {
+	if img == nil || img.renBuf == nil {
+		return nil
+	}
+	n := img.width * img.height * 4
+	if img.format == ImageRGBA8 && img.alpha == AlphaStraight &&
+		img.pixelStep() == 4 && img.renBuf.Stride() == img.width*4 && len(img.Data) >= n {
+		return img.Data[:n]
+	}
+
+	pix := img.ToGoImage().Pix
+	if _, ok := img.format.channelOrder(); ok && img.alpha == AlphaPremultiplied {
+		for i := 0; i < len(pix); i += 4 {
+			a := int(pix[i+3])
+			if a == 255 {
+				continue
+			}
+			for c := i; c < i+3; c++ {
+				if a == 0 {
+					pix[c] = 0
+				} else {
+					pix[c] = uint8(min((int(pix[c])*255+a/2)/a, 255))
+				}
+			}
+		}
+	}
+	return pix
+}
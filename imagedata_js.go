@@ -0,0 +1,37 @@
+//go:build js && wasm
+
+package agg
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+// ToImageData returns the pixels of img as a new browser ImageData, ready for
+// the putImageData method of a canvas 2D context. Premultiplied images are
+// converted to the straight alpha ImageData uses. Only js/wasm builds have
+// it.
+func ToImageData(img *Image) (js.Value, error) {
+	if img == nil || img.renBuf == nil {
+		return js.Undefined(), errors.New("image or buffer is nil")
+	}
+	dst := js.Global().Get("ImageData").New(img.width, img.height)
+	if err := CopyToImageData(img, dst); err != nil {
+		return js.Undefined(), err
+	}
+	return dst, nil
+}
+
+// CopyToImageData copies the pixels of img into dst, an ImageData of the same
+// size. Reusing one ImageData across frames avoids allocating a new one for
+// every frame.
+func CopyToImageData(img *Image, dst js.Value) error {
+	if img == nil || img.renBuf == nil {
+		return errors.New("image or buffer is nil")
+	}
+	if dst.Get("width").Int() != img.width || dst.Get("height").Int() != img.height {
+		return errors.New("ImageData size does not match the image")
+	}
+	js.CopyBytesToJS(dst.Get("data"), imageDataPixels(img))
+	return nil
+}
@@ -0,0 +1,71 @@
+package imagefx
+
+import (
+	"errors"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// Histogram counts the pixels of an image per 8-bit value of each channel
+// and of their luminance.
+type Histogram struct {
+	R, G, B, A, Luma [256]int
+}
+
+// ComputeHistogram returns the histogram of a 32-bit RGBA image in any
+// channel order, such as RGBA8 or BGRA8. Color channels count straight
+// values, so premultiplied pixels are divided by their alpha first.
+func ComputeHistogram(img *agg.Image) (*Histogram, error) {
+	if img == nil {
+		return nil, errors.New("image is nil")
+	}
+	o, ok := colorOffsets(img)
+	if !ok {
+		return nil, errors.New("histograms need 32-bit RGBA images")
+	}
+	pre := img.AlphaMode() == agg.AlphaPremultiplied
+	h := &Histogram{}
+	for y := 0; y < img.Height(); y++ {
+		row := img.Data[y*img.Stride():]
+		for x := 0; x < img.Width()*4; x += 4 {
+			r, g, b, a := row[x+o[0]], row[x+o[1]], row[x+o[2]], row[x+o[3]]
+			if pre {
+				r, g, b = demultiply(r, a), demultiply(g, a), demultiply(b, a)
+			}
+			h.R[r]++
+			h.G[g]++
+			h.B[b]++
+			h.A[a]++
+			h.Luma[luma(r, g, b)]++
+		}
+	}
+	return h, nil
+}
+
+// AutoLevels returns levels that stretch the luminance range of the
+// histogram to the full output range, ignoring the darkest and brightest
+// clip fraction of the pixels, such as 0.005, as outliers.
+func (h *Histogram) AutoLevels(clip float64) Levels {
+	total := 0
+	for _, n := range h.Luma {
+		total += n
+	}
+	skip := int(clip * float64(total))
+	l := DefaultLevels()
+	for i, seen := 0, 0; i < 256; i++ {
+		if seen += h.Luma[i]; seen > skip {
+			l.InBlack = uint8(i)
+			break
+		}
+	}
+	for i, seen := 255, 0; i >= 0; i-- {
+		if seen += h.Luma[i]; seen > skip {
+			l.InWhite = uint8(i)
+			break
+		}
+	}
+	if l.InWhite < l.InBlack {
+		l.InBlack, l.InWhite = 0, 255
+	}
+	return l
+}
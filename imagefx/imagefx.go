@@ -0,0 +1,196 @@
+// Package imagefx applies basic raster adjustments to agg images: invert,
+// binary threshold, levels and curves, plus histogram computation. Every
+// adjustment reads a source image and writes a destination of the same
+// size, which may be the source itself to adjust it in place:
+//
+//	imagefx.ApplyLevels(img, img, imagefx.Levels{InBlack: 20, InWhite: 230, Gamma: 1.2, OutWhite: 255})
+//
+// Adjustments work on 32-bit RGBA images such as RGBA8, BGRA8 and ARGB8;
+// the destination may use any order. They change the color channels and
+// copy alpha unchanged. Tables apply to straight colors: premultiplied
+// pixels, the default and what drawing produces, are divided by their alpha
+// first and multiplied again on output, following each image's AlphaMode.
+package imagefx
+
+import (
+	"errors"
+	"math"
+
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/internal/ctrl/gamma"
+)
+
+// Invert replaces every color channel c of src with 255 - c.
+func Invert(dst, src *agg.Image) error {
+	var table [256]uint8
+	for i := range table {
+		table[i] = uint8(255 - i)
+	}
+	return ApplyTable(dst, src, &table)
+}
+
+// Threshold turns src into black and white: pixels whose luminance is at
+// least level become white, the others black.
+func Threshold(dst, src *agg.Image, level uint8) error {
+	return mapPixels(dst, src, func(r, g, b uint8) (uint8, uint8, uint8) {
+		if luma(r, g, b) >= level {
+			return 255, 255, 255
+		}
+		return 0, 0, 0
+	})
+}
+
+// Levels maps the input range InBlack..InWhite to the output range
+// OutBlack..OutWhite, bending the midtones with Gamma as the levels dialog of
+// photo editors does. Gammas above 1 brighten the midtones; 0 means 1.
+// Input values outside the input range are clamped to it.
+type Levels struct {
+	InBlack, InWhite   uint8
+	Gamma              float64
+	OutBlack, OutWhite uint8
+}
+
+// DefaultLevels returns the identity levels.
+func DefaultLevels() Levels {
+	return Levels{InWhite: 255, Gamma: 1, OutWhite: 255}
+}
+
+// Table returns the 8-bit lookup table of the levels.
+func (l Levels) Table() *[256]uint8 {
+	g := l.Gamma
+	if !(g > 0) {
+		g = 1
+	}
+	lo, hi := float64(l.InBlack), float64(l.InWhite)
+	outLo, outHi := float64(l.OutBlack), float64(l.OutWhite)
+	var table [256]uint8
+	for i := range table {
+		t := 0.0
+		switch {
+		case hi > lo:
+			t = min(max((float64(i)-lo)/(hi-lo), 0), 1)
+		case float64(i) >= lo:
+			t = 1
+		}
+		table[i] = uint8(math.Round(outLo + math.Pow(t, 1/g)*(outHi-outLo)))
+	}
+	return &table
+}
+
+// ApplyLevels adjusts the color channels of src with l.
+func ApplyLevels(dst, src *agg.Image, l Levels) error {
+	return ApplyTable(dst, src, l.Table())
+}
+
+// Curve is a tone curve: a cubic spline from (0, 0) to (1, 1) shaped by two
+// control points, the curve edited by the gamma control of the AGG demos.
+type Curve struct {
+	spline *gamma.GammaSpline
+}
+
+// NewCurve returns the curve with the gamma control values kx1, ky1, kx2 and
+// ky2, each in 0..2. The first control point lies at (kx1, ky1) / 4 and the
+// second at 1 - (kx2, ky2) / 4; all ones is the identity.
+func NewCurve(kx1, ky1, kx2, ky2 float64) *Curve {
+	c := &Curve{spline: gamma.NewGammaSpline()}
+	c.spline.Values(kx1, ky1, kx2, ky2)
+	return c
+}
+
+// Values returns the control values of the curve.
+func (c *Curve) Values() (kx1, ky1, kx2, ky2 float64) {
+	return c.spline.GetValues()
+}
+
+// Map returns the curve's output for the 8-bit input v.
+func (c *Curve) Map(v uint8) uint8 {
+	return c.spline.ApplyGamma(v)
+}
+
+// ApplyCurve maps the color channels of src through c.
+func ApplyCurve(dst, src *agg.Image, c *Curve) error {
+	if c == nil {
+		return errors.New("curve is nil")
+	}
+	var table [256]uint8
+	copy(table[:], c.spline.Gamma())
+	return ApplyTable(dst, src, &table)
+}
+
+// ApplyTable maps every color channel c of src to table[c], the common form
+// of all tone adjustments.
+func ApplyTable(dst, src *agg.Image, table *[256]uint8) error {
+	return mapPixels(dst, src, func(r, g, b uint8) (uint8, uint8, uint8) {
+		return table[r], table[g], table[b]
+	})
+}
+
+// mapPixels writes fn of every source pixel's color to dst, copying alpha.
+func mapPixels(dst, src *agg.Image, fn func(r, g, b uint8) (uint8, uint8, uint8)) error {
+	if dst == nil || src == nil {
+		return errors.New("image is nil")
+	}
+	so, ok := colorOffsets(src)
+	do, dok := colorOffsets(dst)
+	if !ok || !dok {
+		return errors.New("adjustments need 32-bit RGBA images")
+	}
+	w, h := src.Width(), src.Height()
+	if dst.Width() != w || dst.Height() != h {
+		return errors.New("destination size differs from the source")
+	}
+	srcPre := src.AlphaMode() == agg.AlphaPremultiplied
+	dstPre := dst.AlphaMode() == agg.AlphaPremultiplied
+	for y := 0; y < h; y++ {
+		s := src.Data[y*src.Stride():]
+		d := dst.Data[y*dst.Stride():]
+		for x := 0; x < w*4; x += 4 {
+			r, g, b, a := s[x+so[0]], s[x+so[1]], s[x+so[2]], s[x+so[3]]
+			if srcPre {
+				r, g, b = demultiply(r, a), demultiply(g, a), demultiply(b, a)
+			}
+			r, g, b = fn(r, g, b)
+			if dstPre {
+				r, g, b = multiply(r, a), multiply(g, a), multiply(b, a)
+			}
+			d[x+do[0]], d[x+do[1]], d[x+do[2]], d[x+do[3]] = r, g, b, a
+		}
+	}
+	return nil
+}
+
+// demultiply returns the straight value of color c premultiplied by alpha a.
+// Fully transparent pixels have no color and give 0.
+func demultiply(c, a uint8) uint8 {
+	if a == 0 {
+		return 0
+	}
+	return uint8(min((int(c)*255+int(a)/2)/int(a), 255))
+}
+
+// multiply scales c by a/255, rounding as AGG does.
+func multiply(c, a uint8) uint8 {
+	t := int(c)*int(a) + 128
+	return uint8(((t >> 8) + t) >> 8)
+}
+
+// colorOffsets returns the offsets of red, green, blue and alpha in the
+// pixels of img.
+func colorOffsets(img *agg.Image) (o [4]int, ok bool) {
+	switch img.Format() {
+	case agg.ImageRGBA8:
+		return [4]int{0, 1, 2, 3}, true
+	case agg.ImageBGRA8:
+		return [4]int{2, 1, 0, 3}, true
+	case agg.ImageARGB8:
+		return [4]int{1, 2, 3, 0}, true
+	case agg.ImageABGR8:
+		return [4]int{3, 2, 1, 0}, true
+	}
+	return o, false
+}
+
+// luma returns the BT.601 luminance AGG uses to convert RGB to gray.
+func luma(r, g, b uint8) uint8 {
+	return uint8((77*int(r) + 150*int(g) + 29*int(b)) >> 8)
+}
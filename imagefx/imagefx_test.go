@@ -0,0 +1,147 @@
+package imagefx
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// testImage returns a one-row RGBA image with the given pixels.
+func testImage(pix ...[4]uint8) *agg.Image {
+	img := agg.CreateImage(len(pix), 1)
+	for i, p := range pix {
+		copy(img.Data[i*4:], p[:])
+	}
+	return img
+}
+
+func pixel(img *agg.Image, x int) [4]uint8 {
+	return [4]uint8(img.Data[x*4 : x*4+4])
+}
+
+func TestInvertInPlace(t *testing.T) {
+	// Pixel 0 is straight (0, 100, 255) at half alpha, premultiplied.
+	img := testImage([4]uint8{0, 50, 128, 128}, [4]uint8{10, 20, 30, 255})
+	if err := Invert(img, img); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pixel(img, 0), [4]uint8{128, 78, 0, 128}; got != want {
+		t.Errorf("pixel 0 = %v, want %v", got, want)
+	}
+	if got, want := pixel(img, 1), [4]uint8{245, 235, 225, 255}; got != want {
+		t.Errorf("pixel 1 = %v, want %v", got, want)
+	}
+}
+
+func TestInvertDrawnTranslucent(t *testing.T) {
+	ctx := agg.NewContext(4, 4)
+	ctx.Clear(agg.Transparent)
+	ctx.SetColor(agg.NewColor(255, 0, 0, 127))
+	ctx.FillRectangle(0, 0, 4, 4)
+	img := ctx.GetImage()
+	if got := pixel(img, 0); got != [4]uint8{127, 0, 0, 127} {
+		t.Fatalf("drawn pixel = %v", got)
+	}
+	if err := Invert(img, img); err != nil {
+		t.Fatal(err)
+	}
+	// Straight red inverts to cyan; color stays within alpha.
+	if got, want := pixel(img, 0), [4]uint8{0, 127, 127, 127}; got != want {
+		t.Errorf("inverted = %v, want %v", got, want)
+	}
+
+	// A straight destination receives the unscaled result.
+	src := testImage([4]uint8{127, 0, 0, 127})
+	dst := testImage([4]uint8{})
+	if err := dst.Demultiply(); err != nil {
+		t.Fatal(err)
+	}
+	if err := Invert(dst, src); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pixel(dst, 0), [4]uint8{0, 255, 255, 127}; got != want {
+		t.Errorf("straight destination = %v, want %v", got, want)
+	}
+}
+
+func TestThresholdIntoBGRA(t *testing.T) {
+	src := testImage([4]uint8{255, 0, 0, 255}, [4]uint8{0, 200, 0, 200})
+	dst := agg.NewBGRAImage(make([]uint8, 8), 2, 1, 8)
+	if err := Threshold(dst, src, 128); err != nil {
+		t.Fatal(err)
+	}
+	// Red has luminance 76, green 149.
+	if got, want := pixel(dst, 0), [4]uint8{0, 0, 0, 255}; got != want {
+		t.Errorf("red = %v, want %v", got, want)
+	}
+	if got, want := pixel(dst, 1), [4]uint8{200, 200, 200, 200}; got != want {
+		t.Errorf("green = %v, want %v", got, want)
+	}
+	if pixel(src, 0) != [4]uint8{255, 0, 0, 255} {
+		t.Error("source changed")
+	}
+}
+
+func TestLevels(t *testing.T) {
+	if table := DefaultLevels().Table(); table[0] != 0 || table[77] != 77 || table[255] != 255 {
+		t.Errorf("default levels are not the identity: %d %d %d", table[0], table[77], table[255])
+	}
+
+	l := Levels{InBlack: 50, InWhite: 150, Gamma: 1, OutBlack: 10, OutWhite: 210}
+	table := l.Table()
+	for _, c := range [][2]int{{0, 10}, {50, 10}, {100, 110}, {150, 210}, {255, 210}} {
+		if table[c[0]] != uint8(c[1]) {
+			t.Errorf("levels(%d) = %d, want %d", c[0], table[c[0]], c[1])
+		}
+	}
+
+	l = DefaultLevels()
+	l.Gamma = 2
+	if v := l.Table()[64]; v <= 64 {
+		t.Errorf("gamma 2 midtone = %d, want brighter than 64", v)
+	}
+}
+
+func TestCurve(t *testing.T) {
+	identity := NewCurve(1, 1, 1, 1)
+	for _, v := range []uint8{0, 64, 200, 255} {
+		if got := identity.Map(v); int(got) < int(v)-1 || int(got) > int(v)+1 {
+			t.Errorf("identity curve(%d) = %d", v, got)
+		}
+	}
+
+	// Raising the first control point lifts the shadows.
+	lift := NewCurve(1, 1.8, 1, 1)
+	img := testImage([4]uint8{40, 40, 40, 255})
+	if err := ApplyCurve(img, img, lift); err != nil {
+		t.Fatal(err)
+	}
+	if p := pixel(img, 0); p[0] <= 40 || p[0] != lift.Map(40) || p[3] != 255 {
+		t.Errorf("lifted pixel = %v, want %d", p, lift.Map(40))
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	img := testImage([4]uint8{0, 0, 0, 255}, [4]uint8{255, 255, 255, 255}, [4]uint8{50, 50, 50, 128})
+	h, err := ComputeHistogram(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The translucent gray counts as its straight value 100.
+	if h.R[0] != 1 || h.G[255] != 1 || h.B[100] != 1 || h.A[255] != 2 || h.A[128] != 1 {
+		t.Errorf("channel counts wrong: %d %d %d %d %d", h.R[0], h.G[255], h.B[100], h.A[255], h.A[128])
+	}
+	if h.Luma[0] != 1 || h.Luma[255] != 1 || h.Luma[100] != 1 {
+		t.Errorf("luma counts wrong: %d %d %d", h.Luma[0], h.Luma[255], h.Luma[100])
+	}
+
+	narrow := testImage([4]uint8{60, 60, 60, 255}, [4]uint8{90, 90, 90, 255}, [4]uint8{180, 180, 180, 255})
+	h, _ = ComputeHistogram(narrow)
+	if l := h.AutoLevels(0); l.InBlack != 60 || l.InWhite != 180 {
+		t.Errorf("auto levels = %d..%d, want 60..180", l.InBlack, l.InWhite)
+	}
+
+	if _, err := ComputeHistogram(agg.CreateGrayImage(2, 2)); err == nil {
+		t.Error("expected an error for a gray image")
+	}
+}
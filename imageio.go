@@ -0,0 +1,81 @@
+package agg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/qoi"
+	"github.com/MeKo-Christian/agg_go/internal/webp"
+)
+
+// SaveTo saves the image in the format given by the file extension:
+// .png, .jpg or .jpeg (default quality), .qoi, .webp (lossless), or .gif
+// (256 colors, no dithering; see EncodeGIF). Gray images are written as RGBA
+// to QOI and WebP, which have no gray format.
+func (img *Image) SaveTo(filename string) error {
+	var encode func(io.Writer) error
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".png":
+		encode = func(w io.Writer) error { return img.EncodePNG(w, EncodeOptions{}) }
+	case ".jpg", ".jpeg":
+		encode = func(w io.Writer) error { return img.EncodeJPEG(w, EncodeOptions{}) }
+	case ".qoi":
+		encode = img.EncodeQOI
+	case ".webp":
+		encode = img.EncodeWebP
+	case ".gif":
+		encode = func(w io.Writer) error { return img.EncodeGIF(w, PaletteOptions{}) }
+	default:
+		return fmt.Errorf("unsupported image format %q", ext)
+	}
+	return saveEncoded(filename, encode)
+}
+
+// EncodeQOI writes the image to w in the QOI format, a lossless format that
+// encodes much faster than PNG, suited to intermediate captures. QOI stores
+// straight alpha, so premultiplied colors are divided by alpha first.
+func (img *Image) EncodeQOI(w io.Writer) error {
+	if img == nil || img.renBuf == nil {
+		return errors.New("image or buffer is nil")
+	}
+	m := img.toNRGBA()
+	return qoi.Encode(w, m.Pix, img.width, img.height, m.Stride)
+}
+
+// EncodeWebP writes the image to w as lossless WebP. The encoder favors
+// speed over size: it matches runs of repeated pixels and rows but applies
+// none of the WebP transforms, so photos compress worse than with cwebp,
+// while flat graphics such as screenshots stay small. Colors are written with
+// straight alpha, as WebP stores them.
+func (img *Image) EncodeWebP(w io.Writer) error {
+	if img == nil || img.renBuf == nil {
+		return errors.New("image or buffer is nil")
+	}
+	m := img.toNRGBA()
+	return webp.Encode(w, m.Pix, img.width, img.height, m.Stride)
+}
+
+// DecodeQOI reads a QOI image into a new premultiplied RGBA image.
+func DecodeQOI(r io.Reader) (*Image, error) {
+	pix, width, height, err := qoi.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	premultiplyPixels(pix, width, height, width*4, color.OrderRGBA)
+	return NewImage(pix, width, height, width*4), nil
+}
+
+// LoadQOI reads a QOI file like DecodeQOI.
+func LoadQOI(filename string) (*Image, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeQOI(f)
+}
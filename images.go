@@ -1,15 +1,19 @@
 package agg
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"image"
 	_ "image/gif" // Import for gif decoding
 	"image/jpeg"
 	"image/png"
+	"math"
 	"os"
 
 	"github.com/MeKo-Christian/agg_go/internal/agg2d"
 	"github.com/MeKo-Christian/agg_go/internal/buffer"
+	"github.com/MeKo-Christian/agg_go/internal/color"
 )
 
 // ImageFilter represents different image filtering options
@@ -47,13 +51,130 @@ const (
 	ResampleOnZoomOut ImageResample = ImageResample(agg2d.ResampleOnZoomOut)
 )
 
+// ImageFormat is the pixel layout of an Image.
+type ImageFormat int
+
+const (
+	// ImageRGBA8 stores four bytes per pixel: R, G, B, A.
+	ImageRGBA8 ImageFormat = iota
+	// ImageGray8 stores one byte per pixel, as used for coverage masks.
+	ImageGray8
+	// ImageGray16 stores two bytes per pixel, big-endian like image.Gray16.
+	ImageGray16
+	// ImageBGRA8 stores four bytes per pixel: B, G, R, A, the layout of X11,
+	// SDL and Windows surfaces. It can be drawn from and rendered into like
+	// ImageRGBA8, without conversion.
+	ImageBGRA8
+	// ImageRGB8 stores three bytes per pixel: R, G, B, without alpha.
+	ImageRGB8
+	// ImageRGB565 stores two bytes per pixel, a little-endian 16-bit value
+	// with 5 bits of red, 6 of green and 5 of blue, as used by embedded
+	// displays. It has no alpha.
+	ImageRGB565
+	// ImageARGB8 stores four bytes per pixel: A, R, G, B. It is the memory
+	// layout of Cairo's and Skia's native ARGB32 surfaces on big-endian
+	// machines; see NativeARGB32.
+	ImageARGB8
+	// ImageABGR8 stores four bytes per pixel: A, B, G, R.
+	ImageABGR8
+)
+
+// BytesPerPixel returns the pixel size of the format.
+func (f ImageFormat) BytesPerPixel() int {
+	switch f {
+	case ImageGray8:
+		return 1
+	case ImageGray16, ImageRGB565:
+		return 2
+	case ImageRGB8:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// imageTargetFormats maps the formats that can be rendered into to the
+// renderer's pixel format registry.
+var imageTargetFormats = map[ImageFormat]agg2d.TargetFormat{
+	ImageRGBA8:  agg2d.TargetRGBA32,
+	ImageBGRA8:  agg2d.TargetBGRA32,
+	ImageRGB8:   agg2d.TargetRGB24,
+	ImageGray8:  agg2d.TargetGray8,
+	ImageRGB565: agg2d.TargetRGB565,
+	ImageARGB8:  agg2d.TargetARGB32,
+	ImageABGR8:  agg2d.TargetABGR32,
+}
+
+// NativeARGB32 returns the format of pixels stored as native-endian 32-bit
+// values with alpha in the top byte, then red, green and blue, as in Cairo's
+// CAIRO_FORMAT_ARGB32, Skia's N32 and Windows DIB surfaces: ImageBGRA8 on
+// little-endian machines, ImageARGB8 on big-endian ones. These surfaces hold
+// premultiplied colors, as drawing expects.
+func NativeARGB32() ImageFormat {
+	if littleEndian {
+		return ImageBGRA8
+	}
+	return ImageARGB8
+}
+
+// NativeABGR32 is NativeARGB32 for 32-bit values with alpha in the top byte,
+// then blue, green and red: ImageRGBA8 on little-endian machines, ImageABGR8
+// on big-endian ones.
+func NativeABGR32() ImageFormat {
+	if littleEndian {
+		return ImageRGBA8
+	}
+	return ImageABGR8
+}
+
+// littleEndian reports whether the machine stores the low byte of a value
+// first.
+var littleEndian = binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+
+// channelOrder returns the byte offsets of the channels of the 32-bit RGBA
+// formats in any order, and false for the other formats.
+func (f ImageFormat) channelOrder() (color.ColorOrder, bool) {
+	switch f {
+	case ImageRGBA8:
+		return color.OrderRGBA, true
+	case ImageBGRA8:
+		return color.OrderBGRA, true
+	case ImageARGB8:
+		return color.OrderARGB, true
+	case ImageABGR8:
+		return color.OrderABGR, true
+	}
+	return color.ColorOrder{}, false
+}
+
+// targetFormat returns the renderer's pixel format for the image, and false
+// when it cannot be rendered into.
+func (img *Image) targetFormat() (agg2d.TargetFormat, bool) {
+	f, ok := imageTargetFormats[img.format]
+	return f, ok && img.pixelStep() == img.format.BytesPerPixel()
+}
+
+// converted reports whether the format is read through the renderer's pixel
+// conversions rather than as RGBA, BGRA or gray bytes.
+func (f ImageFormat) converted() bool {
+	return f == ImageRGB8 || f == ImageRGB565
+}
+
 // Image represents a raster image that can be used as a rendering target.
 // This matches the C++ Agg2D::Image structure.
+//
+// Image implements draw.Image, so code written against the standard image
+// interfaces reads and writes its pixels in place; see ColorModel.
 type Image struct {
 	renBuf *buffer.RenderingBuffer[uint8]
-	Data   []uint8 // Raw pixel data (RGBA format)
+	Data   []uint8 // Raw pixel data (RGBA format, or one byte per pixel for ImageGray8)
 	width  int     // Width in pixels
 	height int     // Height in pixels
+	format ImageFormat
+	step   int // Bytes between pixels of a channel view; 0 means the format's pixel size
+	mips   []*Image
+	alpha  AlphaMode
+	linear bool // Colors are linear light rather than sRGB
 }
 
 // NewImage creates a new image with the specified buffer.
@@ -68,6 +189,78 @@ func NewImage(buf []uint8, width, height, stride int) *Image {
 	return img
 }
 
+// NewGrayImage creates a Gray8 image over buf, one byte per pixel.
+func NewGrayImage(buf []uint8, width, height, stride int) *Image {
+	img := NewImage(buf, width, height, stride)
+	img.format = ImageGray8
+	return img
+}
+
+// CreateGrayImage creates a new blank Gray8 image.
+func CreateGrayImage(width, height int) *Image {
+	return NewGrayImage(make([]uint8, width*height), width, height, width)
+}
+
+// NewGray16Image creates a Gray16 image over buf, two big-endian bytes per
+// pixel.
+func NewGray16Image(buf []uint8, width, height, stride int) *Image {
+	img := NewImage(buf, width, height, stride)
+	img.format = ImageGray16
+	return img
+}
+
+// NewImageWithFormat creates an image over buf with pixels in format.
+func NewImageWithFormat(buf []uint8, width, height, stride int, format ImageFormat) *Image {
+	img := NewImage(buf, width, height, stride)
+	img.format = format
+	return img
+}
+
+// CreateImageWithFormat creates a new blank image in format.
+func CreateImageWithFormat(width, height int, format ImageFormat) *Image {
+	stride := width * format.BytesPerPixel()
+	return NewImageWithFormat(make([]uint8, height*stride), width, height, stride, format)
+}
+
+// NewBGRAImage creates a BGRA8 image over buf, four bytes per pixel in B, G,
+// R, A order.
+func NewBGRAImage(buf []uint8, width, height, stride int) *Image {
+	img := NewImage(buf, width, height, stride)
+	img.format = ImageBGRA8
+	return img
+}
+
+// AlphaView returns a Gray8 image whose gray levels are the alpha channel of
+// img, a 32-bit image such as RGBA8 or BGRA8. The view shares img's memory:
+// nothing is copied, and later changes to either image show through the
+// other. It can be used wherever a Gray8 image is read, such as a mask for
+// ApplyMask. For other formats AlphaView returns nil.
+func (img *Image) AlphaView() *Image {
+	if img == nil || len(img.Data) < 4 {
+		return nil
+	}
+	o, ok := img.format.channelOrder()
+	if !ok {
+		return nil
+	}
+	view := NewGrayImage(img.Data[o.A:], img.width, img.height, img.renBuf.Stride())
+	view.step = 4
+	return view
+}
+
+// pixelStep returns the number of bytes between horizontally adjacent pixels.
+func (img *Image) pixelStep() int {
+	if img.step > 0 {
+		return img.step
+	}
+	return img.format.BytesPerPixel()
+}
+
+// Format returns the pixel layout of the image.
+func (img *Image) Format() ImageFormat {
+	return img.format
+}
+
 // Width returns the image width.
 func (img *Image) Width() int {
 	return img.width
@@ -90,26 +283,159 @@ func (img *Image) Attach(buf []uint8, width, height, stride int) {
 	img.Data = buf
 	img.width = width
 	img.height = height
+	img.step = 0
+	img.mips = nil
 }
 
-// ToInternalImage converts this Image to the internal agg2d.Image type.
+// ToInternalImage converts this Image to the internal agg2d.Image type,
+// with its mipmaps.
 func (img *Image) ToInternalImage() *agg2d.Image {
 	if img == nil {
 		return nil
 	}
-	return agg2d.NewImage(img.Data, img.width, img.height, img.renBuf.Stride())
+	internal := img.toInternalImage()
+	if len(img.mips) > 0 {
+		levels := make([]*agg2d.Image, len(img.mips))
+		for i, m := range img.mips {
+			levels[i] = m.toInternalImage()
+		}
+		internal.SetMipmaps(levels)
+	}
+	return internal
+}
+
+func (img *Image) toInternalImage() *agg2d.Image {
+	switch img.format {
+	case ImageGray8, ImageGray16, ImageRGB8, ImageRGB565:
+		// The renderer reads RGBA; expand to opaque RGBA pixels.
+		rgba := img.ToGoImage()
+		return agg2d.NewImage(rgba.Pix, img.width, img.height, rgba.Stride)
+	}
+	pix := img.Data
+	if img.alpha == AlphaStraight {
+		// Drawing filters and blends premultiplied colors.
+		pix = img.premultipliedData()
+	}
+	internal := agg2d.NewImage(pix, img.width, img.height, img.renBuf.Stride())
+	switch img.format {
+	case ImageBGRA8:
+		internal.SetOrder(agg2d.OrderBGRA)
+	case ImageARGB8:
+		internal.SetOrder(agg2d.OrderARGB)
+	case ImageABGR8:
+		internal.SetOrder(agg2d.OrderABGR)
+	}
+	return internal
+}
+
+// Downscale returns a copy of the image shrunk by an integer factor, in the
+// same format. Each pixel is the average of the factor x factor block it
+// covers, so no source pixel is skipped as in resampling with a small filter;
+// the size is rounded up, with the blocks at the right and bottom edges
+// averaging what they cover. As for drawing, colors are best premultiplied.
+// A factor below 1 counts as 1 and gives a plain copy.
+func (img *Image) Downscale(factor int) *Image {
+	if img == nil {
+		return nil
+	}
+	channels, wide := 4, false
+	switch img.format {
+	case ImageGray8:
+		channels = 1
+	case ImageGray16:
+		channels, wide = 1, true
+	case ImageRGB8:
+		channels = 3
+	case ImageRGB565:
+		// Packed channels: average the RGBA expansion and pack the result.
+		rgba := img.ToGoImage()
+		small := NewImage(rgba.Pix, img.width, img.height, rgba.Stride).Downscale(factor)
+		out := CreateImageWithFormat(small.width, small.height, ImageRGB565)
+		for y := 0; y < small.height; y++ {
+			for x := 0; x < small.width; x++ {
+				agg2d.TargetRGB565.Encode(out.Data[y*out.renBuf.Stride()+x*2:], [4]uint8(small.Data[y*small.renBuf.Stride()+x*4:]))
+			}
+		}
+		out.linear = img.linear
+		return out
+	}
+	src, stride := img.Data, img.renBuf.Stride()
+	if step := img.pixelStep(); step != img.format.BytesPerPixel() {
+		// A channel view: gather its pixels first.
+		src = make([]uint8, img.width*img.height)
+		for y := 0; y < img.height; y++ {
+			for x := 0; x < img.width; x++ {
+				src[y*img.width+x] = img.Data[y*stride+x*step]
+			}
+		}
+		stride = img.width
+	}
+	pix, w, h := agg2d.DownscaleBox(src, img.width, img.height, stride, channels, wide, factor)
+	out := NewImage(pix, w, h, w*img.format.BytesPerPixel())
+	out.format = img.format
+	out.alpha, out.linear = img.alpha, img.linear
+	return out
+}
+
+// GenerateMipmaps builds the image's mipmaps, copies halved in size with
+// Downscale down to 1 x 1, and returns them from the largest. Drawing the
+// image or filling with it as a pattern then samples the level closest to
+// the drawn size when shrinking it by more than half, which keeps fine
+// detail from shimmering as the scale changes. The levels are copies: call
+// GenerateMipmaps again after changing the image's pixels.
+func (img *Image) GenerateMipmaps() []*Image {
+	if img == nil {
+		return nil
+	}
+	img.mips = img.mips[:0]
+	level := img
+	for level.width > 1 || level.height > 1 {
+		level = level.Downscale(2)
+		img.mips = append(img.mips, level)
+	}
+	return img.mips
+}
+
+// Mipmaps returns the levels built by GenerateMipmaps, or nil.
+func (img *Image) Mipmaps() []*Image {
+	return img.mips
 }
 
-// ToGoImage converts the AGG image to a standard Go image.RGBA.
+// ToGoImage converts the AGG image to a standard Go image.RGBA. Straight-alpha
+// images are premultiplied on the way, as image.RGBA requires.
 func (img *Image) ToGoImage() *image.RGBA {
 	if img == nil {
 		return nil
 	}
 
 	goImg := image.NewRGBA(image.Rect(0, 0, img.width, img.height))
-
-	// Copy pixel data from AGG format (RGBA) to Go image format (RGBA)
 	stride := img.renBuf.Stride()
+
+	if img.format == ImageGray8 || img.format == ImageGray16 {
+		bpp := img.pixelStep()
+		for y := 0; y < img.height; y++ {
+			for x := 0; x < img.width; x++ {
+				v := img.Data[y*stride+x*bpp] // high byte for Gray16
+				copy(goImg.Pix[y*goImg.Stride+x*4:], []uint8{v, v, v, 255})
+			}
+		}
+		return goImg
+	}
+
+	if img.format.converted() {
+		f := imageTargetFormats[img.format]
+		bpp := img.format.BytesPerPixel()
+		for y := 0; y < img.height; y++ {
+			for x := 0; x < img.width; x++ {
+				c := f.Decode(img.Data[y*stride+x*bpp:])
+				copy(goImg.Pix[y*goImg.Stride+x*4:], c[:])
+			}
+		}
+		return goImg
+	}
+
+	// Copy pixel data from AGG format (RGBA in any order) to Go image format (RGBA)
+	o, _ := img.format.channelOrder()
 	for y := 0; y < img.height; y++ {
 		srcRow := y * stride
 		dstRow := y * goImg.Stride
@@ -117,19 +443,145 @@ func (img *Image) ToGoImage() *image.RGBA {
 			srcIdx := srcRow + x*4
 			dstIdx := dstRow + x*4
 
-			// AGG format is RGBA, Go image is also RGBA, so direct copy
 			if srcIdx+3 < len(img.Data) && dstIdx+3 < len(goImg.Pix) {
-				goImg.Pix[dstIdx] = img.Data[srcIdx]     // R
-				goImg.Pix[dstIdx+1] = img.Data[srcIdx+1] // G
-				goImg.Pix[dstIdx+2] = img.Data[srcIdx+2] // B
-				goImg.Pix[dstIdx+3] = img.Data[srcIdx+3] // A
+				goImg.Pix[dstIdx] = img.Data[srcIdx+o.R]   // R
+				goImg.Pix[dstIdx+1] = img.Data[srcIdx+o.G] // G
+				goImg.Pix[dstIdx+2] = img.Data[srcIdx+o.B] // B
+				goImg.Pix[dstIdx+3] = img.Data[srcIdx+o.A] // A
 			}
 		}
 	}
+	if img.alpha == AlphaStraight {
+		premultiplyPixels(goImg.Pix, img.width, img.height, goImg.Stride, color.OrderRGBA)
+	}
 
 	return goImg
 }
 
+// MaskOptions adjusts how ApplyMaskWithOptions reads the mask.
+type MaskOptions struct {
+	// Invert uses 255 minus the mask value, keeping what the mask covers out.
+	Invert bool
+	// OffsetX and OffsetY place the mask's top-left corner in the image.
+	// Pixels the mask does not reach count as mask value 0.
+	OffsetX, OffsetY int
+}
+
+// maskValue returns the 8-bit mask value of pixel (x, y): the gray level of
+// Gray8 and Gray16 images, the alpha of 32-bit RGBA images.
+func (img *Image) maskValue(x, y int) uint8 {
+	i := y*img.renBuf.Stride() + x*img.pixelStep()
+	if o, ok := img.format.channelOrder(); ok {
+		i += o.A
+	}
+	return img.Data[i]
+}
+
+// ApplyMask multiplies the alpha channel of a 32-bit RGBA image, in any
+// channel order, by a mask, such as one from RenderMask. Gray masks use their
+// gray level, 32-bit masks their alpha. The image is modified in place.
+func (img *Image) ApplyMask(mask *Image) error {
+	return img.ApplyMaskWithOptions(mask, MaskOptions{})
+}
+
+// ApplyMaskWithOptions is ApplyMask with an inverted and/or offset mask.
+func (img *Image) ApplyMaskWithOptions(mask *Image, opts MaskOptions) error {
+	if img == nil || mask == nil {
+		return errors.New("image or mask is nil")
+	}
+	o, ok := img.format.channelOrder()
+	if !ok {
+		return errors.New("ApplyMask needs a 32-bit RGBA image")
+	}
+
+	stride := img.renBuf.Stride()
+	for y := 0; y < img.height; y++ {
+		my := y - opts.OffsetY
+		for x := 0; x < img.width; x++ {
+			mx := x - opts.OffsetX
+			m := 0
+			if mx >= 0 && mx < mask.width && my >= 0 && my < mask.height {
+				m = int(mask.maskValue(mx, my))
+			}
+			if opts.Invert {
+				m = 255 - m
+			}
+			a := &img.Data[y*stride+x*4+o.A]
+			*a = uint8((int(*a)*m + 127) / 255)
+		}
+	}
+	return nil
+}
+
+// channelOffsets returns the byte offsets within a pixel of the channels in
+// ch, for 32-bit RGBA images.
+func (img *Image) channelOffsets(ch ChannelMask) []int {
+	o, _ := img.format.channelOrder()
+	var offs []int
+	for _, c := range []struct {
+		bit ChannelMask
+		off int
+	}{{ChannelRed, o.R}, {ChannelGreen, o.G}, {ChannelBlue, o.B}, {ChannelAlpha, o.A}} {
+		if ch&c.bit != 0 {
+			offs = append(offs, c.off)
+		}
+	}
+	return offs
+}
+
+// SetChannel sets the channels in ch of every pixel of a 32-bit RGBA image,
+// in any channel order, to value, leaving the other channels as they are.
+func (img *Image) SetChannel(ch ChannelMask, value uint8) error {
+	if img == nil {
+		return errors.New("image is nil")
+	}
+	if _, ok := img.format.channelOrder(); !ok {
+		return errors.New("SetChannel needs a 32-bit RGBA image")
+	}
+
+	offs := img.channelOffsets(ch)
+	stride := img.renBuf.Stride()
+	for y := 0; y < img.height; y++ {
+		row := img.Data[y*stride : y*stride+img.width*4]
+		for x := 0; x < len(row); x += 4 {
+			for _, o := range offs {
+				row[x+o] = value
+			}
+		}
+	}
+	return nil
+}
+
+// SetChannelFromImage sets the channels in ch of every pixel of a 32-bit
+// RGBA image to the mask value of the same pixel of src: the gray level of a
+// Gray8 or Gray16 image, the alpha of a 32-bit image. Together with
+// AlphaView and Context.SetChannelMask it assembles images channel by
+// channel, such as a color image with an alpha rendered separately. Both
+// images must have the same size.
+func (img *Image) SetChannelFromImage(ch ChannelMask, src *Image) error {
+	if img == nil || src == nil {
+		return errors.New("image or source is nil")
+	}
+	if _, ok := img.format.channelOrder(); !ok {
+		return errors.New("SetChannelFromImage needs a 32-bit RGBA image")
+	}
+	if src.width != img.width || src.height != img.height {
+		return errors.New("source image size does not match")
+	}
+
+	offs := img.channelOffsets(ch)
+	stride := img.renBuf.Stride()
+	for y := 0; y < img.height; y++ {
+		for x := 0; x < img.width; x++ {
+			v := src.maskValue(x, y)
+			for _, o := range offs {
+				img.Data[y*stride+x*4+o] = v
+			}
+		}
+	}
+	return nil
+}
+
 // Context image methods
 
 // DrawImage draws an image at the specified coordinates.
@@ -168,11 +620,11 @@ func (ctx *Context) DrawImageTransformed(img *Image, transform *Transformations)
 		corners[i] = [2]float64{x, y}
 	}
 
-	// Create parallelogram from first three corners
+	// AGG parallelograms list the images of (x1,y1), (x2,y1) and (x2,y2).
 	parallelogram := []float64{
-		corners[0][0], corners[0][1], // First corner
-		corners[1][0], corners[1][1], // Second corner
-		corners[3][0], corners[3][1], // Fourth corner (opposite of second)
+		corners[0][0], corners[0][1], // Top-left
+		corners[1][0], corners[1][1], // Top-right
+		corners[2][0], corners[2][1], // Bottom-right (opposite of the first)
 	}
 
 	return ctx.agg2d.TransformImageParallelogram(img, 0, 0, img.Width(), img.Height(), parallelogram)
@@ -189,18 +641,25 @@ func (ctx *Context) DrawImageRegion(img *Image, srcX, srcY, srcW, srcH int, dstX
 
 // Image loading functions
 
-// LoadImageFromFile loads an image from a file.
+// LoadImageFromFile loads a PNG, JPEG, GIF or QOI image from a file. JPEG
+// files are turned upright according to their EXIF orientation, see
+// DecodeJPEG.
 func LoadImageFromFile(filename string) (*Image, error) {
-	file, err := os.Open(filename)
+	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
+	if bytes.HasPrefix(data, []byte("qoif")) {
+		return DecodeQOI(bytes.NewReader(data))
+	}
 
-	img, _, err := image.Decode(file)
+	img, format, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
 		return nil, err
 	}
+	if format == "jpeg" {
+		return orientImage(rgbaImage(img), jpegOrientation(data)), nil
+	}
 
 	return NewImageFromStandardImage(img)
 }
@@ -275,20 +734,56 @@ func (img *Image) ToStandardImage() (image.Image, error) {
 	stride := img.renBuf.Stride()
 
 	bounds := image.Rect(0, 0, width, height)
-	stdImg := image.NewRGBA(bounds)
+	buffer := img.renBuf.Buf()
+
+	switch img.format {
+	case ImageGray8:
+		gray := image.NewGray(bounds)
+		step := img.pixelStep()
+		for y := 0; y < height; y++ {
+			if step == 1 {
+				copy(gray.Pix[y*gray.Stride:][:width], buffer[y*stride:])
+				continue
+			}
+			for x := 0; x < width; x++ {
+				gray.Pix[y*gray.Stride+x] = buffer[y*stride+x*step]
+			}
+		}
+		return gray, nil
+	case ImageGray16:
+		gray := image.NewGray16(bounds)
+		for y := 0; y < height; y++ {
+			copy(gray.Pix[y*gray.Stride:][:2*width], buffer[y*stride:])
+		}
+		return gray, nil
+	case ImageRGB8, ImageRGB565:
+		return img.ToGoImage(), nil
+	}
+
+	// image.RGBA is premultiplied; straight colors go to an image.NRGBA.
+	var pix []uint8
+	var dstStride int
+	var stdImg image.Image
+	if img.alpha == AlphaStraight {
+		m := image.NewNRGBA(bounds)
+		pix, dstStride, stdImg = m.Pix, m.Stride, m
+	} else {
+		m := image.NewRGBA(bounds)
+		pix, dstStride, stdImg = m.Pix, m.Stride, m
+	}
 
 	// Copy pixel data
-	buffer := img.renBuf.Buf()
+	o, _ := img.format.channelOrder()
 	for y := 0; y < height; y++ {
 		for x := 0; x < width; x++ {
 			srcIndex := y*stride + x*4
-			dstIndex := y*stdImg.Stride + x*4
+			dstIndex := y*dstStride + x*4
 
 			if srcIndex+3 < len(buffer) {
-				stdImg.Pix[dstIndex] = buffer[srcIndex]     // R
-				stdImg.Pix[dstIndex+1] = buffer[srcIndex+1] // G
-				stdImg.Pix[dstIndex+2] = buffer[srcIndex+2] // B
-				stdImg.Pix[dstIndex+3] = buffer[srcIndex+3] // A
+				pix[dstIndex] = buffer[srcIndex+o.R]   // R
+				pix[dstIndex+1] = buffer[srcIndex+o.G] // G
+				pix[dstIndex+2] = buffer[srcIndex+o.B] // B
+				pix[dstIndex+3] = buffer[srcIndex+o.A] // A
 			}
 		}
 	}
@@ -320,25 +815,47 @@ func (ctx *Context) GetImageResample() ImageResample {
 	return ctx.agg2d.GetImageResample()
 }
 
+// SetImageEdge sets what image drawing samples beyond the source rectangle:
+// EdgeClamp (the default) repeats the edge pixels, EdgeTransparent lets
+// filtered edges fade out, EdgeColor shows the image background color, and
+// EdgeRepeat and EdgeReflect tile the image where a transformed image path
+// extends past it.
+func (ctx *Context) SetImageEdge(edge ImageEdge) {
+	ctx.agg2d.ImageEdge(edge)
+}
+
+// GetImageEdge returns the current image edge mode.
+func (ctx *Context) GetImageEdge() ImageEdge {
+	return ctx.agg2d.GetImageEdge()
+}
+
+// SetImageBackgroundColor sets the color shown outside the source rectangle
+// when the image edge mode is EdgeColor.
+func (ctx *Context) SetImageBackgroundColor(c Color) {
+	ctx.agg2d.ImageBackgroundColor(c)
+}
+
+// GetImageBackgroundColor returns the image background color.
+func (ctx *Context) GetImageBackgroundColor() Color {
+	return ctx.agg2d.GetImageBackgroundColor()
+}
+
 // Advanced image operations
 
-// DrawImageRotated draws an image rotated by the specified angle (in radians).
-func (ctx *Context) DrawImageRotated(img *Image, x, y, angle float64) error {
+// DrawImageRotated draws an image centered on (cx, cy), rotated by angle
+// radians about its center and scaled by scale. The image is sampled with
+// filter for this call only, leaving the context's image filter unchanged;
+// when minifying (scale < 1) it is resampled even if resampling is off.
+func (ctx *Context) DrawImageRotated(img *Image, cx, cy, angle, scale float64, filter ImageFilter) error {
 	if img == nil {
 		return errors.New("image is nil")
 	}
-
-	// Create rotation transformation
-	transform := Rotation(angle)
-	transform.AffineMatrix[4] = x // Set translation
-	transform.AffineMatrix[5] = y
-
-	return ctx.DrawImageTransformed(img, transform)
+	return ctx.agg2d.TransformImageRotated(img, cx, cy, angle, scale, filter)
 }
 
-// DrawImageRotatedDegrees draws an image rotated by the specified angle (in degrees).
-func (ctx *Context) DrawImageRotatedDegrees(img *Image, x, y, degrees float64) error {
-	return ctx.DrawImageRotated(img, x, y, degrees*3.14159265359/180.0)
+// DrawImageRotatedDegrees is DrawImageRotated with the angle in degrees.
+func (ctx *Context) DrawImageRotatedDegrees(img *Image, cx, cy, degrees, scale float64, filter ImageFilter) error {
+	return ctx.DrawImageRotated(img, cx, cy, degrees*math.Pi/180.0, scale, filter)
 }
 
 // DrawImageSkewed draws an image with skewing transformation.
@@ -411,5 +928,9 @@ func CloneImage(src *Image) (*Image, error) {
 	dstBuffer := make([]uint8, len(srcBuffer))
 	copy(dstBuffer, srcBuffer)
 
-	return NewImage(dstBuffer, width, height, stride), nil
+	dst := NewImage(dstBuffer, width, height, stride)
+	dst.format = src.format
+	dst.step = src.step
+	dst.alpha, dst.linear = src.alpha, src.linear
+	return dst, nil
 }
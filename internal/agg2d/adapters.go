@@ -5,6 +5,7 @@ package agg2d
 import (
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/color"
+	aggimage "github.com/MeKo-Christian/agg_go/internal/image"
 	"github.com/MeKo-Christian/agg_go/internal/renderer"
 )
 
@@ -59,6 +60,10 @@ func (b *baseRendererAdapter[C]) BlendSolidHspan(x, y, length int, c C, covers [
 	b.rendererBase().BlendSolidHspan(x, y, length, c, covers)
 }
 
+func (b *baseRendererAdapter[C]) BlendSolidVspan(x, y, length int, c C, covers []basics.Int8u) {
+	b.rendererBase().BlendSolidVspan(x, y, length, c, covers)
+}
+
 // BlendFrom blends from another pixel format using the rendering pipeline
 func (b *baseRendererAdapter[C]) BlendFrom(src renderer.PixelFormat[C], rectSrcPtr *basics.RectI, dx, dy int, cover basics.Int8u) {
 	b.rendererBase().BlendFrom(src, rectSrcPtr, dx, dy, cover)
@@ -75,12 +80,54 @@ type imagePixelFormat struct {
 	img      *Image
 	x, y, x0 int
 	pixelBuf [4]basics.Int8u
+
+	// edge selects the pixels sampled outside the source rectangle srcX1,
+	// srcY1, srcX2, srcY2: background for EdgeTransparent and EdgeColor, or
+	// wrapped coordinates for EdgeRepeat and EdgeReflect.
+	edge                       ImageEdge
+	srcX1, srcY1, srcX2, srcY2 int
+	background                 [4]basics.Int8u
+	wrapX, wrapY               aggimage.WrapMode
 }
 
 func newImagePixelFormat(img *Image) *imagePixelFormat {
 	return &imagePixelFormat{img: img}
 }
 
+// newImagePixelFormatEdge returns an image source that samples outside the
+// source rectangle x1, y1, x2, y2 as edge selects. EdgeColor samples
+// background, given as a straight color.
+func newImagePixelFormatEdge(img *Image, edge ImageEdge, x1, y1, x2, y2 int, background Color) *imagePixelFormat {
+	ipf := &imagePixelFormat{img: img, edge: edge}
+	if img == nil {
+		return ipf
+	}
+	ipf.srcX1, ipf.srcY1 = max(x1, 0), max(y1, 0)
+	ipf.srcX2, ipf.srcY2 = min(x2, img.width), min(y2, img.height)
+	w, h := ipf.srcX2-ipf.srcX1, ipf.srcY2-ipf.srcY1
+	if w <= 0 || h <= 0 {
+		ipf.edge = EdgeClamp
+		return ipf
+	}
+	switch edge {
+	case EdgeRepeat:
+		ipf.wrapX = aggimage.NewWrapModeRepeat(basics.Int32u(w))
+		ipf.wrapY = aggimage.NewWrapModeRepeat(basics.Int32u(h))
+	case EdgeReflect:
+		ipf.wrapX = aggimage.NewWrapModeReflect(basics.Int32u(w))
+		ipf.wrapY = aggimage.NewWrapModeReflect(basics.Int32u(h))
+	case EdgeColor:
+		// Image pixels are read as premultiplied, in the image's byte order.
+		o := img.order.colorOrder()
+		a := background[3]
+		ipf.background[o.R] = color.RGBA8Multiply(background[0], a)
+		ipf.background[o.G] = color.RGBA8Multiply(background[1], a)
+		ipf.background[o.B] = color.RGBA8Multiply(background[2], a)
+		ipf.background[o.A] = a
+	}
+	return ipf
+}
+
 type imagePixelFormatPre struct {
 	img    *Image
 	rowY   int
@@ -106,7 +153,7 @@ func (ipf *imagePixelFormat) ColorType() string {
 
 // OrderType returns the channel layout for source spans.
 func (ipf *imagePixelFormat) OrderType() color.ColorOrder {
-	return color.OrderRGBA
+	return ipf.img.order.colorOrder()
 }
 
 // Pixel returns a pixel as RGBA8 color
@@ -158,24 +205,38 @@ func (ipf *imagePixelFormatPre) RowData(y int) []basics.Int8u {
 		ipf.rowBuf = ipf.rowBuf[:rowLen]
 	}
 
+	// The row is handed out in RGBA order, whatever the image's order.
+	o := ipf.img.order.colorOrder()
 	for x := 0; x < ipf.img.width; x++ {
 		off := x * 4
-		a := src[off+3]
-		ipf.rowBuf[off+0] = color.RGBA8Multiply(src[off+0], a)
-		ipf.rowBuf[off+1] = color.RGBA8Multiply(src[off+1], a)
-		ipf.rowBuf[off+2] = color.RGBA8Multiply(src[off+2], a)
+		a := src[off+o.A]
+		ipf.rowBuf[off+0] = color.RGBA8Multiply(src[off+o.R], a)
+		ipf.rowBuf[off+1] = color.RGBA8Multiply(src[off+o.G], a)
+		ipf.rowBuf[off+2] = color.RGBA8Multiply(src[off+o.B], a)
 		ipf.rowBuf[off+3] = a
 	}
 	ipf.rowY = y
 	return ipf.rowBuf
 }
 
-func (ipf *imagePixelFormat) pixelSliceClamped(x, y int) []basics.Int8u {
+// pixelSlice returns the pixel at (x, y), mapping coordinates outside the
+// image according to the edge mode.
+func (ipf *imagePixelFormat) pixelSlice(x, y int) []basics.Int8u {
 	if ipf.img == nil || ipf.img.Data == nil || ipf.img.width <= 0 || ipf.img.height <= 0 {
 		ipf.pixelBuf = [4]basics.Int8u{0, 0, 0, 0}
 		return ipf.pixelBuf[:]
 	}
 
+	switch {
+	case ipf.wrapX != nil:
+		x = ipf.srcX1 + int(ipf.wrapX.Call(x-ipf.srcX1))
+		y = ipf.srcY1 + int(ipf.wrapY.Call(y-ipf.srcY1))
+	case ipf.edge == EdgeTransparent || ipf.edge == EdgeColor:
+		if x < ipf.srcX1 || y < ipf.srcY1 || x >= ipf.srcX2 || y >= ipf.srcY2 {
+			ipf.pixelBuf = ipf.background
+			return ipf.pixelBuf[:]
+		}
+	}
 	if x < 0 {
 		x = 0
 	} else if x >= ipf.img.width {
@@ -209,20 +270,20 @@ func (ipf *imagePixelFormat) Span(x, y, length int) []basics.Int8u {
 	ipf.x = x
 	ipf.x0 = x
 	ipf.y = y
-	return ipf.pixelSliceClamped(x, y)
+	return ipf.pixelSlice(x, y)
 }
 
 // NextX advances sampling by one pixel in x direction.
 func (ipf *imagePixelFormat) NextX() []basics.Int8u {
 	ipf.x++
-	return ipf.pixelSliceClamped(ipf.x, ipf.y)
+	return ipf.pixelSlice(ipf.x, ipf.y)
 }
 
 // NextY advances sampling by one row at the original x position.
 func (ipf *imagePixelFormat) NextY() []basics.Int8u {
 	ipf.y++
 	ipf.x = ipf.x0
-	return ipf.pixelSliceClamped(ipf.x, ipf.y)
+	return ipf.pixelSlice(ipf.x, ipf.y)
 }
 
 // RowPtr returns row bytes for scanline-based image filters.
@@ -7,10 +7,11 @@ import (
 	"github.com/MeKo-Christian/agg_go/internal/conv"
 	"github.com/MeKo-Christian/agg_go/internal/font"
 	"github.com/MeKo-Christian/agg_go/internal/font/freetype"
+	"github.com/MeKo-Christian/agg_go/internal/font/shaping"
+	"github.com/MeKo-Christian/agg_go/internal/glyph"
 	"github.com/MeKo-Christian/agg_go/internal/gsv"
 	aggimage "github.com/MeKo-Christian/agg_go/internal/image"
 	"github.com/MeKo-Christian/agg_go/internal/path"
-	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
 	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
 	"github.com/MeKo-Christian/agg_go/internal/renderer"
 	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
@@ -29,29 +30,50 @@ type (
 	Gradient       = int
 	LineCap        = int
 	LineJoin       = int
+	InnerJoin      = int
 	TextAlignment  = int
 	FontCacheType  = int
 	ImageFilter    = int
 	ImageResample  = int
+	ImageEdge      = int
 	ViewportOption = int
 )
 
 // Core constants mirror the enum values exposed by the C++ Agg2D interface.
 const (
 	// Gradients
-	Solid  Gradient = 0
-	Linear Gradient = 1
-	Radial Gradient = 2
+	Solid   Gradient = 0
+	Linear  Gradient = 1
+	Radial  Gradient = 2
+	Conic   Gradient = 3
+	Pattern Gradient = 4 // image pattern, see SetFillPattern
+	Contour Gradient = 5 // distance to a path's outline, see NewContourGradientPaint
+	Field   Gradient = 6 // gray levels of an image, see NewFieldGradientPaint
 
 	// Line caps
 	CapButt   LineCap = 0
 	CapSquare LineCap = 1
 	CapRound  LineCap = 2
 
-	// Line joins
-	JoinMiter LineJoin = 0
-	JoinRound LineJoin = 2
-	JoinBevel LineJoin = 3
+	// Line joins. JoinMiter follows AGG: past the miter limit the miter is
+	// clipped at the limit distance. JoinMiterRevert falls back to a bevel
+	// instead (SVG 1.1 and canvas "miter"), and JoinMiterRound to a round join.
+	JoinMiter       LineJoin = 0
+	JoinMiterRevert LineJoin = 1
+	JoinRound       LineJoin = 2
+	JoinBevel       LineJoin = 3
+	JoinMiterRound  LineJoin = 4
+	// JoinMiterClip is SVG 2 "miter-clip", which is AGG's JoinMiter geometry.
+	JoinMiterClip LineJoin = 5
+	// JoinArcs is SVG 2 "arcs". The stroker sees flattened straight segments,
+	// for which arcs joins reduce to miter-clip.
+	JoinArcs LineJoin = 6
+
+	// Inner joins, used on the inside of corners.
+	InnerBevel InnerJoin = 0
+	InnerMiter InnerJoin = 1
+	InnerJag   InnerJoin = 2
+	InnerRound InnerJoin = 3
 
 	// Text alignment
 	AlignLeft   TextAlignment = 0
@@ -100,21 +122,27 @@ type Agg2D struct {
 
 	// Scanline and rasterizer
 	scanline   *scanline.ScanlineU8
-	rasterizer *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip]
+	rasterizer *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlClip[int, rasterizer.RasConvInt]]
 
 	// Rendering components (now properly typed)
-	pixfmt         *pixfmt.PixFmtRGBA32[color.Linear]
-	pixfmtPre      *pixfmt.PixFmtRGBA32Pre[color.Linear]
-	pixfmtComp     *pixfmt.PixFmtCompositeRGBA32
-	pixfmtCompPre  *pixfmt.PixFmtCompositeRGBA32Pre
+	pixelOrder     PixelOrder // channel order of the attached buffer
+	targetFormat   TargetFormat
+	pixfmt         targetPixfmt
+	pixfmtPre      targetPixfmt
+	pixfmtComp     compositePixfmt
+	pixfmtCompPre  compositePixfmt
 	renBase        *baseRendererAdapter[color.RGBA8[color.Linear]]
 	renBasePre     *baseRendererAdapter[color.RGBA8[color.Linear]]
 	renBaseComp    *baseRendererAdapter[color.RGBA8[color.Linear]]
 	renBaseCompPre *baseRendererAdapter[color.RGBA8[color.Linear]]
+	lockedChannels ChannelMask // channels drawing leaves untouched
 
 	// Master alpha and anti-aliasing gamma
 	masterAlpha    float64
 	antiAliasGamma float64
+	textGamma      bool       // apply antiAliasGamma to text coverage
+	textGammaLUT   [256]uint8 // glyph coverage table for textGammaFor
+	textGammaFor   float64    // gamma textGammaLUT was built for (0 = none)
 
 	// Fill and line colors
 	fillColor Color
@@ -133,21 +161,37 @@ type Agg2D struct {
 	lineGradientD2     float64
 
 	// Line attributes
-	lineCap   LineCap
-	lineJoin  LineJoin
-	lineWidth float64
+	lineCap     LineCap
+	lineJoin    LineJoin
+	lineWidth   float64
+	approxScale float64 // curve approximation factor on top of the transform scale
+	dashOffset  float64 // dash start as set, before wrapping into the pattern
+	dashCap     LineCap // cap for dash ends when dashCapSet
+	dashCapSet  bool
+	shorten     float64 // length trimmed off the end of open sub-paths
 
 	// Text attributes
-	textAngle     float64
-	textAlignX    TextAlignment
-	textAlignY    TextAlignment
-	textHints     bool
-	flipText      bool
-	resolution    uint
-	fontHeight    float64
-	fontAscent    float64
-	fontDescent   float64
-	fontCacheType FontCacheType
+	textAngle      float64
+	textAlignX     TextAlignment
+	textAlignY     TextAlignment
+	textHints      bool
+	textKerning    bool
+	textLigatures  bool
+	textDecoration TextDecoration
+	textShaper     shaping.TextShaper
+	hintingMode    HintingMode
+	subpixelOrder  SubpixelOrder
+	subpixelPhases int // horizontal glyph phases, see FontOptions.SubpixelPositions
+	glyphRendering GlyphRendering
+	fontFile       string
+	fontLoaded     bool // the last font file loaded successfully
+	bitmapFont     *glyph.GlyphRasterBin
+	flipText       bool
+	resolution     uint
+	fontHeight     float64
+	fontAscent     float64
+	fontDescent    float64
+	fontCacheType  FontCacheType
 
 	// AGG's agg2d.h wires Agg2D through font_cache_manager<FontEngine>.
 	// Keep that stack authoritative here; the fman/font_cache_manager2 path
@@ -157,6 +201,9 @@ type Agg2D struct {
 	// does not need runtime type assertions on the text path.
 	fontEngine       *freetype.FontEngineFreetype
 	fontCacheManager *font.FontCacheManager
+	glyphRunCache    *glyphRunCache // laid-out strings, see glyph_run_cache.go
+	fallbackFonts    []*fallbackFont
+	sharedFonts      map[string]*freetype.FontData // see ShareFontData
 
 	// TODO(Path B): Temporary GSV stroke-font fallback — replace with a proper
 	// pure-Go TTF engine (Path A) once one is available.
@@ -165,12 +212,21 @@ type Agg2D struct {
 	gsvFontMode bool         // True when the active font backend is GSV
 
 	// Image filtering
-	imageFilter    ImageFilter
-	imageResample  ImageResample
-	imageFilterLUT *aggimage.ImageFilterLUT
+	imageFilter     ImageFilter
+	imageResample   ImageResample
+	imageEdge       ImageEdge
+	imageBackground Color
+	imageFilterLUT  *aggimage.ImageFilterLUT
 
 	// Fill mode
 	evenOddFlag bool
+	autoClose   bool // fills close open subpaths
+
+	// Grid fitting of axis-aligned edges and stroke centerlines
+	pixelSnapping bool
+
+	// Hairline strokes drawn as integer Bresenham lines
+	pixelAccurateLines bool
 
 	// Path and transformation
 	path           *path.PathStorageStl
@@ -181,9 +237,11 @@ type Agg2D struct {
 	convCurve  *conv.ConvCurve
 	convDash   *conv.ConvDash // Optional dash converter (nil when not using dashes)
 	convStroke *conv.ConvStroke
+	flatPath   *path.PathStorageStl // Flattened outline shared by FillAndStroke
 
 	// Span rendering components for gradients and patterns
 	spanAllocator   *span.SpanAllocator[color.RGBA8[color.Linear]]
+	gouraudAlloc    *span.SpanAllocator[span.RGBAColor]
 	fillGradientLUT []color.RGBA8[color.Linear]
 	lineGradientLUT []color.RGBA8[color.Linear]
 
@@ -191,6 +249,8 @@ type Agg2D struct {
 	lineLinearSpanInterpolator *span.SpanInterpolatorLinear[*transform.TransAffine]
 	fillRadialSpanInterpolator *span.SpanInterpolatorLinear[*transform.TransAffine]
 	lineRadialSpanInterpolator *span.SpanInterpolatorLinear[*transform.TransAffine]
+	fillConicSpanInterpolator  *span.SpanInterpolatorLinear[*transform.TransAffine]
+	lineConicSpanInterpolator  *span.SpanInterpolatorLinear[*transform.TransAffine]
 
 	fillLinearSpanGenerator *span.SpanGradient[
 		color.RGBA8[color.Linear],
@@ -216,10 +276,52 @@ type Agg2D struct {
 		span.GradientRadial,
 		*span.GradientPrebuiltColorRGBA8[color.Linear],
 	]
+	fillConicSpanGenerator *span.SpanGradient[
+		color.RGBA8[color.Linear],
+		*span.SpanInterpolatorLinear[*transform.TransAffine],
+		span.GradientSweep,
+		*span.GradientPrebuiltColorRGBA8[color.Linear],
+	]
+	lineConicSpanGenerator *span.SpanGradient[
+		color.RGBA8[color.Linear],
+		*span.SpanInterpolatorLinear[*transform.TransAffine],
+		span.GradientSweep,
+		*span.GradientPrebuiltColorRGBA8[color.Linear],
+	]
 
 	fillGradientLUTDirty bool
 	lineGradientLUTDirty bool
 
+	// Color functions over the LUTs above, and the reusable paints that
+	// replace them when installed with SetFillGradient/SetLineGradient.
+	fillGradientColors *span.GradientPrebuiltColorRGBA8[color.Linear]
+	lineGradientColors *span.GradientPrebuiltColorRGBA8[color.Linear]
+	fillGradientPaint  *GradientPaint
+	lineGradientPaint  *GradientPaint
+
+	// Image patterns installed with SetFillPattern/SetLinePattern, used while
+	// the gradient flag is Pattern, and their device-to-pattern matrices.
+	fillPatternPaint  *PatternPaint
+	linePatternPaint  *PatternPaint
+	fillPatternMatrix *transform.TransAffine
+	linePatternMatrix *transform.TransAffine
+
+	// Opacity and blend mode of the fill and line paints.
+	fillStyle paintStyle
+	lineStyle paintStyle
+
+	gradientDither bool         // see SetGradientDithering
+	coverageFunc   CoverageFunc // see SetCoverageFunc
+
+	// Dry-run state, see BeginMeasure.
+	measuring     bool
+	measured      basics.RectI
+	outerMeasures []basics.RectI
+
+	// Opacity mask over fills, see SetFillAlphaGradient.
+	fillAlphaPaint *GradientPaint
+	fillAlphaMask  *alphaGradientConverter
+
 	// Control point tracking for smooth curves
 	lastCtrlX, lastCtrlY float64
 	hasLastCtrl          bool
@@ -273,6 +375,8 @@ func NewAgg2D() *Agg2D {
 		imageBlendColor:    NewColor(0, 0, 0, 255),
 		masterAlpha:        1.0,
 		antiAliasGamma:     1.0,
+		approxScale:        ApproxScale,
+		textGamma:          true,
 		fillColor:          White,
 		lineColor:          Black,
 		fillGradientFlag:   Solid,
@@ -285,6 +389,8 @@ func NewAgg2D() *Agg2D {
 		textAlignX:         AlignLeft,
 		textAlignY:         AlignBottom,
 		textHints:          true,
+		textKerning:        true,
+		autoClose:          true,
 		resolution:         72,
 		fontHeight:         0.0,
 		fontAscent:         0.0,
@@ -292,6 +398,7 @@ func NewAgg2D() *Agg2D {
 		fontCacheType:      RasterFontCache,
 		imageFilter:        ImageFilterBilinear,
 		imageResample:      NoResample,
+		imageEdge:          EdgeClamp,
 		imageFilterLUT:     aggimage.NewImageFilterLUTWithFilter(aggimage.BilinearFilter{}, true),
 		lineWidth:          1.0,
 		lineCap:            CapRound,
@@ -301,6 +408,10 @@ func NewAgg2D() *Agg2D {
 		transform:          transform.NewTransAffine(),
 		fillGradientMatrix: transform.NewTransAffine(),
 		lineGradientMatrix: transform.NewTransAffine(),
+		fillPatternMatrix:  transform.NewTransAffine(),
+		fillStyle:          defaultPaintStyle,
+		lineStyle:          defaultPaintStyle,
+		linePatternMatrix:  transform.NewTransAffine(),
 		scanline:           scanline.NewScanlineU8(),
 	}
 
@@ -309,20 +420,26 @@ func NewAgg2D() *Agg2D {
 	agg2d.convCurve = conv.NewConvCurve(pathAdapter)
 	agg2d.convStroke = conv.NewConvStroke(agg2d.convCurve)
 
-	// Initialize rasterizer with default cell block limit and clipper
-	clipper := rasterizer.NewRasterizerSlNoClip()
+	// Initialize rasterizer with default cell block limit and clipper. As
+	// with AGG's rasterizer_sl_clip_int, edges are clipped to the clip box
+	// before they reach the cells, so far out coordinates cost no cells.
 	conv := rasterizer.RasConvInt{}
-	agg2d.rasterizer = rasterizer.NewRasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip](conv, clipper)
+	clipper := rasterizer.NewRasterizerSlClip[int](conv)
+	agg2d.rasterizer = rasterizer.NewRasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlClip[int, rasterizer.RasConvInt]](conv, clipper)
 
 	// Initialize span allocator for gradient rendering
 	agg2d.spanAllocator = span.NewSpanAllocator[color.RGBA8[color.Linear]]()
 	agg2d.fillGradientLUT = make([]color.RGBA8[color.Linear], 256)
 	agg2d.lineGradientLUT = make([]color.RGBA8[color.Linear], 256)
+	agg2d.fillGradientColors = span.NewGradientPrebuiltColorRGBA8(agg2d.fillGradientLUT)
+	agg2d.lineGradientColors = span.NewGradientPrebuiltColorRGBA8(agg2d.lineGradientLUT)
 
 	agg2d.fillLinearSpanInterpolator = span.NewSpanInterpolatorLinearDefault(agg2d.fillGradientMatrix)
 	agg2d.lineLinearSpanInterpolator = span.NewSpanInterpolatorLinearDefault(agg2d.lineGradientMatrix)
 	agg2d.fillRadialSpanInterpolator = span.NewSpanInterpolatorLinearDefault(agg2d.fillGradientMatrix)
 	agg2d.lineRadialSpanInterpolator = span.NewSpanInterpolatorLinearDefault(agg2d.lineGradientMatrix)
+	agg2d.fillConicSpanInterpolator = span.NewSpanInterpolatorLinearDefault(agg2d.fillGradientMatrix)
+	agg2d.lineConicSpanInterpolator = span.NewSpanInterpolatorLinearDefault(agg2d.lineGradientMatrix)
 
 	agg2d.fillLinearSpanGenerator = span.NewLinearGradientFromLUT(
 		agg2d.fillLinearSpanInterpolator,
@@ -349,6 +466,19 @@ func NewAgg2D() *Agg2D {
 		agg2d.lineGradientD2,
 	)
 
+	agg2d.fillConicSpanGenerator = span.NewSweepGradientFromLUT(
+		agg2d.fillConicSpanInterpolator,
+		agg2d.fillGradientLUT,
+		agg2d.fillGradientD1,
+		agg2d.fillGradientD2,
+	)
+	agg2d.lineConicSpanGenerator = span.NewSweepGradientFromLUT(
+		agg2d.lineConicSpanInterpolator,
+		agg2d.lineGradientLUT,
+		agg2d.lineGradientD1,
+		agg2d.lineGradientD2,
+	)
+
 	agg2d.fillGradientLUTDirty = true
 	agg2d.lineGradientLUTDirty = true
 
@@ -365,21 +495,47 @@ func NewAgg2D() *Agg2D {
 func (agg2d *Agg2D) FillColor(c Color) {
 	agg2d.fillColor = c
 	agg2d.fillGradientFlag = Solid
+	agg2d.fillStyle = defaultPaintStyle
 }
 
 // LineColor sets the line color.
 func (agg2d *Agg2D) LineColor(c Color) {
 	agg2d.lineColor = c
 	agg2d.lineGradientFlag = Solid
+	agg2d.lineStyle = defaultPaintStyle
 }
 
 // GetInternalRasterizer returns the underlying rasterizer.
-func (agg2d *Agg2D) GetInternalRasterizer() *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip] {
+func (agg2d *Agg2D) GetInternalRasterizer() *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlClip[int, rasterizer.RasConvInt]] {
 	return agg2d.rasterizer
 }
 
+// CellOverflowFunc is called when a path needs more rasterizer cells than
+// the cell block limit allows; limit is the limit that was hit.
+type CellOverflowFunc = rasterizer.CellOverflowFunc
+
+// DefaultCellBlockLimit is the rasterizer cell block limit of a new Agg2D.
+const DefaultCellBlockLimit = rasterizer.DefaultCellBlockLimit
+
+// SetCellBlockLimit caps the cell memory of a path at limit blocks of 4096
+// cells; 0 selects DefaultCellBlockLimit. Paths beyond it render truncated.
+func (agg2d *Agg2D) SetCellBlockLimit(limit uint32) {
+	agg2d.rasterizer.SetCellBlockLimit(limit)
+}
+
+// CellBlockLimit returns the rasterizer cell block limit.
+func (agg2d *Agg2D) CellBlockLimit() uint32 {
+	return agg2d.rasterizer.CellBlockLimit()
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit, or removes it when fn is nil.
+func (agg2d *Agg2D) SetOverflowHandler(fn CellOverflowFunc) {
+	agg2d.rasterizer.SetOverflowHandler(fn)
+}
+
 // ScanlineRender renders the given rasterizer data using a custom renderer.
-func (agg2d *Agg2D) ScanlineRender(ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip], renderer renscan.RendererInterface[color.RGBA8[color.Linear]]) {
+func (agg2d *Agg2D) ScanlineRender(ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlClip[int, rasterizer.RasConvInt]], renderer renscan.RendererInterface[color.RGBA8[color.Linear]]) {
 	if !ras.RewindScanlines() {
 		return
 	}
@@ -393,6 +549,22 @@ func (agg2d *Agg2D) ScanlineRender(ras *rasterizer.RasterizerScanlineAA[int, ras
 	}
 }
 
+// SpanAllocator returns the allocator holding the color spans of gradient,
+// pattern and image fills.
+func (agg2d *Agg2D) SpanAllocator() *span.SpanAllocator[color.RGBA8[color.Linear]] {
+	return agg2d.spanAllocator
+}
+
+// SetSpanAllocator makes the context use sa for its color spans, e.g. to share
+// one preallocated buffer between contexts rendering on the same goroutine.
+// A nil sa installs a fresh allocator.
+func (agg2d *Agg2D) SetSpanAllocator(sa *span.SpanAllocator[color.RGBA8[color.Linear]]) {
+	if sa == nil {
+		sa = span.NewSpanAllocator[color.RGBA8[color.Linear]]()
+	}
+	agg2d.spanAllocator = sa
+}
+
 // GouraudTriangle renders a Gouraud-shaded triangle.
 func (agg2d *Agg2D) GouraudTriangle(x1, y1, x2, y2, x3, y3 float64, c1, c2, c3 Color, d float64) {
 	agg2d.rasterizer.Reset()
@@ -405,10 +577,14 @@ func (agg2d *Agg2D) GouraudTriangle(x1, y1, x2, y2, x3, y3 float64, c1, c2, c3 C
 	spanGen := span.NewSpanGouraudRGBAWithTriangle(gc1, gc2, gc3, x1, y1, x2, y2, x3, y3, d)
 
 	// Use a custom renderer that doesn't rely on the broken interfaces
+	if agg2d.gouraudAlloc == nil {
+		agg2d.gouraudAlloc = span.NewSpanAllocator[span.RGBAColor]()
+	}
 	renderer := &gouraudRenderer{
 		ren:   agg2d.renBase.rendererBase(),
 		span:  spanGen,
-		alloc: span.NewSpanAllocator[span.RGBAColor](),
+		alloc: agg2d.gouraudAlloc,
+		out:   agg2d.spanAllocator,
 	}
 
 	// We need an adapter here too because of circular dependency or internal types
@@ -424,6 +600,7 @@ type gouraudRenderer struct {
 	ren   *renderer.RendererBase[renderer.PixelFormat[color.RGBA8[color.Linear]], color.RGBA8[color.Linear]]
 	span  *span.SpanGouraudRGBA
 	alloc *span.SpanAllocator[span.RGBAColor]
+	out   *span.SpanAllocator[color.RGBA8[color.Linear]]
 }
 
 func (r *gouraudRenderer) Prepare() {
@@ -444,7 +621,7 @@ func (r *gouraudRenderer) Render(sl renscan.ScanlineInterface) {
 		r.span.Generate(colors, x, y, uint(length))
 
 		// Convert back to base renderer colors and blend
-		baseColors := make([]color.RGBA8[color.Linear], length)
+		baseColors := r.out.Allocate(length)
 		for i := 0; i < length; i++ {
 			baseColors[i] = color.RGBA8[color.Linear]{
 				R: uint8(colors[i].R),
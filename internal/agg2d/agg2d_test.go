@@ -151,7 +151,7 @@ func TestInitializeDashingPreservesStrokeAttributes(t *testing.T) {
 	if got := ctx.convStroke.InnerMiterLimit(); math.Abs(got-1.75) > 1e-10 {
 		t.Fatalf("inner miter limit = %f, want 1.75", got)
 	}
-	if got := ctx.convStroke.Shorten(); math.Abs(got-2.25) > 1e-10 {
+	if got := ctx.GetShorten(); math.Abs(got-2.25) > 1e-10 {
 		t.Fatalf("shorten = %f, want 2.25", got)
 	}
 	if got := ctx.convStroke.ApproximationScale(); math.Abs(got-3.5) > 1e-10 {
@@ -159,6 +159,85 @@ func TestInitializeDashingPreservesStrokeAttributes(t *testing.T) {
 	}
 }
 
+func TestSetDashPatternFollowsSVG(t *testing.T) {
+	ctx := NewAgg2D()
+
+	ctx.SetDashPattern([]float64{5, 3, 2})
+	want := []float64{5, 3, 2, 5, 3, 2}
+	got := ctx.getDashPattern()
+	if len(got) != len(want) {
+		t.Fatalf("odd pattern expanded to %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("odd pattern expanded to %v, want %v", got, want)
+		}
+	}
+	if l := ctx.DashPatternLength(); l != 20 {
+		t.Fatalf("DashPatternLength() = %v, want 20", l)
+	}
+
+	for _, invalid := range [][]float64{{4, -1}, {0, 0}, nil} {
+		ctx.SetDashPattern(invalid)
+		if n := ctx.convDash.NumDashes(); n != 0 {
+			t.Fatalf("pattern %v should give a solid stroke, got %d dashes", invalid, n)
+		}
+	}
+}
+
+func TestDashStartWrapsLikeSVG(t *testing.T) {
+	ctx := NewAgg2D()
+	ctx.AddDash(6, 4)
+
+	ctx.DashStart(-3)
+	if got := ctx.GetDashStart(); got != -3 {
+		t.Fatalf("GetDashStart() = %v, want the offset as set", got)
+	}
+	if got := ctx.convDash.GetDashStart(); got != 7 {
+		t.Fatalf("negative offset wrapped to %v, want 7", got)
+	}
+
+	ctx.DashStart(0)
+	for i := 0; i < 25; i++ {
+		ctx.AdvanceDashStart(1.5)
+	}
+	if got := ctx.GetDashStart(); math.Abs(got-7.5) > 1e-9 {
+		t.Fatalf("AdvanceDashStart accumulated to %v, want 37.5 wrapped to 7.5", got)
+	}
+}
+
+func TestZeroLengthDashesDrawDotsWithRoundDashCap(t *testing.T) {
+	draw := func(dashCap bool) int {
+		ctx := NewAgg2D()
+		buf := make([]byte, 40*10*4)
+		ctx.Attach(buf, 40, 10, 40*4)
+		ctx.ClearAll(Color{0, 0, 0, 0})
+		ctx.LineColor(Black)
+		ctx.LineWidth(4)
+		ctx.LineCap(CapButt)
+		ctx.SetDashPattern([]float64{0, 10})
+		if dashCap {
+			ctx.DashCap(CapRound)
+		}
+		ctx.Line(5, 5, 35, 5)
+
+		inked := 0
+		for i := 3; i < len(buf); i += 4 {
+			if buf[i] > 128 {
+				inked++
+			}
+		}
+		return inked
+	}
+
+	if n := draw(false); n != 0 {
+		t.Fatalf("butt-capped zero-length dashes should not draw, got %d pixels", n)
+	}
+	if n := draw(true); n < 3*8 {
+		t.Fatalf("round dash caps should draw dots, got %d pixels", n)
+	}
+}
+
 // TestTransformations verifies transformation methods
 func TestTransformations(t *testing.T) {
 	ctx := NewAgg2D()
@@ -457,6 +536,53 @@ func TestFillEvenOddUpdatesRasterizerImmediately(t *testing.T) {
 	}
 }
 
+// TestLargeCoordinates draws shapes reaching a billion pixels past the
+// buffer. The rasterizer clips their edges to the clip box, so they render
+// as the visible part alone instead of running out of cells.
+func TestLargeCoordinates(t *testing.T) {
+	const width, height, far = 50, 50, 1e9
+	ctx := NewAgg2D()
+	buf := make([]uint8, width*height*4)
+	ctx.Attach(buf, width, height, width*4)
+
+	// The hypotenuse runs through the buffer along x + y = 50.
+	ctx.FillColor(NewColorRGB(255, 0, 0))
+	ctx.ResetPath()
+	ctx.MoveTo(-far, 50+far)
+	ctx.LineTo(50+far, -far)
+	ctx.LineTo(-far, -far)
+	ctx.ClosePolygon()
+	ctx.DrawPath(FillOnly)
+
+	for _, tc := range []struct{ x, y, r int }{{5, 5, 255}, {45, 45, 0}, {0, 0, 255}, {49, 49, 0}} {
+		if r, _, _, _ := pixelAt(buf, width, tc.x, tc.y); int(r) != tc.r {
+			t.Errorf("fill at (%d,%d): red = %d, want %d", tc.x, tc.y, r, tc.r)
+		}
+	}
+	// Pixels centered on the hypotenuse are half covered.
+	for _, p := range [][2]int{{0, 49}, {24, 25}, {49, 0}} {
+		if r, _, _, _ := pixelAt(buf, width, p[0], p[1]); r < 120 || r > 136 {
+			t.Errorf("edge at (%d,%d): red = %d, want ~128", p[0], p[1], r)
+		}
+	}
+
+	// A far-reaching stroke along y = 40.
+	ctx.LineColor(NewColorRGB(0, 0, 255))
+	ctx.LineWidth(2)
+	ctx.ResetPath()
+	ctx.MoveTo(-far, 40)
+	ctx.LineTo(far, 40)
+	ctx.DrawPath(StrokeOnly)
+	for _, x := range []int{0, 25, 49} {
+		if _, _, b, a := pixelAt(buf, width, x, 40); b != 255 || a != 255 {
+			t.Errorf("stroke at (%d,40) = blue %d alpha %d, want solid", x, b, a)
+		}
+	}
+	if _, _, b, _ := pixelAt(buf, width, 25, 37); b != 0 {
+		t.Errorf("stroke leaked to (25,37): blue = %d", b)
+	}
+}
+
 func TestClipBoxPropagatesToRendererCopyOps(t *testing.T) {
 	ctx := NewAgg2D()
 
@@ -632,3 +758,84 @@ func BenchmarkShapeDrawing(b *testing.B) {
 		ctx.Ellipse(x, y, 10, 10) // Circle is just an ellipse with equal radii
 	}
 }
+
+func TestStrokeWithWidthsTapers(t *testing.T) {
+	ctx := NewAgg2D()
+	buf := make([]byte, 60*20*4)
+	ctx.Attach(buf, 60, 20, 60*4)
+	ctx.ClearAll(Color{0, 0, 0, 0})
+	ctx.LineColor(Black)
+	ctx.ResetPath()
+	ctx.MoveTo(5, 10)
+	ctx.LineTo(55, 10)
+	ctx.StrokeWithWidths([]float64{0, 12})
+
+	column := func(x int) int {
+		inked := 0
+		for y := 0; y < 20; y++ {
+			if _, _, _, a := pixelAt(buf, 60, x, y); a > 128 {
+				inked++
+			}
+		}
+		return inked
+	}
+	if thin, thick := column(8), column(52); thin >= thick || thick < 10 {
+		t.Fatalf("stroke should widen from start to end, got %d and %d pixels", thin, thick)
+	}
+
+	ctx.ClearAll(Color{0, 0, 0, 0})
+	ctx.StrokeWithWidthFunc(func(t float64) float64 { return 12 * (1 - t) })
+	if thick, thin := column(8), column(52); thin >= thick || thick < 10 {
+		t.Fatalf("width function should taper to the end, got %d and %d pixels", thick, thin)
+	}
+}
+
+func TestJoinStylesPastMiterLimit(t *testing.T) {
+	draw := func(join LineJoin) int {
+		ctx := NewAgg2D()
+		buf := make([]byte, 60*60*4)
+		ctx.Attach(buf, 60, 60, 60*4)
+		ctx.ClearAll(Color{0, 0, 0, 0})
+		ctx.LineColor(Black)
+		ctx.LineWidth(8)
+		ctx.LineJoin(join)
+		ctx.MiterLimit(2)
+		ctx.ResetPath()
+		ctx.MoveTo(10, 50)
+		ctx.LineTo(30, 10)
+		ctx.LineTo(50, 50)
+		ctx.DrawPath(StrokeOnly)
+
+		inked := 0
+		for i := 3; i < len(buf); i += 4 {
+			inked += int(buf[i])
+		}
+		return inked
+	}
+
+	clip, revert := draw(JoinMiterClip), draw(JoinMiterRevert)
+	if clip != draw(JoinMiter) || clip != draw(JoinArcs) {
+		t.Fatal("miter-clip and arcs joins should match AGG's clipped miter on straight segments")
+	}
+	if clip <= revert {
+		t.Fatalf("clipped miter should extend past the bevel fallback: %d vs %d", clip, revert)
+	}
+	if round := draw(JoinMiterRound); round <= revert {
+		t.Fatalf("round fallback should cover more than a bevel: %d vs %d", round, revert)
+	}
+}
+
+func TestInnerJoin(t *testing.T) {
+	ctx := NewAgg2D()
+	if got := ctx.GetInnerJoin(); got != InnerMiter {
+		t.Fatalf("default inner join = %v, want InnerMiter", got)
+	}
+	ctx.InnerJoin(InnerRound)
+	if got := ctx.GetInnerJoin(); got != InnerRound {
+		t.Fatalf("GetInnerJoin() = %v, want InnerRound", got)
+	}
+	ctx.ResetStyle()
+	if got := ctx.GetInnerJoin(); got != InnerMiter {
+		t.Fatalf("ResetStyle left inner join at %v", got)
+	}
+}
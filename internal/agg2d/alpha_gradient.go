@@ -0,0 +1,115 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// SetFillAlphaGradient installs g as an opacity mask over fills: every fill
+// pixel, whether from the fill color, a fill gradient or a transformed image,
+// has its alpha multiplied by the alpha of g at that point. Only the alpha of
+// the stop colors is used. The geometry is placed with the current transform,
+// as with SetFillGradient. A nil g removes the mask. Strokes are not masked.
+//
+// This is the Agg2D counterpart of AGG's span_gradient_alpha, hooked in as a
+// span converter after the fill's color generator.
+func (agg2d *Agg2D) SetFillAlphaGradient(g *GradientPaint) {
+	agg2d.fillAlphaPaint = g
+	if g == nil {
+		agg2d.fillAlphaMask = nil
+		return
+	}
+	mtx := transform.NewTransAffine()
+	d1, d2 := g.setup(mtx, agg2d.transform)
+	agg2d.fillAlphaMask = newAlphaGradientConverter(g, mtx, d1, d2)
+}
+
+// FillAlphaGradient returns the installed opacity mask, or nil.
+func (agg2d *Agg2D) FillAlphaGradient() *GradientPaint {
+	return agg2d.fillAlphaPaint
+}
+
+// maskFillSpans wraps a fill span generator with the opacity mask, if one is
+// installed. premultiplied tells whether gen emits premultiplied colors.
+func (agg2d *Agg2D) maskFillSpans(gen renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]], premultiplied bool) renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]] {
+	if agg2d.fillAlphaMask == nil {
+		return gen
+	}
+	agg2d.fillAlphaMask.premultiplied = premultiplied
+	return span.NewSpanConverter[color.RGBA8[color.Linear]](gen, agg2d.fillAlphaMask)
+}
+
+// renderMaskedSolidFill renders the rasterizer with a solid color through the
+// opacity mask.
+func (agg2d *Agg2D) renderMaskedSolidFill(c color.RGBA8[color.Linear]) {
+	renderer := agg2d.currentRenderer()
+	if renderer == nil || agg2d.spanAllocator == nil {
+		return
+	}
+	gen := agg2d.maskFillSpans(span.NewSolidSpanGenerator(c), false)
+	renscan.RenderScanlinesAA(agg2d.rasterizer, agg2d.scanline, renderer, agg2d.spanAllocator, gen)
+}
+
+// alphaGradientConverter scales span alpha by a gradient's alpha.
+type alphaGradientConverter struct {
+	interpolator   *span.SpanInterpolatorLinear[*transform.TransAffine]
+	shape          span.GradientFunction
+	lut            []color.RGBA8[color.Linear]
+	d1, d2         int // subpixel distances
+	downscaleShift int
+	premultiplied  bool
+}
+
+func newAlphaGradientConverter(g *GradientPaint, mtx *transform.TransAffine, d1, d2 float64) *alphaGradientConverter {
+	interpolator := span.NewSpanInterpolatorLinearDefault(mtx)
+	c := &alphaGradientConverter{
+		interpolator:   interpolator,
+		lut:            g.lut,
+		d1:             basics.IRound(d1 * span.GradientSubpixelScale),
+		d2:             basics.IRound(d2 * span.GradientSubpixelScale),
+		downscaleShift: max(interpolator.SubpixelShift()-span.GradientSubpixelShift, 0),
+	}
+	switch g.kind {
+	case Radial:
+		c.shape = span.GradientRadial{}
+	case Conic:
+		c.shape = span.GradientSweep{}
+	case Contour, Field:
+		c.shape = g.field
+	default:
+		c.shape = span.GradientLinearX{}
+	}
+	return c
+}
+
+func (c *alphaGradientConverter) Prepare() {}
+
+func (c *alphaGradientConverter) Generate(colors []color.RGBA8[color.Linear], x, y, length int) {
+	dd := max(c.d2-c.d1, 1)
+	size := len(c.lut)
+	c.interpolator.Begin(float64(x)+0.5, float64(y)+0.5, length)
+	for i := 0; i < length; i++ {
+		ix, iy := c.interpolator.Coordinates()
+		d := c.shape.Calculate(ix>>c.downscaleShift, iy>>c.downscaleShift, c.d2)
+		idx := min(max((d-c.d1)*size/dd, 0), size-1)
+		a := c.lut[idx].A
+
+		p := &colors[i]
+		if c.premultiplied {
+			p.R = mulAlpha(p.R, a)
+			p.G = mulAlpha(p.G, a)
+			p.B = mulAlpha(p.B, a)
+		}
+		p.A = mulAlpha(p.A, a)
+		c.interpolator.Next()
+	}
+}
+
+// mulAlpha multiplies two 0..255 values with rounding.
+func mulAlpha(v, a uint8) uint8 {
+	t := uint32(v)*uint32(a) + 128
+	return uint8((t + t>>8) >> 8)
+}
@@ -45,6 +45,8 @@ func TestAttachResetsState(t *testing.T) {
 	agg2d.LineJoin(JoinBevel)
 	agg2d.ImageFilter(Hanning)
 	agg2d.ImageResample(ResampleAlways)
+	agg2d.ImageEdge(EdgeRepeat)
+	agg2d.ImageBackgroundColor(Color{255, 255, 255, 255})
 	agg2d.SetMasterAlpha(0.5)
 	agg2d.ClipBox(4, 4, 8, 8)
 
@@ -82,6 +84,14 @@ func TestAttachResetsState(t *testing.T) {
 		t.Fatalf("image resample after Attach = %v, want NoResample", got)
 	}
 
+	// Image edge resets to EdgeClamp.
+	if got := agg2d.GetImageEdge(); got != EdgeClamp {
+		t.Fatalf("image edge after Attach = %v, want EdgeClamp", got)
+	}
+	if got := agg2d.GetImageBackgroundColor(); got != (Color{}) {
+		t.Fatalf("image background after Attach = %v, want transparent", got)
+	}
+
 	// Master alpha resets to 1.0.
 	if got := agg2d.GetMasterAlpha(); got != 1.0 {
 		t.Fatalf("master alpha after Attach = %v, want 1.0", got)
@@ -0,0 +1,18 @@
+package agg2d
+
+// SetAutoClose selects how fills treat open subpaths, those not ended with
+// ClosePolygon. With auto close on, the default, the rasterizer closes an
+// open subpath with a straight line back to its start before filling it.
+// With it off, as with AGG's rasterizer auto_close(false), no closing edge
+// is added: the edges of an open subpath still add coverage, up to the next
+// edge on the same scanline, but the area it would enclose is not filled.
+// Strokes are unaffected, their outlines are always closed.
+func (agg2d *Agg2D) SetAutoClose(on bool) {
+	agg2d.autoClose = on
+	agg2d.rasterizer.AutoClose(on)
+}
+
+// GetAutoClose reports whether fills close open subpaths.
+func (agg2d *Agg2D) GetAutoClose() bool {
+	return agg2d.autoClose
+}
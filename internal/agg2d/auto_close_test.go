@@ -0,0 +1,44 @@
+package agg2d
+
+import "testing"
+
+func TestAutoCloseOpenSubpaths(t *testing.T) {
+	for _, autoClose := range []bool{true, false} {
+		ctx, buf := newSnapTestContext(false)
+		ctx.SetAutoClose(autoClose)
+		ctx.FillColor(Black)
+		ctx.ResetPath()
+		// An open L-shaped subpath and a closed square.
+		ctx.MoveTo(2, 2)
+		ctx.LineTo(18, 2)
+		ctx.LineTo(18, 18)
+		ctx.MoveTo(22, 22)
+		ctx.LineTo(38, 22)
+		ctx.LineTo(38, 38)
+		ctx.LineTo(22, 38)
+		ctx.ClosePolygon()
+		ctx.DrawPath(FillOnly)
+
+		if _, _, _, a := pixelAt(buf, 40, 30, 30); a != 255 {
+			t.Fatalf("autoClose=%v: closed square not filled, alpha %d", autoClose, a)
+		}
+		_, _, _, a := pixelAt(buf, 40, 14, 6)
+		if autoClose && a != 255 {
+			t.Fatalf("open triangle should be filled with auto close, alpha %d", a)
+		}
+		if !autoClose && a != 0 {
+			t.Fatalf("open triangle should not be filled without auto close, alpha %d", a)
+		}
+	}
+
+	ctx := NewAgg2D()
+	if !ctx.GetAutoClose() {
+		t.Fatal("auto close should be on by default")
+	}
+	s := ctx.State()
+	ctx.SetAutoClose(false)
+	ctx.SetState(s)
+	if !ctx.GetAutoClose() {
+		t.Fatal("SetState did not restore auto close")
+	}
+}
@@ -5,6 +5,7 @@ package agg2d
 import (
 	"github.com/MeKo-Christian/agg_go/internal/buffer"
 	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/order"
 	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
 	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
 )
@@ -16,6 +17,8 @@ type Image struct {
 	Data   []uint8 // Raw pixel data (RGBA format)
 	width  int     // Width in pixels
 	height int     // Height in pixels
+	order  PixelOrder
+	mips   []*Image // Mipmap levels, halving in size; see SetMipmaps
 }
 
 // NewImage creates a new Image with the given buffer, dimensions, and stride.
@@ -46,6 +49,25 @@ func (img *Image) Height() int {
 // Attach attaches a rendering buffer to the AGG2D context.
 // This matches the C++ Agg2D::attach method.
 func (agg2d *Agg2D) Attach(buf []uint8, width, height, stride int) {
+	agg2d.AttachOrdered(buf, width, height, stride, OrderRGBA)
+}
+
+// AttachOrdered is Attach for a buffer whose pixels are stored in order o.
+func (agg2d *Agg2D) AttachOrdered(buf []uint8, width, height, stride int, o PixelOrder) {
+	f := TargetRGBA32
+	switch o {
+	case OrderBGRA:
+		f = TargetBGRA32
+	case OrderARGB:
+		f = TargetARGB32
+	case OrderABGR:
+		f = TargetABGR32
+	}
+	agg2d.AttachFormat(buf, width, height, stride, f)
+}
+
+// attach attaches buf and resets the drawing state, see Attach.
+func (agg2d *Agg2D) attach(buf []uint8, width, height, stride int) {
 	agg2d.rbuf.Attach(buf, width, height, stride)
 
 	// Reset clipping and transformations
@@ -60,6 +82,8 @@ func (agg2d *Agg2D) Attach(buf []uint8, width, height, stride int) {
 	agg2d.FlipText(false)
 	agg2d.ImageFilter(ImageFilterBilinear)
 	agg2d.ImageResample(NoResample)
+	agg2d.ImageEdge(EdgeClamp)
+	agg2d.ImageBackgroundColor(Color{})
 	agg2d.masterAlpha = 1.0
 	agg2d.antiAliasGamma = 1.0
 	agg2d.blendMode = BlendAlpha
@@ -69,6 +93,22 @@ func (agg2d *Agg2D) Attach(buf []uint8, width, height, stride int) {
 	agg2d.updateRasterizerGamma()
 }
 
+// Reattach points the renderer at a new buffer like Attach, but keeps the
+// drawing state: transform, colors, stroke and text settings, paints and
+// blend modes. A clip box that covered the whole old buffer is widened to the
+// new size; any other clip box is kept as is.
+func (agg2d *Agg2D) Reattach(buf []uint8, width, height, stride int) {
+	cb := agg2d.clipBox
+	full := cb.X1 <= 0 && cb.Y1 <= 0 && cb.X2 >= float64(agg2d.rbuf.Width()) && cb.Y2 >= float64(agg2d.rbuf.Height())
+	agg2d.rbuf.Attach(buf, width, height, stride)
+	if full {
+		agg2d.clipBox.X1, agg2d.clipBox.Y1 = 0, 0
+		agg2d.clipBox.X2, agg2d.clipBox.Y2 = float64(width), float64(height)
+	}
+	agg2d.initializeRendering()
+	agg2d.updateBlendMode()
+}
+
 // AttachImage attaches a rendering context to an existing Image.
 // This matches the C++ Agg2D::attach(Image& img) overload:
 //
@@ -89,28 +129,41 @@ func (agg2d *Agg2D) initializeRendering() {
 	height := agg2d.rbuf.Height()
 
 	if width > 0 && height > 0 {
-		// Create pixel format
-		agg2d.pixfmt = pixfmt.NewPixFmtRGBA32[color.Linear](agg2d.rbuf)
-		agg2d.pixfmtPre = pixfmt.NewPixFmtRGBA32Pre[color.Linear](agg2d.rbuf)
-		agg2d.renBase = newBaseRendererAdapter[color.RGBA8[color.Linear]](agg2d.pixfmt)
-		agg2d.renBasePre = newBaseRendererAdapter[color.RGBA8[color.Linear]](agg2d.pixfmtPre)
-
-		// Create composite pixel format with default source-over blending
-		agg2d.pixfmtComp = pixfmt.NewPixFmtCompositeRGBA32(agg2d.rbuf, blender.CompOpSrcOver)
-		agg2d.pixfmtCompPre = pixfmt.NewPixFmtCompositeRGBA32Pre(agg2d.rbuf, blender.CompOpSrcOver)
-		agg2d.renBaseComp = newBaseRendererAdapter[color.RGBA8[color.Linear]](agg2d.pixfmtComp)
-		agg2d.renBaseCompPre = newBaseRendererAdapter[color.RGBA8[color.Linear]](agg2d.pixfmtCompPre)
+		// Create pixel formats for the buffer's channel order; the composite
+		// ones start with default source-over blending
+		switch {
+		case targetFormats[agg2d.targetFormat].pixfmts != nil:
+			agg2d.pixfmt, agg2d.pixfmtPre = targetFormats[agg2d.targetFormat].pixfmts(agg2d.rbuf)
+			agg2d.pixfmtComp, agg2d.pixfmtCompPre = newStagedPixfmts(agg2d.rbuf, agg2d.targetFormat)
+		case agg2d.pixelOrder == OrderBGRA:
+			agg2d.pixfmt = pixfmt.NewPixFmtBGRA32[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtPre = pixfmt.NewPixFmtBGRA32Pre[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtComp = pixfmt.NewPixFmtCompositeRGBA[color.Linear, order.BGRA](agg2d.rbuf, blender.CompOpSrcOver)
+			agg2d.pixfmtCompPre = pixfmt.NewPixFmtCompositeRGBAPre[color.Linear, order.BGRA](agg2d.rbuf, blender.CompOpSrcOver)
+		case agg2d.pixelOrder == OrderARGB:
+			agg2d.pixfmt = pixfmt.NewPixFmtARGB32[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtPre = pixfmt.NewPixFmtARGB32Pre[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtComp = pixfmt.NewPixFmtCompositeRGBA[color.Linear, order.ARGB](agg2d.rbuf, blender.CompOpSrcOver)
+			agg2d.pixfmtCompPre = pixfmt.NewPixFmtCompositeRGBAPre[color.Linear, order.ARGB](agg2d.rbuf, blender.CompOpSrcOver)
+		case agg2d.pixelOrder == OrderABGR:
+			agg2d.pixfmt = pixfmt.NewPixFmtABGR32[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtPre = pixfmt.NewPixFmtABGR32Pre[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtComp = pixfmt.NewPixFmtCompositeRGBA[color.Linear, order.ABGR](agg2d.rbuf, blender.CompOpSrcOver)
+			agg2d.pixfmtCompPre = pixfmt.NewPixFmtCompositeRGBAPre[color.Linear, order.ABGR](agg2d.rbuf, blender.CompOpSrcOver)
+		default:
+			agg2d.pixfmt = pixfmt.NewPixFmtRGBA32[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtPre = pixfmt.NewPixFmtRGBA32Pre[color.Linear](agg2d.rbuf)
+			agg2d.pixfmtComp = pixfmt.NewPixFmtCompositeRGBA32(agg2d.rbuf, blender.CompOpSrcOver)
+			agg2d.pixfmtCompPre = pixfmt.NewPixFmtCompositeRGBA32Pre(agg2d.rbuf, blender.CompOpSrcOver)
+		}
+		// The renderers write through the channel mask
+		agg2d.renBase = newBaseRendererAdapter[color.RGBA8[color.Linear]](newChannelMaskPixfmt(agg2d.pixfmt, agg2d))
+		agg2d.renBasePre = newBaseRendererAdapter[color.RGBA8[color.Linear]](newChannelMaskPixfmt(agg2d.pixfmtPre, agg2d))
+		agg2d.renBaseComp = newBaseRendererAdapter[color.RGBA8[color.Linear]](newChannelMaskPixfmt(agg2d.pixfmtComp, agg2d))
+		agg2d.renBaseCompPre = newBaseRendererAdapter[color.RGBA8[color.Linear]](newChannelMaskPixfmt(agg2d.pixfmtCompPre, agg2d))
 
 		// Reapply current clip box to renderer adapters.
 		agg2d.ClipBox(agg2d.clipBox.X1, agg2d.clipBox.Y1, agg2d.clipBox.X2, agg2d.clipBox.Y2)
-
-		// Initialize rasterizer if needed
-		// Note: The rasterizer is already created in NewAgg2D with the correct types
-		if agg2d.rasterizer != nil {
-			// Reset rasterizer clip box
-			agg2d.rasterizer.Reset()
-			agg2d.rasterizer.ClipBox(0, 0, float64(width), float64(height))
-		}
 	}
 }
 
@@ -121,10 +174,20 @@ func (agg2d *Agg2D) ClearAll(c Color) {
 		return
 	}
 
+	if agg2d.measuring {
+		agg2d.addMeasured(0, 0, agg2d.pixfmt.Width(), agg2d.pixfmt.Height())
+		return
+	}
+
 	clearColor := color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: c[3]}
-	agg2d.pixfmt.Clear(clearColor)
+	agg2d.directTarget(agg2d.pixfmt, agg2d.renBase).Clear(clearColor)
 }
 
+// rasterClipMargin is how far, in pixels, the rasterizer's clip box extends
+// past the renderers'. Geometry beyond it costs no cells, however far it
+// reaches.
+const rasterClipMargin = 1024
+
 // ClipBox sets the clipping rectangle.
 func (agg2d *Agg2D) ClipBox(x1, y1, x2, y2 float64) {
 	agg2d.clipBox.X1 = x1
@@ -148,7 +211,17 @@ func (agg2d *Agg2D) ClipBox(x1, y1, x2, y2 float64) {
 	}
 
 	if agg2d.rasterizer != nil {
-		agg2d.rasterizer.ClipBox(x1, y1, x2, y2)
+		// Clip edges well outside the pixels the renderers accept. The
+		// clipped ends are rounded, which shifts coverage slightly, so
+		// geometry near the buffer is left whole and renders the same in
+		// any band or tile of it.
+		const m = rasterClipMargin
+		agg2d.rasterizer.ClipBox(
+			float64(max(rx1, 0)-m), float64(max(ry1, 0)-m),
+			float64(min(rx2+1, agg2d.rbuf.Width())+m), float64(min(ry2+1, agg2d.rbuf.Height())+m))
+	}
+	if agg2d.measuring {
+		agg2d.suppressPainting()
 	}
 }
 
@@ -0,0 +1,168 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+)
+
+// ChannelMask selects channels of the target pixels. Drawing with a channel
+// mask, as the write mask of a GPU pipeline, updates only the selected
+// channels and leaves the others as they were: drawing with ChannelAlpha
+// alone paints a shape into the alpha channel without touching the color.
+type ChannelMask uint8
+
+const (
+	ChannelRed ChannelMask = 1 << iota
+	ChannelGreen
+	ChannelBlue
+	ChannelAlpha
+
+	ChannelRGB = ChannelRed | ChannelGreen | ChannelBlue
+	ChannelAll = ChannelRGB | ChannelAlpha
+)
+
+// SetChannelMask sets the channels subsequent drawing writes to. The default
+// is ChannelAll. Channel masks apply to the 32-bit target formats only; the
+// others, whose channels are packed, merged or missing, ignore them.
+func (agg2d *Agg2D) SetChannelMask(m ChannelMask) {
+	agg2d.lockedChannels = ChannelAll &^ m
+}
+
+// ChannelMask returns the channels drawing writes to.
+func (agg2d *Agg2D) ChannelMask() ChannelMask {
+	return ChannelAll &^ agg2d.lockedChannels
+}
+
+// channelMaskPixfmt writes through a target pixel format and then puts back
+// the channels locked by the channel mask. With no channels locked, the
+// operations go straight to the wrapped format.
+type channelMaskPixfmt struct {
+	targetPixfmt
+	agg2d *Agg2D
+	saved []uint8
+}
+
+func newChannelMaskPixfmt(pf targetPixfmt, agg2d *Agg2D) *channelMaskPixfmt {
+	return &channelMaskPixfmt{targetPixfmt: pf, agg2d: agg2d}
+}
+
+// guard runs op, which writes at most the w x h pixels at (x, y), keeping the
+// locked channels of these pixels.
+func (pf *channelMaskPixfmt) guard(x, y, w, h int, op func()) {
+	locked := pf.agg2d.lockedChannels
+	if locked == 0 || pf.agg2d.targetFormat.BytesPerPixel() != 4 {
+		op()
+		return
+	}
+	rbuf := pf.agg2d.rbuf
+	x1, y1 := max(x, 0), max(y, 0)
+	x2, y2 := min(x+w, rbuf.Width()), min(y+h, rbuf.Height())
+	if x1 >= x2 || y1 >= y2 {
+		op()
+		return
+	}
+
+	var offsets [4]int
+	n := 0
+	ord := pf.agg2d.pixelOrder.colorOrder()
+	for i, off := range [4]int{ord.R, ord.G, ord.B, ord.A} {
+		if locked&(1<<i) != 0 {
+			offsets[n] = off
+			n++
+		}
+	}
+
+	pf.saved = pf.saved[:0]
+	for py := y1; py < y2; py++ {
+		row := rbuf.Row(py)
+		for px := x1; px < x2; px++ {
+			for _, off := range offsets[:n] {
+				pf.saved = append(pf.saved, row[px*4+off])
+			}
+		}
+	}
+	op()
+	i := 0
+	for py := y1; py < y2; py++ {
+		row := rbuf.Row(py)
+		for px := x1; px < x2; px++ {
+			for _, off := range offsets[:n] {
+				row[px*4+off] = pf.saved[i]
+				i++
+			}
+		}
+	}
+}
+
+func (pf *channelMaskPixfmt) CopyPixel(x, y int, c color.RGBA8[color.Linear]) {
+	pf.guard(x, y, 1, 1, func() { pf.targetPixfmt.CopyPixel(x, y, c) })
+}
+
+func (pf *channelMaskPixfmt) BlendPixel(x, y int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.guard(x, y, 1, 1, func() { pf.targetPixfmt.BlendPixel(x, y, c, cover) })
+}
+
+func (pf *channelMaskPixfmt) CopyHline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.guard(x, y, length, 1, func() { pf.targetPixfmt.CopyHline(x, y, length, c) })
+}
+
+func (pf *channelMaskPixfmt) BlendHline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.guard(x, y, length, 1, func() { pf.targetPixfmt.BlendHline(x, y, length, c, cover) })
+}
+
+func (pf *channelMaskPixfmt) CopyVline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.guard(x, y, 1, length, func() { pf.targetPixfmt.CopyVline(x, y, length, c) })
+}
+
+func (pf *channelMaskPixfmt) BlendVline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.guard(x, y, 1, length, func() { pf.targetPixfmt.BlendVline(x, y, length, c, cover) })
+}
+
+func (pf *channelMaskPixfmt) CopyBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear]) {
+	pf.guard(x1, y1, x2-x1+1, y2-y1+1, func() { pf.targetPixfmt.CopyBar(x1, y1, x2, y2, c) })
+}
+
+func (pf *channelMaskPixfmt) BlendBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.guard(x1, y1, x2-x1+1, y2-y1+1, func() { pf.targetPixfmt.BlendBar(x1, y1, x2, y2, c, cover) })
+}
+
+func (pf *channelMaskPixfmt) BlendSolidHspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	pf.guard(x, y, length, 1, func() { pf.targetPixfmt.BlendSolidHspan(x, y, length, c, covers) })
+}
+
+func (pf *channelMaskPixfmt) BlendSolidVspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	pf.guard(x, y, 1, length, func() { pf.targetPixfmt.BlendSolidVspan(x, y, length, c, covers) })
+}
+
+func (pf *channelMaskPixfmt) CopyColorHspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	pf.guard(x, y, length, 1, func() { pf.targetPixfmt.CopyColorHspan(x, y, length, colors) })
+}
+
+func (pf *channelMaskPixfmt) BlendColorHspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	pf.guard(x, y, length, 1, func() { pf.targetPixfmt.BlendColorHspan(x, y, length, colors, covers, cover) })
+}
+
+func (pf *channelMaskPixfmt) CopyColorVspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	pf.guard(x, y, 1, length, func() { pf.targetPixfmt.CopyColorVspan(x, y, length, colors) })
+}
+
+func (pf *channelMaskPixfmt) BlendColorVspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	pf.guard(x, y, 1, length, func() { pf.targetPixfmt.BlendColorVspan(x, y, length, colors, covers, cover) })
+}
+
+func (pf *channelMaskPixfmt) Clear(c color.RGBA8[color.Linear]) {
+	pf.guard(0, 0, pf.Width(), pf.Height(), func() { pf.targetPixfmt.Clear(c) })
+}
+
+func (pf *channelMaskPixfmt) Fill(c color.RGBA8[color.Linear]) {
+	pf.guard(0, 0, pf.Width(), pf.Height(), func() { pf.targetPixfmt.Fill(c) })
+}
+
+// directTarget returns the pixel format to write to bypassing the renderers:
+// pf itself, or the channel-masked format of ren while channels are locked.
+func (agg2d *Agg2D) directTarget(pf targetPixfmt, ren *baseRendererAdapter[color.RGBA8[color.Linear]]) targetPixfmt {
+	if agg2d.lockedChannels != 0 && ren != nil {
+		return ren.pf
+	}
+	return pf
+}
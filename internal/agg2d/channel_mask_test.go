@@ -0,0 +1,51 @@
+package agg2d
+
+import "testing"
+
+func TestChannelMaskBGRA(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]uint8, 8*8*4)
+	agg2d.AttachOrdered(buf, 8, 8, 8*4, OrderBGRA)
+	agg2d.ClearAll(Color{10, 20, 30, 40})
+
+	// Only red may change; in BGRA order it is the third byte.
+	agg2d.SetChannelMask(ChannelRed)
+	if got := agg2d.ChannelMask(); got != ChannelRed {
+		t.Fatalf("ChannelMask() = %v, want %v", got, ChannelRed)
+	}
+	agg2d.FillColor(Color{255, 255, 255, 255})
+	agg2d.NoLine()
+	agg2d.Rectangle(0, 0, 4, 8)
+
+	b, g, r, a := pixelAtStride(buf, 8*4, 1, 1)
+	if b != 30 || g != 20 || r != 255 || a != 40 {
+		t.Errorf("filled pixel = BGRA(%d,%d,%d,%d), want (30,20,255,40)", b, g, r, a)
+	}
+	b, g, r, a = pixelAtStride(buf, 8*4, 6, 1)
+	if b != 30 || g != 20 || r != 10 || a != 40 {
+		t.Errorf("outside pixel = BGRA(%d,%d,%d,%d), want (30,20,10,40)", b, g, r, a)
+	}
+
+	// Images and composite blend modes write through the mask too.
+	src := make([]uint8, 2*2*4)
+	for i := range src {
+		src[i] = 255
+	}
+	agg2d.SetChannelMask(ChannelAlpha)
+	if err := agg2d.BlendImageSimple(NewImage(src, 2, 2, 2*4), 6, 6, 255); err != nil {
+		t.Fatal(err)
+	}
+	b, g, r, a = pixelAtStride(buf, 8*4, 6, 6)
+	if b != 30 || g != 20 || r != 10 || a != 255 {
+		t.Errorf("blended image pixel = BGRA(%d,%d,%d,%d), want (30,20,10,255)", b, g, r, a)
+	}
+
+	agg2d.SetBlendMode(BlendSrc)
+	agg2d.SetChannelMask(ChannelBlue)
+	agg2d.FillColor(Color{0, 0, 0, 255})
+	agg2d.Rectangle(4, 0, 8, 4)
+	b, g, r, a = pixelAtStride(buf, 8*4, 6, 1)
+	if b != 0 || g != 20 || r != 10 || a != 40 {
+		t.Errorf("composite pixel = BGRA(%d,%d,%d,%d), want (0,20,10,40)", b, g, r, a)
+	}
+}
@@ -45,6 +45,24 @@ const (
 	ResampleOnZoomOut ImageResample = 2
 )
 
+// ImageEdge constants select what image drawing samples beyond the edges of
+// the source image, as AGG's image accessors do.
+const (
+	// EdgeClamp repeats the edge pixels of the image (image_accessor_clone).
+	EdgeClamp ImageEdge = 0
+	// EdgeTransparent samples transparent black outside the source rectangle
+	// (image_accessor_clip), so filtered edges fade out.
+	EdgeTransparent ImageEdge = 1
+	// EdgeRepeat tiles the source rectangle (image_accessor_wrap with
+	// wrap_mode_repeat).
+	EdgeRepeat ImageEdge = 2
+	// EdgeReflect tiles the source rectangle mirrored (wrap_mode_reflect).
+	EdgeReflect ImageEdge = 3
+	// EdgeColor samples the image background color outside the source
+	// rectangle (image_accessor_clip), see ImageBackgroundColor.
+	EdgeColor ImageEdge = 4
+)
+
 // Additional TextAlignment constants
 const (
 	AlignRight  TextAlignment = 1
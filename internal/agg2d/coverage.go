@@ -0,0 +1,42 @@
+package agg2d
+
+// CoverageFunc receives the coverage of every shape drawn while it is
+// installed, one span at a time: covers[i] is the coverage (0-255) of device
+// pixel (x+i, y).
+type CoverageFunc func(x, y int, covers []uint8)
+
+// SetCoverageFunc installs f to observe the coverage of subsequent fills,
+// strokes and outline text, before they are painted. A nil f stops
+// reporting.
+func (agg2d *Agg2D) SetCoverageFunc(f CoverageFunc) {
+	agg2d.coverageFunc = f
+}
+
+// reportCoverage sweeps the rasterizer into the coverage func, if any, and
+// into the measured bounds during a dry run. The rasterizer keeps its cells,
+// so it can be swept again for painting; measureOnly tells the caller to
+// skip that.
+func (agg2d *Agg2D) reportCoverage() (measureOnly bool) {
+	f := agg2d.coverageFunc
+	if f == nil && !agg2d.measuring {
+		return false
+	}
+	ras, sl := agg2d.rasterizer, agg2d.scanline
+	if !ras.RewindScanlines() {
+		return agg2d.measuring
+	}
+	sl.Reset(ras.MinX(), ras.MaxX())
+	for ras.SweepScanline(sl) {
+		y := sl.Y()
+		for _, s := range sl.Spans() {
+			covers := s.Covers[:s.Len]
+			if f != nil {
+				f(int(s.X), y, covers)
+			}
+			if agg2d.measuring {
+				agg2d.measureCovers(int(s.X), y, covers)
+			}
+		}
+	}
+	return agg2d.measuring
+}
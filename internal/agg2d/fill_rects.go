@@ -0,0 +1,165 @@
+package agg2d
+
+import (
+	"math"
+	"sort"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/shapes"
+)
+
+// RectColor is one rectangle of a FillRects batch, in user coordinates.
+type RectColor struct {
+	X, Y, Width, Height float64
+	Radius              float64 // corner radius; zero gives sharp corners
+	Color               Color
+}
+
+// FillRects fills a batch of rectangles, each with its own color. The current
+// path, fill color and gradient are left untouched.
+//
+// Rectangles are grouped by color, so overlapping rectangles of different
+// colors are not guaranteed to be painted in slice order. Sharp rectangles
+// under a transform without rotation or skew are blended directly as spans,
+// with anti-aliased fractional edges, skipping the rasterizer; rounded or
+// rotated ones are rasterized with one pass per color.
+func (agg2d *Agg2D) FillRects(rects []RectColor) {
+	renderer := agg2d.currentRenderer()
+	if renderer == nil || len(rects) == 0 {
+		return
+	}
+	if agg2d.coverageFunc != nil || agg2d.measuring {
+		// Direct rectangles skip the rasterizer, so observers see each
+		// rectangle swept on its own, as if filled one by one.
+		measureOnly := false
+		for i := range rects {
+			agg2d.rasterizer.Reset()
+			agg2d.rasterizer.FillingRule(basics.FillNonZero)
+			agg2d.addRectToRasterizer(&rects[i])
+			measureOnly = agg2d.reportCoverage()
+		}
+		if measureOnly {
+			return
+		}
+	}
+
+	order := make([]int, len(rects))
+	for i := range order {
+		order[i] = i
+	}
+	direct := agg2d.transform.SHX == 0 && agg2d.transform.SHY == 0
+	isDirect := func(r *RectColor) bool { return direct && r.Radius <= 0 }
+	sort.SliceStable(order, func(a, b int) bool {
+		ra, rb := &rects[order[a]], &rects[order[b]]
+		ka, kb := colorKey(ra.Color), colorKey(rb.Color)
+		if ka != kb {
+			return ka < kb
+		}
+		return isDirect(ra) && !isDirect(rb)
+	})
+
+	masterAlpha := uint8(agg2d.masterAlpha * 255.0)
+	for start := 0; start < len(order); {
+		c := rects[order[start]].Color
+		ic := color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: uint8(uint16(c[3]) * uint16(masterAlpha) / 255)}
+
+		end := start
+		for end < len(order) && rects[order[end]].Color == c && isDirect(&rects[order[end]]) {
+			agg2d.blendRect(renderer, &rects[order[end]], ic)
+			end++
+		}
+		if end < len(order) && rects[order[end]].Color == c {
+			// The remaining rectangles of this color share one rasterizer pass.
+			agg2d.rasterizer.Reset()
+			agg2d.rasterizer.FillingRule(basics.FillNonZero)
+			for end < len(order) && rects[order[end]].Color == c {
+				agg2d.addRectToRasterizer(&rects[order[end]])
+				end++
+			}
+			agg2d.scanlineRender(renscan.NewRendererScanlineAASolidWithColor(renderer, ic))
+		}
+		start = end
+	}
+}
+
+func colorKey(c Color) uint32 {
+	return uint32(c[0])<<24 | uint32(c[1])<<16 | uint32(c[2])<<8 | uint32(c[3])
+}
+
+// blendRect blends an axis-aligned rectangle straight into the renderer,
+// with partial coverage on fractional edges.
+func (agg2d *Agg2D) blendRect(renderer *baseRendererAdapter[color.RGBA8[color.Linear]], r *RectColor, c color.RGBA8[color.Linear]) {
+	x0, y0 := r.X, r.Y
+	x1, y1 := r.X+r.Width, r.Y+r.Height
+	agg2d.transform.Transform(&x0, &y0)
+	agg2d.transform.Transform(&x1, &y1)
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	if agg2d.pixelSnapping {
+		x0, y0, x1, y1 = math.Round(x0), math.Round(y0), math.Round(x1), math.Round(y1)
+	}
+	if x1-x0 <= 0 || y1-y0 <= 0 {
+		return
+	}
+
+	ix0, ix1 := int(math.Floor(x0)), int(math.Ceil(x1))-1
+	iy0, iy1 := int(math.Floor(y0)), int(math.Ceil(y1))-1
+	coverX0 := math.Min(float64(ix0+1), x1) - x0
+	coverX1 := x1 - math.Max(float64(ix1), x0)
+	for y := iy0; y <= iy1; y++ {
+		cy := math.Min(float64(y+1), y1) - math.Max(float64(y), y0)
+		if ix0 == ix1 {
+			renderer.BlendPixel(ix0, y, c, agg2d.rectCover(coverX0*cy))
+			continue
+		}
+		renderer.BlendPixel(ix0, y, c, agg2d.rectCover(coverX0*cy))
+		if ix1 > ix0+1 {
+			renderer.BlendHline(ix0+1, y, ix1-1, c, agg2d.rectCover(cy))
+		}
+		renderer.BlendPixel(ix1, y, c, agg2d.rectCover(coverX1*cy))
+	}
+}
+
+// rectCover converts an area fraction to a cover value with the rasterizer's
+// anti-alias gamma, so direct rectangles match rasterized ones.
+func (agg2d *Agg2D) rectCover(area float64) basics.Int8u {
+	cover := int(area*basics.CoverFull + 0.5)
+	return basics.Int8u(agg2d.rasterizer.ApplyGamma(cover))
+}
+
+func (agg2d *Agg2D) addRectToRasterizer(r *RectColor) {
+	add := func(x, y float64, cmd basics.PathCommand) {
+		if basics.IsVertex(cmd) {
+			agg2d.transform.Transform(&x, &y)
+		}
+		agg2d.rasterizer.AddVertex(x, y, uint32(cmd))
+	}
+	x1, y1, x2, y2 := r.X, r.Y, r.X+r.Width, r.Y+r.Height
+	if r.Radius <= 0 {
+		add(x1, y1, basics.PathCmdMoveTo)
+		add(x2, y1, basics.PathCmdLineTo)
+		add(x2, y2, basics.PathCmdLineTo)
+		add(x1, y2, basics.PathCmdLineTo)
+		add(0, 0, basics.PathCmdEndPoly|basics.PathFlagClose)
+		return
+	}
+
+	rr := shapes.NewRoundedRect(x1, y1, x2, y2, r.Radius)
+	rr.NormalizeRadius()
+	rr.SetApproximationScale(agg2d.EffectiveApproximationScale())
+	rr.Rewind(0)
+	for {
+		var x, y float64
+		cmd := rr.Vertex(&x, &y)
+		if basics.IsStop(cmd) {
+			break
+		}
+		add(x, y, cmd)
+	}
+}
@@ -0,0 +1,100 @@
+package agg2d
+
+import (
+	"errors"
+
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/font/freetype"
+)
+
+// fallbackFont is one entry of the font fallback chain. Each fallback owns its
+// engine and cache manager so its glyphs never evict the primary font's.
+type fallbackFont struct {
+	fileName   string
+	engine     *freetype.FontEngineFreetype
+	fcm        *font.FontCacheManager
+	loadedType freetype.GlyphRenderingType
+	loaded     bool
+}
+
+// AddFallbackFont appends fileName to the font fallback chain. Characters the
+// primary font has no glyph for are looked up in the fallback fonts in the
+// order they were added, for example a CJK or symbol font behind a Latin one.
+// Fallback fonts follow the height, rendering mode, hinting and flip settings
+// of the primary font.
+func (agg2d *Agg2D) AddFallbackFont(fileName string) error {
+	if fileName == "" {
+		return errors.New("font file name is empty")
+	}
+	engine, err := freetype.NewFontEngineFreetype(false, 32)
+	if err != nil {
+		return err
+	}
+	f := &fallbackFont{
+		fileName: fileName,
+		engine:   engine,
+		fcm:      font.NewFontCacheManager(engine, 32),
+	}
+	if err := agg2d.syncFallbackFont(f); err != nil {
+		return err
+	}
+	agg2d.fallbackFonts = append(agg2d.fallbackFonts, f)
+	agg2d.ClearGlyphRunCache()
+	return nil
+}
+
+// ClearFallbackFonts removes all fallback fonts.
+func (agg2d *Agg2D) ClearFallbackFonts() {
+	agg2d.fallbackFonts = nil
+	agg2d.ClearGlyphRunCache()
+}
+
+// FallbackFonts returns the file names of the fallback chain in lookup order.
+func (agg2d *Agg2D) FallbackFonts() []string {
+	names := make([]string, len(agg2d.fallbackFonts))
+	for i, f := range agg2d.fallbackFonts {
+		names[i] = f.fileName
+	}
+	return names
+}
+
+// syncFallbackFonts applies the primary font settings to every fallback.
+func (agg2d *Agg2D) syncFallbackFonts() error {
+	for _, f := range agg2d.fallbackFonts {
+		if err := agg2d.syncFallbackFont(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncFallbackFont configures f like the primary font, loading the face the
+// first time and whenever the glyph rendering type changes.
+func (agg2d *Agg2D) syncFallbackFont(f *fallbackFont) error {
+	if f.engine == nil {
+		return nil
+	}
+	renderingType := agg2d.glyphRenderingType()
+	f.engine.SetResolution(agg2d.resolution)
+	f.engine.SetFlipY(agg2d.flipText)
+	if !f.loaded || f.loadedType != renderingType {
+		if err := agg2d.loadFace(f.engine, f.fileName, renderingType); err != nil {
+			return err
+		}
+		f.loaded, f.loadedType = true, renderingType
+	}
+	agg2d.configureFontEngine(f.engine)
+	return nil
+}
+
+// fallbackCacheManagers returns the cache managers of the fallback chain.
+func (agg2d *Agg2D) fallbackCacheManagers() []*font.FontCacheManager {
+	if len(agg2d.fallbackFonts) == 0 {
+		return nil
+	}
+	fcms := make([]*font.FontCacheManager, len(agg2d.fallbackFonts))
+	for i, f := range agg2d.fallbackFonts {
+		fcms[i] = f.fcm
+	}
+	return fcms
+}
@@ -0,0 +1,57 @@
+package agg2d
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+)
+
+func TestFallbackFontSuppliesMissingGlyphs(t *testing.T) {
+	bounds := basics.Rect[int]{X1: 0, Y1: 0, X2: 2, Y2: 2}
+	primary := newMockTextFontEngine()
+	primary.glyphs[uint('a')] = mockOutlineGlyph{glyphIndex: 1, advanceX: 2, bounds: bounds}
+	fallback := newMockTextFontEngine()
+	fallback.glyphs[uint('a')] = mockOutlineGlyph{glyphIndex: 7, advanceX: 9, bounds: bounds}
+	fallback.glyphs[uint('字')] = mockOutlineGlyph{glyphIndex: 8, advanceX: 5, bounds: bounds}
+	fallback.kerning[[2]uint{1, 8}] = -100 // must not apply across fonts
+
+	agg2d := NewAgg2D()
+	buf := make([]byte, 16*16*4)
+	agg2d.Attach(buf, 16, 16, 16*4)
+	agg2d.fontCacheType = VectorFontCache
+	agg2d.fontCacheManager = font.NewFontCacheManager(primary, 32)
+
+	if got := agg2d.TextWidth("a字"); got != 2 {
+		t.Fatalf("TextWidth without fallback=%v, want 2", got)
+	}
+
+	agg2d.fallbackFonts = append(agg2d.fallbackFonts, &fallbackFont{
+		fileName: "fallback.ttf",
+		fcm:      font.NewFontCacheManager(fallback, 32),
+	})
+	agg2d.ClearGlyphRunCache()
+
+	run := agg2d.glyphRun("a字")
+	if len(run.glyphs) != 2 {
+		t.Fatalf("got %d glyphs, want 2", len(run.glyphs))
+	}
+	if run.glyphs[0].glyph.GlyphIndex != 1 {
+		t.Fatal("primary font glyph should win over the fallback")
+	}
+	if run.glyphs[1].glyph.GlyphIndex != 8 || run.glyphs[1].x != 2 {
+		t.Fatalf("fallback glyph index=%d at x=%v, want 8 at 2",
+			run.glyphs[1].glyph.GlyphIndex, run.glyphs[1].x)
+	}
+	if run.advanceX != 7 {
+		t.Fatalf("advance=%v, want 7", run.advanceX)
+	}
+	if names := agg2d.FallbackFonts(); len(names) != 1 || names[0] != "fallback.ttf" {
+		t.Fatalf("FallbackFonts()=%v", names)
+	}
+
+	agg2d.ClearFallbackFonts()
+	if got := agg2d.TextWidth("a字"); got != 2 {
+		t.Fatalf("TextWidth after ClearFallbackFonts=%v, want 2", got)
+	}
+}
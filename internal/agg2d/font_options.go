@@ -0,0 +1,242 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/font/freetype"
+)
+
+// GlyphRendering selects how FreeType glyphs are produced and drawn, mapping
+// to AGG's glyph_ren_* rendering types.
+type GlyphRendering int
+
+const (
+	// GlyphGray8 draws 8-bit anti-aliased glyph bitmaps (glyph_ren_agg_gray8).
+	// This is what Font uses for RasterFontCache.
+	GlyphGray8 GlyphRendering = iota
+	// GlyphMono draws 1-bit glyph bitmaps without anti-aliasing
+	// (glyph_ren_agg_mono).
+	GlyphMono
+	// GlyphLCD draws LCD subpixel coverage for horizontal RGB or BGR panels.
+	GlyphLCD
+	// GlyphOutline rasterizes glyph outlines through the Agg2D path pipeline
+	// (glyph_ren_outline). This is what Font uses for VectorFontCache.
+	GlyphOutline
+)
+
+// HintingMode selects how glyph outlines are grid-fitted.
+type HintingMode = freetype.HintingMode
+
+const (
+	HintingNative = freetype.HintingNative
+	HintingAuto   = freetype.HintingAuto
+	HintingNone   = freetype.HintingNone
+)
+
+// SubpixelOrder is the colour stripe order used by GlyphLCD.
+type SubpixelOrder = freetype.SubpixelOrder
+
+const (
+	SubpixelRGB = freetype.SubpixelRGB
+	SubpixelBGR = freetype.SubpixelBGR
+)
+
+// FontOptions configures FontWithOptions. The zero value selects gray8
+// anti-aliased raster glyphs with native hinting.
+type FontOptions struct {
+	Rendering GlyphRendering
+	Hinting   HintingMode
+	Subpixel  SubpixelOrder // stripe order for GlyphLCD
+	Angle     float64       // text rotation in radians, as in Font
+
+	// SubpixelPositions is the number of horizontal phases raster glyphs are
+	// rendered at, up to 64 (1/64 pixel). Each glyph is drawn from the phase
+	// closest to its fractional pen position instead of being snapped to
+	// whole pixels, which keeps the spacing of small text even. 0 or 1 turns
+	// it off; 3 or 4 is usually enough. Every phase is cached separately.
+	SubpixelPositions int
+}
+
+// FontWithOptions loads fileName like Font, with explicit control over the
+// glyph rendering mode and hinting.
+func (agg2d *Agg2D) FontWithOptions(fileName string, height float64, opts FontOptions) error {
+	agg2d.glyphRendering = opts.Rendering
+	agg2d.hintingMode = opts.Hinting
+	agg2d.textHints = opts.Hinting != HintingNone
+	agg2d.subpixelOrder = opts.Subpixel
+	agg2d.subpixelPhases = max(0, min(64, opts.SubpixelPositions))
+	cacheType := RasterFontCache
+	if opts.Rendering == GlyphOutline {
+		cacheType = VectorFontCache
+	}
+	return agg2d.loadFont(fileName, height, cacheType, opts.Angle)
+}
+
+// GetGlyphRendering returns the glyph rendering mode of the current font.
+func (agg2d *Agg2D) GetGlyphRendering() GlyphRendering {
+	return agg2d.glyphRendering
+}
+
+// GetHintingMode returns the hinting mode of the current font.
+func (agg2d *Agg2D) GetHintingMode() HintingMode {
+	return agg2d.hintingMode
+}
+
+// GetSubpixelPositions returns the number of horizontal raster glyph phases,
+// or 0 when glyphs are snapped to whole pixels.
+func (agg2d *Agg2D) GetSubpixelPositions() int {
+	if agg2d.subpixelPhases <= 1 {
+		return 0
+	}
+	return agg2d.subpixelPhases
+}
+
+// subpixelShifter is implemented by font engines that can render raster
+// glyphs at a fractional horizontal offset.
+type subpixelShifter interface {
+	SetSubpixelShift(shift int)
+}
+
+// subpixelGlyph returns the raster glyph of g rendered at the phase nearest to
+// the fractional part of x, and the whole-pixel position to draw it at.
+// Without subpixel positioning, or when the engine cannot shift glyphs, it
+// returns g's own glyph and x unchanged.
+func (agg2d *Agg2D) subpixelGlyph(g *glyphRunGlyph, x float64) (*font.GlyphCache, float64) {
+	n := agg2d.subpixelPhases
+	if n <= 1 || g.src == nil {
+		return g.glyph, x
+	}
+	shifter, ok := g.src.FontEngine().(subpixelShifter)
+	if !ok {
+		return g.glyph, x
+	}
+	px := math.Floor(x)
+	phase := int(math.Round((x - px) * float64(n)))
+	if phase == n {
+		px, phase = px+1, 0
+	}
+	if phase == 0 {
+		return g.glyph, px
+	}
+	shifter.SetSubpixelShift(phase * 64 / n)
+	glyph := g.src.GlyphByIndex(g.glyph.GlyphIndex)
+	shifter.SetSubpixelShift(0)
+	if glyph == nil {
+		return g.glyph, x
+	}
+	return glyph, px
+}
+
+// glyphRenderingType maps the glyph rendering mode to the engine rendering type.
+func (agg2d *Agg2D) glyphRenderingType() freetype.GlyphRenderingType {
+	switch agg2d.glyphRendering {
+	case GlyphOutline:
+		return freetype.GlyphRenderingOutline
+	case GlyphMono:
+		return freetype.GlyphRenderingAAMono
+	case GlyphLCD:
+		return freetype.GlyphRenderingLCD
+	default:
+		return freetype.GlyphRenderingAAGray8
+	}
+}
+
+// configureFontEngine applies the shared glyph settings to a font engine.
+func (agg2d *Agg2D) configureFontEngine(engine *freetype.FontEngineFreetype) {
+	engine.SetHintingMode(agg2d.hintingMode)
+	engine.SetSubpixelOrder(agg2d.subpixelOrder)
+	if agg2d.fontCacheType == VectorFontCache {
+		engine.SetHeight(agg2d.fontHeight)
+	} else {
+		// Raster glyph caches are configured in screen units.
+		engine.SetHeight(agg2d.WorldToScreenScalar(agg2d.fontHeight))
+	}
+}
+
+// renderLCDGlyph blends an LCD glyph bitmap with its origin at x, y (screen
+// space) into the attached buffer, weighting each colour channel by its own
+// subpixel coverage. Blend modes other than BlendAlpha are not applied to
+// subpixel text.
+func (agg2d *Agg2D) renderLCDGlyph(data []byte, bounds basics.Rect[int], x, y float64) {
+	width := bounds.X2 - bounds.X1
+	height := bounds.Y2 - bounds.Y1
+	if width <= 0 || height <= 0 || len(data) == 0 || agg2d.rbuf == nil {
+		return
+	}
+	pitch := len(data) / height
+	if pitch < width*3 {
+		return
+	}
+
+	clipX1 := max(0, int(agg2d.clipBox.X1))
+	clipY1 := max(0, int(agg2d.clipBox.Y1))
+	clipX2 := min(agg2d.rbuf.Width(), int(agg2d.clipBox.X2))
+	clipY2 := min(agg2d.rbuf.Height(), int(agg2d.clipBox.Y2))
+	if agg2d.measuring {
+		x0, y0 := bounds.X1+basics.IRound(x), bounds.Y1+basics.IRound(y)
+		agg2d.measureRect(max(x0, clipX1), max(y0, clipY1), min(x0+width, clipX2), min(y0+height, clipY2))
+		return
+	}
+
+	src := agg2d.fillColor
+	alpha := int(float64(src[3]) * agg2d.masterAlpha)
+	gamma := agg2d.glyphGammaTable()
+	baseX := bounds.X1 + basics.IRound(x)
+	baseY := bounds.Y1 + basics.IRound(y)
+	co := agg2d.pixelOrder.colorOrder()
+	channels := [3]int{co.R, co.G, co.B}
+	// Formats other than the 32-bit ones are blended as RGBA and converted.
+	f := agg2d.targetFormat
+	bpp, converted := f.BytesPerPixel(), targetFormats[f].decode != nil
+	// The channel mask applies as it does through channelMaskPixfmt.
+	locked := agg2d.lockedChannels
+	if bpp != 4 {
+		locked = 0
+	}
+	var rgba [4]uint8
+	for row := 0; row < height; row++ {
+		py := baseY + row
+		if py < clipY1 || py >= clipY2 {
+			continue
+		}
+		line := data[row*pitch:]
+		dst := agg2d.rbuf.Row(py)
+		for col := 0; col < width; col++ {
+			px := baseX + col
+			if px < clipX1 || px >= clipX2 {
+				continue
+			}
+			covers := line[col*3 : col*3+3]
+			if covers[0]|covers[1]|covers[2] == 0 {
+				continue
+			}
+			p := dst[px*bpp:]
+			if converted {
+				rgba = f.Decode(p)
+				p = rgba[:]
+			}
+			maxCover := 0
+			for c := 0; c < 3; c++ {
+				cover := covers[c]
+				if gamma != nil {
+					cover = gamma[cover]
+				}
+				a := int(cover) * alpha / 255
+				maxCover = max(maxCover, a)
+				if locked&(1<<c) != 0 {
+					continue
+				}
+				d := &p[channels[c]]
+				*d = uint8(int(*d) + (int(src[c])-int(*d))*a/255)
+			}
+			if locked&ChannelAlpha == 0 {
+				p[co.A] = uint8(int(p[co.A]) + (255-int(p[co.A]))*maxCover/255)
+			}
+			if converted {
+				f.Encode(dst[px*bpp:], rgba)
+			}
+		}
+	}
+}
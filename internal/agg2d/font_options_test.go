@@ -0,0 +1,209 @@
+package agg2d
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+)
+
+func TestRenderLCDGlyphBlendsChannelsIndependently(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]byte, 4*4*4)
+	agg2d.Attach(buf, 4, 4, 4*4)
+	agg2d.ClearAll(White)
+	agg2d.FillColor(Black)
+
+	// One pixel with full red coverage, half green coverage, no blue coverage.
+	data := []byte{255, 128, 0}
+	agg2d.renderLCDGlyph(data, basics.Rect[int]{X1: 0, Y1: 0, X2: 1, Y2: 1}, 1, 2)
+
+	r, g, b, a := pixelAt(buf, 4, 1, 2)
+	if r != 0 || g < 126 || g > 128 || b != 255 || a != 255 {
+		t.Fatalf("pixel=(%d,%d,%d,%d), want (0,~127,255,255)", r, g, b, a)
+	}
+	if r, g, b, _ := pixelAt(buf, 4, 0, 2); r != 255 || g != 255 || b != 255 {
+		t.Fatal("neighbouring pixel should be untouched")
+	}
+}
+
+func TestRenderLCDGlyphRespectsClipBox(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]byte, 4*4*4)
+	agg2d.Attach(buf, 4, 4, 4*4)
+	agg2d.ClearAll(White)
+	agg2d.FillColor(Black)
+	agg2d.ClipBox(0, 0, 1, 4)
+
+	agg2d.renderLCDGlyph([]byte{255, 255, 255}, basics.Rect[int]{X1: 0, Y1: 0, X2: 1, Y2: 1}, 2, 1)
+	if r, _, _, _ := pixelAt(buf, 4, 2, 1); r != 255 {
+		t.Fatal("glyph outside the clip box must not be drawn")
+	}
+}
+
+func TestRenderLCDGlyphChannelMask(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]byte, 4*4*4)
+	agg2d.Attach(buf, 4, 4, 4*4)
+	agg2d.ClearAll(Color{255, 255, 255, 0})
+	agg2d.FillColor(Black)
+	agg2d.SetChannelMask(ChannelGreen)
+
+	agg2d.renderLCDGlyph([]byte{255, 255, 255}, basics.Rect[int]{X1: 0, Y1: 0, X2: 1, Y2: 1}, 1, 1)
+	if r, g, b, a := pixelAt(buf, 4, 1, 1); r != 255 || g != 0 || b != 255 || a != 0 {
+		t.Fatalf("pixel=(%d,%d,%d,%d), want (255,0,255,0)", r, g, b, a)
+	}
+}
+
+func TestFontWithOptionsRenderingModes(t *testing.T) {
+	fontPath := findSystemFont()
+	if fontPath == "" {
+		t.Skip("No system font found for FreeType testing")
+	}
+
+	modes := []struct {
+		name      string
+		opts      FontOptions
+		cacheType FontCacheType
+	}{
+		{"Gray8", FontOptions{Rendering: GlyphGray8}, RasterFontCache},
+		{"Mono", FontOptions{Rendering: GlyphMono, Hinting: HintingAuto}, RasterFontCache},
+		{"LCD", FontOptions{Rendering: GlyphLCD, Subpixel: SubpixelBGR}, RasterFontCache},
+		{"Outline", FontOptions{Rendering: GlyphOutline, Hinting: HintingNone}, VectorFontCache},
+	}
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			agg2d := NewAgg2D()
+			buf := make([]byte, 64*32*4)
+			agg2d.Attach(buf, 64, 32, 64*4)
+			agg2d.ClearAll(White)
+			agg2d.FillColor(Black)
+			if err := agg2d.FontWithOptions(fontPath, 20, mode.opts); err != nil {
+				t.Skip("FreeType not available or font load failed")
+			}
+			if agg2d.GetGlyphRendering() != mode.opts.Rendering || agg2d.fontCacheType != mode.cacheType {
+				t.Fatalf("rendering=%v cache=%v", agg2d.GetGlyphRendering(), agg2d.fontCacheType)
+			}
+			if agg2d.GetTextHints() != (mode.opts.Hinting != HintingNone) {
+				t.Fatal("TextHints should follow the hinting mode")
+			}
+
+			agg2d.FlipText(true)
+			agg2d.Text(4, 24, "Hg", false, 0, 0)
+			inked := false
+			for i := 0; i < len(buf); i += 4 {
+				if buf[i] != 255 || buf[i+1] != 255 || buf[i+2] != 255 {
+					inked = true
+					break
+				}
+			}
+			if !inked {
+				t.Fatal("expected text pixels")
+			}
+		})
+	}
+}
+
+func TestFontResetsGlyphOptions(t *testing.T) {
+	fontPath := findSystemFont()
+	if fontPath == "" {
+		t.Skip("No system font found for FreeType testing")
+	}
+	agg2d := NewAgg2D()
+	opts := FontOptions{Rendering: GlyphLCD, Hinting: HintingAuto, Subpixel: SubpixelBGR, SubpixelPositions: 4}
+	if err := agg2d.FontWithOptions(fontPath, 20, opts); err != nil {
+		t.Skip("FreeType not available or font load failed")
+	}
+	if err := agg2d.Font(fontPath, 20, false, false, RasterFontCache, 0); err != nil {
+		t.Fatal(err)
+	}
+	if agg2d.GetHintingMode() != HintingNative || agg2d.subpixelOrder != SubpixelRGB || agg2d.GetSubpixelPositions() != 0 {
+		t.Fatalf("hinting=%v subpixel=%v positions=%d, want the defaults",
+			agg2d.GetHintingMode(), agg2d.subpixelOrder, agg2d.GetSubpixelPositions())
+	}
+
+	agg2d.TextHints(false)
+	if err := agg2d.Font(fontPath, 20, false, false, RasterFontCache, 0); err != nil {
+		t.Fatal(err)
+	}
+	if agg2d.GetHintingMode() != HintingNone {
+		t.Fatalf("hinting=%v, want HintingNone after TextHints(false)", agg2d.GetHintingMode())
+	}
+}
+
+// shiftingFontEngine records the subpixel shifts glyphs are prepared with.
+type shiftingFontEngine struct {
+	*mockTextFontEngine
+	shift    int
+	prepared []int
+}
+
+func (e *shiftingFontEngine) FontSignature() string        { return fmt.Sprintf("mock-shift-%d", e.shift) }
+func (e *shiftingFontEngine) SetSubpixelShift(shift int)   { e.shift = shift }
+func (e *shiftingFontEngine) DataType() font.GlyphDataType { return font.GlyphDataGray8 }
+
+func (e *shiftingFontEngine) PrepareGlyph(glyphCode uint) bool {
+	e.prepared = append(e.prepared, e.shift)
+	return e.mockTextFontEngine.PrepareGlyph(glyphCode)
+}
+
+func (e *shiftingFontEngine) PrepareGlyphByIndex(glyphIndex uint) bool {
+	for code, g := range e.glyphs {
+		if g.glyphIndex == glyphIndex {
+			return e.PrepareGlyph(code)
+		}
+	}
+	return false
+}
+
+func TestSubpixelGlyphSelectsPhase(t *testing.T) {
+	engine := &shiftingFontEngine{mockTextFontEngine: newMockTextFontEngine()}
+	engine.glyphs[uint('a')] = mockOutlineGlyph{glyphIndex: 7, advanceX: 5, bounds: basics.Rect[int]{X2: 2, Y2: 2}}
+	fcm := font.NewFontCacheManager(engine, 32)
+	base := fcm.Glyph('a')
+	g := &glyphRunGlyph{glyph: base, src: fcm}
+
+	agg2d := NewAgg2D()
+	if glyph, x := agg2d.subpixelGlyph(g, 10.3); glyph != base || x != 10.3 {
+		t.Fatalf("disabled: got x=%v, want the run glyph at 10.3", x)
+	}
+
+	agg2d.subpixelPhases = 4
+	tests := []struct {
+		x, wantX  float64
+		wantShift int // 0: the unshifted run glyph
+	}{
+		{10, 10, 0},
+		{10.3, 10, 16},
+		{10.5, 10, 32},
+		{10.7, 10, 48},
+		{10.9, 11, 0},
+	}
+	for _, tt := range tests {
+		engine.prepared = nil
+		glyph, x := agg2d.subpixelGlyph(g, tt.x)
+		if x != tt.wantX {
+			t.Errorf("x=%v: drawn at %v, want %v", tt.x, x, tt.wantX)
+		}
+		if tt.wantShift == 0 {
+			if glyph != base {
+				t.Errorf("x=%v: want the unshifted glyph", tt.x)
+			}
+			continue
+		}
+		if glyph == base || len(engine.prepared) != 1 || engine.prepared[0] != tt.wantShift {
+			t.Errorf("x=%v: prepared with shifts %v, want [%d]", tt.x, engine.prepared, tt.wantShift)
+		}
+		if engine.shift != 0 {
+			t.Errorf("x=%v: engine shift left at %d", tt.x, engine.shift)
+		}
+	}
+
+	// Phases are cached like any other glyph.
+	engine.prepared = nil
+	agg2d.subpixelGlyph(g, 20.3)
+	if len(engine.prepared) != 0 {
+		t.Fatal("a cached phase should not be prepared again")
+	}
+}
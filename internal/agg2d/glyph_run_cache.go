@@ -0,0 +1,289 @@
+package agg2d
+
+import (
+	"container/list"
+
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/font/shaping"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+// DefaultGlyphRunCacheSize is the number of laid-out strings an Agg2D keeps
+// by default.
+const DefaultGlyphRunCacheSize = 256
+
+// GlyphRunCacheStats reports glyph-run cache usage.
+type GlyphRunCacheStats struct {
+	Entries   int    // Runs currently cached
+	Capacity  int    // Maximum number of cached runs (0 = disabled)
+	Hits      uint64 // Lookups served from the cache
+	Misses    uint64 // Lookups that had to query the font engine
+	Evictions uint64 // Runs dropped to respect Capacity
+}
+
+// glyphRunGlyph is one positioned glyph of a run. Raster glyphs reference the
+// coverage data held by the font cache; outline glyphs carry their own copy of
+// the outline because the engine's path adaptor only holds the last glyph.
+type glyphRunGlyph struct {
+	glyph   *font.GlyphCache
+	src     *font.FontCacheManager // font the glyph came from
+	x, y    float64                // pen offset from the run origin, kerning included
+	outline *path.PathStorageStl
+}
+
+// glyphRun is the laid-out form of one string for one font signature.
+type glyphRun struct {
+	key      string
+	glyphs   []glyphRunGlyph
+	clusters []glyphRunCluster // visual order
+	advanceX float64
+	advanceY float64
+}
+
+// glyphRunCluster marks where the glyphs of one source cluster start along
+// the run. A cluster ends where the next one starts, or at the run advance.
+type glyphRunCluster struct {
+	index int     // first source rune of the cluster, as reported by the shaper
+	x     float64 // pen position before the cluster's first glyph, kerning included
+}
+
+// glyphRunCache is an LRU cache of glyph runs keyed by font signature and
+// text, so repeated labels skip the per-character engine and kerning calls.
+type glyphRunCache struct {
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+	stats    GlyphRunCacheStats
+}
+
+func newGlyphRunCache(capacity int) *glyphRunCache {
+	return &glyphRunCache{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+func (c *glyphRunCache) get(key string) *glyphRun {
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		c.stats.Hits++
+		return el.Value.(*glyphRun)
+	}
+	c.stats.Misses++
+	return nil
+}
+
+func (c *glyphRunCache) put(run *glyphRun) {
+	if c.capacity <= 0 {
+		return
+	}
+	c.entries[run.key] = c.lru.PushFront(run)
+	c.trim()
+}
+
+func (c *glyphRunCache) trim() {
+	for c.lru.Len() > c.capacity {
+		el := c.lru.Back()
+		c.lru.Remove(el)
+		delete(c.entries, el.Value.(*glyphRun).key)
+		c.stats.Evictions++
+	}
+}
+
+func (c *glyphRunCache) clear() {
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
+
+// SetGlyphRunCacheSize sets how many laid-out strings are cached. Zero
+// disables the cache; shrinking evicts the least recently used runs.
+func (agg2d *Agg2D) SetGlyphRunCacheSize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	c := agg2d.glyphRuns()
+	c.capacity = n
+	c.trim()
+}
+
+// GlyphRunCacheStats returns the current glyph-run cache statistics.
+func (agg2d *Agg2D) GlyphRunCacheStats() GlyphRunCacheStats {
+	c := agg2d.glyphRuns()
+	s := c.stats
+	s.Entries = c.lru.Len()
+	s.Capacity = c.capacity
+	return s
+}
+
+// ClearGlyphRunCache drops all cached runs and resets the statistics.
+func (agg2d *Agg2D) ClearGlyphRunCache() {
+	c := agg2d.glyphRuns()
+	c.clear()
+	c.stats = GlyphRunCacheStats{}
+}
+
+func (agg2d *Agg2D) glyphRuns() *glyphRunCache {
+	if agg2d.glyphRunCache == nil {
+		agg2d.glyphRunCache = newGlyphRunCache(DefaultGlyphRunCacheSize)
+	}
+	return agg2d.glyphRunCache
+}
+
+// glyphRun returns the laid-out run for str with the current FreeType font,
+// building and caching it on a miss.
+func (agg2d *Agg2D) glyphRun(str string) *glyphRun {
+	fcm := agg2d.fontCacheManager
+	if fcm == nil {
+		return nil
+	}
+	c := agg2d.glyphRuns()
+	key := str
+	if engine := fcm.FontEngine(); engine != nil {
+		key = engine.FontSignature() + "\x00" + str
+	}
+	if !agg2d.textKerning {
+		key = "k0\x00" + key
+	}
+	if agg2d.textLigatures {
+		key = "liga\x00" + key
+	}
+	if run := c.get(key); run != nil {
+		return run
+	}
+
+	b := glyphRunBuilder{run: &glyphRun{key: key}, fcm: fcm, fallbacks: agg2d.fallbackCacheManagers()}
+	shaped := agg2d.shaper().Shape(str, agg2d.shapingFont())
+	if shaped.Positioned {
+		agg2d.layoutPositioned(&b, shaped.Glyphs)
+	} else {
+		agg2d.layoutRunes(&b, shaped.Glyphs)
+	}
+	b.run.advanceX, b.run.advanceY = b.x, b.y
+	c.put(b.run)
+	return b.run
+}
+
+// layoutRunes places glyphs by the font's own advances, applying standard
+// ligatures and pair kerning when enabled.
+func (agg2d *Agg2D) layoutRunes(b *glyphRunBuilder, shaped []shaping.ShapedGlyph) {
+	fcm := b.fcm
+	runes := make([]rune, len(shaped))
+	for i, g := range shaped {
+		runes[i] = g.Rune
+	}
+	for i := 0; i < len(runes); i++ {
+		b.cluster = shaped[i].Cluster
+		if agg2d.textLigatures {
+			if lig, n := matchLigature(runes, i); n > 0 {
+				if glyph := fcm.Glyph(uint(lig)); glyph != nil {
+					b.add(glyph, fcm, agg2d.textKerning)
+					i += n - 1
+					continue
+				}
+			}
+		}
+		if glyph, src := b.lookup(runes[i]); glyph != nil {
+			b.add(glyph, src, agg2d.textKerning)
+			continue
+		}
+		// Fonts without Arabic presentation forms still get the base letters.
+		for _, r := range shaping.Decompose(runes[i]) {
+			if glyph, src := b.lookup(r); glyph != nil {
+				b.add(glyph, src, agg2d.textKerning)
+			}
+		}
+		// Characters without any glyph still take a caret position.
+		b.mark()
+	}
+}
+
+// layoutPositioned places glyphs at the advances and offsets chosen by the
+// shaper.
+func (agg2d *Agg2D) layoutPositioned(b *glyphRunBuilder, shaped []shaping.ShapedGlyph) {
+	for _, g := range shaped {
+		b.cluster = g.Cluster
+		b.mark()
+		// Glyph indices refer to the primary font the shaper was given.
+		var glyph *font.GlyphCache
+		src := b.fcm
+		if g.GlyphIndex != 0 {
+			glyph = b.fcm.GlyphByIndex(g.GlyphIndex)
+		}
+		if glyph == nil {
+			glyph, src = b.lookup(g.Rune)
+		}
+		// Shaper offsets point up; flipped text has its Y axis pointing down.
+		dy := g.YOffset
+		if agg2d.flipText {
+			dy = -dy
+		}
+		if glyph != nil {
+			b.place(glyph, src, b.x+g.XOffset, b.y+dy)
+		}
+		b.x += g.XAdvance
+		b.y += g.YAdvance
+	}
+}
+
+// glyphRunBuilder accumulates positioned glyphs into a run.
+type glyphRunBuilder struct {
+	run       *glyphRun
+	fcm       *font.FontCacheManager   // primary font
+	fallbacks []*font.FontCacheManager // fallback chain, in lookup order
+	x, y      float64
+	prevIndex uint
+	prevFont  *font.FontCacheManager
+	cluster   int // source cluster of the glyphs being added
+}
+
+// lookup finds the glyph for r in the primary font or, failing that, in the
+// first fallback font that has it.
+func (b *glyphRunBuilder) lookup(r rune) (*font.GlyphCache, *font.FontCacheManager) {
+	if glyph := b.fcm.Glyph(uint(r)); glyph != nil {
+		return glyph, b.fcm
+	}
+	for _, fcm := range b.fallbacks {
+		if glyph := fcm.Glyph(uint(r)); glyph != nil {
+			return glyph, fcm
+		}
+	}
+	return nil, nil
+}
+
+// add appends glyph from src at the pen position, kerned against the previous
+// glyph when both come from the same font, and advances the pen.
+func (b *glyphRunBuilder) add(glyph *font.GlyphCache, src *font.FontCacheManager, kerning bool) {
+	if kerning && b.prevFont == src {
+		// Kerning in FreeType is defined between glyph indices.
+		src.AddKerning(&b.x, &b.y, b.prevIndex, glyph.GlyphIndex)
+	}
+	b.mark()
+	b.place(glyph, src, b.x, b.y)
+	b.x += glyph.AdvanceX
+	b.y += glyph.AdvanceY
+	b.prevIndex = glyph.GlyphIndex
+	b.prevFont = src
+}
+
+// mark starts the current cluster at the pen position unless it is already
+// the last one started.
+func (b *glyphRunBuilder) mark() {
+	if n := len(b.run.clusters); n > 0 && b.run.clusters[n-1].index == b.cluster {
+		return
+	}
+	b.run.clusters = append(b.run.clusters, glyphRunCluster{index: b.cluster, x: b.x})
+}
+
+// place appends glyph from src at x, y without moving the pen.
+func (b *glyphRunBuilder) place(glyph *font.GlyphCache, src *font.FontCacheManager, x, y float64) {
+	g := glyphRunGlyph{glyph: glyph, src: src, x: x, y: y}
+	if glyph.DataType == font.GlyphDataOutline {
+		// Looking the glyph up re-prepared src's engine for it, so the
+		// adaptor yields its outline at the origin.
+		src.InitEmbeddedAdaptors(glyph, 0, 0)
+		g.outline = path.NewPathStorageStl()
+		g.outline.ConcatPath(src.PathAdaptor(), 0)
+	}
+	b.run.glyphs = append(b.run.glyphs, g)
+}
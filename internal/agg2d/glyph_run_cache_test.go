@@ -0,0 +1,92 @@
+package agg2d
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+func newGlyphRunTestAgg2D(t *testing.T) (*Agg2D, []byte) {
+	t.Helper()
+	engine := newMockTextFontEngine()
+	square := func(ps *path.PathStorageStl) {
+		ps.MoveTo(0, 0)
+		ps.LineTo(2, 0)
+		ps.LineTo(2, 2)
+		ps.LineTo(0, 2)
+		ps.ClosePolygon(basics.PathFlagsNone)
+	}
+	for i, r := range "AB" {
+		engine.glyphs[uint(r)] = mockOutlineGlyph{
+			glyphIndex: uint(100 + i),
+			advanceX:   4,
+			bounds:     basics.Rect[int]{X1: 0, Y1: 0, X2: 2, Y2: 2},
+			buildPath:  square,
+		}
+	}
+
+	agg2d := NewAgg2D()
+	buf := make([]byte, 32*16*4)
+	agg2d.Attach(buf, 32, 16, 32*4)
+	agg2d.fontCacheType = VectorFontCache
+	agg2d.fontCacheManager = font.NewFontCacheManager(engine, 32)
+	agg2d.FillColor(Color{255, 0, 0, 255})
+	agg2d.NoLine()
+	return agg2d, buf
+}
+
+func TestGlyphRunCacheHitsOnRepeatedText(t *testing.T) {
+	agg2d, _ := newGlyphRunTestAgg2D(t)
+
+	if w := agg2d.TextWidth("AB"); w != 8 {
+		t.Fatalf("TextWidth(AB)=%v, want 8", w)
+	}
+	agg2d.TextWidth("AB")
+	agg2d.Text(2, 2, "AB", false, 0, 0)
+
+	stats := agg2d.GlyphRunCacheStats()
+	if stats.Misses != 1 || stats.Hits != 2 || stats.Entries != 1 {
+		t.Fatalf("stats=%+v, want 1 miss, 2 hits, 1 entry", stats)
+	}
+}
+
+func TestGlyphRunCacheRendersEveryGlyphOfCachedRun(t *testing.T) {
+	agg2d, buf := newGlyphRunTestAgg2D(t)
+
+	for i := 0; i < 2; i++ {
+		for j := range buf {
+			buf[j] = 0
+		}
+		agg2d.Text(2, 2, "AB", false, 0, 0)
+		for _, x := range []int{3, 7} {
+			if _, _, _, a := pixelAt(buf, 32, x, 3); a == 0 {
+				t.Fatalf("pass %d: expected glyph coverage at (%d,3)", i, x)
+			}
+		}
+	}
+}
+
+func TestGlyphRunCacheEvictionAndDisable(t *testing.T) {
+	agg2d, _ := newGlyphRunTestAgg2D(t)
+	agg2d.SetGlyphRunCacheSize(1)
+
+	agg2d.TextWidth("A")
+	agg2d.TextWidth("B")
+	agg2d.TextWidth("A")
+
+	stats := agg2d.GlyphRunCacheStats()
+	if stats.Misses != 3 || stats.Evictions != 2 || stats.Entries != 1 {
+		t.Fatalf("stats=%+v, want 3 misses, 2 evictions, 1 entry", stats)
+	}
+
+	agg2d.SetGlyphRunCacheSize(0)
+	agg2d.ClearGlyphRunCache()
+	agg2d.TextWidth("A")
+	agg2d.TextWidth("A")
+	stats = agg2d.GlyphRunCacheStats()
+	if stats.Misses != 2 || stats.Hits != 0 || stats.Entries != 0 {
+		t.Fatalf("disabled cache stats=%+v, want 2 misses and no entries", stats)
+	}
+}
@@ -63,6 +63,8 @@ func (agg2d *Agg2D) setupWorldRadialGradient(matrix *transform.TransAffine, x, y
 func (agg2d *Agg2D) FillLinearGradient(x1, y1, x2, y2 float64, c1, c2 Color, profile float64) {
 	buildProfileGradient(&agg2d.fillGradient, c1, c2, 128-int(profile*127.0), 128+int(profile*127.0))
 	agg2d.fillGradientLUTDirty = true
+	agg2d.fillGradientPaint = nil
+	agg2d.fillStyle = defaultPaintStyle
 
 	// Calculate gradient angle and setup transformation matrix
 	angle := math.Atan2(y2-y1, x2-x1)
@@ -89,6 +91,8 @@ func (agg2d *Agg2D) FillLinearGradient(x1, y1, x2, y2 float64, c1, c2 Color, pro
 func (agg2d *Agg2D) LineLinearGradient(x1, y1, x2, y2 float64, c1, c2 Color, profile float64) {
 	buildProfileGradient(&agg2d.lineGradient, c1, c2, 128-int(profile*128.0), 128+int(profile*128.0))
 	agg2d.lineGradientLUTDirty = true
+	agg2d.lineGradientPaint = nil
+	agg2d.lineStyle = defaultPaintStyle
 
 	// Calculate gradient angle and setup transformation matrix
 	angle := math.Atan2(y2-y1, x2-x1)
@@ -121,6 +125,8 @@ func (agg2d *Agg2D) LineLinearGradient(x1, y1, x2, y2 float64, c1, c2 Color, pro
 func (agg2d *Agg2D) FillRadialGradient(x, y, r float64, c1, c2 Color, profile float64) {
 	buildProfileGradient(&agg2d.fillGradient, c1, c2, 128-int(profile*127.0), 128+int(profile*127.0))
 	agg2d.fillGradientLUTDirty = true
+	agg2d.fillGradientPaint = nil
+	agg2d.fillStyle = defaultPaintStyle
 	agg2d.fillGradientD1, agg2d.fillGradientD2 = agg2d.setupWorldRadialGradient(agg2d.fillGradientMatrix, x, y, r)
 	agg2d.fillGradientFlag = Radial
 	agg2d.fillColor = NewColor(0, 0, 0, 255)
@@ -132,6 +138,8 @@ func (agg2d *Agg2D) FillRadialGradient(x, y, r float64, c1, c2 Color, profile fl
 func (agg2d *Agg2D) LineRadialGradient(x, y, r float64, c1, c2 Color, profile float64) {
 	buildProfileGradient(&agg2d.lineGradient, c1, c2, 128-int(profile*128.0), 128+int(profile*128.0))
 	agg2d.lineGradientLUTDirty = true
+	agg2d.lineGradientPaint = nil
+	agg2d.lineStyle = defaultPaintStyle
 	agg2d.lineGradientD1, agg2d.lineGradientD2 = agg2d.setupWorldRadialGradient(agg2d.lineGradientMatrix, x, y, r)
 	agg2d.lineGradientFlag = Radial
 	agg2d.lineColor = NewColor(0, 0, 0, 255)
@@ -144,6 +152,8 @@ func (agg2d *Agg2D) LineRadialGradient(x, y, r float64, c1, c2 Color, profile fl
 func (agg2d *Agg2D) FillRadialGradientMultiStop(x, y, r float64, c1, c2, c3 Color) {
 	buildThreeColorGradient(&agg2d.fillGradient, c1, c2, c3)
 	agg2d.fillGradientLUTDirty = true
+	agg2d.fillGradientPaint = nil
+	agg2d.fillStyle = defaultPaintStyle
 	agg2d.fillGradientD1, agg2d.fillGradientD2 = agg2d.setupWorldRadialGradient(agg2d.fillGradientMatrix, x, y, r)
 	agg2d.fillGradientFlag = Radial
 	agg2d.fillColor = NewColor(0, 0, 0, 255)
@@ -154,6 +164,8 @@ func (agg2d *Agg2D) FillRadialGradientMultiStop(x, y, r float64, c1, c2, c3 Colo
 func (agg2d *Agg2D) LineRadialGradientMultiStop(x, y, r float64, c1, c2, c3 Color) {
 	buildThreeColorGradient(&agg2d.lineGradient, c1, c2, c3)
 	agg2d.lineGradientLUTDirty = true
+	agg2d.lineGradientPaint = nil
+	agg2d.lineStyle = defaultPaintStyle
 	agg2d.lineGradientD1, agg2d.lineGradientD2 = agg2d.setupWorldRadialGradient(agg2d.lineGradientMatrix, x, y, r)
 	agg2d.lineGradientFlag = Radial
 	agg2d.lineColor = NewColor(0, 0, 0, 255)
@@ -0,0 +1,154 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// SetGradientDithering turns ordered dithering on or off for every gradient
+// fill and stroke, including the ones set up by FillLinearGradient and
+// friends. Paints can also be dithered one by one with
+// GradientPaint.SetDithering.
+func (agg2d *Agg2D) SetGradientDithering(on bool) {
+	agg2d.gradientDither = on
+}
+
+// GradientDithering reports whether all gradients are dithered.
+func (agg2d *Agg2D) GradientDithering() bool {
+	return agg2d.gradientDither
+}
+
+// SetDithering turns ordered dithering on or off for this gradient. A
+// dithered gradient blends neighbouring table colors at full precision and
+// spreads the rounding error with a Bayer matrix, which hides the bands that
+// 8-bit steps leave in large, smooth gradients.
+func (g *GradientPaint) SetDithering(on bool) {
+	g.dither = on
+}
+
+// Dithering reports whether the gradient is dithered.
+func (g *GradientPaint) Dithering() bool {
+	return g.dither
+}
+
+// fineColor is a color with 8.8 fixed-point channels.
+type fineColor [4]uint16
+
+// newFineColor returns a + (b-a)*f in 8.8 fixed point.
+func newFineColor(a, b Color, f float64) fineColor {
+	var c fineColor
+	for i := range c {
+		c[i] = uint16(math.Round((float64(a[i]) + f*(float64(b[i])-float64(a[i]))) * 256))
+	}
+	return c
+}
+
+// fineTable returns the 8.8 fixed-point color table of the fill or line
+// gradient: the installed paint's, or the context LUT widened.
+func (agg2d *Agg2D) fineTable(fill bool) []fineColor {
+	paint, lut := agg2d.lineGradientPaint, agg2d.lineColorFunction()
+	if fill {
+		paint, lut = agg2d.fillGradientPaint, agg2d.fillColorFunction()
+	}
+	if paint != nil {
+		return paint.fine
+	}
+	table := make([]fineColor, lut.Size())
+	for i := range table {
+		c := lut.ColorAt(i)
+		table[i] = fineColor{uint16(c.R) << 8, uint16(c.G) << 8, uint16(c.B) << 8, uint16(c.A) << 8}
+	}
+	return table
+}
+
+// ditheredGradientSpans returns a dithered span generator for the fill or
+// line gradient of the given shape, or nil when that gradient is not
+// dithered.
+func (agg2d *Agg2D) ditheredGradientSpans(shape span.GradientFunction, fill bool) renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]] {
+	paint, mtx, d1, d2 := agg2d.lineGradientPaint, agg2d.lineGradientMatrix, agg2d.lineGradientD1, agg2d.lineGradientD2
+	if fill {
+		paint, mtx, d1, d2 = agg2d.fillGradientPaint, agg2d.fillGradientMatrix, agg2d.fillGradientD1, agg2d.fillGradientD2
+	}
+	if !agg2d.gradientDither && (paint == nil || !paint.dither) {
+		return nil
+	}
+
+	interpolator := span.NewSpanInterpolatorLinearDefault(mtx)
+	gen := &ditheredGradient{
+		interpolator:   interpolator,
+		shape:          shape,
+		table:          agg2d.fineTable(fill),
+		d1:             basics.IRound(d1 * span.GradientSubpixelScale),
+		d2:             basics.IRound(d2 * span.GradientSubpixelScale),
+		downscaleShift: max(interpolator.SubpixelShift()-span.GradientSubpixelShift, 0),
+	}
+	if fill {
+		return agg2d.maskFillSpans(gen, false)
+	}
+	return gen
+}
+
+// bayer8 is the 8x8 ordered dither matrix, values 0..63.
+var bayer8 = [8][8]uint8{
+	{0, 32, 8, 40, 2, 34, 10, 42},
+	{48, 16, 56, 24, 50, 18, 58, 26},
+	{12, 44, 4, 36, 14, 46, 6, 38},
+	{60, 28, 52, 20, 62, 30, 54, 22},
+	{3, 35, 11, 43, 1, 33, 9, 41},
+	{51, 19, 59, 27, 49, 17, 57, 25},
+	{15, 47, 7, 39, 13, 45, 5, 37},
+	{63, 31, 55, 23, 61, 29, 53, 21},
+}
+
+// ditheredGradient is span_gradient with a fractional color index: it
+// interpolates between the two nearest table entries in 8.8 fixed point and
+// adds a Bayer threshold before rounding to 8 bits.
+type ditheredGradient struct {
+	interpolator   *span.SpanInterpolatorLinear[*transform.TransAffine]
+	shape          span.GradientFunction
+	table          []fineColor
+	d1, d2         int // subpixel distances
+	downscaleShift int
+}
+
+func (g *ditheredGradient) Prepare() {}
+
+func (g *ditheredGradient) Generate(colors []color.RGBA8[color.Linear], x, y, length int) {
+	dd := max(g.d2-g.d1, 1)
+	size := len(g.table)
+	last := (size - 1) << 8
+	row := &bayer8[y&7]
+	g.interpolator.Begin(float64(x)+0.5, float64(y)+0.5, length)
+	for i := 0; i < length; i++ {
+		ix, iy := g.interpolator.Coordinates()
+		d := g.shape.Calculate(ix>>g.downscaleShift, iy>>g.downscaleShift, g.d2)
+		// Table entry k covers [k, k+1) of size steps; its color sits at the
+		// middle of that range.
+		pos := min(max((d-g.d1)*size*256/dd-128, 0), last)
+		k, frac := pos>>8, pos&255
+		a, b := &g.table[k], &g.table[k]
+		if frac != 0 {
+			b = &g.table[k+1]
+		}
+		t := int(row[(x+i)&7])*4 + 2
+		colors[i] = color.RGBA8[color.Linear]{
+			R: ditherChannel(a[0], b[0], frac, t),
+			G: ditherChannel(a[1], b[1], frac, t),
+			B: ditherChannel(a[2], b[2], frac, t),
+			A: ditherChannel(a[3], b[3], frac, t),
+		}
+		g.interpolator.Next()
+	}
+}
+
+// ditherChannel interpolates the 8.8 values a to b by frac/256 and rounds
+// to 8 bits with threshold t (0..255).
+func ditherChannel(a, b uint16, frac, t int) basics.Int8u {
+	v := int(a) + (int(b)-int(a))*frac>>8 + t
+	return basics.Int8u(min(v>>8, 255))
+}
@@ -0,0 +1,83 @@
+package agg2d
+
+import (
+	"math"
+	"testing"
+)
+
+// blockMean returns the mean red value of the 8x8 block at (x, 0).
+func blockMean(buf []byte, width, x int) float64 {
+	sum := 0
+	for y := 0; y < 8; y++ {
+		for i := x; i < x+8; i++ {
+			sum += int(buf[(y*width+i)*4])
+		}
+	}
+	return float64(sum) / 64
+}
+
+func TestGradientPaintDithering(t *testing.T) {
+	const width = 256
+	render := func(dither bool) []byte {
+		buf := make([]byte, width*8*4)
+		ctx := NewAgg2D()
+		ctx.Attach(buf, width, 8, width*4)
+		ctx.NoLine()
+		g := NewLinearGradientPaint(0, 0, width, 0, []GradientStop{{0, Color{0, 0, 0, 255}}, {1, Color{4, 4, 4, 255}}})
+		g.SetDithering(dither)
+		if g.Dithering() != dither {
+			t.Fatalf("Dithering() = %v, want %v", g.Dithering(), dither)
+		}
+		ctx.SetFillGradient(g)
+		ctx.Rectangle(0, 0, width, 8)
+		return buf
+	}
+
+	// Over x in [96, 104) the exact value is 4 * 100/256.
+	want := 4 * 100.0 / width
+	if got := blockMean(render(true), width, 96); math.Abs(got-want) > 0.15 {
+		t.Errorf("dithered block mean = %.3f, want %.3f", got, want)
+	}
+	if got := blockMean(render(false), width, 96); math.Abs(got-want) < 0.3 {
+		t.Errorf("undithered block mean = %.3f, expected a visible band step from %.3f", got, want)
+	}
+}
+
+func TestContextGradientDithering(t *testing.T) {
+	const width = 1024
+	render := func(dither bool) []byte {
+		buf := make([]byte, width*8*4)
+		ctx := NewAgg2D()
+		ctx.Attach(buf, width, 8, width*4)
+		ctx.NoLine()
+		ctx.SetGradientDithering(dither)
+		if ctx.GradientDithering() != dither {
+			t.Fatalf("GradientDithering() = %v, want %v", ctx.GradientDithering(), dither)
+		}
+		ctx.FillLinearGradient(0, 0, width, 0, Color{0, 0, 0, 255}, Color{255, 255, 255, 255}, 1.0)
+		ctx.Rectangle(0, 0, width, 8)
+		return buf
+	}
+
+	// Pixels 128..131 fall in one table entry: a single level undithered,
+	// interleaved levels dithered.
+	levels := func(buf []byte) int {
+		seen := map[byte]bool{}
+		for y := 0; y < 8; y++ {
+			for x := 128; x < 132; x++ {
+				seen[buf[(y*width+x)*4]] = true
+			}
+		}
+		return len(seen)
+	}
+	plain, dithered := render(false), render(true)
+	if n := levels(plain); n != 1 {
+		t.Errorf("undithered step has %d levels, want 1", n)
+	}
+	if n := levels(dithered); n < 2 {
+		t.Errorf("dithered step has %d levels, want at least 2", n)
+	}
+	if p, d := blockMean(plain, width, 128), blockMean(dithered, width, 128); math.Abs(p-d) > 0.6 {
+		t.Errorf("dithered block mean = %.3f, undithered %.3f; dithering should keep the average", d, p)
+	}
+}
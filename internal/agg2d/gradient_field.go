@@ -0,0 +1,92 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// fieldGradientD2 is the distance range of contour and field gradients: one
+// step per gray level, so level 0 selects the first table entry and 255 the
+// last.
+const fieldGradientD2 = 256
+
+// grayField is a gradient function reading the gray level under a point from
+// an 8-bit image. Points outside the image take the level of the nearest edge
+// pixel.
+type grayField struct {
+	pix           []uint8
+	width, height int
+	stride, step  int
+}
+
+// Calculate implements span.GradientFunction.
+func (f *grayField) Calculate(x, y, _ int) int {
+	if f.width <= 0 || f.height <= 0 {
+		return 0
+	}
+	px := min(max(x>>span.GradientSubpixelShift, 0), f.width-1)
+	py := min(max(y>>span.GradientSubpixelShift, 0), f.height-1)
+	return int(f.pix[py*f.stride+px*f.step]) << span.GradientSubpixelShift
+}
+
+// NewContourGradientPaint returns a gradient shaded by the distance to the
+// outline of src, as AGG's gradient_contour: offset 0 lies on the outline and
+// offset 1 at the point farthest from it, inside or outside, within the
+// path's bounding box grown by a 10 pixel frame. The distance map is computed
+// once, here; the gradient stays where src is in user coordinates.
+func NewContourGradientPaint(src *path.PathStorageStl, stops []GradientStop) *GradientPaint {
+	gc := span.NewGradientContourWithDistances(0, fieldGradientD2)
+	gc.ContourCreateFrom(path.NewPathStorageStlVertexSourceAdapter(src))
+	g := &GradientPaint{kind: Contour, field: gc}
+	g.x1, g.y1 = gc.ContourOrigin()
+	g.buildLUT(stops)
+	return g
+}
+
+// NewFieldGradientPaint returns a gradient whose offsets are read from an
+// 8-bit image: the level of pixel (px, py) at pix[py*stride+px*step] selects
+// the color at offset level/255. The image's top-left corner is placed at
+// (x, y) in user coordinates. A distance field from GenerateSDF or a blurred
+// mask turns it into a glow or inner shadow. pix is used without copying.
+func NewFieldGradientPaint(pix []uint8, width, height, stride, step int, x, y float64, stops []GradientStop) *GradientPaint {
+	field := &grayField{pix: pix, width: width, height: height, stride: stride, step: step}
+	g := &GradientPaint{kind: Field, field: field, x1: x, y1: y}
+	g.buildLUT(stops)
+	return g
+}
+
+// renderFieldGradientFill renders a contour or field gradient, which only
+// come from an installed GradientPaint.
+func (agg2d *Agg2D) renderFieldGradientFill(useFillGradient bool) {
+	renderer := agg2d.currentRenderer()
+	if renderer == nil || agg2d.spanAllocator == nil {
+		return
+	}
+
+	paint, mtx, d1, d2 := agg2d.lineGradientPaint, agg2d.lineGradientMatrix, agg2d.lineGradientD1, agg2d.lineGradientD2
+	if useFillGradient {
+		paint, mtx, d1, d2 = agg2d.fillGradientPaint, agg2d.fillGradientMatrix, agg2d.fillGradientD1, agg2d.fillGradientD2
+	}
+	if paint == nil || paint.field == nil {
+		return
+	}
+
+	var spanGenerator renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]] = span.NewSpanGradient[
+		color.RGBA8[color.Linear],
+		*span.SpanInterpolatorLinear[*transform.TransAffine],
+		span.GradientFunction,
+		*span.GradientPrebuiltColorRGBA8[color.Linear],
+	](span.NewSpanInterpolatorLinearDefault(mtx), paint.field, paint.colors, d1, d2)
+	if useFillGradient {
+		spanGenerator = agg2d.maskFillSpans(spanGenerator, false)
+	}
+	if dithered := agg2d.ditheredGradientSpans(paint.field, useFillGradient); dithered != nil {
+		spanGenerator = dithered
+	}
+
+	spanGenerator = agg2d.paintSpans(spanGenerator, useFillGradient, false)
+	renscan.RenderScanlinesAA(agg2d.rasterizer, agg2d.scanline, renderer, agg2d.spanAllocator, spanGenerator)
+}
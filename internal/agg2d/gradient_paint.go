@@ -0,0 +1,227 @@
+package agg2d
+
+import (
+	"math"
+	"sort"
+
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// GradientStop is a color at an offset (0..1) along a gradient.
+type GradientStop struct {
+	Offset float64
+	Color  Color
+}
+
+// GradientPaint is a gradient whose color table is built once, at
+// construction, instead of on every fill. A paint holds no per-context state,
+// so one paint may be installed on any number of Agg2D instances, including
+// ones rendering concurrently.
+type GradientPaint struct {
+	kind           Gradient
+	x1, y1, x2, y2 float64 // linear: start and end point
+	cx, cy, r      float64 // radial: center and radius
+	startAngle     float64 // conic: angle of offset 0, center cx, cy
+
+	// field is the distance source of contour and field gradients, placed
+	// with its top-left corner at x1, y1.
+	field span.GradientFunction
+
+	// mtx maps gradient space to user space, like SVG gradientTransform;
+	// nil means identity.
+	mtx *transform.TransAffine
+
+	lut    []color.RGBA8[color.Linear]
+	colors *span.GradientPrebuiltColorRGBA8[color.Linear]
+	// fine holds the table colors in 8.8 fixed point for dithering.
+	fine   []fineColor
+	dither bool
+}
+
+// NewLinearGradientPaint returns a linear gradient from (x1, y1) to (x2, y2)
+// in user coordinates.
+func NewLinearGradientPaint(x1, y1, x2, y2 float64, stops []GradientStop) *GradientPaint {
+	g := &GradientPaint{kind: Linear, x1: x1, y1: y1, x2: x2, y2: y2}
+	g.buildLUT(stops)
+	return g
+}
+
+// NewRadialGradientPaint returns a radial gradient centered at (cx, cy) with
+// radius r in user coordinates.
+func NewRadialGradientPaint(cx, cy, r float64, stops []GradientStop) *GradientPaint {
+	g := &GradientPaint{kind: Radial, cx: cx, cy: cy, r: r}
+	g.buildLUT(stops)
+	return g
+}
+
+// NewConicGradientPaint returns a conic (sweep) gradient around (cx, cy).
+// Offsets run clockwise, on a y-down surface, through one full turn starting
+// at startAngle radians from 12 o'clock, as in CSS conic-gradient.
+func NewConicGradientPaint(cx, cy, startAngle float64, stops []GradientStop) *GradientPaint {
+	g := &GradientPaint{kind: Conic, cx: cx, cy: cy, startAngle: startAngle}
+	g.buildLUT(stops)
+	return g
+}
+
+// Kind returns Linear, Radial, Conic, Contour or Field.
+func (g *GradientPaint) Kind() Gradient {
+	return g.kind
+}
+
+// ColorAt returns the table color at offset t (0..1).
+func (g *GradientPaint) ColorAt(t float64) Color {
+	i := int(math.Round(math.Max(0, math.Min(1, t)) * 255))
+	c := g.lut[i]
+	return Color{c.R, c.G, c.B, c.A}
+}
+
+// SetTransform sets the gradient transform, applied to the gradient geometry
+// before the context transform, so the gradient can be rotated, scaled or
+// moved independently of the shapes it fills. A nil m resets it to identity.
+// The transform is read when the paint is installed; contexts already using
+// the paint keep the previous placement until it is installed again.
+func (g *GradientPaint) SetTransform(m *Transformations) {
+	if m == nil {
+		g.mtx = nil
+		return
+	}
+	g.mtx = transform.NewTransAffineFromArray(m.AffineMatrix)
+}
+
+// Transform returns the gradient transform.
+func (g *GradientPaint) Transform() *Transformations {
+	if g.mtx == nil {
+		return NewTransformations()
+	}
+	return &Transformations{AffineMatrix: [6]float64{g.mtx.SX, g.mtx.SHY, g.mtx.SHX, g.mtx.SY, g.mtx.TX, g.mtx.TY}}
+}
+
+// buildLUT fills the 256-entry color table from stops. Stops are sorted by
+// offset; the first and last colors extend to the ends of the table.
+func (g *GradientPaint) buildLUT(stops []GradientStop) {
+	sorted := append([]GradientStop(nil), stops...)
+	sort.SliceStable(sorted, func(a, b int) bool { return sorted[a].Offset < sorted[b].Offset })
+
+	g.lut = make([]color.RGBA8[color.Linear], 256)
+	g.colors = span.NewGradientPrebuiltColorRGBA8(g.lut)
+	g.fine = make([]fineColor, 256)
+	if len(sorted) == 0 {
+		return
+	}
+
+	k := 0
+	for i := range g.lut {
+		t := float64(i) / 255.0
+		for k < len(sorted)-1 && sorted[k+1].Offset <= t {
+			k++
+		}
+		var c Color
+		switch {
+		case t <= sorted[0].Offset:
+			c = sorted[0].Color
+			g.fine[i] = newFineColor(c, c, 0)
+		case k == len(sorted)-1:
+			c = sorted[k].Color
+			g.fine[i] = newFineColor(c, c, 0)
+		default:
+			a, b := sorted[k], sorted[k+1]
+			f := (t - a.Offset) / (b.Offset - a.Offset)
+			c = a.Color.Gradient(b.Color, f)
+			g.fine[i] = newFineColor(a.Color, b.Color, f)
+		}
+		g.lut[i] = color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: c[3]}
+	}
+}
+
+// conicGradientD2 is the distance range a full turn of a conic gradient is
+// spread over; any value comfortably above the 256 table entries will do.
+const conicGradientD2 = 1024
+
+// setup writes the device-to-gradient matrix for the transform mtx into dst
+// and returns the gradient distances.
+func (g *GradientPaint) setup(dst, mtx *transform.TransAffine) (d1, d2 float64) {
+	dst.Reset()
+	switch g.kind {
+	case Radial:
+		dst.Translate(g.cx, g.cy)
+		d2 = g.r
+	case Conic:
+		// The sweep starts on the +x axis; turn it to point up.
+		dst.Rotate(g.startAngle - math.Pi/2)
+		dst.Translate(g.cx, g.cy)
+		d2 = conicGradientD2
+	case Contour, Field:
+		dst.Translate(g.x1, g.y1)
+		d2 = fieldGradientD2
+	default:
+		dst.Rotate(math.Atan2(g.y2-g.y1, g.x2-g.x1))
+		dst.Translate(g.x1, g.y1)
+		d2 = math.Hypot(g.x2-g.x1, g.y2-g.y1)
+	}
+	if g.mtx != nil {
+		dst.Multiply(g.mtx)
+	}
+	dst.Multiply(mtx)
+	dst.Invert()
+	return 0, d2
+}
+
+// SetFillGradient installs g as the fill paint. The gradient geometry is
+// mapped through the current transform, as with FillLinearGradient; its color
+// table is used as is, without rebuilding. The paint's own transform, if
+// any, is applied first. A nil g selects a solid fill.
+func (agg2d *Agg2D) SetFillGradient(g *GradientPaint) {
+	agg2d.fillGradientPaint = g
+	agg2d.fillStyle = defaultPaintStyle
+	if g == nil {
+		agg2d.fillGradientFlag = Solid
+		return
+	}
+	agg2d.fillGradientD1, agg2d.fillGradientD2 = g.setup(agg2d.fillGradientMatrix, agg2d.transform)
+	agg2d.fillGradientFlag = g.kind
+	agg2d.fillColor = NewColor(0, 0, 0, 255)
+}
+
+// SetLineGradient installs g as the line paint. See SetFillGradient.
+func (agg2d *Agg2D) SetLineGradient(g *GradientPaint) {
+	agg2d.lineGradientPaint = g
+	agg2d.lineStyle = defaultPaintStyle
+	if g == nil {
+		agg2d.lineGradientFlag = Solid
+		return
+	}
+	agg2d.lineGradientD1, agg2d.lineGradientD2 = g.setup(agg2d.lineGradientMatrix, agg2d.transform)
+	agg2d.lineGradientFlag = g.kind
+	agg2d.lineColor = NewColor(0, 0, 0, 255)
+}
+
+// FillGradientPaint returns the installed fill paint, or nil when the fill
+// gradient was set up by FillLinearGradient and friends.
+func (agg2d *Agg2D) FillGradientPaint() *GradientPaint {
+	return agg2d.fillGradientPaint
+}
+
+// LineGradientPaint returns the installed line paint. See FillGradientPaint.
+func (agg2d *Agg2D) LineGradientPaint() *GradientPaint {
+	return agg2d.lineGradientPaint
+}
+
+// fillColorFunction returns the color table for gradient fills: the installed
+// paint's, or the context's own LUT.
+func (agg2d *Agg2D) fillColorFunction() *span.GradientPrebuiltColorRGBA8[color.Linear] {
+	if agg2d.fillGradientPaint != nil {
+		return agg2d.fillGradientPaint.colors
+	}
+	agg2d.refreshFillGradientLUTIfDirty()
+	return agg2d.fillGradientColors
+}
+
+func (agg2d *Agg2D) lineColorFunction() *span.GradientPrebuiltColorRGBA8[color.Linear] {
+	if agg2d.lineGradientPaint != nil {
+		return agg2d.lineGradientPaint.colors
+	}
+	agg2d.refreshLineGradientLUTIfDirty()
+	return agg2d.lineGradientColors
+}
@@ -0,0 +1,250 @@
+package agg2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+func TestGradientPaintStops(t *testing.T) {
+	red, green, blue := Color{255, 0, 0, 255}, Color{0, 255, 0, 255}, Color{0, 0, 255, 255}
+	// Unsorted stops; the ends extend the first and last color.
+	g := NewLinearGradientPaint(0, 0, 100, 0, []GradientStop{
+		{Offset: 0.8, Color: blue},
+		{Offset: 0.2, Color: red},
+		{Offset: 0.5, Color: green},
+	})
+
+	if c := g.ColorAt(0); c != red {
+		t.Errorf("ColorAt(0) = %v, want %v", c, red)
+	}
+	if c := g.ColorAt(1); c != blue {
+		t.Errorf("ColorAt(1) = %v, want %v", c, blue)
+	}
+	if c := g.ColorAt(0.5); c[1] < 250 {
+		t.Errorf("ColorAt(0.5) = %v, want ~%v", c, green)
+	}
+	if c := g.ColorAt(0.35); c[0] < 120 || c[0] > 135 || c[1] < 120 || c[1] > 135 {
+		t.Errorf("ColorAt(0.35) = %v, want red/green midpoint", c)
+	}
+}
+
+// newGradientTarget returns a context attached to a white 40x20 buffer.
+// Attach resets the fill, so gradients are set up afterwards.
+func newGradientTarget() (*Agg2D, []byte) {
+	buf := make([]byte, 40*20*4)
+	ctx := NewAgg2D()
+	ctx.Attach(buf, 40, 20, 40*4)
+	ctx.ClearAll(White)
+	ctx.NoLine()
+	return ctx, buf
+}
+
+func TestGradientPaintMatchesLegacyGradient(t *testing.T) {
+	red, blue := Color{255, 0, 0, 255}, Color{0, 0, 255, 255}
+
+	legacy, want := newGradientTarget()
+	legacy.FillLinearGradient(0, 0, 40, 0, red, blue, 1.0)
+	legacy.Rectangle(0, 0, 40, 20)
+	if p := want[(10*40+1)*4:][:3]; p[0] < 200 || p[2] > 60 {
+		t.Fatalf("reference left edge = %v, want red", p)
+	}
+
+	paint := NewLinearGradientPaint(0, 0, 40, 0, []GradientStop{{0, red}, {1, blue}})
+	// The same paint installed on two contexts renders identically in both.
+	for i := 0; i < 2; i++ {
+		ctx, got := newGradientTarget()
+		ctx.SetFillGradient(paint)
+		if ctx.FillGradientFlag() != Linear {
+			t.Fatalf("fill gradient flag = %d, want Linear", ctx.FillGradientFlag())
+		}
+		ctx.Rectangle(0, 0, 40, 20)
+		for j := range got {
+			if d := int(got[j]) - int(want[j]); d < -3 || d > 3 {
+				p := j / 4
+				t.Fatalf("context %d: pixel (%d,%d) channel %d = %d, want %d", i, p%40, p/40, j%4, got[j], want[j])
+			}
+		}
+	}
+}
+
+func TestLegacyGradientReplacesPaint(t *testing.T) {
+	green, black := Color{0, 255, 0, 255}, Color{0, 0, 0, 255}
+	ctx, buf := newGradientTarget()
+	ctx.SetFillGradient(NewRadialGradientPaint(20, 10, 20, []GradientStop{{0, green}, {1, green}}))
+	ctx.FillLinearGradient(0, 0, 40, 0, black, black, 1.0)
+	if ctx.FillGradientPaint() != nil {
+		t.Fatal("legacy gradient setter should drop the installed paint")
+	}
+
+	ctx.Rectangle(0, 0, 40, 20)
+	if p := buf[(10*40+20)*4:][:3]; p[0] != 0 || p[1] != 0 || p[2] != 0 {
+		t.Errorf("center pixel = %v, want black from the legacy gradient", p)
+	}
+}
+
+func TestGradientPaintTransform(t *testing.T) {
+	black, white := Color{0, 0, 0, 255}, Color{255, 255, 255, 255}
+	// Horizontal black-to-white over 0..20, turned vertical by rotating the
+	// gradient a quarter turn: x becomes y.
+	g := NewLinearGradientPaint(0, 0, 20, 0, []GradientStop{{0, black}, {1, white}})
+	rot := transform.NewTransAffineRotation(math.Pi / 2)
+	g.SetTransform(&Transformations{AffineMatrix: [6]float64{rot.SX, rot.SHY, rot.SHX, rot.SY, rot.TX, rot.TY}})
+
+	ctx, buf := newGradientTarget()
+	ctx.SetFillGradient(g)
+	ctx.Rectangle(0, 0, 40, 20)
+
+	at := func(x, y int) uint8 { return buf[(y*40+x)*4] }
+	if at(5, 1) != at(35, 1) {
+		t.Errorf("row 1 varies along x: %d vs %d", at(5, 1), at(35, 1))
+	}
+	if top, bottom := at(20, 1), at(20, 18); top > 40 || bottom < 215 {
+		t.Errorf("column 20: top %d, bottom %d, want dark to light", top, bottom)
+	}
+
+	g.SetTransform(nil)
+	if m := g.Transform().AffineMatrix; m != NewTransformations().AffineMatrix {
+		t.Errorf("Transform after reset = %v, want identity", m)
+	}
+}
+
+func TestConicGradientPaint(t *testing.T) {
+	black, white := Color{0, 0, 0, 255}, Color{255, 255, 255, 255}
+	buf := make([]byte, 40*40*4)
+	ctx := NewAgg2D()
+	ctx.Attach(buf, 40, 40, 40*4)
+	ctx.ClearAll(White)
+	ctx.NoLine()
+	ctx.SetFillGradient(NewConicGradientPaint(20, 20, 0, []GradientStop{{0, black}, {1, white}}))
+	if ctx.FillGradientFlag() != Conic {
+		t.Fatalf("fill gradient flag = %d, want Conic", ctx.FillGradientFlag())
+	}
+	ctx.Rectangle(0, 0, 40, 40)
+
+	at := func(x, y int) int { return int(buf[(y*40+x)*4]) }
+	// Offsets run clockwise from 12 o'clock.
+	cases := []struct{ x, y, want int }{
+		{38, 20, 64},  // 3 o'clock: a quarter turn
+		{20, 38, 128}, // 6 o'clock: half a turn
+		{2, 20, 191},  // 9 o'clock: three quarters
+	}
+	for _, c := range cases {
+		if got := at(c.x, c.y); got < c.want-12 || got > c.want+12 {
+			t.Errorf("pixel (%d,%d) = %d, want ~%d", c.x, c.y, got, c.want)
+		}
+	}
+	if right, left := at(22, 2), at(18, 2); right > 30 || left < 225 {
+		t.Errorf("seam at 12 o'clock: right %d, left %d, want dark then light", right, left)
+	}
+}
+
+func TestContourGradientPaint(t *testing.T) {
+	black, white := Color{0, 0, 0, 255}, Color{255, 255, 255, 255}
+	square := path.NewPathStorageStl()
+	square.MoveTo(5, 5)
+	square.LineTo(35, 5)
+	square.LineTo(35, 35)
+	square.LineTo(5, 35)
+	square.ClosePolygon(basics.PathFlagsNone)
+
+	buf := make([]byte, 40*40*4)
+	ctx := NewAgg2D()
+	ctx.Attach(buf, 40, 40, 40*4)
+	ctx.ClearAll(White)
+	ctx.NoLine()
+	ctx.SetFillGradient(NewContourGradientPaint(square, []GradientStop{{0, black}, {1, white}}))
+	if ctx.FillGradientFlag() != Contour {
+		t.Fatalf("fill gradient flag = %d, want Contour", ctx.FillGradientFlag())
+	}
+	ctx.Rectangle(5, 5, 35, 35)
+
+	at := func(x, y int) int { return int(buf[(y*40+x)*4]) }
+	// Dark along the outline, brightening towards the middle.
+	edge, mid, center := at(6, 20), at(12, 20), at(20, 20)
+	if edge >= mid || mid >= center {
+		t.Errorf("edge %d, mid %d, center %d: want increasing towards the center", edge, mid, center)
+	}
+	if at(20, 6) != edge {
+		t.Errorf("top edge %d differs from left edge %d", at(20, 6), edge)
+	}
+}
+
+func TestFieldGradientPaint(t *testing.T) {
+	red, blue := Color{255, 0, 0, 255}, Color{0, 0, 255, 255}
+	// Two RGBA pixels; the field reads their alpha.
+	pix := []uint8{9, 9, 9, 0, 9, 9, 9, 255}
+	buf := make([]byte, 8*4*4)
+	ctx := NewAgg2D()
+	ctx.Attach(buf, 8, 4, 8*4)
+	ctx.NoLine()
+	ctx.SetFillGradient(NewFieldGradientPaint(pix[3:], 2, 1, 8, 4, 2, 1, []GradientStop{{0, red}, {1, blue}}))
+	ctx.Rectangle(0, 0, 8, 4)
+
+	at := func(x, y int) []byte { return buf[(y*8+x)*4:][:4] }
+	if p := at(2, 1); p[0] != 255 || p[2] != 0 {
+		t.Errorf("level 0 pixel = %v, want red", p)
+	}
+	if p := at(3, 1); p[0] != 0 || p[2] != 255 {
+		t.Errorf("level 255 pixel = %v, want blue", p)
+	}
+	// Outside the image the nearest edge pixel counts.
+	if p, q := at(0, 3), at(7, 0); p[0] != 255 || q[2] != 255 {
+		t.Errorf("outside pixels = %v, %v, want red and blue", p, q)
+	}
+}
+
+func TestFillAlphaGradientMasksFills(t *testing.T) {
+	opaque, clear := Color{0, 0, 0, 255}, Color{0, 0, 0, 0}
+	mask := NewLinearGradientPaint(0, 0, 40, 0, []GradientStop{{0, opaque}, {1, clear}})
+
+	// Solid fill: red fading out to the white background from left to right.
+	ctx, buf := newGradientTarget()
+	ctx.FillColor(Color{255, 0, 0, 255})
+	ctx.SetFillAlphaGradient(mask)
+	ctx.Rectangle(0, 0, 40, 20)
+	at := func(x int) []byte { return buf[(10*40+x)*4:][:3] }
+	if p := at(1); p[1] > 20 {
+		t.Errorf("left pixel = %v, want nearly opaque red", p)
+	}
+	if p := at(20); p[1] < 110 || p[1] > 145 {
+		t.Errorf("middle pixel = %v, want half-transparent red", p)
+	}
+	if p := at(38); p[1] < 235 {
+		t.Errorf("right pixel = %v, want nearly white", p)
+	}
+
+	// Gradient fills are masked too.
+	ctx, buf = newGradientTarget()
+	ctx.SetFillGradient(NewLinearGradientPaint(0, 0, 40, 0, []GradientStop{{0, Color{0, 0, 255, 255}}, {1, Color{0, 0, 255, 255}}}))
+	ctx.SetFillAlphaGradient(mask)
+	ctx.Rectangle(0, 0, 40, 20)
+	if p := at(38); p[0] < 235 {
+		t.Errorf("masked gradient right pixel = %v, want nearly white", p)
+	}
+
+	// So are transformed images.
+	ctx, buf = newGradientTarget()
+	ctx.SetFillAlphaGradient(mask)
+	px := make([]uint8, 4*4*4)
+	for i := 0; i < len(px); i += 4 {
+		px[i], px[i+3] = 255, 255
+	}
+	if err := ctx.TransformImageSimple(NewImage(px, 4, 4, 4*4), 0, 0, 40, 20); err != nil {
+		t.Fatal(err)
+	}
+	if p := at(1); p[0] < 235 || p[1] > 20 {
+		t.Errorf("masked image left pixel = %v, want nearly opaque red", p)
+	}
+	if p := at(38); p[1] < 235 {
+		t.Errorf("masked image right pixel = %v, want nearly white", p)
+	}
+
+	ctx.ResetStyle()
+	if ctx.FillAlphaGradient() != nil {
+		t.Error("ResetStyle should remove the fill alpha gradient")
+	}
+}
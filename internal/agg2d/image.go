@@ -4,12 +4,14 @@ package agg2d
 
 import (
 	"errors"
+	"math"
 
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/buffer"
 	"github.com/MeKo-Christian/agg_go/internal/color"
 	"github.com/MeKo-Christian/agg_go/internal/conv"
 	"github.com/MeKo-Christian/agg_go/internal/order"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
 	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
 	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
 	"github.com/MeKo-Christian/agg_go/internal/span"
@@ -246,6 +248,10 @@ func (agg2d *Agg2D) clipImageTransfer(img *Image, imgX1, imgY1, imgX2, imgY2, ds
 	if rect.srcX < 0 || rect.srcY < 0 || rect.srcX+rect.width > img.Width() || rect.srcY+rect.height > img.Height() {
 		return imageTransferRect{}, false
 	}
+	if agg2d.measuring {
+		agg2d.measureRect(rect.dstX, rect.dstY, rect.dstX+rect.width, rect.dstY+rect.height)
+		return imageTransferRect{}, false
+	}
 	return rect, true
 }
 
@@ -277,15 +283,27 @@ func (agg2d *Agg2D) renderImage(img *Image, x1, y1, x2, y2 int, parallelogram []
 		mtx.Multiply(agg2d.transform)
 	}
 	mtx.Invert()
+	if level, m := img.mipLevel(mtx); level != img {
+		// Scale the source rectangle to the mip level, rounding outwards.
+		fullW, fullH := img.width, img.height
+		x1, y1 = x1*level.width/fullW, y1*level.height/fullH
+		x2, y2 = (x2*level.width+fullW-1)/fullW, (y2*level.height+fullH-1)/fullH
+		img, mtx = level, m
+	}
 
 	agg2d.rasterizer.Reset()
 	agg2d.rasterizer.FillingRule(agg2d.GetFillRule())
 	agg2d.addCurrentPathToRasterizer()
 
+	if agg2d.measuring {
+		agg2d.measureSweep(agg2d.rasterizer, agg2d.scanline)
+		return nil
+	}
+
 	interpolator := span.NewSpanInterpolatorLinearDefault(mtx)
-	imageSource := newImagePixelFormat(img)
+	imageSource := newImagePixelFormatEdge(img, agg2d.imageEdge, x1, y1, x2, y2, agg2d.imageBackground)
 	sampleGenerator := agg2d.newImageFilterGenerator(imageSource, interpolator)
-	spanGenerator := newImageSpanGenerator(sampleGenerator, agg2d.imageBlendMode, agg2d.imageBlendColor)
+	spanGenerator := agg2d.maskFillSpans(newImageSpanGenerator(sampleGenerator, agg2d.imageBlendMode, agg2d.imageBlendColor), true)
 
 	renderer := agg2d.currentImageRenderer()
 	if renderer == nil {
@@ -354,6 +372,44 @@ func (agg2d *Agg2D) TransformImageParallelogramSimple(img *Image, parallelogram
 	return agg2d.TransformImageParallelogram(img, 0, 0, img.Width(), img.Height(), parallelogram)
 }
 
+// TransformImageRotated renders the entire image centered on (cx, cy),
+// scaled by scale and rotated by angle radians about its center. The image is
+// sampled with filter for this call only; when filter is the current filter,
+// its radius is kept. Minified images (scale < 1) are resampled even when the
+// resampling mode is NoResample, so that they do not alias.
+func (agg2d *Agg2D) TransformImageRotated(img *Image, cx, cy, angle, scale float64, filter ImageFilter) error {
+	if img == nil {
+		return errors.New("image is nil")
+	}
+	if !(scale > 0) || math.IsInf(scale, 1) {
+		return errors.New("scale must be positive and finite")
+	}
+
+	if filter != agg2d.imageFilter {
+		f, lut := agg2d.imageFilter, agg2d.imageFilterLUT
+		agg2d.imageFilterLUT = nil
+		agg2d.ImageFilter(filter)
+		defer func() { agg2d.imageFilter, agg2d.imageFilterLUT = f, lut }()
+	}
+	if scale < 1 && agg2d.imageResample == NoResample {
+		defer agg2d.ImageResample(agg2d.imageResample)
+		agg2d.imageResample = ResampleOnZoomOut
+	}
+
+	w, h := float64(img.Width()), float64(img.Height())
+	mtx := transform.NewTransAffineTranslation(-w/2, -h/2)
+	mtx.Multiply(transform.NewTransAffineScaling(scale))
+	mtx.Multiply(transform.NewTransAffineRotation(angle))
+	mtx.Multiply(transform.NewTransAffineTranslation(cx, cy))
+
+	// AGG parallelograms list the images of (x1,y1), (x2,y1) and (x2,y2).
+	parallelogram := []float64{0, 0, w, 0, w, h}
+	for i := 0; i < 6; i += 2 {
+		mtx.Transform(&parallelogram[i], &parallelogram[i+1])
+	}
+	return agg2d.TransformImageParallelogram(img, 0, 0, img.Width(), img.Height(), parallelogram)
+}
+
 // TransformImagePath transforms and renders image along current path.
 // The image is clipped to the shape of the current path, matching the original AGG C++ behavior.
 func (agg2d *Agg2D) TransformImagePath(img *Image, imgX1, imgY1, imgX2, imgY2 int, dstX1, dstY1, dstX2, dstY2 float64) error {
@@ -434,24 +490,51 @@ func (agg2d *Agg2D) BlendImage(img *Image, imgX1, imgY1, imgX2, imgY2 int, dstX,
 
 	if agg2d.blendMode == BlendAlpha {
 		if agg2d.pixfmtPre != nil {
-			src := newImagePixelFormatPre(img)
-			for row := 0; row < rect.height; row++ {
-				agg2d.pixfmtPre.BlendFrom(src, rect.dstX, rect.dstY+row, rect.srcX, rect.srcY+row, rect.width, basics.Int8u(alpha))
-			}
+			blendImageRows(agg2d.directTarget(agg2d.pixfmtPre, agg2d.renBasePre), newImagePixelFormatPre(img), rect, basics.Int8u(alpha))
 		}
 		return nil
 	}
 
 	if agg2d.pixfmtCompPre != nil {
-		src := newImagePixelFormatPre(img)
-		for row := 0; row < rect.height; row++ {
-			agg2d.pixfmtCompPre.BlendFrom(src, rect.dstX, rect.dstY+row, rect.srcX, rect.srcY+row, rect.width, basics.Int8u(alpha))
-		}
+		blendImageRows(agg2d.directTarget(agg2d.pixfmtCompPre, agg2d.renBaseCompPre), newImagePixelFormatPre(img), rect, basics.Int8u(alpha))
 	}
 
 	return nil
 }
 
+// blendImageRows blends the transfer rectangle of src into dst. The pixel
+// formats built by initializeRendering blend whole rows directly; any other
+// target goes through a color span.
+func blendImageRows(dst targetPixfmt, src *imagePixelFormatPre, rect imageTransferRect, cover basics.Int8u) {
+	for row := 0; row < rect.height; row++ {
+		xd, yd, xs, ys := rect.dstX, rect.dstY+row, rect.srcX, rect.srcY+row
+		switch pf := dst.(type) {
+		case *pixfmt.PixFmtRGBA32Pre[color.Linear]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtBGRA32Pre[color.Linear]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtARGB32Pre[color.Linear]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtABGR32Pre[color.Linear]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtCompositeRGBA[color.Linear, order.RGBA]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtCompositeRGBA[color.Linear, order.BGRA]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtCompositeRGBA[color.Linear, order.ARGB]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		case *pixfmt.PixFmtCompositeRGBA[color.Linear, order.ABGR]:
+			pf.BlendFrom(src, xd, yd, xs, ys, rect.width, cover)
+		default:
+			colors := make([]color.RGBA8[color.Linear], rect.width)
+			for i := range colors {
+				colors[i] = src.GetPixel(xs+i, ys)
+			}
+			dst.BlendColorHspan(xd, yd, rect.width, colors, nil, cover)
+		}
+	}
+}
+
 // BlendImageSimple blends entire image to destination without transformation.
 func (agg2d *Agg2D) BlendImageSimple(img *Image, dstX, dstY float64, alpha uint) error {
 	if img == nil {
@@ -489,11 +572,24 @@ func (agg2d *Agg2D) CopyImage(img *Image, imgX1, imgY1, imgX2, imgY2 int, dstX,
 		return nil
 	}
 
-	if agg2d.pixfmt != nil {
-		src := newImagePixelFormat(img)
+	if agg2d.pixfmt == nil {
+		return nil
+	}
+	src := newImagePixelFormat(img)
+	dst := agg2d.directTarget(agg2d.pixfmt, agg2d.renBase)
+	if pf, ok := dst.(*pixfmt.PixFmtRGBA32[color.Linear]); ok && img.order == OrderRGBA {
 		for row := 0; row < rect.height; row++ {
-			agg2d.pixfmt.CopyFrom(src, rect.dstX, rect.dstY+row, rect.srcX, rect.srcY+row, rect.width)
+			pf.CopyFrom(src, rect.dstX, rect.dstY+row, rect.srcX, rect.srcY+row, rect.width)
+		}
+		return nil
+	}
+	// The channel orders differ: copy through colors.
+	colors := make([]color.RGBA8[color.Linear], rect.width)
+	for row := 0; row < rect.height; row++ {
+		for i := range colors {
+			colors[i] = src.Pixel(rect.srcX+i, rect.srcY+row)
 		}
+		dst.CopyColorHspan(rect.dstX, rect.dstY+row, rect.width, colors)
 	}
 
 	return nil
@@ -518,24 +614,25 @@ func (img *Image) Premultiply() error {
 		return errors.New("image data is nil")
 	}
 
-	// Process each pixel (assuming RGBA format with 4 bytes per pixel)
+	// Process each 4-byte pixel in the image's channel order
+	o := img.order.colorOrder()
 	for i := 0; i < len(img.Data); i += 4 {
-		r := float64(img.Data[i+0])
-		g := float64(img.Data[i+1])
-		b := float64(img.Data[i+2])
-		a := float64(img.Data[i+3])
+		r := float64(img.Data[i+o.R])
+		g := float64(img.Data[i+o.G])
+		b := float64(img.Data[i+o.B])
+		a := float64(img.Data[i+o.A])
 
 		// Premultiply RGB by alpha
 		if a > 0 {
 			scale := a / 255.0
-			img.Data[i+0] = uint8(r * scale)
-			img.Data[i+1] = uint8(g * scale)
-			img.Data[i+2] = uint8(b * scale)
+			img.Data[i+o.R] = uint8(r * scale)
+			img.Data[i+o.G] = uint8(g * scale)
+			img.Data[i+o.B] = uint8(b * scale)
 		} else {
 			// If alpha is 0, RGB should be 0 in premultiplied format
-			img.Data[i+0] = 0
-			img.Data[i+1] = 0
-			img.Data[i+2] = 0
+			img.Data[i+o.R] = 0
+			img.Data[i+o.G] = 0
+			img.Data[i+o.B] = 0
 		}
 		// Alpha remains unchanged
 	}
@@ -553,19 +650,20 @@ func (img *Image) Demultiply() error {
 		return errors.New("image data is nil")
 	}
 
-	// Process each pixel (assuming RGBA format with 4 bytes per pixel)
+	// Process each 4-byte pixel in the image's channel order
+	o := img.order.colorOrder()
 	for i := 0; i < len(img.Data); i += 4 {
-		r := float64(img.Data[i+0])
-		g := float64(img.Data[i+1])
-		b := float64(img.Data[i+2])
-		a := float64(img.Data[i+3])
+		r := float64(img.Data[i+o.R])
+		g := float64(img.Data[i+o.G])
+		b := float64(img.Data[i+o.B])
+		a := float64(img.Data[i+o.A])
 
 		// Demultiply RGB by alpha
 		if a > 0 {
 			scale := 255.0 / a
-			img.Data[i+0] = uint8(Clamp(r*scale, 0, 255))
-			img.Data[i+1] = uint8(Clamp(g*scale, 0, 255))
-			img.Data[i+2] = uint8(Clamp(b*scale, 0, 255))
+			img.Data[i+o.R] = uint8(Clamp(r*scale, 0, 255))
+			img.Data[i+o.G] = uint8(Clamp(g*scale, 0, 255))
+			img.Data[i+o.B] = uint8(Clamp(b*scale, 0, 255))
 		}
 		// If alpha is 0, RGB values remain as they are
 		// Alpha remains unchanged
@@ -580,6 +678,7 @@ func (img *Image) Attach(buf []uint8, width, height, stride int) {
 	img.Data = buf
 	img.width = width
 	img.height = height
+	img.mips = nil
 	if img.renBuf == nil {
 		img.renBuf = buffer.NewRenderingBuffer[uint8]()
 	}
@@ -605,7 +704,8 @@ func (img *Image) Stride() int {
 	return img.width * 4
 }
 
-// GetPixel returns a pixel at the specified coordinates as RGBA8 array
+// GetPixel returns a pixel at the specified coordinates as an RGBA8 array,
+// whatever the image's byte order.
 func (img *Image) GetPixel(x, y int) [4]uint8 {
 	if img.Data == nil || x < 0 || y < 0 || x >= img.width || y >= img.height {
 		return [4]uint8{0, 0, 0, 0}
@@ -617,11 +717,12 @@ func (img *Image) GetPixel(x, y int) [4]uint8 {
 		return [4]uint8{0, 0, 0, 0}
 	}
 
+	o := img.order.colorOrder()
 	return [4]uint8{
-		img.Data[offset],
-		img.Data[offset+1],
-		img.Data[offset+2],
-		img.Data[offset+3],
+		img.Data[offset+o.R],
+		img.Data[offset+o.G],
+		img.Data[offset+o.B],
+		img.Data[offset+o.A],
 	}
 }
 
@@ -2,6 +2,7 @@
 package agg2d
 
 import (
+	"math"
 	"testing"
 
 	"github.com/MeKo-Christian/agg_go/internal/color"
@@ -134,6 +135,62 @@ func TestTransformImageParallelogramSimple(t *testing.T) {
 	}
 }
 
+// TestTransformImageRotated tests rotation and scaling about the image center.
+func TestTransformImageRotated(t *testing.T) {
+	agg2d := NewAgg2D()
+	width, height := 32, 32
+	buf := make([]uint8, width*height*4)
+	agg2d.Attach(buf, width, height, width*4)
+	agg2d.SetImageFilterRadius(Lanczos, 3)
+	lut := agg2d.imageFilterLUT
+
+	// 4x2 image: left half red, right half blue.
+	src := make([]uint8, 4*2*4)
+	for i := 0; i < len(src); i += 4 {
+		if i/4%4 < 2 {
+			src[i], src[i+3] = 255, 255
+		} else {
+			src[i+2], src[i+3] = 255, 255
+		}
+	}
+	img := NewImage(src, 4, 2, 4*4)
+
+	// A quarter turn with y down moves the left half above the center.
+	if err := agg2d.TransformImageRotated(img, 16, 16, math.Pi/2, 2, NoFilter); err != nil {
+		t.Fatalf("TransformImageRotated failed: %v", err)
+	}
+	if r, g, b, a := pixelAt(buf, width, 16, 13); r != 255 || g != 0 || b != 0 || a != 255 {
+		t.Errorf("pixel above center = (%d,%d,%d,%d), want red", r, g, b, a)
+	}
+	if r, g, b, a := pixelAt(buf, width, 16, 18); r != 0 || g != 0 || b != 255 || a != 255 {
+		t.Errorf("pixel below center = (%d,%d,%d,%d), want blue", r, g, b, a)
+	}
+	for _, p := range [][2]int{{10, 16}, {21, 16}} {
+		if r, g, b, a := pixelAt(buf, width, p[0], p[1]); r|g|b|a != 0 {
+			t.Errorf("pixel %v = (%d,%d,%d,%d), want untouched", p, r, g, b, a)
+		}
+	}
+
+	if agg2d.GetImageFilter() != Lanczos || agg2d.imageFilterLUT != lut {
+		t.Errorf("image filter not restored: got %d", agg2d.GetImageFilter())
+	}
+
+	agg2d.ImageResample(NoResample)
+	if err := agg2d.TransformImageRotated(img, 16, 16, 0, 0.25, Bilinear); err != nil {
+		t.Fatalf("TransformImageRotated minified failed: %v", err)
+	}
+	if agg2d.GetImageResample() != NoResample {
+		t.Errorf("image resample not restored: got %d", agg2d.GetImageResample())
+	}
+
+	if err := agg2d.TransformImageRotated(img, 16, 16, 0, 0, Bilinear); err == nil {
+		t.Error("Expected error for zero scale")
+	}
+	if err := agg2d.TransformImageRotated(nil, 16, 16, 0, 1, Bilinear); err == nil {
+		t.Error("Expected error for nil image")
+	}
+}
+
 // TestTransformImagePath tests path-based image transformation.
 func TestTransformImagePath(t *testing.T) {
 	agg2d := NewAgg2D()
@@ -1016,3 +1073,81 @@ func BenchmarkBlendImage(b *testing.B) {
 		agg2d.BlendImage(img, 0, 0, 64, 64, 100, 100, 128)
 	}
 }
+
+func TestImageEdgeModes(t *testing.T) {
+	// 2x1 image: red then blue, drawn 1:1 into a path four times as wide.
+	src := []uint8{255, 0, 0, 255, 0, 0, 255, 255}
+	img := NewImage(src, 2, 1, 2*4)
+
+	red, blue, none := [4]uint8{255, 0, 0, 255}, [4]uint8{0, 0, 255, 255}, [4]uint8{}
+	tests := []struct {
+		edge ImageEdge
+		want [8][4]uint8
+	}{
+		{EdgeClamp, [8][4]uint8{red, blue, blue, blue, blue, blue, blue, blue}},
+		{EdgeTransparent, [8][4]uint8{red, blue, none, none, none, none, none, none}},
+		{EdgeRepeat, [8][4]uint8{red, blue, red, blue, red, blue, red, blue}},
+		{EdgeReflect, [8][4]uint8{red, blue, blue, red, red, blue, blue, red}},
+	}
+	for _, tt := range tests {
+		agg2d := NewAgg2D()
+		width, height := 8, 1
+		buf := make([]uint8, width*height*4)
+		agg2d.Attach(buf, width, height, width*4)
+		agg2d.ImageFilter(NoFilter)
+		agg2d.ImageEdge(tt.edge)
+		if agg2d.GetImageEdge() != tt.edge {
+			t.Fatalf("GetImageEdge() = %d, want %d", agg2d.GetImageEdge(), tt.edge)
+		}
+
+		agg2d.setImagePathRect(0, 0, 8, 1)
+		if err := agg2d.TransformImagePathSimple(img, 0, 0, 2, 1); err != nil {
+			t.Fatalf("edge %d: TransformImagePathSimple failed: %v", tt.edge, err)
+		}
+		for x, want := range tt.want {
+			if r, g, b, a := pixelAt(buf, width, x, 0); [4]uint8{r, g, b, a} != want {
+				t.Errorf("edge %d: pixel %d = (%d,%d,%d,%d), want %v", tt.edge, x, r, g, b, a, want)
+			}
+		}
+	}
+}
+
+func TestImageEdgeSourceRect(t *testing.T) {
+	// 4x1 image green, red, blue, green; only red and blue are drawn.
+	src := []uint8{0, 255, 0, 255, 255, 0, 0, 255, 0, 0, 255, 255, 0, 255, 0, 255}
+	img := NewImage(src, 4, 1, 4*4)
+
+	red, blue := [4]uint8{255, 0, 0, 255}, [4]uint8{0, 0, 255, 255}
+	gray := [4]uint8{128, 128, 128, 128} // half transparent white, premultiplied
+	tests := []struct {
+		edge ImageEdge
+		want [4][4]uint8
+	}{
+		{EdgeColor, [4][4]uint8{red, blue, gray, gray}},
+		{EdgeRepeat, [4][4]uint8{red, blue, red, blue}},
+	}
+	for _, tt := range tests {
+		agg2d := NewAgg2D()
+		width, height := 4, 1
+		buf := make([]uint8, width*height*4)
+		agg2d.Attach(buf, width, height, width*4)
+		agg2d.ImageFilter(NoFilter)
+		agg2d.ImageEdge(tt.edge)
+		agg2d.ImageBackgroundColor(Color{255, 255, 255, 128})
+
+		agg2d.setImagePathRect(0, 0, 4, 1)
+		if err := agg2d.TransformImagePath(img, 1, 0, 3, 1, 0, 0, 2, 1); err != nil {
+			t.Fatalf("edge %d: TransformImagePath failed: %v", tt.edge, err)
+		}
+		for x, want := range tt.want {
+			r, g, b, a := pixelAt(buf, width, x, 0)
+			got := [4]uint8{r, g, b, a}
+			for i := range got {
+				if d := int(got[i]) - int(want[i]); d < -1 || d > 1 {
+					t.Errorf("edge %d: pixel %d = %v, want %v", tt.edge, x, got, want)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/scanline"
+)
+
+// RenderMask rasterizes src into a width x height Gray8 coverage mask: one
+// byte per pixel, 0 outside the path, 255 fully inside and anti-aliased
+// coverage on the edges. Coordinates are in pixels with no transform.
+func RenderMask(src *path.PathStorageStl, width, height int, evenOdd bool) []uint8 {
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	mask := make([]uint8, width*height)
+
+	ras := pathRasterizer(src, evenOdd)
+	renscan.RenderScanlinesAASolid[uint8](ras, scanline.NewScanlineU8(), &maskWriter{buf: mask, width: width, height: height}, 255)
+	return mask
+}
+
+// AccumulateCoverage rasterizes src in one pass and adds its coverage to the
+// width x height density map dst, one value per pixel. Overlapping polygons
+// add up instead of saturating at full coverage, so a pixel inside n
+// overlapping shapes gains n; see rasterizer.SweepAccumulated. Shapes need
+// the same orientation, as opposite ones cancel. Coordinates are in pixels
+// with no transform.
+func AccumulateCoverage(dst []float32, width, height int, src *path.PathStorageStl) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	pathRasterizer(src, false).SweepAccumulated(func(x, y, length int, coverage float64) {
+		if y < 0 || y >= height {
+			return
+		}
+		row := dst[y*width : (y+1)*width]
+		for i := max(x, 0); i < min(x+length, width); i++ {
+			row[i] += float32(coverage)
+		}
+	})
+}
+
+// HitTestPath reports for every point whether the pixel containing it gets
+// any coverage when src is filled, as RenderMask would paint it. All points
+// share one rasterization and one walk per scanline. It returns the number of
+// hits; hits must be at least as long as pts.
+func HitTestPath(src *path.PathStorageStl, evenOdd bool, pts []basics.Point[float64], hits []bool) int {
+	ipts := make([]basics.Point[int], len(pts))
+	for i, p := range pts {
+		ipts[i] = basics.Point[int]{X: int(math.Floor(p.X)), Y: int(math.Floor(p.Y))}
+	}
+	return pathRasterizer(src, evenOdd).HitTestPoints(ipts, hits)
+}
+
+// pathRasterizer returns a rasterizer filled with src, curves flattened.
+func pathRasterizer(src *path.PathStorageStl, evenOdd bool) *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip] {
+	ras := rasterizer.NewRasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip](
+		rasterizer.RasConvInt{}, rasterizer.NewRasterizerSlNoClip())
+	if evenOdd {
+		ras.FillingRule(basics.FillEvenOdd)
+	} else {
+		ras.FillingRule(basics.FillNonZero)
+	}
+
+	curve := conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(src))
+	curve.Rewind(0)
+	for {
+		x, y, cmd := curve.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		ras.AddVertex(x, y, uint32(cmd))
+	}
+	return ras
+}
+
+// maskWriter is a clipped base renderer over a Gray8 buffer that composites
+// coverage with "over", so touching spans accumulate.
+type maskWriter struct {
+	buf           []uint8
+	width, height int
+}
+
+func (m *maskWriter) blend(x, y int, v uint8, cover basics.Int8u) {
+	if x < 0 || x >= m.width || y < 0 || y >= m.height {
+		return
+	}
+	a := uint32(v) * uint32(cover) / 255
+	p := &m.buf[y*m.width+x]
+	*p = uint8(uint32(*p) + a*(255-uint32(*p))/255)
+}
+
+func (m *maskWriter) BlendSolidHspan(x, y, length int, c uint8, covers []basics.Int8u) {
+	for i := 0; i < length; i++ {
+		m.blend(x+i, y, c, covers[i])
+	}
+}
+
+func (m *maskWriter) BlendHline(x1, y, x2 int, c uint8, cover basics.Int8u) {
+	for x := x1; x <= x2; x++ {
+		m.blend(x, y, c, cover)
+	}
+}
+
+func (m *maskWriter) BlendColorHspan(x, y, length int, colors []uint8, covers []basics.Int8u, cover basics.Int8u) {
+	for i := 0; i < length; i++ {
+		c := cover
+		if covers != nil {
+			c = covers[i]
+		}
+		m.blend(x+i, y, colors[i], c)
+	}
+}
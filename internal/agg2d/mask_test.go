@@ -0,0 +1,141 @@
+package agg2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+func squarePath(ps *path.PathStorageStl, x1, y1, x2, y2 float64) {
+	ps.MoveTo(x1, y1)
+	ps.LineTo(x2, y1)
+	ps.LineTo(x2, y2)
+	ps.LineTo(x1, y2)
+	ps.ClosePolygon(basics.PathFlagsNone)
+}
+
+func TestRenderMaskCoverage(t *testing.T) {
+	ps := path.NewPathStorageStl()
+	squarePath(ps, 2, 2, 18.5, 18)
+	squarePath(ps, 6, 6, 14, 14) // same winding: a hole only with even-odd
+
+	for _, evenOdd := range []bool{false, true} {
+		mask := RenderMask(ps, 20, 20, evenOdd)
+		at := func(x, y int) uint8 { return mask[y*20+x] }
+		if at(0, 0) != 0 || at(19, 19) != 0 {
+			t.Errorf("evenOdd=%v: outside = %d, %d, want 0", evenOdd, at(0, 0), at(19, 19))
+		}
+		if at(3, 10) != 255 {
+			t.Errorf("evenOdd=%v: inside = %d, want 255", evenOdd, at(3, 10))
+		}
+		if v := at(18, 10); v < 120 || v > 136 {
+			t.Errorf("evenOdd=%v: half-covered edge = %d, want ~128", evenOdd, v)
+		}
+		want := uint8(255)
+		if evenOdd {
+			want = 0
+		}
+		if at(10, 10) != want {
+			t.Errorf("evenOdd=%v: center = %d, want %d", evenOdd, at(10, 10), want)
+		}
+	}
+}
+
+func TestRenderMaskClipsToBounds(t *testing.T) {
+	ps := path.NewPathStorageStl()
+	squarePath(ps, -10, -10, 30, 5)
+	mask := RenderMask(ps, 8, 8, false)
+	if len(mask) != 64 || mask[0] != 255 || mask[7] != 255 || mask[6*8] != 0 {
+		t.Errorf("unexpected clipped mask %v", mask)
+	}
+	if RenderMask(ps, 0, 8, false) != nil {
+		t.Error("empty mask should be nil")
+	}
+}
+
+// TestTileSeams rasterizes a mesh of tiles separately, as a tiled renderer
+// would, and sums their coverage. The tiles are given in world units around
+// (1e6, 2e6) and scaled down, and many have vertices on the edges of their
+// neighbors, so their vertices fall between subpixel positions. The rounding
+// leaves seams where the sum is short of full coverage by up to about
+// 1/PolySubpixelScale: visible 8-bit coverage levels with the default build,
+// and far below one level with the subpixel16 build.
+func TestTileSeams(t *testing.T) {
+	checkTileSeams(t, 0, 0)
+}
+
+// TestTileSeamsLargeCoordinates renders the mesh of TestTileSeams far from
+// the device origin, where the fixed-point coordinates of the subpixel16
+// build no longer fit in 32 bits.
+func TestTileSeamsLargeCoordinates(t *testing.T) {
+	checkTileSeams(t, 3e5, 2e5)
+}
+
+// checkTileSeams renders the tile mesh with its top left corner at device
+// position (dx, dy) and checks the seams it leaves.
+func checkTileSeams(t *testing.T, dx, dy float64) {
+	t.Helper()
+	const size, cells, tile = 64, 6, 1000.0
+	const ox, oy, scale = 1e6, 2e6, 1.0 / 97
+	acc := make([]float32, size*size)
+	x0, y0 := int(dx), int(dy)
+	triangle := func(pts ...float64) {
+		ps := path.NewPathStorageStl()
+		for i := 0; i < len(pts); i += 2 {
+			x, y := (pts[i]-ox)*scale+0.5+dx, (pts[i+1]-oy)*scale+0.5+dy
+			if i == 0 {
+				ps.MoveTo(x, y)
+			} else {
+				ps.LineTo(x, y)
+			}
+		}
+		ps.ClosePolygon(basics.PathFlagsNone)
+		pathRasterizer(ps, false).SweepAccumulated(func(x, y, length int, coverage float64) {
+			x, y = x-x0, y-y0
+			if y < 0 || y >= size {
+				t.Fatalf("span at row %d, outside the mesh", y)
+			}
+			for i := max(x, 0); i < min(x+length, size); i++ {
+				acc[y*size+i] += float32(coverage)
+			}
+		})
+	}
+	// Each cell is split along its diagonal. The upper triangle is a fan
+	// whose vertices lie on the diagonal edge of the lower one.
+	for j := 0; j < cells; j++ {
+		for i := 0; i < cells; i++ {
+			x0, y0 := ox+float64(i)*tile, oy+float64(j)*tile
+			triangle(x0, y0, x0+tile, y0+tile, x0, y0+tile)
+			n := 3 + i + j
+			for k := 0; k < n; k++ {
+				t0, t1 := tile*float64(k)/float64(n), tile*float64(k+1)/float64(n)
+				triangle(x0+t0, y0+t0, x0+tile, y0, x0+t1, y0+t1)
+			}
+		}
+	}
+
+	worst, seams := 0.0, 0
+	end := int(math.Floor(cells * tile * scale))
+	for y := 2; y < end-1; y++ {
+		for x := 2; x < end-1; x++ {
+			v := float64(acc[y*size+x])
+			worst = max(worst, math.Abs(v-1))
+			if math.Round(v*255) != 255 {
+				seams++
+			}
+		}
+	}
+	if limit := 2.0 / basics.PolySubpixelScale; worst > limit {
+		t.Errorf("coverage error %g, want at most %g", worst, limit)
+	}
+	// With 8 bits the mesh must show seams, or it does not exercise the
+	// rounding the subpixel16 build removes.
+	if basics.PolySubpixelShift >= 16 && seams != 0 {
+		t.Errorf("%d seam pixels with %d subpixel bits, want none", seams, basics.PolySubpixelShift)
+	} else if basics.PolySubpixelShift < 16 && seams == 0 {
+		t.Errorf("no seam pixels with %d subpixel bits", basics.PolySubpixelShift)
+	}
+	t.Logf("%d subpixel bits: coverage error %g, %d seam pixels", basics.PolySubpixelShift, worst, seams)
+}
@@ -0,0 +1,147 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+)
+
+// BeginMeasure starts a dry run. Until EndMeasure, drawing is rasterized as
+// usual but writes no pixels; the device bounds of the coverage it produces
+// are accumulated instead. Fills, strokes, text, images, FillRects, Gouraud
+// triangles and ClearAll are measured; code that writes the buffer directly
+// must check Measuring and report its pixels with MeasureRect. Dry runs nest: the bounds of an inner
+// one are added to the enclosing one.
+func (agg2d *Agg2D) BeginMeasure() {
+	if agg2d.measuring {
+		agg2d.outerMeasures = append(agg2d.outerMeasures, agg2d.measured)
+	}
+	agg2d.measuring = true
+	agg2d.measured = basics.RectI{X1: math.MaxInt, Y1: math.MaxInt, X2: math.MinInt, Y2: math.MinInt}
+	// An empty clip box on the renderers keeps anything not measured from
+	// reaching the buffer.
+	agg2d.suppressPainting()
+}
+
+// EndMeasure ends the innermost dry run and returns the bounds of everything
+// drawn since the matching BeginMeasure, with X2 and Y2 exclusive. ok is
+// false when nothing was covered.
+func (agg2d *Agg2D) EndMeasure() (bounds basics.RectI, ok bool) {
+	if !agg2d.measuring {
+		return basics.RectI{}, false
+	}
+	bounds = agg2d.measured
+	if n := len(agg2d.outerMeasures); n > 0 {
+		agg2d.measured = agg2d.outerMeasures[n-1]
+		agg2d.outerMeasures = agg2d.outerMeasures[:n-1]
+		agg2d.addMeasured(bounds.X1, bounds.Y1, bounds.X2, bounds.Y2)
+	} else {
+		agg2d.measuring = false
+		agg2d.ClipBox(agg2d.clipBox.X1, agg2d.clipBox.Y1, agg2d.clipBox.X2, agg2d.clipBox.Y2)
+	}
+	if bounds.X1 >= bounds.X2 || bounds.Y1 >= bounds.Y2 {
+		return basics.RectI{}, false
+	}
+	return bounds, true
+}
+
+// Measuring reports whether a dry run is in progress.
+func (agg2d *Agg2D) Measuring() bool {
+	return agg2d.measuring
+}
+
+func (agg2d *Agg2D) suppressPainting() {
+	for _, b := range []*baseRendererAdapter[color.RGBA8[color.Linear]]{agg2d.renBase, agg2d.renBasePre, agg2d.renBaseComp, agg2d.renBaseCompPre} {
+		if b != nil {
+			b.rendererBase().ResetClipping(false)
+		}
+	}
+}
+
+// MeasureRect adds the pixels [x1, x2) x [y1, y2) inside the clip box to the
+// bounds of the current dry run, for code that writes the buffer directly.
+func (agg2d *Agg2D) MeasureRect(x1, y1, x2, y2 int) {
+	if agg2d.measuring {
+		agg2d.measureRect(x1, y1, x2, y2)
+	}
+}
+
+// measureRect adds the pixels [x1, x2) x [y1, y2) inside the clip box to the
+// measured bounds.
+func (agg2d *Agg2D) measureRect(x1, y1, x2, y2 int) {
+	cb := agg2d.clipBox
+	x1, y1 = max(x1, int(cb.X1), 0), max(y1, int(cb.Y1), 0)
+	x2, y2 = min(x2, int(cb.X2)+1, agg2d.rbuf.Width()), min(y2, int(cb.Y2)+1, agg2d.rbuf.Height())
+	agg2d.addMeasured(x1, y1, x2, y2)
+}
+
+// addMeasured adds [x1, x2) x [y1, y2) to the measured bounds unclipped.
+func (agg2d *Agg2D) addMeasured(x1, y1, x2, y2 int) {
+	if x1 >= x2 || y1 >= y2 {
+		return
+	}
+	m := &agg2d.measured
+	m.X1, m.Y1 = min(m.X1, x1), min(m.Y1, y1)
+	m.X2, m.Y2 = max(m.X2, x2), max(m.Y2, y2)
+}
+
+// measureCovers adds the covered pixels of a span starting at (x, y).
+func (agg2d *Agg2D) measureCovers(x, y int, covers []basics.Int8u) {
+	first, last := 0, len(covers)-1
+	for first <= last && covers[first] == 0 {
+		first++
+	}
+	for last >= first && covers[last] == 0 {
+		last--
+	}
+	agg2d.measureRect(x+first, y, x+last+1, y+1)
+}
+
+// measureSweep adds the coverage of everything in ras to the measured
+// bounds.
+func (agg2d *Agg2D) measureSweep(ras renscan.RasterizerInterface, sl renscan.ScanlineInterface) {
+	if !ras.RewindScanlines() {
+		return
+	}
+	sl.Reset(ras.MinX(), ras.MaxX())
+	for ras.SweepScanline(sl) {
+		if sl.NumSpans() == 0 {
+			continue
+		}
+		y := sl.Y()
+		it := sl.BeginIterator()
+		for {
+			s := it.GetSpan()
+			switch {
+			case s.Len < 0 && len(s.Covers) > 0 && s.Covers[0] != 0:
+				// Solid span: one cover for -Len pixels.
+				agg2d.measureRect(s.X, y, s.X-s.Len, y+1)
+			case s.Covers == nil:
+				agg2d.measureRect(s.X, y, s.X+max(s.Len, -s.Len), y+1)
+			case s.Len > 0:
+				agg2d.measureCovers(s.X, y, s.Covers[:s.Len])
+			}
+			if !it.Next() {
+				break
+			}
+		}
+	}
+}
+
+// coverageMeter is a base renderer that measures spans instead of blending
+// them, for the raster text renderers.
+type coverageMeter struct{ agg2d *Agg2D }
+
+func (m coverageMeter) BlendSolidHspan(x, y, length int, _ color.RGBA8[color.Linear], covers []basics.CoverType) {
+	m.agg2d.measureCovers(x, y, covers[:min(length, len(covers))])
+}
+
+func (m coverageMeter) BlendSolidVspan(x, y, length int, _ color.RGBA8[color.Linear], covers []basics.CoverType) {
+	for i, c := range covers[:min(length, len(covers))] {
+		if c != 0 {
+			m.agg2d.measureRect(x, y+i, x+1, y+i+1)
+		}
+	}
+}
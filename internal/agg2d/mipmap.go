@@ -0,0 +1,100 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// DownscaleBox shrinks an image by an integer factor with a box filter: each
+// output pixel is the average of the factor x factor block of input pixels it
+// covers. The output is ceil(width/factor) x ceil(height/factor) pixels with
+// a stride of its width times channels; blocks cut off by the right or bottom
+// edge average the pixels they have. Each pixel has channels bytes, averaged
+// separately, or channels big-endian 16-bit values when wide is set. The
+// averages are only exact for premultiplied colors; straight alpha darkens
+// the edges of transparent areas.
+func DownscaleBox(pix []uint8, width, height, stride, channels int, wide bool, factor int) ([]uint8, int, int) {
+	factor = max(factor, 1)
+	dw, dh := (width+factor-1)/factor, (height+factor-1)/factor
+	if width <= 0 || height <= 0 {
+		return nil, 0, 0
+	}
+	size := 1
+	if wide {
+		size = 2
+	}
+	bpp := channels * size
+	out := make([]uint8, dw*dh*bpp)
+	sums := make([]int, dw*channels)
+	for dy := 0; dy < dh; dy++ {
+		clear(sums)
+		y1, y2 := dy*factor, min(dy*factor+factor, height)
+		for y := y1; y < y2; y++ {
+			row := pix[y*stride:]
+			for x := 0; x < width; x++ {
+				s := sums[x/factor*channels:]
+				for c := 0; c < channels; c++ {
+					i := x*bpp + c*size
+					if wide {
+						s[c] += int(row[i])<<8 | int(row[i+1])
+					} else {
+						s[c] += int(row[i])
+					}
+				}
+			}
+		}
+		dst := out[dy*dw*bpp:]
+		for dx := 0; dx < dw; dx++ {
+			n := (min(dx*factor+factor, width) - dx*factor) * (y2 - y1)
+			for c := 0; c < channels; c++ {
+				v := (sums[dx*channels+c] + n/2) / n
+				if wide {
+					dst[dx*bpp+c*2] = uint8(v >> 8)
+					dst[dx*bpp+c*2+1] = uint8(v)
+				} else {
+					dst[dx*bpp+c] = uint8(v)
+				}
+			}
+		}
+	}
+	return out, dw, dh
+}
+
+// SetMipmaps sets the mipmap chain, starting with the level of half the
+// image's size. Each level should be half the size of the previous one,
+// rounded up. Image and pattern drawing minified by more than a factor of
+// two samples the closest level; nil drops the levels.
+func (img *Image) SetMipmaps(levels []*Image) {
+	img.mips = levels
+}
+
+// Mipmaps returns the mipmap chain, without the image itself.
+func (img *Image) Mipmaps() []*Image {
+	return img.mips
+}
+
+// mipLevel returns the level to sample when mtx maps device to image
+// coordinates, with mtx adjusted to map to the level's coordinates. It picks
+// the smallest level with at least one pixel per device pixel along both
+// axes. Without mipmaps, or when not minifying, it returns img and mtx.
+func (img *Image) mipLevel(mtx *transform.TransAffine) (*Image, *transform.TransAffine) {
+	if len(img.mips) == 0 {
+		return img, mtx
+	}
+	sx, sy := mtx.GetScalingAbs()
+	n := int(math.Floor(math.Log2(min(sx, sy))))
+	if n < 1 {
+		return img, mtx
+	}
+	level := img.mips[min(n, len(img.mips))-1]
+	if level.width <= 0 || level.height <= 0 {
+		return img, mtx
+	}
+	m := mtx.Copy()
+	m.Multiply(transform.NewTransAffineScalingXY(
+		float64(level.width)/float64(img.width),
+		float64(level.height)/float64(img.height),
+	))
+	return level, m
+}
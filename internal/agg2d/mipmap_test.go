@@ -0,0 +1,60 @@
+package agg2d
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+func TestDownscaleBox(t *testing.T) {
+	// 3x2 gray: the right column forms a partial block.
+	pix := []uint8{
+		10, 30, 100,
+		50, 70, 200,
+	}
+	out, w, h := DownscaleBox(pix, 3, 2, 3, 1, false, 2)
+	if w != 2 || h != 1 {
+		t.Fatalf("size = %dx%d, want 2x1", w, h)
+	}
+	if out[0] != 40 || out[1] != 150 {
+		t.Errorf("out = %v, want [40 150]", out)
+	}
+
+	wide := []uint8{0x01, 0x00, 0x03, 0x00}
+	out, w, h = DownscaleBox(wide, 2, 1, 4, 1, true, 2)
+	if w != 1 || h != 1 || out[0] != 0x02 || out[1] != 0x00 {
+		t.Errorf("wide out = %v (%dx%d), want [2 0] (1x1)", out, w, h)
+	}
+}
+
+func TestMipLevel(t *testing.T) {
+	buf := make([]uint8, 8*4*4)
+	for i := 0; i < len(buf); i += 4 {
+		if (i/4)%2 == 0 {
+			buf[i], buf[i+3] = 255, 255
+		}
+	}
+	img := NewImage(buf, 8, 4, 8*4)
+	var mips []*Image
+	for level := img; level.Width() > 1 || level.Height() > 1; {
+		pix, w, h := DownscaleBox(level.Data, level.Width(), level.Height(), level.Width()*4, 4, false, 2)
+		level = NewImage(pix, w, h, w*4)
+		mips = append(mips, level)
+	}
+	img.SetMipmaps(mips)
+	if len(img.Mipmaps()) != 3 {
+		t.Fatalf("got %d levels, want 3", len(img.Mipmaps()))
+	}
+
+	// Shrinking by 5 picks the level of a quarter size.
+	level, mtx := img.mipLevel(transform.NewTransAffineScalingXY(5, 5))
+	if level != mips[1] {
+		t.Errorf("picked level of %dx%d, want 2x1", level.Width(), level.Height())
+	}
+	if x, _ := mtx.GetScalingAbs(); x != 5.0/4 {
+		t.Errorf("level scaling = %v, want 1.25", x)
+	}
+	if level, _ := img.mipLevel(transform.NewTransAffineScalingXY(1.5, 1.5)); level != img {
+		t.Error("mild minification should sample the image itself")
+	}
+}
@@ -0,0 +1,79 @@
+package agg2d
+
+import "github.com/MeKo-Christian/agg_go/internal/transform"
+
+// PaintState is a snapshot of the fill and line paints: colors, gradients,
+// patterns and their styles. Take one with PaintState and put it back with
+// SetPaintState to draw with temporary paints.
+type PaintState struct {
+	fill, line sidePaint
+}
+
+// sidePaint is the state of one of the two paints.
+type sidePaint struct {
+	color          Color
+	flag           Gradient
+	colors         [256]Color // legacy gradient colors
+	d1, d2         float64
+	gradientMatrix transform.TransAffine
+	patternMatrix  transform.TransAffine
+	gradient       *GradientPaint
+	pattern        *PatternPaint
+	style          paintStyle
+}
+
+// PaintState returns the current fill and line paints.
+func (agg2d *Agg2D) PaintState() PaintState {
+	return PaintState{
+		fill: sidePaint{
+			color:          agg2d.fillColor,
+			flag:           agg2d.fillGradientFlag,
+			colors:         agg2d.fillGradient,
+			d1:             agg2d.fillGradientD1,
+			d2:             agg2d.fillGradientD2,
+			gradientMatrix: *agg2d.fillGradientMatrix,
+			patternMatrix:  *agg2d.fillPatternMatrix,
+			gradient:       agg2d.fillGradientPaint,
+			pattern:        agg2d.fillPatternPaint,
+			style:          agg2d.fillStyle,
+		},
+		line: sidePaint{
+			color:          agg2d.lineColor,
+			flag:           agg2d.lineGradientFlag,
+			colors:         agg2d.lineGradient,
+			d1:             agg2d.lineGradientD1,
+			d2:             agg2d.lineGradientD2,
+			gradientMatrix: *agg2d.lineGradientMatrix,
+			patternMatrix:  *agg2d.linePatternMatrix,
+			gradient:       agg2d.lineGradientPaint,
+			pattern:        agg2d.linePatternPaint,
+			style:          agg2d.lineStyle,
+		},
+	}
+}
+
+// SetPaintState restores paints saved with PaintState. Gradients and
+// patterns keep the placement they had when the state was taken.
+func (agg2d *Agg2D) SetPaintState(s PaintState) {
+	agg2d.fillColor = s.fill.color
+	agg2d.fillGradientFlag = s.fill.flag
+	agg2d.fillGradient = s.fill.colors
+	agg2d.fillGradientLUTDirty = true
+	agg2d.fillGradientD1, agg2d.fillGradientD2 = s.fill.d1, s.fill.d2
+	*agg2d.fillGradientMatrix = s.fill.gradientMatrix
+	*agg2d.fillPatternMatrix = s.fill.patternMatrix
+	agg2d.fillGradientPaint = s.fill.gradient
+	agg2d.fillPatternPaint = s.fill.pattern
+	agg2d.fillStyle = s.fill.style
+
+	agg2d.lineColor = s.line.color
+	agg2d.lineGradientFlag = s.line.flag
+	agg2d.lineGradient = s.line.colors
+	agg2d.lineGradientLUTDirty = true
+	agg2d.lineGradientD1, agg2d.lineGradientD2 = s.line.d1, s.line.d2
+	*agg2d.lineGradientMatrix = s.line.gradientMatrix
+	*agg2d.linePatternMatrix = s.line.patternMatrix
+	agg2d.lineGradientPaint = s.line.gradient
+	agg2d.linePatternPaint = s.line.pattern
+	agg2d.lineStyle = s.line.style
+}
@@ -0,0 +1,105 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+)
+
+// BlendInherit, as the blend mode of a paint, draws the paint with the
+// context blend mode set by SetBlendMode.
+const BlendInherit BlendMode = -1
+
+// paintStyle is the opacity and blend mode of the installed fill or line
+// paint. Installing a new color, gradient or pattern resets it to
+// defaultPaintStyle.
+type paintStyle struct {
+	opacity float64 // multiplies the paint alpha, 0..1
+	blend   BlendMode
+}
+
+var defaultPaintStyle = paintStyle{opacity: 1, blend: BlendInherit}
+
+// SetFillPaintStyle sets the opacity (0..1) and blend mode of the installed
+// fill paint. Both apply on top of the master alpha; BlendInherit keeps the
+// context blend mode. The style lasts until the next fill color, gradient or
+// pattern is set.
+func (agg2d *Agg2D) SetFillPaintStyle(opacity float64, blend BlendMode) {
+	agg2d.fillStyle = paintStyle{opacity: math.Max(0, math.Min(1, opacity)), blend: blend}
+}
+
+// SetLinePaintStyle sets the opacity and blend mode of the installed line
+// paint. See SetFillPaintStyle.
+func (agg2d *Agg2D) SetLinePaintStyle(opacity float64, blend BlendMode) {
+	agg2d.lineStyle = paintStyle{opacity: math.Max(0, math.Min(1, opacity)), blend: blend}
+}
+
+// FillPaintStyle returns the opacity and blend mode of the fill paint.
+func (agg2d *Agg2D) FillPaintStyle() (opacity float64, blend BlendMode) {
+	return agg2d.fillStyle.opacity, agg2d.fillStyle.blend
+}
+
+// LinePaintStyle returns the opacity and blend mode of the line paint.
+func (agg2d *Agg2D) LinePaintStyle() (opacity float64, blend BlendMode) {
+	return agg2d.lineStyle.opacity, agg2d.lineStyle.blend
+}
+
+// alpha returns the opacity as a 0..255 coverage value.
+func (s paintStyle) alpha() uint8 {
+	return uint8(math.Round(s.opacity * 255))
+}
+
+// apply scales the alpha of a solid paint color by the opacity.
+func (s paintStyle) apply(c Color) Color {
+	if s.opacity < 1 {
+		c[3] = mulAlpha(c[3], s.alpha())
+	}
+	return c
+}
+
+// paintSpans wraps a gradient or pattern span generator with the opacity of
+// the fill or line paint. premultiplied tells whether gen emits premultiplied
+// colors.
+func (agg2d *Agg2D) paintSpans(gen renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]], fill, premultiplied bool) renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]] {
+	s := agg2d.lineStyle
+	if fill {
+		s = agg2d.fillStyle
+	}
+	if s.opacity >= 1 {
+		return gen
+	}
+	return span.NewSpanConverter[color.RGBA8[color.Linear]](gen, &opacityConverter{alpha: s.alpha(), premultiplied: premultiplied})
+}
+
+// usePaintBlend switches to the blend mode of a paint style for one draw and
+// returns the function restoring the context blend mode.
+func (agg2d *Agg2D) usePaintBlend(s paintStyle) func() {
+	if s.blend == BlendInherit || s.blend == agg2d.blendMode {
+		return func() {}
+	}
+	saved := agg2d.blendMode
+	agg2d.SetBlendMode(s.blend)
+	return func() { agg2d.SetBlendMode(saved) }
+}
+
+// opacityConverter scales span alpha by a constant.
+type opacityConverter struct {
+	alpha         uint8
+	premultiplied bool
+}
+
+func (c *opacityConverter) Prepare() {}
+
+func (c *opacityConverter) Generate(colors []color.RGBA8[color.Linear], x, y, length int) {
+	for i := range colors[:length] {
+		p := &colors[i]
+		if c.premultiplied {
+			p.R = mulAlpha(p.R, c.alpha)
+			p.G = mulAlpha(p.G, c.alpha)
+			p.B = mulAlpha(p.B, c.alpha)
+		}
+		p.A = mulAlpha(p.A, c.alpha)
+	}
+}
@@ -280,6 +280,7 @@ func (agg2d *Agg2D) Curve4RelSmoothTo(dxCtrl2, dyCtrl2, dxTo, dyTo float64) {
 func (agg2d *Agg2D) AddEllipse(cx, cy, rx, ry float64, dir Direction) {
 	// Use proper ellipse implementation from internal/shapes
 	ellipse := shapes.NewEllipseWithParams(cx, cy, rx, ry, 0, dir == CW)
+	ellipse.SetApproximationScale(agg2d.EffectiveApproximationScale())
 
 	// Rewind the ellipse to start generating vertices
 	ellipse.Rewind(0)
@@ -326,8 +327,7 @@ func (agg2d *Agg2D) DrawPath(flag DrawPathFlag) {
 		agg2d.renderStroke()
 	case FillAndStroke:
 		// Render both fill and stroke
-		agg2d.renderFill()
-		agg2d.renderStroke()
+		agg2d.renderFillAndStroke()
 	case FillWithLineColor:
 		// Render fill using line color
 		agg2d.renderFillWithLineColor()
@@ -0,0 +1,195 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// PatternPaint tiles an image over the plane. Like GradientPaint it holds no
+// per-context state; the image pixels are read at render time, as with
+// TransformImage, so they are taken to be premultiplied.
+type PatternPaint struct {
+	img *Image
+	// mtx maps pattern space to user space, like SVG patternTransform; nil
+	// means identity.
+	mtx *transform.TransAffine
+	// once draws a single tile, transparent around it, instead of
+	// repeating it; place maps the tile onto its rectangle.
+	once  bool
+	place *transform.TransAffine
+}
+
+// NewPatternPaint returns a pattern repeating img with its top-left corner
+// at the user-space origin.
+func NewPatternPaint(img *Image) *PatternPaint {
+	return &PatternPaint{img: img}
+}
+
+// NewImagePaint returns a paint drawing img once, stretched over the
+// rectangle (x1, y1)-(x2, y2) in user coordinates, and transparent outside
+// it.
+func NewImagePaint(img *Image, x1, y1, x2, y2 float64) *PatternPaint {
+	p := &PatternPaint{img: img, once: true}
+	if img != nil && img.Width() > 0 && img.Height() > 0 {
+		p.place = transform.NewTransAffineScalingXY(
+			(x2-x1)/float64(img.Width()),
+			(y2-y1)/float64(img.Height()),
+		)
+		p.place.Translate(x1, y1)
+	}
+	return p
+}
+
+// Repeats reports whether the image is tiled, rather than drawn once as by
+// NewImagePaint.
+func (p *PatternPaint) Repeats() bool {
+	return !p.once
+}
+
+// Image returns the pattern tile.
+func (p *PatternPaint) Image() *Image {
+	return p.img
+}
+
+// SetTransform sets the pattern transform, applied to the tile before the
+// context transform. A nil m resets it to identity. As with GradientPaint,
+// the transform is read when the paint is installed.
+func (p *PatternPaint) SetTransform(m *Transformations) {
+	if m == nil {
+		p.mtx = nil
+		return
+	}
+	p.mtx = transform.NewTransAffineFromArray(m.AffineMatrix)
+}
+
+// Transform returns the pattern transform.
+func (p *PatternPaint) Transform() *Transformations {
+	if p.mtx == nil {
+		return NewTransformations()
+	}
+	return &Transformations{AffineMatrix: [6]float64{p.mtx.SX, p.mtx.SHY, p.mtx.SHX, p.mtx.SY, p.mtx.TX, p.mtx.TY}}
+}
+
+// setup writes the device-to-pattern matrix for the transform mtx into dst.
+func (p *PatternPaint) setup(dst, mtx *transform.TransAffine) {
+	dst.Reset()
+	if p.place != nil {
+		dst.Multiply(p.place)
+	}
+	if p.mtx != nil {
+		dst.Multiply(p.mtx)
+	}
+	dst.Multiply(mtx)
+	dst.Invert()
+}
+
+// usable reports whether the pattern has pixels to tile.
+func (p *PatternPaint) usable() bool {
+	return p.img != nil && p.img.Data != nil && p.img.Width() > 0 && p.img.Height() > 0
+}
+
+// SetFillPattern installs p as the fill paint. The tile is placed with the
+// current transform, after the paint's own transform. A nil p, or one
+// without pixels, selects a solid fill.
+func (agg2d *Agg2D) SetFillPattern(p *PatternPaint) {
+	agg2d.fillStyle = defaultPaintStyle
+	if p == nil || !p.usable() {
+		agg2d.fillPatternPaint = nil
+		agg2d.fillGradientFlag = Solid
+		return
+	}
+	agg2d.fillPatternPaint = p
+	p.setup(agg2d.fillPatternMatrix, agg2d.transform)
+	agg2d.fillGradientFlag = Pattern
+}
+
+// SetLinePattern installs p as the line paint. See SetFillPattern.
+func (agg2d *Agg2D) SetLinePattern(p *PatternPaint) {
+	agg2d.lineStyle = defaultPaintStyle
+	if p == nil || !p.usable() {
+		agg2d.linePatternPaint = nil
+		agg2d.lineGradientFlag = Solid
+		return
+	}
+	agg2d.linePatternPaint = p
+	p.setup(agg2d.linePatternMatrix, agg2d.transform)
+	agg2d.lineGradientFlag = Pattern
+}
+
+// FillPatternPaint returns the installed fill pattern, or nil when the fill
+// is not a pattern.
+func (agg2d *Agg2D) FillPatternPaint() *PatternPaint {
+	if agg2d.fillGradientFlag != Pattern {
+		return nil
+	}
+	return agg2d.fillPatternPaint
+}
+
+// LinePatternPaint returns the installed line pattern. See FillPatternPaint.
+func (agg2d *Agg2D) LinePatternPaint() *PatternPaint {
+	if agg2d.lineGradientFlag != Pattern {
+		return nil
+	}
+	return agg2d.linePatternPaint
+}
+
+// renderPatternFill renders the rasterizer with the fill or line pattern.
+func (agg2d *Agg2D) renderPatternFill(useFillPattern bool) {
+	renderer := agg2d.currentImageRenderer()
+	if renderer == nil || agg2d.spanAllocator == nil {
+		return
+	}
+
+	var spanGenerator renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]]
+	if useFillPattern {
+		gen := newPatternSpanGenerator(agg2d.fillPatternPaint, agg2d.fillPatternMatrix)
+		spanGenerator = agg2d.maskFillSpans(gen, true)
+	} else {
+		spanGenerator = newPatternSpanGenerator(agg2d.linePatternPaint, agg2d.linePatternMatrix)
+	}
+	spanGenerator = agg2d.paintSpans(spanGenerator, useFillPattern, true)
+
+	renscan.RenderScanlinesAA(agg2d.rasterizer, agg2d.scanline, renderer, agg2d.spanAllocator, spanGenerator)
+}
+
+// patternSpanGenerator samples a pattern image, nearest neighbour.
+type patternSpanGenerator struct {
+	img          *Image
+	once         bool
+	interpolator *span.SpanInterpolatorLinear[*transform.TransAffine]
+}
+
+func newPatternSpanGenerator(p *PatternPaint, mtx *transform.TransAffine) *patternSpanGenerator {
+	img, mtx := p.img.mipLevel(mtx)
+	return &patternSpanGenerator{img: img, once: p.once, interpolator: span.NewSpanInterpolatorLinearDefault(mtx)}
+}
+
+func (g *patternSpanGenerator) Prepare() {}
+
+func (g *patternSpanGenerator) Generate(colors []color.RGBA8[color.Linear], x, y, length int) {
+	w, h := g.img.Width(), g.img.Height()
+	shift := g.interpolator.SubpixelShift()
+	g.interpolator.Begin(float64(x)+0.5, float64(y)+0.5, length)
+	for i := 0; i < length; i++ {
+		ix, iy := g.interpolator.Coordinates()
+		sx, sy := ix>>shift, iy>>shift
+		if !g.once {
+			sx, sy = wrapIndex(sx, w), wrapIndex(sy, h)
+		}
+		// GetPixel is transparent outside the image.
+		px := g.img.GetPixel(sx, sy)
+		colors[i] = color.RGBA8[color.Linear]{R: px[0], G: px[1], B: px[2], A: px[3]}
+		g.interpolator.Next()
+	}
+}
+
+// wrapIndex maps v into [0, n) with repeat wrapping.
+func wrapIndex(v, n int) int {
+	v %= n
+	if v < 0 {
+		v += n
+	}
+	return v
+}
@@ -0,0 +1,146 @@
+package agg2d
+
+import "testing"
+
+// newCheckerTile returns a 2x2 tile: red and blue on the top row, blue and
+// red below.
+func newCheckerTile() *Image {
+	red, blue := []byte{255, 0, 0, 255}, []byte{0, 0, 255, 255}
+	var data []byte
+	for _, px := range [][]byte{red, blue, blue, red} {
+		data = append(data, px...)
+	}
+	return NewImage(data, 2, 2, 2*4)
+}
+
+func TestFillPatternTiles(t *testing.T) {
+	ctx, buf := newGradientTarget()
+	ctx.SetFillPattern(NewPatternPaint(newCheckerTile()))
+	if ctx.FillGradientFlag() != Pattern {
+		t.Fatalf("fill gradient flag = %d, want Pattern", ctx.FillGradientFlag())
+	}
+	ctx.Rectangle(0, 0, 40, 20)
+
+	at := func(x, y int) []byte { return buf[(y*40+x)*4:][:3] }
+	for _, c := range []struct {
+		x, y int
+		red  bool
+	}{{0, 0, true}, {1, 0, false}, {0, 1, false}, {1, 1, true}, {30, 10, true}, {31, 10, false}, {7, 13, true}} {
+		p := at(c.x, c.y)
+		if isRed := p[0] == 255 && p[2] == 0; isRed != c.red {
+			t.Errorf("pixel (%d,%d) = %v, want red %v", c.x, c.y, p, c.red)
+		}
+	}
+
+	ctx.FillColor(Color{0, 255, 0, 255})
+	if ctx.FillGradientFlag() != Solid || ctx.FillPatternPaint() != nil {
+		t.Errorf("FillColor left flag %d, pattern %v; want a solid fill", ctx.FillGradientFlag(), ctx.FillPatternPaint())
+	}
+}
+
+func TestFillPatternFollowsTransform(t *testing.T) {
+	p := NewPatternPaint(newCheckerTile())
+	tr := NewTransformations()
+	tr.AffineMatrix = [6]float64{4, 0, 0, 4, 0, 0}
+	p.SetTransform(tr)
+
+	ctx, buf := newGradientTarget()
+	ctx.Translate(1, 0)
+	ctx.SetFillPattern(p)
+	ctx.Rectangle(0, 0, 40, 20)
+
+	// Tiles are 8x8 device pixels, shifted right by one.
+	red := func(x, y int) bool { px := buf[(y*40+x)*4:]; return px[0] == 255 && px[2] == 0 }
+	if !red(1, 0) || !red(4, 3) || red(5, 0) || red(1, 4) || !red(5, 4) || red(0, 0) {
+		t.Errorf("pattern not scaled by 4 and translated by 1")
+	}
+}
+
+func TestLinePaints(t *testing.T) {
+	ctx, buf := newGradientTarget()
+	ctx.LineWidth(6)
+	ctx.SetLinePattern(NewPatternPaint(newCheckerTile()))
+	if ctx.LineGradientFlag() != Pattern || ctx.LinePatternPaint() == nil {
+		t.Fatalf("line gradient flag = %d, want Pattern", ctx.LineGradientFlag())
+	}
+	ctx.Line(0, 10, 40, 10)
+	if p := buf[(10*40+20)*4:][:3]; p[1] != 0 || p[0]+p[2] != 255 {
+		t.Errorf("pattern stroke pixel = %v, want red or blue", p)
+	}
+
+	black, white := Color{0, 0, 0, 255}, Color{255, 255, 255, 255}
+	ctx, buf = newGradientTarget()
+	ctx.LineWidth(4)
+	ctx.SetLineGradient(NewConicGradientPaint(20, 10, 0, []GradientStop{{0, black}, {1, white}}))
+	if ctx.LineGradientFlag() != Conic {
+		t.Fatalf("line gradient flag = %d, want Conic", ctx.LineGradientFlag())
+	}
+	ctx.Line(0, 10, 40, 10)
+	// 3 o'clock is a quarter turn, 9 o'clock three quarters.
+	if right, left := buf[(10*40+38)*4], buf[(10*40+2)*4]; right < 52 || right > 76 || left < 179 || left > 203 {
+		t.Errorf("conic stroke: right %d, left %d, want ~64 and ~191", right, left)
+	}
+}
+
+func TestImagePaintDrawsOnce(t *testing.T) {
+	ctx, buf := newGradientTarget()
+	ctx.SetFillPattern(NewImagePaint(newCheckerTile(), 10, 0, 18, 8))
+	ctx.Rectangle(0, 0, 40, 20)
+
+	at := func(x, y int) []byte { return buf[(y*40+x)*4:][:3] }
+	// The 2x2 tile is stretched to 8x8 at (10, 0).
+	if p := at(11, 1); p[0] != 255 || p[2] != 0 {
+		t.Errorf("pixel (11,1) = %v, want red", p)
+	}
+	if p := at(15, 1); p[0] != 0 || p[2] != 255 {
+		t.Errorf("pixel (15,1) = %v, want blue", p)
+	}
+	for _, pt := range [][2]int{{5, 1}, {25, 1}, {11, 12}} {
+		if p := at(pt[0], pt[1]); p[0] != 255 || p[1] != 255 || p[2] != 255 {
+			t.Errorf("pixel %v = %v, want white outside the image", pt, p)
+		}
+	}
+}
+
+func TestPaintStyle(t *testing.T) {
+	ctx, buf := newGradientTarget()
+	ctx.FillColor(Color{0, 0, 0, 255})
+	ctx.SetFillPaintStyle(0.5, BlendInherit)
+	ctx.Rectangle(0, 0, 10, 20)
+	if v := buf[(5*40+5)*4]; v < 125 || v > 130 {
+		t.Errorf("half-opaque black over white = %d, want ~128", v)
+	}
+
+	// Opacity also applies to patterns.
+	ctx.SetFillPattern(NewPatternPaint(newCheckerTile()))
+	ctx.SetFillPaintStyle(0.5, BlendInherit)
+	ctx.Rectangle(10, 0, 20, 20)
+	if p := buf[(0*40+10)*4:][:3]; p[0] != 255 || p[1] < 125 || p[1] > 130 {
+		t.Errorf("half-opaque red pattern over white = %v, want (255, ~128, ~128)", p)
+	}
+
+	// A paint blend mode is used for its own draws only.
+	ctx.FillColor(Color{0, 0, 0, 255})
+	ctx.SetFillPaintStyle(1, BlendDst)
+	ctx.Rectangle(20, 0, 30, 20)
+	if p := buf[(5*40+25)*4:][:3]; p[0] != 255 {
+		t.Errorf("BlendDst paint changed the destination to %v", p)
+	}
+	if ctx.GetBlendMode() != BlendAlpha {
+		t.Errorf("context blend mode = %d after the draw, want BlendAlpha", ctx.GetBlendMode())
+	}
+
+	ctx.FillColor(Color{0, 0, 0, 255})
+	if o, b := ctx.FillPaintStyle(); o != 1 || b != BlendInherit {
+		t.Errorf("FillColor left paint style (%v, %d), want (1, BlendInherit)", o, b)
+	}
+
+	// Line paints carry their own style.
+	ctx.LineColor(Color{0, 0, 0, 255})
+	ctx.SetLinePaintStyle(0.5, BlendInherit)
+	ctx.LineWidth(4)
+	ctx.Line(30, 10, 40, 10)
+	if v := buf[(10*40+35)*4]; v < 125 || v > 130 {
+		t.Errorf("half-opaque black stroke over white = %d, want ~128", v)
+	}
+}
@@ -0,0 +1,115 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/primitives"
+)
+
+// SetPixelAccurateLines enables the pixel accuracy mode for hairlines. While
+// it is on, solid-color strokes at most one device pixel wide are drawn
+// aliased, as the pixels of the classic integer Bresenham algorithm (see
+// primitives.BresenhamLine) between the device pixels containing the
+// vertices, at full coverage. This reproduces the output of legacy software
+// drawing width-1 lines bit for bit, like the "regular accuracy" Bresenham
+// lines of AGG's rasterizers2 demo, where the anti-aliased stroke would
+// spread each line over two pixels. Curves are flattened first; caps, joins
+// and shortening do not apply, dashes do. Each segment is drawn without its
+// last pixel, so the vertex shared by consecutive segments is drawn once,
+// and open subpaths get their end point as well. Pixels where a
+// subpath crosses or retraces itself, or where subpaths overlap, are drawn
+// again, which shows with translucent colors.
+func (agg2d *Agg2D) SetPixelAccurateLines(on bool) {
+	agg2d.pixelAccurateLines = on
+}
+
+// GetPixelAccurateLines reports whether the pixel accuracy mode is enabled.
+func (agg2d *Agg2D) GetPixelAccurateLines() bool {
+	return agg2d.pixelAccurateLines
+}
+
+// useBresenhamStroke reports whether the current stroke is drawn as
+// Bresenham lines.
+func (agg2d *Agg2D) useBresenhamStroke() bool {
+	return agg2d.pixelAccurateLines && agg2d.lineGradientFlag == Solid &&
+		agg2d.lineWidth*agg2d.transform.GetScale() <= 1
+}
+
+// renderBresenhamStroke draws the current path as aliased Bresenham lines in
+// the line color.
+func (agg2d *Agg2D) renderBresenhamStroke() {
+	var src conv.VertexSource = agg2d.convCurve
+	if agg2d.convDash != nil && agg2d.convDash.NumDashes() > 0 {
+		agg2d.convDash.Shorten(0)
+		agg2d.convDash.Attach(agg2d.convCurve)
+		src = agg2d.convDash
+	}
+
+	defer agg2d.usePaintBlend(agg2d.lineStyle)()
+	c := agg2d.lineStyle.apply(agg2d.lineColor)
+	masterAlpha := uint8(agg2d.masterAlpha * 255.0)
+	lineColor := color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: uint8((uint16(c[3]) * uint16(masterAlpha)) / 255)}
+	var ren interface {
+		BlendPixel(x, y int, c color.RGBA8[color.Linear], cover basics.Int8u)
+	}
+	if r := agg2d.currentRenderer(); r != nil {
+		ren = r.rendererBase()
+	}
+	full := []basics.Int8u{basics.CoverFull}
+	plot := func(x, y int) {
+		if agg2d.coverageFunc != nil {
+			agg2d.coverageFunc(x, y, full)
+		}
+		if agg2d.measuring {
+			agg2d.measureRect(x, y, x+1, y+1)
+		} else if ren != nil {
+			ren.BlendPixel(x, y, lineColor, basics.CoverFull)
+		}
+	}
+
+	// Pixel (x, y) contains the points [x, x+1) x [y, y+1).
+	device := conv.NewConvTransform(src, agg2d.transform)
+	device.Rewind(0)
+	var startX, startY, prevX, prevY int
+	open := false
+	finish := func() {
+		if open {
+			plot(prevX, prevY)
+		}
+		open = false
+	}
+	for {
+		fx, fy, cmd := device.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		x, y := int(math.Floor(fx)), int(math.Floor(fy))
+		switch {
+		case basics.IsMoveTo(cmd):
+			finish()
+			startX, startY, prevX, prevY = x, y, x, y
+			open = true
+		case basics.IsVertex(cmd):
+			if !open {
+				startX, startY, prevX, prevY = x, y, x, y
+				open = true
+				continue
+			}
+			if x != prevX || y != prevY {
+				primitives.BresenhamLine(prevX, prevY, x, y, false, plot)
+			}
+			prevX, prevY = x, y
+		case basics.IsEndPoly(cmd) && basics.IsClosed(uint32(cmd)):
+			if open {
+				if prevX != startX || prevY != startY {
+					primitives.BresenhamLine(prevX, prevY, startX, startY, false, plot)
+				}
+				open = false
+			}
+		}
+	}
+	finish()
+}
@@ -0,0 +1,58 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
+	"github.com/MeKo-Christian/agg_go/internal/renderer"
+)
+
+// PixelOrder is the byte order of the channels of 32-bit pixels, both in the
+// attached buffer and in images drawn from. The rendering pipeline works in
+// RGBA colors either way; the order only decides where each channel is stored,
+// so a BGRA buffer from a window system can be drawn into without conversion.
+type PixelOrder int
+
+const (
+	OrderRGBA PixelOrder = iota // R, G, B, A
+	OrderBGRA                   // B, G, R, A, as used by X11, SDL and Windows surfaces
+	OrderARGB                   // A, R, G, B
+	OrderABGR                   // A, B, G, R
+)
+
+// colorOrder returns the channel offsets of the order.
+func (o PixelOrder) colorOrder() color.ColorOrder {
+	switch o {
+	case OrderBGRA:
+		return color.OrderBGRA
+	case OrderARGB:
+		return color.OrderARGB
+	case OrderABGR:
+		return color.OrderABGR
+	}
+	return color.OrderRGBA
+}
+
+// SetOrder sets the byte order of the image's pixels. It only changes how
+// the bytes are read; the data is left as is.
+func (img *Image) SetOrder(o PixelOrder) {
+	img.order = o
+}
+
+// Order returns the byte order of the image's pixels.
+func (img *Image) Order() PixelOrder {
+	return img.order
+}
+
+// PixelOrder returns the byte order of the attached buffer.
+func (agg2d *Agg2D) PixelOrder() PixelOrder {
+	return agg2d.pixelOrder
+}
+
+// targetPixfmt is a pixel format over the attached buffer, in any order.
+type targetPixfmt = renderer.PixelFormat[color.RGBA8[color.Linear]]
+
+// compositePixfmt is a targetPixfmt with a switchable compositing operation.
+type compositePixfmt interface {
+	targetPixfmt
+	SetCompOp(op blender.CompOp)
+}
@@ -0,0 +1,149 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// pixelSnapEpsilon is how far, in device pixels, a segment may deviate from
+// horizontal or vertical and still be snapped.
+const pixelSnapEpsilon = 1e-3
+
+// SetPixelSnapping enables grid fitting of axis-aligned geometry. Horizontal
+// and vertical fill edges are moved to whole device pixels, and horizontal and
+// vertical stroke centerlines to pixel centers for odd device widths (whole
+// pixels for even widths), so thin UI lines render crisp instead of smeared
+// across two pixel rows. Slanted segments and curves are left alone.
+func (agg2d *Agg2D) SetPixelSnapping(on bool) {
+	agg2d.pixelSnapping = on
+}
+
+// GetPixelSnapping reports whether pixel snapping is enabled.
+func (agg2d *Agg2D) GetPixelSnapping() bool {
+	return agg2d.pixelSnapping
+}
+
+// strokeSnapSource returns src snapped for stroking with the current line
+// width. Snapping happens in device space; when the stroke is built in user
+// space the snapped points are mapped back through the inverse transform.
+func (agg2d *Agg2D) strokeSnapSource(src conv.VertexSource) conv.VertexSource {
+	if !agg2d.pixelSnapping {
+		return src
+	}
+	offset := 0.0
+	if w := math.Round(agg2d.lineWidth * agg2d.transform.GetScale()); math.Mod(w, 2) == 1 {
+		offset = 0.5
+	}
+	return &pixelSnapper{source: src, mtx: agg2d.transform, offset: offset}
+}
+
+// fillSnapSource returns device-space src snapped to whole pixels.
+func (agg2d *Agg2D) fillSnapSource(src conv.VertexSource) conv.VertexSource {
+	if !agg2d.pixelSnapping {
+		return src
+	}
+	return &pixelSnapper{source: src}
+}
+
+type snapVertex struct {
+	x, y         float64
+	cmd          basics.PathCommand
+	snapX, snapY bool
+}
+
+// pixelSnapper moves the vertices of horizontal and vertical segments onto
+// the pixel grid. mtx maps source coordinates to device space; nil means the
+// source is already in device space.
+type pixelSnapper struct {
+	source   conv.VertexSource
+	mtx      *transform.TransAffine
+	offset   float64
+	vertices []snapVertex
+	index    int
+}
+
+func (s *pixelSnapper) Rewind(pathID uint) {
+	s.source.Rewind(pathID)
+	s.vertices = s.vertices[:0]
+	s.index = 0
+	start := 0
+	for {
+		x, y, cmd := s.source.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		if basics.IsMoveTo(cmd) {
+			s.markAxisAligned(start, false)
+			start = len(s.vertices)
+		}
+		if basics.IsVertex(cmd) && s.mtx != nil {
+			s.mtx.Transform(&x, &y)
+		}
+		s.vertices = append(s.vertices, snapVertex{x: x, y: y, cmd: cmd})
+		if basics.IsEndPoly(cmd) && basics.IsClosed(uint32(cmd)) {
+			s.markAxisAligned(start, true)
+			start = len(s.vertices)
+		}
+	}
+	s.markAxisAligned(start, false)
+
+	for i := range s.vertices {
+		v := &s.vertices[i]
+		if !basics.IsVertex(v.cmd) {
+			continue
+		}
+		if v.snapX {
+			v.x = s.snap(v.x)
+		}
+		if v.snapY {
+			v.y = s.snap(v.y)
+		}
+		if s.mtx != nil {
+			s.mtx.InverseTransform(&v.x, &v.y)
+		}
+	}
+}
+
+func (s *pixelSnapper) snap(v float64) float64 {
+	if s.offset == 0 {
+		return math.Round(v)
+	}
+	return math.Floor(v) + s.offset
+}
+
+// markAxisAligned flags the vertices of axis-aligned segments in the
+// sub-path starting at index start.
+func (s *pixelSnapper) markAxisAligned(start int, closed bool) {
+	var idx []int
+	for i := start; i < len(s.vertices); i++ {
+		if basics.IsVertex(s.vertices[i].cmd) {
+			idx = append(idx, i)
+		}
+	}
+	n := len(idx)
+	segs := n - 1
+	if closed && n > 2 {
+		segs = n
+	}
+	for k := 0; k < segs; k++ {
+		a, b := &s.vertices[idx[k]], &s.vertices[idx[(k+1)%n]]
+		if math.Abs(a.x-b.x) < pixelSnapEpsilon {
+			a.snapX, b.snapX = true, true
+		}
+		if math.Abs(a.y-b.y) < pixelSnapEpsilon {
+			a.snapY, b.snapY = true, true
+		}
+	}
+}
+
+func (s *pixelSnapper) Vertex() (x, y float64, cmd basics.PathCommand) {
+	if s.index >= len(s.vertices) {
+		return 0, 0, basics.PathCmdStop
+	}
+	v := s.vertices[s.index]
+	s.index++
+	return v.x, v.y, v.cmd
+}
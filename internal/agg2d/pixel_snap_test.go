@@ -0,0 +1,89 @@
+package agg2d
+
+import "testing"
+
+func newSnapTestContext(snap bool) (*Agg2D, []byte) {
+	ctx := NewAgg2D()
+	buf := make([]byte, 40*40*4)
+	ctx.Attach(buf, 40, 40, 40*4)
+	ctx.ClearAll(Color{0, 0, 0, 0})
+	ctx.SetPixelSnapping(snap)
+	return ctx, buf
+}
+
+func alphaColumn(buf []byte, x int) []uint8 {
+	col := make([]uint8, 40)
+	for y := range col {
+		_, _, _, col[y] = pixelAt(buf, 40, x, y)
+	}
+	return col
+}
+
+func TestPixelSnappingCrispHairline(t *testing.T) {
+	for _, snap := range []bool{false, true} {
+		ctx, buf := newSnapTestContext(snap)
+		ctx.LineColor(Black)
+		ctx.LineWidth(1)
+		ctx.LineCap(CapButt)
+		ctx.Line(5, 10, 35, 10)
+
+		col := alphaColumn(buf, 20)
+		if snap {
+			if col[10] != 255 || col[9] != 0 || col[11] != 0 {
+				t.Errorf("snapped hairline should fill one row, got %v", col[8:13])
+			}
+		} else if col[9] == 0 || col[10] == 0 || col[10] == 255 {
+			t.Errorf("unsnapped hairline on a pixel boundary should straddle two rows, got %v", col[8:13])
+		}
+	}
+}
+
+func TestPixelSnappingEvenWidthUnderScale(t *testing.T) {
+	ctx, buf := newSnapTestContext(true)
+	ctx.Scale(2, 2)
+	ctx.LineColor(Black)
+	ctx.LineWidth(1)
+	ctx.LineCap(CapButt)
+	ctx.Line(2, 5.3, 18, 5.3)
+
+	// A 2px device line snaps to whole pixels: rows 10 and 11.
+	col := alphaColumn(buf, 20)
+	if col[10] != 255 || col[11] != 255 || col[9] != 0 || col[12] != 0 {
+		t.Errorf("2px line should cover rows 10-11 exactly, got %v", col[8:14])
+	}
+}
+
+func TestPixelSnappingFillEdges(t *testing.T) {
+	ctx, buf := newSnapTestContext(true)
+	ctx.FillColor(Black)
+	ctx.NoLine()
+	ctx.Rectangle(10.3, 10.3, 20.6, 20.6)
+
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 40; x++ {
+			_, _, _, a := pixelAt(buf, 40, x, y)
+			inside := x >= 10 && x < 21 && y >= 10 && y < 21
+			if (inside && a != 255) || (!inside && a != 0) {
+				t.Fatalf("pixel (%d,%d) alpha %d, want crisp edges at 10 and 21", x, y, a)
+			}
+		}
+	}
+
+	// Slanted edges are not snapped.
+	ctx.ClearAll(Color{0, 0, 0, 0})
+	ctx.ResetPath()
+	ctx.MoveTo(5, 5)
+	ctx.LineTo(35, 8)
+	ctx.LineTo(20, 35)
+	ctx.ClosePolygon()
+	ctx.DrawPath(FillOnly)
+	partial := 0
+	for i := 3; i < len(buf); i += 4 {
+		if buf[i] != 0 && buf[i] != 255 {
+			partial++
+		}
+	}
+	if partial == 0 {
+		t.Error("slanted edges should keep their anti-aliasing")
+	}
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/MeKo-Christian/agg_go/internal/color"
 	"github.com/MeKo-Christian/agg_go/internal/conv"
 	aggimage "github.com/MeKo-Christian/agg_go/internal/image"
+	"github.com/MeKo-Christian/agg_go/internal/path"
 	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
 	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/span"
 	"github.com/MeKo-Christian/agg_go/internal/transform"
 )
 
@@ -70,7 +72,7 @@ func (agg2d *Agg2D) renderFill() {
 	}
 
 	// Create transformed curve converter
-	transformedPath := conv.NewConvTransform(agg2d.convCurve, agg2d.transform)
+	transformedPath := agg2d.fillSnapSource(conv.NewConvTransform(agg2d.convCurve, agg2d.transform))
 
 	// Add path vertices to rasterizer
 	transformedPath.Rewind(0)
@@ -83,6 +85,10 @@ func (agg2d *Agg2D) renderFill() {
 	}
 
 	// Render with appropriate color/gradient
+	if agg2d.reportCoverage() {
+		return
+	}
+	defer agg2d.usePaintBlend(agg2d.fillStyle)()
 	if agg2d.fillGradientFlag == Solid {
 		agg2d.renderSolidFill()
 	} else {
@@ -90,12 +96,53 @@ func (agg2d *Agg2D) renderFill() {
 	}
 }
 
+// renderFillAndStroke renders the fill of the current path and then its
+// stroke. Curves are flattened only once: both passes read the flattened
+// outline instead of subdividing every curve again.
+func (agg2d *Agg2D) renderFillAndStroke() {
+	hasCurves := false
+	for i := uint(0); i < agg2d.path.TotalVertices() && !hasCurves; i++ {
+		hasCurves = basics.IsCurve(basics.PathCommand(agg2d.path.Command(i)))
+	}
+	if !hasCurves {
+		agg2d.renderFill()
+		agg2d.renderStroke()
+		return
+	}
+
+	if agg2d.flatPath == nil {
+		agg2d.flatPath = path.NewPathStorageStl()
+	}
+	flat := agg2d.flatPath
+	flat.RemoveAll()
+	agg2d.convCurve.Rewind(0)
+	for {
+		x, y, cmd := agg2d.convCurve.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		flat.Vertices().AddVertex(x, y, uint32(cmd))
+	}
+
+	// The dash and stroke converters read convCurve, which passes the
+	// flattened outline through unchanged.
+	agg2d.convCurve.Attach(path.NewPathStorageStlVertexSourceAdapter(flat))
+	defer agg2d.convCurve.Attach(path.NewPathStorageStlVertexSourceAdapter(agg2d.path))
+	agg2d.renderFill()
+	agg2d.renderStroke()
+}
+
 // renderStroke renders the current path as a stroked outline
 func (agg2d *Agg2D) renderStroke() {
 	if agg2d.rasterizer == nil || agg2d.path == nil || agg2d.convStroke == nil || agg2d.scanline == nil {
 		return
 	}
 
+	if agg2d.useBresenhamStroke() {
+		agg2d.renderBresenhamStroke()
+		return
+	}
+
 	// Reset rasterizer for new path
 	agg2d.rasterizer.Reset()
 
@@ -106,13 +153,32 @@ func (agg2d *Agg2D) renderStroke() {
 	// stroke convCurve directly. This matches AGG C++ which uses separate
 	// conv_stroke and conv_stroke<conv_dash> pipelines: when no dashes are set,
 	// the plain conv_stroke<conv_curve> is used rather than the dashed one.
-	if agg2d.convDash != nil && agg2d.convDash.NumDashes() == 0 {
-		agg2d.addStrokeToRasterizer(conv.NewConvStroke(agg2d.convCurve))
-	} else {
-		agg2d.addStrokeToRasterizer(agg2d.convStroke)
+	// Shortening applies to the source path, so with dashes it is done by the
+	// dash generator instead of trimming every dash.
+	src := agg2d.strokeSnapSource(agg2d.convCurve)
+	switch {
+	case agg2d.convDash == nil:
+		agg2d.convStroke.SetShorten(agg2d.shorten)
+		agg2d.convStroke.Attach(src)
+		agg2d.addStrokeToRasterizer(agg2d.convStroke, agg2d.lineCap)
+		agg2d.convStroke.Attach(agg2d.convCurve)
+	case agg2d.convDash.NumDashes() == 0:
+		stroke := conv.NewConvStroke(src)
+		stroke.SetShorten(agg2d.shorten)
+		agg2d.addStrokeToRasterizer(stroke, agg2d.lineCap)
+	default:
+		agg2d.convDash.Shorten(agg2d.shorten)
+		agg2d.convStroke.SetShorten(0)
+		agg2d.convDash.Attach(src)
+		agg2d.addStrokeToRasterizer(agg2d.convStroke, agg2d.GetDashCap())
+		agg2d.convDash.Attach(agg2d.convCurve)
 	}
 
 	// Render with appropriate color/gradient
+	if agg2d.reportCoverage() {
+		return
+	}
+	defer agg2d.usePaintBlend(agg2d.lineStyle)()
 	if agg2d.lineGradientFlag == Solid {
 		agg2d.renderSolidStroke()
 	} else {
@@ -120,12 +186,21 @@ func (agg2d *Agg2D) renderStroke() {
 	}
 }
 
-// addStrokeToRasterizer applies the given stroke converter (with current settings)
-// through the world transform and feeds vertices into the rasterizer.
-func (agg2d *Agg2D) addStrokeToRasterizer(stroke *conv.ConvStroke) {
+// addStrokeToRasterizer applies the given stroke converter (with current settings
+// and the given cap) through the world transform and feeds vertices into the
+// rasterizer.
+func (agg2d *Agg2D) addStrokeToRasterizer(stroke *conv.ConvStroke, lineCap LineCap) {
 	stroke.SetWidth(agg2d.lineWidth)
-	stroke.SetLineCap(basics.LineCap(agg2d.lineCap))
-	stroke.SetLineJoin(basics.LineJoin(agg2d.lineJoin))
+	stroke.SetLineCap(basics.LineCap(lineCap))
+	stroke.SetLineJoin(strokeLineJoin(agg2d.lineJoin))
+	if stroke != agg2d.convStroke {
+		// Undashed strokes use a fresh converter; carry over the settings
+		// that only live on convStroke.
+		stroke.SetMiterLimit(agg2d.convStroke.MiterLimit())
+		stroke.SetInnerMiterLimit(agg2d.convStroke.InnerMiterLimit())
+		stroke.SetInnerJoin(agg2d.convStroke.InnerJoin())
+		stroke.SetApproximationScale(agg2d.convStroke.ApproximationScale())
+	}
 	strokeSource := conv.NewConvTransform(stroke, agg2d.transform)
 	strokeSource.Rewind(0)
 	for {
@@ -154,7 +229,7 @@ func (agg2d *Agg2D) renderFillWithLineColor() {
 	}
 
 	// Create transformed curve converter
-	transformedPath := conv.NewConvTransform(agg2d.convCurve, agg2d.transform)
+	transformedPath := agg2d.fillSnapSource(conv.NewConvTransform(agg2d.convCurve, agg2d.transform))
 
 	// Add path vertices to rasterizer
 	transformedPath.Rewind(0)
@@ -167,8 +242,12 @@ func (agg2d *Agg2D) renderFillWithLineColor() {
 	}
 
 	// Render using line color instead of fill color
+	if agg2d.reportCoverage() {
+		return
+	}
+	defer agg2d.usePaintBlend(agg2d.lineStyle)()
 	if agg2d.lineGradientFlag == Solid {
-		agg2d.renderSolidFillWithColor(agg2d.lineColor)
+		agg2d.renderSolidFillWithColor(agg2d.lineStyle.apply(agg2d.lineColor))
 	} else {
 		agg2d.renderGradientFillWithLineGradient()
 	}
@@ -176,7 +255,13 @@ func (agg2d *Agg2D) renderFillWithLineColor() {
 
 // renderSolidFill renders solid fill using current fill color
 func (agg2d *Agg2D) renderSolidFill() {
-	agg2d.renderSolidFillWithColor(agg2d.fillColor)
+	c := agg2d.fillStyle.apply(agg2d.fillColor)
+	if agg2d.fillAlphaMask != nil {
+		a := uint8(uint16(c[3]) * uint16(uint8(agg2d.masterAlpha*255.0)) / 255)
+		agg2d.renderMaskedSolidFill(color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: a})
+		return
+	}
+	agg2d.renderSolidFillWithColor(c)
 }
 
 // RenderRasterizerWithColor renders whatever is currently accumulated in the rasterizer
@@ -214,12 +299,13 @@ func (agg2d *Agg2D) renderSolidStroke() {
 		return
 	}
 
-	// Apply master alpha to line color
+	// Apply paint opacity and master alpha to line color
+	c := agg2d.lineStyle.apply(agg2d.lineColor)
 	masterAlpha := uint8(agg2d.masterAlpha * 255.0)
-	adjustedAlpha := uint8((uint16(agg2d.lineColor[3]) * uint16(masterAlpha)) / 255)
+	adjustedAlpha := uint8((uint16(c[3]) * uint16(masterAlpha)) / 255)
 
 	// Convert Color to internal color format with master alpha applied
-	internalColor := color.RGBA8[color.Linear]{R: agg2d.lineColor[0], G: agg2d.lineColor[1], B: agg2d.lineColor[2], A: adjustedAlpha}
+	internalColor := color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: adjustedAlpha}
 
 	// Create solid renderer
 	renSolid := renscan.NewRendererScanlineAASolidWithColor(renderer, internalColor)
@@ -235,6 +321,12 @@ func (agg2d *Agg2D) renderGradientFill() {
 		agg2d.renderLinearGradientFill(true) // true = use fill gradient settings
 	case Radial:
 		agg2d.renderRadialGradientFill(true) // true = use fill gradient settings
+	case Conic:
+		agg2d.renderConicGradientFill(true)
+	case Contour, Field:
+		agg2d.renderFieldGradientFill(true)
+	case Pattern:
+		agg2d.renderPatternFill(true)
 	default:
 		// Solid fill fallback
 		agg2d.renderSolidFill()
@@ -264,20 +356,25 @@ func (agg2d *Agg2D) renderLinearGradientFill(useFillGradient bool) {
 
 	var spanGenerator renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]]
 	if useFillGradient {
-		agg2d.refreshFillGradientLUTIfDirty()
+		agg2d.fillLinearSpanGenerator.SetColorFunction(agg2d.fillColorFunction())
 		agg2d.fillLinearSpanInterpolator.SetTransformer(gradientMatrix)
 		agg2d.fillLinearSpanGenerator.SetD1(d1)
 		agg2d.fillLinearSpanGenerator.SetD2(d2)
-		spanGenerator = agg2d.fillLinearSpanGenerator
+		spanGenerator = agg2d.maskFillSpans(agg2d.fillLinearSpanGenerator, false)
 	} else {
-		agg2d.refreshLineGradientLUTIfDirty()
+		agg2d.lineLinearSpanGenerator.SetColorFunction(agg2d.lineColorFunction())
 		agg2d.lineLinearSpanInterpolator.SetTransformer(gradientMatrix)
 		agg2d.lineLinearSpanGenerator.SetD1(d1)
 		agg2d.lineLinearSpanGenerator.SetD2(d2)
 		spanGenerator = agg2d.lineLinearSpanGenerator
 	}
 
+	if dithered := agg2d.ditheredGradientSpans(span.GradientLinearX{}, useFillGradient); dithered != nil {
+		spanGenerator = dithered
+	}
+
 	// Render scanlines using the span generator directly
+	spanGenerator = agg2d.paintSpans(spanGenerator, useFillGradient, false)
 	renscan.RenderScanlinesAA(agg2d.rasterizer, agg2d.scanline, renderer, agg2d.spanAllocator, spanGenerator)
 }
 
@@ -304,20 +401,54 @@ func (agg2d *Agg2D) renderRadialGradientFill(useFillGradient bool) {
 
 	var spanGenerator renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]]
 	if useFillGradient {
-		agg2d.refreshFillGradientLUTIfDirty()
+		agg2d.fillRadialSpanGenerator.SetColorFunction(agg2d.fillColorFunction())
 		agg2d.fillRadialSpanInterpolator.SetTransformer(gradientMatrix)
 		agg2d.fillRadialSpanGenerator.SetD1(d1)
 		agg2d.fillRadialSpanGenerator.SetD2(d2)
-		spanGenerator = agg2d.fillRadialSpanGenerator
+		spanGenerator = agg2d.maskFillSpans(agg2d.fillRadialSpanGenerator, false)
 	} else {
-		agg2d.refreshLineGradientLUTIfDirty()
+		agg2d.lineRadialSpanGenerator.SetColorFunction(agg2d.lineColorFunction())
 		agg2d.lineRadialSpanInterpolator.SetTransformer(gradientMatrix)
 		agg2d.lineRadialSpanGenerator.SetD1(d1)
 		agg2d.lineRadialSpanGenerator.SetD2(d2)
 		spanGenerator = agg2d.lineRadialSpanGenerator
 	}
 
+	if dithered := agg2d.ditheredGradientSpans(span.GradientRadial{}, useFillGradient); dithered != nil {
+		spanGenerator = dithered
+	}
+
 	// Render scanlines using the span generator directly
+	spanGenerator = agg2d.paintSpans(spanGenerator, useFillGradient, false)
+	renscan.RenderScanlinesAA(agg2d.rasterizer, agg2d.scanline, renderer, agg2d.spanAllocator, spanGenerator)
+}
+
+// renderConicGradientFill renders conic (sweep) gradient fill
+func (agg2d *Agg2D) renderConicGradientFill(useFillGradient bool) {
+	renderer := agg2d.currentRenderer()
+	if renderer == nil || agg2d.spanAllocator == nil {
+		return
+	}
+
+	var spanGenerator renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]]
+	if useFillGradient {
+		agg2d.fillConicSpanGenerator.SetColorFunction(agg2d.fillColorFunction())
+		agg2d.fillConicSpanInterpolator.SetTransformer(agg2d.fillGradientMatrix)
+		agg2d.fillConicSpanGenerator.SetD1(agg2d.fillGradientD1)
+		agg2d.fillConicSpanGenerator.SetD2(agg2d.fillGradientD2)
+		spanGenerator = agg2d.maskFillSpans(agg2d.fillConicSpanGenerator, false)
+	} else {
+		agg2d.lineConicSpanGenerator.SetColorFunction(agg2d.lineColorFunction())
+		agg2d.lineConicSpanInterpolator.SetTransformer(agg2d.lineGradientMatrix)
+		agg2d.lineConicSpanGenerator.SetD1(agg2d.lineGradientD1)
+		agg2d.lineConicSpanGenerator.SetD2(agg2d.lineGradientD2)
+		spanGenerator = agg2d.lineConicSpanGenerator
+	}
+	if dithered := agg2d.ditheredGradientSpans(span.GradientSweep{}, useFillGradient); dithered != nil {
+		spanGenerator = dithered
+	}
+
+	spanGenerator = agg2d.paintSpans(spanGenerator, useFillGradient, false)
 	renscan.RenderScanlinesAA(agg2d.rasterizer, agg2d.scanline, renderer, agg2d.spanAllocator, spanGenerator)
 }
 
@@ -328,6 +459,12 @@ func (agg2d *Agg2D) renderGradientStroke() {
 		agg2d.renderLinearGradientFill(false) // false = use line gradient settings
 	case Radial:
 		agg2d.renderRadialGradientFill(false) // false = use line gradient settings
+	case Conic:
+		agg2d.renderConicGradientFill(false)
+	case Contour, Field:
+		agg2d.renderFieldGradientFill(false)
+	case Pattern:
+		agg2d.renderPatternFill(false)
 	default:
 		// Solid stroke fallback
 		agg2d.renderSolidStroke()
@@ -341,18 +478,28 @@ func (agg2d *Agg2D) renderGradientFillWithLineGradient() {
 		agg2d.renderLinearGradientFill(false) // false = use line gradient settings
 	case Radial:
 		agg2d.renderRadialGradientFill(false) // false = use line gradient settings
+	case Conic:
+		agg2d.renderConicGradientFill(false)
+	case Contour, Field:
+		agg2d.renderFieldGradientFill(false)
+	case Pattern:
+		agg2d.renderPatternFill(false)
 	default:
 		// Solid fill fallback using line color
-		agg2d.renderSolidFillWithColor(agg2d.lineColor)
+		agg2d.renderSolidFillWithColor(agg2d.lineStyle.apply(agg2d.lineColor))
 	}
 }
 
 // RenderScanlinesAAWithSpanGen renders the rasterizer using a custom span generator.
 // This enables advanced effects like combining color gradients with alpha gradients.
 func (agg2d *Agg2D) RenderScanlinesAAWithSpanGen(
-	ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip],
+	ras *rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlClip[int, rasterizer.RasConvInt]],
 	spanGen renscan.SpanGeneratorInterface[color.RGBA8[color.Linear]],
 ) {
+	if agg2d.measuring {
+		agg2d.measureSweep(ras, agg2d.scanline)
+		return
+	}
 	renderer := agg2d.currentRenderer()
 	if renderer == nil || agg2d.spanAllocator == nil {
 		return
@@ -364,6 +511,10 @@ func (agg2d *Agg2D) RenderScanlinesAAWithSpanGen(
 func (agg2d *Agg2D) scanlineRender(renderer renscan.RendererInterface[color.RGBA8[color.Linear]]) {
 	ras := agg2d.rasterizer
 	sl := agg2d.scanline
+	if agg2d.measuring {
+		agg2d.measureSweep(ras, sl)
+		return
+	}
 
 	if !ras.RewindScanlines() {
 		return
@@ -380,21 +531,31 @@ func (agg2d *Agg2D) scanlineRender(renderer renscan.RendererInterface[color.RGBA
 // updateApproximationScales updates the approximation scale for curve converters
 // based on the current transformation matrix scaling
 func (agg2d *Agg2D) updateApproximationScales() {
+	scale := agg2d.EffectiveApproximationScale()
 	if agg2d.convCurve != nil {
-		// Use the world-to-screen scaling factor with the global approximation scale
-		scale := agg2d.WorldToScreenScalar(1.0) * ApproxScale
-
-		// Update curve approximation scale
 		agg2d.convCurve.SetApproximationScale(scale)
 	}
-
 	if agg2d.convStroke != nil {
-		// Also update the stroke converter with the same scale for consistency
-		scale := agg2d.WorldToScreenScalar(1.0) * ApproxScale
 		agg2d.convStroke.SetApproximationScale(scale)
 	}
 }
 
+// EffectiveApproximationScale returns the approximation scale used to flatten
+// curves, arcs and round joins: the largest stretch of the current transform
+// times the ApproximationScale factor. Taking the largest stretch keeps curves
+// smooth along the most magnified axis of non-uniform or skewed transforms.
+func (agg2d *Agg2D) EffectiveApproximationScale() float64 {
+	t := agg2d.transform
+	// Largest singular value of the linear part of the matrix.
+	sq := t.SX*t.SX + t.SHX*t.SHX + t.SHY*t.SHY + t.SY*t.SY
+	det := t.Determinant()
+	stretch := math.Sqrt((sq + math.Sqrt(math.Max(sq*sq-4*det*det, 0))) / 2)
+	if stretch <= 0 || math.IsNaN(stretch) || math.IsInf(stretch, 0) {
+		stretch = 1.0
+	}
+	return stretch * agg2d.approxScale
+}
+
 // render is the main rendering method that handles both fill and stroke colors
 func (agg2d *Agg2D) render(fillColor bool) {
 	if fillColor {
@@ -406,11 +567,16 @@ func (agg2d *Agg2D) render(fillColor bool) {
 
 // updateRasterizerGamma updates the rasterizer gamma correction
 func (agg2d *Agg2D) updateRasterizerGamma() {
+	agg2d.setRasterizerGamma(agg2d.antiAliasGamma)
+}
+
+// setRasterizerGamma installs the coverage function for gamma and the current
+// master alpha on the rasterizer.
+func (agg2d *Agg2D) setRasterizerGamma(gamma float64) {
 	if agg2d.rasterizer == nil {
 		return
 	}
 
-	gamma := agg2d.antiAliasGamma
 	alpha := agg2d.masterAlpha
 	gammaFunc := func(x float64) float64 {
 		if x <= 0.0 {
@@ -444,7 +610,17 @@ func (agg2d *Agg2D) LineCap(lineCap LineCap) {
 func (agg2d *Agg2D) LineJoin(join LineJoin) {
 	agg2d.lineJoin = join
 	if agg2d.convStroke != nil {
-		agg2d.convStroke.SetLineJoin(basics.LineJoin(join))
+		agg2d.convStroke.SetLineJoin(strokeLineJoin(join))
+	}
+}
+
+// strokeLineJoin maps an Agg2D join onto the stroker's join styles.
+func strokeLineJoin(join LineJoin) basics.LineJoin {
+	switch join {
+	case JoinMiterClip, JoinArcs:
+		return basics.MiterJoin
+	default:
+		return basics.LineJoin(join)
 	}
 }
 
@@ -452,6 +628,7 @@ func (agg2d *Agg2D) LineJoin(join LineJoin) {
 func (agg2d *Agg2D) ResetTransformations() {
 	if agg2d.transform != nil {
 		agg2d.transform.Reset()
+		agg2d.updateApproximationScales()
 	}
 }
 
@@ -530,12 +707,30 @@ func (agg2d *Agg2D) ImageResample(r ImageResample) {
 	agg2d.imageResample = r
 }
 
+// ImageEdge sets what image drawing samples beyond the source rectangle.
+// It matters where filters reach past the edges and where TransformImagePath
+// fills a path larger than the image, which EdgeRepeat and EdgeReflect tile.
+func (agg2d *Agg2D) ImageEdge(e ImageEdge) {
+	agg2d.imageEdge = e
+}
+
+// ImageBackgroundColor sets the color EdgeColor samples outside the source
+// rectangle. It defaults to transparent.
+func (agg2d *Agg2D) ImageBackgroundColor(c Color) {
+	agg2d.imageBackground = c
+}
+
 // TextAlignment sets text alignment.
 func (agg2d *Agg2D) TextAlignment(alignX, alignY TextAlignment) {
 	agg2d.textAlignX = alignX
 	agg2d.textAlignY = alignY
 }
 
+// GetTextAlignment returns the horizontal and vertical text alignment.
+func (agg2d *Agg2D) GetTextAlignment() (alignX, alignY TextAlignment) {
+	return agg2d.textAlignX, agg2d.textAlignY
+}
+
 // GetLineWidth returns the current line width
 func (agg2d *Agg2D) GetLineWidth() float64 {
 	return agg2d.lineWidth
@@ -561,6 +756,16 @@ func (agg2d *Agg2D) GetImageResample() ImageResample {
 	return agg2d.imageResample
 }
 
+// GetImageEdge returns the current image edge mode
+func (agg2d *Agg2D) GetImageEdge() ImageEdge {
+	return agg2d.imageEdge
+}
+
+// GetImageBackgroundColor returns the image background color
+func (agg2d *Agg2D) GetImageBackgroundColor() Color {
+	return agg2d.imageBackground
+}
+
 // GetMasterAlpha returns the current master alpha value
 func (agg2d *Agg2D) GetMasterAlpha() float64 {
 	return agg2d.masterAlpha
@@ -593,6 +798,34 @@ func (agg2d *Agg2D) SetAntiAliasGamma(gamma float64) {
 	agg2d.updateRasterizerGamma()
 }
 
+// SetTextGamma sets whether the anti-alias gamma also applies to text. It is
+// enabled by default; disabling it draws glyphs with linear coverage so text
+// weight stays constant while shapes are tuned with SetAntiAliasGamma.
+func (agg2d *Agg2D) SetTextGamma(enabled bool) {
+	agg2d.textGamma = enabled
+}
+
+// GetTextGamma reports whether the anti-alias gamma applies to text.
+func (agg2d *Agg2D) GetTextGamma() bool {
+	return agg2d.textGamma
+}
+
+// glyphGammaTable returns the coverage table for bitmap glyphs, or nil when
+// glyph coverage is used unchanged.
+func (agg2d *Agg2D) glyphGammaTable() *[256]uint8 {
+	gamma := agg2d.antiAliasGamma
+	if !agg2d.textGamma || gamma == 1.0 || gamma <= 0 {
+		return nil
+	}
+	if agg2d.textGammaFor != gamma {
+		for i := range agg2d.textGammaLUT {
+			agg2d.textGammaLUT[i] = uint8(basics.URound(255 * math.Pow(float64(i)/255, 1.0/gamma)))
+		}
+		agg2d.textGammaFor = gamma
+	}
+	return &agg2d.textGammaLUT
+}
+
 // Math helpers local to the rendering package.
 func cos(x float64) float64 {
 	return math.Cos(x)
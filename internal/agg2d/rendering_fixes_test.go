@@ -6,6 +6,8 @@ import (
 	"math"
 	"testing"
 
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
 	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
 )
 
@@ -63,6 +65,50 @@ func TestAttachResetsRasterizerGamma(t *testing.T) {
 	}
 }
 
+func TestTextGammaAppliesToGlyphCoverage(t *testing.T) {
+	render := func(gamma float64, textGamma bool) uint8 {
+		agg2d := NewAgg2D()
+		buf := make([]byte, 4*4*4)
+		agg2d.Attach(buf, 4, 4, 4*4)
+		agg2d.ClearAll(Color{0, 0, 0, 0})
+		agg2d.FillColor(Color{255, 0, 0, 255})
+		agg2d.SetAntiAliasGamma(gamma)
+		agg2d.SetTextGamma(textGamma)
+
+		bounds := basics.Rect[int]{X1: 1, Y1: 1, X2: 2, Y2: 2}
+		adaptor := font.NewSerializedScanlinesAdaptorAA([]byte{64}, bounds)
+		agg2d.renderGlyphScanlines(adaptor, &font.GlyphCache{DataType: font.GlyphDataGray8}, 0, 0)
+		_, _, _, a := pixelAt(buf, 4, 1, 1)
+		return a
+	}
+
+	linear := render(1.0, true)
+	if got := render(2.0, true); got <= linear {
+		t.Fatalf("gamma 2.0 should thicken glyph coverage: got alpha %d, linear %d", got, linear)
+	}
+	if got := render(2.0, false); got != linear {
+		t.Fatalf("disabled text gamma should keep linear coverage: got alpha %d, want %d", got, linear)
+	}
+}
+
+func TestTextGammaDisabledRestoresRasterizerGamma(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]uint8, 32*32*4)
+	agg2d.Attach(buf, 32, 32, 32*4)
+	agg2d.fontCacheManager = font.NewFontCacheManager(newMockTextFontEngine(), 32)
+	agg2d.fontCacheType = VectorFontCache
+
+	if !agg2d.GetTextGamma() {
+		t.Fatal("text gamma should be enabled by default")
+	}
+	agg2d.SetAntiAliasGamma(2.0)
+	agg2d.SetTextGamma(false)
+	agg2d.Text(4, 20, "A", false, 0, 0)
+	if got := agg2d.rasterizer.ApplyGamma(128); got == 128 {
+		t.Fatal("Text should restore the anti-alias gamma for shapes")
+	}
+}
+
 func TestMasterAlpha(t *testing.T) {
 	// Create AGG2D instance
 	agg2d := NewAgg2D()
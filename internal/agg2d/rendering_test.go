@@ -266,3 +266,37 @@ func TestBlendDstPreservesDestinationForRenderedPaths(t *testing.T) {
 		t.Fatalf("BlendDst pixel = (%d,%d,%d,%d), want unchanged destination", r, g, b, a)
 	}
 }
+
+// TestFillAndStrokeSharesFlattenedCurves checks that FillAndStroke, which
+// flattens curves once for both passes, paints what a fill and a stroke of
+// the path do, and leaves the path in place.
+func TestFillAndStrokeSharesFlattenedCurves(t *testing.T) {
+	draw := func(flags ...DrawPathFlag) []uint8 {
+		agg2d := NewAgg2D()
+		buf := make([]uint8, 64*64*4)
+		agg2d.Attach(buf, 64, 64, 64*4)
+		agg2d.ClearAll(White)
+		agg2d.FillColor(NewColor(0, 0, 255, 160))
+		agg2d.LineColor(NewColor(255, 0, 0, 128))
+		agg2d.LineWidth(6)
+		agg2d.ResetPath()
+		agg2d.MoveTo(8, 32)
+		agg2d.CubicCurveTo(8, 0, 56, 0, 56, 32)
+		agg2d.QuadricCurveTo(32, 64, 8, 32)
+		agg2d.ClosePolygon()
+		for _, flag := range flags {
+			agg2d.DrawPath(flag)
+		}
+		if n := agg2d.path.TotalVertices(); n != 7 {
+			t.Fatalf("path has %d vertices after drawing, want 7", n)
+		}
+		return buf
+	}
+
+	got, want := draw(FillAndStroke), draw(FillOnly, StrokeOnly)
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("byte %d = %d, want %d as for a fill and a stroke", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/font/freetype"
+)
+
+// RichTextRun is one styled piece of a paragraph drawn with RichText. Zero
+// fields keep the setting the Agg2D has when the paragraph is drawn.
+type RichTextRun struct {
+	Text string
+	// Font is the font file of the run. It has no effect with the GSV font,
+	// which has a single typeface.
+	Font string
+	// Size is the font height of the run.
+	Size float64
+	// Color is the fill color the run is drawn with.
+	Color *Color
+	// Decoration adds lines to those set with SetTextDecoration.
+	Decoration TextDecoration
+}
+
+// fontSelection is the font a paragraph switches away from and back to.
+type fontSelection struct {
+	file    string
+	height  float64
+	loaded  bool
+	engine  *freetype.FontEngineFreetype
+	manager *font.FontCacheManager
+}
+
+func (agg2d *Agg2D) fontSelection() fontSelection {
+	return fontSelection{
+		file:    agg2d.fontFile,
+		height:  agg2d.fontHeight,
+		loaded:  agg2d.fontLoaded,
+		engine:  agg2d.fontEngine,
+		manager: agg2d.fontCacheManager,
+	}
+}
+
+// MeasureRichText returns the advance of the paragraph and the largest
+// ascent and descent of its runs, in the units of TextWidth.
+func (agg2d *Agg2D) MeasureRichText(runs []RichTextRun) (width, ascent, descent float64, err error) {
+	base := agg2d.fontSelection()
+	layouts, err := agg2d.layoutRichText(base, runs)
+	if rerr := agg2d.restoreFont(base); err == nil {
+		err = rerr
+	}
+	for _, l := range layouts {
+		width += l.Width()
+		ascent = max(ascent, l.Ascent())
+		descent = max(descent, l.Descent())
+	}
+	return width, ascent, descent, err
+}
+
+// RichText draws runs one after another on a common baseline. The
+// paragraph as a whole follows the text alignment: x aligns its advance and
+// AlignTop and AlignCenter use the largest ascent of its runs. The font,
+// fill and decoration are restored afterwards, also when a run's font
+// fails to load, in which case nothing is drawn.
+func (agg2d *Agg2D) RichText(x, y float64, runs []RichTextRun) error {
+	base := agg2d.fontSelection()
+	layouts, err := agg2d.layoutRichText(base, runs)
+	if err != nil {
+		agg2d.restoreFont(base)
+		return err
+	}
+	width, ascent, up := 0.0, 0.0, -1.0
+	for _, l := range layouts {
+		width += l.Width()
+		ascent = max(ascent, l.Ascent())
+		if l.up != 0 {
+			up = l.up
+		}
+	}
+
+	paints, decoration := agg2d.PaintState(), agg2d.textDecoration
+	alignX, alignY := agg2d.textAlignX, agg2d.textAlignY
+	switch alignX {
+	case AlignCenter:
+		x -= width * 0.5
+	case AlignRight:
+		x -= width
+	}
+	switch alignY {
+	case AlignCenter:
+		y -= up * ascent * 0.5
+	case AlignTop:
+		y -= up * ascent
+	}
+
+	agg2d.TextAlignment(AlignLeft, AlignBottom)
+	for i, run := range runs {
+		if err = agg2d.selectRunFont(base, run); err != nil {
+			break
+		}
+		if run.Color != nil {
+			agg2d.FillColor(*run.Color)
+		}
+		agg2d.SetTextDecoration(decoration | run.Decoration)
+		agg2d.Text(x, y, run.Text, false, 0, 0)
+		x += layouts[i].Width()
+		agg2d.SetPaintState(paints)
+	}
+	agg2d.SetTextDecoration(decoration)
+	agg2d.TextAlignment(alignX, alignY)
+	if rerr := agg2d.restoreFont(base); err == nil {
+		err = rerr
+	}
+	return err
+}
+
+// layoutRichText lays out every run in its own font.
+func (agg2d *Agg2D) layoutRichText(base fontSelection, runs []RichTextRun) ([]*TextLayout, error) {
+	layouts := make([]*TextLayout, 0, len(runs))
+	for _, run := range runs {
+		if err := agg2d.selectRunFont(base, run); err != nil {
+			return layouts, err
+		}
+		layouts = append(layouts, agg2d.TextLayout(run.Text))
+	}
+	return layouts, nil
+}
+
+// selectRunFont switches to the font and size of run, falling back to those
+// of base. A new size for the loaded face only reconfigures the engine.
+func (agg2d *Agg2D) selectRunFont(base fontSelection, run RichTextRun) error {
+	file, height := base.file, base.height
+	if run.Font != "" {
+		file = run.Font
+	}
+	if run.Size > 0 {
+		height = run.Size
+	}
+	if agg2d.gsvFontMode {
+		if height != agg2d.fontHeight {
+			agg2d.FontGSV(height)
+		}
+		return nil
+	}
+	if file != agg2d.fontFile || file != "" && !agg2d.fontLoaded {
+		return agg2d.loadFont(file, height, agg2d.fontCacheType, agg2d.textAngle)
+	}
+	if height != agg2d.fontHeight {
+		agg2d.fontHeight = height
+		if agg2d.fontEngine != nil && agg2d.fontLoaded {
+			agg2d.configureFontEngine(agg2d.fontEngine)
+			return agg2d.syncFallbackFonts()
+		}
+	}
+	return nil
+}
+
+// restoreFont switches back to the font of base. Without a font file that
+// loaded, text goes back to the bitmap fallback it used before.
+func (agg2d *Agg2D) restoreFont(base fontSelection) error {
+	if !agg2d.gsvFontMode && !base.loaded {
+		if agg2d.fontEngine != base.engine {
+			// loadFont created the engine for the paragraph.
+			_ = agg2d.fontEngine.Close()
+		}
+		agg2d.fontFile, agg2d.fontHeight, agg2d.fontLoaded = base.file, base.height, false
+		agg2d.fontEngine, agg2d.fontCacheManager = base.engine, base.manager
+		return nil
+	}
+	return agg2d.selectRunFont(base, RichTextRun{})
+}
@@ -0,0 +1,34 @@
+package agg2d
+
+import "testing"
+
+func TestRichTextMeasuresAndRestoresState(t *testing.T) {
+	agg2d := newShaperTestAgg2D()
+	agg2d.FillColor(Color{1, 2, 3, 255})
+	agg2d.TextAlignment(AlignRight, AlignTop)
+	red := Color{255, 0, 0, 255}
+	runs := []RichTextRun{
+		{Text: "a"},
+		{Text: "aא", Color: &red, Decoration: DecorationUnderline},
+	}
+
+	width, _, _, err := agg2d.MeasureRichText(runs)
+	if err != nil || width != 7 {
+		t.Fatalf("MeasureRichText width=%v err=%v, want 7", width, err)
+	}
+	if err := agg2d.RichText(10, 10, runs); err != nil {
+		t.Fatalf("RichText: %v", err)
+	}
+	if agg2d.fillColor != (Color{1, 2, 3, 255}) {
+		t.Fatalf("fill color %v not restored", agg2d.fillColor)
+	}
+	if agg2d.textDecoration != DecorationNone {
+		t.Fatalf("decoration %v not restored", agg2d.textDecoration)
+	}
+	if x, y := agg2d.GetTextAlignment(); x != AlignRight || y != AlignTop {
+		t.Fatalf("alignment %v/%v not restored", x, y)
+	}
+	if agg2d.fontCacheManager == nil {
+		t.Fatal("font cache manager dropped")
+	}
+}
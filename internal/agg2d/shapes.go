@@ -60,6 +60,7 @@ func (agg2d *Agg2D) RoundedRectVariableRadii(x1, y1, x2, y2, rxBottom, ryBottom,
 	roundedRect := shapes.NewRoundedRectEmpty()
 	roundedRect.SetRect(x1, y1, x2, y2)
 	roundedRect.SetRadiusBottomTop(rxBottom, ryBottom, rxTop, ryTop)
+	roundedRect.SetApproximationScale(agg2d.EffectiveApproximationScale())
 
 	agg2d.ResetPath()
 
@@ -116,6 +117,7 @@ func (agg2d *Agg2D) FillCircle(cx, cy, radius float64) {
 func (agg2d *Agg2D) Arc(cx, cy, rx, ry, start, sweep float64) {
 	// Use proper arc implementation from internal/shapes
 	arc := shapes.NewArcWithParams(cx, cy, rx, ry, start, start+sweep, true) // ccw=true for positive sweep
+	arc.SetApproximationScale(agg2d.EffectiveApproximationScale())
 
 	agg2d.ResetPath()
 
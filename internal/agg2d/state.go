@@ -0,0 +1,200 @@
+package agg2d
+
+import aggimage "github.com/MeKo-Christian/agg_go/internal/image"
+
+// State is a snapshot of the drawing state: paints, transform, clip box,
+// blend modes, alpha and gamma, stroke, fill rule, image and text
+// attributes and the coverage func. It is the counterpart of the C++ Agg2D helpers that save the
+// renderer state to a struct and restore it from one, and lets callers run
+// user code between drawing calls without it leaking state changes.
+//
+// The path, the attached buffer, the font file and the transform stack are
+// not part of the state; the height, angle and rendering options of the
+// font are.
+type State struct {
+	paints    PaintState
+	transform Transformations
+	clipBox   struct{ X1, Y1, X2, Y2 float64 }
+
+	blendMode       BlendMode
+	imageBlendMode  BlendMode
+	imageBlendColor Color
+	masterAlpha     float64
+	antiAliasGamma  float64
+	lockedChannels  ChannelMask
+	gradientDither  bool
+	fillAlphaPaint  *GradientPaint
+	fillAlphaMask   *alphaGradientConverter
+
+	stroke     StrokeAttributes
+	innerJoin  InnerJoin
+	dashCap    LineCap
+	dashCapSet bool
+
+	evenOddFlag        bool
+	autoClose          bool
+	pixelSnapping      bool
+	pixelAccurateLines bool
+	coverageFunc       CoverageFunc
+
+	imageFilter     ImageFilter
+	imageFilterLUT  *aggimage.ImageFilterLUT
+	imageResample   ImageResample
+	imageEdge       ImageEdge
+	imageBackground Color
+
+	textAlignX     TextAlignment
+	textAlignY     TextAlignment
+	textHints      bool
+	textKerning    bool
+	textLigatures  bool
+	textDecoration TextDecoration
+	textGamma      bool
+	flipText       bool
+
+	textAngle      float64
+	fontHeight     float64
+	fontCacheType  FontCacheType
+	hintingMode    HintingMode
+	glyphRendering GlyphRendering
+	subpixelOrder  SubpixelOrder
+	subpixelPhases int
+}
+
+// State returns the current drawing state.
+func (agg2d *Agg2D) State() State {
+	return State{
+		paints:    agg2d.PaintState(),
+		transform: *agg2d.GetTransformations(),
+		clipBox:   agg2d.clipBox,
+
+		blendMode:       agg2d.blendMode,
+		imageBlendMode:  agg2d.imageBlendMode,
+		imageBlendColor: agg2d.imageBlendColor,
+		masterAlpha:     agg2d.masterAlpha,
+		antiAliasGamma:  agg2d.antiAliasGamma,
+		lockedChannels:  agg2d.lockedChannels,
+		gradientDither:  agg2d.gradientDither,
+		fillAlphaPaint:  agg2d.fillAlphaPaint,
+		fillAlphaMask:   agg2d.fillAlphaMask,
+
+		stroke:     agg2d.GetStrokeAttributes(),
+		innerJoin:  agg2d.GetInnerJoin(),
+		dashCap:    agg2d.dashCap,
+		dashCapSet: agg2d.dashCapSet,
+
+		evenOddFlag:        agg2d.evenOddFlag,
+		autoClose:          agg2d.autoClose,
+		pixelSnapping:      agg2d.pixelSnapping,
+		pixelAccurateLines: agg2d.pixelAccurateLines,
+		coverageFunc:       agg2d.coverageFunc,
+
+		imageFilter:     agg2d.imageFilter,
+		imageFilterLUT:  copyFilterLUT(agg2d.imageFilterLUT),
+		imageResample:   agg2d.imageResample,
+		imageEdge:       agg2d.imageEdge,
+		imageBackground: agg2d.imageBackground,
+
+		textAlignX:     agg2d.textAlignX,
+		textAlignY:     agg2d.textAlignY,
+		textHints:      agg2d.textHints,
+		textKerning:    agg2d.textKerning,
+		textLigatures:  agg2d.textLigatures,
+		textDecoration: agg2d.textDecoration,
+		textGamma:      agg2d.textGamma,
+		flipText:       agg2d.flipText,
+
+		textAngle:      agg2d.textAngle,
+		fontHeight:     agg2d.fontHeight,
+		fontCacheType:  agg2d.fontCacheType,
+		hintingMode:    agg2d.hintingMode,
+		glyphRendering: agg2d.glyphRendering,
+		subpixelOrder:  agg2d.subpixelOrder,
+		subpixelPhases: agg2d.subpixelPhases,
+	}
+}
+
+// SetState re-applies a state taken with State. The image filter table is
+// restored as it was, including a radius set with SetImageFilterRadius. The
+// current font is reconfigured, or reloaded when the glyph rendering
+// changes; errors doing so are ignored, as the face loaded before.
+func (agg2d *Agg2D) SetState(s State) {
+	tr := s.transform
+	agg2d.SetTransformations(&tr)
+	agg2d.ClipBox(s.clipBox.X1, s.clipBox.Y1, s.clipBox.X2, s.clipBox.Y2)
+
+	agg2d.SetBlendMode(s.blendMode)
+	agg2d.SetImageBlendMode(s.imageBlendMode)
+	agg2d.SetImageBlendColor(s.imageBlendColor)
+	agg2d.masterAlpha = s.masterAlpha
+	agg2d.SetAntiAliasGamma(s.antiAliasGamma)
+	agg2d.lockedChannels = s.lockedChannels
+	agg2d.SetGradientDithering(s.gradientDither)
+
+	// Like the paints, the alpha mask keeps the placement it had when the
+	// state was taken.
+	agg2d.SetPaintState(s.paints)
+	agg2d.fillAlphaPaint, agg2d.fillAlphaMask = s.fillAlphaPaint, s.fillAlphaMask
+
+	stroke := s.stroke
+	agg2d.SetStrokeAttributes(&stroke)
+	agg2d.InnerJoin(s.innerJoin)
+	agg2d.dashCap, agg2d.dashCapSet = s.dashCap, s.dashCapSet
+
+	agg2d.FillEvenOdd(s.evenOddFlag)
+	agg2d.SetAutoClose(s.autoClose)
+	agg2d.SetPixelSnapping(s.pixelSnapping)
+	agg2d.SetPixelAccurateLines(s.pixelAccurateLines)
+	agg2d.coverageFunc = s.coverageFunc
+
+	agg2d.imageFilter = s.imageFilter
+	agg2d.imageFilterLUT = copyFilterLUT(s.imageFilterLUT)
+	agg2d.ImageResample(s.imageResample)
+	agg2d.ImageEdge(s.imageEdge)
+	agg2d.ImageBackgroundColor(s.imageBackground)
+
+	agg2d.TextAlignment(s.textAlignX, s.textAlignY)
+	if s.textHints != agg2d.textHints {
+		agg2d.TextHints(s.textHints)
+	}
+	agg2d.TextKerning(s.textKerning)
+	agg2d.TextLigatures(s.textLigatures)
+	agg2d.SetTextDecoration(s.textDecoration)
+	agg2d.SetTextGamma(s.textGamma)
+	agg2d.FlipText(s.flipText)
+	agg2d.setFontState(s)
+}
+
+// setFontState applies the font settings of s to the current font.
+func (agg2d *Agg2D) setFontState(s State) {
+	reload := s.glyphRendering != agg2d.glyphRendering || s.fontCacheType != agg2d.fontCacheType
+	changed := reload || s.fontHeight != agg2d.fontHeight ||
+		s.hintingMode != agg2d.hintingMode || s.subpixelOrder != agg2d.subpixelOrder
+	agg2d.textAngle = s.textAngle
+	agg2d.hintingMode = s.hintingMode
+	agg2d.glyphRendering = s.glyphRendering
+	agg2d.subpixelOrder = s.subpixelOrder
+	agg2d.subpixelPhases = s.subpixelPhases
+	if !changed {
+		return
+	}
+	switch {
+	case agg2d.gsvFontMode:
+		agg2d.FontGSV(s.fontHeight)
+	case reload && agg2d.fontLoaded:
+		_ = agg2d.loadFont(agg2d.fontFile, s.fontHeight, s.fontCacheType, s.textAngle)
+	default:
+		agg2d.fontHeight, agg2d.fontCacheType = s.fontHeight, s.fontCacheType
+		if agg2d.fontEngine != nil && agg2d.fontLoaded {
+			agg2d.configureFontEngine(agg2d.fontEngine)
+			_ = agg2d.syncFallbackFonts()
+		}
+	}
+}
+
+func copyFilterLUT(lut *aggimage.ImageFilterLUT) *aggimage.ImageFilterLUT {
+	if lut == nil {
+		return nil
+	}
+	return lut.Copy()
+}
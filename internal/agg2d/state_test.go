@@ -0,0 +1,103 @@
+package agg2d
+
+import "testing"
+
+func TestStateRoundTrip(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]byte, 32*32*4)
+	agg2d.Attach(buf, 32, 32, 32*4)
+
+	agg2d.FillColor(Color{255, 0, 0, 255})
+	agg2d.LineWidth(3)
+	agg2d.LineCap(CapRound)
+	agg2d.AddDash(4, 2)
+	agg2d.DashStart(1)
+	agg2d.Translate(5, 6)
+	agg2d.ClipBox(2, 2, 20, 20)
+	agg2d.SetBlendMode(BlendMultiply)
+	agg2d.SetMasterAlpha(0.5)
+	agg2d.FillEvenOdd(true)
+	agg2d.TextAlignment(AlignCenter, AlignTop)
+	agg2d.SetImageFilterRadius(Lanczos, 2)
+	agg2d.FontGSV(12)
+	agg2d.textAngle = 0.5
+	agg2d.hintingMode = HintingAuto
+	agg2d.glyphRendering = GlyphLCD
+	agg2d.subpixelOrder = SubpixelBGR
+	agg2d.subpixelPhases = 4
+	covered := 0
+	agg2d.SetCoverageFunc(func(_, _ int, covers []uint8) { covered += len(covers) })
+	saved := agg2d.State()
+
+	agg2d.FillColor(Color{0, 0, 255, 255})
+	agg2d.LineWidth(1)
+	agg2d.LineCap(CapButt)
+	agg2d.RemoveAllDashes()
+	agg2d.ResetTransformations()
+	agg2d.Scale(2, 2)
+	agg2d.ClipBox(0, 0, 31, 31)
+	agg2d.SetBlendMode(BlendAlpha)
+	agg2d.SetMasterAlpha(1)
+	agg2d.FillEvenOdd(false)
+	agg2d.TextAlignment(AlignLeft, AlignBottom)
+	agg2d.ImageFilter(Bicubic)
+	agg2d.FontGSV(30)
+	agg2d.textAngle = 0
+	agg2d.hintingMode = HintingNative
+	agg2d.glyphRendering = GlyphGray8
+	agg2d.subpixelOrder = SubpixelRGB
+	agg2d.subpixelPhases = 0
+	agg2d.SetCoverageFunc(nil)
+	agg2d.SetState(saved)
+
+	if got := agg2d.GetFillColor(); got != (Color{255, 0, 0, 255}) {
+		t.Errorf("fill color = %v", got)
+	}
+	if agg2d.GetLineWidth() != 3 || agg2d.GetLineCap() != CapRound {
+		t.Errorf("line width %v cap %v, want 3 and CapRound", agg2d.GetLineWidth(), agg2d.GetLineCap())
+	}
+	if agg2d.DashPatternLength() != 6 || agg2d.GetDashStart() != 1 {
+		t.Errorf("dash length %v start %v, want 6 and 1", agg2d.DashPatternLength(), agg2d.GetDashStart())
+	}
+	if m := agg2d.GetTransformations().AffineMatrix; m != [6]float64{1, 0, 0, 1, 5, 6} {
+		t.Errorf("transform = %v", m)
+	}
+	if x1, y1, x2, y2 := agg2d.GetClipBox(); x1 != 2 || y1 != 2 || x2 != 20 || y2 != 20 {
+		t.Errorf("clip box = %v %v %v %v", x1, y1, x2, y2)
+	}
+	if agg2d.GetBlendMode() != BlendMultiply || agg2d.GetMasterAlpha() != 0.5 {
+		t.Errorf("blend mode %v master alpha %v", agg2d.GetBlendMode(), agg2d.GetMasterAlpha())
+	}
+	if !agg2d.GetFillEvenOdd() {
+		t.Error("fill rule not restored to even-odd")
+	}
+	if x, y := agg2d.GetTextAlignment(); x != AlignCenter || y != AlignTop {
+		t.Errorf("text alignment = %v %v", x, y)
+	}
+	if agg2d.GetImageFilter() != Lanczos || agg2d.imageFilterLUT.Radius() != 2 {
+		t.Errorf("image filter %v radius %v, want Lanczos and 2", agg2d.GetImageFilter(), agg2d.imageFilterLUT.Radius())
+	}
+	if agg2d.FontHeight() != 12 || agg2d.textAngle != 0.5 {
+		t.Errorf("font height %v angle %v, want 12 and 0.5", agg2d.FontHeight(), agg2d.textAngle)
+	}
+	if agg2d.GetHintingMode() != HintingAuto || agg2d.GetGlyphRendering() != GlyphLCD {
+		t.Errorf("hinting %v rendering %v, want HintingAuto and GlyphLCD", agg2d.GetHintingMode(), agg2d.GetGlyphRendering())
+	}
+	if agg2d.subpixelOrder != SubpixelBGR || agg2d.GetSubpixelPositions() != 4 {
+		t.Errorf("subpixel order %v positions %v, want BGR and 4", agg2d.subpixelOrder, agg2d.GetSubpixelPositions())
+	}
+	agg2d.ResetTransformations()
+	agg2d.ClipBox(0, 0, 31, 31)
+	agg2d.Rectangle(0, 0, 4, 4)
+	if covered == 0 {
+		t.Error("coverage func not restored")
+	}
+
+	// The saved filter table is a copy; changing the filter again must not
+	// alter what the state restores.
+	agg2d.ImageFilter(Bicubic)
+	agg2d.SetState(saved)
+	if agg2d.imageFilterLUT.Radius() != 2 {
+		t.Errorf("filter radius %v after a second restore, want 2", agg2d.imageFilterLUT.Radius())
+	}
+}
@@ -3,6 +3,9 @@
 package agg2d
 
 import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/conv"
 	"github.com/MeKo-Christian/agg_go/internal/path"
 )
@@ -43,6 +46,23 @@ func (agg2d *Agg2D) InnerMiterLimit(ml float64) {
 	}
 }
 
+// InnerJoin sets how the inside of corners is joined. AGG's default is
+// InnerMiter; InnerRound and InnerJag reduce artifacts on thick strokes with
+// short segments.
+func (agg2d *Agg2D) InnerJoin(ij InnerJoin) {
+	if agg2d.convStroke != nil {
+		agg2d.convStroke.SetInnerJoin(basics.InnerJoin(ij))
+	}
+}
+
+// GetInnerJoin returns the current inner join style.
+func (agg2d *Agg2D) GetInnerJoin() InnerJoin {
+	if agg2d.convStroke != nil {
+		return InnerJoin(agg2d.convStroke.InnerJoin())
+	}
+	return InnerMiter
+}
+
 // GetInnerMiterLimit returns the current inner miter limit.
 func (agg2d *Agg2D) GetInnerMiterLimit() float64 {
 	if agg2d.convStroke != nil {
@@ -59,6 +79,7 @@ func (agg2d *Agg2D) GetInnerMiterLimit() float64 {
 // gapLen: length of the gap between dashes
 // Multiple calls accumulate dash patterns to create complex dash sequences.
 // This matches the functionality available in AGG's conv_dash.
+// A zero dashLen draws a dot when the stroke has round or square caps.
 func (agg2d *Agg2D) AddDash(dashLen, gapLen float64) {
 	// Initialize dash converter if not already present
 	if agg2d.convDash == nil {
@@ -68,9 +89,42 @@ func (agg2d *Agg2D) AddDash(dashLen, gapLen float64) {
 
 	if agg2d.convDash != nil {
 		agg2d.convDash.AddDash(dashLen, gapLen)
+		agg2d.syncDashStart()
 	}
 }
 
+// SetDashPattern replaces the dash pattern following SVG stroke-dasharray
+// rules: values alternate dash and gap lengths, an odd number of values is
+// repeated to make it even, and an empty pattern, a negative value or a zero
+// total length yields a solid stroke.
+func (agg2d *Agg2D) SetDashPattern(pattern []float64) {
+	agg2d.RemoveAllDashes()
+	pattern = SVGDashPattern(pattern)
+	for i := 0; i+1 < len(pattern); i += 2 {
+		agg2d.AddDash(pattern[i], pattern[i+1])
+	}
+}
+
+// SVGDashPattern applies SVG stroke-dasharray rules: odd-length patterns are
+// repeated to an even length, and nil is returned for patterns that should
+// give a solid stroke.
+func SVGDashPattern(pattern []float64) []float64 {
+	total := 0.0
+	for _, v := range pattern {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+		total += v
+	}
+	if total <= 0 {
+		return nil
+	}
+	if len(pattern)%2 != 0 {
+		pattern = append(append([]float64(nil), pattern...), pattern...)
+	}
+	return pattern
+}
+
 // RemoveAllDashes clears all dash patterns, returning to solid line rendering.
 func (agg2d *Agg2D) RemoveAllDashes() {
 	if agg2d.convDash != nil {
@@ -82,35 +136,85 @@ func (agg2d *Agg2D) RemoveAllDashes() {
 // DashStart sets the starting offset for the dash pattern.
 // This allows animation or positioning of the dash pattern along the line.
 // offset: distance along the line where the dash pattern begins
+// As with SVG stroke-dashoffset, the offset wraps around the pattern length
+// and negative offsets shift the pattern forward along the path.
 func (agg2d *Agg2D) DashStart(offset float64) {
-	if agg2d.convDash != nil {
-		agg2d.convDash.DashStart(offset)
-	}
+	agg2d.dashOffset = offset
+	agg2d.syncDashStart()
 }
 
 // GetDashStart returns the current dash start offset.
 func (agg2d *Agg2D) GetDashStart() float64 {
+	return agg2d.dashOffset
+}
+
+// AdvanceDashStart moves the dash offset by delta, wrapped into one pattern
+// length so it can be called once per frame indefinitely ("marching ants").
+func (agg2d *Agg2D) AdvanceDashStart(delta float64) {
+	agg2d.dashOffset = wrapDashOffset(agg2d.dashOffset+delta, agg2d.DashPatternLength())
+	agg2d.syncDashStart()
+}
+
+// DashPatternLength returns the length of one cycle of the dash pattern, or 0
+// when no dashes are set.
+func (agg2d *Agg2D) DashPatternLength() float64 {
+	if agg2d.convDash == nil {
+		return 0
+	}
+	return agg2d.convDash.DashGenerator().PatternLength()
+}
+
+// DashCap sets the cap drawn at the ends of each dash, overriding LineCap for
+// dashed strokes. Round or square dash caps on zero-length dashes draw dots.
+func (agg2d *Agg2D) DashCap(lineCap LineCap) {
+	agg2d.dashCap = lineCap
+	agg2d.dashCapSet = true
+}
+
+// ClearDashCap makes dashes use the LineCap again.
+func (agg2d *Agg2D) ClearDashCap() {
+	agg2d.dashCapSet = false
+}
+
+// GetDashCap returns the cap used for dash ends: the DashCap override when
+// one is set, and the LineCap otherwise.
+func (agg2d *Agg2D) GetDashCap() LineCap {
+	if agg2d.dashCapSet {
+		return agg2d.dashCap
+	}
+	return agg2d.lineCap
+}
+
+// syncDashStart passes the wrapped dash offset to the dash generator.
+func (agg2d *Agg2D) syncDashStart() {
 	if agg2d.convDash != nil {
-		return agg2d.convDash.GetDashStart()
+		agg2d.convDash.DashStart(wrapDashOffset(agg2d.dashOffset, agg2d.DashPatternLength()))
 	}
-	return 0.0
 }
 
-// Shorten sets the path shortening distance for strokes.
-// This shortens the path by the specified amount at both ends.
-// Useful for creating gaps between connected path segments.
-func (agg2d *Agg2D) Shorten(s float64) {
-	if agg2d.convStroke != nil {
-		agg2d.convStroke.SetShorten(s)
+// wrapDashOffset maps offset into [0, length).
+func wrapDashOffset(offset, length float64) float64 {
+	if length <= 0 || math.IsNaN(offset) || math.IsInf(offset, 0) {
+		return 0
+	}
+	offset = math.Mod(offset, length)
+	if offset < 0 {
+		offset += length
 	}
+	return offset
+}
+
+// Shorten sets the path shortening distance for strokes: the end of each
+// open sub-path is trimmed by s before stroking, like AGG's shorten_path.
+// This leaves a gap for an arrowhead or marker attached to the path end.
+// Dashed strokes shorten the path before it is split into dashes.
+func (agg2d *Agg2D) Shorten(s float64) {
+	agg2d.shorten = s
 }
 
 // GetShorten returns the current path shortening distance.
 func (agg2d *Agg2D) GetShorten() float64 {
-	if agg2d.convStroke != nil {
-		return agg2d.convStroke.Shorten()
-	}
-	return 0.0
+	return agg2d.shorten
 }
 
 // Private helper methods
@@ -128,13 +232,12 @@ func (agg2d *Agg2D) initializeDashing() {
 	lineJoin := agg2d.lineJoin
 	miterLimit := agg2d.GetMiterLimit()
 	innerMiterLimit := agg2d.GetInnerMiterLimit()
-	approximationScale := agg2d.GetApproximationScale()
-	shorten := agg2d.GetShorten()
 
 	// Create dash converter that operates on the curve converter
 	pathAdapter := path.NewPathStorageStlVertexSourceAdapter(agg2d.path)
 	agg2d.convCurve = conv.NewConvCurve(pathAdapter)
 	agg2d.convDash = conv.NewConvDash(agg2d.convCurve)
+	agg2d.convDash.DashGenerator().SetDots(true)
 
 	// Recreate stroke converter to operate on dashed output
 	agg2d.convStroke = conv.NewConvStroke(agg2d.convDash)
@@ -145,28 +248,26 @@ func (agg2d *Agg2D) initializeDashing() {
 	agg2d.LineJoin(lineJoin)
 	agg2d.convStroke.SetMiterLimit(miterLimit)
 	agg2d.convStroke.SetInnerMiterLimit(innerMiterLimit)
-	agg2d.convStroke.SetApproximationScale(approximationScale)
-	agg2d.convStroke.SetShorten(shorten)
+	agg2d.updateApproximationScales()
 }
 
 // ApproximationScale sets the approximation scale for curved segments.
 // This affects the quality vs. performance trade-off for curve rendering.
 // Higher values produce smoother curves but require more computation.
+// The scale multiplies the one derived from the current transform, so zoomed
+// drawings stay smooth without adjusting it.
 func (agg2d *Agg2D) ApproximationScale(scale float64) {
-	if agg2d.convCurve != nil {
-		agg2d.convCurve.SetApproximationScale(scale)
-	}
-	if agg2d.convStroke != nil {
-		agg2d.convStroke.SetApproximationScale(scale)
+	if scale <= 0 {
+		scale = ApproxScale
 	}
+	agg2d.approxScale = scale
+	agg2d.updateApproximationScales()
 }
 
-// GetApproximationScale returns the current approximation scale.
+// GetApproximationScale returns the approximation scale factor set with
+// ApproximationScale.
 func (agg2d *Agg2D) GetApproximationScale() float64 {
-	if agg2d.convStroke != nil {
-		return agg2d.convStroke.ApproximationScale()
-	}
-	return 1.0 // Default scale
+	return agg2d.approxScale
 }
 
 // StrokeAttributes represents a complete set of stroke attributes.
@@ -220,6 +321,9 @@ func (agg2d *Agg2D) SetStrokeAttributes(attrs *StrokeAttributes) {
 	agg2d.InnerMiterLimit(attrs.InnerMiterLimit)
 	agg2d.LineCap(attrs.Cap)
 	agg2d.LineJoin(attrs.Join)
+	if len(attrs.DashPattern) > 0 || agg2d.convDash != nil {
+		agg2d.SetDashPattern(attrs.DashPattern)
+	}
 	agg2d.DashStart(attrs.DashStart)
 	agg2d.Shorten(attrs.Shorten)
 	agg2d.ApproximationScale(attrs.ApproximationScale)
@@ -239,9 +343,61 @@ func (agg2d *Agg2D) getDashPattern() []float64 {
 	if agg2d.convDash == nil {
 		return nil
 	}
+	return agg2d.convDash.DashGenerator().Dashes()
+}
+
+// StrokeWithWidths strokes the current path with per-vertex widths, such as
+// pen pressure samples, producing a tapered outline in the line color.
+// widths[i] applies to the i-th vertex of each sub-path after curve
+// flattening; vertices past the end of the slice reuse the last width.
+func (agg2d *Agg2D) StrokeWithWidths(widths []float64) {
+	agg2d.renderVariableStroke(func(vs *conv.ConvVariableStroke) {
+		vs.SetWidths(widths)
+	})
+}
+
+// StrokeWithWidthFunc strokes the current path with the width returned by f
+// for each relative position t in [0, 1] along every sub-path.
+func (agg2d *Agg2D) StrokeWithWidthFunc(f func(t float64) float64) {
+	if f == nil {
+		return
+	}
+	agg2d.renderVariableStroke(func(vs *conv.ConvVariableStroke) {
+		vs.SetWidthFunc(f)
+	})
+}
+
+func (agg2d *Agg2D) renderVariableStroke(configure func(*conv.ConvVariableStroke)) {
+	if agg2d.rasterizer == nil || agg2d.path == nil || agg2d.scanline == nil {
+		return
+	}
+	agg2d.updateApproximationScales()
+
+	vs := conv.NewConvVariableStroke(agg2d.convCurve)
+	vs.SetWidth(agg2d.lineWidth)
+	vs.SetLineCap(basics.LineCap(agg2d.lineCap))
+	vs.SetApproximationScale(agg2d.EffectiveApproximationScale())
+	configure(vs)
+
+	agg2d.rasterizer.Reset()
+	agg2d.rasterizer.FillingRule(basics.FillNonZero)
+	src := conv.NewConvTransform(vs, agg2d.transform)
+	src.Rewind(0)
+	for {
+		x, y, cmd := src.Vertex()
+		if cmd == basics.PathCmdStop {
+			break
+		}
+		agg2d.rasterizer.AddVertex(x, y, uint32(cmd))
+	}
 
-	// For now, return empty slice as we don't have direct access to the pattern
-	// In a full implementation, conv_dash would expose its pattern
-	// This would require extending the conv.ConvDash interface
-	return []float64{}
+	if agg2d.reportCoverage() {
+		return
+	}
+	defer agg2d.usePaintBlend(agg2d.lineStyle)()
+	if agg2d.lineGradientFlag == Solid {
+		agg2d.renderSolidStroke()
+	} else {
+		agg2d.renderGradientStroke()
+	}
 }
@@ -0,0 +1,142 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+// StrokeOptions describes a stroke for StrokeOutline. Zero values select the
+// Agg2D defaults: width 1, butt caps, miter joins and miter limit 4.
+type StrokeOptions struct {
+	Width      float64
+	LineCap    LineCap
+	LineJoin   LineJoin
+	MiterLimit float64
+	// Dashes follows SVG stroke-dasharray semantics; see SetDashPattern.
+	Dashes     []float64
+	DashOffset float64
+	// ApproximationScale controls curve flattening and round join/cap
+	// tessellation. Zero means 1.
+	ApproximationScale float64
+	// Shorten trims this length off the end of each open sub-path before
+	// dashing and stroking, leaving room for an arrowhead.
+	Shorten float64
+}
+
+// StrokeOutline returns the outline of src stroked with opts. The result
+// holds only move-to, line-to and closed end-poly commands and is meant to be
+// filled with the non-zero rule, which reproduces the rendered stroke.
+func StrokeOutline(src *path.PathStorageStl, opts StrokeOptions) *path.PathStorageStl {
+	scale := opts.ApproximationScale
+	if scale <= 0 {
+		scale = 1
+	}
+
+	curve := conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(src))
+	curve.SetApproximationScale(scale)
+
+	var stroke *conv.ConvStroke
+	if dashes := SVGDashPattern(opts.Dashes); dashes != nil {
+		dash := conv.NewConvDash(curve)
+		length := 0.0
+		for i := 0; i+1 < len(dashes); i += 2 {
+			dash.AddDash(dashes[i], dashes[i+1])
+			length += dashes[i] + dashes[i+1]
+		}
+		dash.DashStart(wrapDashOffset(opts.DashOffset, length))
+		dash.Shorten(opts.Shorten)
+		stroke = conv.NewConvStroke(dash)
+	} else {
+		stroke = conv.NewConvStroke(curve)
+		stroke.SetShorten(opts.Shorten)
+	}
+
+	width := opts.Width
+	if width <= 0 {
+		width = 1
+	}
+	stroke.SetWidth(width)
+	stroke.SetLineCap(basics.LineCap(opts.LineCap))
+	stroke.SetLineJoin(strokeLineJoin(opts.LineJoin))
+	if opts.MiterLimit > 0 {
+		stroke.SetMiterLimit(opts.MiterLimit)
+	}
+	stroke.SetApproximationScale(scale)
+
+	out := path.NewPathStorageStl()
+	stroke.Rewind(0)
+	for {
+		x, y, cmd := stroke.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		out.Vertices().AddVertex(x, y, uint32(cmd))
+	}
+	return out
+}
+
+// DashOutline returns src flattened and cut into dashes, as open polylines.
+// dashes and offset follow SVG stroke-dasharray and stroke-dashoffset
+// semantics; an empty or invalid pattern returns the flattened path.
+func DashOutline(src *path.PathStorageStl, dashes []float64, offset float64) *path.PathStorageStl {
+	curve := conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(src))
+	var vs conv.VertexSource = curve
+	if pattern := SVGDashPattern(dashes); pattern != nil {
+		dash := conv.NewConvDash(curve)
+		length := 0.0
+		for i := 0; i+1 < len(pattern); i += 2 {
+			dash.AddDash(pattern[i], pattern[i+1])
+			length += pattern[i] + pattern[i+1]
+		}
+		dash.DashStart(wrapDashOffset(offset, length))
+		vs = dash
+	}
+
+	// A dash starting exactly at the end of a sub-path leaves a lone move-to;
+	// moves are only emitted once a segment follows.
+	out := path.NewPathStorageStl()
+	var moveX, moveY float64
+	pending := false
+	vs.Rewind(0)
+	for {
+		x, y, cmd := vs.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		if basics.IsMoveTo(cmd) {
+			moveX, moveY, pending = x, y, true
+			continue
+		}
+		if pending && basics.IsVertex(cmd) {
+			out.MoveTo(moveX, moveY)
+			pending = false
+		}
+		out.Vertices().AddVertex(x, y, uint32(cmd))
+	}
+	return out
+}
+
+// RoundCornersOutline returns src flattened, with every corner replaced by a
+// circular arc of the given radius; see conv.ConvRoundCorners. The arcs are
+// kept as cubic curves.
+func RoundCornersOutline(src *path.PathStorageStl, radius float64) *path.PathStorageStl {
+	rounded := conv.NewConvRoundCorners(conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(src)))
+	rounded.SetRadius(radius)
+
+	out := path.NewPathStorageStl()
+	rounded.Rewind(0)
+	for {
+		x, y, cmd := rounded.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		out.Vertices().AddVertex(x, y, uint32(cmd))
+	}
+	return out
+}
+
+// AppendPath appends the sub-paths of ps to the current path.
+func (agg2d *Agg2D) AppendPath(ps *path.PathStorageStl) {
+	agg2d.path.ConcatPath(ps, 0)
+}
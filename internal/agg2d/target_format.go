@@ -0,0 +1,289 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/buffer"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/order"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
+)
+
+// TargetFormat is the pixel layout of the buffer Agg2D renders into. The
+// rendering pipeline works in RGBA colors whatever the format. Every format
+// is blended in place by its native pixel format; only the compositing blend
+// modes of the formats without alpha convert pixels around each write.
+type TargetFormat int
+
+const (
+	TargetRGBA32 TargetFormat = iota // R, G, B, A
+	TargetBGRA32                     // B, G, R, A
+	TargetRGB24                      // R, G, B without alpha
+	TargetGray8                      // one luminance byte, without alpha
+	TargetRGB565                     // 16-bit little-endian 5:6:5 RGB, as used by embedded displays
+	TargetARGB32                     // A, R, G, B
+	TargetABGR32                     // A, B, G, R
+)
+
+// targetFormatInfo describes a target format of the registry.
+type targetFormatInfo struct {
+	bytesPerPixel int
+	// order is the channel order of the 32-bit formats.
+	order PixelOrder
+	// decode and encode convert a pixel to and from straight RGBA. They are
+	// nil for the 32-bit formats, which have RGBA pixel formats.
+	decode func(p []uint8) [4]uint8
+	encode func(p []uint8, c [4]uint8)
+	// pixfmts returns the plain and premultiplied native pixel formats of a
+	// format with decode and encode.
+	pixfmts func(rbuf *buffer.RenderingBuffer[uint8]) (plain, pre targetPixfmt)
+}
+
+// targetFormats is the registry of the formats Agg2D can render into.
+var targetFormats = map[TargetFormat]targetFormatInfo{
+	TargetRGBA32: {bytesPerPixel: 4, order: OrderRGBA},
+	TargetBGRA32: {bytesPerPixel: 4, order: OrderBGRA},
+	TargetARGB32: {bytesPerPixel: 4, order: OrderARGB},
+	TargetABGR32: {bytesPerPixel: 4, order: OrderABGR},
+	TargetRGB24: {
+		bytesPerPixel: 3,
+		decode:        func(p []uint8) [4]uint8 { return [4]uint8{p[0], p[1], p[2], 255} },
+		encode:        func(p []uint8, c [4]uint8) { p[0], p[1], p[2] = c[0], c[1], c[2] },
+		pixfmts: func(rbuf *buffer.RenderingBuffer[uint8]) (plain, pre targetPixfmt) {
+			return newRGBTargetPixfmt(pixfmt.NewPixFmtRGB24(rbuf)), newRGBTargetPixfmt(pixfmt.NewPixFmtRGB24Pre(rbuf))
+		},
+	},
+	TargetGray8: {
+		bytesPerPixel: 1,
+		decode:        func(p []uint8) [4]uint8 { return [4]uint8{p[0], p[0], p[0], 255} },
+		encode: func(p []uint8, c [4]uint8) {
+			p[0] = gray8(color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: c[3]}).V
+		},
+		pixfmts: func(rbuf *buffer.RenderingBuffer[uint8]) (plain, pre targetPixfmt) {
+			return &grayTargetPixfmt[blender.BlenderGray8Linear]{gray: pixfmt.NewPixFmtGray8(rbuf)},
+				&grayTargetPixfmt[blender.BlenderGray8PreLinear]{gray: pixfmt.NewPixFmtGray8Pre(rbuf)}
+		},
+	},
+	TargetRGB565: {
+		bytesPerPixel: 2,
+		decode: func(p []uint8) [4]uint8 {
+			v := uint16(p[0]) | uint16(p[1])<<8
+			r, g, b := uint8(v>>11), uint8(v>>5)&0x3F, uint8(v)&0x1F
+			return [4]uint8{r<<3 | r>>2, g<<2 | g>>4, b<<3 | b>>2, 255}
+		},
+		encode: func(p []uint8, c [4]uint8) {
+			v := uint16(c[0]>>3)<<11 | uint16(c[1]>>2)<<5 | uint16(c[2]>>3)
+			p[0], p[1] = uint8(v), uint8(v>>8)
+		},
+		pixfmts: func(rbuf *buffer.RenderingBuffer[uint8]) (plain, pre targetPixfmt) {
+			return &rgb565TargetPixfmt[blender.BlenderRGB565]{rbuf: rbuf},
+				&rgb565TargetPixfmt[blender.BlenderRGB565Pre]{rbuf: rbuf}
+		},
+	},
+}
+
+// Valid reports whether f is a registered target format.
+func (f TargetFormat) Valid() bool {
+	_, ok := targetFormats[f]
+	return ok
+}
+
+// BytesPerPixel returns the pixel size of the format, or 0 for an
+// unregistered one.
+func (f TargetFormat) BytesPerPixel() int {
+	return targetFormats[f].bytesPerPixel
+}
+
+// Decode returns the straight RGBA color of the pixel at the start of p.
+func (f TargetFormat) Decode(p []uint8) [4]uint8 {
+	info := targetFormats[f]
+	if info.decode != nil {
+		return info.decode(p)
+	}
+	o := info.order.colorOrder()
+	return [4]uint8{p[o.R], p[o.G], p[o.B], p[o.A]}
+}
+
+// Encode stores c in the pixel at the start of p. Formats without alpha drop
+// it.
+func (f TargetFormat) Encode(p []uint8, c [4]uint8) {
+	info := targetFormats[f]
+	if info.encode != nil {
+		info.encode(p, c)
+		return
+	}
+	o := info.order.colorOrder()
+	p[o.R], p[o.G], p[o.B], p[o.A] = c[0], c[1], c[2], c[3]
+}
+
+// AttachFormat is Attach for a buffer of pixels in format f. Unregistered
+// formats attach as TargetRGBA32.
+func (agg2d *Agg2D) AttachFormat(buf []uint8, width, height, stride int, f TargetFormat) {
+	if !f.Valid() {
+		f = TargetRGBA32
+	}
+	agg2d.targetFormat = f
+	agg2d.pixelOrder = targetFormats[f].order
+	agg2d.attach(buf, width, height, stride)
+}
+
+// TargetFormat returns the pixel format of the attached buffer.
+func (agg2d *Agg2D) TargetFormat() TargetFormat {
+	return agg2d.targetFormat
+}
+
+// newStagedPixfmts returns the compositing pixel formats over a buffer in a
+// converted format. The native pixel formats of these formats have no
+// compositing operations.
+func newStagedPixfmts(rbuf *buffer.RenderingBuffer[uint8], f TargetFormat) (comp, compPre compositePixfmt) {
+	return newStagedPixfmt(rbuf, f, func(rb *buffer.RenderingBuffer[uint8]) targetPixfmt {
+			return pixfmt.NewPixFmtCompositeRGBA[color.Linear, order.RGBA](rb, blender.CompOpSrcOver)
+		}), newStagedPixfmt(rbuf, f, func(rb *buffer.RenderingBuffer[uint8]) targetPixfmt {
+			return pixfmt.NewPixFmtCompositeRGBAPre[color.Linear, order.RGBA](rb, blender.CompOpSrcOver)
+		})
+}
+
+// stagedPixfmt renders into a buffer of a converted format through an RGBA
+// pixel format: every write decodes the pixels it touches into an RGBA
+// scratch buffer, blends there and encodes the result back, so all formats
+// share the compositing of the RGBA formats.
+type stagedPixfmt struct {
+	rbuf    *buffer.RenderingBuffer[uint8]
+	format  TargetFormat
+	bpp     int
+	scratch *buffer.RenderingBuffer[uint8]
+	pix     []uint8
+	inner   targetPixfmt
+}
+
+func newStagedPixfmt(rbuf *buffer.RenderingBuffer[uint8], f TargetFormat, inner func(*buffer.RenderingBuffer[uint8]) targetPixfmt) *stagedPixfmt {
+	scratch := buffer.NewRenderingBuffer[uint8]()
+	return &stagedPixfmt{rbuf: rbuf, format: f, bpp: f.BytesPerPixel(), scratch: scratch, inner: inner(scratch)}
+}
+
+// stage runs op on the inner format attached to a w x h scratch buffer that
+// holds the pixels from (x, y), and writes them back. Pixels outside the
+// buffer are neither read nor written.
+func (pf *stagedPixfmt) stage(x, y, w, h int, op func()) {
+	x1, y1 := max(x, 0), max(y, 0)
+	x2, y2 := min(x+w, pf.rbuf.Width()), min(y+h, pf.rbuf.Height())
+	if x1 >= x2 || y1 >= y2 {
+		return
+	}
+	if n := w * h * 4; cap(pf.pix) < n {
+		pf.pix = make([]uint8, n)
+	} else {
+		pf.pix = pf.pix[:n]
+	}
+	pf.scratch.Attach(pf.pix, w, h, w*4)
+	decode := targetFormats[pf.format].decode
+	for py := y1; py < y2; py++ {
+		row, s := pf.rbuf.Row(py), pf.pix[(py-y)*w*4:]
+		for px := x1; px < x2; px++ {
+			c := decode(row[px*pf.bpp:])
+			copy(s[(px-x)*4:], c[:])
+		}
+	}
+	op()
+	encode := targetFormats[pf.format].encode
+	for py := y1; py < y2; py++ {
+		row, s := pf.rbuf.Row(py), pf.pix[(py-y)*w*4:]
+		for px := x1; px < x2; px++ {
+			encode(row[px*pf.bpp:], [4]uint8(s[(px-x)*4:]))
+		}
+	}
+}
+
+func (pf *stagedPixfmt) Width() int    { return pf.rbuf.Width() }
+func (pf *stagedPixfmt) Height() int   { return pf.rbuf.Height() }
+func (pf *stagedPixfmt) PixWidth() int { return pf.bpp }
+
+func (pf *stagedPixfmt) Pixel(x, y int) color.RGBA8[color.Linear] {
+	if x < 0 || y < 0 || x >= pf.rbuf.Width() || y >= pf.rbuf.Height() {
+		return color.RGBA8[color.Linear]{}
+	}
+	c := targetFormats[pf.format].decode(pf.rbuf.Row(y)[x*pf.bpp:])
+	return color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: c[3]}
+}
+
+func (pf *stagedPixfmt) CopyPixel(x, y int, c color.RGBA8[color.Linear]) {
+	pf.stage(x, y, 1, 1, func() { pf.inner.CopyPixel(0, 0, c) })
+}
+
+func (pf *stagedPixfmt) BlendPixel(x, y int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.stage(x, y, 1, 1, func() { pf.inner.BlendPixel(0, 0, c, cover) })
+}
+
+func (pf *stagedPixfmt) CopyHline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.stage(x, y, length, 1, func() { pf.inner.CopyHline(0, 0, length, c) })
+}
+
+func (pf *stagedPixfmt) BlendHline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.stage(x, y, length, 1, func() { pf.inner.BlendHline(0, 0, length, c, cover) })
+}
+
+func (pf *stagedPixfmt) CopyVline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.stage(x, y, 1, length, func() { pf.inner.CopyVline(0, 0, length, c) })
+}
+
+func (pf *stagedPixfmt) BlendVline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.stage(x, y, 1, length, func() { pf.inner.BlendVline(0, 0, length, c, cover) })
+}
+
+func (pf *stagedPixfmt) CopyBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear]) {
+	x1, x2 = min(x1, x2), max(x1, x2)
+	y1, y2 = min(y1, y2), max(y1, y2)
+	pf.stage(x1, y1, x2-x1+1, y2-y1+1, func() { pf.inner.CopyBar(0, 0, x2-x1, y2-y1, c) })
+}
+
+func (pf *stagedPixfmt) BlendBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	x1, x2 = min(x1, x2), max(x1, x2)
+	y1, y2 = min(y1, y2), max(y1, y2)
+	pf.stage(x1, y1, x2-x1+1, y2-y1+1, func() { pf.inner.BlendBar(0, 0, x2-x1, y2-y1, c, cover) })
+}
+
+func (pf *stagedPixfmt) BlendSolidHspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	pf.stage(x, y, length, 1, func() { pf.inner.BlendSolidHspan(0, 0, length, c, covers) })
+}
+
+func (pf *stagedPixfmt) BlendSolidVspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	pf.stage(x, y, 1, length, func() { pf.inner.BlendSolidVspan(0, 0, length, c, covers) })
+}
+
+func (pf *stagedPixfmt) CopyColorHspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	pf.stage(x, y, length, 1, func() { pf.inner.CopyColorHspan(0, 0, length, colors) })
+}
+
+func (pf *stagedPixfmt) BlendColorHspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	pf.stage(x, y, length, 1, func() { pf.inner.BlendColorHspan(0, 0, length, colors, covers, cover) })
+}
+
+func (pf *stagedPixfmt) CopyColorVspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	pf.stage(x, y, 1, length, func() { pf.inner.CopyColorVspan(0, 0, length, colors) })
+}
+
+func (pf *stagedPixfmt) BlendColorVspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	pf.stage(x, y, 1, length, func() { pf.inner.BlendColorVspan(0, 0, length, colors, covers, cover) })
+}
+
+// Clear stores c in every pixel, like the RGBA formats.
+func (pf *stagedPixfmt) Clear(c color.RGBA8[color.Linear]) {
+	encode := targetFormats[pf.format].encode
+	for y := 0; y < pf.rbuf.Height(); y++ {
+		row := pf.rbuf.Row(y)
+		for x := 0; x < pf.rbuf.Width(); x++ {
+			encode(row[x*pf.bpp:], [4]uint8{c.R, c.G, c.B, c.A})
+		}
+	}
+}
+
+func (pf *stagedPixfmt) Fill(c color.RGBA8[color.Linear]) {
+	pf.Clear(c)
+}
+
+// SetCompOp sets the compositing operation of a compositing inner format.
+func (pf *stagedPixfmt) SetCompOp(op blender.CompOp) {
+	if comp, ok := pf.inner.(compositePixfmt); ok {
+		comp.SetCompOp(op)
+	}
+}
@@ -0,0 +1,135 @@
+package agg2d
+
+import "testing"
+
+func TestTargetFormatCodecs(t *testing.T) {
+	tests := []struct {
+		f    TargetFormat
+		in   [4]uint8
+		want [4]uint8
+	}{
+		{TargetRGBA32, [4]uint8{10, 20, 30, 40}, [4]uint8{10, 20, 30, 40}},
+		{TargetBGRA32, [4]uint8{10, 20, 30, 40}, [4]uint8{10, 20, 30, 40}},
+		{TargetARGB32, [4]uint8{10, 20, 30, 40}, [4]uint8{10, 20, 30, 40}},
+		{TargetABGR32, [4]uint8{10, 20, 30, 40}, [4]uint8{10, 20, 30, 40}},
+		{TargetRGB24, [4]uint8{10, 20, 30, 40}, [4]uint8{10, 20, 30, 255}},
+		{TargetGray8, [4]uint8{255, 255, 255, 0}, [4]uint8{255, 255, 255, 255}},
+		{TargetRGB565, [4]uint8{255, 128, 0, 255}, [4]uint8{255, 130, 0, 255}},
+	}
+	for _, tt := range tests {
+		p := make([]uint8, tt.f.BytesPerPixel())
+		tt.f.Encode(p, tt.in)
+		if got := tt.f.Decode(p); got != tt.want {
+			t.Errorf("format %d: round trip of %v = %v, want %v", tt.f, tt.in, got, tt.want)
+		}
+	}
+	if TargetFormat(99).Valid() {
+		t.Error("unregistered format reported valid")
+	}
+}
+
+func TestAttachFormatBlendModes(t *testing.T) {
+	agg2d := NewAgg2D()
+	width, height := 8, 8
+	buf := make([]uint8, width*height*3)
+	agg2d.AttachFormat(buf, width, height, width*3, TargetRGB24)
+	if agg2d.TargetFormat() != TargetRGB24 {
+		t.Fatalf("TargetFormat() = %d, want TargetRGB24", agg2d.TargetFormat())
+	}
+
+	agg2d.ClearAll(Color{200, 100, 50, 255})
+	agg2d.SetBlendMode(BlendMultiply)
+	agg2d.FillColor(Color{128, 255, 0, 255})
+	agg2d.NoLine()
+	agg2d.Rectangle(0, 0, 8, 8)
+
+	got := [3]uint8(buf[(4*width+4)*3:])
+	want := [3]uint8{100, 100, 0}
+	for i := range got {
+		if d := int(got[i]) - int(want[i]); d < -1 || d > 1 {
+			t.Fatalf("multiplied pixel = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestAttachOrderedAlphaFirst(t *testing.T) {
+	tests := []struct {
+		o    PixelOrder
+		want [4]uint8 // bytes of a red pixel
+	}{
+		{OrderARGB, [4]uint8{255, 255, 0, 0}},
+		{OrderABGR, [4]uint8{255, 0, 0, 255}},
+	}
+	for _, tt := range tests {
+		agg2d := NewAgg2D()
+		width, height := 8, 8
+		buf := make([]uint8, width*height*4)
+		agg2d.AttachOrdered(buf, width, height, width*4, tt.o)
+		agg2d.ClearAll(Color{0, 0, 0, 0})
+		agg2d.FillColor(Color{255, 0, 0, 255})
+		agg2d.NoLine()
+		agg2d.Rectangle(0, 0, 8, 8)
+		if got := [4]uint8(buf[(4*width+4)*4:]); got != tt.want {
+			t.Errorf("order %d: filled pixel = %v, want %v", tt.o, got, tt.want)
+		}
+
+		// Images in the same order blend without swapping channels.
+		img := NewImage(make([]uint8, 4*4*4), 4, 4, 4*4)
+		img.SetOrder(tt.o)
+		for i := 0; i < len(img.Data); i += 4 {
+			copy(img.Data[i:], []uint8{0, 0, 0, 0})
+			o := tt.o.colorOrder()
+			img.Data[i+o.G], img.Data[i+o.A] = 255, 255
+		}
+		if err := agg2d.BlendImageSimple(img, 0, 0, 255); err != nil {
+			t.Fatal(err)
+		}
+		o := tt.o.colorOrder()
+		p := buf[(2*width+2)*4:]
+		if p[o.R] != 0 || p[o.G] != 255 || p[o.A] != 255 {
+			t.Errorf("order %d: blended pixel = %v, want green", tt.o, p[:4])
+		}
+	}
+}
+
+func TestAttachFormatNativePixfmts(t *testing.T) {
+	const width, height = 16, 16
+	draw := func(agg2d *Agg2D) {
+		agg2d.ClearAll(Color{200, 220, 240, 255})
+		agg2d.FillColor(Color{200, 40, 20, 160})
+		agg2d.NoLine()
+		agg2d.Ellipse(8, 8, 5.5, 4.3)
+	}
+	ref := NewAgg2D()
+	refBuf := make([]uint8, width*height*4)
+	ref.Attach(refBuf, width, height, width*4)
+	draw(ref)
+
+	for _, f := range []TargetFormat{TargetRGB24, TargetGray8, TargetRGB565} {
+		agg2d := NewAgg2D()
+		bpp := f.BytesPerPixel()
+		buf := make([]uint8, width*height*bpp)
+		agg2d.AttachFormat(buf, width, height, width*bpp, f)
+		if _, staged := agg2d.pixfmt.(*stagedPixfmt); staged {
+			t.Fatalf("format %d: rendered through the staged pixel format", f)
+		}
+		draw(agg2d)
+
+		// The native formats blend at their own precision; the result must
+		// stay close to the RGBA rendering converted to the format.
+		tol := 2
+		if f == TargetRGB565 {
+			tol = 8
+		}
+		for i := 0; i < width*height; i++ {
+			want := make([]uint8, bpp)
+			f.Encode(want, [4]uint8(refBuf[i*4:]))
+			got, exp := f.Decode(buf[i*bpp:]), f.Decode(want)
+			for c := 0; c < 3; c++ {
+				if d := int(got[c]) - int(exp[c]); d < -tol || d > tol {
+					t.Fatalf("format %d: pixel %d = %v, want about %v", f, i, got, exp)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/buffer"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
+)
+
+// The pixel formats below render the formats without an alpha channel with
+// their native AGG pixel formats and blenders. Like Encode, copies store the
+// color and drop its alpha.
+
+// rgbTargetPixfmt renders into an RGB24 buffer through the native RGB pixel
+// format and its RGBA8 adaptor.
+type rgbTargetPixfmt[B blender.RGBBlender[color.Linear]] struct {
+	*pixfmt.PixFmtRGBARendererAdaptor[color.Linear, B]
+	rgb *pixfmt.PixFmtAlphaBlendRGB[color.Linear, B]
+}
+
+func newRGBTargetPixfmt[B blender.RGBBlender[color.Linear]](rgb *pixfmt.PixFmtAlphaBlendRGB[color.Linear, B]) *rgbTargetPixfmt[B] {
+	return &rgbTargetPixfmt[B]{PixFmtRGBARendererAdaptor: pixfmt.NewPixFmtRGBARendererAdaptor(rgb), rgb: rgb}
+}
+
+func rgb8(c color.RGBA8[color.Linear]) color.RGB8[color.Linear] {
+	return color.RGB8[color.Linear]{R: c.R, G: c.G, B: c.B}
+}
+
+func (pf *rgbTargetPixfmt[B]) CopyPixel(x, y int, c color.RGBA8[color.Linear]) {
+	pf.rgb.CopyPixel(x, y, rgb8(c))
+}
+
+func (pf *rgbTargetPixfmt[B]) CopyHline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.rgb.CopyHline(x, y, x+length-1, rgb8(c))
+}
+
+func (pf *rgbTargetPixfmt[B]) CopyVline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.rgb.CopyVline(x, y, y+length-1, rgb8(c))
+}
+
+func (pf *rgbTargetPixfmt[B]) CopyBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear]) {
+	pf.rgb.CopyBar(x1, y1, x2, y2, rgb8(c))
+}
+
+func (pf *rgbTargetPixfmt[B]) CopyColorHspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	for i := 0; i < length && i < len(colors); i++ {
+		pf.rgb.CopyPixel(x+i, y, rgb8(colors[i]))
+	}
+}
+
+func (pf *rgbTargetPixfmt[B]) CopyColorVspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	for i := 0; i < length && i < len(colors); i++ {
+		pf.rgb.CopyPixel(x, y+i, rgb8(colors[i]))
+	}
+}
+
+func (pf *rgbTargetPixfmt[B]) Clear(c color.RGBA8[color.Linear]) {
+	pf.rgb.CopyBar(0, 0, pf.Width()-1, pf.Height()-1, rgb8(c))
+}
+
+func (pf *rgbTargetPixfmt[B]) Fill(c color.RGBA8[color.Linear]) {
+	pf.Clear(c)
+}
+
+// grayTargetPixfmt renders into a Gray8 buffer through the native gray pixel
+// format, converting colors to their luminance.
+type grayTargetPixfmt[B blender.GrayBlender[color.Linear]] struct {
+	gray *pixfmt.PixFmtAlphaBlendGray[color.Linear, B]
+	span []color.Gray8[color.Linear]
+}
+
+// gray8 converts c with the BT.601 luminance weights AGG's RGB to gray
+// alpha mask conversions use.
+func gray8(c color.RGBA8[color.Linear]) color.Gray8[color.Linear] {
+	v := (77*int(c.R) + 150*int(c.G) + 29*int(c.B)) >> 8
+	return color.Gray8[color.Linear]{V: basics.Int8u(v), A: c.A}
+}
+
+// grays converts colors into the span buffer.
+func (pf *grayTargetPixfmt[B]) grays(length int, colors []color.RGBA8[color.Linear]) []color.Gray8[color.Linear] {
+	pf.span = pf.span[:0]
+	for i := 0; i < length && i < len(colors); i++ {
+		pf.span = append(pf.span, gray8(colors[i]))
+	}
+	return pf.span
+}
+
+func (pf *grayTargetPixfmt[B]) Width() int    { return pf.gray.Width() }
+func (pf *grayTargetPixfmt[B]) Height() int   { return pf.gray.Height() }
+func (pf *grayTargetPixfmt[B]) PixWidth() int { return pf.gray.PixWidth() }
+
+func (pf *grayTargetPixfmt[B]) Pixel(x, y int) color.RGBA8[color.Linear] {
+	g := pf.gray.Pixel(x, y)
+	return color.RGBA8[color.Linear]{R: g.V, G: g.V, B: g.V, A: g.A}
+}
+
+func (pf *grayTargetPixfmt[B]) CopyPixel(x, y int, c color.RGBA8[color.Linear]) {
+	pf.gray.CopyPixel(x, y, gray8(c))
+}
+
+func (pf *grayTargetPixfmt[B]) BlendPixel(x, y int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.gray.BlendPixel(x, y, gray8(c), cover)
+}
+
+func (pf *grayTargetPixfmt[B]) CopyHline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.gray.CopyHline(x, y, length, gray8(c))
+}
+
+func (pf *grayTargetPixfmt[B]) BlendHline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.gray.BlendHline(x, y, length, gray8(c), cover)
+}
+
+func (pf *grayTargetPixfmt[B]) CopyVline(x, y, length int, c color.RGBA8[color.Linear]) {
+	pf.gray.CopyVline(x, y, length, gray8(c))
+}
+
+func (pf *grayTargetPixfmt[B]) BlendVline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.gray.BlendVline(x, y, length, gray8(c), cover)
+}
+
+func (pf *grayTargetPixfmt[B]) CopyBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear]) {
+	pf.gray.CopyBar(x1, y1, x2, y2, gray8(c))
+}
+
+func (pf *grayTargetPixfmt[B]) BlendBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	pf.gray.BlendBar(x1, y1, x2, y2, gray8(c), cover)
+}
+
+func (pf *grayTargetPixfmt[B]) BlendSolidHspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	pf.gray.BlendSolidHspan(x, y, length, gray8(c), covers)
+}
+
+func (pf *grayTargetPixfmt[B]) BlendSolidVspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	pf.gray.BlendSolidVspan(x, y, length, gray8(c), covers)
+}
+
+func (pf *grayTargetPixfmt[B]) CopyColorHspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	span := pf.grays(length, colors)
+	pf.gray.CopyColorHspan(x, y, len(span), span)
+}
+
+func (pf *grayTargetPixfmt[B]) BlendColorHspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	span := pf.grays(length, colors)
+	pf.gray.BlendColorHspan(x, y, len(span), span, covers, cover)
+}
+
+func (pf *grayTargetPixfmt[B]) CopyColorVspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	span := pf.grays(length, colors)
+	pf.gray.CopyColorVspan(x, y, len(span), span)
+}
+
+func (pf *grayTargetPixfmt[B]) BlendColorVspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	span := pf.grays(length, colors)
+	pf.gray.BlendColorVspan(x, y, len(span), span, covers, cover)
+}
+
+func (pf *grayTargetPixfmt[B]) Clear(c color.RGBA8[color.Linear]) { pf.gray.Clear(gray8(c)) }
+func (pf *grayTargetPixfmt[B]) Fill(c color.RGBA8[color.Linear])  { pf.gray.Fill(gray8(c)) }
+
+// rgb565TargetPixfmt renders into a little-endian RGB565 buffer with the
+// native packed RGB565 blenders. The packed pixel formats address their
+// buffer as uint16 values, so the pixels are packed and blended in place
+// here instead.
+type rgb565TargetPixfmt[B blender.RGB16PackedBlender] struct {
+	rbuf    *buffer.RenderingBuffer[uint8]
+	blender B
+}
+
+// pix returns the bytes of the pixel at x, y, or nil outside the buffer.
+func (pf *rgb565TargetPixfmt[B]) pix(x, y int) []uint8 {
+	if !pixfmt.InBounds(x, y, pf.rbuf.Width(), pf.rbuf.Height()) {
+		return nil
+	}
+	return pf.rbuf.Row(y)[x*2 : x*2+2]
+}
+
+func (pf *rgb565TargetPixfmt[B]) Width() int    { return pf.rbuf.Width() }
+func (pf *rgb565TargetPixfmt[B]) Height() int   { return pf.rbuf.Height() }
+func (pf *rgb565TargetPixfmt[B]) PixWidth() int { return 2 }
+
+func (pf *rgb565TargetPixfmt[B]) Pixel(x, y int) color.RGBA8[color.Linear] {
+	p := pf.pix(x, y)
+	if p == nil {
+		return color.RGBA8[color.Linear]{}
+	}
+	c := TargetRGB565.Decode(p)
+	return color.RGBA8[color.Linear]{R: c[0], G: c[1], B: c[2], A: c[3]}
+}
+
+func (pf *rgb565TargetPixfmt[B]) CopyPixel(x, y int, c color.RGBA8[color.Linear]) {
+	if p := pf.pix(x, y); p != nil {
+		v := pixfmt.MakePixel565(c.R, c.G, c.B)
+		p[0], p[1] = uint8(v), uint8(v>>8)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendPixel(x, y int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	if p := pf.pix(x, y); p != nil {
+		v := basics.Int16u(p[0]) | basics.Int16u(p[1])<<8
+		pf.blender.BlendPix(&v, c.R, c.G, c.B, c.A, cover)
+		p[0], p[1] = uint8(v), uint8(v>>8)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) CopyHline(x, y, length int, c color.RGBA8[color.Linear]) {
+	for i := 0; i < length; i++ {
+		pf.CopyPixel(x+i, y, c)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendHline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	for i := 0; i < length; i++ {
+		pf.BlendPixel(x+i, y, c, cover)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) CopyVline(x, y, length int, c color.RGBA8[color.Linear]) {
+	for i := 0; i < length; i++ {
+		pf.CopyPixel(x, y+i, c)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendVline(x, y, length int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	for i := 0; i < length; i++ {
+		pf.BlendPixel(x, y+i, c, cover)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) CopyBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear]) {
+	x1, x2 = min(x1, x2), max(x1, x2)
+	for y := min(y1, y2); y <= max(y1, y2); y++ {
+		pf.CopyHline(x1, y, x2-x1+1, c)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendBar(x1, y1, x2, y2 int, c color.RGBA8[color.Linear], cover basics.Int8u) {
+	x1, x2 = min(x1, x2), max(x1, x2)
+	for y := min(y1, y2); y <= max(y1, y2); y++ {
+		pf.BlendHline(x1, y, x2-x1+1, c, cover)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendSolidHspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	for i := 0; i < length && i < len(covers); i++ {
+		pf.BlendPixel(x+i, y, c, covers[i])
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendSolidVspan(x, y, length int, c color.RGBA8[color.Linear], covers []basics.Int8u) {
+	for i := 0; i < length && i < len(covers); i++ {
+		pf.BlendPixel(x, y+i, c, covers[i])
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) CopyColorHspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	for i := 0; i < length && i < len(colors); i++ {
+		pf.CopyPixel(x+i, y, colors[i])
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) CopyColorVspan(x, y, length int, colors []color.RGBA8[color.Linear]) {
+	for i := 0; i < length && i < len(colors); i++ {
+		pf.CopyPixel(x, y+i, colors[i])
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendColorHspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	for i := 0; i < length && i < len(colors); i++ {
+		c := cover
+		if covers != nil && i < len(covers) {
+			c = covers[i]
+		}
+		pf.BlendPixel(x+i, y, colors[i], c)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) BlendColorVspan(x, y, length int, colors []color.RGBA8[color.Linear], covers []basics.Int8u, cover basics.Int8u) {
+	for i := 0; i < length && i < len(colors); i++ {
+		c := cover
+		if covers != nil && i < len(covers) {
+			c = covers[i]
+		}
+		pf.BlendPixel(x, y+i, colors[i], c)
+	}
+}
+
+func (pf *rgb565TargetPixfmt[B]) Clear(c color.RGBA8[color.Linear]) {
+	pf.CopyBar(0, 0, pf.Width()-1, pf.Height()-1, c)
+}
+
+func (pf *rgb565TargetPixfmt[B]) Fill(c color.RGBA8[color.Linear]) {
+	pf.Clear(c)
+}
@@ -16,9 +16,30 @@ import (
 
 // Font loads and configures a font for text rendering.
 // This matches the C++ Agg2D::font() method signature and behavior.
+//
+// Glyph settings chosen with FontWithOptions do not carry over: the font
+// uses RGB subpixel order, no subpixel positioning, and native hinting
+// unless TextHints turned hinting off.
 func (agg2d *Agg2D) Font(fileName string, height float64, bold, italic bool,
 	cacheType FontCacheType, angle float64,
 ) error {
+	// Determine rendering type based on cache type
+	agg2d.glyphRendering = GlyphGray8
+	if cacheType == VectorFontCache {
+		agg2d.glyphRendering = GlyphOutline
+	}
+	agg2d.hintingMode = HintingNative
+	if !agg2d.textHints {
+		agg2d.hintingMode = HintingNone
+	}
+	agg2d.subpixelOrder = SubpixelRGB
+	agg2d.subpixelPhases = 0
+	return agg2d.loadFont(fileName, height, cacheType, angle)
+}
+
+// loadFont loads fileName into the primary font engine with the current
+// glyph rendering and hinting settings.
+func (agg2d *Agg2D) loadFont(fileName string, height float64, cacheType FontCacheType, angle float64) error {
 	if agg2d.fontEngine == nil {
 		// Initialize font engine if not already done
 		engine, err := freetype.NewFontEngineFreetype(false, 32)
@@ -33,36 +54,40 @@ func (agg2d *Agg2D) Font(fileName string, height float64, bold, italic bool,
 	agg2d.textAngle = angle
 	agg2d.fontHeight = height
 	agg2d.fontCacheType = cacheType
-
-	// Determine rendering type based on cache type
-	var renderingType freetype.GlyphRenderingType
-	if cacheType == VectorFontCache {
-		renderingType = freetype.GlyphRenderingOutline
-	} else {
-		renderingType = freetype.GlyphRenderingAAGray8
-	}
+	agg2d.fontFile = fileName
+	agg2d.fontLoaded = false
 
 	// Load the font
 	if agg2d.fontEngine != nil {
 		agg2d.fontEngine.SetResolution(agg2d.resolution)
 		agg2d.fontEngine.SetFlipY(agg2d.flipText)
-		err := agg2d.fontEngine.LoadFont(fileName, 0, renderingType, nil)
+		err := agg2d.loadFace(agg2d.fontEngine, fileName, agg2d.glyphRenderingType())
 		if err != nil {
 			return err
 		}
+		agg2d.configureFontEngine(agg2d.fontEngine)
+		agg2d.fontLoaded = true
+	}
 
-		agg2d.fontEngine.SetHinting(agg2d.textHints)
+	return agg2d.syncFallbackFonts()
+}
 
-		// Set height based on cache type
-		if cacheType == VectorFontCache {
-			agg2d.fontEngine.SetHeight(height)
-		} else {
-			// Raster glyph caches are configured in screen units.
-			agg2d.fontEngine.SetHeight(agg2d.WorldToScreenScalar(height))
-		}
+// ShareFontData makes fonts named fileName load from data instead of the
+// file. Agg2D instances given the same data hold a single copy of the font
+// file; each still has its own engine and glyph cache.
+func (agg2d *Agg2D) ShareFontData(fileName string, data *freetype.FontData) {
+	if agg2d.sharedFonts == nil {
+		agg2d.sharedFonts = make(map[string]*freetype.FontData)
 	}
+	agg2d.sharedFonts[fileName] = data
+}
 
-	return nil
+// loadFace loads fileName into engine, from shared data when there is some.
+func (agg2d *Agg2D) loadFace(engine *freetype.FontEngineFreetype, fileName string, renderingType freetype.GlyphRenderingType) error {
+	if data := agg2d.sharedFonts[fileName]; data != nil {
+		return engine.LoadFontData(fileName, 0, renderingType, data)
+	}
+	return engine.LoadFont(fileName, 0, renderingType, nil)
 }
 
 // FontGSV configures the built-in AGG GSV stroke-vector font as the active text
@@ -92,6 +117,11 @@ func (agg2d *Agg2D) SetResolution(dpi uint) {
 	if agg2d.fontEngine != nil {
 		agg2d.fontEngine.SetResolution(dpi)
 	}
+	for _, f := range agg2d.fallbackFonts {
+		if f.engine != nil {
+			f.engine.SetResolution(dpi)
+		}
+	}
 }
 
 // FontHeight returns the current font height.
@@ -141,16 +171,33 @@ func (agg2d *Agg2D) FlipText(flip bool) {
 	if agg2d.fontEngine != nil {
 		agg2d.fontEngine.SetFlipY(flip)
 	}
+	for _, f := range agg2d.fallbackFonts {
+		if f.engine != nil {
+			f.engine.SetFlipY(flip)
+		}
+	}
 }
 
 // NOTE: TextAlignment method already exists in agg2d.go, so we don't redefine it here
 
 // TextHints enables or disables font hinting for better text rendering.
+// Enabling hinting after HintingNone selects HintingNative.
 func (agg2d *Agg2D) TextHints(hints bool) {
 	agg2d.textHints = hints
+	switch {
+	case !hints:
+		agg2d.hintingMode = HintingNone
+	case agg2d.hintingMode == HintingNone:
+		agg2d.hintingMode = HintingNative
+	}
 	if agg2d.fontEngine != nil {
 		agg2d.fontEngine.SetHinting(hints)
 	}
+	for _, f := range agg2d.fallbackFonts {
+		if f.engine != nil {
+			f.engine.SetHinting(hints)
+		}
+	}
 }
 
 // GetTextHints returns whether text hinting is currently enabled.
@@ -164,32 +211,15 @@ func (agg2d *Agg2D) TextWidth(str string) float64 {
 	if agg2d.gsvFontMode && agg2d.gsvText != nil {
 		return agg2d.gsvText.MeasureText(str)
 	}
-
-	fcm := agg2d.fontCacheManager
-	if fcm == nil {
-		return 0.0
+	if agg2d.useBitmapFont() {
+		return agg2d.ScreenToWorldScalar(agg2d.bitmapGlyphs().Width(str))
 	}
 
-	x := 0.0
-	y := 0.0
-	first := true
-	var prevGlyphIndex uint
-
-	// Iterate through each character to calculate total width.
-	for _, r := range str {
-		glyph := fcm.Glyph(uint(r))
-		if glyph == nil {
-			continue
-		}
-		if !first {
-			// Kerning in FreeType is defined between glyph indices.
-			fcm.AddKerning(&x, &y, prevGlyphIndex, glyph.GlyphIndex)
-		}
-		x += glyph.AdvanceX
-		y += glyph.AdvanceY
-		first = false
-		prevGlyphIndex = glyph.GlyphIndex
+	run := agg2d.glyphRun(str)
+	if run == nil {
+		return 0.0
 	}
+	x := run.advanceX
 
 	if agg2d.fontCacheType == RasterFontCache {
 		return agg2d.ScreenToWorldScalar(x)
@@ -266,6 +296,7 @@ vertexLoopDone:
 	strokeAdapter.SetWidth(agg2d.fontHeight * 0.08) // ~8 % of glyph height
 	strokeAdapter.SetLineCap(basics.RoundCap)
 	strokeAdapter.SetLineJoin(basics.RoundJoin)
+	strokeAdapter.SetApproximationScale(agg2d.EffectiveApproximationScale())
 
 	// Apply the global affine transform so text respects Viewport(), Rotate(), etc.
 	transformedStroke := conv.NewConvTransform(strokeAdapter, agg2d.transform)
@@ -282,7 +313,15 @@ vertexLoopDone:
 	}
 
 	// Paint using fill color (mirrors FreeType path: fill color = text color).
-	agg2d.renderSolidFillWithColor(agg2d.fillColor)
+	if !agg2d.reportCoverage() {
+		agg2d.renderSolidFillWithColor(agg2d.fillColor)
+	}
+
+	if agg2d.textDecoration != DecorationNone {
+		agg2d.path.RemoveAll()
+		agg2d.addTextDecorations(startX, startY, agg2d.TextWidth(str), agg2d.decorationMetrics(), -1, nil)
+		agg2d.DrawPath(FillOnly)
+	}
 }
 
 // Text renders text at the specified position with optional positioning adjustments.
@@ -293,12 +332,81 @@ func (agg2d *Agg2D) Text(x, y float64, str string, roundOff bool, dx, dy float64
 		agg2d.textGSV(x, y, str, roundOff, dx, dy)
 		return
 	}
+	if agg2d.useBitmapFont() {
+		agg2d.textBitmap(x, y, str, dx, dy)
+		return
+	}
 
 	fcm := agg2d.fontCacheManager
 	if fcm == nil || str == "" {
 		return
 	}
 
+	// Outline glyphs go through the shared rasterizer; keep their coverage
+	// linear when text gamma is off.
+	if !agg2d.textGamma && agg2d.antiAliasGamma != 1.0 {
+		agg2d.setRasterizerGamma(1.0)
+		defer agg2d.updateRasterizerGamma()
+	}
+
+	startX, startY, textTransform := agg2d.textOrigin(x, y, str, roundOff, dx, dy)
+
+	// Render each glyph of the (possibly cached) run
+	run := agg2d.glyphRun(str)
+	for i := range run.glyphs {
+		g := &run.glyphs[i]
+		currentX := startX + g.x
+		currentY := startY + g.y
+
+		switch g.glyph.DataType {
+		case font.GlyphDataOutline:
+			agg2d.path.RemoveAll()
+			if g.outline != nil {
+				mtx := transform.NewTransAffineTranslation(currentX, currentY)
+				if textTransform != nil {
+					mtx.Multiply(textTransform)
+				}
+				agg2d.path.ConcatPath(&transformedPathSource{src: g.outline, mtx: mtx}, 0)
+				agg2d.DrawPath(FillAndStroke)
+			}
+
+		case font.GlyphDataGray8:
+			glyph, gx := agg2d.subpixelGlyph(g, currentX)
+			fcm.InitEmbeddedAdaptors(glyph, gx, currentY)
+			if adaptor := fcm.Gray8Adaptor(); adaptor != nil {
+				agg2d.renderGlyphScanlines(adaptor, glyph, gx, currentY)
+			}
+
+		case font.GlyphDataLCD:
+			glyph, gx := agg2d.subpixelGlyph(g, currentX)
+			agg2d.renderLCDGlyph(glyph.Data, glyph.Bounds, gx, currentY)
+
+		// GlyphDataMono: Go extension — C++ agg2d.cpp text() only handles outline and
+		// gray8; mono is rendered here for completeness when a font engine is configured
+		// for binary (non-AA) rasterization.
+		case font.GlyphDataMono:
+			glyph, gx := agg2d.subpixelGlyph(g, currentX)
+			fcm.InitEmbeddedAdaptors(glyph, gx, currentY)
+			if adaptor := fcm.MonoAdaptor(); adaptor != nil {
+				agg2d.renderGlyphScanlines(adaptor, glyph, gx, currentY)
+			}
+		}
+	}
+
+	if agg2d.textDecoration != DecorationNone {
+		agg2d.path.RemoveAll()
+		agg2d.addTextDecorations(startX, startY, run.advanceX, agg2d.decorationMetrics(), agg2d.textUp(), textTransform)
+		if agg2d.fontCacheType == RasterFontCache {
+			agg2d.DrawPathNoTransform(FillOnly)
+		} else {
+			agg2d.DrawPath(FillAndStroke)
+		}
+	}
+}
+
+// textOrigin returns the pen position of the first glyph of str drawn at
+// (x, y) with the current alignment, and the rotation for a text angle.
+func (agg2d *Agg2D) textOrigin(x, y float64, str string, roundOff bool, dx, dy float64) (startX, startY float64, textTransform *transform.TransAffine) {
 	// Calculate alignment offsets
 	alignDx := 0.0
 	alignDy := 0.0
@@ -314,7 +422,7 @@ func (agg2d *Agg2D) Text(x, y float64, str string, roundOff bool, dx, dy float64
 	// Vertical alignment - calculate font ascender
 	ascent := agg2d.fontHeight
 	// Try to get ascent from 'H' character for better alignment
-	glyph := fcm.Glyph(uint('H'))
+	glyph := agg2d.fontCacheManager.Glyph(uint('H'))
 	if glyph != nil {
 		ascent = float64(glyph.Bounds.Y2 - glyph.Bounds.Y1)
 	}
@@ -336,8 +444,8 @@ func (agg2d *Agg2D) Text(x, y float64, str string, roundOff bool, dx, dy float64
 	}
 
 	// Calculate starting position
-	startX := x + alignDx
-	startY := y + alignDy
+	startX = x + alignDx
+	startY = y + alignDy
 
 	// Apply rounding if requested (matches C++ int() truncation semantics)
 	if roundOff {
@@ -349,8 +457,6 @@ func (agg2d *Agg2D) Text(x, y float64, str string, roundOff bool, dx, dy float64
 	startX += dx
 	startY += dy
 
-	pathStorage := fcm.PathAdaptor()
-	var textTransform *transform.TransAffine
 	if agg2d.textAngle != 0.0 {
 		textTransform = transform.NewTransAffine()
 		textTransform.Translate(-x, -y)
@@ -363,56 +469,40 @@ func (agg2d *Agg2D) Text(x, y float64, str string, roundOff bool, dx, dy float64
 		agg2d.WorldToScreen(&startX, &startY)
 	}
 
-	// Render each character
-	currentX := startX
-	currentY := startY
-	firstGlyph := true
-	var prevGlyphIndex uint
-
-	for _, r := range str {
-		glyph = fcm.Glyph(uint(r))
-		if glyph == nil {
-			continue
-		}
-
-		if !firstGlyph {
-			fcm.AddKerning(&currentX, &currentY, prevGlyphIndex, glyph.GlyphIndex)
-		}
+	return startX, startY, textTransform
+}
 
-		// Initialize glyph adaptors for rendering.
-		fcm.InitEmbeddedAdaptors(glyph, currentX, currentY)
+// TextOutline replaces the current path with the outlines of str and its
+// decoration lines placed as Text would draw them, so the text can be filled and stroked like any
+// other shape. It reports false, leaving the path empty, unless a font file
+// is loaded with outline glyphs (VectorFontCache).
+func (agg2d *Agg2D) TextOutline(x, y float64, str string, roundOff bool) bool {
+	agg2d.path.RemoveAll()
+	if agg2d.gsvFontMode || agg2d.useBitmapFont() || agg2d.fontCacheManager == nil || str == "" {
+		return false
+	}
 
-		switch glyph.DataType {
-		case font.GlyphDataOutline:
+	startX, startY, textTransform := agg2d.textOrigin(x, y, str, roundOff, 0, 0)
+	run := agg2d.glyphRun(str)
+	for i := range run.glyphs {
+		g := &run.glyphs[i]
+		if g.glyph.DataType != font.GlyphDataOutline {
 			agg2d.path.RemoveAll()
-			if pathStorage != nil {
-				if textTransform != nil {
-					agg2d.path.ConcatPath(&transformedPathSource{src: pathStorage, mtx: textTransform}, 0)
-				} else {
-					agg2d.path.ConcatPath(pathStorage, 0)
-				}
-				agg2d.DrawPath(FillAndStroke)
-			}
-
-		case font.GlyphDataGray8:
-			if adaptor := fcm.Gray8Adaptor(); adaptor != nil {
-				agg2d.renderGlyphScanlines(adaptor, glyph, currentX, currentY)
-			}
-
-		// GlyphDataMono: Go extension — C++ agg2d.cpp text() only handles outline and
-		// gray8; mono is rendered here for completeness when a font engine is configured
-		// for binary (non-AA) rasterization.
-		case font.GlyphDataMono:
-			if adaptor := fcm.MonoAdaptor(); adaptor != nil {
-				agg2d.renderGlyphScanlines(adaptor, glyph, currentX, currentY)
-			}
+			return false
 		}
-
-		currentX += glyph.AdvanceX
-		currentY += glyph.AdvanceY
-		prevGlyphIndex = glyph.GlyphIndex
-		firstGlyph = false
+		if g.outline == nil {
+			continue
+		}
+		mtx := transform.NewTransAffineTranslation(startX+g.x, startY+g.y)
+		if textTransform != nil {
+			mtx.Multiply(textTransform)
+		}
+		agg2d.path.ConcatPath(&transformedPathSource{src: g.outline, mtx: mtx}, 0)
+	}
+	if agg2d.textDecoration != DecorationNone {
+		agg2d.addTextDecorations(startX, startY, run.advanceX, agg2d.decorationMetrics(), agg2d.textUp(), textTransform)
 	}
+	return true
 }
 
 // transformedPathSource applies an affine transform while iterating a path source.
@@ -447,6 +537,7 @@ type glyphBitmapRasterizer struct {
 	offsetX  int
 	offsetY  int
 	row      int
+	gamma    *[256]uint8 // optional gray8 coverage table
 }
 
 func newGlyphBitmapRasterizer(adaptor font.SerializedScanlinesAdaptor, dataType font.GlyphDataType, x, y float64) *glyphBitmapRasterizer {
@@ -568,6 +659,9 @@ func (r *glyphBitmapRasterizer) SweepScanline(sl renscan.ScanlineInterface) bool
 				var cov basics.Int8u
 				if col < len(rowData) {
 					cov = basics.Int8u(rowData[col])
+					if r.gamma != nil {
+						cov = basics.Int8u(r.gamma[cov])
+					}
 				}
 				if cov == 0 {
 					flush()
@@ -608,12 +702,17 @@ func (agg2d *Agg2D) renderGlyphScanlines(adaptor font.SerializedScanlinesAdaptor
 	if ras == nil {
 		return
 	}
+	ras.gamma = agg2d.glyphGammaTable()
 
 	agg2d.renderScanlines(ras, agg2d.scanline, glyph.DataType == font.GlyphDataMono)
 }
 
 // renderScanlines renders scanlines using the provided rasterizer and scanline adaptors.
 func (agg2d *Agg2D) renderScanlines(ras renscan.RasterizerInterface, sl renscan.ScanlineInterface, mono bool) {
+	if agg2d.measuring {
+		agg2d.measureSweep(ras, sl)
+		return
+	}
 	renderer := agg2d.currentRenderer()
 	if renderer == nil {
 		return
@@ -0,0 +1,102 @@
+package agg2d
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/fonts"
+	"github.com/MeKo-Christian/agg_go/internal/glyph"
+	"github.com/MeKo-Christian/agg_go/internal/renderer"
+)
+
+// DefaultBitmapFont is the embedded raster font Text uses until a font file
+// is loaded.
+var DefaultBitmapFont = fonts.GSE8x16
+
+// BitmapFont selects the embedded raster font (AGG glyph_raster_bin format)
+// that Text falls back to when no font file is loaded and FontGSV is not
+// active. nil restores DefaultBitmapFont.
+func (agg2d *Agg2D) BitmapFont(data []byte) {
+	if data == nil {
+		data = DefaultBitmapFont
+	}
+	agg2d.bitmapGlyphs().SetFont(data)
+}
+
+// GetBitmapFont returns the raster font data used by the bitmap fallback.
+func (agg2d *Agg2D) GetBitmapFont() []byte {
+	return agg2d.bitmapGlyphs().Font()
+}
+
+func (agg2d *Agg2D) bitmapGlyphs() *glyph.GlyphRasterBin {
+	if agg2d.bitmapFont == nil {
+		agg2d.bitmapFont = glyph.NewGlyphRasterBin(DefaultBitmapFont)
+	}
+	return agg2d.bitmapFont
+}
+
+// useBitmapFont reports whether text goes through the bitmap fallback: no
+// GSV font is active and there is no font engine, or loading the last font
+// file failed.
+func (agg2d *Agg2D) useBitmapFont() bool {
+	if agg2d.gsvFontMode {
+		return false
+	}
+	return agg2d.fontCacheManager == nil || (agg2d.fontFile != "" && !agg2d.fontLoaded)
+}
+
+// textBitmap renders str with the bitmap fallback font through AGG's
+// renderer_raster_htext_solid. Glyphs are pixel-aligned at the screen
+// position of (x, y), which is the baseline start; the transform moves the
+// text but does not scale or rotate it.
+func (agg2d *Agg2D) textBitmap(x, y float64, str string, dx, dy float64) {
+	ren := agg2d.currentRenderer()
+	if ren == nil || str == "" {
+		return
+	}
+	g := agg2d.bitmapGlyphs()
+
+	agg2d.WorldToScreen(&x, &y)
+	switch agg2d.textAlignX {
+	case AlignCenter:
+		x -= g.Width(str) * 0.5
+	case AlignRight:
+		x -= g.Width(str)
+	}
+	// The font baseline is measured from the bottom of the glyph cell.
+	ascent := g.Height() - g.BaseLine()
+	switch agg2d.textAlignY {
+	case AlignCenter:
+		y += ascent * 0.5
+	case AlignTop:
+		y += ascent
+	}
+
+	c := color.RGBA8[color.Linear]{
+		R: agg2d.fillColor[0],
+		G: agg2d.fillColor[1],
+		B: agg2d.fillColor[2],
+		A: agg2d.fillColor[3],
+	}
+	if agg2d.masterAlpha != 1.0 {
+		c.A = uint8(float64(c.A) * agg2d.masterAlpha)
+	}
+
+	// Unflipped, glyph_raster_bin places the cell top at y-baseline+1. Move
+	// it so the font baseline, counted up from the cell bottom, sits on y.
+	baseline := float64(int(y + dy))
+	y += 2*g.BaseLine() - g.Height() - 1
+
+	if agg2d.measuring {
+		meter := renderer.NewRendererRasterHTextSolid[coverageMeter, *glyph.GlyphRasterBin, color.RGBA8[color.Linear]](coverageMeter{agg2d}, g)
+		meter.RenderText(float64(int(x+dx)), float64(int(y+dy)), str, false)
+	} else {
+		text := renderer.NewRendererRasterHTextSolid[*baseRendererAdapter[color.RGBA8[color.Linear]], *glyph.GlyphRasterBin, color.RGBA8[color.Linear]](ren, g)
+		text.SetColor(c)
+		text.RenderText(float64(int(x+dx)), float64(int(y+dy)), str, false)
+	}
+
+	if agg2d.textDecoration != DecorationNone {
+		agg2d.path.RemoveAll()
+		agg2d.addTextDecorations(float64(int(x+dx)), baseline, g.Width(str), agg2d.decorationMetrics(), -1, nil)
+		agg2d.DrawPathNoTransform(FillOnly)
+	}
+}
@@ -0,0 +1,187 @@
+package agg2d
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/glyph"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// TextDecoration selects the lines Text draws along the text: any
+// combination of DecorationUnderline, DecorationOverline and
+// DecorationLineThrough.
+type TextDecoration int
+
+const (
+	DecorationUnderline TextDecoration = 1 << iota
+	DecorationOverline
+	DecorationLineThrough
+
+	// DecorationNone draws plain text.
+	DecorationNone TextDecoration = 0
+)
+
+// DecorationMetrics places the decoration lines of the current font. The
+// positions are the distances of the line centers above the baseline, in
+// the units of TextWidth; the underline is usually negative.
+type DecorationMetrics struct {
+	Underline   float64
+	Overline    float64
+	LineThrough float64
+	Thickness   float64
+}
+
+// SetTextDecoration selects the lines drawn with subsequent text. They
+// span the advance of the string and are painted like the glyphs, with the
+// fill paint, following the text transform and rotation.
+func (agg2d *Agg2D) SetTextDecoration(d TextDecoration) {
+	agg2d.textDecoration = d
+}
+
+// GetTextDecoration returns the lines drawn with text.
+func (agg2d *Agg2D) GetTextDecoration() TextDecoration {
+	return agg2d.textDecoration
+}
+
+// TextDecorationMetrics returns where the decoration lines of the current
+// font go. Outline fonts use the underline position and thickness stored in
+// the font; the other lines, and fonts without those values, are placed
+// from the font height.
+func (agg2d *Agg2D) TextDecorationMetrics() DecorationMetrics {
+	m := agg2d.decorationMetrics()
+	if agg2d.useBitmapFont() || agg2d.fontCacheType == RasterFontCache && !agg2d.gsvFontMode {
+		m.Underline = agg2d.ScreenToWorldScalar(m.Underline)
+		m.Overline = agg2d.ScreenToWorldScalar(m.Overline)
+		m.LineThrough = agg2d.ScreenToWorldScalar(m.LineThrough)
+		m.Thickness = agg2d.ScreenToWorldScalar(m.Thickness)
+	}
+	return m
+}
+
+// decorationMetrics returns the metrics in the units the text is laid out
+// in: device pixels for bitmap and raster fonts.
+func (agg2d *Agg2D) decorationMetrics() DecorationMetrics {
+	if agg2d.gsvFontMode {
+		// GSV glyphs are stroked at 8% of the height; match that.
+		h := agg2d.fontHeight
+		return DecorationMetrics{Underline: -0.15 * h, Overline: 1.15 * h, LineThrough: 0.4 * h, Thickness: 0.08 * h}
+	}
+	if agg2d.useBitmapFont() {
+		return bitmapDecorationMetrics(agg2d.bitmapGlyphs())
+	}
+
+	h := agg2d.fontHeight
+	if agg2d.fontCacheType == RasterFontCache {
+		h = agg2d.WorldToScreenScalar(h)
+	}
+	m := DecorationMetrics{Underline: -0.1 * h, Overline: 0.8 * h, LineThrough: 0.25 * h, Thickness: h / 14}
+	if e := agg2d.fontEngine; e != nil && agg2d.fontLoaded {
+		if t := e.GetUnderlineThickness(); t > 0 {
+			m.Underline, m.Thickness = e.GetUnderlinePosition(), t
+		}
+		if a := e.GetAscender(); a > 0 {
+			m.Overline = a
+		}
+	}
+	if x := agg2d.fontCacheManager.Glyph(uint('x')); x != nil && x.Bounds.Y2 > x.Bounds.Y1 {
+		m.LineThrough = float64(x.Bounds.Y2-x.Bounds.Y1) / 2
+	}
+	return m
+}
+
+// bitmapDecorationMetrics places one-pixel lines on pixel rows of a bitmap
+// font: one row below the letters with a row of gap, one row above the
+// capitals with a row of gap, and through the middle of the x-height. The
+// embedded fonts often declare the cell bottom as their baseline, so the
+// rows are found from the ink of 'H' and 'x' rather than the font header.
+func bitmapDecorationMetrics(g *glyph.GlyphRasterBin) DecorationMetrics {
+	// Offsets are measured from the y text is drawn at, which textBitmap
+	// puts BaseLine rows above the cell bottom.
+	cellTop := g.Height() - g.BaseLine()
+	capTop, base, ok := bitmapInkRows(g, 'H')
+	if !ok {
+		return DecorationMetrics{Underline: -1.5, Overline: cellTop + 0.5, LineThrough: math.Floor(cellTop/3) + 0.5, Thickness: 1}
+	}
+	xTop, _, ok := bitmapInkRows(g, 'x')
+	if !ok {
+		xTop = (capTop + base) / 2
+	}
+	baseline := cellTop - base - 1 // bottom edge of the letters
+	xHeight := base - xTop + 1
+	capHeight := base - capTop + 1
+	return DecorationMetrics{
+		Underline:   baseline - 1.5,
+		Overline:    baseline + capHeight + 1.5,
+		LineThrough: baseline + math.Floor(xHeight/2) + 0.5,
+		Thickness:   1,
+	}
+}
+
+// bitmapInkRows returns the first and last rows of the glyph cell of r,
+// counted from the top, that have ink.
+func bitmapInkRows(g *glyph.GlyphRasterBin, r rune) (top, bottom float64, ok bool) {
+	var rect glyph.GlyphRect
+	g.Prepare(&rect, 0, 0, r, false)
+	if rect.X2 < rect.X1 {
+		return 0, 0, false
+	}
+	h := int(g.Height())
+	first, last := -1, -1
+	for row := 0; row < h; row++ {
+		span := g.Span(h - 1 - row)
+		for _, c := range span[:min(len(span), rect.X2-rect.X1+1)] {
+			if c != 0 {
+				if first < 0 {
+					first = row
+				}
+				last = row
+				break
+			}
+		}
+	}
+	return float64(first), float64(last), first >= 0
+}
+
+// addTextDecorations appends the decoration rectangles of a string of the
+// given advance, with its baseline starting at (x, y), to the path. up is
+// the direction of the glyph ascent along y (-1 when text is flipped for a
+// y-down buffer); mtx, if not nil, rotates the lines with the text.
+func (agg2d *Agg2D) addTextDecorations(x, y, width float64, m DecorationMetrics, up float64, mtx *transform.TransAffine) {
+	d := agg2d.textDecoration
+	for _, line := range []struct {
+		flag   TextDecoration
+		offset float64
+	}{
+		{DecorationUnderline, m.Underline},
+		{DecorationOverline, m.Overline},
+		{DecorationLineThrough, m.LineThrough},
+	} {
+		if d&line.flag == 0 {
+			continue
+		}
+		y1 := y + up*(line.offset-m.Thickness/2)
+		y2 := y + up*(line.offset+m.Thickness/2)
+		corners := [4][2]float64{{x, y1}, {x + width, y1}, {x + width, y2}, {x, y2}}
+		for i, c := range corners {
+			if mtx != nil {
+				mtx.Transform(&c[0], &c[1])
+			}
+			if i == 0 {
+				agg2d.path.MoveTo(c[0], c[1])
+			} else {
+				agg2d.path.LineTo(c[0], c[1])
+			}
+		}
+		agg2d.path.ClosePolygon(basics.PathFlagsNone)
+	}
+}
+
+// textUp returns the direction of the glyph ascent along y for outline and
+// raster fonts.
+func (agg2d *Agg2D) textUp() float64 {
+	if agg2d.flipText {
+		return -1
+	}
+	return 1
+}
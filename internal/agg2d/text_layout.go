@@ -0,0 +1,265 @@
+package agg2d
+
+import (
+	"math"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/MeKo-Christian/agg_go/internal/font/shaping"
+)
+
+// TextBox is an axis-aligned box in text layout coordinates.
+type TextBox struct {
+	X1, Y1, X2, Y2 float64
+}
+
+// TextCluster is the smallest piece of laid-out text that shaping keeps
+// together: a character, a ligature, or a letter and its combining marks.
+type TextCluster struct {
+	Start, End int     // byte range of the cluster in the text
+	Box        TextBox // the cluster advance by the font ascent and descent
+	RTL        bool    // the cluster runs right to left
+}
+
+// TextLayout is the geometry of one string laid out with the font settings
+// it was created with, for building text editors: cluster boxes, caret
+// positions, hit testing and selection boxes.
+//
+// Coordinates are in the units of TextWidth and relative to the point text
+// is drawn at with AlignLeft and AlignBottom: x runs along the baseline and
+// the boxes span the font ascent and descent on the side the glyphs are
+// drawn upright on. Text indices are byte offsets into the string; they
+// are moved back to the start of the rune they fall in.
+type TextLayout struct {
+	text     string
+	offsets  []int           // byte offset of every rune, then len(text)
+	clusters []layoutCluster // visual order
+	width    float64
+	ascent   float64
+	descent  float64
+	up       float64 // direction of the ascent along y
+	y1, y2   float64
+}
+
+// layoutCluster is a TextCluster with its rune range.
+type layoutCluster struct {
+	TextCluster
+	first, last int // runes [first, last)
+}
+
+// TextLayout lays out str with the current font and text settings. Text
+// laid out with the GSV or the bitmap font has one cluster per character.
+func (agg2d *Agg2D) TextLayout(str string) *TextLayout {
+	l := &TextLayout{text: str}
+	for i := range str {
+		l.offsets = append(l.offsets, i)
+	}
+	l.offsets = append(l.offsets, len(str))
+	runes := []rune(str)
+
+	var marks []glyphRunCluster
+	scale, up := 1.0, -1.0
+	switch {
+	case agg2d.gsvFontMode:
+		for i := range runes {
+			marks = append(marks, glyphRunCluster{index: i, x: agg2d.TextWidth(str[:l.offsets[i]])})
+		}
+		l.width = agg2d.TextWidth(str)
+		// GSV glyphs stand on the baseline and are fontHeight tall;
+		// descenders reach about a quarter of that below it.
+		l.ascent, l.descent = agg2d.fontHeight, 0.25*agg2d.fontHeight
+	case agg2d.useBitmapFont():
+		g := agg2d.bitmapGlyphs()
+		scale = agg2d.ScreenToWorldScalar(1)
+		for i := range runes {
+			marks = append(marks, glyphRunCluster{index: i, x: g.Width(str[:l.offsets[i]])})
+		}
+		l.width = g.Width(str)
+		l.ascent, l.descent = g.Height()-g.BaseLine(), g.BaseLine()
+	default:
+		run := agg2d.glyphRun(str)
+		if run == nil {
+			return l
+		}
+		if agg2d.fontCacheType == RasterFontCache {
+			scale = agg2d.ScreenToWorldScalar(1)
+		}
+		up = agg2d.textUp()
+		marks = run.clusters
+		l.width = run.advanceX
+		l.ascent, l.descent = agg2d.GetAscender(), -agg2d.GetDescender()
+		if l.ascent <= 0 && l.descent <= 0 {
+			l.ascent, l.descent = agg2d.FontHeight(), 0
+			if agg2d.fontCacheType == RasterFontCache {
+				l.ascent = agg2d.WorldToScreenScalar(l.ascent)
+			}
+		}
+	}
+	l.width *= scale
+	l.ascent *= scale
+	l.descent *= scale
+	l.up = up
+	l.y1 = math.Min(up*l.ascent, -up*l.descent)
+	l.y2 = math.Max(up*l.ascent, -up*l.descent)
+
+	// A cluster covers the runes up to the next cluster start in logical
+	// order; runes before the first start belong to the first cluster.
+	starts := make([]int, 0, len(marks))
+	for _, m := range marks {
+		starts = append(starts, m.index)
+	}
+	sort.Ints(starts)
+	rtl := shaping.RightToLeft(runes)
+	for k, m := range marks {
+		first := min(max(m.index, 0), len(runes))
+		if first == starts[0] {
+			first = 0
+		}
+		last := len(runes)
+		if j := sort.SearchInts(starts, m.index+1); j < len(starts) {
+			last = starts[j]
+		}
+		x1 := m.x * scale
+		x2 := l.width
+		if k+1 < len(marks) {
+			x2 = marks[k+1].x * scale
+		}
+		c := layoutCluster{first: first, last: last}
+		c.Start, c.End = l.offsets[first], l.offsets[last]
+		c.Box = TextBox{X1: math.Min(x1, x2), Y1: l.y1, X2: math.Max(x1, x2), Y2: l.y2}
+		c.RTL = first < len(rtl) && rtl[first]
+		l.clusters = append(l.clusters, c)
+	}
+	return l
+}
+
+// Text returns the laid-out string.
+func (l *TextLayout) Text() string { return l.text }
+
+// Width returns the advance of the whole string, like TextWidth.
+func (l *TextLayout) Width() float64 { return l.width }
+
+// Ascent returns the height of the boxes above the baseline.
+func (l *TextLayout) Ascent() float64 { return l.ascent }
+
+// Descent returns the depth of the boxes below the baseline.
+func (l *TextLayout) Descent() float64 { return l.descent }
+
+// Bounds returns the box of the whole string.
+func (l *TextLayout) Bounds() TextBox {
+	return TextBox{X1: 0, Y1: l.y1, X2: l.width, Y2: l.y2}
+}
+
+// Clusters returns the clusters in visual order, left to right.
+func (l *TextLayout) Clusters() []TextCluster {
+	out := make([]TextCluster, len(l.clusters))
+	for i, c := range l.clusters {
+		out[i] = c.TextCluster
+	}
+	return out
+}
+
+// CaretX returns the x of the caret in front of the character at index, or
+// behind the last character for index len(text). Carets inside a ligature
+// divide its advance evenly between the characters it was formed from; in
+// right-to-left clusters the caret in front of a character is on its right.
+func (l *TextLayout) CaretX(index int) float64 {
+	if len(l.clusters) == 0 {
+		return 0
+	}
+	r := l.runeIndex(index)
+	if r == len(l.offsets)-1 && r > 0 {
+		// The end of the text is the trailing edge of its last character.
+		return l.clusters[l.clusterOf(r-1)].x(r)
+	}
+	return l.clusters[l.clusterOf(r)].x(r)
+}
+
+// Caret returns a zero-width box for the caret at index, spanning the
+// ascent and descent.
+func (l *TextLayout) Caret(index int) TextBox {
+	x := l.CaretX(index)
+	return TextBox{X1: x, Y1: l.y1, X2: x, Y2: l.y2}
+}
+
+// HitTest returns the index of the caret position closest to x. Points
+// before or after the text hit its first or last cluster.
+func (l *TextLayout) HitTest(x float64) int {
+	if len(l.clusters) == 0 {
+		return 0
+	}
+	hit := &l.clusters[len(l.clusters)-1]
+	for i := range l.clusters {
+		c := &l.clusters[i]
+		if c.Box.X2 > c.Box.X1 && x < c.Box.X2 {
+			hit = c
+			break
+		}
+	}
+	t := 0.0
+	if w := hit.Box.X2 - hit.Box.X1; w > 0 {
+		t = math.Min(math.Max((x-hit.Box.X1)/w, 0), 1)
+	}
+	if hit.RTL {
+		t = 1 - t
+	}
+	r := hit.first + int(math.Round(t*float64(hit.last-hit.first)))
+	return l.offsets[r]
+}
+
+// Selection returns the boxes covering the text between the indices start
+// and end, in visual order. Text mixing directions can need several boxes;
+// adjacent ones are merged.
+func (l *TextLayout) Selection(start, end int) []TextBox {
+	r1, r2 := l.runeIndex(start), l.runeIndex(end)
+	if r1 > r2 {
+		r1, r2 = r2, r1
+	}
+	var boxes []TextBox
+	for _, c := range l.clusters {
+		a, b := max(r1, c.first), min(r2, c.last)
+		if a >= b {
+			continue
+		}
+		xa, xb := c.x(a), c.x(b)
+		box := TextBox{X1: math.Min(xa, xb), Y1: l.y1, X2: math.Max(xa, xb), Y2: l.y2}
+		if n := len(boxes); n > 0 && math.Abs(boxes[n-1].X2-box.X1) < 1e-9 {
+			boxes[n-1].X2 = box.X2
+			continue
+		}
+		boxes = append(boxes, box)
+	}
+	return boxes
+}
+
+// runeIndex returns the rune that byte index falls in, clamped to the text.
+func (l *TextLayout) runeIndex(index int) int {
+	index = min(max(index, 0), len(l.text))
+	for index > 0 && index < len(l.text) && !utf8.RuneStart(l.text[index]) {
+		index--
+	}
+	return sort.SearchInts(l.offsets, index)
+}
+
+// clusterOf returns the cluster holding rune r.
+func (l *TextLayout) clusterOf(r int) int {
+	for i, c := range l.clusters {
+		if r >= c.first && r < c.last {
+			return i
+		}
+	}
+	return 0
+}
+
+// x returns the position of the caret in front of rune r of the cluster,
+// or behind it for r == c.last.
+func (c *layoutCluster) x(r int) float64 {
+	t := 0.0
+	if n := c.last - c.first; n > 0 {
+		t = float64(r-c.first) / float64(n)
+	}
+	if c.RTL {
+		t = 1 - t
+	}
+	return c.Box.X1 + t*(c.Box.X2-c.Box.X1)
+}
@@ -0,0 +1,80 @@
+package agg2d
+
+import "testing"
+
+func TestTextLayoutLeftToRight(t *testing.T) {
+	agg2d := newShaperTestAgg2D()
+	l := agg2d.TextLayout("aa")
+	if l.Width() != 4 || len(l.Clusters()) != 2 {
+		t.Fatalf("width=%v clusters=%d, want 4 and 2", l.Width(), len(l.Clusters()))
+	}
+	for i, want := range []float64{0, 2, 4} {
+		if got := l.CaretX(i); got != want {
+			t.Fatalf("CaretX(%d)=%v, want %v", i, got, want)
+		}
+	}
+	for _, tc := range []struct {
+		x    float64
+		want int
+	}{{-5, 0}, {0.9, 0}, {1.1, 1}, {3.5, 2}, {100, 2}} {
+		if got := l.HitTest(tc.x); got != tc.want {
+			t.Fatalf("HitTest(%v)=%d, want %d", tc.x, got, tc.want)
+		}
+	}
+	if sel := l.Selection(2, 1); len(sel) != 1 || sel[0].X1 != 2 || sel[0].X2 != 4 {
+		t.Fatalf("Selection(2, 1)=%v, want one box from 2 to 4", sel)
+	}
+}
+
+func TestTextLayoutRightToLeft(t *testing.T) {
+	// Visual order: a [0,2], ב [2,6], א [6,9]; bytes: a 0, א 1, ב 3.
+	agg2d := newShaperTestAgg2D()
+	l := agg2d.TextLayout("aאב")
+	clusters := l.Clusters()
+	if len(clusters) != 3 {
+		t.Fatalf("got %d clusters, want 3", len(clusters))
+	}
+	if c := clusters[1]; c.Start != 3 || c.End != 5 || !c.RTL || c.Box.X1 != 2 || c.Box.X2 != 6 {
+		t.Fatalf("cluster 1 = %+v, want ב at [2,6] right to left", c)
+	}
+	for _, tc := range []struct {
+		index int
+		want  float64
+	}{{0, 0}, {1, 9}, {2, 9}, {3, 6}, {5, 2}} {
+		if got := l.CaretX(tc.index); got != tc.want {
+			t.Fatalf("CaretX(%d)=%v, want %v", tc.index, got, tc.want)
+		}
+	}
+	if got := l.HitTest(8); got != 1 {
+		t.Fatalf("HitTest(8)=%d, want 1", got)
+	}
+	if got := l.HitTest(6.5); got != 3 {
+		t.Fatalf("HitTest(6.5)=%d, want 3", got)
+	}
+	if sel := l.Selection(1, 5); len(sel) != 1 || sel[0].X1 != 2 || sel[0].X2 != 9 {
+		t.Fatalf("Selection(1, 5)=%v, want one box from 2 to 9", sel)
+	}
+	if sel := l.Selection(0, 3); len(sel) != 2 {
+		t.Fatalf("Selection(0, 3)=%v, want two boxes", sel)
+	}
+}
+
+func TestTextLayoutLigatureCarets(t *testing.T) {
+	agg2d, _ := newLigatureTestAgg2D()
+	agg2d.TextLigatures(true)
+	l := agg2d.TextLayout("fil")
+	clusters := l.Clusters()
+	if len(clusters) != 2 || clusters[0].Start != 0 || clusters[0].End != 2 {
+		t.Fatalf("clusters=%+v, want the fi ligature and l", clusters)
+	}
+	// The ligature's advance of 6 is split between f and i.
+	if got := l.CaretX(1); got != 3 {
+		t.Fatalf("CaretX(1)=%v, want 3", got)
+	}
+	if got := l.HitTest(3.5); got != 1 {
+		t.Fatalf("HitTest(3.5)=%d, want 1", got)
+	}
+	if got := l.CaretX(3); got != 9 {
+		t.Fatalf("CaretX(3)=%v, want 9", got)
+	}
+}
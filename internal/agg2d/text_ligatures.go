@@ -0,0 +1,62 @@
+package agg2d
+
+// standardLigatures lists the Latin standard ligatures (OpenType "liga") that
+// have precomposed code points in the Alphabetic Presentation Forms block,
+// longest sequences first so "ffi" wins over "ff".
+var standardLigatures = []struct {
+	seq []rune
+	lig rune
+}{
+	{[]rune("ffi"), 'ﬃ'},
+	{[]rune("ffl"), 'ﬄ'},
+	{[]rune("ff"), 'ﬀ'},
+	{[]rune("fi"), 'ﬁ'},
+	{[]rune("fl"), 'ﬂ'},
+}
+
+// matchLigature returns the ligature code point for the longest standard
+// ligature starting at runes[i] and the number of runes it replaces, or
+// (0, 0) when none starts there.
+func matchLigature(runes []rune, i int) (rune, int) {
+	for _, l := range standardLigatures {
+		n := len(l.seq)
+		if i+n > len(runes) {
+			continue
+		}
+		match := true
+		for k, r := range l.seq {
+			if runes[i+k] != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return l.lig, n
+		}
+	}
+	return 0, 0
+}
+
+// TextKerning enables or disables pair kerning from the font engine. Kerning
+// is enabled by default.
+func (agg2d *Agg2D) TextKerning(kerning bool) {
+	agg2d.textKerning = kerning
+}
+
+// GetTextKerning returns whether pair kerning is applied to text.
+func (agg2d *Agg2D) GetTextKerning() bool {
+	return agg2d.textKerning
+}
+
+// TextLigatures enables or disables standard ligature substitution (fi, fl,
+// ff, ffi, ffl). Ligatures are only used when the current font provides the
+// precomposed glyph; otherwise the individual characters are drawn. They are
+// disabled by default.
+func (agg2d *Agg2D) TextLigatures(ligatures bool) {
+	agg2d.textLigatures = ligatures
+}
+
+// GetTextLigatures returns whether standard ligatures are substituted.
+func (agg2d *Agg2D) GetTextLigatures() bool {
+	return agg2d.textLigatures
+}
@@ -0,0 +1,72 @@
+package agg2d
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+)
+
+func newLigatureTestAgg2D() (*Agg2D, *mockTextFontEngine) {
+	engine := newMockTextFontEngine()
+	bounds := basics.Rect[int]{X1: 0, Y1: 0, X2: 2, Y2: 2}
+	engine.glyphs[uint('f')] = mockOutlineGlyph{glyphIndex: 10, advanceX: 4, bounds: bounds}
+	engine.glyphs[uint('i')] = mockOutlineGlyph{glyphIndex: 11, advanceX: 3, bounds: bounds}
+	engine.glyphs[uint('l')] = mockOutlineGlyph{glyphIndex: 12, advanceX: 3, bounds: bounds}
+	engine.glyphs[uint('ﬁ')] = mockOutlineGlyph{glyphIndex: 20, advanceX: 6, bounds: bounds}
+	engine.kerning[[2]uint{10, 11}] = -1
+
+	agg2d := NewAgg2D()
+	buf := make([]byte, 16*16*4)
+	agg2d.Attach(buf, 16, 16, 16*4)
+	agg2d.fontCacheType = VectorFontCache
+	agg2d.fontCacheManager = font.NewFontCacheManager(engine, 32)
+	return agg2d, engine
+}
+
+func TestTextKerningCanBeDisabled(t *testing.T) {
+	agg2d, _ := newLigatureTestAgg2D()
+	if !agg2d.GetTextKerning() {
+		t.Fatal("kerning should be enabled by default")
+	}
+	if got := agg2d.TextWidth("fi"); got != 6 {
+		t.Fatalf("kerned TextWidth(fi)=%v, want 6", got)
+	}
+	agg2d.TextKerning(false)
+	if got := agg2d.TextWidth("fi"); got != 7 {
+		t.Fatalf("unkerned TextWidth(fi)=%v, want 7", got)
+	}
+}
+
+func TestTextLigaturesSubstituteAvailableGlyphs(t *testing.T) {
+	agg2d, _ := newLigatureTestAgg2D()
+	agg2d.TextKerning(false)
+	agg2d.TextLigatures(true)
+
+	if got := agg2d.TextWidth("fi"); got != 6 {
+		t.Fatalf("TextWidth(fi) with ligatures=%v, want 6", got)
+	}
+	// No "fl" glyph in the font: fall back to the individual characters.
+	if got := agg2d.TextWidth("fl"); got != 7 {
+		t.Fatalf("TextWidth(fl) with ligatures=%v, want 7", got)
+	}
+	// "ffi" has no glyph either, but its "fi" tail does.
+	if got := agg2d.TextWidth("ffi"); got != 10 {
+		t.Fatalf("TextWidth(ffi) with ligatures=%v, want 10", got)
+	}
+
+	agg2d.TextLigatures(false)
+	if got := agg2d.TextWidth("fi"); got != 7 {
+		t.Fatalf("TextWidth(fi) without ligatures=%v, want 7", got)
+	}
+}
+
+func TestMatchLigaturePrefersLongest(t *testing.T) {
+	lig, n := matchLigature([]rune("affix"), 1)
+	if lig != 'ﬃ' || n != 3 {
+		t.Fatalf("matchLigature=%q,%d want ffi ligature over 3 runes", lig, n)
+	}
+	if _, n := matchLigature([]rune("fa"), 0); n != 0 {
+		t.Fatalf("unexpected ligature match of length %d", n)
+	}
+}
@@ -0,0 +1,152 @@
+package agg2d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+func squareGlyph(index uint) mockOutlineGlyph {
+	return mockOutlineGlyph{
+		glyphIndex: index,
+		advanceX:   10,
+		bounds:     basics.Rect[int]{X1: 0, Y1: 0, X2: 6, Y2: 6},
+		buildPath: func(ps *path.PathStorageStl) {
+			ps.MoveTo(0, 0)
+			ps.LineTo(6, 0)
+			ps.LineTo(6, 6)
+			ps.LineTo(0, 6)
+			ps.ClosePolygon(basics.PathFlagsNone)
+		},
+	}
+}
+
+func TestTextOutlineCollectsGlyphs(t *testing.T) {
+	engine := newMockTextFontEngine()
+	engine.glyphs['A'] = squareGlyph(1)
+	engine.glyphs['B'] = squareGlyph(2)
+
+	agg2d := NewAgg2D()
+	agg2d.Attach(make([]byte, 40*20*4), 40, 20, 40*4)
+	if agg2d.TextOutline(4, 4, "AB", false) {
+		t.Fatal("TextOutline succeeded with the bitmap font")
+	}
+
+	agg2d.fontCacheType = VectorFontCache
+	agg2d.fontCacheManager = font.NewFontCacheManager(engine, 32)
+	if !agg2d.TextOutline(4, 4, "AB", false) {
+		t.Fatal("TextOutline failed with an outline font")
+	}
+
+	moves := 0
+	minX, maxX := 1e9, -1e9
+	agg2d.path.Rewind(0)
+	for {
+		x, y, cmd := agg2d.path.NextVertex()
+		if basics.IsStop(basics.PathCommand(cmd)) {
+			break
+		}
+		if basics.IsMoveTo(basics.PathCommand(cmd)) {
+			moves++
+		}
+		if basics.IsVertex(basics.PathCommand(cmd)) {
+			minX, maxX = min(minX, x), max(maxX, x)
+			if y < 4 || y > 10 {
+				t.Errorf("vertex y = %v outside the glyph row", y)
+			}
+		}
+	}
+	if moves != 2 || minX != 4 || maxX != 20 {
+		t.Errorf("outline has %d contours spanning x %v..%v, want 2 spanning 4..20", moves, minX, maxX)
+	}
+}
+
+func TestPaintStateRoundTrip(t *testing.T) {
+	agg2d := NewAgg2D()
+	buf := make([]byte, 16*16*4)
+	agg2d.Attach(buf, 16, 16, 16*4)
+	agg2d.FillLinearGradient(0, 0, 16, 0, Color{255, 0, 0, 255}, Color{0, 0, 255, 255}, 1)
+	agg2d.LineColor(Color{0, 255, 0, 255})
+	agg2d.SetLinePaintStyle(0.5, BlendInherit)
+	saved := agg2d.PaintState()
+
+	agg2d.NoLine()
+	agg2d.Rectangle(0, 0, 16, 16)
+	want := append([]byte(nil), buf...)
+	agg2d.SetPaintState(saved)
+
+	agg2d.FillColor(Color{1, 2, 3, 4})
+	agg2d.LineColor(Color{5, 6, 7, 8})
+	agg2d.SetPaintState(saved)
+
+	if agg2d.FillGradientFlag() != Linear {
+		t.Errorf("fill gradient flag = %v, want Linear", agg2d.FillGradientFlag())
+	}
+	if agg2d.GetLineColor() != (Color{0, 255, 0, 255}) {
+		t.Errorf("line color = %v", agg2d.GetLineColor())
+	}
+	if opacity, _ := agg2d.LinePaintStyle(); opacity != 0.5 {
+		t.Errorf("line opacity = %v, want 0.5", opacity)
+	}
+
+	clear(buf)
+	agg2d.NoLine()
+	agg2d.Rectangle(0, 0, 16, 16)
+	if string(buf) != string(want) {
+		t.Error("restored gradient renders differently")
+	}
+}
+
+func TestTextOutlineDecorations(t *testing.T) {
+	engine := newMockTextFontEngine()
+	engine.glyphs['A'] = squareGlyph(1)
+
+	agg2d := NewAgg2D()
+	agg2d.Attach(make([]byte, 40*20*4), 40, 20, 40*4)
+	agg2d.fontCacheType = VectorFontCache
+	agg2d.fontCacheManager = font.NewFontCacheManager(engine, 32)
+	agg2d.fontHeight = 10
+	agg2d.SetTextDecoration(DecorationUnderline | DecorationLineThrough)
+
+	m := agg2d.TextDecorationMetrics()
+	if m.Underline >= 0 || m.Thickness <= 0 || m.LineThrough <= 0 {
+		t.Fatalf("metrics %+v", m)
+	}
+	if !agg2d.TextOutline(4, 10, "A", false) {
+		t.Fatal("TextOutline failed")
+	}
+
+	// The glyph square, then the underline and the line-through, each
+	// spanning the advance.
+	var contours [][]float64
+	agg2d.path.Rewind(0)
+	for {
+		x, y, cmd := agg2d.path.NextVertex()
+		c := basics.PathCommand(cmd)
+		if basics.IsStop(c) {
+			break
+		}
+		if basics.IsMoveTo(c) {
+			contours = append(contours, nil)
+		}
+		if basics.IsVertex(c) {
+			contours[len(contours)-1] = append(contours[len(contours)-1], x, y)
+		}
+	}
+	if len(contours) != 3 {
+		t.Fatalf("got %d contours, want 3", len(contours))
+	}
+	for i, offset := range []float64{m.Underline, m.LineThrough} {
+		c := contours[i+1]
+		if c[0] != 4 || c[2] != 14 {
+			t.Errorf("line %d spans x %v..%v, want 4..14", i, c[0], c[2])
+		}
+		// Unflipped text has its ascent along +y.
+		if mid := (c[1] + c[5]) / 2; math.Abs(mid-(10+offset)) > 1e-9 {
+			t.Errorf("line %d centered at y=%v, want %v", i, mid, 10+offset)
+		}
+	}
+}
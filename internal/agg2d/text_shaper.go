@@ -0,0 +1,33 @@
+package agg2d
+
+import "github.com/MeKo-Christian/agg_go/internal/font/shaping"
+
+// SetTextShaper sets the shaper that orders and selects glyphs for Text and
+// TextWidth. nil restores the default shaping.SimpleShaper.
+func (agg2d *Agg2D) SetTextShaper(shaper shaping.TextShaper) {
+	agg2d.textShaper = shaper
+	agg2d.ClearGlyphRunCache()
+}
+
+// GetTextShaper returns the shaper set with SetTextShaper, or nil when the
+// default shaper is in use.
+func (agg2d *Agg2D) GetTextShaper() shaping.TextShaper {
+	return agg2d.textShaper
+}
+
+func (agg2d *Agg2D) shaper() shaping.TextShaper {
+	if agg2d.textShaper == nil {
+		return shaping.SimpleShaper{}
+	}
+	return agg2d.textShaper
+}
+
+// shapingFont describes the loaded font in the units the font engine
+// produces advances in.
+func (agg2d *Agg2D) shapingFont() shaping.Font {
+	f := shaping.Font{FileName: agg2d.fontFile, Size: agg2d.fontHeight}
+	if agg2d.fontEngine != nil {
+		f.Size = agg2d.fontEngine.GetHeight()
+	}
+	return f
+}
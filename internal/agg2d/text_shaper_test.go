@@ -0,0 +1,76 @@
+package agg2d
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/font"
+	"github.com/MeKo-Christian/agg_go/internal/font/shaping"
+)
+
+type fixedAdvanceShaper struct{ calls int }
+
+func (s *fixedAdvanceShaper) Shape(text string, _ shaping.Font) shaping.ShapedText {
+	s.calls++
+	out := shaping.ShapedText{Positioned: true}
+	for i, r := range text {
+		out.Glyphs = append(out.Glyphs, shaping.ShapedGlyph{Rune: r, Cluster: i, XAdvance: 5})
+	}
+	return out
+}
+
+func newShaperTestAgg2D() *Agg2D {
+	engine := newMockTextFontEngine()
+	bounds := basics.Rect[int]{X1: 0, Y1: 0, X2: 2, Y2: 2}
+	engine.glyphs[uint('a')] = mockOutlineGlyph{glyphIndex: 1, advanceX: 2, bounds: bounds}
+	engine.glyphs[uint('א')] = mockOutlineGlyph{glyphIndex: 2, advanceX: 3, bounds: bounds}
+	engine.glyphs[uint('ב')] = mockOutlineGlyph{glyphIndex: 3, advanceX: 4, bounds: bounds}
+
+	agg2d := NewAgg2D()
+	buf := make([]byte, 16*16*4)
+	agg2d.Attach(buf, 16, 16, 16*4)
+	agg2d.fontCacheType = VectorFontCache
+	agg2d.fontCacheManager = font.NewFontCacheManager(engine, 32)
+	return agg2d
+}
+
+func TestDefaultShaperReordersRightToLeftRuns(t *testing.T) {
+	agg2d := newShaperTestAgg2D()
+	run := agg2d.glyphRun("aאב")
+	if len(run.glyphs) != 3 {
+		t.Fatalf("got %d glyphs, want 3", len(run.glyphs))
+	}
+	wantIndex := []uint{1, 3, 2}
+	wantX := []float64{0, 2, 6}
+	for i, g := range run.glyphs {
+		if g.glyph.GlyphIndex != wantIndex[i] || g.x != wantX[i] {
+			t.Fatalf("glyph %d = index %d at x=%v, want index %d at x=%v",
+				i, g.glyph.GlyphIndex, g.x, wantIndex[i], wantX[i])
+		}
+	}
+	if run.advanceX != 9 {
+		t.Fatalf("advance=%v, want 9", run.advanceX)
+	}
+}
+
+func TestPositionedShaperAdvancesOverrideFontMetrics(t *testing.T) {
+	agg2d := newShaperTestAgg2D()
+	shaper := &fixedAdvanceShaper{}
+	agg2d.SetTextShaper(shaper)
+	if agg2d.GetTextShaper() != shaper {
+		t.Fatal("GetTextShaper did not return the installed shaper")
+	}
+
+	if got := agg2d.TextWidth("aa"); got != 10 {
+		t.Fatalf("TextWidth=%v, want 10", got)
+	}
+	agg2d.TextWidth("aa")
+	if shaper.calls != 1 {
+		t.Fatalf("shaper called %d times, want 1 (cached run)", shaper.calls)
+	}
+
+	agg2d.SetTextShaper(nil)
+	if got := agg2d.TextWidth("aa"); got != 4 {
+		t.Fatalf("TextWidth with default shaper=%v, want 4", got)
+	}
+}
@@ -1,8 +1,11 @@
 package agg2d
 
 import (
+	"math"
 	"os"
 	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/fonts"
 )
 
 // TestTextAlignmentText tests the text alignment functionality for text rendering.
@@ -89,10 +92,10 @@ func TestTextWidth(t *testing.T) {
 	buf := make([]byte, 800*600*4)
 	agg2d.Attach(buf, 800, 600, 800*4)
 
-	// Test with no font loaded
+	// Without a font the bitmap fallback measures 8 px per character.
 	width := agg2d.TextWidth("Hello World")
-	if width != 0.0 {
-		t.Errorf("Expected text width to be 0 with no font loaded, got %v", width)
+	if math.Abs(width-88) > 1e-9 {
+		t.Errorf("Expected bitmap fallback text width 88 with no font loaded, got %v", width)
 	}
 
 	// Test with actual font loading when FreeType is available
@@ -571,3 +574,44 @@ func findSystemFont() string {
 
 	return ""
 }
+
+// TestTextBitmapFallback checks that Text draws with the embedded bitmap font
+// when no font is loaded, with the glyphs resting on the baseline.
+func TestTextBitmapFallback(t *testing.T) {
+	const w, h = 40, 40
+	agg2d := NewAgg2D()
+	buf := make([]byte, w*h*4)
+	agg2d.Attach(buf, w, h, w*4)
+	agg2d.ClearAllRGBA(255, 255, 255, 255)
+	agg2d.FillColorRGBA(0, 0, 0, 255)
+
+	agg2d.Text(10, 30, "H", false, 0, 0)
+
+	minY, maxY, minX, maxX := h, -1, w, -1
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if buf[(y*w+x)*4] < 128 {
+				minY, maxY = min(minY, y), max(maxY, y)
+				minX, maxX = min(minX, x), max(maxX, x)
+			}
+		}
+	}
+	if maxY < 0 {
+		t.Fatal("bitmap fallback drew nothing")
+	}
+	if minY < 30-16 || maxY > 29 {
+		t.Errorf("glyph rows = %d..%d, want them inside the cell above the baseline", minY, maxY)
+	}
+	if minX < 10 || maxX >= 18 {
+		t.Errorf("glyph columns = %d..%d, want them inside 10..17", minX, maxX)
+	}
+
+	agg2d.BitmapFont(fonts.Verdana12)
+	if got, want := agg2d.TextWidth("Hi"), agg2d.bitmapGlyphs().Width("Hi"); math.Abs(got-want) > 1e-9 {
+		t.Errorf("TextWidth with Verdana12 = %v, want %v", got, want)
+	}
+	agg2d.BitmapFont(nil)
+	if got := len(agg2d.GetBitmapFont()); got != len(DefaultBitmapFont) {
+		t.Errorf("BitmapFont(nil) should restore the default font")
+	}
+}
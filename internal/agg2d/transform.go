@@ -156,6 +156,13 @@ func (agg2d *Agg2D) PushTransform() {
 	agg2d.transformStack.stack = append(agg2d.transformStack.stack, transformCopy)
 }
 
+// ClearTransformStack drops all transformations saved with PushTransform.
+func (agg2d *Agg2D) ClearTransformStack() {
+	if agg2d.transformStack != nil {
+		agg2d.transformStack.stack = agg2d.transformStack.stack[:0]
+	}
+}
+
 // PopTransform restores the most recently saved transformation state.
 // Returns false if no transformation was saved.
 func (agg2d *Agg2D) PopTransform() bool {
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/MeKo-Christian/agg_go/internal/path"
 	"github.com/MeKo-Christian/agg_go/internal/transform"
 )
 
@@ -580,3 +581,74 @@ func BenchmarkViewportTransform(b *testing.B) {
 		agg2d.Viewport(0, 0, 1000, 1000, 0, 0, 800, 600, XMidYMid)
 	}
 }
+
+func TestEffectiveApproximationScaleFollowsTransform(t *testing.T) {
+	agg2d := NewAgg2D()
+
+	if got := agg2d.EffectiveApproximationScale(); !floatEqual(got, 1.0, 1e-9) {
+		t.Fatalf("identity scale = %v, want 1", got)
+	}
+
+	agg2d.Scale(1, 50)
+	if got := agg2d.EffectiveApproximationScale(); !floatEqual(got, 50, 1e-9) {
+		t.Fatalf("non-uniform scale = %v, want the largest stretch 50", got)
+	}
+
+	agg2d.ResetTransformations()
+	agg2d.Rotate(0.7)
+	agg2d.Scale(8, 8)
+	agg2d.ApproximationScale(2)
+	if got := agg2d.EffectiveApproximationScale(); !floatEqual(got, 16, 1e-9) {
+		t.Fatalf("rotated scale with factor 2 = %v, want 16", got)
+	}
+	if got := agg2d.GetApproximationScale(); got != 2 {
+		t.Fatalf("GetApproximationScale() = %v, want the factor 2", got)
+	}
+}
+
+func TestAddEllipseTessellatesForZoom(t *testing.T) {
+	agg2d := NewAgg2D()
+	agg2d.AddEllipse(0, 0, 1, 1, CCW)
+	plain := agg2d.path.TotalVertices()
+
+	agg2d.ResetPath()
+	agg2d.Scale(200, 200)
+	agg2d.AddEllipse(0, 0, 1, 1, CCW)
+	zoomed := agg2d.path.TotalVertices()
+
+	if zoomed <= plain*4 {
+		t.Fatalf("zoomed ellipse has %d vertices, unzoomed %d; expected a much finer tessellation", zoomed, plain)
+	}
+}
+
+func TestApproximationScaleFollowsTransform(t *testing.T) {
+	ctx := createTestAgg2D()
+	ctx.ApproximationScale(2)
+	ctx.Scale(4, 4)
+
+	if got := ctx.convStroke.ApproximationScale(); !floatEqual(got, 8, 1e-10) {
+		t.Fatalf("stroke approximation scale = %f, want 8", got)
+	}
+	if got := ctx.convCurve.ApproximationScale(); !floatEqual(got, 8, 1e-10) {
+		t.Fatalf("curve approximation scale = %f, want 8", got)
+	}
+
+	ctx.ResetTransformations()
+	if got := ctx.convStroke.ApproximationScale(); !floatEqual(got, 2, 1e-10) {
+		t.Fatalf("stroke approximation scale after reset = %f, want 2", got)
+	}
+}
+
+func TestStrokeOutlineRoundCapApproximation(t *testing.T) {
+	src := path.NewPathStorageStl()
+	src.MoveTo(0, 0)
+	src.LineTo(10, 0)
+
+	opts := StrokeOptions{Width: 4, LineCap: CapRound}
+	coarse := StrokeOutline(src, opts).TotalVertices()
+	opts.ApproximationScale = 16
+	fine := StrokeOutline(src, opts).TotalVertices()
+	if fine <= coarse {
+		t.Fatalf("round caps should gain vertices with approximation scale: %d <= %d", fine, coarse)
+	}
+}
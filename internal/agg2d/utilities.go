@@ -77,6 +77,7 @@ func (agg2d *Agg2D) ScreenToWorldScalar(scalar float64) float64 {
 func (agg2d *Agg2D) NoFill() {
 	agg2d.fillColor = Color{0, 0, 0, 0} // Transparent fill
 	agg2d.fillGradientFlag = Solid
+	agg2d.fillStyle = defaultPaintStyle
 }
 
 // NoLine disables line/stroke rendering.
@@ -84,6 +85,7 @@ func (agg2d *Agg2D) NoFill() {
 func (agg2d *Agg2D) NoLine() {
 	agg2d.lineColor = Color{0, 0, 0, 0} // Transparent line
 	agg2d.lineGradientFlag = Solid
+	agg2d.lineStyle = defaultPaintStyle
 }
 
 // ResetStyle resets all style settings to their default values.
@@ -91,8 +93,11 @@ func (agg2d *Agg2D) NoLine() {
 func (agg2d *Agg2D) ResetStyle() {
 	agg2d.fillColor = White
 	agg2d.fillGradientFlag = Solid
+	agg2d.fillStyle = defaultPaintStyle
+	agg2d.SetFillAlphaGradient(nil)
 	agg2d.lineColor = Black
 	agg2d.lineGradientFlag = Solid
+	agg2d.lineStyle = defaultPaintStyle
 	agg2d.lineWidth = 1.0
 	agg2d.lineCap = CapRound
 	agg2d.lineJoin = JoinRound
@@ -102,8 +107,11 @@ func (agg2d *Agg2D) ResetStyle() {
 		agg2d.convStroke.SetWidth(1.0)
 		agg2d.convStroke.SetLineCap(basics.LineCap(CapRound))
 		agg2d.convStroke.SetLineJoin(basics.LineJoin(JoinRound))
+		agg2d.convStroke.SetInnerJoin(basics.InnerMiter)
 	}
 	agg2d.NoDashes()
+	agg2d.DashStart(0)
+	agg2d.ClearDashCap()
 	agg2d.textAlignX = AlignLeft
 	agg2d.textAlignY = AlignBottom
 }
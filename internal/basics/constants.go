@@ -16,13 +16,6 @@ const (
 	CoverFull  = CoverMask
 )
 
-// Poly subpixel scale enumeration
-const (
-	PolySubpixelShift = 8
-	PolySubpixelScale = 1 << PolySubpixelShift
-	PolySubpixelMask  = PolySubpixelScale - 1
-)
-
 // Filling rule enumeration
 type FillingRule int
 
@@ -0,0 +1,12 @@
+//go:build !subpixel16
+
+package basics
+
+// Poly subpixel scale enumeration. Rasterizer coordinates are fixed point
+// with PolySubpixelShift fractional bits, 8 as in AGG. Build with the
+// subpixel16 tag for 16 bits.
+const (
+	PolySubpixelShift = 8
+	PolySubpixelScale = 1 << PolySubpixelShift
+	PolySubpixelMask  = PolySubpixelScale - 1
+)
@@ -0,0 +1,14 @@
+//go:build subpixel16
+
+package basics
+
+// Poly subpixel scale enumeration, subpixel16 build. Rasterizer coordinates
+// have 16 fractional bits instead of AGG's 8: vertices snap to 1/65536 of a
+// pixel, so edges that should meet, such as a tile edge and the vertices of
+// its neighbors along it, no longer leave seams of a fraction of a coverage
+// level. The cell areas need 64-bit ints; the build is for 64-bit targets.
+const (
+	PolySubpixelShift = 16
+	PolySubpixelScale = 1 << PolySubpixelShift
+	PolySubpixelMask  = PolySubpixelScale - 1
+)
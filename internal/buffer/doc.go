@@ -3,4 +3,10 @@
 // It wraps pixel memory with width, height, stride, row, and cached-row access
 // helpers that other packages use as the common attachment point for pixfmts
 // and renderers.
+//
+// Buffers are generic over the element type, with aliases for the storage of
+// each pixel format family: RenderingBufferU8 for 8-bit channels,
+// RenderingBufferU16 for 16-bit ones (Gray16, RGB48, RGBA64) and
+// RenderingBufferF32 for float ones (Gray32, RGB96). Rows are slices of
+// elements; strides are always in bytes.
 package buffer
@@ -151,14 +151,15 @@ func (rb *RenderingBuffer[T]) RowData(y int) basics.ConstRowInfo[T] {
 	}
 }
 
-// CopyFrom copies data from another rendering buffer.
+// CopyFrom copies data from another rendering buffer: the rows both buffers
+// have, each up to the shorter of the two strides. As in AGG, whole rows are
+// copied, all the elements of every pixel included.
 func (rb *RenderingBuffer[T]) CopyFrom(src *RenderingBuffer[T]) {
 	if src == nil {
 		return
 	}
 
 	minHeight := basics.IMin(rb.height, src.height)
-	minWidth := basics.IMin(rb.width, src.width)
 
 	for y := 0; y < minHeight; y++ {
 		srcRow := src.Row(y)
@@ -169,8 +170,6 @@ func (rb *RenderingBuffer[T]) CopyFrom(src *RenderingBuffer[T]) {
 		}
 
 		copyLen := basics.IMin(len(srcRow), len(dstRow))
-		copyLen = basics.IMin(copyLen, minWidth)
-
 		copy(dstRow[:copyLen], srcRow[:copyLen])
 	}
 }
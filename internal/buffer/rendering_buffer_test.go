@@ -263,3 +263,47 @@ func TestRenderingBufferU8(t *testing.T) {
 		t.Errorf("RowU8 length expected %d, got %d", width, len(row))
 	}
 }
+
+// Test the uint16 and float32 buffers: strides are in bytes, rows in elements
+func TestRenderingBufferU16F32(t *testing.T) {
+	width, height := 3, 2
+
+	// RGBA with 16-bit channels: 4 values, 8 bytes per pixel
+	buf16 := make([]basics.Int16u, width*height*4)
+	rb16 := NewRenderingBufferU16WithData(buf16, width, height, width*8)
+	if rb16.Stride() != width*8 {
+		t.Errorf("U16 Stride() expected %d, got %d", width*8, rb16.Stride())
+	}
+	row := RowU16(rb16, 1)
+	if len(row) != width*4 {
+		t.Fatalf("RowU16 length expected %d, got %d", width*4, len(row))
+	}
+	row[len(row)-1] = 0xFFFF
+	if buf16[len(buf16)-1] != 0xFFFF {
+		t.Error("RowU16 should alias the last row of the buffer")
+	}
+	if p := rb16.RowPtr(4, 1, 4); len(p) != 4 || &p[0] != &buf16[width*4+4] {
+		t.Error("RowPtr should address elements, starting at the second pixel")
+	}
+
+	// CopyFrom copies whole rows, every channel of every pixel
+	dst16 := NewRenderingBufferU16WithData(make([]basics.Int16u, len(buf16)), width, height, width*8)
+	dst16.CopyFrom(rb16)
+	if got := RowU16(dst16, 1)[width*4-1]; got != 0xFFFF {
+		t.Errorf("CopyFrom missed the last channel of the row: got %d", got)
+	}
+
+	// Bottom-up float buffer: RGB with float channels, 12 bytes per pixel
+	buf32 := make([]float32, width*height*3)
+	rb32 := NewRenderingBufferF32WithData(buf32, width, height, -width*12)
+	RowF32(rb32, 0)[0] = 0.5
+	if buf32[width*3] != 0.5 {
+		t.Error("row 0 of a bottom-up F32 buffer should be the last row in memory")
+	}
+	rb32.Clear(1)
+	for i, v := range buf32 {
+		if v != 1 {
+			t.Fatalf("Clear left element %d at %g", i, v)
+		}
+	}
+}
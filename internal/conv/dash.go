@@ -52,7 +52,7 @@ func (c *ConvDash) GetDashStart() float64 {
 	return c.dashGen.GetDashStart()
 }
 
-// Shorten trims both ends of open paths before dash generation.
+// Shorten trims s off the end of each open path before dash generation.
 func (c *ConvDash) Shorten(s float64) {
 	c.dashGen.Shorten(s)
 }
@@ -0,0 +1,44 @@
+package conv
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/vcgen"
+)
+
+// ConvRoundCorners rounds every corner of its source's polylines and polygons
+// with a circular arc of a given radius. The arcs are cubic curves, so the
+// output is usually passed through ConvCurve before rasterizing. Curved
+// sources should be flattened first: the converter only sees vertices.
+type ConvRoundCorners struct {
+	*ConvAdaptorVCGen
+	generator *vcgen.VCGenRoundCorners
+}
+
+// NewConvRoundCorners creates a corner-rounding converter with radius 0.
+func NewConvRoundCorners(source VertexSource) *ConvRoundCorners {
+	generator := vcgen.NewVCGenRoundCorners()
+	return &ConvRoundCorners{
+		ConvAdaptorVCGen: NewConvAdaptorVCGen(source, generator),
+		generator:        generator,
+	}
+}
+
+// SetRadius sets the corner radius.
+func (c *ConvRoundCorners) SetRadius(r float64) {
+	c.generator.SetRadius(r)
+}
+
+// Radius returns the corner radius.
+func (c *ConvRoundCorners) Radius() float64 {
+	return c.generator.Radius()
+}
+
+// Rewind rewinds the converter.
+func (c *ConvRoundCorners) Rewind(pathID uint) {
+	c.ConvAdaptorVCGen.Rewind(pathID)
+}
+
+// Vertex returns the next vertex of the rounded path.
+func (c *ConvRoundCorners) Vertex() (x, y float64, cmd basics.PathCommand) {
+	return c.ConvAdaptorVCGen.Vertex()
+}
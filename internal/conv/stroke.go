@@ -112,7 +112,7 @@ func (cs *ConvStroke) ApproximationScale() float64 {
 	return cs.strokeGen.ApproximationScale()
 }
 
-// SetShorten trims both ends of open paths before stroking.
+// SetShorten trims s off the end of each open path before stroking.
 func (cs *ConvStroke) SetShorten(s float64) {
 	cs.strokeGen.SetShorten(s)
 }
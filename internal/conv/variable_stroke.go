@@ -0,0 +1,68 @@
+package conv
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/vcgen"
+)
+
+// ConvVariableStroke expands any vertex source into the outline of a stroke
+// whose width varies along the path. It wraps VCGenVariableStroke behind
+// ConvAdaptorVCGen, mirroring ConvStroke; the output is a polygon to be filled
+// with the non-zero rule.
+type ConvVariableStroke struct {
+	*ConvAdaptorVCGen
+	strokeGen *vcgen.VCGenVariableStroke
+}
+
+// NewConvVariableStroke creates a variable-width stroke converter.
+func NewConvVariableStroke(source VertexSource) *ConvVariableStroke {
+	strokeGen := vcgen.NewVCGenVariableStroke()
+	adaptor := NewConvAdaptorVCGen(source, strokeGen)
+
+	return &ConvVariableStroke{
+		ConvAdaptorVCGen: adaptor,
+		strokeGen:        strokeGen,
+	}
+}
+
+// SetWidth sets the constant width used without per-vertex widths or a width
+// function.
+func (cs *ConvVariableStroke) SetWidth(w float64) {
+	cs.strokeGen.SetWidth(w)
+}
+
+// Width returns the constant width.
+func (cs *ConvVariableStroke) Width() float64 {
+	return cs.strokeGen.Width()
+}
+
+// SetWidths sets per-vertex widths, indexed by vertex within each sub-path.
+func (cs *ConvVariableStroke) SetWidths(widths []float64) {
+	cs.strokeGen.SetWidths(widths)
+}
+
+// SetWidthFunc sets the width as a function of relative position along the
+// path.
+func (cs *ConvVariableStroke) SetWidthFunc(f vcgen.WidthFunc) {
+	cs.strokeGen.SetWidthFunc(f)
+}
+
+// SetLineCap sets the cap style used on open-path ends.
+func (cs *ConvVariableStroke) SetLineCap(lc basics.LineCap) {
+	cs.strokeGen.SetLineCap(lc)
+}
+
+// LineCap returns the current cap style.
+func (cs *ConvVariableStroke) LineCap() basics.LineCap {
+	return cs.strokeGen.LineCap()
+}
+
+// SetApproximationScale sets the scale used for arcs and width sampling.
+func (cs *ConvVariableStroke) SetApproximationScale(s float64) {
+	cs.strokeGen.SetApproximationScale(s)
+}
+
+// ApproximationScale returns the current approximation scale.
+func (cs *ConvVariableStroke) ApproximationScale() float64 {
+	return cs.strokeGen.ApproximationScale()
+}
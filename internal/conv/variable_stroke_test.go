@@ -0,0 +1,47 @@
+package conv
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+func TestConvVariableStrokeSubpaths(t *testing.T) {
+	vertices := []Vertex{
+		{X: 0, Y: 0, Cmd: basics.PathCmdMoveTo},
+		{X: 10, Y: 0, Cmd: basics.PathCmdLineTo},
+		{X: 0, Y: 20, Cmd: basics.PathCmdMoveTo},
+		{X: 10, Y: 20, Cmd: basics.PathCmdLineTo},
+	}
+	cs := NewConvVariableStroke(NewMockVertexSource(vertices))
+	cs.SetWidths([]float64{2, 6})
+
+	cs.Rewind(0)
+	var moves, ends int
+	maxHalf := [2]float64{}
+	for {
+		x, y, cmd := cs.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		switch {
+		case basics.IsMoveTo(cmd):
+			moves++
+		case basics.IsEndPoly(cmd):
+			ends++
+		}
+		if basics.IsVertex(cmd) && x == 10 {
+			i := int(y+5) / 20
+			maxHalf[i] = max(maxHalf[i], y-float64(i*20))
+		}
+	}
+	if moves != 2 || ends != 2 {
+		t.Fatalf("expected two closed outlines, got %d moves and %d ends", moves, ends)
+	}
+	// Widths restart at each sub-path, so both ends are 6 wide.
+	for i, h := range maxHalf {
+		if h != 3 {
+			t.Errorf("sub-path %d: end half-width = %v, want 3", i, h)
+		}
+	}
+}
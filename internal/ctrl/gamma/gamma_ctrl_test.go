@@ -6,6 +6,7 @@ import (
 
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
 )
 
 func TestNewGammaCtrlImpl(t *testing.T) {
@@ -460,6 +461,30 @@ func TestGammaCtrlTransformation(t *testing.T) {
 	}
 }
 
+func TestGammaCtrlTransformAppliesToAllPaths(t *testing.T) {
+	firstVertices := func(ctrl *GammaCtrlImpl[color.RGBA]) [][2]float64 {
+		var out [][2]float64
+		for path := uint(0); path < ctrl.NumPaths(); path++ {
+			ctrl.Rewind(path)
+			x, y, cmd := ctrl.Vertex()
+			if basics.IsStop(cmd) {
+				t.Fatalf("path %d is empty", path)
+			}
+			out = append(out, [2]float64{x, y})
+		}
+		return out
+	}
+
+	plain := firstVertices(NewGammaCtrlImpl[color.RGBA](0, 0, 100, 100, false))
+	moved := NewGammaCtrlImpl[color.RGBA](0, 0, 100, 100, false)
+	moved.SetTransform(transform.NewTransAffineTranslation(100, 50))
+	for path, v := range firstVertices(moved) {
+		if math.Abs(v[0]-plain[path][0]-100) > 1e-9 || math.Abs(v[1]-plain[path][1]-50) > 1e-9 {
+			t.Errorf("path %d: first vertex %v, want %v moved by (100, 50)", path, v, plain[path])
+		}
+	}
+}
+
 func TestGammaCtrlEdgeCases(t *testing.T) {
 	_ = NewGammaCtrlImpl[color.RGBA](0, 0, 100, 100, false) // For potential future use
 
@@ -371,7 +371,9 @@ func (s *SplineCtrlImpl[C]) Rewind(pathID uint) {
 			if basics.IsStop(sc) {
 				break
 			}
-			if basics.IsMoveTo(sc) {
+			if basics.IsEndPoly(sc) {
+				s.strokePath.ClosePolygon(basics.PathFlagsClose)
+			} else if basics.IsMoveTo(sc) {
 				s.strokePath.MoveTo(sx, sy)
 			} else {
 				s.strokePath.LineTo(sx, sy)
@@ -51,8 +51,12 @@ func NewBSplineFromPoints(x, y []float64) *BSpline {
 }
 
 // Init initializes the B-spline with a maximum number of points.
-// This allocates memory for the internal arrays.
+// This allocates memory for the internal arrays, so a zero BSpline is ready
+// for use after Init.
 func (bs *BSpline) Init(maxPoints int) {
+	if bs.am == nil {
+		bs.am = array.NewPodArray[float64]()
+	}
 	if maxPoints > 2 && maxPoints > bs.max {
 		// Allocate space for coefficients and coordinate arrays
 		// Layout: [coefficients][x coordinates][y coordinates]
@@ -0,0 +1,78 @@
+package effects
+
+import (
+	"math"
+	"runtime"
+	"sync"
+)
+
+// Convolve applies a size x size convolution kernel, given row by row, to a
+// width x height image of channels 8-bit channels per pixel. Each output
+// channel is the kernel-weighted sum of the source channel around the pixel,
+// divided by divisor, plus bias, rounded and clamped to 0..255. Pixels
+// beyond the image edge repeat the edge pixels. The channel at offset alpha
+// is copied unchanged and the others are clamped to it, so premultiplied
+// pixels stay valid; pass a negative alpha to convolve every channel.
+//
+// src and dst must not overlap. Rows are split between up to GOMAXPROCS
+// goroutines.
+func Convolve(dst, src []uint8, width, height, stride, channels, alpha int, kernel []float64, size int, divisor, bias float64) {
+	if width <= 0 || height <= 0 || size <= 0 || len(kernel) < size*size {
+		return
+	}
+	if divisor == 0 {
+		divisor = 1
+	}
+	r := size / 2
+
+	convolveRows := func(y1, y2 int) {
+		acc := make([]float64, channels)
+		for y := y1; y < y2; y++ {
+			out := dst[y*stride:]
+			for x := 0; x < width; x++ {
+				clear(acc)
+				for ky := 0; ky < size; ky++ {
+					row := src[min(max(y+ky-r, 0), height-1)*stride:]
+					for kx := 0; kx < size; kx++ {
+						k := kernel[ky*size+kx]
+						if k == 0 {
+							continue
+						}
+						p := row[min(max(x+kx-r, 0), width-1)*channels:]
+						for c := range acc {
+							acc[c] += k * float64(p[c])
+						}
+					}
+				}
+				d := out[x*channels:]
+				limit := 255.0
+				if alpha >= 0 {
+					limit = float64(src[y*stride+x*channels+alpha])
+				}
+				for c, v := range acc {
+					if c == alpha {
+						d[c] = uint8(limit)
+						continue
+					}
+					d[c] = uint8(min(max(math.Round(v/divisor+bias), 0), limit))
+				}
+			}
+		}
+	}
+
+	workers := min(runtime.GOMAXPROCS(0), height)
+	if workers <= 1 {
+		convolveRows(0, height)
+		return
+	}
+	var wg sync.WaitGroup
+	rows := (height + workers - 1) / workers
+	for y := 0; y < height; y += rows {
+		wg.Add(1)
+		go func(y1, y2 int) {
+			defer wg.Done()
+			convolveRows(y1, y2)
+		}(y, min(y+rows, height))
+	}
+	wg.Wait()
+}
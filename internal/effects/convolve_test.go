@@ -0,0 +1,72 @@
+package effects
+
+import (
+	"bytes"
+	"runtime"
+	"testing"
+)
+
+func TestConvolve(t *testing.T) {
+	const w, h = 5, 5
+	src := make([]uint8, w*h)
+	src[2*w+2] = 90
+
+	// A 3x3 box blur spreads the center over its 8 neighbors.
+	box := []float64{1, 1, 1, 1, 1, 1, 1, 1, 1}
+	dst := make([]uint8, w*h)
+	Convolve(dst, src, w, h, w, 1, -1, box, 3, 9, 0)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			want := uint8(0)
+			if x >= 1 && x <= 3 && y >= 1 && y <= 3 {
+				want = 10
+			}
+			if dst[y*w+x] != want {
+				t.Errorf("box (%d,%d) = %d, want %d", x, y, dst[y*w+x], want)
+			}
+		}
+	}
+
+	// Negative sums clamp to zero; the bias shifts the result.
+	edge := []float64{-1, -1, -1, -1, 8, -1, -1, -1, -1}
+	Convolve(dst, src, w, h, w, 1, -1, edge, 3, 1, 100)
+	if dst[2*w+2] != 255 || dst[1*w+1] != 10 || dst[0] != 100 {
+		t.Errorf("edge detect center %d, neighbor %d, flat %d", dst[2*w+2], dst[1*w+1], dst[0])
+	}
+}
+
+func TestConvolveAlphaAndEdges(t *testing.T) {
+	// Two RGBA pixels: colors are clamped to the kept alpha.
+	src := []uint8{200, 0, 0, 128, 0, 0, 0, 255}
+	dst := make([]uint8, len(src))
+	sharpen := []float64{0, -1, 0, -1, 5, -1, 0, -1, 0}
+	Convolve(dst, src, 2, 1, 8, 4, 3, sharpen, 3, 1, 0)
+	want := []uint8{128, 0, 0, 128, 0, 0, 0, 255}
+	if !bytes.Equal(dst, want) {
+		t.Errorf("got %v, want %v", dst, want)
+	}
+}
+
+func TestConvolveParallelMatchesSerial(t *testing.T) {
+	const w, h = 37, 53
+	src := make([]uint8, w*h*4)
+	for i := range src {
+		src[i] = uint8(i * 31)
+	}
+	kernel := make([]float64, 25)
+	for i := range kernel {
+		kernel[i] = float64(i%7) - 3
+	}
+
+	serial := make([]uint8, len(src))
+	prev := runtime.GOMAXPROCS(1)
+	Convolve(serial, src, w, h, w*4, 4, -1, kernel, 5, 3, 20)
+	runtime.GOMAXPROCS(max(prev, 4))
+	parallel := make([]uint8, len(src))
+	Convolve(parallel, src, w, h, w*4, 4, -1, kernel, 5, 3, 20)
+	runtime.GOMAXPROCS(prev)
+
+	if !bytes.Equal(serial, parallel) {
+		t.Error("parallel convolution differs from serial")
+	}
+}
@@ -3,6 +3,10 @@
 // The package is centered on the blur family from agg_blur.h: stack blur with
 // the same precomputed mul/shift tables AGG uses for fast 8-bit kernels, plus
 // smaller convenience filters such as SlightBlur for very small-radius cleanup.
+// GaussianBlur samples a true Gaussian kernel and MotionBlur smears along a
+// direction; both work on a rectangle of a raw pixel buffer so callers can
+// blur a region. Convolve applies general convolution kernels such as
+// sharpen and emboss, filtering rows in parallel.
 //
 // These helpers are intentionally separate from the main rasterizer/renderer
 // pipeline so callers can apply them explicitly as a post-process step.
@@ -0,0 +1,86 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+func TestGaussianKernel(t *testing.T) {
+	k := GaussianKernel(2)
+	if len(k) != 7 {
+		t.Fatalf("len = %d, want radius 6 + 1", len(k))
+	}
+	sum := k[0]
+	for i := 1; i < len(k); i++ {
+		sum += 2 * k[i]
+		if k[i] >= k[i-1] {
+			t.Errorf("k[%d] = %g not below k[%d] = %g", i, k[i], i-1, k[i-1])
+		}
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("sum = %g, want 1", sum)
+	}
+	if k := GaussianKernel(0); len(k) != 1 || k[0] != 1 {
+		t.Errorf("zero sigma kernel = %v, want identity", k)
+	}
+}
+
+func TestGaussianBlurInvalidSigma(t *testing.T) {
+	for _, sigma := range []float64{0, -1, math.Inf(1), math.NaN()} {
+		if k := GaussianKernel(sigma); len(k) != 1 || k[0] != 1 {
+			t.Errorf("GaussianKernel(%g) = %v, want [1]", sigma, k)
+		}
+		pix := []uint8{0, 255, 0}
+		GaussianBlur(pix, 3, 1, 3, 1, basics.RectI{X1: 0, Y1: 0, X2: 2, Y2: 0}, sigma)
+		if pix[1] != 255 {
+			t.Errorf("GaussianBlur with sigma %g changed the pixels: %v", sigma, pix)
+		}
+	}
+}
+
+func TestGaussianBlurImpulse(t *testing.T) {
+	const w, h = 21, 21
+	pix := make([]uint8, w*h)
+	pix[10*w+10] = 255
+	GaussianBlur(pix, w, h, w, 1, basics.RectI{X1: 0, Y1: 0, X2: w - 1, Y2: h - 1}, 1.5)
+
+	k := GaussianKernel(1.5)
+	for _, d := range [][2]int{{0, 0}, {1, 0}, {2, 1}, {3, 3}} {
+		want := math.Round(255 * k[d[0]] * k[d[1]])
+		for _, p := range [][2]int{{10 + d[0], 10 + d[1]}, {10 - d[1], 10 - d[0]}} {
+			if got := float64(pix[p[1]*w+p[0]]); got != want {
+				t.Errorf("pixel %v = %g, want %g", p, got, want)
+			}
+		}
+	}
+}
+
+func TestGaussianBlurRegion(t *testing.T) {
+	const w, h = 16, 8
+	pix := make([]uint8, w*h*4)
+	for y := 0; y < h; y++ {
+		for x := w / 2; x < w; x++ {
+			copy(pix[(y*w+x)*4:], []uint8{255, 255, 255, 255})
+		}
+	}
+	orig := append([]uint8(nil), pix...)
+
+	// The region ends one pixel left of the edge, so only its blurred
+	// neighbors outside the region would change.
+	GaussianBlur(pix, w, h, w*4, 4, basics.RectI{X1: 0, Y1: 0, X2: w/2 - 1, Y2: h - 1}, 1)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := (y*w + x) * 4
+			switch {
+			case x >= w/2 && pix[i] != orig[i]:
+				t.Fatalf("pixel (%d,%d) outside the region changed", x, y)
+			case x == w/2-1 && (pix[i] == 0 || pix[i] != pix[i+3]):
+				t.Fatalf("pixel (%d,%d) = %v, want gray blurred from the right", x, y, pix[i:i+4])
+			case x < w/2-4 && pix[i] != 0:
+				t.Fatalf("pixel (%d,%d) = %d far from the edge, want 0", x, y, pix[i])
+			}
+		}
+	}
+}
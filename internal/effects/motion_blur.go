@@ -0,0 +1,64 @@
+package effects
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+// MotionBlur smears the pixels inside bounds, an inclusive pixel rectangle,
+// along the direction angle (radians, measured from the x axis toward y):
+// each pixel becomes the average of the image along a line of length
+// distance centered on it, sampled bilinearly at one pixel spacing. Pixels
+// have channels 8-bit channels each, averaged separately. As GaussianBlur,
+// it reads the image outside bounds, extending the edge pixels outward, and
+// writes only inside bounds.
+func MotionBlur(pix []uint8, width, height, stride, channels int, bounds basics.RectI, angle, distance float64) {
+	x1, y1 := max(bounds.X1, 0), max(bounds.Y1, 0)
+	x2, y2 := min(bounds.X2, width-1), min(bounds.Y2, height-1)
+	if x1 > x2 || y1 > y2 || !(distance > 0) || math.IsInf(distance, 1) {
+		return
+	}
+	n := int(math.Ceil(distance)) + 1
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	step := distance / float64(n-1)
+
+	// The samples reach up to distance/2 above and below the region; keep a
+	// copy of these rows since the region is written in place.
+	reach := int(math.Ceil(distance/2*math.Abs(dy))) + 1
+	sy1, sy2 := max(y1-reach, 0), min(y2+reach, height-1)
+	src := make([]uint8, (sy2-sy1+1)*width*channels)
+	for y := sy1; y <= sy2; y++ {
+		copy(src[(y-sy1)*width*channels:][:width*channels], pix[y*stride:])
+	}
+	at := func(x, y int) []uint8 {
+		x = min(max(x, 0), width-1)
+		y = min(max(y, sy1), sy2)
+		return src[((y-sy1)*width+x)*channels:]
+	}
+
+	acc := make([]float64, channels)
+	for y := y1; y <= y2; y++ {
+		row := pix[y*stride:]
+		for x := x1; x <= x2; x++ {
+			clear(acc)
+			for i := 0; i < n; i++ {
+				t := -distance/2 + float64(i)*step
+				fx, fy := float64(x)+t*dx, float64(y)+t*dy
+				ix, iy := int(math.Floor(fx)), int(math.Floor(fy))
+				ax, ay := fx-float64(ix), fy-float64(iy)
+				p00, p10 := at(ix, iy), at(ix+1, iy)
+				p01, p11 := at(ix, iy+1), at(ix+1, iy+1)
+				for c := range acc {
+					top := float64(p00[c]) + ax*(float64(p10[c])-float64(p00[c]))
+					bottom := float64(p01[c]) + ax*(float64(p11[c])-float64(p01[c]))
+					acc[c] += top + ay*(bottom-top)
+				}
+			}
+			d := row[x*channels:]
+			for c, v := range acc {
+				d[c] = uint8(min(math.Round(v/float64(n)), 255))
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package effects
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+func TestMotionBlurDirection(t *testing.T) {
+	const w, h = 21, 21
+	all := basics.RectI{X1: 0, Y1: 0, X2: w - 1, Y2: h - 1}
+	impulse := func() []uint8 {
+		pix := make([]uint8, w*h)
+		pix[10*w+10] = 250
+		return pix
+	}
+
+	// Horizontal: a 5-pixel line of 6 samples, 1/6 each, at half-pixel
+	// offsets that split between two pixels.
+	pix := impulse()
+	MotionBlur(pix, w, h, w, 1, all, 0, 5)
+	for x := 0; x < w; x++ {
+		want := uint8(0)
+		switch {
+		case x == 7 || x == 13:
+			want = 21
+		case x >= 8 && x <= 12:
+			want = 42
+		}
+		if pix[10*w+x] != want {
+			t.Errorf("row pixel %d = %d, want %d", x, pix[10*w+x], want)
+		}
+	}
+	for _, p := range []int{9*w + 10, 11*w + 10} {
+		if pix[p] != 0 {
+			t.Errorf("pixel off the row = %d, want 0", pix[p])
+		}
+	}
+
+	// Vertical: the smear follows the angle.
+	pix = impulse()
+	MotionBlur(pix, w, h, w, 1, all, math.Pi/2, 4)
+	if pix[8*w+10] == 0 || pix[12*w+10] == 0 || pix[10*w+9] != 0 || pix[10*w+11] != 0 {
+		t.Errorf("vertical smear: up %d down %d left %d right %d",
+			pix[8*w+10], pix[12*w+10], pix[10*w+9], pix[10*w+11])
+	}
+
+	// A zero distance leaves the image alone.
+	pix = impulse()
+	MotionBlur(pix, w, h, w, 1, all, 0, 0)
+	if pix[10*w+10] != 250 {
+		t.Errorf("zero distance changed the image")
+	}
+}
@@ -0,0 +1,108 @@
+package effects
+
+import "math"
+
+// SignedDistanceField returns, for every pixel of a width x height binary
+// image, the Euclidean distance in pixels to the shape edge: positive inside,
+// negative outside. The edge is taken to lie halfway between an inside and an
+// outside pixel center, so pixels next to the edge get ±0.5.
+//
+// Distances are exact (Felzenszwalb-Huttenlocher squared distance transform),
+// computed in O(width*height).
+func SignedDistanceField(inside []bool, width, height int) []float64 {
+	n := width * height
+	if n <= 0 {
+		return nil
+	}
+	toOutside := squaredDistanceTransform(inside, false, width, height)
+	toInside := squaredDistanceTransform(inside, true, width, height)
+
+	sdf := make([]float64, n)
+	for i := range sdf {
+		if inside[i] {
+			sdf[i] = math.Sqrt(toOutside[i]) - 0.5
+		} else {
+			sdf[i] = 0.5 - math.Sqrt(toInside[i])
+		}
+	}
+	return sdf
+}
+
+// squaredDistanceTransform returns the squared distance from every pixel to
+// the nearest pixel whose inside flag equals target. With no such pixel the
+// distances are +Inf.
+func squaredDistanceTransform(inside []bool, target bool, width, height int) []float64 {
+	d := make([]float64, width*height)
+	for i, in := range inside {
+		if in == target {
+			d[i] = 0
+		} else {
+			d[i] = math.Inf(1)
+		}
+	}
+
+	size := max(width, height)
+	f := make([]float64, size)
+	out := make([]float64, size)
+	v := make([]int, size)
+	z := make([]float64, size+1)
+
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			f[y] = d[y*width+x]
+		}
+		distanceTransform1D(f[:height], out[:height], v, z)
+		for y := 0; y < height; y++ {
+			d[y*width+x] = out[y]
+		}
+	}
+	for y := 0; y < height; y++ {
+		row := d[y*width : (y+1)*width]
+		copy(f, row)
+		distanceTransform1D(f[:width], out[:width], v, z)
+		copy(row, out[:width])
+	}
+	return d
+}
+
+// distanceTransform1D computes the lower envelope of the parabolas rooted at
+// (q, f[q]) and samples it into out. v and z are scratch space.
+func distanceTransform1D(f, out []float64, v []int, z []float64) {
+	n := len(f)
+	k := -1
+	for q := 0; q < n; q++ {
+		if math.IsInf(f[q], 1) {
+			continue
+		}
+		for k >= 0 {
+			p := v[k]
+			s := ((f[q] + float64(q*q)) - (f[p] + float64(p*p))) / float64(2*(q-p))
+			if s > z[k] {
+				k++
+				v[k], z[k] = q, s
+				break
+			}
+			k--
+		}
+		if k < 0 {
+			k = 0
+			v[0], z[0] = q, math.Inf(-1)
+		}
+		z[k+1] = math.Inf(1)
+	}
+
+	if k < 0 {
+		for q := range out {
+			out[q] = math.Inf(1)
+		}
+		return
+	}
+	j := 0
+	for q := 0; q < n; q++ {
+		for z[j+1] < float64(q) {
+			j++
+		}
+		dq := float64(q - v[j])
+		out[q] = dq*dq + f[v[j]]
+	}
+}
@@ -0,0 +1,46 @@
+package effects
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSignedDistanceFieldMatchesBruteForce(t *testing.T) {
+	const w, h = 23, 17
+	rng := rand.New(rand.NewSource(1))
+	inside := make([]bool, w*h)
+	for i := range inside {
+		inside[i] = rng.Intn(4) == 0
+	}
+
+	got := SignedDistanceField(inside, w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			best := math.Inf(1)
+			for yy := 0; yy < h; yy++ {
+				for xx := 0; xx < w; xx++ {
+					if inside[yy*w+xx] != inside[y*w+x] {
+						best = math.Min(best, math.Hypot(float64(x-xx), float64(y-yy)))
+					}
+				}
+			}
+			want := 0.5 - best
+			if inside[y*w+x] {
+				want = best - 0.5
+			}
+			if math.Abs(got[y*w+x]-want) > 1e-9 {
+				t.Fatalf("(%d,%d) = %v, want %v", x, y, got[y*w+x], want)
+			}
+		}
+	}
+}
+
+func TestSignedDistanceFieldUniform(t *testing.T) {
+	sdf := SignedDistanceField(make([]bool, 4), 2, 2)
+	for _, d := range sdf {
+		if !math.IsInf(d, -1) {
+			t.Fatalf("empty image distance = %v, want -Inf", d)
+		}
+	}
+}
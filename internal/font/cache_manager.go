@@ -35,6 +35,17 @@ type FontEngine interface {
 	PathAdaptor() *path.PathStorageStl
 }
 
+// GlyphIndexEngine is implemented by font engines that can also load glyphs by
+// their font glyph index instead of a character code. Text shapers emit glyph
+// indices for ligatures and contextual forms that have no character mapping.
+type GlyphIndexEngine interface {
+	PrepareGlyphByIndex(glyphIndex uint) bool
+}
+
+// glyphIndexCacheSuffix separates glyph-index caches from character-code
+// caches of the same font signature.
+const glyphIndexCacheSuffix = "\x00glyph-index"
+
 // FontCache stores glyphs for one font signature using the same two-level
 // [msb][lsb] lookup shape as AGG's font_cache.
 type FontCache struct {
@@ -207,10 +218,18 @@ func NewFontCacheManager(fontEngine FontEngine, maxFonts int) *FontCacheManager
 	}
 }
 
+// FontEngine returns the engine whose glyphs this manager caches.
+func (fcm *FontCacheManager) FontEngine() FontEngine {
+	return fcm.fontEngine
+}
+
 // findFontCache finds or creates a cache for the current font signature.
 func (fcm *FontCacheManager) findFontCache() *FontCache {
-	signature := fcm.fontEngine.FontSignature()
+	return fcm.findCache(fcm.fontEngine.FontSignature())
+}
 
+// findCache finds or creates the cache registered under signature.
+func (fcm *FontCacheManager) findCache(signature string) *FontCache {
 	// Look for existing cache
 	for _, cache := range fcm.fontCaches {
 		if cache.FontIs(signature) {
@@ -236,25 +255,39 @@ func (fcm *FontCacheManager) findFontCache() *FontCache {
 // a miss and refreshing outline-engine state on outline hits.
 func (fcm *FontCacheManager) Glyph(charCode uint) *GlyphCache {
 	fcm.currentCache = fcm.findFontCache()
+	return fcm.glyph(charCode, fcm.fontEngine.PrepareGlyph)
+}
+
+// GlyphByIndex returns the cached glyph for a font glyph index. It returns nil
+// when the engine does not implement GlyphIndexEngine or the index is invalid.
+func (fcm *FontCacheManager) GlyphByIndex(glyphIndex uint) *GlyphCache {
+	engine, ok := fcm.fontEngine.(GlyphIndexEngine)
+	if !ok {
+		return nil
+	}
+	fcm.currentCache = fcm.findCache(fcm.fontEngine.FontSignature() + glyphIndexCacheSuffix)
+	return fcm.glyph(glyphIndex, engine.PrepareGlyphByIndex)
+}
 
+func (fcm *FontCacheManager) glyph(code uint, prepare func(uint) bool) *GlyphCache {
 	// Look for cached glyph
-	if glyph := fcm.currentCache.FindGlyph(charCode); glyph != nil {
+	if glyph := fcm.currentCache.FindGlyph(code); glyph != nil {
 		// Outline paths are held by the font engine adaptor, so refresh engine state
 		// to the current glyph before returning cached metrics/advance data.
 		if glyph.DataType == GlyphDataOutline {
-			_ = fcm.fontEngine.PrepareGlyph(charCode)
+			_ = prepare(code)
 		}
 		return glyph
 	}
 
 	// Load glyph from font engine
-	if !fcm.fontEngine.PrepareGlyph(charCode) {
+	if !prepare(code) {
 		return nil
 	}
 
 	// Cache the glyph with typed values
 	glyph := fcm.currentCache.CacheGlyph(
-		charCode,
+		code,
 		fcm.fontEngine.GlyphIndex(),
 		fcm.fontEngine.DataSize(),
 		fcm.fontEngine.DataType(),
@@ -291,7 +324,7 @@ func (fcm *FontCacheManager) InitEmbeddedAdaptors(glyph *GlyphCache, x, y float6
 	}
 
 	switch glyph.DataType {
-	case GlyphDataGray8:
+	case GlyphDataGray8, GlyphDataLCD:
 		fcm.gray8Adaptor = NewSerializedScanlinesAdaptorAA(glyph.Data, glyph.Bounds)
 	case GlyphDataMono:
 		fcm.monoAdaptor = NewSerializedScanlinesAdaptorBin(glyph.Data, glyph.Bounds)
@@ -8,6 +8,8 @@ package freetype
 #cgo pkg-config: freetype2
 #include <ft2build.h>
 #include FT_FREETYPE_H
+#include FT_LCD_FILTER_H
+#include FT_OUTLINE_H
 #include <stdlib.h>
 #include <string.h>
 
@@ -44,6 +46,11 @@ static void set_name_in_array(char** names, int index, const char* name) {
     names[index] = strdup(name);
 }
 
+// move_name_in_array moves names[from] to names[to]; from < 0 clears to.
+static void move_name_in_array(char** names, int to, int from) {
+    names[to] = from < 0 ? NULL : names[from];
+}
+
 static char* get_name_from_array(char** names, int index) {
     return names[index];
 }
@@ -74,6 +81,7 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"unsafe"
 
 	"github.com/MeKo-Christian/agg_go/internal/basics"
@@ -174,6 +182,9 @@ type FontEngineFreetype struct {
 	height             uint
 	width              uint
 	hinting            bool
+	hintingMode        HintingMode
+	subpixelOrder      SubpixelOrder
+	subpixelShift      int // horizontal bitmap offset in 1/64 pixel
 	flipY              bool
 	libraryInitialized bool
 	resolution         int
@@ -182,8 +193,9 @@ type FontEngineFreetype struct {
 
 	// FreeType handles
 	library     *C.FT_Library
-	faces       *C.FT_Face // Array of font faces
-	faceNames   **C.char   // Array of face name strings
+	faces       *C.FT_Face  // Array of font faces
+	faceNames   **C.char    // Array of face name strings
+	faceData    []*FontData // shared data each face was opened on, if any
 	numFaces    uint
 	maxFaces    uint
 	currentFace C.FT_Face
@@ -209,6 +221,9 @@ const (
 	GlyphRenderingAAGray8
 	GlyphRenderingAAMono
 	GlyphRenderingMono
+	// GlyphRenderingLCD renders horizontal LCD subpixel coverage: three
+	// filtered samples per pixel in the engine's SubpixelOrder.
+	GlyphRenderingLCD
 )
 
 // NewFontEngineFreetype creates a FreeType engine with a bounded face cache.
@@ -220,6 +235,7 @@ func NewFontEngineFreetype(flag32 bool, maxFaces uint) (*FontEngineFreetype, err
 	engine := &FontEngineFreetype{
 		flag32:      flag32,
 		maxFaces:    maxFaces,
+		faceData:    make([]*FontData, maxFaces),
 		resolution:  72, // Default DPI
 		hinting:     true,
 		flipY:       false,
@@ -235,6 +251,9 @@ func NewFontEngineFreetype(flag32 bool, maxFaces uint) (*FontEngineFreetype, err
 	}
 
 	engine.libraryInitialized = true
+	// Subpixel rendering needs a filter to avoid colour fringes; the default
+	// FIR filter is FreeType's recommendation.
+	C.FT_Library_SetLcdFilter(*engine.library, C.FT_LCD_FILTER_DEFAULT)
 
 	// Allocate face arrays
 	engine.faces = C.new_face_array(C.int(maxFaces))
@@ -257,6 +276,7 @@ func (fe *FontEngineFreetype) Close() error {
 
 		C.free_face_array(fe.faces)
 		C.free_name_array(fe.faceNames, C.int(fe.maxFaces))
+		clear(fe.faceData)
 
 		C.FT_Done_FreeType(*fe.library)
 		C.free_library(fe.library)
@@ -280,6 +300,13 @@ func (fe *FontEngineFreetype) LoadFont(fontName string, faceIndex uint, renType
 
 	fe.glyphRendering = renType
 
+	// Faces are kept by name and index, so switching back to a font does not
+	// load it again (C++ find_face).
+	if idx := fe.findFace(fontName, faceIndex); idx >= 0 {
+		fe.selectFace(C.get_face_from_array(fe.faces, C.int(idx)), faceIndex, fontName)
+		return nil
+	}
+
 	if len(fontMem) > 0 {
 		// Load from memory
 		err = C.FT_New_Memory_Face(*fe.library,
@@ -299,19 +326,99 @@ func (fe *FontEngineFreetype) LoadFont(fontName string, faceIndex uint, renType
 		return fmt.Errorf("failed to load font %s: FreeType error %d", fontName, err)
 	}
 
-	// Store the face
+	fe.addFace(face, faceIndex, fontName, nil)
+	return nil
+}
+
+// FontData is a font file held in C memory. Engines open faces on it with
+// LoadFontData instead of each reading and keeping its own copy of the file,
+// so one FontData can back the engines of several goroutines. It is freed
+// once neither the caller nor a loaded face refers to it.
+type FontData struct {
+	mem  unsafe.Pointer
+	size int
+}
+
+// NewFontData copies the font file contents b into C memory.
+func NewFontData(b []byte) *FontData {
+	d := &FontData{mem: C.CBytes(b), size: len(b)}
+	runtime.SetFinalizer(d, func(d *FontData) { C.free(d.mem) })
+	return d
+}
+
+// LoadFontData loads a font like LoadFont, reading the face from data
+// instead of a file. fontName identifies the face in the engine's face list.
+func (fe *FontEngineFreetype) LoadFontData(fontName string, faceIndex uint, renType GlyphRenderingType,
+	data *FontData,
+) error {
+	fe.glyphRendering = renType
+	if idx := fe.findFace(fontName, faceIndex); idx >= 0 {
+		fe.selectFace(C.get_face_from_array(fe.faces, C.int(idx)), faceIndex, fontName)
+		return nil
+	}
+
+	var face C.FT_Face
+	err := C.FT_New_Memory_Face(*fe.library, (*C.FT_Byte)(data.mem), C.FT_Long(data.size),
+		C.FT_Long(faceIndex), &face)
+	if err != 0 {
+		fe.lastError = int(err)
+		return fmt.Errorf("failed to load font %s: FreeType error %d", fontName, err)
+	}
+	fe.addFace(face, faceIndex, fontName, data)
+	return nil
+}
+
+// addFace stores a newly opened face, dropping the oldest one when the table
+// is full, and selects it. data keeps shared font memory alive for the face.
+func (fe *FontEngineFreetype) addFace(face C.FT_Face, faceIndex uint, fontName string, data *FontData) {
 	if fe.numFaces >= fe.maxFaces {
-		return errors.New("maximum number of faces exceeded")
+		fe.dropOldestFace()
 	}
 
 	C.set_face_in_array(fe.faces, C.int(fe.numFaces), face)
-	C.set_name_in_array(fe.faceNames, C.int(fe.numFaces), C.CString(fontName))
+	cName := C.CString(fontName)
+	C.set_name_in_array(fe.faceNames, C.int(fe.numFaces), cName)
+	C.free(unsafe.Pointer(cName))
+	fe.faceData[fe.numFaces] = data
 	fe.numFaces++
 
+	fe.selectFace(face, faceIndex, fontName)
+}
+
+// findFace returns the slot of the loaded face faceIndex of the font named
+// name, or -1.
+func (fe *FontEngineFreetype) findFace(name string, faceIndex uint) int {
+	for i := uint(0); i < fe.numFaces; i++ {
+		face := C.get_face_from_array(fe.faces, C.int(i))
+		if face.face_index == C.FT_Long(faceIndex) &&
+			C.GoString(C.get_name_from_array(fe.faceNames, C.int(i))) == name {
+			return int(i)
+		}
+	}
+	return -1
+}
+
+// dropOldestFace releases the first face and shifts the others down.
+func (fe *FontEngineFreetype) dropOldestFace() {
+	C.FT_Done_Face(C.get_face_from_array(fe.faces, 0))
+	C.free(unsafe.Pointer(C.get_name_from_array(fe.faceNames, 0)))
+	for i := 1; i < int(fe.numFaces); i++ {
+		C.set_face_in_array(fe.faces, C.int(i-1), C.get_face_from_array(fe.faces, C.int(i)))
+		C.move_name_in_array(fe.faceNames, C.int(i-1), C.int(i))
+	}
+	copy(fe.faceData, fe.faceData[1:fe.numFaces])
+	fe.numFaces--
+	fe.faceData[fe.numFaces] = nil
+	C.set_face_in_array(fe.faces, C.int(fe.numFaces), nil)
+	C.move_name_in_array(fe.faceNames, C.int(fe.numFaces), -1)
+}
+
+// selectFace makes face the current face and applies the size settings.
+func (fe *FontEngineFreetype) selectFace(face C.FT_Face, faceIndex uint, name string) {
 	fe.currentFace = face
 	fe.faceIndex = faceIndex
-	fe.name = fontName
-	fe.nameLen = uint(len(fontName))
+	fe.name = name
+	fe.nameLen = uint(len(name))
 
 	// Set character map to Unicode
 	fe.charMap = C.FT_ENCODING_UNICODE
@@ -320,8 +427,6 @@ func (fe *FontEngineFreetype) LoadFont(fontName string, faceIndex uint, renType
 	fe.updateCharSize()
 	fe.updateSignature()
 	fe.changeStamp++
-
-	return nil
 }
 
 // updateCharSize updates the character size in FreeType.
@@ -338,8 +443,9 @@ func (fe *FontEngineFreetype) updateCharSize() {
 // updateSignature updates the font signature string with CRC32 hash.
 func (fe *FontEngineFreetype) updateSignature() {
 	// Create signature string similar to AGG C++ implementation
-	sigStr := fmt.Sprintf("%s_%d_%d_%t_%t_%d",
-		fe.name, fe.height, fe.width, fe.hinting, fe.flipY, int(fe.glyphRendering))
+	sigStr := fmt.Sprintf("%s_%d_%d_%t_%t_%d_%d_%d_%d",
+		fe.name, fe.height, fe.width, fe.hinting, fe.flipY, int(fe.glyphRendering),
+		int(fe.hintingMode), int(fe.subpixelOrder), fe.subpixelShift)
 
 	// Calculate CRC32 hash for uniqueness (similar to AGG)
 	crc := calcCRC32([]byte(sigStr))
@@ -362,13 +468,63 @@ func (fe *FontEngineFreetype) SetWidth(w float64) {
 	fe.changeStamp++
 }
 
-// SetHinting enables or disables font hinting.
+// SetHinting enables or disables font hinting. Enabling it restores
+// HintingNative when hinting had been switched off via SetHintingMode.
 func (fe *FontEngineFreetype) SetHinting(h bool) {
 	fe.hinting = h
+	if h && fe.hintingMode == HintingNone {
+		fe.hintingMode = HintingNative
+	} else if !h {
+		fe.hintingMode = HintingNone
+	}
+	fe.updateSignature()
+	fe.changeStamp++
+}
+
+// SetHintingMode selects native, auto or no hinting.
+func (fe *FontEngineFreetype) SetHintingMode(mode HintingMode) {
+	fe.hintingMode = mode
+	fe.hinting = mode != HintingNone
+	fe.updateSignature()
+	fe.changeStamp++
+}
+
+// GetHintingMode returns the current hinting mode.
+func (fe *FontEngineFreetype) GetHintingMode() HintingMode {
+	return fe.hintingMode
+}
+
+// SetSubpixelOrder sets the stripe order of GlyphRenderingLCD output.
+func (fe *FontEngineFreetype) SetSubpixelOrder(order SubpixelOrder) {
+	fe.subpixelOrder = order
+	fe.updateSignature()
+	fe.changeStamp++
+}
+
+// GetSubpixelOrder returns the stripe order of GlyphRenderingLCD output.
+func (fe *FontEngineFreetype) GetSubpixelOrder() SubpixelOrder {
+	return fe.subpixelOrder
+}
+
+// SetSubpixelShift moves raster glyphs right by shift/64 pixel before they are
+// rendered, so text can be placed at fractional positions. The shift is part
+// of the font signature, giving every phase its own glyph cache. Outline
+// glyphs are not affected.
+func (fe *FontEngineFreetype) SetSubpixelShift(shift int) {
+	shift = max(0, min(63, shift))
+	if shift == fe.subpixelShift {
+		return
+	}
+	fe.subpixelShift = shift
 	fe.updateSignature()
 	fe.changeStamp++
 }
 
+// GetSubpixelShift returns the raster glyph shift in 1/64 pixel.
+func (fe *FontEngineFreetype) GetSubpixelShift() int {
+	return fe.subpixelShift
+}
+
 // SetFlipY sets whether to flip Y coordinates.
 func (fe *FontEngineFreetype) SetFlipY(f bool) {
 	fe.flipY = f
@@ -428,6 +584,24 @@ func (fe *FontEngineFreetype) GetDescender() float64 {
 	return 0
 }
 
+// GetUnderlinePosition returns the distance of the underline center above
+// the baseline, negative below it, or 0 when the font does not say.
+func (fe *FontEngineFreetype) GetUnderlinePosition() float64 {
+	if fe.currentFace != nil && fe.currentFace.units_per_EM != 0 {
+		return float64(fe.currentFace.underline_position) * fe.GetHeight() / float64(fe.currentFace.units_per_EM)
+	}
+	return 0
+}
+
+// GetUnderlineThickness returns the underline thickness, or 0 when the font
+// does not say.
+func (fe *FontEngineFreetype) GetUnderlineThickness() float64 {
+	if fe.currentFace != nil && fe.currentFace.units_per_EM != 0 {
+		return float64(fe.currentFace.underline_thickness) * fe.GetHeight() / float64(fe.currentFace.units_per_EM)
+	}
+	return 0
+}
+
 // NumFaces returns the number of loaded faces.
 func (fe *FontEngineFreetype) NumFaces() uint {
 	return fe.numFaces
@@ -450,15 +624,32 @@ func (fe *FontEngineFreetype) PrepareGlyph(glyphCode uint) bool {
 	}
 
 	// Get glyph index
-	fe.glyphIndex = uint(C.FT_Get_Char_Index(fe.currentFace, C.FT_ULong(glyphCode)))
-	if fe.glyphIndex == 0 {
+	glyphIndex := uint(C.FT_Get_Char_Index(fe.currentFace, C.FT_ULong(glyphCode)))
+	if glyphIndex == 0 {
 		return false
 	}
+	return fe.PrepareGlyphByIndex(glyphIndex)
+}
+
+// PrepareGlyphByIndex prepares the glyph with the given font glyph index for
+// rendering. Shapers such as HarfBuzz produce glyph indices directly, including
+// glyphs that no character maps to.
+func (fe *FontEngineFreetype) PrepareGlyphByIndex(glyphIndex uint) bool {
+	if fe.currentFace == nil || glyphIndex == 0 {
+		return false
+	}
+	fe.glyphIndex = glyphIndex
 
 	// Load glyph
 	loadFlags := C.FT_LOAD_DEFAULT
-	if !fe.hinting {
+	switch {
+	case !fe.hinting:
 		loadFlags |= C.FT_LOAD_NO_HINTING
+	case fe.hintingMode == HintingAuto:
+		loadFlags |= C.FT_LOAD_FORCE_AUTOHINT
+	}
+	if fe.glyphRendering == GlyphRenderingLCD && fe.hinting {
+		loadFlags |= C.FT_LOAD_TARGET_LCD
 	}
 
 	err := C.FT_Load_Glyph(fe.currentFace, C.FT_UInt(fe.glyphIndex), C.FT_Int32(loadFlags))
@@ -469,14 +660,6 @@ func (fe *FontEngineFreetype) PrepareGlyph(glyphCode uint) bool {
 
 	glyph := fe.currentFace.glyph
 
-	// Set bounds and advance
-	fe.bounds = basics.Rect[int]{
-		X1: int(glyph.bitmap_left),
-		Y1: int(int(glyph.bitmap_top) - int(glyph.bitmap.rows)),
-		X2: int(int(glyph.bitmap_left) + int(glyph.bitmap.width)),
-		Y2: int(glyph.bitmap_top),
-	}
-
 	fe.advanceX = float64(glyph.advance.x) / 64.0
 	fe.advanceY = float64(glyph.advance.y) / 64.0
 
@@ -493,30 +676,45 @@ func (fe *FontEngineFreetype) PrepareGlyph(glyphCode uint) bool {
 				fe.lastError = -1
 				return false
 			}
+			var cbox C.FT_BBox
+			C.FT_Outline_Get_CBox(&glyph.outline, &cbox)
+			fe.bounds = basics.Rect[int]{
+				X1: int(cbox.xMin) >> 6,
+				Y1: int(cbox.yMin) >> 6,
+				X2: (int(cbox.xMax) + 63) >> 6,
+				Y2: (int(cbox.yMax) + 63) >> 6,
+			}
+			if fe.flipY {
+				fe.bounds.Y1, fe.bounds.Y2 = -fe.bounds.Y2, -fe.bounds.Y1
+			}
 		}
 
 	case GlyphRenderingAAGray8:
 		fe.dataType = font.GlyphDataGray8
 		// Render to bitmap if not already done
-		if glyph.format != C.FT_GLYPH_FORMAT_BITMAP {
-			err = C.FT_Render_Glyph(glyph, C.FT_RENDER_MODE_NORMAL)
-			if err != 0 {
-				fe.lastError = int(err)
-				return false
-			}
+		if !fe.renderBitmap(glyph, C.FT_RENDER_MODE_NORMAL) {
+			return false
 		}
+		fe.setBitmapBounds(glyph)
 		fe.dataSize = uint(int(glyph.bitmap.rows) * int(glyph.bitmap.pitch))
 
 	case GlyphRenderingAAMono:
 		fe.dataType = font.GlyphDataMono
 		// Render to monochrome bitmap
-		if glyph.format != C.FT_GLYPH_FORMAT_BITMAP {
-			err = C.FT_Render_Glyph(glyph, C.FT_RENDER_MODE_MONO)
-			if err != 0 {
-				fe.lastError = int(err)
-				return false
-			}
+		if !fe.renderBitmap(glyph, C.FT_RENDER_MODE_MONO) {
+			return false
 		}
+		fe.setBitmapBounds(glyph)
+		fe.dataSize = uint(int(glyph.bitmap.rows) * int(glyph.bitmap.pitch))
+
+	case GlyphRenderingLCD:
+		fe.dataType = font.GlyphDataLCD
+		if !fe.renderBitmap(glyph, C.FT_RENDER_MODE_LCD) {
+			return false
+		}
+		fe.setBitmapBounds(glyph)
+		// The LCD bitmap holds three samples per pixel.
+		fe.bounds.X2 = fe.bounds.X1 + int(glyph.bitmap.width)/3
 		fe.dataSize = uint(int(glyph.bitmap.rows) * int(glyph.bitmap.pitch))
 
 	default:
@@ -527,6 +725,36 @@ func (fe *FontEngineFreetype) PrepareGlyph(glyphCode uint) bool {
 	return true
 }
 
+// renderBitmap renders the loaded glyph into its bitmap, applying the subpixel
+// shift to the outline first. Embedded bitmap glyphs are used as they are.
+func (fe *FontEngineFreetype) renderBitmap(glyph C.FT_GlyphSlot, mode C.FT_Render_Mode) bool {
+	if glyph.format == C.FT_GLYPH_FORMAT_BITMAP {
+		return true
+	}
+	if fe.subpixelShift != 0 && glyph.format == C.FT_GLYPH_FORMAT_OUTLINE {
+		C.FT_Outline_Translate(&glyph.outline, C.FT_Pos(fe.subpixelShift), 0)
+	}
+	if err := C.FT_Render_Glyph(glyph, mode); err != 0 {
+		fe.lastError = int(err)
+		return false
+	}
+	return true
+}
+
+// setBitmapBounds sets the glyph bounds from the rendered bitmap. The bitmap
+// fields of the glyph slot are only valid after FT_Render_Glyph. Bitmap glyphs
+// are drawn in screen space, whose Y axis points down, so the bitmap top is at
+// -bitmap_top regardless of flipY.
+func (fe *FontEngineFreetype) setBitmapBounds(glyph C.FT_GlyphSlot) {
+	top := int(glyph.bitmap_top)
+	fe.bounds = basics.Rect[int]{
+		X1: int(glyph.bitmap_left),
+		Y1: -top,
+		X2: int(glyph.bitmap_left) + int(glyph.bitmap.width),
+		Y2: int(glyph.bitmap.rows) - top,
+	}
+}
+
 // GlyphIndex returns the current glyph index.
 func (fe *FontEngineFreetype) GlyphIndex() uint {
 	return fe.glyphIndex
@@ -566,14 +794,23 @@ func (fe *FontEngineFreetype) WriteGlyphTo(data []byte) {
 	glyph := fe.currentFace.glyph
 
 	switch fe.dataType {
-	case font.GlyphDataGray8:
+	case font.GlyphDataGray8, font.GlyphDataMono:
 		bitmap := &glyph.bitmap
 		srcData := unsafe.Slice((*byte)(bitmap.buffer), fe.dataSize)
 		copy(data, srcData)
-	case font.GlyphDataMono:
+	case font.GlyphDataLCD:
 		bitmap := &glyph.bitmap
 		srcData := unsafe.Slice((*byte)(bitmap.buffer), fe.dataSize)
 		copy(data, srcData)
+		if fe.subpixelOrder == SubpixelBGR {
+			pitch := int(bitmap.pitch)
+			for row := 0; row < int(bitmap.rows); row++ {
+				line := data[row*pitch : row*pitch+int(bitmap.width)]
+				for i := 0; i+2 < len(line); i += 3 {
+					line[i], line[i+2] = line[i+2], line[i]
+				}
+			}
+		}
 	}
 }
 
@@ -608,9 +845,10 @@ func (fe *FontEngineFreetype) decomposeFTOutline(outline *C.FT_Outline, flipY bo
 	}
 
 	first := 0
+	contours := unsafe.Slice(outline.contours, int(outline.n_contours))
 
 	for n := 0; n < int(outline.n_contours); n++ {
-		last := int(C.short(uintptr(unsafe.Pointer(uintptr(unsafe.Pointer(outline.contours)) + uintptr(n)*unsafe.Sizeof(C.short(0))))))
+		last := int(contours[n])
 
 		// Bounds checking - ensure indices are within valid range
 		if first < 0 || last < 0 || first >= int(outline.n_points) || last >= int(outline.n_points) {
@@ -3,6 +3,7 @@
 package freetype
 
 import (
+	"os"
 	"testing"
 )
 
@@ -495,3 +496,86 @@ func TestDecomposeFTOutlineFlipY(t *testing.T) {
 		t.Error("No vertices produced with Y-axis flipping")
 	}
 }
+
+// TestLoadFontReusesFaces checks that loading a font again selects the face
+// already loaded, and that loading more fonts than maxFaces drops the oldest.
+func TestLoadFontReusesFaces(t *testing.T) {
+	engine, err := NewFontEngineFreetype(false, 2)
+	if err != nil {
+		t.Fatalf("Failed to create engine: %v", err)
+	}
+	defer engine.Close()
+
+	sans := "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"
+	serif := "/usr/share/fonts/truetype/dejavu/DejaVuSerif.ttf"
+	mono := "/usr/share/fonts/truetype/dejavu/DejaVuSansMono.ttf"
+	if engine.LoadFont(sans, 0, GlyphRenderingOutline, nil) != nil {
+		t.Skip("DejaVu fonts not available - skipping face reuse test")
+	}
+	for i := 0; i < 4; i++ {
+		if err := engine.LoadFont(sans, 0, GlyphRenderingOutline, nil); err != nil {
+			t.Fatalf("reload %d: %v", i, err)
+		}
+	}
+	if engine.numFaces != 1 {
+		t.Fatalf("numFaces=%d after reloading one font, want 1", engine.numFaces)
+	}
+	for _, name := range []string{serif, mono, sans} {
+		if err := engine.LoadFont(name, 0, GlyphRenderingOutline, nil); err != nil {
+			t.Skipf("%s not available: %v", name, err)
+		}
+		if engine.Name() != name {
+			t.Fatalf("Name()=%q, want %q", engine.Name(), name)
+		}
+	}
+	if engine.numFaces != 2 || engine.findFace(serif, 0) >= 0 {
+		t.Fatalf("numFaces=%d, serif slot %d; want 2 faces with serif dropped", engine.numFaces, engine.findFace(serif, 0))
+	}
+
+	// Faces are kept per index: DejaVuSans has no second face to reuse.
+	if err := engine.LoadFont(sans, 1, GlyphRenderingOutline, nil); err == nil {
+		t.Error("face 1 of a single-face font reused face 0")
+	}
+}
+
+// TestLoadFontDataShared checks that engines opening faces on one FontData
+// lay out glyphs like an engine that read the file itself.
+func TestLoadFontDataShared(t *testing.T) {
+	sans := "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"
+	b, err := os.ReadFile(sans)
+	if err != nil {
+		t.Skip("DejaVu fonts not available - skipping shared font data test")
+	}
+	data := NewFontData(b)
+
+	advance := func(load func(*FontEngineFreetype) error) float64 {
+		engine, err := NewFontEngineFreetype(false, 1)
+		if err != nil {
+			t.Fatalf("Failed to create engine: %v", err)
+		}
+		defer engine.Close()
+		if err := load(engine); err != nil {
+			t.Fatal(err)
+		}
+		engine.SetHeight(20)
+		if !engine.PrepareGlyph('W') {
+			t.Fatal("PrepareGlyph('W') failed")
+		}
+		return engine.AdvanceX()
+	}
+	want := advance(func(e *FontEngineFreetype) error { return e.LoadFont(sans, 0, GlyphRenderingOutline, nil) })
+	for i := 0; i < 2; i++ {
+		got := advance(func(e *FontEngineFreetype) error {
+			if err := e.LoadFontData(sans, 0, GlyphRenderingOutline, data); err != nil {
+				return err
+			}
+			if e.faceData[0] != data {
+				t.Error("face does not keep its font data")
+			}
+			return nil
+		})
+		if got != want || got == 0 {
+			t.Errorf("engine %d: advance %v from shared data, want %v", i, got, want)
+		}
+	}
+}
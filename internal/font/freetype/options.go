@@ -0,0 +1,23 @@
+package freetype
+
+// HintingMode selects how FreeType grid-fits glyph outlines.
+type HintingMode int
+
+const (
+	// HintingNative uses FreeType's default hinting: the font's own bytecode
+	// hints where present, the auto-hinter otherwise.
+	HintingNative HintingMode = iota
+	// HintingAuto forces FreeType's auto-hinter, ignoring font hints.
+	HintingAuto
+	// HintingNone disables hinting; glyphs keep their design outlines.
+	HintingNone
+)
+
+// SubpixelOrder is the physical order of the colour stripes within a pixel,
+// used by GlyphRenderingLCD.
+type SubpixelOrder int
+
+const (
+	SubpixelRGB SubpixelOrder = iota
+	SubpixelBGR
+)
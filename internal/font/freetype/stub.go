@@ -29,6 +29,7 @@ const (
 	GlyphRenderingAAGray8
 	GlyphRenderingAAMono
 	GlyphRenderingMono
+	GlyphRenderingLCD
 )
 
 // Use GlyphDataType from font package to avoid duplication
@@ -58,6 +59,10 @@ func (fe *FontEngineFreetype) PrepareGlyph(glyphCode uint) bool {
 	return false
 }
 
+func (fe *FontEngineFreetype) PrepareGlyphByIndex(glyphIndex uint) bool {
+	return false
+}
+
 func (fe *FontEngineFreetype) GlyphIndex() uint {
 	return 0
 }
@@ -100,6 +105,17 @@ func (fe *FontEngineFreetype) LoadFont(fontName string, faceIndex uint, renType
 	return errors.New("FreeType not available")
 }
 
+// FontData is the stub counterpart of the shared font file data.
+type FontData struct{}
+
+func NewFontData(b []byte) *FontData {
+	return &FontData{}
+}
+
+func (fe *FontEngineFreetype) LoadFontData(fontName string, faceIndex uint, renType GlyphRenderingType, data *FontData) error {
+	return errors.New("FreeType not available")
+}
+
 func (fe *FontEngineFreetype) SetHeight(h float64) {
 }
 
@@ -109,6 +125,27 @@ func (fe *FontEngineFreetype) SetWidth(w float64) {
 func (fe *FontEngineFreetype) SetHinting(h bool) {
 }
 
+func (fe *FontEngineFreetype) SetHintingMode(mode HintingMode) {
+}
+
+func (fe *FontEngineFreetype) GetHintingMode() HintingMode {
+	return HintingNative
+}
+
+func (fe *FontEngineFreetype) SetSubpixelOrder(order SubpixelOrder) {
+}
+
+func (fe *FontEngineFreetype) GetSubpixelOrder() SubpixelOrder {
+	return SubpixelRGB
+}
+
+func (fe *FontEngineFreetype) SetSubpixelShift(shift int) {
+}
+
+func (fe *FontEngineFreetype) GetSubpixelShift() int {
+	return 0
+}
+
 func (fe *FontEngineFreetype) SetFlipY(f bool) {
 }
 
@@ -139,6 +176,14 @@ func (fe *FontEngineFreetype) GetDescender() float64 {
 	return 0
 }
 
+func (fe *FontEngineFreetype) GetUnderlinePosition() float64 {
+	return 0
+}
+
+func (fe *FontEngineFreetype) GetUnderlineThickness() float64 {
+	return 0
+}
+
 func (fe *FontEngineFreetype) NumFaces() uint {
 	return 0
 }
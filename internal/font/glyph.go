@@ -12,6 +12,7 @@ const (
 	GlyphDataMono                         // 1-bit monochrome glyph data
 	GlyphDataGray8                        // 8-bit anti-aliased glyph data
 	GlyphDataOutline                      // Vector outline glyph data
+	GlyphDataLCD                          // 8-bit coverage, three subpixel samples per pixel
 )
 
 // GlyphCache stores the cached metrics and serialized glyph payload for one
@@ -36,6 +37,7 @@ const (
 	GlyphRenderingAAGray8                           // Anti-aliased gray8 rendering
 	GlyphRenderingAAMono                            // Anti-aliased mono rendering
 	GlyphRenderingMono                              // 1-bit mono rendering
+	GlyphRenderingLCD                               // LCD subpixel coverage
 )
 
 // FontMetrics stores the line metrics reported by a font face.
@@ -0,0 +1,163 @@
+package shaping
+
+import "unicode"
+
+// joiningType is the Arabic joining behaviour of a character (ArabicShaping.txt).
+type joiningType uint8
+
+const (
+	joinNone        joiningType = iota // U: does not join
+	joinRight                          // R: joins to the preceding letter only
+	joinDual                           // D: joins on both sides
+	joinCausing                        // C: tatweel, forces joining
+	joinTransparent                    // T: marks, skipped when joining
+)
+
+// Positional forms, as offsets from a letter's isolated presentation form.
+const (
+	formIsolated = 0
+	formFinal    = 1
+	formInitial  = 2
+	formMedial   = 3
+)
+
+const (
+	arabicLam     = 'ل'
+	arabicTatweel = 'ـ'
+)
+
+type arabicLetter struct {
+	join     joiningType
+	isolated rune // first presentation form in Arabic Presentation Forms-B
+}
+
+var (
+	arabicLetters = map[rune]arabicLetter{}
+	// presentationBase maps each presentation form back to its letters in
+	// visual order.
+	presentationBase = map[rune][]rune{}
+)
+
+// lamAlef maps the alef following a lam to the isolated lam-alef ligature.
+var lamAlef = map[rune]rune{
+	'آ': 'ﻵ',
+	'أ': 'ﻷ',
+	'إ': 'ﻹ',
+	'ا': 'ﻻ',
+}
+
+func init() {
+	// Letters U+0621..U+063A and U+0641..U+064A have their presentation
+	// forms laid out consecutively from U+FE80: one form for non-joining,
+	// two for right-joining and four for dual-joining letters.
+	next := rune(0xFE80)
+	add := func(first rune, types string) {
+		for i, t := range types {
+			letter := arabicLetter{isolated: next}
+			n := 0
+			switch t {
+			case 'U':
+				letter.join, n = joinNone, 1
+			case 'R':
+				letter.join, n = joinRight, 2
+			case 'D':
+				letter.join, n = joinDual, 4
+			}
+			base := first + rune(i)
+			arabicLetters[base] = letter
+			for k := 0; k < n; k++ {
+				presentationBase[next+rune(k)] = []rune{base}
+			}
+			next += rune(n)
+		}
+	}
+	add(0x0621, "URRRRDRDRDDDDDRRRRDDDDDDDD")
+	add(0x0641, "DDDDDDDRRD")
+
+	for alef, lig := range lamAlef {
+		presentationBase[lig] = []rune{alef, arabicLam}
+		presentationBase[lig+formFinal] = []rune{alef, arabicLam}
+	}
+}
+
+func joiningOf(r rune) joiningType {
+	if l, ok := arabicLetters[r]; ok {
+		return l.join
+	}
+	if r == arabicTatweel {
+		return joinCausing
+	}
+	if unicode.In(r, unicode.Mn, unicode.Me) {
+		return joinTransparent
+	}
+	return joinNone
+}
+
+// joinArabic replaces Arabic letters with their contextual presentation forms
+// and lam-alef pairs with their ligatures. It works in logical order and
+// returns the shaped runes along with the source index of each one.
+func joinArabic(runes []rune) ([]rune, []int) {
+	types := make([]joiningType, len(runes))
+	for i, r := range runes {
+		types[i] = joiningOf(r)
+	}
+	neighbour := func(i, step int) joiningType {
+		for i += step; i >= 0 && i < len(types); i += step {
+			if types[i] != joinTransparent {
+				return types[i]
+			}
+		}
+		return joinNone
+	}
+
+	out := make([]rune, 0, len(runes))
+	source := make([]int, 0, len(runes))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		letter, ok := arabicLetters[r]
+		if !ok || letter.join == joinNone {
+			out = append(out, r)
+			source = append(source, i)
+			continue
+		}
+
+		prev := neighbour(i, -1)
+		joinsPrev := prev == joinDual || prev == joinCausing
+
+		if r == arabicLam && i+1 < len(runes) {
+			if lig, ok := lamAlef[runes[i+1]]; ok {
+				if joinsPrev {
+					lig += formFinal
+				}
+				out = append(out, lig)
+				source = append(source, i)
+				i++
+				continue
+			}
+		}
+
+		next := neighbour(i, 1)
+		joinsNext := letter.join == joinDual &&
+			(next == joinRight || next == joinDual || next == joinCausing)
+
+		form := formIsolated
+		switch {
+		case joinsPrev && joinsNext:
+			form = formMedial
+		case joinsPrev:
+			form = formFinal
+		case joinsNext:
+			form = formInitial
+		}
+		out = append(out, letter.isolated+rune(form))
+		source = append(source, i)
+	}
+	return out, source
+}
+
+// Decompose returns the letters a presentation form was built from, in visual
+// order, or nil when r is not an Arabic presentation form. Renderers use it
+// to fall back to the base letters when a font lacks presentation forms.
+func Decompose(r rune) []rune {
+	return presentationBase[r]
+}
@@ -0,0 +1,239 @@
+package shaping
+
+import "unicode"
+
+// bidiClass is the reduced set of Unicode bidirectional classes the simple
+// shaper distinguishes. Explicit embeddings and isolates are not supported.
+type bidiClass uint8
+
+const (
+	bidiL   bidiClass = iota // strong left-to-right
+	bidiR                    // strong right-to-left (R and AL)
+	bidiEN                   // numbers (EN and AN)
+	bidiNSM                  // non-spacing marks
+	bidiON                   // whitespace, punctuation and other neutrals
+)
+
+func classify(r rune) bidiClass {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return bidiNSM
+	case unicode.IsDigit(r):
+		return bidiEN
+	case unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko):
+		return bidiR
+	case unicode.IsLetter(r) || unicode.In(r, unicode.Mc):
+		return bidiL
+	default:
+		return bidiON
+	}
+}
+
+// resolveLevels assigns an embedding level to every rune following a
+// simplified Unicode Bidirectional Algorithm: the paragraph direction comes
+// from the first strong character (P2-P3), marks take the type of their base
+// (W1), numbers after left-to-right text become left-to-right (W7), neutrals
+// take the direction of matching neighbours or the paragraph (N1-N2), and
+// implicit levels follow I1-I2.
+func resolveLevels(runes []rune) []uint8 {
+	classes := make([]bidiClass, len(runes))
+	for i, r := range runes {
+		classes[i] = classify(r)
+	}
+
+	base := bidiL
+	for _, c := range classes {
+		if c == bidiL || c == bidiR {
+			base = c
+			break
+		}
+	}
+
+	// W1: marks inherit the type of the preceding character.
+	prev := bidiON
+	for i, c := range classes {
+		if c == bidiNSM {
+			if i == 0 {
+				classes[i] = base
+			} else {
+				classes[i] = prev
+			}
+		}
+		prev = classes[i]
+	}
+
+	// W7: numbers preceded by left-to-right text are left-to-right.
+	strong := base
+	for i, c := range classes {
+		switch c {
+		case bidiL, bidiR:
+			strong = c
+		case bidiEN:
+			if strong == bidiL {
+				classes[i] = bidiL
+			}
+		}
+	}
+
+	// N1-N2: neutrals between characters of the same direction take that
+	// direction (numbers count as right-to-left), others the paragraph's.
+	asStrong := func(c bidiClass) bidiClass {
+		if c == bidiEN {
+			return bidiR
+		}
+		return c
+	}
+	for i := 0; i < len(classes); {
+		if classes[i] != bidiON {
+			i++
+			continue
+		}
+		j := i
+		for j < len(classes) && classes[j] == bidiON {
+			j++
+		}
+		before, after := base, base
+		if i > 0 {
+			before = asStrong(classes[i-1])
+		}
+		if j < len(classes) {
+			after = asStrong(classes[j])
+		}
+		resolved := base
+		if before == after {
+			resolved = before
+		}
+		for k := i; k < j; k++ {
+			classes[k] = resolved
+		}
+		i = j
+	}
+
+	// I1-I2: implicit levels.
+	levels := make([]uint8, len(classes))
+	for i, c := range classes {
+		switch {
+		case base == bidiL && c == bidiR:
+			levels[i] = 1
+		case base == bidiL && c == bidiEN:
+			levels[i] = 2
+		case base == bidiR && c == bidiR:
+			levels[i] = 1
+		case base == bidiR:
+			levels[i] = 2
+		}
+	}
+	return levels
+}
+
+// visualOrder returns the logical indices of runes in visual order (rule L2).
+// Combining marks stay after their base character (rule L3) so zero-advance
+// marks are drawn over the glyph they belong to.
+func visualOrder(runes []rune, levels []uint8) []int {
+	// Group base characters with their trailing marks.
+	type cluster struct {
+		start, end int
+		level      uint8
+	}
+	var clusters []cluster
+	var maxLevel, minOdd uint8 = 0, 255
+	for i := range runes {
+		if i > 0 && len(clusters) > 0 && unicode.In(runes[i], unicode.Mn, unicode.Me) {
+			clusters[len(clusters)-1].end = i + 1
+			continue
+		}
+		clusters = append(clusters, cluster{start: i, end: i + 1, level: levels[i]})
+		maxLevel = max(maxLevel, levels[i])
+		if levels[i]&1 == 1 {
+			minOdd = min(minOdd, levels[i])
+		}
+	}
+
+	// From the highest level down to the lowest odd level, reverse every
+	// maximal sequence at that level or above.
+	for level := maxLevel; level >= minOdd && level > 0; level-- {
+		for i := 0; i < len(clusters); {
+			if clusters[i].level < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(clusters) && clusters[j].level >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				clusters[a], clusters[b] = clusters[b], clusters[a]
+			}
+			i = j
+		}
+	}
+
+	order := make([]int, 0, len(runes))
+	for _, c := range clusters {
+		for i := c.start; i < c.end; i++ {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// RightToLeft reports for every rune of runes whether it is laid out right
+// to left, by the same bidi resolution the built-in shapers reorder with.
+func RightToLeft(runes []rune) []bool {
+	rtl := make([]bool, len(runes))
+	if !needsShaping(runes) {
+		return rtl
+	}
+	for i, level := range resolveLevels(runes) {
+		rtl[i] = level&1 == 1
+	}
+	return rtl
+}
+
+// levelRun is a maximal logical range of runes sharing one embedding level.
+type levelRun struct {
+	start, end int
+	rtl        bool
+}
+
+// visualRuns splits runes into level runs and returns them in visual order.
+func visualRuns(runes []rune) []levelRun {
+	levels := resolveLevels(runes)
+	var runs []levelRun
+	runOf := make([]int, len(runes))
+	for i := range runes {
+		if i == 0 || levels[i] != levels[i-1] {
+			runs = append(runs, levelRun{start: i, rtl: levels[i]&1 == 1})
+		}
+		runs[len(runs)-1].end = i + 1
+		runOf[i] = len(runs) - 1
+	}
+
+	visual := make([]levelRun, 0, len(runs))
+	seen := make([]bool, len(runs))
+	for _, i := range visualOrder(runes, levels) {
+		if r := runOf[i]; !seen[r] {
+			seen[r] = true
+			visual = append(visual, runs[r])
+		}
+	}
+	return visual
+}
+
+var mirrorPairs = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+	'‹': '›', '›': '‹',
+}
+
+// mirror returns the mirrored glyph for characters drawn right-to-left
+// (rule L4).
+func mirror(r rune) rune {
+	if m, ok := mirrorPairs[r]; ok {
+		return m
+	}
+	return r
+}
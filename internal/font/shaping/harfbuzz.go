@@ -0,0 +1,125 @@
+//go:build harfbuzz
+
+package shaping
+
+/*
+#cgo pkg-config: harfbuzz
+#include <stdlib.h>
+#include <hb.h>
+*/
+import "C"
+
+import (
+	"math"
+	"sync"
+	"unsafe"
+)
+
+// HarfBuzzShaper shapes text with HarfBuzz, covering OpenType features such as
+// Arabic joining, mark positioning and Indic reordering. Bidirectional runs
+// are split with the same rules as SimpleShaper and shaped one by one.
+//
+// It is safe for concurrent use. Faces are loaded from Font.FileName on first
+// use and kept until Close.
+type HarfBuzzShaper struct {
+	mu    sync.Mutex
+	faces map[hbFaceKey]*C.hb_face_t
+}
+
+type hbFaceKey struct {
+	fileName string
+	index    int
+}
+
+// NewHarfBuzzShaper creates a shaper with an empty face cache.
+func NewHarfBuzzShaper() *HarfBuzzShaper {
+	return &HarfBuzzShaper{faces: make(map[hbFaceKey]*C.hb_face_t)}
+}
+
+// Close releases all cached faces.
+func (s *HarfBuzzShaper) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, face := range s.faces {
+		C.hb_face_destroy(face)
+		delete(s.faces, key)
+	}
+}
+
+// Shape implements TextShaper. It falls back to SimpleShaper when the font
+// file cannot be opened.
+func (s *HarfBuzzShaper) Shape(text string, font Font) ShapedText {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	face := s.face(font)
+	if face == nil || text == "" {
+		return SimpleShaper{}.Shape(text, font)
+	}
+	hbFont := C.hb_font_create(face)
+	defer C.hb_font_destroy(hbFont)
+	scale := C.int(math.Round(font.Size * 64))
+	C.hb_font_set_scale(hbFont, scale, scale)
+
+	runes := []rune(text)
+	codepoints := make([]C.hb_codepoint_t, len(runes))
+	for i, r := range runes {
+		codepoints[i] = C.hb_codepoint_t(r)
+	}
+
+	shaped := ShapedText{Positioned: true}
+	for _, run := range visualRuns(runes) {
+		buf := C.hb_buffer_create()
+		C.hb_buffer_add_codepoints(buf, &codepoints[0], C.int(len(codepoints)),
+			C.uint(run.start), C.int(run.end-run.start))
+		if run.rtl {
+			C.hb_buffer_set_direction(buf, C.HB_DIRECTION_RTL)
+		} else {
+			C.hb_buffer_set_direction(buf, C.HB_DIRECTION_LTR)
+		}
+		C.hb_buffer_guess_segment_properties(buf)
+		C.hb_shape(hbFont, buf, nil, 0)
+
+		var n C.uint
+		infos := unsafe.Slice(C.hb_buffer_get_glyph_infos(buf, &n), int(n))
+		positions := unsafe.Slice(C.hb_buffer_get_glyph_positions(buf, &n), int(n))
+		for i := range infos {
+			cluster := int(infos[i].cluster)
+			shaped.Glyphs = append(shaped.Glyphs, ShapedGlyph{
+				Rune:       runes[cluster],
+				GlyphIndex: uint(infos[i].codepoint),
+				Cluster:    cluster,
+				XAdvance:   float64(positions[i].x_advance) / 64,
+				YAdvance:   float64(positions[i].y_advance) / 64,
+				XOffset:    float64(positions[i].x_offset) / 64,
+				YOffset:    float64(positions[i].y_offset) / 64,
+			})
+		}
+		C.hb_buffer_destroy(buf)
+	}
+	return shaped
+}
+
+func (s *HarfBuzzShaper) face(font Font) *C.hb_face_t {
+	key := hbFaceKey{font.FileName, font.FaceIndex}
+	if face, ok := s.faces[key]; ok {
+		return face
+	}
+	if font.FileName == "" {
+		return nil
+	}
+	cName := C.CString(font.FileName)
+	defer C.free(unsafe.Pointer(cName))
+	blob := C.hb_blob_create_from_file(cName)
+	defer C.hb_blob_destroy(blob)
+	if C.hb_blob_get_length(blob) == 0 {
+		return nil
+	}
+	face := C.hb_face_create(blob, C.uint(font.FaceIndex))
+	if C.hb_face_get_glyph_count(face) == 0 {
+		C.hb_face_destroy(face)
+		return nil
+	}
+	s.faces[key] = face
+	return face
+}
@@ -0,0 +1,91 @@
+// Package shaping turns a string into the sequence of glyphs the Agg2D text
+// pipeline draws from left to right.
+//
+// AGG itself draws one glyph per character in logical order, which is only
+// correct for simple left-to-right scripts. A TextShaper sits in front of the
+// glyph cache and resolves bidirectional ordering and contextual forms first.
+// SimpleShaper is the dependency-free default: it reorders Hebrew and Arabic
+// runs and applies Arabic joining through the Unicode presentation forms. A
+// HarfBuzz-backed shaper for full OpenType shaping (including Indic scripts)
+// is available with the harfbuzz build tag.
+package shaping
+
+// Font describes the face a shaper should use.
+type Font struct {
+	FileName  string  // Font file loaded by the font engine
+	FaceIndex int     // Face index within FileName
+	Size      float64 // Em size in the font engine's output units
+}
+
+// ShapedGlyph is one glyph of a shaped string in visual order.
+type ShapedGlyph struct {
+	// Rune is the character to draw. It is used when GlyphIndex is zero and
+	// as a fallback when the glyph index cannot be loaded.
+	Rune rune
+	// GlyphIndex is the font glyph index chosen by the shaper, or zero to
+	// look the glyph up by Rune.
+	GlyphIndex uint
+	// Cluster is the index of the first source rune this glyph represents.
+	Cluster int
+	// XAdvance and YAdvance move the pen after this glyph. They are only
+	// meaningful when the containing ShapedText is Positioned.
+	XAdvance, YAdvance float64
+	// XOffset and YOffset displace this glyph from the pen position, with Y
+	// pointing up as in font design space. Only used when Positioned.
+	XOffset, YOffset float64
+}
+
+// ShapedText is the result of shaping one string.
+type ShapedText struct {
+	Glyphs []ShapedGlyph
+	// Positioned reports that the shaper set advances and offsets. Otherwise
+	// the caller advances by the font's glyph metrics and applies kerning.
+	Positioned bool
+}
+
+// TextShaper converts text into glyphs in visual (left-to-right drawing)
+// order.
+type TextShaper interface {
+	Shape(text string, font Font) ShapedText
+}
+
+// SimpleShaper implements bidirectional reordering and Arabic joining
+// without external dependencies. Glyph positions are left to the font
+// engine, so kerning and ligature settings still apply to its output.
+type SimpleShaper struct{}
+
+// Shape implements TextShaper.
+func (SimpleShaper) Shape(text string, _ Font) ShapedText {
+	runes := []rune(text)
+	if !needsShaping(runes) {
+		glyphs := make([]ShapedGlyph, len(runes))
+		for i, r := range runes {
+			glyphs[i] = ShapedGlyph{Rune: r, Cluster: i}
+		}
+		return ShapedText{Glyphs: glyphs}
+	}
+
+	joined, source := joinArabic(runes)
+	levels := resolveLevels(joined)
+	order := visualOrder(joined, levels)
+	glyphs := make([]ShapedGlyph, 0, len(order))
+	for _, i := range order {
+		r := joined[i]
+		if levels[i]&1 == 1 {
+			r = mirror(r)
+		}
+		glyphs = append(glyphs, ShapedGlyph{Rune: r, Cluster: source[i]})
+	}
+	return ShapedText{Glyphs: glyphs}
+}
+
+// needsShaping reports whether runes contain right-to-left characters.
+// Purely left-to-right text passes through SimpleShaper unchanged.
+func needsShaping(runes []rune) bool {
+	for _, r := range runes {
+		if r >= 0x0590 && classify(r) == bidiR {
+			return true
+		}
+	}
+	return false
+}
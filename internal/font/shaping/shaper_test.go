@@ -0,0 +1,111 @@
+package shaping
+
+import "testing"
+
+func shapedRunes(text string) string {
+	var out []rune
+	for _, g := range (SimpleShaper{}).Shape(text, Font{}).Glyphs {
+		out = append(out, g.Rune)
+	}
+	return string(out)
+}
+
+func TestSimpleShaperPassesLatinThrough(t *testing.T) {
+	shaped := SimpleShaper{}.Shape("Hello (1)", Font{})
+	if shaped.Positioned {
+		t.Fatal("simple shaper should leave positioning to the font engine")
+	}
+	if got := shapedRunes("Hello (1)"); got != "Hello (1)" {
+		t.Fatalf("got %q", got)
+	}
+	for i, g := range shaped.Glyphs {
+		if g.Cluster != i {
+			t.Fatalf("glyph %d cluster=%d", i, g.Cluster)
+		}
+	}
+}
+
+func TestSimpleShaperReordersHebrew(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"שלום", "םולש"},
+		{"abc שלום def", "abc םולש def"},
+		{"שלום abc", "abc םולש"},
+		{"שלום 123", "123 םולש"},
+		{"abc שלום 123", "abc 123 םולש"},
+		{"(שלום)", "(םולש)"},
+	}
+	for _, tt := range tests {
+		if got := shapedRunes(tt.in); got != tt.want {
+			t.Errorf("Shape(%q)=%q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestSimpleShaperKeepsMarksAfterBase(t *testing.T) {
+	// Bet with dagesh followed by shin: the mark must follow its base.
+	if got, want := shapedRunes("בּש"), "שבּ"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestSimpleShaperJoinsArabic(t *testing.T) {
+	// "سلام": seen initial, lam-alef final ligature, meem isolated, drawn
+	// right to left.
+	got := shapedRunes("سلام")
+	want := string([]rune{0xFEE1, 0xFEFC, 0xFEB3})
+	if got != want {
+		t.Fatalf("got %U, want %U", []rune(got), []rune(want))
+	}
+
+	// "بيت": beh initial, yeh medial, teh final.
+	got = shapedRunes("بيت")
+	want = string([]rune{0xFE96, 0xFEF4, 0xFE91})
+	if got != want {
+		t.Fatalf("got %U, want %U", []rune(got), []rune(want))
+	}
+}
+
+func TestSimpleShaperClusters(t *testing.T) {
+	glyphs := SimpleShaper{}.Shape("سلام", Font{}).Glyphs
+	want := []int{3, 1, 0}
+	if len(glyphs) != len(want) {
+		t.Fatalf("got %d glyphs, want %d", len(glyphs), len(want))
+	}
+	for i, g := range glyphs {
+		if g.Cluster != want[i] {
+			t.Fatalf("glyph %d cluster=%d, want %d", i, g.Cluster, want[i])
+		}
+	}
+}
+
+func TestDecompose(t *testing.T) {
+	if got := Decompose(0xFEB3); len(got) != 1 || got[0] != 'س' {
+		t.Fatalf("Decompose(seen initial)=%q", got)
+	}
+	if got := Decompose(0xFEFC); string(got) != "ال" {
+		t.Fatalf("Decompose(lam-alef final)=%q, want alef then lam", got)
+	}
+	if Decompose('a') != nil {
+		t.Fatal("Latin letters have no presentation-form base")
+	}
+}
+
+func TestVisualRuns(t *testing.T) {
+	runs := visualRuns([]rune("ab שלום cd"))
+	if len(runs) != 3 {
+		t.Fatalf("got %d runs, want 3", len(runs))
+	}
+	if runs[0].rtl || !runs[1].rtl || runs[2].rtl {
+		t.Fatalf("unexpected run directions %+v", runs)
+	}
+}
+
+func TestRightToLeft(t *testing.T) {
+	rtl := RightToLeft([]rune("ab שלום"))
+	want := []bool{false, false, false, true, true, true, true}
+	for i := range want {
+		if rtl[i] != want[i] {
+			t.Fatalf("RightToLeft=%v, want %v", rtl, want)
+		}
+	}
+}
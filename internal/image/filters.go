@@ -140,6 +140,13 @@ func (lut *ImageFilterLUT) Normalize() {
 	lut.weightArray.Set(0, lut.weightArray.At(end))
 }
 
+// Copy returns an independent copy of the lookup table.
+func (lut *ImageFilterLUT) Copy() *ImageFilterLUT {
+	c := *lut
+	c.weightArray = array.NewPodArrayCopy(lut.weightArray)
+	return &c
+}
+
 // Radius returns the filter radius
 func (lut *ImageFilterLUT) Radius() float64 {
 	return lut.radius
@@ -15,15 +15,15 @@ import (
 type RGBABlender16[S color.Space] interface {
 	// GetPlain reads a pixel and returns plain RGBA components
 	// interpreted according to color space S
-	GetPlain(src []basics.Int8u) (r, g, b, a basics.Int16u)
+	GetPlain(src []basics.Int16u) (r, g, b, a basics.Int16u)
 
 	// SetPlain writes plain RGBA components to a pixel, mapping them to the
 	// internal order of the blender
-	SetPlain(dst []basics.Int8u, r, g, b, a basics.Int16u)
+	SetPlain(dst []basics.Int16u, r, g, b, a basics.Int16u)
 
 	// BlendPix blends plain RGBA source into the pixel with given coverage
 	// r,g,b,a are interpreted according to S, and mapped to the order internal to the blender
-	BlendPix(dst []basics.Int8u, r, g, b, a, cover basics.Int16u)
+	BlendPix(dst []basics.Int16u, r, g, b, a, cover basics.Int16u)
 }
 
 // RawRGBA16Order provides optional fast path for zero-cost index access for RGBA16.
@@ -44,18 +44,18 @@ type BlenderRGBA16[S color.Space, O order.RGBAOrder] struct{}
 
 // BlendPix blends a non-premultiplied RGBA16 source into a premultiplied buffer.
 // Alpha is scaled by coverage; channels use lerp; alpha uses prelerp.
-func (BlenderRGBA16[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover basics.Int16u) {
+func (BlenderRGBA16[S, O]) BlendPix(dst []basics.Int16u, r, g, b, a, cover basics.Int16u) {
 	alpha := color.RGBA16MultCover(a, cover)
 	if alpha == 0 {
 		return
 	}
 	var o O
 
-	// Load dst components (little-endian) in order O
-	dr := basics.Int16u(dst[o.IdxR()*2]) | basics.Int16u(dst[o.IdxR()*2+1])<<8
-	dg := basics.Int16u(dst[o.IdxG()*2]) | basics.Int16u(dst[o.IdxG()*2+1])<<8
-	db := basics.Int16u(dst[o.IdxB()*2]) | basics.Int16u(dst[o.IdxB()*2+1])<<8
-	da := basics.Int16u(dst[o.IdxA()*2]) | basics.Int16u(dst[o.IdxA()*2+1])<<8
+	// Load dst components in order O
+	dr := dst[o.IdxR()]
+	dg := dst[o.IdxG()]
+	db := dst[o.IdxB()]
+	da := dst[o.IdxA()]
 
 	// Blend
 	dr = color.RGBA16Lerp(dr, r, alpha)
@@ -63,40 +63,33 @@ func (BlenderRGBA16[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover basics
 	db = color.RGBA16Lerp(db, b, alpha)
 	da = color.RGBA16Prelerp(da, alpha, alpha)
 
-	// Store back (little-endian)
-	dst[o.IdxR()*2+0], dst[o.IdxR()*2+1] = basics.Int8u(dr), basics.Int8u(dr>>8)
-	dst[o.IdxG()*2+0], dst[o.IdxG()*2+1] = basics.Int8u(dg), basics.Int8u(dg>>8)
-	dst[o.IdxB()*2+0], dst[o.IdxB()*2+1] = basics.Int8u(db), basics.Int8u(db>>8)
-	dst[o.IdxA()*2+0], dst[o.IdxA()*2+1] = basics.Int8u(da), basics.Int8u(da>>8)
+	// Store back
+	dst[o.IdxR()] = dr
+	dst[o.IdxG()] = dg
+	dst[o.IdxB()] = db
+	dst[o.IdxA()] = da
 }
 
-func (BlenderRGBA16[S, O]) SetPlain(dst []basics.Int8u, r, g, b, a basics.Int16u) {
+func (BlenderRGBA16[S, O]) SetPlain(dst []basics.Int16u, r, g, b, a basics.Int16u) {
 	// store PREMULTIPLIED to the framebuffer
 	var o O
 	pr := color.RGBA16Multiply(r, a)
 	pg := color.RGBA16Multiply(g, a)
 	pb := color.RGBA16Multiply(b, a)
 
-	put16 := func(off int, v basics.Int16u) {
-		dst[off+0] = basics.Int8u(v)
-		dst[off+1] = basics.Int8u(v >> 8)
-	}
-	put16(o.IdxR()*2, pr)
-	put16(o.IdxG()*2, pg)
-	put16(o.IdxB()*2, pb)
-	put16(o.IdxA()*2, a)
+	dst[o.IdxR()] = pr
+	dst[o.IdxG()] = pg
+	dst[o.IdxB()] = pb
+	dst[o.IdxA()] = a
 }
 
-func (BlenderRGBA16[S, O]) GetPlain(src []basics.Int8u) (r, g, b, a basics.Int16u) {
+func (BlenderRGBA16[S, O]) GetPlain(src []basics.Int16u) (r, g, b, a basics.Int16u) {
 	// read PREMULTIPLIED from the framebuffer, return PLAIN
 	var o O
-	get16 := func(off int) basics.Int16u {
-		return basics.Int16u(src[off]) | basics.Int16u(src[off+1])<<8
-	}
-	pr := get16(o.IdxR() * 2)
-	pg := get16(o.IdxG() * 2)
-	pb := get16(o.IdxB() * 2)
-	a = get16(o.IdxA() * 2)
+	pr := src[o.IdxR()]
+	pg := src[o.IdxG()]
+	pb := src[o.IdxB()]
+	a = src[o.IdxA()]
 
 	if a != 0 {
 		r = demul16(pr, a)
@@ -124,7 +117,7 @@ type BlenderRGBA16Pre[S color.Space, O order.RGBAOrder] struct{}
 
 // BlendPix blends premultiplied RGBA16 into premultiplied buffer.
 // Coverage scales all premultiplied components; channels & alpha use prelerp.
-func (BlenderRGBA16Pre[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover basics.Int16u) {
+func (BlenderRGBA16Pre[S, O]) BlendPix(dst []basics.Int16u, r, g, b, a, cover basics.Int16u) {
 	// Scale by coverage only when not full mask
 	if cover != 0xFFFF {
 		r = color.RGBA16MultCover(r, cover)
@@ -138,11 +131,11 @@ func (BlenderRGBA16Pre[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover bas
 	}
 	var o O
 
-	// Load dst (little-endian)
-	dr := basics.Int16u(dst[o.IdxR()*2]) | basics.Int16u(dst[o.IdxR()*2+1])<<8
-	dg := basics.Int16u(dst[o.IdxG()*2]) | basics.Int16u(dst[o.IdxG()*2+1])<<8
-	db := basics.Int16u(dst[o.IdxB()*2]) | basics.Int16u(dst[o.IdxB()*2+1])<<8
-	da := basics.Int16u(dst[o.IdxA()*2]) | basics.Int16u(dst[o.IdxA()*2+1])<<8
+	// Load dst
+	dr := dst[o.IdxR()]
+	dg := dst[o.IdxG()]
+	db := dst[o.IdxB()]
+	da := dst[o.IdxA()]
 
 	// Blend in premultiplied space
 	dr = color.RGBA16Prelerp(dr, r, a)
@@ -150,18 +143,18 @@ func (BlenderRGBA16Pre[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover bas
 	db = color.RGBA16Prelerp(db, b, a)
 	da = color.RGBA16Prelerp(da, a, a)
 
-	// Store (little-endian)
-	dst[o.IdxR()*2+0], dst[o.IdxR()*2+1] = basics.Int8u(dr), basics.Int8u(dr>>8)
-	dst[o.IdxG()*2+0], dst[o.IdxG()*2+1] = basics.Int8u(dg), basics.Int8u(dg>>8)
-	dst[o.IdxB()*2+0], dst[o.IdxB()*2+1] = basics.Int8u(db), basics.Int8u(db>>8)
-	dst[o.IdxA()*2+0], dst[o.IdxA()*2+1] = basics.Int8u(da), basics.Int8u(da>>8)
+	// Store
+	dst[o.IdxR()] = dr
+	dst[o.IdxG()] = dg
+	dst[o.IdxB()] = db
+	dst[o.IdxA()] = da
 }
 
-func (BlenderRGBA16Pre[S, O]) SetPlain(dst []basics.Int8u, r, g, b, a basics.Int16u) {
+func (BlenderRGBA16Pre[S, O]) SetPlain(dst []basics.Int16u, r, g, b, a basics.Int16u) {
 	BlenderRGBA16[S, O]{}.SetPlain(dst, r, g, b, a) // premultiply on write
 }
 
-func (BlenderRGBA16Pre[S, O]) GetPlain(src []basics.Int8u) (r, g, b, a basics.Int16u) {
+func (BlenderRGBA16Pre[S, O]) GetPlain(src []basics.Int16u) (r, g, b, a basics.Int16u) {
 	return BlenderRGBA16[S, O]{}.GetPlain(src) // demultiply on read
 }
 
@@ -180,18 +173,18 @@ func (BlenderRGBA16Pre[S, O]) IdxA() int { var o O; return o.IdxA() }
 type BlenderRGBA16Plain[S color.Space, O order.RGBAOrder] struct{}
 
 // BlendPix blends plain src into plain dst using premultiplied math internally.
-func (BlenderRGBA16Plain[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover basics.Int16u) {
+func (BlenderRGBA16Plain[S, O]) BlendPix(dst []basics.Int16u, r, g, b, a, cover basics.Int16u) {
 	alpha := color.RGBA16MultCover(a, cover)
 	if alpha == 0 {
 		return
 	}
 	var o O
 
-	// Load dst (little-endian)
-	dr := basics.Int16u(dst[o.IdxR()*2]) | basics.Int16u(dst[o.IdxR()*2+1])<<8
-	dg := basics.Int16u(dst[o.IdxG()*2]) | basics.Int16u(dst[o.IdxG()*2+1])<<8
-	db := basics.Int16u(dst[o.IdxB()*2]) | basics.Int16u(dst[o.IdxB()*2+1])<<8
-	da := basics.Int16u(dst[o.IdxA()*2]) | basics.Int16u(dst[o.IdxA()*2+1])<<8
+	// Load dst
+	dr := dst[o.IdxR()]
+	dg := dst[o.IdxG()]
+	db := dst[o.IdxB()]
+	da := dst[o.IdxA()]
 
 	// Premultiply destination by its alpha
 	pdr := color.RGBA16Multiply(dr, da)
@@ -213,34 +206,27 @@ func (BlenderRGBA16Plain[S, O]) BlendPix(dst []basics.Int8u, r, g, b, a, cover b
 		dr, dg, db = 0, 0, 0
 	}
 
-	// Store (little-endian)
-	dst[o.IdxR()*2+0], dst[o.IdxR()*2+1] = basics.Int8u(dr), basics.Int8u(dr>>8)
-	dst[o.IdxG()*2+0], dst[o.IdxG()*2+1] = basics.Int8u(dg), basics.Int8u(dg>>8)
-	dst[o.IdxB()*2+0], dst[o.IdxB()*2+1] = basics.Int8u(db), basics.Int8u(db>>8)
-	dst[o.IdxA()*2+0], dst[o.IdxA()*2+1] = basics.Int8u(da), basics.Int8u(da>>8)
+	// Store
+	dst[o.IdxR()] = dr
+	dst[o.IdxG()] = dg
+	dst[o.IdxB()] = db
+	dst[o.IdxA()] = da
 }
 
-func (BlenderRGBA16Plain[S, O]) SetPlain(dst []basics.Int8u, r, g, b, a basics.Int16u) {
+func (BlenderRGBA16Plain[S, O]) SetPlain(dst []basics.Int16u, r, g, b, a basics.Int16u) {
 	var o O
-	put16 := func(off int, v basics.Int16u) {
-		dst[off+0] = basics.Int8u(v)
-		dst[off+1] = basics.Int8u(v >> 8)
-	}
-	put16(o.IdxR()*2, r)
-	put16(o.IdxG()*2, g)
-	put16(o.IdxB()*2, b)
-	put16(o.IdxA()*2, a)
+	dst[o.IdxR()] = r
+	dst[o.IdxG()] = g
+	dst[o.IdxB()] = b
+	dst[o.IdxA()] = a
 }
 
-func (BlenderRGBA16Plain[S, O]) GetPlain(src []basics.Int8u) (r, g, b, a basics.Int16u) {
+func (BlenderRGBA16Plain[S, O]) GetPlain(src []basics.Int16u) (r, g, b, a basics.Int16u) {
 	var o O
-	get16 := func(off int) basics.Int16u {
-		return basics.Int16u(src[off]) | basics.Int16u(src[off+1])<<8
-	}
-	r = get16(o.IdxR() * 2)
-	g = get16(o.IdxG() * 2)
-	b = get16(o.IdxB() * 2)
-	a = get16(o.IdxA() * 2)
+	r = src[o.IdxR()]
+	g = src[o.IdxG()]
+	b = src[o.IdxB()]
+	a = src[o.IdxA()]
 	return
 }
 
@@ -261,7 +247,7 @@ func demul16(x, a basics.Int16u) basics.Int16u {
 
 // BlendRGBA16Pixel is a typed helper like the 8-bit version.
 func BlendRGBA16Pixel[B RGBABlender16[S], S color.Space](
-	dst []basics.Int8u,
+	dst []basics.Int16u,
 	src color.RGBA16[S],
 	cover basics.Int16u,
 	b B,
@@ -17,7 +17,7 @@ func TestBlenderRGBA16SetGetPlainRoundTrip(t *testing.T) {
 			name: "PremultipliedFramebuffer",
 			run: func(t *testing.T) {
 				bl := BlenderRGBA16[color.Linear, order.RGBA]{}
-				dst := make([]basics.Int8u, 8)
+				dst := make([]basics.Int16u, 4)
 				bl.SetPlain(dst, 40000, 20000, 10000, 50000)
 				r, g, b, a := bl.GetPlain(dst)
 				if r < 39990 || r > 40010 || g < 19990 || g > 20010 || b < 9990 || b > 10010 || a != 50000 {
@@ -32,7 +32,7 @@ func TestBlenderRGBA16SetGetPlainRoundTrip(t *testing.T) {
 			name: "PremultipliedSourceBlender",
 			run: func(t *testing.T) {
 				bl := BlenderRGBA16Pre[color.Linear, order.RGBA]{}
-				dst := make([]basics.Int8u, 8)
+				dst := make([]basics.Int16u, 4)
 				bl.SetPlain(dst, 30000, 15000, 5000, 45000)
 				r, g, b, a := bl.GetPlain(dst)
 				if r < 29990 || r > 30010 || g < 14990 || g > 15010 || b < 4990 || b > 5010 || a != 45000 {
@@ -44,7 +44,7 @@ func TestBlenderRGBA16SetGetPlainRoundTrip(t *testing.T) {
 			name: "PlainFramebuffer",
 			run: func(t *testing.T) {
 				bl := BlenderRGBA16Plain[color.Linear, order.RGBA]{}
-				dst := make([]basics.Int8u, 8)
+				dst := make([]basics.Int16u, 4)
 				bl.SetPlain(dst, 11111, 22222, 33333, 44444)
 				r, g, b, a := bl.GetPlain(dst)
 				if r != 11111 || g != 22222 || b != 33333 || a != 44444 {
@@ -62,23 +62,23 @@ func TestBlenderRGBA16SetGetPlainRoundTrip(t *testing.T) {
 func TestBlenderRGBA16BlendPixChangesDestination(t *testing.T) {
 	tests := []struct {
 		name string
-		run  func(dst []basics.Int8u)
+		run  func(dst []basics.Int16u)
 	}{
 		{
 			name: "PlainToPremul",
-			run: func(dst []basics.Int8u) {
+			run: func(dst []basics.Int16u) {
 				BlenderRGBA16[color.Linear, order.RGBA]{}.BlendPix(dst, 50000, 10000, 2000, 32768, 65535)
 			},
 		},
 		{
 			name: "PremulToPremul",
-			run: func(dst []basics.Int8u) {
+			run: func(dst []basics.Int16u) {
 				BlenderRGBA16Pre[color.Linear, order.RGBA]{}.BlendPix(dst, 25000, 5000, 1000, 32768, 65535)
 			},
 		},
 		{
 			name: "PlainToPlain",
-			run: func(dst []basics.Int8u) {
+			run: func(dst []basics.Int16u) {
 				BlenderRGBA16Plain[color.Linear, order.RGBA]{}.BlendPix(dst, 45000, 12000, 3000, 32768, 65535)
 			},
 		},
@@ -86,7 +86,7 @@ func TestBlenderRGBA16BlendPixChangesDestination(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			dst := make([]basics.Int8u, 8)
+			dst := make([]basics.Int16u, 4)
 			tc.run(dst)
 			zero := true
 			for _, v := range dst {
@@ -103,7 +103,7 @@ func TestBlenderRGBA16BlendPixChangesDestination(t *testing.T) {
 }
 
 func TestBlendRGBA16Pixel(t *testing.T) {
-	dst := make([]basics.Int8u, 8)
+	dst := make([]basics.Int16u, 4)
 	src := color.NewRGBA16[color.Linear](40000, 30000, 20000, 50000)
 	var bl BlenderRGBA16[color.Linear, order.RGBA]
 
@@ -113,7 +113,7 @@ func TestBlendRGBA16Pixel(t *testing.T) {
 		t.Fatalf("BlendRGBA16Pixel produced zeroed pixel (%d,%d,%d,%d)", r, g, b, a)
 	}
 
-	orig := append([]basics.Int8u(nil), dst...)
+	orig := append([]basics.Int16u(nil), dst...)
 	transparent := color.NewRGBA16[color.Linear](60000, 60000, 60000, 0)
 	BlendRGBA16Pixel(dst, transparent, 65535, bl)
 	for i := range dst {
@@ -373,13 +373,18 @@ func (pf *PixFmtCompositeRGBA[CS, O]) BlendFrom(src compositeBlendFromSource[CS]
 	if rowSrc, ok := src.(interface{ RowData(y int) []basics.Int8u }); ok {
 		srcRow := rowSrc.RowData(ysrc)
 		if srcRow != nil {
+			// Rows are RGBA unless the source tells its order.
+			sor := color.OrderRGBA
+			if ot, ok := src.(interface{ OrderType() color.ColorOrder }); ok {
+				sor = ot.OrderType()
+			}
 			for i := 0; i < length; i++ {
 				srcOff := (xsrc + i) * 4
 				pf.BlendPixel(xdst+i, ydst, color.RGBA8[CS]{
-					R: srcRow[srcOff+0],
-					G: srcRow[srcOff+1],
-					B: srcRow[srcOff+2],
-					A: srcRow[srcOff+3],
+					R: srcRow[srcOff+sor.R],
+					G: srcRow[srcOff+sor.G],
+					B: srcRow[srcOff+sor.B],
+					A: srcRow[srcOff+sor.A],
 				}, cover)
 			}
 			return
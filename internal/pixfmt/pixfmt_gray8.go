@@ -379,7 +379,7 @@ func (pf *PixFmtAlphaBlendGray[CS, B]) CopyBar(x1, y1, x2, y2 int, c color.Gray8
 	y2 = Min(pf.Height()-1, y2)
 
 	for y := y1; y <= y2; y++ {
-		pf.CopyHline(x1, y, x2, c)
+		pf.CopyHline(x1, y, x2-x1+1, c)
 	}
 }
 
@@ -402,7 +402,7 @@ func (pf *PixFmtAlphaBlendGray[CS, B]) BlendBar(x1, y1, x2, y2 int, c color.Gray
 	y2 = Min(pf.Height()-1, y2)
 
 	for y := y1; y <= y2; y++ {
-		pf.BlendHline(x1, y, x2, c, cover)
+		pf.BlendHline(x1, y, x2-x1+1, c, cover)
 	}
 }
 
@@ -22,6 +22,11 @@ func (pa *PixFmtRGBARendererAdaptor[S, B]) Width() int    { return pa.pixfmt.Wid
 func (pa *PixFmtRGBARendererAdaptor[S, B]) Height() int   { return pa.pixfmt.Height() }
 func (pa *PixFmtRGBARendererAdaptor[S, B]) PixWidth() int { return pa.pixfmt.PixWidth() }
 
+// RowData returns the raw row bytes of the wrapped RGB format.
+func (pa *PixFmtRGBARendererAdaptor[S, B]) RowData(y int) []basics.Int8u {
+	return pa.pixfmt.RowData(y)
+}
+
 func (pa *PixFmtRGBARendererAdaptor[S, B]) Pixel(x, y int) color.RGBA8[S] {
 	c := pa.pixfmt.Pixel(x, y)
 	return color.RGBA8[S]{R: c.R, G: c.G, B: c.B, A: 255}
@@ -11,6 +11,9 @@ import (
 // pixWidth16 is bytes per pixel for RGBA16 (4 channels × 2 bytes).
 const pixWidth16 = 8
 
+// pixElems16 is the number of 16-bit values per RGBA16 pixel.
+const pixElems16 = 4
+
 // cover8to16 scales an 8-bit coverage value (0–255) to 16-bit (0–65535).
 // 255 × 257 = 65535 exactly.
 func cover8to16(c basics.Int8u) basics.Int16u {
@@ -18,15 +21,15 @@ func cover8to16(c basics.Int8u) basics.Int16u {
 }
 
 // PixFmtAlphaBlendRGBA16 is the 16-bit RGBA pixel format with alpha blending.
-// Each pixel occupies 8 bytes (4 channels × 2 bytes, little-endian), stored in
-// a standard RenderingBufferU8 with stride = width*8.
+// Each pixel is 4 native uint16 channels (8 bytes), stored in a
+// RenderingBufferU16 with stride = width*8 bytes.
 type PixFmtAlphaBlendRGBA16[S color.Space, B blender.RGBABlender16[S]] struct {
-	rbuf    *buffer.RenderingBufferU8
+	rbuf    *buffer.RenderingBufferU16
 	blender B
 }
 
 // NewPixFmtAlphaBlendRGBA16 creates a new RGBA16 pixel format.
-func NewPixFmtAlphaBlendRGBA16[S color.Space, B blender.RGBABlender16[S]](rbuf *buffer.RenderingBufferU8, b B) *PixFmtAlphaBlendRGBA16[S, B] {
+func NewPixFmtAlphaBlendRGBA16[S color.Space, B blender.RGBABlender16[S]](rbuf *buffer.RenderingBufferU16, b B) *PixFmtAlphaBlendRGBA16[S, B] {
 	return &PixFmtAlphaBlendRGBA16[S, B]{rbuf: rbuf, blender: b}
 }
 
@@ -35,12 +38,12 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) Height() int   { return pf.rbuf.Height()
 func (pf *PixFmtAlphaBlendRGBA16[S, B]) PixWidth() int { return pixWidth16 }
 func (pf *PixFmtAlphaBlendRGBA16[S, B]) Stride() int   { return pf.rbuf.Stride() }
 
-// RowData returns the raw row bytes for transfer operations.
-func (pf *PixFmtAlphaBlendRGBA16[S, B]) RowData(y int) []basics.Int8u {
+// RowData returns the raw row values for transfer operations.
+func (pf *PixFmtAlphaBlendRGBA16[S, B]) RowData(y int) []basics.Int16u {
 	if y < 0 || y >= pf.Height() {
 		return nil
 	}
-	return buffer.RowU8(pf.rbuf, y)
+	return buffer.RowU16(pf.rbuf, y)
 }
 
 // Pixel returns the (demultiplied) color at (x, y).
@@ -48,12 +51,12 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) Pixel(x, y int) color.RGBA16[S] {
 	if !InBounds(x, y, pf.Width(), pf.Height()) {
 		return color.RGBA16[S]{}
 	}
-	row := buffer.RowU8(pf.rbuf, y)
-	off := x * pixWidth16
-	if off+pixWidth16 > len(row) {
+	row := buffer.RowU16(pf.rbuf, y)
+	off := x * pixElems16
+	if off+pixElems16 > len(row) {
 		return color.RGBA16[S]{}
 	}
-	r, g, b, a := pf.blender.GetPlain(row[off : off+pixWidth16])
+	r, g, b, a := pf.blender.GetPlain(row[off : off+pixElems16])
 	return color.RGBA16[S]{R: r, G: g, B: b, A: a}
 }
 
@@ -62,12 +65,12 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) CopyPixel(x, y int, c color.RGBA16[S]) {
 	if !InBounds(x, y, pf.Width(), pf.Height()) {
 		return
 	}
-	row := buffer.RowU8(pf.rbuf, y)
-	off := x * pixWidth16
-	if off+pixWidth16 > len(row) {
+	row := buffer.RowU16(pf.rbuf, y)
+	off := x * pixElems16
+	if off+pixElems16 > len(row) {
 		return
 	}
-	pf.blender.SetPlain(row[off:off+pixWidth16], c.R, c.G, c.B, c.A)
+	pf.blender.SetPlain(row[off:off+pixElems16], c.R, c.G, c.B, c.A)
 }
 
 // BlendPixel blends a pixel with 8-bit coverage (converted to 16-bit internally).
@@ -75,12 +78,12 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) BlendPixel(x, y int, c color.RGBA16[S],
 	if !InBounds(x, y, pf.Width(), pf.Height()) || c.IsTransparent() {
 		return
 	}
-	row := buffer.RowU8(pf.rbuf, y)
-	off := x * pixWidth16
-	if off+pixWidth16 > len(row) {
+	row := buffer.RowU16(pf.rbuf, y)
+	off := x * pixElems16
+	if off+pixElems16 > len(row) {
 		return
 	}
-	pf.blender.BlendPix(row[off:off+pixWidth16], c.R, c.G, c.B, c.A, cover8to16(cover))
+	pf.blender.BlendPix(row[off:off+pixElems16], c.R, c.G, c.B, c.A, cover8to16(cover))
 }
 
 // CopyHline copies a horizontal line without blending.
@@ -94,11 +97,11 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) CopyHline(x, y, length int, c color.RGBA
 		length = pf.Width() - x
 	}
 
-	row := buffer.RowU8(pf.rbuf, y)
-	off := x * pixWidth16
+	row := buffer.RowU16(pf.rbuf, y)
+	off := x * pixElems16
 	for i := 0; i < length; i++ {
-		pf.blender.SetPlain(row[off:off+pixWidth16], c.R, c.G, c.B, c.A)
-		off += pixWidth16
+		pf.blender.SetPlain(row[off:off+pixElems16], c.R, c.G, c.B, c.A)
+		off += pixElems16
 	}
 }
 
@@ -118,12 +121,12 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) BlendHline(x, y, length int, c color.RGB
 		return
 	}
 
-	row := buffer.RowU8(pf.rbuf, y)
+	row := buffer.RowU16(pf.rbuf, y)
 	cover16 := cover8to16(cover)
-	off := x * pixWidth16
+	off := x * pixElems16
 	for i := 0; i < length; i++ {
-		pf.blender.BlendPix(row[off:off+pixWidth16], c.R, c.G, c.B, c.A, cover16)
-		off += pixWidth16
+		pf.blender.BlendPix(row[off:off+pixElems16], c.R, c.G, c.B, c.A, cover16)
+		off += pixElems16
 	}
 }
 
@@ -137,19 +140,19 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) BlendSolidHspan(x, y, length int, c colo
 		length = pf.Width() - x
 	}
 
-	row := buffer.RowU8(pf.rbuf, y)
-	off := x * pixWidth16
+	row := buffer.RowU16(pf.rbuf, y)
+	off := x * pixElems16
 	if covers == nil {
 		for i := 0; i < length; i++ {
-			pf.blender.BlendPix(row[off:off+pixWidth16], c.R, c.G, c.B, c.A, 0xFFFF)
-			off += pixWidth16
+			pf.blender.BlendPix(row[off:off+pixElems16], c.R, c.G, c.B, c.A, 0xFFFF)
+			off += pixElems16
 		}
 	} else {
 		for i := 0; i < length && i < len(covers); i++ {
 			if covers[i] > 0 {
-				pf.blender.BlendPix(row[off:off+pixWidth16], c.R, c.G, c.B, c.A, cover8to16(covers[i]))
+				pf.blender.BlendPix(row[off:off+pixElems16], c.R, c.G, c.B, c.A, cover8to16(covers[i]))
 			}
-			off += pixWidth16
+			off += pixElems16
 		}
 	}
 }
@@ -209,14 +212,14 @@ func (pf *PixFmtAlphaBlendRGBA16[S, B]) Fill(c color.RGBA16[S]) {
 // ApplyGammaDir applies a 16-bit gamma function to RGB channels of the entire buffer.
 func (pf *PixFmtAlphaBlendRGBA16[S, B]) ApplyGammaDir(gamma func(basics.Int16u) basics.Int16u) {
 	for y := 0; y < pf.Height(); y++ {
-		row := buffer.RowU8(pf.rbuf, y)
+		row := buffer.RowU16(pf.rbuf, y)
 		for x := 0; x < pf.Width(); x++ {
-			off := x * pixWidth16
-			if off+pixWidth16 > len(row) {
+			off := x * pixElems16
+			if off+pixElems16 > len(row) {
 				break
 			}
-			r, g, b, a := pf.blender.GetPlain(row[off : off+pixWidth16])
-			pf.blender.SetPlain(row[off:off+pixWidth16], gamma(r), gamma(g), gamma(b), a)
+			r, g, b, a := pf.blender.GetPlain(row[off : off+pixElems16])
+			pf.blender.SetPlain(row[off:off+pixElems16], gamma(r), gamma(g), gamma(b), a)
 		}
 	}
 }
@@ -249,51 +252,51 @@ type (
 // Generic constructors
 ////////////////////////////////////////////////////////////////////////////////
 
-func NewPixFmtRGBA64[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtRGBA64[S] {
+func NewPixFmtRGBA64[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtRGBA64[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16[S, order.RGBA]{})
 }
 
-func NewPixFmtBGRA64[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtBGRA64[S] {
+func NewPixFmtBGRA64[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtBGRA64[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16[S, order.BGRA]{})
 }
 
-func NewPixFmtARGB64[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtARGB64[S] {
+func NewPixFmtARGB64[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtARGB64[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16[S, order.ARGB]{})
 }
 
-func NewPixFmtABGR64[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtABGR64[S] {
+func NewPixFmtABGR64[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtABGR64[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16[S, order.ABGR]{})
 }
 
-func NewPixFmtRGBA64Pre[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtRGBA64Pre[S] {
+func NewPixFmtRGBA64Pre[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtRGBA64Pre[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Pre[S, order.RGBA]{})
 }
 
-func NewPixFmtBGRA64Pre[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtBGRA64Pre[S] {
+func NewPixFmtBGRA64Pre[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtBGRA64Pre[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Pre[S, order.BGRA]{})
 }
 
-func NewPixFmtARGB64Pre[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtARGB64Pre[S] {
+func NewPixFmtARGB64Pre[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtARGB64Pre[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Pre[S, order.ARGB]{})
 }
 
-func NewPixFmtABGR64Pre[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtABGR64Pre[S] {
+func NewPixFmtABGR64Pre[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtABGR64Pre[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Pre[S, order.ABGR]{})
 }
 
-func NewPixFmtRGBA64Plain[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtRGBA64Plain[S] {
+func NewPixFmtRGBA64Plain[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtRGBA64Plain[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Plain[S, order.RGBA]{})
 }
 
-func NewPixFmtBGRA64Plain[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtBGRA64Plain[S] {
+func NewPixFmtBGRA64Plain[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtBGRA64Plain[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Plain[S, order.BGRA]{})
 }
 
-func NewPixFmtARGB64Plain[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtARGB64Plain[S] {
+func NewPixFmtARGB64Plain[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtARGB64Plain[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Plain[S, order.ARGB]{})
 }
 
-func NewPixFmtABGR64Plain[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmtABGR64Plain[S] {
+func NewPixFmtABGR64Plain[S color.Space](rbuf *buffer.RenderingBufferU16) *PixFmtABGR64Plain[S] {
 	return NewPixFmtAlphaBlendRGBA16[S](rbuf, blender.BlenderRGBA16Plain[S, order.ABGR]{})
 }
 
@@ -301,50 +304,50 @@ func NewPixFmtABGR64Plain[S color.Space](rbuf *buffer.RenderingBufferU8) *PixFmt
 // Concrete (linear) constructors
 ////////////////////////////////////////////////////////////////////////////////
 
-func NewPixFmtRGBA64Linear(rbuf *buffer.RenderingBufferU8) *PixFmtRGBA64[color.Linear] {
+func NewPixFmtRGBA64Linear(rbuf *buffer.RenderingBufferU16) *PixFmtRGBA64[color.Linear] {
 	return NewPixFmtRGBA64[color.Linear](rbuf)
 }
 
-func NewPixFmtBGRA64Linear(rbuf *buffer.RenderingBufferU8) *PixFmtBGRA64[color.Linear] {
+func NewPixFmtBGRA64Linear(rbuf *buffer.RenderingBufferU16) *PixFmtBGRA64[color.Linear] {
 	return NewPixFmtBGRA64[color.Linear](rbuf)
 }
 
-func NewPixFmtARGB64Linear(rbuf *buffer.RenderingBufferU8) *PixFmtARGB64[color.Linear] {
+func NewPixFmtARGB64Linear(rbuf *buffer.RenderingBufferU16) *PixFmtARGB64[color.Linear] {
 	return NewPixFmtARGB64[color.Linear](rbuf)
 }
 
-func NewPixFmtABGR64Linear(rbuf *buffer.RenderingBufferU8) *PixFmtABGR64[color.Linear] {
+func NewPixFmtABGR64Linear(rbuf *buffer.RenderingBufferU16) *PixFmtABGR64[color.Linear] {
 	return NewPixFmtABGR64[color.Linear](rbuf)
 }
 
-func NewPixFmtRGBA64PreLinear(rbuf *buffer.RenderingBufferU8) *PixFmtRGBA64Pre[color.Linear] {
+func NewPixFmtRGBA64PreLinear(rbuf *buffer.RenderingBufferU16) *PixFmtRGBA64Pre[color.Linear] {
 	return NewPixFmtRGBA64Pre[color.Linear](rbuf)
 }
 
-func NewPixFmtBGRA64PreLinear(rbuf *buffer.RenderingBufferU8) *PixFmtBGRA64Pre[color.Linear] {
+func NewPixFmtBGRA64PreLinear(rbuf *buffer.RenderingBufferU16) *PixFmtBGRA64Pre[color.Linear] {
 	return NewPixFmtBGRA64Pre[color.Linear](rbuf)
 }
 
-func NewPixFmtARGB64PreLinear(rbuf *buffer.RenderingBufferU8) *PixFmtARGB64Pre[color.Linear] {
+func NewPixFmtARGB64PreLinear(rbuf *buffer.RenderingBufferU16) *PixFmtARGB64Pre[color.Linear] {
 	return NewPixFmtARGB64Pre[color.Linear](rbuf)
 }
 
-func NewPixFmtABGR64PreLinear(rbuf *buffer.RenderingBufferU8) *PixFmtABGR64Pre[color.Linear] {
+func NewPixFmtABGR64PreLinear(rbuf *buffer.RenderingBufferU16) *PixFmtABGR64Pre[color.Linear] {
 	return NewPixFmtABGR64Pre[color.Linear](rbuf)
 }
 
-func NewPixFmtRGBA64PlainLinear(rbuf *buffer.RenderingBufferU8) *PixFmtRGBA64Plain[color.Linear] {
+func NewPixFmtRGBA64PlainLinear(rbuf *buffer.RenderingBufferU16) *PixFmtRGBA64Plain[color.Linear] {
 	return NewPixFmtRGBA64Plain[color.Linear](rbuf)
 }
 
-func NewPixFmtBGRA64PlainLinear(rbuf *buffer.RenderingBufferU8) *PixFmtBGRA64Plain[color.Linear] {
+func NewPixFmtBGRA64PlainLinear(rbuf *buffer.RenderingBufferU16) *PixFmtBGRA64Plain[color.Linear] {
 	return NewPixFmtBGRA64Plain[color.Linear](rbuf)
 }
 
-func NewPixFmtARGB64PlainLinear(rbuf *buffer.RenderingBufferU8) *PixFmtARGB64Plain[color.Linear] {
+func NewPixFmtARGB64PlainLinear(rbuf *buffer.RenderingBufferU16) *PixFmtARGB64Plain[color.Linear] {
 	return NewPixFmtARGB64Plain[color.Linear](rbuf)
 }
 
-func NewPixFmtABGR64PlainLinear(rbuf *buffer.RenderingBufferU8) *PixFmtABGR64Plain[color.Linear] {
+func NewPixFmtABGR64PlainLinear(rbuf *buffer.RenderingBufferU16) *PixFmtABGR64Plain[color.Linear] {
 	return NewPixFmtABGR64Plain[color.Linear](rbuf)
 }
@@ -8,10 +8,10 @@ import (
 	"github.com/MeKo-Christian/agg_go/internal/color"
 )
 
-// Helper: create an RGBA64 rendering buffer (8 bytes per pixel).
-func createRGBA64Buffer(width, height int) *buffer.RenderingBufferU8 {
-	bufData := make([]basics.Int8u, width*height*8)
-	return buffer.NewRenderingBufferU8WithData(bufData, width, height, width*8)
+// Helper: create an RGBA64 rendering buffer (4 uint16 values, 8 bytes per pixel).
+func createRGBA64Buffer(width, height int) *buffer.RenderingBufferU16 {
+	bufData := make([]basics.Int16u, width*height*4)
+	return buffer.NewRenderingBufferU16WithData(bufData, width, height, width*8)
 }
 
 func TestPixFmtRGBA64Linear_Construction(t *testing.T) {
@@ -26,106 +26,74 @@ func TestPixFmtRGBA64Linear_Construction(t *testing.T) {
 	}
 }
 
-// Byte-order tests use the Plain blender so that SetPlain stores channels
-// as-is (no premultiplication), letting us verify the raw byte layout directly.
+// Channel-order tests use the Plain blender so that SetPlain stores channels
+// as-is (no premultiplication), letting us verify the raw layout directly.
 
-func TestPixFmtRGBA64_ByteOrderRGBA(t *testing.T) {
+func TestPixFmtRGBA64_ChannelOrderRGBA(t *testing.T) {
 	rbuf := createRGBA64Buffer(10, 10)
 	pf := NewPixFmtRGBA64PlainLinear(rbuf)
 
 	testColor := color.RGBA16[color.Linear]{R: 0x1234, G: 0x5678, B: 0x9ABC, A: 0xDEF0}
 	pf.CopyPixel(5, 5, testColor)
 
-	row := buffer.RowU8(rbuf, 5)
-	ptr := 5 * 8 // 5 pixels * 8 bytes
-
-	// RGBA, little-endian
-	if row[ptr+0] != 0x34 || row[ptr+1] != 0x12 {
-		t.Errorf("R channel incorrect: got %02x%02x, expected 3412", row[ptr+1], row[ptr+0])
-	}
-	if row[ptr+2] != 0x78 || row[ptr+3] != 0x56 {
-		t.Errorf("G channel incorrect: got %02x%02x, expected 7856", row[ptr+3], row[ptr+2])
-	}
-	if row[ptr+4] != 0xBC || row[ptr+5] != 0x9A {
-		t.Errorf("B channel incorrect: got %02x%02x, expected BC9A", row[ptr+5], row[ptr+4])
-	}
-	if row[ptr+6] != 0xF0 || row[ptr+7] != 0xDE {
-		t.Errorf("A channel incorrect: got %02x%02x, expected F0DE", row[ptr+7], row[ptr+6])
+	row := buffer.RowU16(rbuf, 5)
+	got := row[5*4 : 5*4+4]
+	want := []basics.Int16u{0x1234, 0x5678, 0x9ABC, 0xDEF0} // RGBA
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("channel %d = %04x, want %04x", i, got[i], want[i])
+		}
 	}
 }
 
-func TestPixFmtRGBA64_ByteOrderARGB(t *testing.T) {
+func TestPixFmtRGBA64_ChannelOrderARGB(t *testing.T) {
 	rbuf := createRGBA64Buffer(10, 10)
 	pf := NewPixFmtARGB64PlainLinear(rbuf)
 
 	testColor := color.RGBA16[color.Linear]{R: 0x1234, G: 0x5678, B: 0x9ABC, A: 0xDEF0}
 	pf.CopyPixel(5, 5, testColor)
 
-	row := buffer.RowU8(rbuf, 5)
-	ptr := 5 * 8
-
-	// ARGB, little-endian
-	if row[ptr+0] != 0xF0 || row[ptr+1] != 0xDE {
-		t.Errorf("A channel incorrect: got %02x%02x, expected F0DE", row[ptr+1], row[ptr+0])
-	}
-	if row[ptr+2] != 0x34 || row[ptr+3] != 0x12 {
-		t.Errorf("R channel incorrect: got %02x%02x, expected 3412", row[ptr+3], row[ptr+2])
-	}
-	if row[ptr+4] != 0x78 || row[ptr+5] != 0x56 {
-		t.Errorf("G channel incorrect: got %02x%02x, expected 7856", row[ptr+5], row[ptr+4])
-	}
-	if row[ptr+6] != 0xBC || row[ptr+7] != 0x9A {
-		t.Errorf("B channel incorrect: got %02x%02x, expected BC9A", row[ptr+7], row[ptr+6])
+	row := buffer.RowU16(rbuf, 5)
+	got := row[5*4 : 5*4+4]
+	want := []basics.Int16u{0xDEF0, 0x1234, 0x5678, 0x9ABC} // ARGB
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("channel %d = %04x, want %04x", i, got[i], want[i])
+		}
 	}
 }
 
-func TestPixFmtRGBA64_ByteOrderABGR(t *testing.T) {
+func TestPixFmtRGBA64_ChannelOrderABGR(t *testing.T) {
 	rbuf := createRGBA64Buffer(10, 10)
 	pf := NewPixFmtABGR64PlainLinear(rbuf)
 
 	testColor := color.RGBA16[color.Linear]{R: 0x1234, G: 0x5678, B: 0x9ABC, A: 0xDEF0}
 	pf.CopyPixel(5, 5, testColor)
 
-	row := buffer.RowU8(rbuf, 5)
-	ptr := 5 * 8
-
-	// ABGR, little-endian
-	if row[ptr+0] != 0xF0 || row[ptr+1] != 0xDE {
-		t.Errorf("A channel incorrect: got %02x%02x, expected F0DE", row[ptr+1], row[ptr+0])
-	}
-	if row[ptr+2] != 0xBC || row[ptr+3] != 0x9A {
-		t.Errorf("B channel incorrect: got %02x%02x, expected BC9A", row[ptr+3], row[ptr+2])
-	}
-	if row[ptr+4] != 0x78 || row[ptr+5] != 0x56 {
-		t.Errorf("G channel incorrect: got %02x%02x, expected 7856", row[ptr+5], row[ptr+4])
-	}
-	if row[ptr+6] != 0x34 || row[ptr+7] != 0x12 {
-		t.Errorf("R channel incorrect: got %02x%02x, expected 3412", row[ptr+7], row[ptr+6])
+	row := buffer.RowU16(rbuf, 5)
+	got := row[5*4 : 5*4+4]
+	want := []basics.Int16u{0xDEF0, 0x9ABC, 0x5678, 0x1234} // ABGR
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("channel %d = %04x, want %04x", i, got[i], want[i])
+		}
 	}
 }
 
-func TestPixFmtRGBA64_ByteOrderBGRA(t *testing.T) {
+func TestPixFmtRGBA64_ChannelOrderBGRA(t *testing.T) {
 	rbuf := createRGBA64Buffer(10, 10)
 	pf := NewPixFmtBGRA64PlainLinear(rbuf)
 
 	testColor := color.RGBA16[color.Linear]{R: 0x1234, G: 0x5678, B: 0x9ABC, A: 0xDEF0}
 	pf.CopyPixel(5, 5, testColor)
 
-	row := buffer.RowU8(rbuf, 5)
-	ptr := 5 * 8
-
-	// BGRA, little-endian
-	if row[ptr+0] != 0xBC || row[ptr+1] != 0x9A {
-		t.Errorf("B channel incorrect: got %02x%02x, expected BC9A", row[ptr+1], row[ptr+0])
-	}
-	if row[ptr+2] != 0x78 || row[ptr+3] != 0x56 {
-		t.Errorf("G channel incorrect: got %02x%02x, expected 7856", row[ptr+3], row[ptr+2])
-	}
-	if row[ptr+4] != 0x34 || row[ptr+5] != 0x12 {
-		t.Errorf("R channel incorrect: got %02x%02x, expected 3412", row[ptr+5], row[ptr+4])
-	}
-	if row[ptr+6] != 0xF0 || row[ptr+7] != 0xDE {
-		t.Errorf("A channel incorrect: got %02x%02x, expected F0DE", row[ptr+7], row[ptr+6])
+	row := buffer.RowU16(rbuf, 5)
+	got := row[5*4 : 5*4+4]
+	want := []basics.Int16u{0x9ABC, 0x5678, 0x1234, 0xDEF0} // BGRA
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("channel %d = %04x, want %04x", i, got[i], want[i])
+		}
 	}
 }
 
@@ -148,30 +116,30 @@ func TestPixFmtRGBA64_PixelReadBack(t *testing.T) {
 func TestPixFmtRGBA64_PixelReadBackDifferentOrders(t *testing.T) {
 	orders := []struct {
 		name string
-		pf   func(*buffer.RenderingBufferU8) interface {
+		pf   func(*buffer.RenderingBufferU16) interface {
 			CopyPixel(x, y int, c color.RGBA16[color.Linear])
 			Pixel(x, y int) color.RGBA16[color.Linear]
 		}
 	}{
-		{"RGBA", func(r *buffer.RenderingBufferU8) interface {
+		{"RGBA", func(r *buffer.RenderingBufferU16) interface {
 			CopyPixel(x, y int, c color.RGBA16[color.Linear])
 			Pixel(x, y int) color.RGBA16[color.Linear]
 		} {
 			return NewPixFmtRGBA64PlainLinear(r)
 		}},
-		{"ARGB", func(r *buffer.RenderingBufferU8) interface {
+		{"ARGB", func(r *buffer.RenderingBufferU16) interface {
 			CopyPixel(x, y int, c color.RGBA16[color.Linear])
 			Pixel(x, y int) color.RGBA16[color.Linear]
 		} {
 			return NewPixFmtARGB64PlainLinear(r)
 		}},
-		{"ABGR", func(r *buffer.RenderingBufferU8) interface {
+		{"ABGR", func(r *buffer.RenderingBufferU16) interface {
 			CopyPixel(x, y int, c color.RGBA16[color.Linear])
 			Pixel(x, y int) color.RGBA16[color.Linear]
 		} {
 			return NewPixFmtABGR64PlainLinear(r)
 		}},
-		{"BGRA", func(r *buffer.RenderingBufferU8) interface {
+		{"BGRA", func(r *buffer.RenderingBufferU16) interface {
 			CopyPixel(x, y int, c color.RGBA16[color.Linear])
 			Pixel(x, y int) color.RGBA16[color.Linear]
 		} {
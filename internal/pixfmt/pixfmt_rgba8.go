@@ -851,6 +851,11 @@ func (pf *PixFmtAlphaBlendRGBA[S, B]) BlendFrom(src rgbaBlendFromSource[S], xdst
 	if rowSrc, ok := src.(interface{ RowData(y int) []basics.Int8u }); ok {
 		srcRow := rowSrc.RowData(ysrc)
 		if srcRow != nil {
+			// Rows are RGBA unless the source tells its order.
+			sor := color.OrderRGBA
+			if ot, ok := src.(interface{ OrderType() color.ColorOrder }); ok {
+				sor = ot.OrderType()
+			}
 			bytesPerPixel := 4
 			if bytesPerPixel == 4 {
 				if dstRow := pf.RowData(ydst); dstRow != nil && cover == basics.CoverFull {
@@ -862,20 +867,27 @@ func (pf *PixFmtAlphaBlendRGBA[S, B]) BlendFrom(src rgbaBlendFromSource[S], xdst
 						end = -1
 						step = -1
 					}
+					// Move each channel from the source's order to the
+					// destination's.
+					dor := color.OrderRGBA
+					if ro, ok := any(pf.blender).(blender.RawRGBAOrder); ok {
+						dor = color.ColorOrder{R: ro.IdxR(), G: ro.IdxG(), B: ro.IdxB(), A: ro.IdxA()}
+					}
 					for i := start; i != end; i += step {
 						srcOff := (xsrc + i) * 4
 						dstOff := (xdst + i) * 4
-						copy(dstRow[dstOff:dstOff+4], srcRow[srcOff:srcOff+4])
+						d, s := dstRow[dstOff:dstOff+4], srcRow[srcOff:srcOff+4]
+						d[dor.R], d[dor.G], d[dor.B], d[dor.A] = s[sor.R], s[sor.G], s[sor.B], s[sor.A]
 					}
 					return
 				}
 				for i := 0; i < length; i++ {
 					srcOff := (xsrc + i) * 4
 					c := color.RGBA8[S]{
-						R: srcRow[srcOff+0],
-						G: srcRow[srcOff+1],
-						B: srcRow[srcOff+2],
-						A: srcRow[srcOff+3],
+						R: srcRow[srcOff+sor.R],
+						G: srcRow[srcOff+sor.G],
+						B: srcRow[srcOff+sor.B],
+						A: srcRow[srcOff+sor.A],
 					}
 					if cover == basics.CoverFull && c.A == 255 {
 						pf.CopyPixel(xdst+i, ydst, c)
@@ -22,6 +22,14 @@ type PlatformBackend interface {
 	SetWindowSize(width, height int) error
 	GetWindowSize() (width, height int)
 
+	// Window management. Settings made before Init are applied when the
+	// window is created. The icon is tightly packed, non-premultiplied RGBA.
+	SetFullscreen(fullscreen bool) error
+	IsFullscreen() bool
+	SetMinSize(width, height int) error
+	SetCursor(cursor CursorType) error
+	SetIcon(width, height int, rgba []byte) error
+
 	// Buffer management
 	UpdateWindow(buffer *buffer.RenderingBuffer[uint8]) error
 	CreateImageSurface(width, height int) (types.ImageSurface, error)
@@ -112,6 +120,11 @@ type MockBackend struct {
 	initialized   bool
 	eventCallback EventCallback
 	startTicks    uint32
+	fullscreen    bool
+	minWidth      int
+	minHeight     int
+	cursor        CursorType
+	icon          []byte
 }
 
 // NewMockBackend creates a new mock backend for testing
@@ -183,6 +196,51 @@ func (m *MockBackend) GetWindowSize() (width, height int) {
 	return m.width, m.height
 }
 
+// SetFullscreen records the fullscreen state
+func (m *MockBackend) SetFullscreen(fullscreen bool) error {
+	m.fullscreen = fullscreen
+	return nil
+}
+
+// IsFullscreen returns the recorded fullscreen state
+func (m *MockBackend) IsFullscreen() bool {
+	return m.fullscreen
+}
+
+// SetMinSize records the minimum window size
+func (m *MockBackend) SetMinSize(width, height int) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("invalid minimum size %dx%d", width, height)
+	}
+	m.minWidth, m.minHeight = width, height
+	return nil
+}
+
+// GetMinSize returns the recorded minimum window size
+func (m *MockBackend) GetMinSize() (width, height int) {
+	return m.minWidth, m.minHeight
+}
+
+// SetCursor records the cursor
+func (m *MockBackend) SetCursor(cursor CursorType) error {
+	m.cursor = cursor
+	return nil
+}
+
+// GetCursor returns the recorded cursor
+func (m *MockBackend) GetCursor() CursorType {
+	return m.cursor
+}
+
+// SetIcon records a copy of the window icon
+func (m *MockBackend) SetIcon(width, height int, rgba []byte) error {
+	if err := types.ValidateIcon(width, height, rgba); err != nil {
+		return err
+	}
+	m.icon = append(m.icon[:0], rgba[:width*height*4]...)
+	return nil
+}
+
 // UpdateWindow updates the window display (no-op for mock)
 func (m *MockBackend) UpdateWindow(buffer *buffer.RenderingBuffer[uint8]) error {
 	return nil
@@ -31,6 +31,11 @@ type EventHandler interface {
 	OnMouseMove(x, y int, flags types.InputFlags)
 	OnMouseButtonDown(x, y int, flags types.InputFlags)
 	OnMouseButtonUp(x, y int, flags types.InputFlags)
+	OnMouseWheel(x, y int, dx, dy float64, flags types.InputFlags)
+	OnDoubleClick(x, y int, flags types.InputFlags)
+	OnTouchDown(e types.TouchEvent)
+	OnTouchMove(e types.TouchEvent)
+	OnTouchUp(e types.TouchEvent)
 	OnKey(x, y int, key types.KeyCode, flags types.InputFlags)
 	OnCtrlChange()
 	OnDraw()
@@ -59,6 +64,21 @@ func (h *BaseEventHandler) OnMouseButtonDown(x, y int, flags types.InputFlags) {
 // OnMouseButtonUp is called when a mouse button is released.
 func (h *BaseEventHandler) OnMouseButtonUp(x, y int, flags types.InputFlags) {}
 
+// OnMouseWheel is called when the wheel or a touchpad scrolls.
+func (h *BaseEventHandler) OnMouseWheel(x, y int, dx, dy float64, flags types.InputFlags) {}
+
+// OnDoubleClick is called after the second press of a double-click.
+func (h *BaseEventHandler) OnDoubleClick(x, y int, flags types.InputFlags) {}
+
+// OnTouchDown is called when a finger touches the screen.
+func (h *BaseEventHandler) OnTouchDown(e types.TouchEvent) {}
+
+// OnTouchMove is called when a finger moves on the screen.
+func (h *BaseEventHandler) OnTouchMove(e types.TouchEvent) {}
+
+// OnTouchUp is called when a finger is lifted.
+func (h *BaseEventHandler) OnTouchUp(e types.TouchEvent) {}
+
 // OnKey is called when a key is pressed or released.
 func (h *BaseEventHandler) OnKey(x, y int, key types.KeyCode, flags types.InputFlags) {}
 
@@ -3,6 +3,8 @@ package platform
 import (
 	"strings"
 	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/platform/types"
 )
 
 func TestInputFlags(t *testing.T) {
@@ -213,6 +215,11 @@ func TestBaseEventHandler(t *testing.T) {
 	handler.OnMouseMove(50, 50, MouseLeft)
 	handler.OnMouseButtonDown(50, 50, MouseLeft)
 	handler.OnMouseButtonUp(50, 50, MouseLeft)
+	handler.OnMouseWheel(50, 50, 0, 1, 0)
+	handler.OnDoubleClick(50, 50, MouseLeft)
+	handler.OnTouchDown(TouchEvent{ID: 1, X: 50, Y: 50, Pressure: 1})
+	handler.OnTouchMove(TouchEvent{ID: 1, X: 60, Y: 50, Pressure: 1})
+	handler.OnTouchUp(TouchEvent{ID: 1, X: 60, Y: 50})
 	handler.OnKey(50, 50, KeyEscape, KbdCtrl)
 	handler.OnCtrlChange()
 	handler.OnDraw()
@@ -403,3 +410,38 @@ func TestInputFlagsCombinations(t *testing.T) {
 		})
 	}
 }
+
+func TestScancodeValues(t *testing.T) {
+	// Scancodes are USB HID usage IDs, shared with SDL.
+	tests := []struct {
+		code Scancode
+		want int
+	}{
+		{types.ScancodeA, 4},
+		{types.ScancodeZ, 29},
+		{types.Scancode0, 39},
+		{types.ScancodeReturn, 40},
+		{types.ScancodeSpace, 44},
+		{types.ScancodeF1, 58},
+		{types.ScancodeF12, 69},
+		{types.ScancodeUp, 82},
+		{types.ScancodeKP0, 98},
+		{types.ScancodeApplication, 101},
+		{types.ScancodeLCtrl, 224},
+	}
+	for _, tt := range tests {
+		if int(tt.code) != tt.want {
+			t.Errorf("scancode = %d, want %d", tt.code, tt.want)
+		}
+	}
+}
+
+func TestModifiersFlags(t *testing.T) {
+	m := Modifiers{Shift: true, Alt: true}
+	if got := m.Flags(); got != KbdShift {
+		t.Errorf("Flags() = %v, want KbdShift", got)
+	}
+	if got := (Modifiers{Ctrl: true, CapsLock: true}).Flags(); got != KbdCtrl {
+		t.Errorf("Flags() = %v, want KbdCtrl", got)
+	}
+}
@@ -0,0 +1,68 @@
+package platform
+
+import "fmt"
+
+// Image slots are stored in the platform pixel format, like the window. The
+// codecs read and write RGBA and convert through putPixel and getPixel, which
+// cover the 8-bit-per-channel formats.
+
+// checkImageFormat reports whether images can be loaded into and saved from
+// the platform pixel format.
+func (ps *PlatformSupport) checkImageFormat() error {
+	switch ps.format {
+	case PixelFormatGray8, PixelFormatSGray8,
+		PixelFormatRGB24, PixelFormatSRGB24, PixelFormatBGR24, PixelFormatSBGR24,
+		PixelFormatRGBA32, PixelFormatSRGBA32, PixelFormatBGRA32, PixelFormatSBGRA32,
+		PixelFormatARGB32, PixelFormatSARGB32, PixelFormatABGR32, PixelFormatSABGR32:
+		return nil
+	}
+	return fmt.Errorf("image files are not supported for pixel format %v", ps.format)
+}
+
+// hasAlpha reports whether the platform pixel format has an alpha channel.
+func (ps *PlatformSupport) hasAlpha() bool {
+	return ps.bpp == 32
+}
+
+// putPixel stores an RGBA pixel at the start of dst in the platform format.
+// Gray formats store the luminance with AGG's weights.
+func (ps *PlatformSupport) putPixel(dst []uint8, r, g, b, a uint8) {
+	switch ps.format {
+	case PixelFormatGray8, PixelFormatSGray8:
+		dst[0] = uint8((uint32(r)*77 + uint32(g)*150 + uint32(b)*29) >> 8)
+	case PixelFormatRGB24, PixelFormatSRGB24:
+		dst[0], dst[1], dst[2] = r, g, b
+	case PixelFormatBGR24, PixelFormatSBGR24:
+		dst[0], dst[1], dst[2] = b, g, r
+	case PixelFormatRGBA32, PixelFormatSRGBA32:
+		dst[0], dst[1], dst[2], dst[3] = r, g, b, a
+	case PixelFormatBGRA32, PixelFormatSBGRA32:
+		dst[0], dst[1], dst[2], dst[3] = b, g, r, a
+	case PixelFormatARGB32, PixelFormatSARGB32:
+		dst[0], dst[1], dst[2], dst[3] = a, r, g, b
+	case PixelFormatABGR32, PixelFormatSABGR32:
+		dst[0], dst[1], dst[2], dst[3] = a, b, g, r
+	}
+}
+
+// getPixel reads the pixel at the start of src in the platform format as
+// RGBA. Formats without alpha are opaque.
+func (ps *PlatformSupport) getPixel(src []uint8) (r, g, b, a uint8) {
+	switch ps.format {
+	case PixelFormatGray8, PixelFormatSGray8:
+		return src[0], src[0], src[0], 255
+	case PixelFormatRGB24, PixelFormatSRGB24:
+		return src[0], src[1], src[2], 255
+	case PixelFormatBGR24, PixelFormatSBGR24:
+		return src[2], src[1], src[0], 255
+	case PixelFormatRGBA32, PixelFormatSRGBA32:
+		return src[0], src[1], src[2], src[3]
+	case PixelFormatBGRA32, PixelFormatSBGRA32:
+		return src[2], src[1], src[0], src[3]
+	case PixelFormatARGB32, PixelFormatSARGB32:
+		return src[1], src[2], src[3], src[0]
+	case PixelFormatABGR32, PixelFormatSABGR32:
+		return src[3], src[2], src[1], src[0]
+	}
+	return 0, 0, 0, 0
+}
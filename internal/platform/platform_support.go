@@ -10,6 +10,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"image"
+	"image/color"
 	"image/png"
 	"io"
 	"os"
@@ -28,6 +29,19 @@ type (
 	InputFlags    = types.InputFlags
 	KeyCode       = types.KeyCode
 	EventCallback = types.EventCallback
+
+	Scancode         = types.Scancode
+	Modifiers        = types.Modifiers
+	KeyEvent         = types.KeyEvent
+	KeyEventHandler  = types.KeyEventHandler
+	TextInputHandler = types.TextInputHandler
+
+	MouseWheelHandler  = types.MouseWheelHandler
+	DoubleClickHandler = types.DoubleClickHandler
+	TouchEvent         = types.TouchEvent
+	TouchHandler       = types.TouchHandler
+
+	CursorType = types.CursorType
 )
 
 // Re-export constants for backward compatibility
@@ -161,6 +175,19 @@ const (
 	KeyLAlt       = types.KeyLAlt
 )
 
+// Re-export cursor types
+const (
+	CursorArrow     = types.CursorArrow
+	CursorIBeam     = types.CursorIBeam
+	CursorCrosshair = types.CursorCrosshair
+	CursorHand      = types.CursorHand
+	CursorMove      = types.CursorMove
+	CursorResizeH   = types.CursorResizeH
+	CursorResizeV   = types.CursorResizeV
+	CursorWait      = types.CursorWait
+	CursorHidden    = types.CursorHidden
+)
+
 // PlatformSupport provides the core platform support functionality for AGG applications.
 // It manages rendering buffers, handles events, and provides basic window operations.
 type PlatformSupport struct {
@@ -171,12 +198,19 @@ type PlatformSupport struct {
 	windowFlags WindowFlags
 	caption     string
 	waitMode    bool
+	fullscreen  bool
+	cursor      CursorType
+
+	// Backend receiving window management calls, if attached
+	backend PlatformBackend
 
 	// Window dimensions
 	initialWidth  int
 	initialHeight int
 	currentWidth  int
 	currentHeight int
+	minWidth      int
+	minHeight     int
 
 	// Rendering buffers
 	windowBuffer buffer.RenderingBuffer[uint8]
@@ -247,6 +281,9 @@ func NewPlatformSupport(format PixelFormat, flipY bool) *PlatformSupport {
 // Caption sets the window caption (title).
 func (ps *PlatformSupport) Caption(caption string) {
 	ps.caption = caption
+	if ps.backend != nil {
+		ps.backend.SetCaption(caption)
+	}
 }
 
 // GetCaption returns the current window caption.
@@ -254,6 +291,82 @@ func (ps *PlatformSupport) GetCaption() string {
 	return ps.caption
 }
 
+// SetBackend attaches the backend that displays the window. Window
+// management calls (Caption, SetFullscreen, SetMinSize, SetCursor, SetIcon)
+// are forwarded to it; without a backend they are only recorded.
+func (ps *PlatformSupport) SetBackend(backend PlatformBackend) {
+	ps.backend = backend
+}
+
+// Backend returns the attached backend, or nil.
+func (ps *PlatformSupport) Backend() PlatformBackend {
+	return ps.backend
+}
+
+// SetFullscreen switches the window between fullscreen and windowed mode.
+func (ps *PlatformSupport) SetFullscreen(fullscreen bool) error {
+	if ps.backend != nil {
+		if err := ps.backend.SetFullscreen(fullscreen); err != nil {
+			return err
+		}
+	}
+	ps.fullscreen = fullscreen
+	return nil
+}
+
+// Fullscreen returns whether the window is in fullscreen mode.
+func (ps *PlatformSupport) Fullscreen() bool {
+	return ps.fullscreen
+}
+
+// SetMinSize sets the smallest size the user can resize the window to.
+// Zero removes the limit.
+func (ps *PlatformSupport) SetMinSize(width, height int) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("invalid minimum size %dx%d", width, height)
+	}
+	if ps.backend != nil {
+		if err := ps.backend.SetMinSize(width, height); err != nil {
+			return err
+		}
+	}
+	ps.minWidth, ps.minHeight = width, height
+	return nil
+}
+
+// MinSize returns the minimum window size set with SetMinSize.
+func (ps *PlatformSupport) MinSize() (width, height int) {
+	return ps.minWidth, ps.minHeight
+}
+
+// SetCursor selects the mouse cursor shown over the window.
+func (ps *PlatformSupport) SetCursor(cursor CursorType) error {
+	if ps.backend != nil {
+		if err := ps.backend.SetCursor(cursor); err != nil {
+			return err
+		}
+	}
+	ps.cursor = cursor
+	return nil
+}
+
+// Cursor returns the current mouse cursor.
+func (ps *PlatformSupport) Cursor() CursorType {
+	return ps.cursor
+}
+
+// SetIcon sets the window icon from tightly packed, non-premultiplied RGBA
+// pixels.
+func (ps *PlatformSupport) SetIcon(width, height int, rgba []byte) error {
+	if err := types.ValidateIcon(width, height, rgba); err != nil {
+		return err
+	}
+	if ps.backend != nil {
+		return ps.backend.SetIcon(width, height, rgba)
+	}
+	return nil
+}
+
 // Format returns the pixel format.
 func (ps *PlatformSupport) Format() PixelFormat {
 	return ps.format
@@ -455,19 +568,8 @@ func (ps *PlatformSupport) loadBMP(filename string) ([]uint8, int, int, error) {
 				a = rowData[srcIdx+3]
 			}
 
-			// Write to target buffer (assuming RGBA format)
 			dstIdx := targetY*targetStride + x*ps.bpp/8
-			switch ps.bpp {
-			case 32:
-				buffer[dstIdx] = r
-				buffer[dstIdx+1] = g
-				buffer[dstIdx+2] = b
-				buffer[dstIdx+3] = a
-			case 24:
-				buffer[dstIdx] = r
-				buffer[dstIdx+1] = g
-				buffer[dstIdx+2] = b
-			}
+			ps.putPixel(buffer[dstIdx:], r, g, b, a)
 		}
 	}
 
@@ -546,17 +648,7 @@ func (ps *PlatformSupport) loadPPM(filename string) ([]uint8, int, int, error) {
 			b := pixelData[srcIdx+2]
 
 			dstIdx := targetY*targetStride + x*ps.bpp/8
-			switch ps.bpp {
-			case 32:
-				buffer[dstIdx] = r
-				buffer[dstIdx+1] = g
-				buffer[dstIdx+2] = b
-				buffer[dstIdx+3] = 255 // Full alpha
-			case 24:
-				buffer[dstIdx] = r
-				buffer[dstIdx+1] = g
-				buffer[dstIdx+2] = b
-			}
+			ps.putPixel(buffer[dstIdx:], r, g, b, 255)
 		}
 	}
 
@@ -593,37 +685,39 @@ func (ps *PlatformSupport) loadPNG(filename string) ([]uint8, int, int, error) {
 		}
 
 		for x := 0; x < width; x++ {
-			srcR, srcG, srcB, srcA := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
-
-			// Convert from 16-bit to 8-bit
-			r := uint8(srcR >> 8)
-			g := uint8(srcG >> 8)
-			b := uint8(srcB >> 8)
-			a := uint8(srcA >> 8)
+			// Image slots hold straight (non-premultiplied) alpha, as BMP does
+			c := color.NRGBAModel.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			r, g, b, a := c.R, c.G, c.B, c.A
 
 			dstIdx := targetY*targetStride + x*ps.bpp/8
-			switch ps.bpp {
-			case 32:
-				buffer[dstIdx] = r
-				buffer[dstIdx+1] = g
-				buffer[dstIdx+2] = b
-				buffer[dstIdx+3] = a
-			case 24:
-				buffer[dstIdx] = r
-				buffer[dstIdx+1] = g
-				buffer[dstIdx+2] = b
-			}
+			ps.putPixel(buffer[dstIdx:], r, g, b, a)
 		}
 	}
 
 	return buffer, width, height, nil
 }
 
-// LoadImage loads an image from file.
+// LoadImage loads an image file into image slot idx, like load_img in AGG's
+// platform_support, and reports whether it succeeded; errors are printed.
+// Use LoadImageFile to get the error instead.
 func (ps *PlatformSupport) LoadImage(idx int, filename string) bool {
-	if idx < 0 || idx >= maxImages {
+	if err := ps.LoadImageFile(idx, filename); err != nil {
+		fmt.Printf("Error loading image %s: %v\n", filename, err)
 		return false
 	}
+	return true
+}
+
+// LoadImageFile loads a BMP, PPM (P6) or PNG file into image slot idx,
+// converting it to the platform pixel format and honouring flipY. Without an
+// extension, the .bmp, .ppm and .png variants are tried in that order.
+func (ps *PlatformSupport) LoadImageFile(idx int, filename string) error {
+	if idx < 0 || idx >= maxImages {
+		return fmt.Errorf("image index %d out of range [0, %d)", idx, maxImages)
+	}
+	if err := ps.checkImageFormat(); err != nil {
+		return err
+	}
 
 	// Determine file format from extension
 	ext := strings.ToLower(filepath.Ext(filename))
@@ -660,18 +754,16 @@ func (ps *PlatformSupport) LoadImage(idx int, filename string) bool {
 	case ".png":
 		buffer, width, height, err = ps.loadPNG(filename)
 	default:
-		return false
+		return fmt.Errorf("unsupported image format: %s", ext)
 	}
-
 	if err != nil {
-		fmt.Printf("Error loading image %s: %v\n", filename, err)
-		return false
+		return err
 	}
 
 	// Attach buffer to image slot
 	stride := width * ps.bpp / 8
 	ps.imageBuffers[idx].Attach(buffer, width, height, stride)
-	return true
+	return nil
 }
 
 // saveBMP saves an image buffer to a BMP file
@@ -682,9 +774,14 @@ func (ps *PlatformSupport) saveBMP(filename string, buffer []uint8, width, heigh
 	}
 	defer file.Close()
 
-	// Calculate BMP parameters
-	bitsPerPixel := uint16(ps.bpp)
-	imageSize := uint32(height * stride)
+	// Calculate BMP parameters: 32-bit when the format has alpha, else 24-bit
+	bmpBytes := 3
+	if ps.hasAlpha() {
+		bmpBytes = 4
+	}
+	bitsPerPixel := uint16(bmpBytes * 8)
+	bmpStride := ((width*int(bitsPerPixel) + 31) / 32) * 4
+	imageSize := uint32(height * bmpStride)
 	fileSize := 54 + imageSize // File header (14) + Info header (40) + Image data
 
 	// Create BMP file header
@@ -719,10 +816,9 @@ func (ps *PlatformSupport) saveBMP(filename string, buffer []uint8, width, heigh
 		return fmt.Errorf("failed to write BMP info header: %v", err)
 	}
 
-	// Calculate BMP row stride (must be multiple of 4)
-	bmpStride := ((width*int(bitsPerPixel) + 31) / 32) * 4
-	rowPadding := bmpStride - (width * int(bitsPerPixel) / 8)
-	padding := make([]uint8, rowPadding)
+	// BMP rows are padded to a multiple of 4 bytes
+	padding := make([]uint8, bmpStride-width*bmpBytes)
+	row := make([]uint8, 0, bmpStride)
 
 	// Write pixel data (BMP is bottom-to-top, BGR format)
 	for y := height - 1; y >= 0; y-- {
@@ -734,28 +830,19 @@ func (ps *PlatformSupport) saveBMP(filename string, buffer []uint8, width, heigh
 		for x := 0; x < width; x++ {
 			srcIdx := srcY*stride + x*ps.bpp/8
 
-			// Convert from source format to BGR(A)
-			switch ps.bpp {
-			case 32:
-				// Source is RGBA, write as BGRA
-				r := buffer[srcIdx]
-				g := buffer[srcIdx+1]
-				b := buffer[srcIdx+2]
-				a := buffer[srcIdx+3]
-				file.Write([]byte{b, g, r, a})
-			case 24:
-				// Source is RGB, write as BGR
-				r := buffer[srcIdx]
-				g := buffer[srcIdx+1]
-				b := buffer[srcIdx+2]
-				file.Write([]byte{b, g, r})
+			// BMP stores BGR(A)
+			r, g, b, a := ps.getPixel(buffer[srcIdx:])
+			if bmpBytes == 4 {
+				row = append(row, b, g, r, a)
+			} else {
+				row = append(row, b, g, r)
 			}
 		}
-
-		// Write row padding
-		if rowPadding > 0 {
-			file.Write(padding)
+		row = append(row, padding...)
+		if _, err := file.Write(row); err != nil {
+			return fmt.Errorf("failed to write BMP pixel data: %v", err)
 		}
+		row = row[:0]
 	}
 
 	return nil
@@ -786,17 +873,7 @@ func (ps *PlatformSupport) savePPM(filename string, buffer []uint8, width, heigh
 		for x := 0; x < width; x++ {
 			srcIdx := srcY*stride + x*ps.bpp/8
 
-			// Extract RGB components
-			switch ps.bpp {
-			case 32:
-				pixelData[0] = buffer[srcIdx]   // R
-				pixelData[1] = buffer[srcIdx+1] // G
-				pixelData[2] = buffer[srcIdx+2] // B
-			case 24:
-				pixelData[0] = buffer[srcIdx]   // R
-				pixelData[1] = buffer[srcIdx+1] // G
-				pixelData[2] = buffer[srcIdx+2] // B
-			}
+			pixelData[0], pixelData[1], pixelData[2], _ = ps.getPixel(buffer[srcIdx:])
 
 			if _, err := file.Write(pixelData); err != nil {
 				return fmt.Errorf("failed to write PPM pixel data: %v", err)
@@ -817,7 +894,7 @@ func (ps *PlatformSupport) savePNG(filename string, buffer []uint8, width, heigh
 
 	// Create Go image from buffer
 	bounds := image.Rect(0, 0, width, height)
-	img := image.NewRGBA(bounds)
+	img := image.NewNRGBA(bounds)
 
 	// Copy pixel data
 	for y := 0; y < height; y++ {
@@ -830,18 +907,11 @@ func (ps *PlatformSupport) savePNG(filename string, buffer []uint8, width, heigh
 			srcIdx := srcY*stride + x*ps.bpp/8
 			dstIdx := y*img.Stride + x*4
 
-			switch ps.bpp {
-			case 32:
-				img.Pix[dstIdx] = buffer[srcIdx]     // R
-				img.Pix[dstIdx+1] = buffer[srcIdx+1] // G
-				img.Pix[dstIdx+2] = buffer[srcIdx+2] // B
-				img.Pix[dstIdx+3] = buffer[srcIdx+3] // A
-			case 24:
-				img.Pix[dstIdx] = buffer[srcIdx]     // R
-				img.Pix[dstIdx+1] = buffer[srcIdx+1] // G
-				img.Pix[dstIdx+2] = buffer[srcIdx+2] // B
-				img.Pix[dstIdx+3] = 255              // A
-			}
+			r, g, b, a := ps.getPixel(buffer[srcIdx:])
+			img.Pix[dstIdx] = r
+			img.Pix[dstIdx+1] = g
+			img.Pix[dstIdx+2] = b
+			img.Pix[dstIdx+3] = a
 		}
 	}
 
@@ -849,11 +919,27 @@ func (ps *PlatformSupport) savePNG(filename string, buffer []uint8, width, heigh
 	return png.Encode(file, img)
 }
 
-// SaveImage saves an image to file.
+// SaveImage saves image slot idx to a file, like save_img in AGG's
+// platform_support, and reports whether it succeeded; errors are printed.
+// Use SaveImageFile to get the error instead.
 func (ps *PlatformSupport) SaveImage(idx int, filename string) bool {
-	if idx < 0 || idx >= maxImages || ps.imageBuffers[idx].Buf() == nil {
+	if err := ps.SaveImageFile(idx, filename); err != nil {
+		fmt.Printf("Error saving image %s: %v\n", filename, err)
 		return false
 	}
+	return true
+}
+
+// SaveImageFile saves image slot idx as BMP, PPM or PNG, chosen by the
+// extension of filename; .bmp is appended when there is none. BMP files are
+// 32-bit when the pixel format has alpha.
+func (ps *PlatformSupport) SaveImageFile(idx int, filename string) error {
+	if idx < 0 || idx >= maxImages || ps.imageBuffers[idx].Buf() == nil {
+		return fmt.Errorf("image slot %d is empty", idx)
+	}
+	if err := ps.checkImageFormat(); err != nil {
+		return err
+	}
 
 	// Get buffer information
 	buffer := ps.imageBuffers[idx].Buf()
@@ -870,26 +956,16 @@ func (ps *PlatformSupport) SaveImage(idx int, filename string) bool {
 		ext = ".bmp"
 	}
 
-	// Save image based on format
-	var err error
 	switch ext {
 	case ".bmp":
-		err = ps.saveBMP(filename, buffer, width, height, stride)
+		return ps.saveBMP(filename, buffer, width, height, stride)
 	case ".ppm":
-		err = ps.savePPM(filename, buffer, width, height, stride)
+		return ps.savePPM(filename, buffer, width, height, stride)
 	case ".png":
-		err = ps.savePNG(filename, buffer, width, height, stride)
+		return ps.savePNG(filename, buffer, width, height, stride)
 	default:
-		fmt.Printf("Unsupported image format: %s\n", ext)
-		return false
-	}
-
-	if err != nil {
-		fmt.Printf("Error saving image %s: %v\n", filename, err)
-		return false
+		return fmt.Errorf("unsupported image format: %s", ext)
 	}
-
-	return true
 }
 
 // CopyImageToWindow copies the specified image buffer to the window buffer.
@@ -381,6 +381,73 @@ func TestLoadSaveImage(t *testing.T) {
 	}
 }
 
+func TestLoadSaveImageFormats(t *testing.T) {
+	// One translucent orange pixel and one opaque blue pixel.
+	pixels := [][4]uint8{{255, 128, 0, 128}, {0, 0, 255, 255}}
+	formats := []PixelFormat{
+		PixelFormatRGB24, PixelFormatBGR24,
+		PixelFormatRGBA32, PixelFormatBGRA32, PixelFormatARGB32, PixelFormatABGR32,
+		PixelFormatGray8,
+	}
+	dir := t.TempDir()
+
+	for _, format := range formats {
+		for _, ext := range []string{".bmp", ".ppm", ".png"} {
+			t.Run(format.String()+ext, func(t *testing.T) {
+				ps := NewPlatformSupport(format, false)
+				ps.CreateImage(0, 2, 1)
+				bpp := format.BPP() / 8
+				for i, p := range pixels {
+					ps.putPixel(ps.ImageBuffer(0).Buf()[i*bpp:], p[0], p[1], p[2], p[3])
+				}
+				filename := dir + "/" + format.String() + ext
+				if err := ps.SaveImageFile(0, filename); err != nil {
+					t.Fatalf("SaveImageFile: %v", err)
+				}
+
+				loaded := NewPlatformSupport(format, false)
+				if err := loaded.LoadImageFile(1, filename); err != nil {
+					t.Fatalf("LoadImageFile: %v", err)
+				}
+				for i := range pixels {
+					want := [4]uint8{}
+					want[0], want[1], want[2], want[3] = ps.getPixel(ps.ImageBuffer(0).Buf()[i*bpp:])
+					if ext == ".ppm" {
+						want[3] = 255 // PPM has no alpha
+					}
+					if !loaded.hasAlpha() {
+						want[3] = 255
+					}
+					var got [4]uint8
+					got[0], got[1], got[2], got[3] = loaded.getPixel(loaded.ImageBuffer(1).Buf()[i*bpp:])
+					if got != want {
+						t.Errorf("pixel %d = %v, want %v", i, got, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestLoadImageFileErrors(t *testing.T) {
+	ps := NewPlatformSupport(PixelFormatRGBA32, false)
+	if err := ps.LoadImageFile(maxImages, "x.bmp"); err == nil {
+		t.Error("Expected error for out-of-range slot")
+	}
+	if err := ps.LoadImageFile(0, t.TempDir()+"/missing.png"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+	if err := ps.SaveImageFile(3, t.TempDir()+"/empty.png"); err == nil {
+		t.Error("Expected error for empty slot")
+	}
+
+	ps16 := NewPlatformSupport(PixelFormatRGBA64, false)
+	ps16.CreateImage(0, 1, 1)
+	if err := ps16.SaveImageFile(0, t.TempDir()+"/wide.png"); err == nil {
+		t.Error("Expected error for 16-bit pixel format")
+	}
+}
+
 func TestImageExtension(t *testing.T) {
 	ps := NewPlatformSupport(PixelFormatRGB24, false)
 
@@ -440,3 +507,48 @@ func TestWindowFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestWindowManagement(t *testing.T) {
+	ps := NewPlatformSupport(PixelFormatRGBA32, false)
+
+	// Without a backend the settings are only recorded.
+	if err := ps.SetMinSize(200, 100); err != nil {
+		t.Fatalf("SetMinSize: %v", err)
+	}
+	if err := ps.SetMinSize(-1, 0); err == nil {
+		t.Error("Expected error for negative minimum size")
+	}
+
+	backend := NewMockBackend(PixelFormatRGBA32, false)
+	ps.SetBackend(backend)
+	if ps.Backend() != backend {
+		t.Fatal("Backend() did not return the attached backend")
+	}
+
+	ps.Caption("Managed")
+	if backend.GetCaption() != "Managed" {
+		t.Errorf("Expected caption forwarded, got %q", backend.GetCaption())
+	}
+	if err := ps.SetFullscreen(true); err != nil || !ps.Fullscreen() || !backend.IsFullscreen() {
+		t.Errorf("SetFullscreen(true) not applied: err=%v", err)
+	}
+	if err := ps.SetMinSize(320, 240); err != nil {
+		t.Fatalf("SetMinSize: %v", err)
+	}
+	if w, h := backend.GetMinSize(); w != 320 || h != 240 {
+		t.Errorf("Expected backend min size 320x240, got %dx%d", w, h)
+	}
+	if err := ps.SetCursor(CursorCrosshair); err != nil || backend.GetCursor() != CursorCrosshair {
+		t.Errorf("SetCursor not forwarded: err=%v cursor=%v", err, backend.GetCursor())
+	}
+	if ps.Cursor() != CursorCrosshair {
+		t.Errorf("Expected Cursor() = Crosshair, got %v", ps.Cursor())
+	}
+
+	if err := ps.SetIcon(2, 2, make([]byte, 16)); err != nil {
+		t.Errorf("SetIcon: %v", err)
+	}
+	if err := ps.SetIcon(2, 2, make([]byte, 15)); err == nil {
+		t.Error("Expected error for short icon data")
+	}
+}
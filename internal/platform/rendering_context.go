@@ -3,16 +3,35 @@ package platform
 import (
 	"math"
 
+	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/buffer"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/gamma"
+	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
+	"github.com/MeKo-Christian/agg_go/internal/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/shapes"
+	pipelinestats "github.com/MeKo-Christian/agg_go/internal/stats"
 	"github.com/MeKo-Christian/agg_go/internal/transform"
 )
 
 // RenderingContext provides enhanced rendering capabilities for platform support.
 // It integrates the rendering buffer management with coordinate transformations
 // and provides utilities for image manipulation and display.
+//
+// Shapes are rasterized by AGG's scanline pipeline rather than plotted pixel
+// by pixel, so lines and circles are anti-aliased unless SetAntiAlias(false)
+// is called. Drawing supports the 8-bit-per-channel pixel formats; it is a
+// no-op for the others.
 type RenderingContext struct {
 	platformSupport *PlatformSupport
 	resizeMatrix    *transform.TransAffine
+
+	antiAlias bool
+	ras       *rasterizerAA
+	sl        *scanline.ScanlineU8
+	path      outline
+	stroke    *conv.ConvStroke
+	ellipse   *shapes.Ellipse
 }
 
 // NewRenderingContext creates a new rendering context attached to the given platform support.
@@ -20,10 +39,36 @@ func NewRenderingContext(ps *PlatformSupport) *RenderingContext {
 	rc := &RenderingContext{
 		platformSupport: ps,
 		resizeMatrix:    transform.NewTransAffine(),
+		antiAlias:       true,
+		ras: rasterizer.NewRasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip](
+			rasterizer.RasConvInt{}, rasterizer.NewRasterizerSlNoClip(),
+		),
+		sl:      scanline.NewScanlineU8(),
+		ellipse: shapes.NewEllipse(),
 	}
+	rc.stroke = conv.NewConvStroke(&rc.path)
+	rc.stroke.SetWidth(1.0)
+	rc.stroke.SetLineCap(basics.SquareCap)
+	rc.stroke.SetLineJoin(basics.MiterJoin)
 	return rc
 }
 
+// SetAntiAlias turns anti-aliasing of the drawing methods on or off. Without
+// it, a pixel is painted when the shape covers at least half of it.
+func (rc *RenderingContext) SetAntiAlias(aa bool) {
+	rc.antiAlias = aa
+	if aa {
+		rc.ras.SetGamma(gamma.GammaNone{}.Apply)
+	} else {
+		rc.ras.SetGamma(gamma.NewGammaThreshold(0.5).Apply)
+	}
+}
+
+// AntiAlias reports whether the drawing methods anti-alias.
+func (rc *RenderingContext) AntiAlias() bool {
+	return rc.antiAlias
+}
+
 // PlatformSupport returns the underlying platform support instance.
 func (rc *RenderingContext) PlatformSupport() *PlatformSupport {
 	return rc.platformSupport
@@ -92,122 +137,44 @@ func (rc *RenderingContext) InverseTransformPoint(x, y float64) (float64, float6
 
 // ClearWindow clears the window buffer with the specified color components.
 func (rc *RenderingContext) ClearWindow(r, g, b, a uint8) {
-	buf := rc.WindowBuffer()
-	if buf.Buf() == nil {
-		return
-	}
-
-	data := buf.Buf()
-	bpp := rc.platformSupport.bpp / 8
-
-	// Fill buffer based on pixel format
-	switch rc.platformSupport.format {
-	case PixelFormatRGBA32, PixelFormatSRGBA32:
-		for i := 0; i < len(data); i += 4 {
-			if i+3 < len(data) {
-				data[i] = r
-				data[i+1] = g
-				data[i+2] = b
-				data[i+3] = a
-			}
-		}
-	case PixelFormatBGRA32, PixelFormatSBGRA32:
-		for i := 0; i < len(data); i += 4 {
-			if i+3 < len(data) {
-				data[i] = b
-				data[i+1] = g
-				data[i+2] = r
-				data[i+3] = a
-			}
-		}
-	case PixelFormatRGB24, PixelFormatSRGB24:
-		for i := 0; i < len(data); i += 3 {
-			if i+2 < len(data) {
-				data[i] = r
-				data[i+1] = g
-				data[i+2] = b
-			}
-		}
-	case PixelFormatBGR24, PixelFormatSBGR24:
-		for i := 0; i < len(data); i += 3 {
-			if i+2 < len(data) {
-				data[i] = b
-				data[i+1] = g
-				data[i+2] = r
-			}
-		}
-	case PixelFormatGray8, PixelFormatSGray8:
-		// Convert to grayscale using standard luminance formula
-		gray := uint8(0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b))
-		for i := 0; i < len(data); i++ {
-			data[i] = gray
-		}
-	default:
-		// For other formats, use a simple approach
-		for i := 0; i < len(data); i += bpp {
-			for j := 0; j < bpp && i+j < len(data); j++ {
-				switch j {
-				case 0:
-					data[i+j] = r
-				case 1:
-					data[i+j] = g
-				case 2:
-					data[i+j] = b
-				case 3:
-					data[i+j] = a
-				}
-			}
-		}
+	if p := rc.pipeline(rc.WindowBuffer()); p != nil {
+		p.clear(r, g, b, a)
 	}
 }
 
 // ClearImage clears the specified image buffer with the given color.
 func (rc *RenderingContext) ClearImage(idx int, r, g, b, a uint8) {
-	buf := rc.ImageBuffer(idx)
-	if buf == nil || buf.Buf() == nil {
-		return
+	if p := rc.pipeline(rc.ImageBuffer(idx)); p != nil {
+		p.clear(r, g, b, a)
 	}
+}
 
-	data := buf.Buf()
-	bpp := rc.platformSupport.bpp / 8
+// CopyImageToWindowAt copies image buffer idx into the window buffer with
+// its top-left corner at (x, y), clipped to the window. Unlike
+// PlatformSupport.CopyImageToWindow it leaves the rest of the window as is.
+func (rc *RenderingContext) CopyImageToWindowAt(idx, x, y int) {
+	img := rc.ImageBuffer(idx)
+	if p := rc.pipeline(rc.WindowBuffer()); p != nil && img != nil && img.Buf() != nil {
+		p.copyFrom(img, x, y)
+	}
+}
 
-	// Use the same clearing logic as ClearWindow
-	switch rc.platformSupport.format {
-	case PixelFormatRGBA32, PixelFormatSRGBA32:
-		for i := 0; i < len(data); i += 4 {
-			if i+3 < len(data) {
-				data[i] = r
-				data[i+1] = g
-				data[i+2] = b
-				data[i+3] = a
-			}
-		}
-	case PixelFormatBGRA32, PixelFormatSBGRA32:
-		for i := 0; i < len(data); i += 4 {
-			if i+3 < len(data) {
-				data[i] = b
-				data[i+1] = g
-				data[i+2] = r
-				data[i+3] = a
-			}
-		}
-	default:
-		// Simplified approach for other formats
-		for i := 0; i < len(data); i += bpp {
-			for j := 0; j < bpp && i+j < len(data); j++ {
-				switch j {
-				case 0:
-					data[i+j] = r
-				case 1:
-					data[i+j] = g
-				case 2:
-					data[i+j] = b
-				case 3:
-					data[i+j] = a
-				}
-			}
-		}
+// BlendImageToWindow alpha-blends image buffer idx over the window buffer
+// with its top-left corner at (x, y), scaling its opacity by alpha.
+func (rc *RenderingContext) BlendImageToWindow(idx, x, y int, alpha uint8) {
+	img := rc.ImageBuffer(idx)
+	if p := rc.pipeline(rc.WindowBuffer()); p != nil && img != nil && img.Buf() != nil {
+		p.blendFrom(img, x, y, basics.Int8u(alpha))
+	}
+}
+
+// pipeline returns the renderer for buf, or nil when buf has no memory
+// attached or the pixel format cannot be drawn to.
+func (rc *RenderingContext) pipeline(buf *buffer.RenderingBuffer[uint8]) pipeline {
+	if buf == nil || buf.Buf() == nil {
+		return nil
 	}
+	return newPipeline(rc.platformSupport.format, buf)
 }
 
 // GetPixel gets a pixel value from the window buffer at the specified coordinates.
@@ -340,90 +307,86 @@ func (rc *RenderingContext) BlendPixel(x, y int, r, g, b, a uint8) bool {
 	return rc.SetPixel(x, y, blendedR, blendedG, blendedB, blendedA)
 }
 
-// DrawLine draws a simple line using Bresenham's algorithm.
-// This is a basic implementation for testing purposes.
+// DrawLine draws a one pixel wide line through the centers of the pixels
+// (x0, y0) and (x1, y1), both included.
 func (rc *RenderingContext) DrawLine(x0, y0, x1, y1 int, r, g, b, a uint8) {
-	dx := abs(x1 - x0)
-	dy := abs(y1 - y0)
-
-	var sx, sy int
-	if x0 < x1 {
-		sx = 1
-	} else {
-		sx = -1
-	}
-	if y0 < y1 {
-		sy = 1
-	} else {
-		sy = -1
-	}
-
-	err := dx - dy
-	x, y := x0, y0
-
-	for {
-		rc.SetPixel(x, y, r, g, b, a)
-
-		if x == x1 && y == y1 {
-			break
-		}
-
-		e2 := 2 * err
-		if e2 > -dy {
-			err -= dy
-			x += sx
-		}
-		if e2 < dx {
-			err += dx
-			y += sy
-		}
+	if x0 == x1 && y0 == y1 {
+		rc.FillRectangle(x0, y0, 1, 1, r, g, b, a)
+		return
 	}
+	rc.path.reset(false)
+	rc.path.add(float64(x0)+0.5, float64(y0)+0.5)
+	rc.path.add(float64(x1)+0.5, float64(y1)+0.5)
+	rc.render(rc.stroke, r, g, b, a)
 }
 
-// DrawRectangle draws a simple rectangle outline.
+// DrawRectangle draws a one pixel wide rectangle outline covering the pixels
+// from (x, y) to (x+width-1, y+height-1).
 func (rc *RenderingContext) DrawRectangle(x, y, width, height int, r, g, b, a uint8) {
-	// Top edge
-	rc.DrawLine(x, y, x+width-1, y, r, g, b, a)
-	// Bottom edge
-	rc.DrawLine(x, y+height-1, x+width-1, y+height-1, r, g, b, a)
-	// Left edge
-	rc.DrawLine(x, y, x, y+height-1, r, g, b, a)
-	// Right edge
-	rc.DrawLine(x+width-1, y, x+width-1, y+height-1, r, g, b, a)
+	if width <= 2 || height <= 2 {
+		rc.FillRectangle(x, y, width, height, r, g, b, a)
+		return
+	}
+	x0, y0 := float64(x)+0.5, float64(y)+0.5
+	x1, y1 := float64(x+width)-0.5, float64(y+height)-0.5
+	rc.path.reset(true)
+	rc.path.add(x0, y0)
+	rc.path.add(x1, y0)
+	rc.path.add(x1, y1)
+	rc.path.add(x0, y1)
+	rc.render(rc.stroke, r, g, b, a)
 }
 
 // FillRectangle fills a rectangle with the specified color.
 func (rc *RenderingContext) FillRectangle(x, y, width, height int, r, g, b, a uint8) {
-	for dy := 0; dy < height; dy++ {
-		for dx := 0; dx < width; dx++ {
-			rc.SetPixel(x+dx, y+dy, r, g, b, a)
-		}
+	if width <= 0 || height <= 0 {
+		return
 	}
+	x0, y0 := float64(x), float64(y)
+	x1, y1 := float64(x+width), float64(y+height)
+	rc.path.reset(true)
+	rc.path.add(x0, y0)
+	rc.path.add(x1, y0)
+	rc.path.add(x1, y1)
+	rc.path.add(x0, y1)
+	rc.render(&rc.path, r, g, b, a)
 }
 
-// DrawCircle draws a simple circle outline using the midpoint circle algorithm.
+// DrawCircle draws a one pixel wide circle outline around the center of the
+// pixel (centerX, centerY).
 func (rc *RenderingContext) DrawCircle(centerX, centerY, radius int, r, g, b, a uint8) {
-	x := radius
-	y := 0
-	err := 0
-
-	for x >= y {
-		rc.SetPixel(centerX+x, centerY+y, r, g, b, a)
-		rc.SetPixel(centerX+y, centerY+x, r, g, b, a)
-		rc.SetPixel(centerX-y, centerY+x, r, g, b, a)
-		rc.SetPixel(centerX-x, centerY+y, r, g, b, a)
-		rc.SetPixel(centerX-x, centerY-y, r, g, b, a)
-		rc.SetPixel(centerX-y, centerY-x, r, g, b, a)
-		rc.SetPixel(centerX+y, centerY-x, r, g, b, a)
-		rc.SetPixel(centerX+x, centerY-y, r, g, b, a)
-
-		y++
-		err += 1 + 2*y
-		if 2*(err-x)+1 > 0 {
-			x--
-			err += 1 - 2*x
+	if radius <= 0 {
+		rc.FillRectangle(centerX, centerY, 1, 1, r, g, b, a)
+		return
+	}
+	rc.ellipse.Init(float64(centerX)+0.5, float64(centerY)+0.5, float64(radius), float64(radius), 0, false)
+	rc.ellipse.Rewind(0)
+	rc.path.reset(true)
+	var x, y float64
+	for cmd := rc.ellipse.Vertex(&x, &y); !basics.IsStop(cmd); cmd = rc.ellipse.Vertex(&x, &y) {
+		if basics.IsVertex(cmd) {
+			rc.path.add(x, y)
+		}
+	}
+	rc.render(rc.stroke, r, g, b, a)
+}
+
+// render rasterizes src and blends it into the window buffer.
+func (rc *RenderingContext) render(src conv.VertexSource, r, g, b, a uint8) {
+	p := rc.pipeline(rc.WindowBuffer())
+	if p == nil {
+		return
+	}
+	rc.ras.Reset()
+	src.Rewind(0)
+	for {
+		x, y, cmd := src.Vertex()
+		if basics.IsStop(cmd) {
+			break
 		}
+		rc.ras.AddVertex(x, y, uint32(cmd))
 	}
+	p.render(rc.ras, rc.sl, r, g, b, a)
 }
 
 // GetBufferInfo returns information about the current window buffer.
@@ -447,14 +410,6 @@ func (rc *RenderingContext) ValidateBufferAccess(x, y int) bool {
 	return x >= 0 && y >= 0 && x < buf.Width() && y < buf.Height()
 }
 
-// Helper function to calculate absolute value
-func abs(x int) int {
-	if x < 0 {
-		return -x
-	}
-	return x
-}
-
 // Statistics contains rendering statistics and buffer information.
 type Statistics struct {
 	// Window buffer info
@@ -477,6 +432,9 @@ type Statistics struct {
 	ResizeScaleY       float64 `json:"resize_scale_y,omitempty"`
 	ResizeTranslateX   float64 `json:"resize_translate_x,omitempty"`
 	ResizeTranslateY   float64 `json:"resize_translate_y,omitempty"`
+
+	// Rendering pipeline counters, zero unless counting is enabled
+	Pipeline pipelinestats.Snapshot `json:"pipeline"`
 }
 
 // Statistics returns rendering statistics and buffer information.
@@ -518,5 +476,7 @@ func (rc *RenderingContext) Statistics() *Statistics {
 		stats.ResizeTranslateY = rc.resizeMatrix.TY
 	}
 
+	stats.Pipeline = pipelinestats.Read()
+
 	return stats
 }
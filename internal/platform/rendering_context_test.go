@@ -118,6 +118,36 @@ func TestClearImage(t *testing.T) {
 	// but we can test that the method doesn't crash)
 }
 
+func TestImageToWindowTransfers(t *testing.T) {
+	ps := NewPlatformSupport(PixelFormatRGBA32, false)
+	ps.Init(20, 20, 0)
+	rc := NewRenderingContext(ps)
+	if !ps.CreateImage(0, 8, 8) {
+		t.Fatal("Failed to create image buffer")
+	}
+	rc.ClearWindow(255, 255, 255, 255)
+	rc.ClearImage(0, 255, 0, 0, 255)
+
+	// The copy is clipped to the window and leaves the rest alone.
+	rc.CopyImageToWindowAt(0, 16, 2)
+	if r, g, b, a, _ := rc.GetPixel(18, 4); r != 255 || g != 0 || b != 0 || a != 255 {
+		t.Errorf("copied pixel = %d,%d,%d,%d, want red", r, g, b, a)
+	}
+	if r, g, _, _, _ := rc.GetPixel(15, 4); r != 255 || g != 255 {
+		t.Errorf("pixel left of the copy = %d,%d, want white", r, g)
+	}
+
+	rc.BlendImageToWindow(0, 0, 10, 128)
+	r, g, _, _, _ := rc.GetPixel(2, 12)
+	if r != 255 || g < 126 || g > 128 {
+		t.Errorf("blended pixel = %d,%d, want half red over white", r, g)
+	}
+
+	// Out-of-range slots are ignored.
+	rc.CopyImageToWindowAt(-1, 0, 0)
+	rc.BlendImageToWindow(99, 0, 0, 255)
+}
+
 func TestGetSetPixel(t *testing.T) {
 	ps := NewPlatformSupport(PixelFormatRGBA32, false)
 	ps.Init(100, 100, 0)
@@ -333,9 +363,56 @@ func TestDrawCircle(t *testing.T) {
 	// Draw a circle
 	rc.DrawCircle(50, 50, 20, 255, 255, 0, 255) // Yellow circle
 
-	// Check that some points on the circle are yellow
-	// We can't easily test exact circle points without implementing the algorithm,
-	// but we can check that the method doesn't crash and some pixels change
+	// The rightmost and topmost points of the outline are fully covered
+	for _, pt := range [][2]int{{70, 50}, {50, 30}} {
+		r, g, b, _, _ := rc.GetPixel(pt[0], pt[1])
+		if r < 200 || g < 200 || b != 0 {
+			t.Errorf("pixel %v should be yellow, got (%d,%d,%d)", pt, r, g, b)
+		}
+	}
+
+	// Center stays black
+	if r, g, b, _, _ := rc.GetPixel(50, 50); r != 0 || g != 0 || b != 0 {
+		t.Error("Center of circle should remain black")
+	}
+}
+
+func TestAntiAlias(t *testing.T) {
+	ps := NewPlatformSupport(PixelFormatRGBA32, false)
+	ps.Init(100, 100, 0)
+	rc := NewRenderingContext(ps)
+
+	if !rc.AntiAlias() {
+		t.Fatal("Anti-aliasing should be on by default")
+	}
+
+	// partial counts pixels that are neither background nor line color.
+	partial := func() int {
+		rc.ClearWindow(0, 0, 0, 255)
+		rc.DrawLine(10, 10, 90, 37, 255, 255, 255, 255)
+		rc.DrawCircle(50, 50, 30, 255, 255, 255, 255)
+		n := 0
+		for y := 0; y < 100; y++ {
+			for x := 0; x < 100; x++ {
+				if r, _, _, _, _ := rc.GetPixel(x, y); r != 0 && r != 255 {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	if partial() == 0 {
+		t.Error("Anti-aliased drawing should produce partially covered pixels")
+	}
+
+	rc.SetAntiAlias(false)
+	if rc.AntiAlias() {
+		t.Error("AntiAlias should report false after SetAntiAlias(false)")
+	}
+	if n := partial(); n != 0 {
+		t.Errorf("Aliased drawing produced %d partially covered pixels", n)
+	}
 }
 
 func TestGetBufferInfo(t *testing.T) {
@@ -529,6 +606,16 @@ func TestDifferentPixelFormats(t *testing.T) {
 			// but should not crash
 			rc.SetPixel(25, 25, 255, 128, 64, 255)
 			_, _, _, _, _ = rc.GetPixel(25, 25)
+
+			// Drawing goes through the format's own pixel format
+			rc.ClearWindow(0, 0, 0, 255)
+			rc.FillRectangle(10, 10, 5, 5, 255, 255, 255, 255)
+			if r, g, b, _, _ := rc.GetPixel(12, 12); r != 255 || g != 255 || b != 255 {
+				t.Errorf("Filled pixel should be white, got (%d,%d,%d)", r, g, b)
+			}
+			if r, g, b, _, _ := rc.GetPixel(15, 12); r != 0 || g != 0 || b != 0 {
+				t.Errorf("Pixel right of the rectangle should stay black, got (%d,%d,%d)", r, g, b)
+			}
 		})
 	}
 }
@@ -0,0 +1,159 @@
+package platform
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/buffer"
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt"
+	"github.com/MeKo-Christian/agg_go/internal/pixfmt/blender"
+	"github.com/MeKo-Christian/agg_go/internal/rasterizer"
+	"github.com/MeKo-Christian/agg_go/internal/renderer"
+	renscan "github.com/MeKo-Christian/agg_go/internal/renderer/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/scanline"
+)
+
+// RenderingContext draws through the same stages as Context: a scanline AA
+// rasterizer feeding renderer_base over a pixel format. The pixel format is
+// chosen from the platform format, so every 8-bit-per-channel layout gets
+// the same anti-aliased output.
+
+type rasterizerAA = rasterizer.RasterizerScanlineAA[int, rasterizer.RasConvInt, *rasterizer.RasterizerSlNoClip]
+
+// pipeline renders the rasterizer contents into one rendering buffer.
+type pipeline interface {
+	render(ras *rasterizerAA, sl *scanline.ScanlineU8, r, g, b, a uint8)
+	clear(r, g, b, a uint8)
+	// copyFrom and blendFrom transfer src, a buffer in the same format, with
+	// its top-left corner at (dx, dy).
+	copyFrom(src *buffer.RenderingBufferU8, dx, dy int)
+	blendFrom(src *buffer.RenderingBufferU8, dx, dy int, cover basics.Int8u)
+}
+
+// pixfmtPipeline is a renderer_base over a concrete pixel format. newPixfmt
+// builds the format over a buffer, and toColor converts the platform's RGBA
+// arguments to the format's color type.
+type pixfmtPipeline[PF renderer.PixelFormat[C], C any] struct {
+	ren       *renderer.RendererBase[PF, C]
+	newPixfmt func(*buffer.RenderingBufferU8) PF
+	toColor   func(r, g, b, a uint8) C
+}
+
+func newPixfmtPipeline[PF renderer.PixelFormat[C], C any](rbuf *buffer.RenderingBufferU8, newPixfmt func(*buffer.RenderingBufferU8) PF, toColor func(r, g, b, a uint8) C) *pixfmtPipeline[PF, C] {
+	return &pixfmtPipeline[PF, C]{
+		ren:       renderer.NewRendererBaseWithPixfmt[PF, C](newPixfmt(rbuf)),
+		newPixfmt: newPixfmt,
+		toColor:   toColor,
+	}
+}
+
+func (p *pixfmtPipeline[PF, C]) render(ras *rasterizerAA, sl *scanline.ScanlineU8, r, g, b, a uint8) {
+	renscan.RenderScanlinesAASolid(ras, sl, p.ren, p.toColor(r, g, b, a))
+}
+
+func (p *pixfmtPipeline[PF, C]) clear(r, g, b, a uint8) {
+	p.ren.Clear(p.toColor(r, g, b, a))
+}
+
+func (p *pixfmtPipeline[PF, C]) copyFrom(src *buffer.RenderingBufferU8, dx, dy int) {
+	p.ren.CopyFromBuffer(src, nil, dx, dy)
+}
+
+func (p *pixfmtPipeline[PF, C]) blendFrom(src *buffer.RenderingBufferU8, dx, dy int, cover basics.Int8u) {
+	p.ren.BlendFrom(p.newPixfmt(src), nil, dx, dy, cover)
+}
+
+// rgbAdaptor returns a constructor for the RGB format newPixfmt builds,
+// wrapped for the RGBA8 renderer surface.
+func rgbAdaptor[S color.Space, B blender.RGBBlender[S]](newPixfmt func(*buffer.RenderingBufferU8) *pixfmt.PixFmtAlphaBlendRGB[S, B]) func(*buffer.RenderingBufferU8) *pixfmt.PixFmtRGBARendererAdaptor[S, B] {
+	return func(rbuf *buffer.RenderingBufferU8) *pixfmt.PixFmtRGBARendererAdaptor[S, B] {
+		return pixfmt.NewPixFmtRGBARendererAdaptor(newPixfmt(rbuf))
+	}
+}
+
+func rgba8[S color.Space](r, g, b, a uint8) color.RGBA8[S] {
+	return color.RGBA8[S]{R: r, G: g, B: b, A: a}
+}
+
+// gray8 uses the luminance weights of putPixel, so drawing and image loading
+// agree on gray levels.
+func gray8[S color.Space](r, g, b, a uint8) color.Gray8[S] {
+	v := uint8((uint32(r)*77 + uint32(g)*150 + uint32(b)*29) >> 8)
+	return color.Gray8[S]{V: basics.Int8u(v), A: basics.Int8u(a)}
+}
+
+// newPipeline returns the pipeline for rbuf in the given format, or nil when
+// the format has no 8-bit-per-channel pixel format.
+func newPipeline(format PixelFormat, rbuf *buffer.RenderingBufferU8) pipeline {
+	switch format {
+	case PixelFormatGray8:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtGray8, gray8[color.Linear])
+	case PixelFormatSGray8:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtSGray8, gray8[color.SRGB])
+	case PixelFormatRGB24:
+		return newPixfmtPipeline(rbuf, rgbAdaptor(pixfmt.NewPixFmtRGB24), rgba8[color.Linear])
+	case PixelFormatSRGB24:
+		return newPixfmtPipeline(rbuf, rgbAdaptor(pixfmt.NewPixFmtSRGB24), rgba8[color.SRGB])
+	case PixelFormatBGR24:
+		return newPixfmtPipeline(rbuf, rgbAdaptor(pixfmt.NewPixFmtBGR24), rgba8[color.Linear])
+	case PixelFormatSBGR24:
+		return newPixfmtPipeline(rbuf, rgbAdaptor(pixfmt.NewPixFmtSBGR24), rgba8[color.SRGB])
+	case PixelFormatRGBA32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtRGBA32[color.Linear], rgba8[color.Linear])
+	case PixelFormatSRGBA32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtRGBA32[color.SRGB], rgba8[color.SRGB])
+	case PixelFormatBGRA32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtBGRA32[color.Linear], rgba8[color.Linear])
+	case PixelFormatSBGRA32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtBGRA32[color.SRGB], rgba8[color.SRGB])
+	case PixelFormatARGB32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtARGB32[color.Linear], rgba8[color.Linear])
+	case PixelFormatSARGB32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtARGB32[color.SRGB], rgba8[color.SRGB])
+	case PixelFormatABGR32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtABGR32[color.Linear], rgba8[color.Linear])
+	case PixelFormatSABGR32:
+		return newPixfmtPipeline(rbuf, pixfmt.NewPixFmtABGR32[color.SRGB], rgba8[color.SRGB])
+	}
+	return nil
+}
+
+// outline is a polyline vertex source for the stroke and fill paths built by
+// RenderingContext.
+type outline struct {
+	points []float64
+	closed bool
+	idx    int
+}
+
+func (o *outline) reset(closed bool) {
+	o.points = o.points[:0]
+	o.closed = closed
+}
+
+func (o *outline) add(x, y float64) {
+	o.points = append(o.points, x, y)
+}
+
+// Rewind implements conv.VertexSource.
+func (o *outline) Rewind(pathID uint) {
+	o.idx = 0
+}
+
+// Vertex implements conv.VertexSource.
+func (o *outline) Vertex() (x, y float64, cmd basics.PathCommand) {
+	n := len(o.points) / 2
+	switch {
+	case o.idx < n:
+		x, y = o.points[o.idx*2], o.points[o.idx*2+1]
+		cmd = basics.PathCmdLineTo
+		if o.idx == 0 {
+			cmd = basics.PathCmdMoveTo
+		}
+	case o.idx == n && o.closed && n > 0:
+		cmd = basics.PathCmdEndPoly | basics.PathFlagClose
+	default:
+		return 0, 0, basics.PathCmdStop
+	}
+	o.idx++
+	return x, y, cmd
+}
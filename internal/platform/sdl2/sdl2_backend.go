@@ -42,6 +42,14 @@ type SDL2Backend struct {
 
 	// Image surfaces for the max_images functionality
 	imageSurfaces [16]*sdl.Surface
+
+	// Window management state, kept so settings made before Init apply
+	fullscreen            bool
+	minWidth, minHeight   int
+	cursor                types.CursorType
+	cursorHandle          *sdl.Cursor
+	icon                  []byte
+	iconWidth, iconHeight int
 }
 
 // NewSDL2BackendImpl creates a new SDL2 backend implementation
@@ -90,6 +98,10 @@ func (s *SDL2Backend) Init(width, height int, flags types.WindowFlags) error {
 		s.cleanup()
 		return fmt.Errorf("failed to create SDL2 window: %w", err)
 	}
+	if err := s.applyWindowSettings(); err != nil {
+		s.cleanup()
+		return err
+	}
 
 	// Create renderer
 	s.renderer, err = sdl.CreateRenderer(s.window, -1, sdl.RENDERER_ACCELERATED)
@@ -121,6 +133,9 @@ func (s *SDL2Backend) Init(width, height int, flags types.WindowFlags) error {
 		return fmt.Errorf("failed to create SDL2 surface: %w", err)
 	}
 
+	// Deliver TEXTINPUT events for TextInputHandler.
+	sdl.StartTextInput()
+
 	s.initialized = true
 	s.startTicks = sdl.GetTicks()
 
@@ -274,6 +289,8 @@ func (s *SDL2Backend) cleanup() {
 		s.renderer = nil
 	}
 
+	s.freeCursor()
+
 	if s.window != nil {
 		s.window.Destroy()
 		s.window = nil
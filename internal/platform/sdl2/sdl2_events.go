@@ -52,10 +52,16 @@ func (s *SDL2Backend) handleEvent(event sdl.Event) {
 		s.handleWindowEvent(e)
 	case *sdl.KeyboardEvent:
 		s.handleKeyboardEvent(e)
+	case *sdl.TextInputEvent:
+		s.handleTextInputEvent(e)
 	case *sdl.MouseButtonEvent:
 		s.handleMouseButtonEvent(e)
 	case *sdl.MouseMotionEvent:
 		s.handleMouseMotionEvent(e)
+	case *sdl.MouseWheelEvent:
+		s.handleMouseWheelEvent(e)
+	case *sdl.TouchFingerEvent:
+		s.handleTouchFingerEvent(e)
 	}
 }
 
@@ -108,16 +114,28 @@ func (s *SDL2Backend) handleWindowEvent(event *sdl.WindowEvent) {
 
 // handleKeyboardEvent handles keyboard events
 func (s *SDL2Backend) handleKeyboardEvent(event *sdl.KeyboardEvent) {
-	if event.Type != sdl.KEYDOWN {
-		return // Only handle key down events for now
-	}
-
 	// Get mouse position for key events
 	mouseX, mouseY, _ := sdl.GetMouseState()
 
 	// Convert SDL2 key to AGG key code
 	keyCode := s.sdlKeyToAGG(event.Keysym.Sym)
 
+	if h, ok := s.eventCallback.(types.KeyEventHandler); ok {
+		h.OnKeyEvent(types.KeyEvent{
+			Key:      keyCode,
+			Scancode: types.Scancode(event.Keysym.Scancode), // SDL scancodes are USB HID usages
+			Mods:     modifiersFromSDL(event.Keysym.Mod),
+			Pressed:  event.Type == sdl.KEYDOWN,
+			Repeat:   event.Repeat != 0,
+			X:        int(mouseX),
+			Y:        int(mouseY),
+		})
+	}
+
+	if event.Type != sdl.KEYDOWN {
+		return // The classic OnKey only sees presses
+	}
+
 	// Get input flags
 	flags := s.getInputFlags()
 
@@ -134,6 +152,27 @@ func (s *SDL2Backend) handleKeyboardEvent(event *sdl.KeyboardEvent) {
 	}
 }
 
+// handleTextInputEvent forwards typed text to a TextInputHandler.
+func (s *SDL2Backend) handleTextInputEvent(event *sdl.TextInputEvent) {
+	if h, ok := s.eventCallback.(types.TextInputHandler); ok {
+		if text := event.GetText(); text != "" {
+			h.OnTextInput(text)
+		}
+	}
+}
+
+// modifiersFromSDL converts an SDL2 modifier state to Modifiers.
+func modifiersFromSDL(mod uint16) types.Modifiers {
+	return types.Modifiers{
+		Shift:    mod&sdl.KMOD_SHIFT != 0,
+		Ctrl:     mod&sdl.KMOD_CTRL != 0,
+		Alt:      mod&sdl.KMOD_ALT != 0,
+		Super:    mod&sdl.KMOD_GUI != 0,
+		CapsLock: mod&sdl.KMOD_CAPS != 0,
+		NumLock:  mod&sdl.KMOD_NUM != 0,
+	}
+}
+
 // handleMouseButtonEvent handles mouse button events
 func (s *SDL2Backend) handleMouseButtonEvent(event *sdl.MouseButtonEvent) {
 	flags := s.getInputFlags()
@@ -153,6 +192,9 @@ func (s *SDL2Backend) handleMouseButtonEvent(event *sdl.MouseButtonEvent) {
 	if s.eventCallback != nil {
 		if event.Type == sdl.MOUSEBUTTONDOWN {
 			s.eventCallback.OnMouseButtonDown(int(event.X), int(event.Y), flags)
+			if h, ok := s.eventCallback.(types.DoubleClickHandler); ok && event.Clicks == 2 {
+				h.OnDoubleClick(int(event.X), int(event.Y), flags)
+			}
 		} else {
 			s.eventCallback.OnMouseButtonUp(int(event.X), int(event.Y), flags)
 		}
@@ -168,6 +210,48 @@ func (s *SDL2Backend) handleMouseMotionEvent(event *sdl.MouseMotionEvent) {
 	}
 }
 
+// handleMouseWheelEvent forwards scrolling to a MouseWheelHandler.
+func (s *SDL2Backend) handleMouseWheelEvent(event *sdl.MouseWheelEvent) {
+	h, ok := s.eventCallback.(types.MouseWheelHandler)
+	if !ok {
+		return
+	}
+	// PreciseX/Y carry touchpad fractions since SDL 2.0.18; older versions
+	// leave them zero.
+	dx, dy := float64(event.PreciseX), float64(event.PreciseY)
+	if dx == 0 && dy == 0 {
+		dx, dy = float64(event.X), float64(event.Y)
+	}
+	if event.Direction == sdl.MOUSEWHEEL_FLIPPED {
+		dx, dy = -dx, -dy
+	}
+	mouseX, mouseY, _ := sdl.GetMouseState()
+	h.OnMouseWheel(int(mouseX), int(mouseY), dx, dy, s.getInputFlags())
+}
+
+// handleTouchFingerEvent forwards touch input to a TouchHandler. SDL itself
+// synthesizes mouse events for the first finger.
+func (s *SDL2Backend) handleTouchFingerEvent(event *sdl.TouchFingerEvent) {
+	h, ok := s.eventCallback.(types.TouchHandler)
+	if !ok {
+		return
+	}
+	e := types.TouchEvent{
+		ID:       int64(event.FingerID),
+		X:        float64(event.X) * float64(s.width),
+		Y:        float64(event.Y) * float64(s.height),
+		Pressure: float64(event.Pressure),
+	}
+	switch event.Type {
+	case sdl.FINGERDOWN:
+		h.OnTouchDown(e)
+	case sdl.FINGERMOTION:
+		h.OnTouchMove(e)
+	case sdl.FINGERUP:
+		h.OnTouchUp(e)
+	}
+}
+
 // getInputFlags gets the current input state flags
 func (s *SDL2Backend) getInputFlags() types.InputFlags {
 	var flags types.InputFlags
@@ -316,6 +400,24 @@ func (s *SDL2Backend) sdlKeyToAGG(key sdl.Keycode) types.KeyCode {
 	case sdl.K_SCROLLLOCK:
 		return types.KeyScrollLock
 
+	// Modifier keys
+	case sdl.K_LSHIFT:
+		return types.KeyLShift
+	case sdl.K_RSHIFT:
+		return types.KeyRShift
+	case sdl.K_LCTRL:
+		return types.KeyLCtrl
+	case sdl.K_RCTRL:
+		return types.KeyRCtrl
+	case sdl.K_LALT:
+		return types.KeyLAlt
+	case sdl.K_RALT:
+		return types.KeyRAlt
+	case sdl.K_LGUI:
+		return types.KeyLSuper
+	case sdl.K_RGUI:
+		return types.KeyRSuper
+
 	default:
 		// Return the raw key code for unknown keys
 		return types.KeyCode(key)
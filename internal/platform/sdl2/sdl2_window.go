@@ -0,0 +1,137 @@
+package sdl2
+
+import (
+	"fmt"
+
+	"github.com/MeKo-Christian/agg_go/internal/platform/types"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// sdlCursorShapes maps cursor types to SDL system cursors.
+var sdlCursorShapes = map[types.CursorType]sdl.SystemCursor{
+	types.CursorArrow:     sdl.SYSTEM_CURSOR_ARROW,
+	types.CursorIBeam:     sdl.SYSTEM_CURSOR_IBEAM,
+	types.CursorCrosshair: sdl.SYSTEM_CURSOR_CROSSHAIR,
+	types.CursorHand:      sdl.SYSTEM_CURSOR_HAND,
+	types.CursorMove:      sdl.SYSTEM_CURSOR_SIZEALL,
+	types.CursorResizeH:   sdl.SYSTEM_CURSOR_SIZEWE,
+	types.CursorResizeV:   sdl.SYSTEM_CURSOR_SIZENS,
+	types.CursorWait:      sdl.SYSTEM_CURSOR_WAIT,
+}
+
+// SetFullscreen switches to fullscreen at the desktop resolution or back.
+func (s *SDL2Backend) SetFullscreen(fullscreen bool) error {
+	s.fullscreen = fullscreen
+	if s.window == nil {
+		return nil
+	}
+	return s.applyFullscreen()
+}
+
+// IsFullscreen returns whether the window is in fullscreen mode.
+func (s *SDL2Backend) IsFullscreen() bool {
+	return s.fullscreen
+}
+
+func (s *SDL2Backend) applyFullscreen() error {
+	var flags uint32
+	if s.fullscreen {
+		flags = sdl.WINDOW_FULLSCREEN_DESKTOP
+	}
+	if err := s.window.SetFullscreen(flags); err != nil {
+		return fmt.Errorf("failed to set fullscreen: %w", err)
+	}
+	return nil
+}
+
+// SetMinSize sets the minimum window size. Zero removes the limit.
+func (s *SDL2Backend) SetMinSize(width, height int) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("invalid minimum size %dx%d", width, height)
+	}
+	s.minWidth, s.minHeight = width, height
+	if s.window != nil {
+		s.window.SetMinimumSize(int32(width), int32(height))
+	}
+	return nil
+}
+
+// SetCursor selects the cursor shown over the window.
+func (s *SDL2Backend) SetCursor(cursor types.CursorType) error {
+	if _, ok := sdlCursorShapes[cursor]; !ok && cursor != types.CursorHidden {
+		return fmt.Errorf("unsupported cursor %v", cursor)
+	}
+	s.cursor = cursor
+	if s.window != nil {
+		s.applyCursor()
+	}
+	return nil
+}
+
+func (s *SDL2Backend) applyCursor() {
+	if s.cursor == types.CursorHidden {
+		sdl.ShowCursor(sdl.DISABLE)
+		return
+	}
+	c := sdl.CreateSystemCursor(sdlCursorShapes[s.cursor])
+	sdl.SetCursor(c)
+	sdl.ShowCursor(sdl.ENABLE)
+	s.freeCursor()
+	s.cursorHandle = c
+}
+
+func (s *SDL2Backend) freeCursor() {
+	if s.cursorHandle != nil {
+		sdl.FreeCursor(s.cursorHandle)
+		s.cursorHandle = nil
+	}
+}
+
+// SetIcon sets the window icon.
+func (s *SDL2Backend) SetIcon(width, height int, rgba []byte) error {
+	if err := types.ValidateIcon(width, height, rgba); err != nil {
+		return err
+	}
+	s.icon = append(s.icon[:0], rgba[:width*height*4]...)
+	s.iconWidth, s.iconHeight = width, height
+	if s.window == nil {
+		return nil
+	}
+	return s.applyIcon()
+}
+
+func (s *SDL2Backend) applyIcon() error {
+	if len(s.icon) == 0 {
+		return nil
+	}
+	surface, err := sdl.CreateRGBSurfaceWithFormat(0, int32(s.iconWidth), int32(s.iconHeight), 32,
+		uint32(sdl.PIXELFORMAT_RGBA32))
+	if err != nil {
+		return fmt.Errorf("failed to create icon surface: %w", err)
+	}
+	defer surface.Free()
+	pixels := surface.Pixels()
+	rowBytes := s.iconWidth * 4
+	for y := range s.iconHeight {
+		copy(pixels[y*int(surface.Pitch):], s.icon[y*rowBytes:(y+1)*rowBytes])
+	}
+	s.window.SetIcon(surface) // SDL copies the pixels
+	return nil
+}
+
+// applyWindowSettings applies settings made before the window existed.
+func (s *SDL2Backend) applyWindowSettings() error {
+	if s.minWidth > 0 || s.minHeight > 0 {
+		s.window.SetMinimumSize(int32(s.minWidth), int32(s.minHeight))
+	}
+	if s.cursor != types.CursorArrow {
+		s.applyCursor()
+	}
+	if err := s.applyIcon(); err != nil {
+		return err
+	}
+	if s.fullscreen {
+		return s.applyFullscreen()
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package types
+
+// Scancode identifies a physical key by its position, independent of the
+// keyboard layout, using USB HID usage IDs (keyboard page), as SDL does. The
+// key labelled A on a US keyboard is ScancodeA on every layout.
+type Scancode uint16
+
+// Scancodes of commonly used keys.
+const (
+	ScancodeUnknown Scancode = 0
+
+	ScancodeA Scancode = 4 + iota - 1
+	ScancodeB
+	ScancodeC
+	ScancodeD
+	ScancodeE
+	ScancodeF
+	ScancodeG
+	ScancodeH
+	ScancodeI
+	ScancodeJ
+	ScancodeK
+	ScancodeL
+	ScancodeM
+	ScancodeN
+	ScancodeO
+	ScancodeP
+	ScancodeQ
+	ScancodeR
+	ScancodeS
+	ScancodeT
+	ScancodeU
+	ScancodeV
+	ScancodeW
+	ScancodeX
+	ScancodeY
+	ScancodeZ
+	Scancode1
+	Scancode2
+	Scancode3
+	Scancode4
+	Scancode5
+	Scancode6
+	Scancode7
+	Scancode8
+	Scancode9
+	Scancode0
+	ScancodeReturn
+	ScancodeEscape
+	ScancodeBackspace
+	ScancodeTab
+	ScancodeSpace
+	ScancodeMinus
+	ScancodeEquals
+	ScancodeLeftBracket
+	ScancodeRightBracket
+	ScancodeBackslash
+	ScancodeNonUSHash
+	ScancodeSemicolon
+	ScancodeApostrophe
+	ScancodeGrave
+	ScancodeComma
+	ScancodePeriod
+	ScancodeSlash
+	ScancodeCapsLock
+	ScancodeF1
+	ScancodeF2
+	ScancodeF3
+	ScancodeF4
+	ScancodeF5
+	ScancodeF6
+	ScancodeF7
+	ScancodeF8
+	ScancodeF9
+	ScancodeF10
+	ScancodeF11
+	ScancodeF12
+	ScancodePrintScreen
+	ScancodeScrollLock
+	ScancodePause
+	ScancodeInsert
+	ScancodeHome
+	ScancodePageUp
+	ScancodeDelete
+	ScancodeEnd
+	ScancodePageDown
+	ScancodeRight
+	ScancodeLeft
+	ScancodeDown
+	ScancodeUp
+	ScancodeNumLock
+	ScancodeKPDivide
+	ScancodeKPMultiply
+	ScancodeKPMinus
+	ScancodeKPPlus
+	ScancodeKPEnter
+	ScancodeKP1
+	ScancodeKP2
+	ScancodeKP3
+	ScancodeKP4
+	ScancodeKP5
+	ScancodeKP6
+	ScancodeKP7
+	ScancodeKP8
+	ScancodeKP9
+	ScancodeKP0
+	ScancodeKPPeriod
+	ScancodeNonUSBackslash
+	ScancodeApplication
+	_ // Power
+	ScancodeKPEquals
+)
+
+// Scancodes of the modifier keys.
+const (
+	ScancodeLCtrl  Scancode = 224
+	ScancodeLShift Scancode = 225
+	ScancodeLAlt   Scancode = 226
+	ScancodeLSuper Scancode = 227
+	ScancodeRCtrl  Scancode = 228
+	ScancodeRShift Scancode = 229
+	ScancodeRAlt   Scancode = 230
+	ScancodeRSuper Scancode = 231
+)
+
+// Modifiers is the state of the modifier keys and lock keys at the time of
+// an event.
+type Modifiers struct {
+	Shift, Ctrl, Alt, Super bool
+	CapsLock, NumLock       bool
+}
+
+// Flags returns the modifiers as the InputFlags of the classic event API.
+func (m Modifiers) Flags() InputFlags {
+	var f InputFlags
+	if m.Shift {
+		f |= KbdShift
+	}
+	if m.Ctrl {
+		f |= KbdCtrl
+	}
+	return f
+}
+
+// KeyEvent is a key press or release.
+//
+// Key is the logical key under the active layout: the unshifted character
+// for printable keys (lower-case for letters), so shortcuts can compare Key
+// with KeyCode('z') on any layout, including ones where z is not on the US
+// position. Scancode names the physical key for layout-independent bindings
+// such as WASD. For typed characters use text input events instead: they
+// apply Shift, dead keys and the layout.
+type KeyEvent struct {
+	Key      KeyCode
+	Scancode Scancode
+	Mods     Modifiers
+	Pressed  bool // false for a release
+	Repeat   bool // auto-repeat of a held key
+	X, Y     int  // mouse position
+}
+
+// KeyEventHandler is an optional extension of EventCallback. Backends
+// deliver every press and release to OnKeyEvent in addition to the classic
+// OnKey, which only sees presses.
+type KeyEventHandler interface {
+	OnKeyEvent(e KeyEvent)
+}
+
+// TextInputHandler is an optional extension of EventCallback receiving the
+// text typed by the user, as UTF-8, after the layout, Shift and dead keys
+// were applied. Control characters are not delivered.
+type TextInputHandler interface {
+	OnTextInput(text string)
+}
@@ -0,0 +1,33 @@
+package types
+
+// MouseWheelHandler is an optional extension of EventCallback receiving
+// scroll wheel and touchpad scroll input. dy is positive when scrolling away
+// from the user (wheel up), dx positive when scrolling right. One notch of a
+// classic wheel is 1; touchpads and high-resolution wheels report fractions.
+type MouseWheelHandler interface {
+	OnMouseWheel(x, y int, dx, dy float64, flags InputFlags)
+}
+
+// DoubleClickHandler is an optional extension of EventCallback. OnDoubleClick
+// follows the OnMouseButtonDown of the second press; flags names the button.
+type DoubleClickHandler interface {
+	OnDoubleClick(x, y int, flags InputFlags)
+}
+
+// TouchEvent is one finger of a touch gesture. ID stays the same from the
+// finger's down to its up event, so gestures can track several fingers.
+// X and Y are in window pixels.
+type TouchEvent struct {
+	ID       int64
+	X, Y     float64
+	Pressure float64 // 0..1, 1 where the device does not report pressure
+}
+
+// TouchHandler is an optional extension of EventCallback receiving touch
+// screen input. Backends also synthesize mouse events for the primary
+// finger, so demos that only handle the mouse keep working.
+type TouchHandler interface {
+	OnTouchDown(e TouchEvent)
+	OnTouchMove(e TouchEvent)
+	OnTouchUp(e TouchEvent)
+}
@@ -0,0 +1,56 @@
+package types
+
+import "fmt"
+
+// CursorType selects one of the system mouse cursors.
+type CursorType int
+
+const (
+	CursorArrow     CursorType = iota // Default arrow
+	CursorIBeam                       // Text insertion
+	CursorCrosshair                   // Precise picking
+	CursorHand                        // Links and draggable items
+	CursorMove                        // Four-way arrow for moving
+	CursorResizeH                     // Horizontal double arrow
+	CursorResizeV                     // Vertical double arrow
+	CursorWait                        // Busy
+	CursorHidden                      // No visible cursor
+)
+
+// String returns the string representation of the cursor type.
+func (c CursorType) String() string {
+	switch c {
+	case CursorArrow:
+		return "Arrow"
+	case CursorIBeam:
+		return "IBeam"
+	case CursorCrosshair:
+		return "Crosshair"
+	case CursorHand:
+		return "Hand"
+	case CursorMove:
+		return "Move"
+	case CursorResizeH:
+		return "ResizeH"
+	case CursorResizeV:
+		return "ResizeV"
+	case CursorWait:
+		return "Wait"
+	case CursorHidden:
+		return "Hidden"
+	default:
+		return fmt.Sprintf("Unknown(%d)", int(c))
+	}
+}
+
+// ValidateIcon checks that rgba holds a width x height icon of tightly
+// packed, non-premultiplied RGBA pixels, as expected by SetIcon.
+func ValidateIcon(width, height int, rgba []byte) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid icon size %dx%d", width, height)
+	}
+	if len(rgba) < width*height*4 {
+		return fmt.Errorf("icon data has %d bytes, need %d for %dx%d RGBA", len(rgba), width*height*4, width, height)
+	}
+	return nil
+}
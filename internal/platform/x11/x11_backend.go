@@ -80,6 +80,21 @@ type X11Backend struct {
 
 	// Keymap for converting X11 keys to AGG keys
 	keymap [256]types.KeyCode
+
+	// repeatKeycode is the key whose auto-repeat release was dropped, so the
+	// following press is reported as a repeat.
+	repeatKeycode uint32
+
+	// lastClick is the previous button press, for double-click detection.
+	lastClick clickRecord
+
+	// Window management state, kept so settings made before Init apply
+	windowFlags         types.WindowFlags
+	fullscreen          bool
+	minWidth, minHeight int
+	cursor              types.CursorType
+	cursorHandle        C.Cursor
+	icon                []uint32
 }
 
 // NewX11BackendImpl creates a new X11 backend implementation
@@ -162,6 +177,9 @@ func (x *X11Backend) Init(width, height int, flags types.WindowFlags) error {
 
 	// Map window (make it visible)
 	C.XMapWindow(x.display, x.window)
+	if x.fullscreen {
+		x.applyFullscreen() // window managers only honour this once mapped
+	}
 	C.XFlush(x.display)
 
 	x.initialized = true
@@ -182,17 +200,14 @@ func (x *X11Backend) setWindowProperties(flags types.WindowFlags) {
 	defer C.free(unsafe.Pointer(cCaption))
 	C.XStoreName(x.display, x.window, cCaption)
 
-	// Handle resize flag
-	if flags&types.WindowResize == 0 {
-		// Make window non-resizable
-		var hints C.XSizeHints
-		hints.flags = C.PMinSize | C.PMaxSize
-		hints.min_width = C.int(x.width)
-		hints.min_height = C.int(x.height)
-		hints.max_width = C.int(x.width)
-		hints.max_height = C.int(x.height)
-		C.XSetWMNormalHints(x.display, x.window, &hints)
+	// Size hints: fixed size without WindowResize, else the minimum size
+	x.windowFlags = flags
+	x.applySizeHints()
+
+	if x.cursor != types.CursorArrow {
+		x.applyCursor()
 	}
+	x.applyIcon()
 }
 
 // createImageBuffer creates the XImage for displaying the rendering buffer
@@ -234,6 +249,8 @@ func (x *X11Backend) Destroy() error {
 		x.ximg = nil
 	}
 
+	x.freeCursor()
+
 	if x.gc != nil {
 		C.XFreeGC(x.display, x.gc)
 		x.gc = nil
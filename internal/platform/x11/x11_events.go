@@ -102,35 +102,80 @@ func (x *X11Backend) handleConfigureEvent(event *C.XEvent) {
 // handleKeyPressEvent handles key press events
 func (x *X11Backend) handleKeyPressEvent(event *C.XEvent) {
 	keyEvent := (*C.XKeyEvent)(unsafe.Pointer(event))
+	repeat := x.repeatKeycode != 0 && C.uint(x.repeatKeycode) == keyEvent.keycode
+	x.repeatKeycode = 0
+	x.dispatchKeyEvent(keyEvent, true, repeat)
 
-	// Get key symbol
-	keySym := C.XLookupKeysym(keyEvent, 0)
-
-	// Convert to AGG key code
-	keyCode := x.x11KeyToAGG(keySym)
-
-	// Get input flags
-	flags := x.getInputFlags(keyEvent.state)
-
+	// Legacy callback: presses only, with the unshifted key.
 	if x.eventCallback != nil {
+		keyCode := x.x11KeyToAGG(C.XLookupKeysym(keyEvent, 0))
+		flags := x.getInputFlags(keyEvent.state)
 		x.eventCallback.OnKey(int(keyEvent.x), int(keyEvent.y), keyCode, flags)
 	}
+
+	if h, ok := x.eventCallback.(types.TextInputHandler); ok {
+		// XLookupString applies Shift and the layout; the keysym it returns
+		// carries the typed character.
+		var buf [32]C.char
+		var keySym C.KeySym
+		C.XLookupString(keyEvent, &buf[0], C.int(len(buf)), &keySym, nil)
+		if r, ok := keysymToRune(uint32(keySym)); ok && keyEvent.state&C.ControlMask == 0 {
+			h.OnTextInput(string(r))
+		}
+	}
 }
 
 // handleKeyReleaseEvent handles key release events
 func (x *X11Backend) handleKeyReleaseEvent(event *C.XEvent) {
-	// For now, we treat key release the same as key press
-	// A more sophisticated implementation might track key states
-	x.handleKeyPressEvent(event)
+	keyEvent := (*C.XKeyEvent)(unsafe.Pointer(event))
+
+	// X11 reports auto-repeat as a release immediately followed by a press
+	// with the same time stamp. Drop the release and flag the press.
+	if C.XPending(x.display) > 0 {
+		var next C.XEvent
+		C.XPeekEvent(x.display, &next)
+		nextKey := (*C.XKeyEvent)(unsafe.Pointer(&next))
+		if nextKey._type == C.KeyPress && nextKey.keycode == keyEvent.keycode && nextKey.time == keyEvent.time {
+			x.repeatKeycode = uint32(keyEvent.keycode)
+			return
+		}
+	}
+	x.dispatchKeyEvent(keyEvent, false, false)
+}
+
+// dispatchKeyEvent sends a key press or release to a KeyEventHandler.
+func (x *X11Backend) dispatchKeyEvent(keyEvent *C.XKeyEvent, pressed, repeat bool) {
+	h, ok := x.eventCallback.(types.KeyEventHandler)
+	if !ok {
+		return
+	}
+	h.OnKeyEvent(types.KeyEvent{
+		Key:      x.x11KeyToAGG(C.XLookupKeysym(keyEvent, 0)),
+		Scancode: scancodeFromKeycode(uint32(keyEvent.keycode)),
+		Mods:     modifiersFromState(uint32(keyEvent.state)),
+		Pressed:  pressed,
+		Repeat:   repeat,
+		X:        int(keyEvent.x),
+		Y:        int(keyEvent.y),
+	})
 }
 
 // handleButtonPressEvent handles mouse button press events
 func (x *X11Backend) handleButtonPressEvent(event *C.XEvent) {
 	buttonEvent := (*C.XButtonEvent)(unsafe.Pointer(event))
+	bx, by := int(buttonEvent.x), int(buttonEvent.y)
 
 	// Convert X11 button to input flags
 	flags := x.getInputFlags(buttonEvent.state)
 
+	// X11 reports wheel notches as presses of buttons 4-7.
+	if dx, dy, ok := wheelDelta(uint(buttonEvent.button)); ok {
+		if h, ok := x.eventCallback.(types.MouseWheelHandler); ok {
+			h.OnMouseWheel(bx, by, dx, dy, flags)
+		}
+		return
+	}
+
 	// Add the pressed button to flags
 	switch buttonEvent.button {
 	case C.Button1: // Left button
@@ -140,13 +185,25 @@ func (x *X11Backend) handleButtonPressEvent(event *C.XEvent) {
 	}
 
 	if x.eventCallback != nil {
-		x.eventCallback.OnMouseButtonDown(int(buttonEvent.x), int(buttonEvent.y), flags)
+		x.eventCallback.OnMouseButtonDown(bx, by, flags)
 	}
+
+	click := clickRecord{button: uint(buttonEvent.button), time: uint64(buttonEvent.time), x: bx, y: by}
+	if x.lastClick.isDoubleClick(click) {
+		if h, ok := x.eventCallback.(types.DoubleClickHandler); ok {
+			h.OnDoubleClick(bx, by, flags)
+		}
+		click = clickRecord{} // a third press starts a new pair
+	}
+	x.lastClick = click
 }
 
 // handleButtonReleaseEvent handles mouse button release events
 func (x *X11Backend) handleButtonReleaseEvent(event *C.XEvent) {
 	buttonEvent := (*C.XButtonEvent)(unsafe.Pointer(event))
+	if _, _, ok := wheelDelta(uint(buttonEvent.button)); ok {
+		return // wheel buttons have no matching press for the application
+	}
 
 	// Convert X11 button to input flags (without the released button)
 	flags := x.getInputFlags(buttonEvent.state)
@@ -179,6 +236,19 @@ func (x *X11Backend) handleClientMessageEvent(event *C.XEvent) {
 	}
 }
 
+// modifiersFromState converts an X11 modifier state to Modifiers. Alt is
+// Mod1, NumLock Mod2 and Super Mod4, the usual XKB assignment.
+func modifiersFromState(state uint32) types.Modifiers {
+	return types.Modifiers{
+		Shift:    state&C.ShiftMask != 0,
+		Ctrl:     state&C.ControlMask != 0,
+		Alt:      state&C.Mod1Mask != 0,
+		Super:    state&C.Mod4Mask != 0,
+		CapsLock: state&C.LockMask != 0,
+		NumLock:  state&C.Mod2Mask != 0,
+	}
+}
+
 // getInputFlags converts X11 modifier state to AGG input flags
 func (x *X11Backend) getInputFlags(state C.uint) types.InputFlags {
 	var flags types.InputFlags
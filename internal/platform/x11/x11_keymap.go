@@ -11,10 +11,11 @@ import (
 
 // initKeymap initializes the X11 key symbol to AGG key code mapping
 func (x *X11Backend) initKeymap() {
-	// Initialize default mapping (for ASCII characters)
-	for i := 0; i < 256; i++ {
-		x.keymap[i] = types.KeyCode(i)
-	}
+	// Control keys whose AGG codes are their ASCII codes
+	x.setKeyMapping(C.XK_BackSpace, types.KeyBackspace)
+	x.setKeyMapping(C.XK_Tab, types.KeyTab)
+	x.setKeyMapping(C.XK_Return, types.KeyReturn)
+	x.setKeyMapping(C.XK_Escape, types.KeyEscape)
 
 	// Map special X11 key symbols to AGG key codes
 	// This is based on the original AGG X11 implementation
@@ -87,6 +88,18 @@ func (x *X11Backend) initKeymap() {
 	x.setKeyMapping(C.XK_Caps_Lock, types.KeyCapsLock)
 	x.setKeyMapping(C.XK_Scroll_Lock, types.KeyScrollLock)
 
+	// Modifier keys
+	x.setKeyMapping(C.XK_Shift_L, types.KeyLShift)
+	x.setKeyMapping(C.XK_Shift_R, types.KeyRShift)
+	x.setKeyMapping(C.XK_Control_L, types.KeyLCtrl)
+	x.setKeyMapping(C.XK_Control_R, types.KeyRCtrl)
+	x.setKeyMapping(C.XK_Alt_L, types.KeyLAlt)
+	x.setKeyMapping(C.XK_Alt_R, types.KeyRAlt)
+	x.setKeyMapping(C.XK_Meta_L, types.KeyLMeta)
+	x.setKeyMapping(C.XK_Meta_R, types.KeyRMeta)
+	x.setKeyMapping(C.XK_Super_L, types.KeyLSuper)
+	x.setKeyMapping(C.XK_Super_R, types.KeyRSuper)
+
 	// Other special keys
 	x.setKeyMapping(C.XK_Pause, types.KeyPause)
 	x.setKeyMapping(C.XK_Clear, types.KeyClear)
@@ -101,38 +114,44 @@ func (x *X11Backend) setKeyMapping(keySym C.ulong, keyCode types.KeyCode) {
 	}
 }
 
-// x11KeyToAGG converts an X11 KeySym to AGG KeyCode
+// x11KeyToAGG converts an X11 KeySym to AGG KeyCode. Printable keysyms
+// become their character, so letters on any layout compare equal to
+// KeyCode('a') and friends.
 func (x *X11Backend) x11KeyToAGG(keySym C.ulong) types.KeyCode {
 	// Handle ASCII range directly
 	if keySym >= 32 && keySym <= 126 {
 		return types.KeyCode(keySym)
 	}
 
-	// Handle special keys through keymap
-	index := int(keySym) & 0xFF
-	if index < len(x.keymap) {
-		mappedKey := x.keymap[index]
-		if mappedKey != 0 {
+	// Function, cursor, keypad and modifier keysyms live in 0xff00-0xffff
+	// and are looked up by their low byte.
+	if keySym&^0xFF == 0xFF00 {
+		if mappedKey := x.keymap[keySym&0xFF]; mappedKey != 0 {
 			return mappedKey
 		}
+		return types.KeyCode(0)
 	}
 
-	// Handle some special cases that don't fit in 8-bit index
-	switch keySym {
-	case C.XK_BackSpace:
-		return types.KeyBackspace
-	case C.XK_Tab:
-		return types.KeyTab
-	case C.XK_Return:
-		return types.KeyReturn
-	case C.XK_Escape:
-		return types.KeyEscape
-	default:
-		// For unknown keys, try to return the raw value if it's in ASCII range
-		if keySym < 256 {
-			return types.KeyCode(keySym)
+	// Latin-1 and Unicode keysyms map to their character.
+	if r, ok := keysymToRune(uint32(keySym)); ok {
+		return types.KeyCode(r)
+	}
+
+	// Return a safe default for unknown keys
+	return types.KeyCode(0)
+}
+
+// keysymToRune returns the printable character of a keysym. Latin-1 keysyms
+// equal their code point and Unicode keysyms are 0x01000000 plus the code
+// point. The legacy keysym blocks of other scripts are not translated.
+func keysymToRune(keySym uint32) (rune, bool) {
+	switch {
+	case keySym >= 0x20 && keySym <= 0x7E, keySym >= 0xA0 && keySym <= 0xFF:
+		return rune(keySym), true
+	case keySym&0xFF000000 == 0x01000000:
+		if r := rune(keySym & 0x00FFFFFF); r >= 0x20 && r != 0x7F && (r < 0x80 || r >= 0xA0) {
+			return r, true
 		}
-		// Return a safe default for unknown keys
-		return types.KeyCode(0)
 	}
+	return 0, false
 }
@@ -0,0 +1,51 @@
+package x11
+
+import (
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/platform/types"
+)
+
+func TestKeysymToRune(t *testing.T) {
+	tests := []struct {
+		keySym uint32
+		want   rune
+		ok     bool
+	}{
+		{'a', 'a', true},
+		{'Z', 'Z', true},
+		{0xE9, 'é', true},       // XK_eacute
+		{0x010020AC, '€', true}, // Unicode keysym
+		{0x01000410, 'А', true}, // Cyrillic capital A as Unicode keysym
+		{0xFF0D, 0, false},      // XK_Return
+		{0xFFE1, 0, false},      // XK_Shift_L
+		{0x0100001B, 0, false},  // Unicode escape is not printable
+	}
+	for _, tt := range tests {
+		got, ok := keysymToRune(tt.keySym)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("keysymToRune(%#x) = %q, %v; want %q, %v", tt.keySym, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestScancodeFromKeycode(t *testing.T) {
+	tests := []struct {
+		keycode uint32
+		want    types.Scancode
+	}{
+		{38, types.ScancodeA},     // evdev KEY_A = 30
+		{52, types.ScancodeZ},     // KEY_Z = 44
+		{10, types.Scancode1},     // KEY_1 = 2
+		{9, types.ScancodeEscape}, // KEY_ESC = 1
+		{111, types.ScancodeUp},   // KEY_UP = 103
+		{50, types.ScancodeLShift},
+		{3, types.ScancodeUnknown},
+		{1000, types.ScancodeUnknown},
+	}
+	for _, tt := range tests {
+		if got := scancodeFromKeycode(tt.keycode); got != tt.want {
+			t.Errorf("scancodeFromKeycode(%d) = %d, want %d", tt.keycode, got, tt.want)
+		}
+	}
+}
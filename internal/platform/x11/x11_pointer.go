@@ -0,0 +1,40 @@
+package x11
+
+// Double-click limits, matching the GTK defaults.
+const (
+	doubleClickTime     = 400 // milliseconds
+	doubleClickDistance = 5   // pixels
+)
+
+// clickRecord is a button press as needed for double-click detection.
+type clickRecord struct {
+	button uint
+	time   uint64 // server time in milliseconds
+	x, y   int
+}
+
+// isDoubleClick reports whether next completes a double-click started by c.
+func (c clickRecord) isDoubleClick(next clickRecord) bool {
+	if c.button == 0 || c.button != next.button || next.time < c.time {
+		return false
+	}
+	dx, dy := next.x-c.x, next.y-c.y
+	return next.time-c.time <= doubleClickTime &&
+		dx*dx+dy*dy <= doubleClickDistance*doubleClickDistance
+}
+
+// wheelDelta maps the X11 wheel buttons (4 up, 5 down, 6 left, 7 right) to a
+// scroll delta of one notch.
+func wheelDelta(button uint) (dx, dy float64, ok bool) {
+	switch button {
+	case 4:
+		return 0, 1, true
+	case 5:
+		return 0, -1, true
+	case 6:
+		return -1, 0, true
+	case 7:
+		return 1, 0, true
+	}
+	return 0, 0, false
+}
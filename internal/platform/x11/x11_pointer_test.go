@@ -0,0 +1,37 @@
+package x11
+
+import "testing"
+
+func TestIsDoubleClick(t *testing.T) {
+	first := clickRecord{button: 1, time: 1000, x: 10, y: 10}
+	tests := []struct {
+		name string
+		prev clickRecord
+		next clickRecord
+		want bool
+	}{
+		{"quick", first, clickRecord{button: 1, time: 1200, x: 12, y: 11}, true},
+		{"slow", first, clickRecord{button: 1, time: 1500, x: 10, y: 10}, false},
+		{"moved", first, clickRecord{button: 1, time: 1100, x: 20, y: 10}, false},
+		{"other button", first, clickRecord{button: 3, time: 1100, x: 10, y: 10}, false},
+		{"no previous", clickRecord{}, clickRecord{button: 1, time: 100}, false},
+		{"time wrapped", first, clickRecord{button: 1, time: 10, x: 10, y: 10}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.prev.isDoubleClick(tt.next); got != tt.want {
+			t.Errorf("%s: isDoubleClick = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestWheelDelta(t *testing.T) {
+	for button, want := range map[uint][2]float64{4: {0, 1}, 5: {0, -1}, 6: {-1, 0}, 7: {1, 0}} {
+		dx, dy, ok := wheelDelta(button)
+		if !ok || dx != want[0] || dy != want[1] {
+			t.Errorf("wheelDelta(%d) = %v, %v, %v, want %v", button, dx, dy, ok, want)
+		}
+	}
+	if _, _, ok := wheelDelta(1); ok {
+		t.Error("button 1 must not be a wheel button")
+	}
+}
@@ -0,0 +1,45 @@
+package x11
+
+import "github.com/MeKo-Christian/agg_go/internal/platform/types"
+
+// evdevToScancode maps Linux evdev key codes to USB HID scancodes.
+var evdevToScancode = [...]types.Scancode{
+	1: types.ScancodeEscape,
+	2: types.Scancode1, 3: types.Scancode2, 4: types.Scancode3, 5: types.Scancode4, 6: types.Scancode5,
+	7: types.Scancode6, 8: types.Scancode7, 9: types.Scancode8, 10: types.Scancode9, 11: types.Scancode0,
+	12: types.ScancodeMinus, 13: types.ScancodeEquals, 14: types.ScancodeBackspace, 15: types.ScancodeTab,
+	16: types.ScancodeQ, 17: types.ScancodeW, 18: types.ScancodeE, 19: types.ScancodeR, 20: types.ScancodeT,
+	21: types.ScancodeY, 22: types.ScancodeU, 23: types.ScancodeI, 24: types.ScancodeO, 25: types.ScancodeP,
+	26: types.ScancodeLeftBracket, 27: types.ScancodeRightBracket, 28: types.ScancodeReturn, 29: types.ScancodeLCtrl,
+	30: types.ScancodeA, 31: types.ScancodeS, 32: types.ScancodeD, 33: types.ScancodeF, 34: types.ScancodeG,
+	35: types.ScancodeH, 36: types.ScancodeJ, 37: types.ScancodeK, 38: types.ScancodeL,
+	39: types.ScancodeSemicolon, 40: types.ScancodeApostrophe, 41: types.ScancodeGrave, 42: types.ScancodeLShift,
+	43: types.ScancodeBackslash,
+	44: types.ScancodeZ, 45: types.ScancodeX, 46: types.ScancodeC, 47: types.ScancodeV, 48: types.ScancodeB,
+	49: types.ScancodeN, 50: types.ScancodeM,
+	51: types.ScancodeComma, 52: types.ScancodePeriod, 53: types.ScancodeSlash, 54: types.ScancodeRShift,
+	55: types.ScancodeKPMultiply, 56: types.ScancodeLAlt, 57: types.ScancodeSpace, 58: types.ScancodeCapsLock,
+	59: types.ScancodeF1, 60: types.ScancodeF2, 61: types.ScancodeF3, 62: types.ScancodeF4, 63: types.ScancodeF5,
+	64: types.ScancodeF6, 65: types.ScancodeF7, 66: types.ScancodeF8, 67: types.ScancodeF9, 68: types.ScancodeF10,
+	69: types.ScancodeNumLock, 70: types.ScancodeScrollLock,
+	71: types.ScancodeKP7, 72: types.ScancodeKP8, 73: types.ScancodeKP9, 74: types.ScancodeKPMinus,
+	75: types.ScancodeKP4, 76: types.ScancodeKP5, 77: types.ScancodeKP6, 78: types.ScancodeKPPlus,
+	79: types.ScancodeKP1, 80: types.ScancodeKP2, 81: types.ScancodeKP3, 82: types.ScancodeKP0,
+	83: types.ScancodeKPPeriod, 86: types.ScancodeNonUSBackslash, 87: types.ScancodeF11, 88: types.ScancodeF12,
+	96: types.ScancodeKPEnter, 97: types.ScancodeRCtrl, 98: types.ScancodeKPDivide, 99: types.ScancodePrintScreen,
+	100: types.ScancodeRAlt, 102: types.ScancodeHome, 103: types.ScancodeUp, 104: types.ScancodePageUp,
+	105: types.ScancodeLeft, 106: types.ScancodeRight, 107: types.ScancodeEnd, 108: types.ScancodeDown,
+	109: types.ScancodePageDown, 110: types.ScancodeInsert, 111: types.ScancodeDelete,
+	117: types.ScancodeKPEquals, 119: types.ScancodePause,
+	125: types.ScancodeLSuper, 126: types.ScancodeRSuper, 127: types.ScancodeApplication,
+}
+
+// scancodeFromKeycode converts an X11 key code to a USB HID scancode. X
+// servers using the evdev or libinput drivers, the norm on Linux, number
+// keys as the evdev code plus 8.
+func scancodeFromKeycode(keycode uint32) types.Scancode {
+	if keycode < 8 || keycode-8 >= uint32(len(evdevToScancode)) {
+		return types.ScancodeUnknown
+	}
+	return evdevToScancode[keycode-8]
+}
@@ -0,0 +1,190 @@
+package x11
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/Xutil.h>
+#include <X11/Xatom.h>
+#include <X11/cursorfont.h>
+#include <stdlib.h>
+#include <string.h>
+
+// setNetWMState asks the window manager to add or remove a _NET_WM_STATE
+// atom, as specified by EWMH for mapped windows.
+static void setNetWMState(Display* display, Window window, int add, const char* state) {
+	XEvent ev;
+	memset(&ev, 0, sizeof(ev));
+	ev.xclient.type = ClientMessage;
+	ev.xclient.window = window;
+	ev.xclient.message_type = XInternAtom(display, "_NET_WM_STATE", False);
+	ev.xclient.format = 32;
+	ev.xclient.data.l[0] = add ? 1 : 0; // _NET_WM_STATE_ADD / _REMOVE
+	ev.xclient.data.l[1] = XInternAtom(display, state, False);
+	ev.xclient.data.l[3] = 1; // normal application
+	XSendEvent(display, DefaultRootWindow(display), False,
+		SubstructureRedirectMask | SubstructureNotifyMask, &ev);
+}
+
+// createBlankCursor returns an invisible cursor.
+static Cursor createBlankCursor(Display* display, Window window) {
+	static char bits[1] = {0};
+	XColor black;
+	memset(&black, 0, sizeof(black));
+	Pixmap pixmap = XCreateBitmapFromData(display, window, bits, 1, 1);
+	Cursor cursor = XCreatePixmapCursor(display, pixmap, pixmap, &black, &black, 0, 0);
+	XFreePixmap(display, pixmap);
+	return cursor;
+}
+
+// setNetWMIcon stores an icon given as width, height and ARGB pixels in
+// unsigned longs, the format of _NET_WM_ICON.
+static void setNetWMIcon(Display* display, Window window, unsigned long* data, int n) {
+	Atom icon = XInternAtom(display, "_NET_WM_ICON", False);
+	XChangeProperty(display, window, icon, XA_CARDINAL, 32, PropModeReplace,
+		(unsigned char*)data, n);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	"github.com/MeKo-Christian/agg_go/internal/platform/types"
+)
+
+// x11CursorShapes maps cursor types to X cursor font glyphs.
+var x11CursorShapes = map[types.CursorType]C.uint{
+	types.CursorArrow:     C.XC_left_ptr,
+	types.CursorIBeam:     C.XC_xterm,
+	types.CursorCrosshair: C.XC_crosshair,
+	types.CursorHand:      C.XC_hand2,
+	types.CursorMove:      C.XC_fleur,
+	types.CursorResizeH:   C.XC_sb_h_double_arrow,
+	types.CursorResizeV:   C.XC_sb_v_double_arrow,
+	types.CursorWait:      C.XC_watch,
+}
+
+// SetFullscreen switches fullscreen mode through the window manager.
+func (x *X11Backend) SetFullscreen(fullscreen bool) error {
+	x.fullscreen = fullscreen
+	if x.initialized {
+		x.applyFullscreen()
+		C.XFlush(x.display)
+	}
+	return nil
+}
+
+// IsFullscreen returns whether fullscreen mode was requested.
+func (x *X11Backend) IsFullscreen() bool {
+	return x.fullscreen
+}
+
+func (x *X11Backend) applyFullscreen() {
+	state := C.CString("_NET_WM_STATE_FULLSCREEN")
+	defer C.free(unsafe.Pointer(state))
+	add := C.int(0)
+	if x.fullscreen {
+		add = 1
+	}
+	C.setNetWMState(x.display, x.window, add, state)
+}
+
+// SetMinSize sets the minimum window size hint. Zero removes the limit.
+func (x *X11Backend) SetMinSize(width, height int) error {
+	if width < 0 || height < 0 {
+		return fmt.Errorf("invalid minimum size %dx%d", width, height)
+	}
+	x.minWidth, x.minHeight = width, height
+	if x.window != 0 {
+		x.applySizeHints()
+		C.XFlush(x.display)
+	}
+	return nil
+}
+
+// applySizeHints sets WM_NORMAL_HINTS from the window flags and minimum
+// size. Windows without WindowResize are fixed to their size.
+func (x *X11Backend) applySizeHints() {
+	var hints C.XSizeHints
+	if x.windowFlags&types.WindowResize == 0 {
+		hints.flags = C.PMinSize | C.PMaxSize
+		hints.min_width = C.int(x.width)
+		hints.min_height = C.int(x.height)
+		hints.max_width = C.int(x.width)
+		hints.max_height = C.int(x.height)
+	} else if x.minWidth > 0 || x.minHeight > 0 {
+		hints.flags = C.PMinSize
+		hints.min_width = C.int(x.minWidth)
+		hints.min_height = C.int(x.minHeight)
+	}
+	C.XSetWMNormalHints(x.display, x.window, &hints)
+}
+
+// SetCursor selects the cursor shown over the window.
+func (x *X11Backend) SetCursor(cursor types.CursorType) error {
+	if _, ok := x11CursorShapes[cursor]; !ok && cursor != types.CursorHidden {
+		return fmt.Errorf("unsupported cursor %v", cursor)
+	}
+	x.cursor = cursor
+	if x.window != 0 {
+		x.applyCursor()
+		C.XFlush(x.display)
+	}
+	return nil
+}
+
+func (x *X11Backend) applyCursor() {
+	var c C.Cursor
+	if x.cursor == types.CursorHidden {
+		c = C.createBlankCursor(x.display, x.window)
+	} else {
+		c = C.XCreateFontCursor(x.display, x11CursorShapes[x.cursor])
+	}
+	C.XDefineCursor(x.display, x.window, c)
+	x.freeCursor()
+	x.cursorHandle = c
+}
+
+func (x *X11Backend) freeCursor() {
+	if x.cursorHandle != 0 {
+		C.XFreeCursor(x.display, x.cursorHandle)
+		x.cursorHandle = 0
+	}
+}
+
+// SetIcon sets the window icon through _NET_WM_ICON.
+func (x *X11Backend) SetIcon(width, height int, rgba []byte) error {
+	if err := types.ValidateIcon(width, height, rgba); err != nil {
+		return err
+	}
+	x.icon = netWMIcon(width, height, rgba)
+	if x.window != 0 {
+		x.applyIcon()
+		C.XFlush(x.display)
+	}
+	return nil
+}
+
+func (x *X11Backend) applyIcon() {
+	if len(x.icon) == 0 {
+		return
+	}
+	data := make([]C.ulong, len(x.icon))
+	for i, v := range x.icon {
+		data[i] = C.ulong(v)
+	}
+	C.setNetWMIcon(x.display, x.window, &data[0], C.int(len(data)))
+}
+
+// netWMIcon converts RGBA pixels to the _NET_WM_ICON layout: width, height,
+// then one ARGB value per pixel.
+func netWMIcon(width, height int, rgba []byte) []uint32 {
+	icon := make([]uint32, 2+width*height)
+	icon[0], icon[1] = uint32(width), uint32(height)
+	for i := range width * height {
+		p := rgba[i*4 : i*4+4]
+		icon[2+i] = uint32(p[3])<<24 | uint32(p[0])<<16 | uint32(p[1])<<8 | uint32(p[2])
+	}
+	return icon
+}
@@ -0,0 +1,20 @@
+package x11
+
+import "testing"
+
+func TestNetWMIcon(t *testing.T) {
+	rgba := []byte{
+		0x11, 0x22, 0x33, 0xff, // opaque pixel
+		0xaa, 0xbb, 0xcc, 0x80, // translucent pixel
+	}
+	icon := netWMIcon(2, 1, rgba)
+	want := []uint32{2, 1, 0xff112233, 0x80aabbcc}
+	if len(icon) != len(want) {
+		t.Fatalf("len = %d, want %d", len(icon), len(want))
+	}
+	for i := range want {
+		if icon[i] != want[i] {
+			t.Errorf("icon[%d] = %#x, want %#x", i, icon[i], want[i])
+		}
+	}
+}
@@ -210,3 +210,48 @@ func TestLineBresenhamInterpolatorDiagonal(t *testing.T) {
 		}
 	}
 }
+
+func TestBresenhamLine(t *testing.T) {
+	collect := func(x1, y1, x2, y2 int, last bool) [][2]int {
+		var pts [][2]int
+		BresenhamLine(x1, y1, x2, y2, last, func(x, y int) { pts = append(pts, [2]int{x, y}) })
+		return pts
+	}
+
+	want := [][2]int{{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, 2}}
+	got := collect(0, 0, 5, 2, true)
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	// The same pixels in the other direction, and in steep octants.
+	for _, l := range [][4]int{{0, 0, 5, 2}, {3, -7, -4, 9}, {0, 0, 6, 6}, {2, 5, 2, -3}} {
+		fwd := collect(l[0], l[1], l[2], l[3], true)
+		rev := collect(l[2], l[3], l[0], l[1], true)
+		set := map[[2]int]bool{}
+		for _, p := range fwd {
+			set[p] = true
+		}
+		if len(rev) != len(fwd) {
+			t.Errorf("%v: %d pixels forward, %d reversed", l, len(fwd), len(rev))
+			continue
+		}
+		for _, p := range rev {
+			if !set[p] {
+				t.Errorf("%v: reversed line has %v", l, p)
+			}
+		}
+	}
+
+	if got := collect(0, 0, 5, 2, false); len(got) != 5 || got[4] != [2]int{4, 2} {
+		t.Errorf("without last = %v", got)
+	}
+	if got := collect(3, 3, 3, 3, true); len(got) != 1 {
+		t.Errorf("single point = %v", got)
+	}
+}
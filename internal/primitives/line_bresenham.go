@@ -181,3 +181,63 @@ func (li *LineBresenhamInterpolator) X2() int {
 func (li *LineBresenhamInterpolator) Y2() int {
 	return li.LineLr(li.interpolator.Y())
 }
+
+// BresenhamLine calls plot for every pixel of the classic integer Bresenham
+// line from (x1, y1) to (x2, y2): one pixel per step along the major axis,
+// stepping the minor axis when the midpoint error turns positive. Both end
+// points are included unless last is false, in which case (x2, y2) is left
+// out so that joined segments plot their shared vertex once. The pixels do
+// not depend on the direction of the line: it is always traced from the end
+// with the smaller major coordinate, so ties round the same way both ways.
+func BresenhamLine(x1, y1, x2, y2 int, last bool, plot func(x, y int)) {
+	ex, ey := x2, y2
+	dx, dy := x2-x1, y2-y1
+	if abs(dy) <= abs(dx) {
+		if x1 > x2 {
+			x1, y1, x2, y2 = x2, y2, x1, y1
+			dx, dy = -dx, -dy
+		}
+		step := 1
+		if dy < 0 {
+			step, dy = -1, -dy
+		}
+		d := 2*dy - dx
+		for x, y := x1, y1; x <= x2; x++ {
+			if last || x != ex || y != ey {
+				plot(x, y)
+			}
+			if d > 0 {
+				y += step
+				d -= 2 * dx
+			}
+			d += 2 * dy
+		}
+		return
+	}
+	if y1 > y2 {
+		x1, y1, x2, y2 = x2, y2, x1, y1
+		dx, dy = -dx, -dy
+	}
+	step := 1
+	if dx < 0 {
+		step, dx = -1, -dx
+	}
+	d := 2*dx - dy
+	for x, y := x1, y1; y <= y2; y++ {
+		if last || x != ex || y != ey {
+			plot(x, y)
+		}
+		if d > 0 {
+			x += step
+			d -= 2 * dy
+		}
+		d += 2 * dx
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,189 @@
+// Package qoi implements the Quite OK Image format (https://qoiformat.org),
+// a lossless RGBA format that encodes and decodes much faster than PNG at a
+// similar size, which makes it handy for intermediate captures.
+package qoi
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+const (
+	opIndex = 0x00 // 00xxxxxx
+	opDiff  = 0x40 // 01xxxxxx
+	opLuma  = 0x80 // 10xxxxxx
+	opRun   = 0xc0 // 11xxxxxx
+	opRGB   = 0xfe
+	opRGBA  = 0xff
+	mask2   = 0xc0
+
+	headerSize = 14
+	// maxPixels guards against corrupt headers, as in the reference decoder.
+	maxPixels = 400_000_000
+)
+
+var endMarker = [8]byte{0, 0, 0, 0, 0, 0, 0, 1}
+
+type pixel struct{ r, g, b, a uint8 }
+
+func (p pixel) hash() int {
+	return (int(p.r)*3 + int(p.g)*5 + int(p.b)*7 + int(p.a)*11) % 64
+}
+
+// Encode writes width x height RGBA pixels, stride bytes per row, as QOI.
+// The image is stored with three channels when every alpha is 255.
+func Encode(w io.Writer, pix []byte, width, height, stride int) error {
+	if width <= 0 || height <= 0 || width*height > maxPixels {
+		return errors.New("qoi: invalid image size")
+	}
+
+	channels := byte(3)
+	for y := 0; y < height && channels == 3; y++ {
+		row := pix[y*stride : y*stride+width*4]
+		for x := 3; x < len(row); x += 4 {
+			if row[x] != 255 {
+				channels = 4
+				break
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	var header [headerSize]byte
+	copy(header[:], "qoif")
+	binary.BigEndian.PutUint32(header[4:], uint32(width))
+	binary.BigEndian.PutUint32(header[8:], uint32(height))
+	header[12] = channels
+	header[13] = 0 // sRGB with linear alpha
+	bw.Write(header[:])
+
+	var index [64]pixel
+	prev := pixel{a: 255}
+	run := 0
+	for y := 0; y < height; y++ {
+		row := pix[y*stride:]
+		for x := 0; x < width; x++ {
+			px := pixel{row[x*4], row[x*4+1], row[x*4+2], row[x*4+3]}
+			if channels == 3 {
+				px.a = 255
+			}
+			if px == prev {
+				run++
+				if run == 62 {
+					bw.WriteByte(opRun | byte(run-1))
+					run = 0
+				}
+				continue
+			}
+			if run > 0 {
+				bw.WriteByte(opRun | byte(run-1))
+				run = 0
+			}
+
+			h := px.hash()
+			switch {
+			case index[h] == px:
+				bw.WriteByte(opIndex | byte(h))
+			case px.a != prev.a:
+				index[h] = px
+				bw.Write([]byte{opRGBA, px.r, px.g, px.b, px.a})
+			default:
+				index[h] = px
+				dr := int8(px.r - prev.r)
+				dg := int8(px.g - prev.g)
+				db := int8(px.b - prev.b)
+				drg, dbg := dr-dg, db-dg
+				switch {
+				case dr >= -2 && dr <= 1 && dg >= -2 && dg <= 1 && db >= -2 && db <= 1:
+					bw.WriteByte(opDiff | byte(dr+2)<<4 | byte(dg+2)<<2 | byte(db+2))
+				case dg >= -32 && dg <= 31 && drg >= -8 && drg <= 7 && dbg >= -8 && dbg <= 7:
+					bw.Write([]byte{opLuma | byte(dg+32), byte(drg+8)<<4 | byte(dbg+8)})
+				default:
+					bw.Write([]byte{opRGB, px.r, px.g, px.b})
+				}
+			}
+			prev = px
+		}
+	}
+	if run > 0 {
+		bw.WriteByte(opRun | byte(run-1))
+	}
+	bw.Write(endMarker[:])
+	return bw.Flush()
+}
+
+// Decode reads a QOI image and returns its pixels as RGBA, width*4 bytes
+// per row. Three-channel images decode with opaque alpha.
+func Decode(r io.Reader) (pix []byte, width, height int, err error) {
+	br := bufio.NewReader(r)
+	var header [headerSize]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, 0, 0, err
+	}
+	if string(header[:4]) != "qoif" {
+		return nil, 0, 0, errors.New("qoi: not a QOI image")
+	}
+	w, h := binary.BigEndian.Uint32(header[4:]), binary.BigEndian.Uint32(header[8:])
+	if w == 0 || h == 0 || uint64(w)*uint64(h) > maxPixels || (header[12] != 3 && header[12] != 4) {
+		return nil, 0, 0, errors.New("qoi: invalid header")
+	}
+	width, height = int(w), int(h)
+
+	pix = make([]byte, width*height*4)
+	var index [64]pixel
+	px := pixel{a: 255}
+	run := 0
+	for i := 0; i < len(pix); i += 4 {
+		if run > 0 {
+			run--
+		} else {
+			b, err := br.ReadByte()
+			if err != nil {
+				return nil, 0, 0, unexpected(err)
+			}
+			switch {
+			case b == opRGB:
+				var c [3]byte
+				if _, err := io.ReadFull(br, c[:]); err != nil {
+					return nil, 0, 0, unexpected(err)
+				}
+				px.r, px.g, px.b = c[0], c[1], c[2]
+			case b == opRGBA:
+				var c [4]byte
+				if _, err := io.ReadFull(br, c[:]); err != nil {
+					return nil, 0, 0, unexpected(err)
+				}
+				px = pixel{c[0], c[1], c[2], c[3]}
+			case b&mask2 == opIndex:
+				px = index[b]
+			case b&mask2 == opDiff:
+				px.r += (b>>4)&3 - 2
+				px.g += (b>>2)&3 - 2
+				px.b += b&3 - 2
+			case b&mask2 == opLuma:
+				b2, err := br.ReadByte()
+				if err != nil {
+					return nil, 0, 0, unexpected(err)
+				}
+				dg := b&0x3f - 32
+				px.r += dg + (b2>>4)&0x0f - 8
+				px.g += dg
+				px.b += dg + b2&0x0f - 8
+			default: // opRun
+				run = int(b & 0x3f)
+			}
+			index[px.hash()] = px
+		}
+		pix[i], pix[i+1], pix[i+2], pix[i+3] = px.r, px.g, px.b, px.a
+	}
+	return pix, width, height, nil
+}
+
+func unexpected(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
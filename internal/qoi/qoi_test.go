@@ -0,0 +1,88 @@
+package qoi
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const w, h = 70, 30
+	gradient := make([]byte, w*h*4)
+	for i := 0; i < w*h; i++ {
+		// Small steps exercise the diff and luma ops, the flat rows runs
+		// longer than 62 pixels, and the repeating palette the index.
+		x, y := i%w, i/w
+		p := gradient[i*4:]
+		switch {
+		case y < 10:
+			p[0], p[1], p[2], p[3] = byte(x), byte(x*3), byte(x*2), 255
+		case y < 20:
+			p[0], p[1], p[2], p[3] = 10, 20, 30, 255
+		default:
+			p[0], p[1], p[2], p[3] = byte(x%5*40), 7, byte(x%3*90), byte(255-x%4)
+		}
+	}
+	noise := make([]byte, w*h*4)
+	rng.Read(noise)
+
+	for name, pix := range map[string][]byte{"gradient": gradient, "noise": noise} {
+		var buf bytes.Buffer
+		if err := Encode(&buf, pix, w, h, w*4); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		got, gw, gh, err := Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if gw != w || gh != h || !bytes.Equal(got, pix) {
+			t.Errorf("%s: round trip differs", name)
+		}
+	}
+}
+
+func TestEncodeChannels(t *testing.T) {
+	pix := []byte{1, 2, 3, 255, 4, 5, 6, 255, 0, 0, 0, 0, 1, 1, 1, 1}
+	var buf bytes.Buffer
+	// A stride of 8 bytes covers only the opaque first row.
+	if err := Encode(&buf, pix, 2, 1, 8); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Bytes()[12] != 3 {
+		t.Errorf("opaque image stored with %d channels", buf.Bytes()[12])
+	}
+	buf.Reset()
+	if err := Encode(&buf, pix, 2, 2, 8); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Bytes()[12] != 4 {
+		t.Errorf("translucent image stored with %d channels", buf.Bytes()[12])
+	}
+	if !bytes.HasSuffix(buf.Bytes(), endMarker[:]) {
+		t.Error("missing end marker")
+	}
+
+	flat := make([]byte, 100*100*4)
+	buf.Reset()
+	if err := Encode(&buf, flat, 100, 100, 400); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() > headerSize+len(endMarker)+200 {
+		t.Errorf("flat image takes %d bytes", buf.Len())
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, _, _, err := Decode(bytes.NewReader([]byte("GIF89a........"))); err == nil {
+		t.Error("non-QOI data accepted")
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, make([]byte, 16), 2, 2, 8); err != nil {
+		t.Fatal(err)
+	}
+	truncated := buf.Bytes()[:headerSize]
+	if _, _, _, err := Decode(bytes.NewReader(truncated)); err == nil {
+		t.Error("truncated data accepted")
+	}
+}
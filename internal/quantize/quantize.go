@@ -0,0 +1,230 @@
+// Package quantize reduces RGBA images to a small palette: median cut builds
+// the palette, and Map assigns every pixel its nearest palette entry,
+// optionally with Floyd–Steinberg error diffusion.
+//
+// Pixels are straight (non-premultiplied) RGBA. Pixels with alpha below 128
+// count as transparent: MedianCut ignores them and Map gives them the first
+// fully transparent palette entry, if there is one.
+package quantize
+
+import (
+	"image/color"
+	"slices"
+)
+
+// opaque reports whether an alpha value counts as visible.
+func opaque(a uint8) bool { return a >= 128 }
+
+// bin is one cell of the 5-bit-per-channel color histogram.
+type bin struct {
+	key        uint16 // r<<10 | g<<5 | b, 5 bits each
+	count      int
+	r, g, b    int // sums of the full 8-bit channels
+	rr, gg, bb uint8
+}
+
+// box is a run of histogram bins that median cut splits further.
+type box struct {
+	bins  []bin
+	count int
+	axis  int // channel with the widest range
+	span  int // width of that range
+}
+
+func newBox(bins []bin) box {
+	b := box{bins: bins}
+	lo, hi := [3]uint8{31, 31, 31}, [3]uint8{}
+	for _, e := range bins {
+		b.count += e.count
+		for c, v := range [3]uint8{e.rr, e.gg, e.bb} {
+			lo[c], hi[c] = min(lo[c], v), max(hi[c], v)
+		}
+	}
+	for c := range 3 {
+		if s := int(hi[c]) - int(lo[c]); s > b.span {
+			b.span, b.axis = s, c
+		}
+	}
+	return b
+}
+
+func (e bin) channel(c int) uint8 {
+	switch c {
+	case 0:
+		return e.rr
+	case 1:
+		return e.gg
+	}
+	return e.bb
+}
+
+// MedianCut returns a palette of at most n colors for the opaque pixels of
+// the image. Each step splits the box with the most pixels times color range
+// at the pixel-weighted median of its widest channel. The palette is shorter
+// than n when the image has fewer distinct colors at 5-bit precision.
+func MedianCut(pix []uint8, width, height, stride, n int) []color.NRGBA {
+	if n <= 0 {
+		return nil
+	}
+	hist := make(map[uint16]*bin)
+	for y := range height {
+		row := pix[y*stride : y*stride+width*4]
+		for x := 0; x < len(row); x += 4 {
+			r, g, b, a := row[x], row[x+1], row[x+2], row[x+3]
+			if !opaque(a) {
+				continue
+			}
+			key := uint16(r>>3)<<10 | uint16(g>>3)<<5 | uint16(b>>3)
+			e := hist[key]
+			if e == nil {
+				e = &bin{key: key, rr: r >> 3, gg: g >> 3, bb: b >> 3}
+				hist[key] = e
+			}
+			e.count++
+			e.r += int(r)
+			e.g += int(g)
+			e.b += int(b)
+		}
+	}
+	if len(hist) == 0 {
+		return nil
+	}
+	bins := make([]bin, 0, len(hist))
+	for _, e := range hist {
+		bins = append(bins, *e)
+	}
+	// Map iteration order is random; sort for a deterministic palette.
+	slices.SortFunc(bins, func(a, b bin) int { return int(a.key) - int(b.key) })
+
+	boxes := []box{newBox(bins)}
+	for len(boxes) < n {
+		best, score := -1, 0
+		for i, b := range boxes {
+			if len(b.bins) > 1 && b.span > 0 && b.count*b.span > score {
+				best, score = i, b.count*b.span
+			}
+		}
+		if best < 0 {
+			break
+		}
+		b := boxes[best]
+		axis := b.axis
+		slices.SortStableFunc(b.bins, func(p, q bin) int { return int(p.channel(axis)) - int(q.channel(axis)) })
+		// Split at the weighted median, keeping both halves non-empty.
+		cut, seen := 1, 0
+		for i, e := range b.bins[:len(b.bins)-1] {
+			seen += e.count
+			cut = i + 1
+			if seen*2 >= b.count {
+				break
+			}
+		}
+		boxes[best] = newBox(b.bins[:cut])
+		boxes = append(boxes, newBox(b.bins[cut:]))
+	}
+
+	pal := make([]color.NRGBA, len(boxes))
+	for i, b := range boxes {
+		var r, g, bl int
+		for _, e := range b.bins {
+			r += e.r
+			g += e.g
+			bl += e.b
+		}
+		pal[i] = color.NRGBA{
+			R: uint8((r + b.count/2) / b.count),
+			G: uint8((g + b.count/2) / b.count),
+			B: uint8((bl + b.count/2) / b.count),
+			A: 255,
+		}
+	}
+	return pal
+}
+
+// Map writes the palette index of every pixel to dst, one byte per pixel
+// with rows dstStride apart. Opaque pixels get the nearest opaque entry by
+// squared RGB distance; with dither set the rounding error of each pixel is
+// spread to its neighbors with Floyd–Steinberg weights. pal must not hold
+// more than 256 entries.
+func Map(dst []uint8, dstStride int, pix []uint8, width, height, stride int, pal []color.NRGBA, dither bool) {
+	if len(pal) == 0 {
+		return
+	}
+	transparent, solid := -1, make([]int, 0, len(pal))
+	for i, c := range pal {
+		if c.A == 0 && transparent < 0 {
+			transparent = i
+		}
+		if opaque(c.A) {
+			solid = append(solid, i)
+		}
+	}
+	if len(solid) == 0 {
+		// Only transparent entries: match on color anyway.
+		for i := range pal {
+			solid = append(solid, i)
+		}
+	}
+
+	cache := make(map[uint32]uint8)
+	nearest := func(r, g, b int) uint8 {
+		key := uint32(r)<<16 | uint32(g)<<8 | uint32(b)
+		if idx, ok := cache[key]; ok {
+			return idx
+		}
+		best, bestD := solid[0], -1
+		for _, i := range solid {
+			c := pal[i]
+			dr, dg, db := r-int(c.R), g-int(c.G), b-int(c.B)
+			if d := dr*dr + dg*dg + db*db; bestD < 0 || d < bestD {
+				best, bestD = i, d
+			}
+		}
+		cache[key] = uint8(best)
+		return uint8(best)
+	}
+
+	// Error rows for the current and next scanline, with one pixel of
+	// padding on both sides.
+	var cur, next []int32
+	if dither {
+		cur, next = make([]int32, (width+2)*3), make([]int32, (width+2)*3)
+	}
+	for y := range height {
+		row := pix[y*stride : y*stride+width*4]
+		out := dst[y*dstStride : y*dstStride+width]
+		for x := range width {
+			r, g, b, a := int(row[x*4]), int(row[x*4+1]), int(row[x*4+2]), row[x*4+3]
+			if !opaque(a) && transparent >= 0 {
+				out[x] = uint8(transparent)
+				continue
+			}
+			if !dither {
+				out[x] = nearest(r, g, b)
+				continue
+			}
+			e := cur[(x+1)*3:]
+			r = clamp8(r + int(e[0]+8)>>4)
+			g = clamp8(g + int(e[1]+8)>>4)
+			b = clamp8(b + int(e[2]+8)>>4)
+			idx := nearest(r, g, b)
+			out[x] = idx
+			c := pal[idx]
+			for ch, diff := range [3]int32{int32(r) - int32(c.R), int32(g) - int32(c.G), int32(b) - int32(c.B)} {
+				// Errors are kept in sixteenths.
+				cur[(x+2)*3+ch] += diff * 7
+				next[x*3+ch] += diff * 3
+				next[(x+1)*3+ch] += diff * 5
+				next[(x+2)*3+ch] += diff
+			}
+		}
+		if dither {
+			cur, next = next, cur
+			clear(next)
+		}
+	}
+}
+
+func clamp8(v int) int {
+	return max(0, min(255, v))
+}
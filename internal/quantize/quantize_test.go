@@ -0,0 +1,66 @@
+package quantize
+
+import (
+	"image/color"
+	"testing"
+)
+
+func fill(pix []uint8, colors ...color.NRGBA) {
+	for i := 0; i < len(pix); i += 4 {
+		c := colors[(i/4)%len(colors)]
+		pix[i], pix[i+1], pix[i+2], pix[i+3] = c.R, c.G, c.B, c.A
+	}
+}
+
+func TestMedianCutExactColors(t *testing.T) {
+	want := []color.NRGBA{{255, 0, 0, 255}, {0, 255, 0, 255}, {0, 0, 255, 255}, {255, 255, 255, 255}}
+	pix := make([]uint8, 8*8*4)
+	fill(pix, want...)
+
+	pal := MedianCut(pix, 8, 8, 8*4, 16)
+	if len(pal) != len(want) {
+		t.Fatalf("palette has %d colors, want %d: %v", len(pal), len(want), pal)
+	}
+	for _, w := range want {
+		found := false
+		for _, c := range pal {
+			found = found || c == w
+		}
+		if !found {
+			t.Errorf("palette %v misses %v", pal, w)
+		}
+	}
+
+	pal = MedianCut(pix, 8, 8, 8*4, 2)
+	if len(pal) != 2 {
+		t.Fatalf("limited palette has %d colors, want 2", len(pal))
+	}
+}
+
+func TestMapTransparentAndDither(t *testing.T) {
+	pal := []color.NRGBA{{}, {0, 0, 0, 255}, {255, 255, 255, 255}}
+	pix := make([]uint8, 16*16*4)
+	fill(pix, color.NRGBA{128, 128, 128, 255})
+	pix[3] = 0 // first pixel transparent
+
+	dst := make([]uint8, 16*16)
+	Map(dst, 16, pix, 16, 16, 16*4, pal, false)
+	if dst[0] != 0 {
+		t.Errorf("transparent pixel mapped to %d, want 0", dst[0])
+	}
+	if dst[1] != 2 {
+		t.Errorf("mid gray mapped to %d without dither, want 2 (white)", dst[1])
+	}
+
+	Map(dst, 16, pix, 16, 16, 16*4, pal, true)
+	white := 0
+	for _, idx := range dst[1:] {
+		if idx == 2 {
+			white++
+		}
+	}
+	// Dithering mid gray with black and white gives about half of each.
+	if frac := float64(white) / float64(len(dst)-1); frac < 0.4 || frac > 0.6 {
+		t.Errorf("dithered white fraction = %.2f, want about 0.5", frac)
+	}
+}
@@ -8,6 +8,17 @@ const (
 	CellBlockPool  = 256
 )
 
+// DefaultCellBlockLimit is the block limit used by the rasterizers, AGG's
+// cell_block_limit default. Blocks of CellBlockSize cells are allocated as a
+// path needs them, so the limit caps memory at 1024 * 4096 cells (about
+// 64 MiB) without costing anything for small paths.
+const DefaultCellBlockLimit = 1024
+
+// CellOverflowFunc is called when a path needs more cells than the block
+// limit allows. The cells beyond the limit are dropped, so the rendered
+// shape is truncated; limit is the block limit that was hit.
+type CellOverflowFunc func(limit uint32)
+
 // SortedY represents a range of cells for a specific Y coordinate
 type SortedY struct {
 	Start uint32 // Starting index in sorted cells array
@@ -5,6 +5,7 @@ import (
 
 	"github.com/MeKo-Christian/agg_go/internal/array"
 	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/stats"
 )
 
 // RasterizerCellsAASimple implements the main rasterization algorithm with concrete CellAA type.
@@ -25,10 +26,15 @@ type RasterizerCellsAASimple struct {
 	minX, minY     int                       // Bounding box minimum
 	maxX, maxY     int                       // Bounding box maximum
 	sorted         bool                      // Whether cells are sorted
+	overflowed     bool                      // Whether cells were dropped at the block limit
+	onOverflow     CellOverflowFunc          // Called on the first dropped cell after Reset
 }
 
 // NewRasterizerCellsAASimple creates a new cell-based rasterizer with the specified cell block limit
 func NewRasterizerCellsAASimple(cellBlockLimit uint32) *RasterizerCellsAASimple {
+	if cellBlockLimit == 0 {
+		cellBlockLimit = DefaultCellBlockLimit
+	}
 	r := &RasterizerCellsAASimple{
 		numBlocks:      0,
 		maxBlocks:      0,
@@ -54,6 +60,7 @@ func NewRasterizerCellsAASimple(cellBlockLimit uint32) *RasterizerCellsAASimple
 func (r *RasterizerCellsAASimple) Reset() {
 	r.numCells = 0
 	r.currBlock = 0
+	r.overflowed = false
 	r.currCell.Initial()
 	r.styleCell.Initial()
 	r.sorted = false
@@ -63,6 +70,42 @@ func (r *RasterizerCellsAASimple) Reset() {
 	r.maxY = math.MinInt32
 }
 
+// CellBlockLimit returns the maximum number of cell blocks.
+func (r *RasterizerCellsAASimple) CellBlockLimit() uint32 {
+	return r.cellBlockLimit
+}
+
+// SetCellBlockLimit sets the maximum number of cell blocks of CellBlockSize
+// cells a path may use; 0 selects DefaultCellBlockLimit. Blocks already
+// allocated are kept for reuse but not used beyond the new limit.
+func (r *RasterizerCellsAASimple) SetCellBlockLimit(limit uint32) {
+	if limit == 0 {
+		limit = DefaultCellBlockLimit
+	}
+	r.cellBlockLimit = limit
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit, or removes it when fn is nil.
+func (r *RasterizerCellsAASimple) SetOverflowHandler(fn CellOverflowFunc) {
+	r.onOverflow = fn
+}
+
+// Overflowed reports whether cells were dropped since the last Reset.
+func (r *RasterizerCellsAASimple) Overflowed() bool {
+	return r.overflowed
+}
+
+func (r *RasterizerCellsAASimple) overflow() {
+	if r.overflowed {
+		return
+	}
+	r.overflowed = true
+	if r.onOverflow != nil {
+		r.onOverflow(r.cellBlockLimit)
+	}
+}
+
 // Style sets the style cell for subsequent operations
 func (r *RasterizerCellsAASimple) Style(styleCell CellAA) {
 	r.styleCell = styleCell
@@ -71,7 +114,8 @@ func (r *RasterizerCellsAASimple) Style(styleCell CellAA) {
 // coordLimit is the maximum absolute coordinate value accepted by Line().
 // The C++ AGG library uses 32-bit int, so coordinates are inherently bounded.
 // With Go's 64-bit int, we must clamp to prevent degenerate rendering behavior.
-const coordLimit = math.MaxInt32
+// The limit grows with the subpixel shift to keep the same range in pixels.
+const coordLimit = math.MaxInt32 << (basics.PolySubpixelShift - 8)
 
 func clampCoord(v int) int {
 	if v > coordLimit {
@@ -270,6 +314,8 @@ func (r *RasterizerCellsAASimple) SortCells() {
 		return
 	}
 
+	stats.AddCells(int(r.numCells))
+
 	// 1) Count cells per Y
 	h := r.maxY - r.minY + 1
 	if cap(r.countsScratch) < h {
@@ -412,11 +458,12 @@ func (r *RasterizerCellsAASimple) addCurrCell() {
 	if r.currCell.GetArea() != 0 || r.currCell.GetCover() != 0 {
 		if (r.numCells & CellBlockMask) == 0 {
 			blockNeeded := r.numCells >> CellBlockShift
+			if blockNeeded >= r.cellBlockLimit {
+				r.overflow()
+				return
+			}
 			if blockNeeded >= r.numBlocks {
 				// Need a new block
-				if r.numBlocks >= r.cellBlockLimit {
-					return
-				}
 				r.allocateBlock()
 			}
 			// else: block already allocated from a previous render, reuse it
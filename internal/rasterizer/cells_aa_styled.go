@@ -26,10 +26,15 @@ type RasterizerCellsAAStyled struct {
 	minX, minY     int                            // Bounding box minimum
 	maxX, maxY     int                            // Bounding box maximum
 	sorted         bool                           // Whether cells are sorted
+	overflowed     bool                           // Whether cells were dropped at the block limit
+	onOverflow     CellOverflowFunc               // Called on the first dropped cell after Reset
 }
 
 // NewRasterizerCellsAAStyled creates a new styled cell-based rasterizer with the specified cell block limit
 func NewRasterizerCellsAAStyled(cellBlockLimit uint32) *RasterizerCellsAAStyled {
+	if cellBlockLimit == 0 {
+		cellBlockLimit = DefaultCellBlockLimit
+	}
 	r := &RasterizerCellsAAStyled{
 		numBlocks:      0,
 		maxBlocks:      0,
@@ -55,6 +60,7 @@ func NewRasterizerCellsAAStyled(cellBlockLimit uint32) *RasterizerCellsAAStyled
 func (r *RasterizerCellsAAStyled) Reset() {
 	r.numCells = 0
 	r.currBlock = 0
+	r.overflowed = false
 	r.currCell.Initial()
 	r.styleCell.Initial()
 	r.sorted = false
@@ -64,6 +70,42 @@ func (r *RasterizerCellsAAStyled) Reset() {
 	r.maxY = math.MinInt32
 }
 
+// CellBlockLimit returns the maximum number of cell blocks.
+func (r *RasterizerCellsAAStyled) CellBlockLimit() uint32 {
+	return r.cellBlockLimit
+}
+
+// SetCellBlockLimit sets the maximum number of cell blocks of CellBlockSize
+// cells a path may use; 0 selects DefaultCellBlockLimit. Blocks already
+// allocated are kept for reuse but not used beyond the new limit.
+func (r *RasterizerCellsAAStyled) SetCellBlockLimit(limit uint32) {
+	if limit == 0 {
+		limit = DefaultCellBlockLimit
+	}
+	r.cellBlockLimit = limit
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit, or removes it when fn is nil.
+func (r *RasterizerCellsAAStyled) SetOverflowHandler(fn CellOverflowFunc) {
+	r.onOverflow = fn
+}
+
+// Overflowed reports whether cells were dropped since the last Reset.
+func (r *RasterizerCellsAAStyled) Overflowed() bool {
+	return r.overflowed
+}
+
+func (r *RasterizerCellsAAStyled) overflow() {
+	if r.overflowed {
+		return
+	}
+	r.overflowed = true
+	if r.onOverflow != nil {
+		r.onOverflow(r.cellBlockLimit)
+	}
+}
+
 // Style sets the style cell for subsequent operations
 func (r *RasterizerCellsAAStyled) Style(styleCell CellStyleAA) {
 	r.styleCell = styleCell
@@ -398,11 +440,12 @@ func (r *RasterizerCellsAAStyled) addCurrCell() {
 	if r.currCell.GetArea() != 0 || r.currCell.GetCover() != 0 {
 		if (r.numCells & CellBlockMask) == 0 {
 			blockNeeded := r.numCells >> CellBlockShift
+			if blockNeeded >= r.cellBlockLimit {
+				r.overflow()
+				return
+			}
 			if blockNeeded >= r.numBlocks {
 				// Need a new block
-				if r.numBlocks >= r.cellBlockLimit {
-					return
-				}
 				r.allocateBlock()
 			}
 			// else: block already allocated from a previous render, reuse it
@@ -176,3 +176,44 @@ func TestRasterizerCellsAA_SortPreservesDuplicateXCells(t *testing.T) {
 		t.Fatalf("Expected both sorted cells to keep X=7, got %d and %d", cells[0].X, cells[1].X)
 	}
 }
+
+func TestRasterizerCellsAA_BlockLimitOverflow(t *testing.T) {
+	r := NewRasterizerCellsAASimple(0)
+	if r.CellBlockLimit() != DefaultCellBlockLimit {
+		t.Fatalf("CellBlockLimit() = %d, want default %d", r.CellBlockLimit(), DefaultCellBlockLimit)
+	}
+
+	var calls []uint32
+	r.SetOverflowHandler(func(limit uint32) { calls = append(calls, limit) })
+	r.SetCellBlockLimit(1)
+
+	// A long diagonal needs about two cells per pixel, well over one block.
+	end := 5000 << basics.PolySubpixelShift
+	r.Line(0, 0, end, end)
+	r.SortCells()
+
+	if !r.Overflowed() {
+		t.Fatal("Overflowed() = false, want true")
+	}
+	if len(calls) != 1 || calls[0] != 1 {
+		t.Fatalf("overflow handler calls = %v, want [1]", calls)
+	}
+	if r.TotalCells() != CellBlockSize {
+		t.Errorf("TotalCells() = %d, want %d", r.TotalCells(), CellBlockSize)
+	}
+
+	// Raising the limit lets the same path through; Reset clears the flag.
+	r.SetCellBlockLimit(8)
+	r.Reset()
+	r.Line(0, 0, end, end)
+	r.SortCells()
+	if r.Overflowed() {
+		t.Error("Overflowed() = true after raising the limit")
+	}
+	if r.TotalCells() <= CellBlockSize {
+		t.Errorf("TotalCells() = %d, want more than one block", r.TotalCells())
+	}
+	if len(calls) != 1 {
+		t.Errorf("overflow handler called again: %v", calls)
+	}
+}
@@ -53,9 +53,9 @@ func TestIntConv(t *testing.T) {
 	}
 
 	// Test Downscale
-	downscaled := conv.Downscale(256)
+	downscaled := conv.Downscale(basics.PolySubpixelScale)
 	if downscaled != 1 {
-		t.Errorf("Downscale(256) = %d, want 1", downscaled)
+		t.Errorf("Downscale(%d) = %d, want 1", basics.PolySubpixelScale, downscaled)
 	}
 }
 
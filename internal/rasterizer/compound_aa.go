@@ -135,7 +135,7 @@ type RasterizerCompoundAA[Clip CompoundClipInterface] struct {
 // NewRasterizerCompoundAA creates the styled scanline rasterizer.
 func NewRasterizerCompoundAA[Clip CompoundClipInterface](clipper Clip) *RasterizerCompoundAA[Clip] {
 	return &RasterizerCompoundAA[Clip]{
-		outline:     NewRasterizerCellsAAStyled(1024),
+		outline:     NewRasterizerCellsAAStyled(DefaultCellBlockLimit),
 		clipper:     clipper,
 		fillingRule: basics.FillNonZero,
 		layerOrder:  basics.LayerDirect,
@@ -181,6 +181,29 @@ func (r *RasterizerCompoundAA[Clip]) FillingRule(rule basics.FillingRule) {
 	r.fillingRule = rule
 }
 
+// CellBlockLimit returns the maximum number of cell blocks a path may use.
+func (r *RasterizerCompoundAA[Clip]) CellBlockLimit() uint32 {
+	return r.outline.CellBlockLimit()
+}
+
+// SetCellBlockLimit caps the cell memory of a path at limit blocks of
+// CellBlockSize cells; 0 selects DefaultCellBlockLimit. Raise it for huge
+// paths, lower it to bound memory use.
+func (r *RasterizerCompoundAA[Clip]) SetCellBlockLimit(limit uint32) {
+	r.outline.SetCellBlockLimit(limit)
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit. Such paths render truncated.
+func (r *RasterizerCompoundAA[Clip]) SetOverflowHandler(fn CellOverflowFunc) {
+	r.outline.SetOverflowHandler(fn)
+}
+
+// Overflowed reports whether the current path exceeded the cell block limit.
+func (r *RasterizerCompoundAA[Clip]) Overflowed() bool {
+	return r.outline.Overflowed()
+}
+
 // LayerOrder sets the layer rendering order
 func (r *RasterizerCompoundAA[Clip]) LayerOrder(order basics.LayerOrder) {
 	r.layerOrder = order
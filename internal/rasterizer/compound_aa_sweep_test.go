@@ -88,7 +88,7 @@ func TestCompoundAACalculateAlphaWithConstants(t *testing.T) {
 	}
 
 	// Test non-zero area
-	alpha = rasterizer.CalculateAlpha(1000)
+	alpha = rasterizer.CalculateAlpha(1000 * areaScale)
 	if alpha == 0 {
 		t.Error("CalculateAlpha(1000) should produce non-zero alpha")
 	}
@@ -306,6 +306,10 @@ func TestRasterizerCompoundAAEdge(t *testing.T) {
 	}
 }
 
+// areaScale converts cell areas of AGG's 8-bit subpixel precision to the
+// precision of the build.
+const areaScale = 1 << (2 * (basics.PolySubpixelShift - 8))
+
 // TestRasterizerCompoundAACalculateAlpha tests alpha calculation
 func TestRasterizerCompoundAACalculateAlpha(t *testing.T) {
 	rasterizer := NewRasterizerCompoundAA(NewMockCompoundClipper(nil))
@@ -317,20 +321,20 @@ func TestRasterizerCompoundAACalculateAlpha(t *testing.T) {
 	}
 
 	// Test that positive area produces positive alpha
-	alpha1 := rasterizer.CalculateAlpha(1000)
+	alpha1 := rasterizer.CalculateAlpha(1000 * areaScale)
 	if alpha1 == 0 {
 		t.Error("CalculateAlpha(1000) should produce non-zero alpha")
 	}
 
 	// Test that larger area produces larger or equal alpha
-	alpha2 := rasterizer.CalculateAlpha(10000)
+	alpha2 := rasterizer.CalculateAlpha(10000 * areaScale)
 	if alpha2 < alpha1 {
 		t.Error("Larger area should produce larger or equal alpha")
 	}
 
 	// Test with even-odd filling rule
 	rasterizer.FillingRule(basics.FillEvenOdd)
-	alpha := rasterizer.CalculateAlpha(256 * 512) // Should wrap around in even-odd
+	alpha := rasterizer.CalculateAlpha(256 * 512 * areaScale) // Should wrap around in even-odd
 	if alpha == 0 {
 		t.Error("Even-odd filling should produce non-zero alpha for large areas")
 	}
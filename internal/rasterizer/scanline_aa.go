@@ -1,8 +1,13 @@
 package rasterizer
 
 import (
+	"cmp"
+	"math"
+	"slices"
+
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/stats"
 )
 
 // AA scale constants are defined in compound_aa.go
@@ -48,7 +53,7 @@ func NewRasterizerScanlineAA[C basics.CoordType, V Conv[C], Clip any](conv V, cl
 },
 ) *RasterizerScanlineAA[C, V, Clip] {
 	r := &RasterizerScanlineAA[C, V, Clip]{
-		outline:     NewRasterizerCellsAASimple(256), // Default cell block limit
+		outline:     NewRasterizerCellsAASimple(DefaultCellBlockLimit),
 		clipper:     clipper,
 		conv:        conv,
 		fillingRule: basics.FillNonZero,
@@ -107,6 +112,29 @@ func (r *RasterizerScanlineAA[C, V, Clip]) GetFillingRule() basics.FillingRule {
 	return r.fillingRule
 }
 
+// CellBlockLimit returns the maximum number of cell blocks a path may use.
+func (r *RasterizerScanlineAA[C, V, Clip]) CellBlockLimit() uint32 {
+	return r.outline.CellBlockLimit()
+}
+
+// SetCellBlockLimit caps the cell memory of a path at limit blocks of
+// CellBlockSize cells; 0 selects DefaultCellBlockLimit. Raise it for huge
+// paths, lower it to bound memory use.
+func (r *RasterizerScanlineAA[C, V, Clip]) SetCellBlockLimit(limit uint32) {
+	r.outline.SetCellBlockLimit(limit)
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit. Such paths render truncated.
+func (r *RasterizerScanlineAA[C, V, Clip]) SetOverflowHandler(fn CellOverflowFunc) {
+	r.outline.SetOverflowHandler(fn)
+}
+
+// Overflowed reports whether the current path exceeded the cell block limit.
+func (r *RasterizerScanlineAA[C, V, Clip]) Overflowed() bool {
+	return r.outline.Overflowed()
+}
+
 // AutoClose controls whether a new MoveTo implicitly closes the previous contour.
 func (r *RasterizerScanlineAA[C, V, Clip]) AutoClose(flag bool) {
 	r.autoClose = flag
@@ -126,6 +154,14 @@ func (r *RasterizerScanlineAA[C, V, Clip]) SetGamma(gammaFunc func(float64) floa
 	}
 }
 
+// SetGammaTable installs a precomputed coverage gamma table, such as the
+// 256-entry LUT returned by a gamma control's Gamma method. Entry i is the
+// alpha for coverage i; entries missing from a shorter table keep their
+// current value.
+func (r *RasterizerScanlineAA[C, V, Clip]) SetGammaTable(table []uint8) {
+	copy(r.gamma[:], table)
+}
+
 // ApplyGamma maps a raw coverage value through the configured gamma table.
 func (r *RasterizerScanlineAA[C, V, Clip]) ApplyGamma(cover int) uint8 {
 	if cover > AAMask {
@@ -380,6 +416,7 @@ func (r *RasterizerScanlineAA[C, V, Clip]) SweepScanline(sl scanline.Scanline) b
 
 	sl.Finalize(r.scanY)
 	r.scanY++
+	stats.AddScanline()
 	return true
 }
 
@@ -418,3 +455,88 @@ func (r *RasterizerScanlineAA[C, V, Clip]) HitTest(tx, ty int) bool {
 	alpha := r.CalculateAlpha(cover << (basics.PolySubpixelShift + 1))
 	return alpha != 0
 }
+
+// HitTestPoints runs HitTest for many points against the same rasterized
+// geometry and stores the results in hits, which must be at least as long as
+// pts. Points are visited row by row in x order, so every scanline's cells
+// are walked once for all points on it. It returns the number of hits.
+func (r *RasterizerScanlineAA[C, V, Clip]) HitTestPoints(pts []basics.Point[int], hits []bool) int {
+	order := make([]int, len(pts))
+	for i := range order {
+		order[i] = i
+		hits[i] = false
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		if pts[a].Y != pts[b].Y {
+			return cmp.Compare(pts[a].Y, pts[b].Y)
+		}
+		return cmp.Compare(pts[a].X, pts[b].X)
+	})
+
+	n := 0
+	for start := 0; start < len(order); {
+		ty := pts[order[start]].Y
+		end := start + 1
+		for end < len(order) && pts[order[end]].Y == ty {
+			end++
+		}
+		if r.NavigateScanline(ty) {
+			cells := r.outline.ScanlineCellsView(ty)
+			i, cover := 0, 0
+			for _, k := range order[start:end] {
+				tx := pts[k].X
+				for i < len(cells) && cells[i].X < tx {
+					cover += cells[i].Cover
+					i++
+				}
+				c, area := cover, 0
+				for j := i; j < len(cells) && cells[j].X == tx; j++ {
+					c += cells[j].Cover
+					area += cells[j].Area
+				}
+				if r.CalculateAlpha((c<<(basics.PolySubpixelShift+1))-area) != 0 {
+					hits[k] = true
+					n++
+				}
+			}
+		}
+		start = end
+	}
+	return n
+}
+
+// SweepAccumulated walks all scanlines like SweepScanline, but reports the
+// coverage accumulated by overlapping polygons instead of clamping it: where
+// n shapes of the same orientation overlap fully, the coverage is n. It is
+// the absolute winding-weighted area per pixel, so the filling rule and the
+// gamma table do not apply, and shapes of opposite orientation cancel. fn
+// receives runs of length pixels starting at (x, y) that share one coverage;
+// runs of zero coverage are skipped.
+func (r *RasterizerScanlineAA[C, V, Clip]) SweepAccumulated(fn func(x, y, length int, coverage float64)) {
+	if !r.RewindScanlines() {
+		return
+	}
+	const full = float64(int(1) << (basics.PolySubpixelShift*2 + 1))
+	for y := r.outline.MinY(); y <= r.outline.MaxY(); y++ {
+		cells := r.outline.ScanlineCellsView(y)
+		cover := 0
+		for i := 0; i < len(cells); {
+			x := cells[i].X
+			area := 0
+			for ; i < len(cells) && cells[i].X == x; i++ {
+				area += cells[i].Area
+				cover += cells[i].Cover
+			}
+			if area != 0 {
+				if a := (cover << (basics.PolySubpixelShift + 1)) - area; a != 0 {
+					fn(x, y, 1, math.Abs(float64(a))/full)
+				}
+				x++
+			}
+			if i < len(cells) && cells[i].X > x && cover != 0 {
+				fn(x, y, cells[i].X-x, math.Abs(float64(cover<<(basics.PolySubpixelShift+1)))/full)
+			}
+		}
+	}
+	r.scanY = r.outline.MaxY() + 1
+}
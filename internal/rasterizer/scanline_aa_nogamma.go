@@ -3,6 +3,7 @@ package rasterizer
 import (
 	"github.com/MeKo-Christian/agg_go/internal/basics"
 	"github.com/MeKo-Christian/agg_go/internal/scanline"
+	"github.com/MeKo-Christian/agg_go/internal/stats"
 )
 
 // RasterizerScanlineAANoGamma is a polygon rasterizer optimized for high-quality
@@ -35,7 +36,7 @@ func NewRasterizerScanlineAANoGamma[C basics.CoordType, V Conv[C], Clip any](con
 },
 ) *RasterizerScanlineAANoGamma[C, V, Clip] {
 	return &RasterizerScanlineAANoGamma[C, V, Clip]{
-		outline:     NewRasterizerCellsAASimple(256), // Default cell block limit
+		outline:     NewRasterizerCellsAASimple(DefaultCellBlockLimit),
 		clipper:     clipper,
 		conv:        conv,
 		fillingRule: basics.FillNonZero,
@@ -68,6 +69,29 @@ func (r *RasterizerScanlineAANoGamma[C, V, Clip]) FillingRule(rule basics.Fillin
 	r.fillingRule = rule
 }
 
+// CellBlockLimit returns the maximum number of cell blocks a path may use.
+func (r *RasterizerScanlineAANoGamma[C, V, Clip]) CellBlockLimit() uint32 {
+	return r.outline.CellBlockLimit()
+}
+
+// SetCellBlockLimit caps the cell memory of a path at limit blocks of
+// CellBlockSize cells; 0 selects DefaultCellBlockLimit. Raise it for huge
+// paths, lower it to bound memory use.
+func (r *RasterizerScanlineAANoGamma[C, V, Clip]) SetCellBlockLimit(limit uint32) {
+	r.outline.SetCellBlockLimit(limit)
+}
+
+// SetOverflowHandler sets the function called when a path exceeds the cell
+// block limit. Such paths render truncated.
+func (r *RasterizerScanlineAANoGamma[C, V, Clip]) SetOverflowHandler(fn CellOverflowFunc) {
+	r.outline.SetOverflowHandler(fn)
+}
+
+// Overflowed reports whether the current path exceeded the cell block limit.
+func (r *RasterizerScanlineAANoGamma[C, V, Clip]) Overflowed() bool {
+	return r.outline.Overflowed()
+}
+
 // AutoClose sets whether polygons should be automatically closed
 func (r *RasterizerScanlineAANoGamma[C, V, Clip]) AutoClose(flag bool) {
 	r.autoClose = flag
@@ -330,6 +354,7 @@ func (r *RasterizerScanlineAANoGamma[C, V, Clip]) SweepScanline(sl scanline.Scan
 
 	sl.Finalize(r.scanY)
 	r.scanY++
+	stats.AddScanline()
 	return true
 }
 
@@ -1,6 +1,7 @@
 package rasterizer
 
 import (
+	"math"
 	"testing"
 
 	"github.com/MeKo-Christian/agg_go/internal/basics"
@@ -164,6 +165,26 @@ func TestRasterizerScanlineAA_SetGamma(t *testing.T) {
 	}
 }
 
+func TestRasterizerScanlineAA_SetGammaTable(t *testing.T) {
+	clip := &MockClip{}
+	r := NewRasterizerScanlineAA[float64, DblConv, *MockClip](DblConv{}, clip)
+
+	table := make([]uint8, AAScale)
+	for i := range table {
+		table[i] = uint8(AAMask - i)
+	}
+	r.SetGammaTable(table)
+	if r.ApplyGamma(0) != AAMask || r.ApplyGamma(200) != AAMask-200 {
+		t.Errorf("ApplyGamma(0)=%d ApplyGamma(200)=%d, want inverted table", r.ApplyGamma(0), r.ApplyGamma(200))
+	}
+
+	// A short table only replaces the leading entries.
+	r.SetGammaTable([]uint8{7})
+	if r.ApplyGamma(0) != 7 || r.ApplyGamma(1) != AAMask-1 {
+		t.Errorf("ApplyGamma(0)=%d ApplyGamma(1)=%d after short table", r.ApplyGamma(0), r.ApplyGamma(1))
+	}
+}
+
 func TestRasterizerScanlineAA_ApplyGamma(t *testing.T) {
 	clip := &MockClip{}
 	r := NewRasterizerScanlineAA[float64, DblConv, *MockClip](DblConv{}, clip)
@@ -332,3 +353,77 @@ func TestRasterizerScanlineAA_AddVertex(t *testing.T) {
 		t.Error("Expected status to be StatusClosed after Close command")
 	}
 }
+
+func TestRasterizerScanlineAA_HitTestPoints(t *testing.T) {
+	for _, rule := range []basics.FillingRule{basics.FillNonZero, basics.FillEvenOdd} {
+		ras := NewRasterizerScanlineAA[int, IntConv, *RasterizerSlNoClip](IntConv{}, NewRasterizerSlNoClip())
+		ras.FillingRule(rule)
+		// A triangle with a square hole drawn in the same direction.
+		ras.MoveToD(2, 2)
+		ras.LineToD(30, 4)
+		ras.LineToD(12, 28)
+		ras.ClosePolygon()
+		ras.MoveToD(10, 8)
+		ras.LineToD(16, 8)
+		ras.LineToD(16, 14)
+		ras.LineToD(10, 14)
+		ras.ClosePolygon()
+
+		var pts []basics.Point[int]
+		for y := 31; y >= -1; y-- {
+			for x := -1; x < 32; x += 3 {
+				pts = append(pts, basics.Point[int]{X: x, Y: y})
+			}
+		}
+		hits := make([]bool, len(pts))
+		n := ras.HitTestPoints(pts, hits)
+
+		want := 0
+		for i, p := range pts {
+			h := ras.HitTest(p.X, p.Y)
+			if h {
+				want++
+			}
+			if hits[i] != h {
+				t.Errorf("rule %v: point %v batch hit %v, HitTest %v", rule, p, hits[i], h)
+			}
+		}
+		if n != want || n == 0 {
+			t.Errorf("rule %v: %d hits, want %d (non-zero)", rule, n, want)
+		}
+	}
+}
+
+func TestRasterizerScanlineAA_SweepAccumulated(t *testing.T) {
+	ras := NewRasterizerScanlineAA[int, IntConv, *RasterizerSlNoClip](IntConv{}, NewRasterizerSlNoClip())
+	square := func(x1, y1, x2, y2 float64) {
+		ras.MoveToD(x1, y1)
+		ras.LineToD(x2, y1)
+		ras.LineToD(x2, y2)
+		ras.LineToD(x1, y2)
+		ras.ClosePolygon()
+	}
+	// Three squares overlapping in [4, 6) x [4, 6); the last one ends on a
+	// half pixel.
+	square(0, 0, 6, 6)
+	square(4, 4, 10, 10)
+	square(2, 2, 6.5, 6)
+
+	got := map[[2]int]float64{}
+	ras.SweepAccumulated(func(x, y, length int, coverage float64) {
+		for i := 0; i < length; i++ {
+			got[[2]int{x + i, y}] += coverage
+		}
+	})
+
+	for _, tc := range []struct {
+		x, y int
+		want float64
+	}{
+		{1, 1, 1}, {3, 3, 2}, {5, 5, 3}, {6, 5, 1.5}, {8, 8, 1}, {12, 12, 0},
+	} {
+		if c := got[[2]int{tc.x, tc.y}]; math.Abs(c-tc.want) > 1e-9 {
+			t.Errorf("coverage at (%d,%d) = %v, want %v", tc.x, tc.y, c, tc.want)
+		}
+	}
+}
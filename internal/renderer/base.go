@@ -3,6 +3,8 @@ package renderer
 
 import (
 	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/buffer"
+	"github.com/MeKo-Christian/agg_go/internal/stats"
 )
 
 // PixelFormat is the minimal pixel-format contract required by RendererBase.
@@ -91,6 +93,14 @@ func (r *RendererBase[PF, C]) Width() int { return r.pixfmt.Width() }
 // Height returns the height of the rendering buffer
 func (r *RendererBase[PF, C]) Height() int { return r.pixfmt.Height() }
 
+// intersectInclusive intersects two boxes with inclusive bounds. Unlike
+// basics.IntersectRectangles a single row or column is a valid result, as in
+// AGG's rect::clip.
+func intersectInclusive(a, b basics.RectI) (basics.RectI, bool) {
+	r := basics.RectI{X1: max(a.X1, b.X1), Y1: max(a.Y1, b.Y1), X2: min(a.X2, b.X2), Y2: min(a.Y2, b.Y2)}
+	return r, r.X1 <= r.X2 && r.Y1 <= r.Y2
+}
+
 // ClipBox sets the clipping box with bounds checking
 // Returns true if the clipping box intersects with the buffer bounds
 func (r *RendererBase[PF, C]) ClipBox(x1, y1, x2, y2 int) bool {
@@ -102,8 +112,7 @@ func (r *RendererBase[PF, C]) ClipBox(x1, y1, x2, y2 int) bool {
 	}
 	cb := basics.RectI{X1: x1, Y1: y1, X2: x2, Y2: y2}
 	bufferBounds := basics.RectI{X1: 0, Y1: 0, X2: r.Width() - 1, Y2: r.Height() - 1}
-	clipped, hasIntersection := basics.IntersectRectangles(cb, bufferBounds)
-	if hasIntersection {
+	if clipped, ok := intersectInclusive(cb, bufferBounds); ok {
 		r.clipBox = clipped
 		return true
 	}
@@ -219,6 +228,7 @@ func (r *RendererBase[PF, C]) CopyHline(x1, y, x2 int, c C) {
 	if x2 > r.Xmax() {
 		x2 = r.Xmax()
 	}
+	stats.AddSpan(x2 - x1 + 1)
 	r.pixfmt.CopyHline(x1, y, x2-x1+1, c)
 }
 
@@ -239,6 +249,7 @@ func (r *RendererBase[PF, C]) CopyVline(x, y1, y2 int, c C) {
 	if y2 > r.Ymax() {
 		y2 = r.Ymax()
 	}
+	stats.AddSpan(y2 - y1 + 1)
 	r.pixfmt.CopyVline(x, y1, y2-y1+1, c)
 }
 
@@ -259,6 +270,7 @@ func (r *RendererBase[PF, C]) BlendHline(x1, y, x2 int, c C, cover basics.Int8u)
 	if x2 > r.Xmax() {
 		x2 = r.Xmax()
 	}
+	stats.AddSpan(x2 - x1 + 1)
 	r.pixfmt.BlendHline(x1, y, x2-x1+1, c, cover)
 }
 
@@ -279,6 +291,7 @@ func (r *RendererBase[PF, C]) BlendVline(x, y1, y2 int, c C, cover basics.Int8u)
 	if y2 > r.Ymax() {
 		y2 = r.Ymax()
 	}
+	stats.AddSpan(y2 - y1 + 1)
 	r.pixfmt.BlendVline(x, y1, y2-y1+1, c, cover)
 }
 
@@ -291,8 +304,7 @@ func (r *RendererBase[PF, C]) CopyBar(x1, y1, x2, y2 int, c C) {
 		y1, y2 = y2, y1
 	}
 	rc := basics.RectI{X1: x1, Y1: y1, X2: x2, Y2: y2}
-	clipped, hasIntersection := basics.IntersectRectangles(rc, r.clipBox)
-	if hasIntersection {
+	if clipped, ok := intersectInclusive(rc, r.clipBox); ok {
 		for y := clipped.Y1; y <= clipped.Y2; y++ {
 			r.pixfmt.CopyHline(clipped.X1, y, clipped.X2-clipped.X1+1, c)
 		}
@@ -308,8 +320,7 @@ func (r *RendererBase[PF, C]) BlendBar(x1, y1, x2, y2 int, c C, cover basics.Int
 		y1, y2 = y2, y1
 	}
 	rc := basics.RectI{X1: x1, Y1: y1, X2: x2, Y2: y2}
-	clipped, hasIntersection := basics.IntersectRectangles(rc, r.clipBox)
-	if hasIntersection {
+	if clipped, ok := intersectInclusive(rc, r.clipBox); ok {
 		for y := clipped.Y1; y <= clipped.Y2; y++ {
 			r.pixfmt.BlendHline(clipped.X1, y, clipped.X2-clipped.X1+1, c, cover)
 		}
@@ -335,6 +346,7 @@ func (r *RendererBase[PF, C]) BlendSolidHspan(x, y, length int, c C, covers []ba
 			return
 		}
 	}
+	stats.AddSpan(length)
 	r.pixfmt.BlendSolidHspan(x, y, length, c, covers)
 }
 
@@ -357,6 +369,7 @@ func (r *RendererBase[PF, C]) BlendSolidVspan(x, y, length int, c C, covers []ba
 			return
 		}
 	}
+	stats.AddSpan(length)
 	r.pixfmt.BlendSolidVspan(x, y, length, c, covers)
 }
 
@@ -380,6 +393,7 @@ func (r *RendererBase[PF, C]) CopyColorHspan(x, y, length int, colors []C) {
 			return
 		}
 	}
+	stats.AddSpan(length)
 	r.pixfmt.CopyColorHspan(x, y, length, colors)
 }
 
@@ -403,6 +417,7 @@ func (r *RendererBase[PF, C]) CopyColorVspan(x, y, length int, colors []C) {
 			return
 		}
 	}
+	stats.AddSpan(length)
 	r.pixfmt.CopyColorVspan(x, y, length, colors)
 }
 
@@ -429,6 +444,7 @@ func (r *RendererBase[PF, C]) BlendColorHspan(x, y, length int, colors []C, cove
 			return
 		}
 	}
+	stats.AddSpan(length)
 	r.pixfmt.BlendColorHspan(x, y, length, colors, covers, cover)
 }
 
@@ -455,6 +471,7 @@ func (r *RendererBase[PF, C]) BlendColorVspan(x, y, length int, colors []C, cove
 			return
 		}
 	}
+	stats.AddSpan(length)
 	r.pixfmt.BlendColorVspan(x, y, length, colors, covers, cover)
 }
 
@@ -562,6 +579,71 @@ func (r *RendererBase[PF, C]) CopyFrom(src PixelFormat[C], rectSrcPtr *basics.Re
 	}
 }
 
+// rowDataCapable is checked via type assertion on the pixfmt by
+// CopyFromBuffer.
+type rowDataCapable interface {
+	RowData(y int) []basics.Int8u
+}
+
+// CopyFromBuffer copies the pixels of src, a rendering buffer in this
+// renderer's pixel format, offset by (dx, dy) and clipped to the clip box.
+// Like AGG's renderer_base::copy_from it moves whole rows of bytes without
+// converting them. If rectSrcPtr is nil, copies the full source. src may be
+// the buffer the renderer draws into. The pixfmt must expose its rows through
+// RowData; otherwise this is a no-op.
+func (r *RendererBase[PF, C]) CopyFromBuffer(src *buffer.RenderingBufferU8, rectSrcPtr *basics.RectI, dx, dy int) {
+	pf, ok := any(r.pixfmt).(rowDataCapable)
+	if !ok || src == nil {
+		return
+	}
+
+	wsrc, hsrc := src.Width(), src.Height()
+	if wsrc <= 0 || hsrc <= 0 || r.Width() <= 0 || r.Height() <= 0 {
+		return
+	}
+
+	var srcRect basics.RectI
+	if rectSrcPtr == nil {
+		srcRect = basics.RectI{X1: 0, Y1: 0, X2: wsrc - 1, Y2: hsrc - 1}
+	} else {
+		srcRect = *rectSrcPtr
+	}
+
+	dstRect := basics.RectI{
+		X1: srcRect.X1 + dx,
+		Y1: srcRect.Y1 + dy,
+		X2: dx + (srcRect.X2 - srcRect.X1),
+		Y2: dy + (srcRect.Y2 - srcRect.Y1),
+	}
+	dstRect.X2 += srcRect.X1
+	dstRect.Y2 += srcRect.Y1
+
+	rc := r.ClipRectArea(&dstRect, &srcRect, wsrc, hsrc)
+	if rc.X2 <= 0 || rc.Y2 <= 0 {
+		return
+	}
+
+	bpp := r.pixfmt.PixWidth()
+	incy := 1
+	if dstRect.Y1 > srcRect.Y1 {
+		srcRect.Y1 += rc.Y2 - 1
+		dstRect.Y1 += rc.Y2 - 1
+		incy = -1
+	}
+	for rc.Y2 > 0 {
+		srcRow := buffer.RowU8(src, srcRect.Y1)
+		dstRow := pf.RowData(dstRect.Y1)
+		so, do, n := srcRect.X1*bpp, dstRect.X1*bpp, rc.X2*bpp
+		if so+n <= len(srcRow) && do+n <= len(dstRow) {
+			// copy moves overlapping bytes like memmove.
+			copy(dstRow[do:do+n], srcRow[so:so+n])
+		}
+		dstRect.Y1 += incy
+		srcRect.Y1 += incy
+		rc.Y2--
+	}
+}
+
 // GraySource is a grayscale image used as coverage source in BlendFromColor/BlendFromLUT.
 type GraySource interface {
 	RowData(y int) []basics.Int8u
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/buffer"
 )
 
 // MockPixelFormat is a typed mock pixel format for tests.
@@ -161,6 +162,29 @@ func TestRendererBaseTypedBasics(t *testing.T) {
 	}
 }
 
+func TestRendererBaseClipBoxSingleRow(t *testing.T) {
+	pf := NewMockPixelFormat[string](8, 1)
+	r := NewRendererBaseWithPixfmt[*MockPixelFormat[string], string](pf)
+
+	if !r.ClipBox(0, 0, 8, 1) {
+		t.Fatal("ClipBox should accept a one-row buffer")
+	}
+	if r.Ymin() != 0 || r.Ymax() != 0 || r.Xmax() != 7 {
+		t.Fatalf("unexpected clip box %+v", r.ClipBoxRect())
+	}
+	r.CopyHline(0, 0, 8, "red")
+	if got := r.Pixel(3, 0); got != "red" {
+		t.Fatalf("expected red, got %q", got)
+	}
+	r.CopyBar(0, 0, 7, 0, "blue")
+	if got := r.Pixel(7, 0); got != "blue" {
+		t.Fatalf("CopyBar should fill a one-row box, got %q", got)
+	}
+	if r.ClipBox(0, 2, 8, 5) {
+		t.Fatal("ClipBox below the buffer should fail")
+	}
+}
+
 func TestRendererBaseCopyFromOverlappingVerticalRegion(t *testing.T) {
 	pf := NewMockPixelFormat[string](4, 4)
 	r := NewRendererBaseWithPixfmt[*MockPixelFormat[string], string](pf)
@@ -213,6 +237,44 @@ func TestRendererBaseBlendFromOverlappingVerticalRegion(t *testing.T) {
 	}
 }
 
+// rowPixelFormat is a MockPixelFormat whose rows are the bytes of a one-byte
+// per pixel buffer.
+type rowPixelFormat struct {
+	*MockPixelFormat[string]
+	rbuf *buffer.RenderingBufferU8
+}
+
+func (p rowPixelFormat) PixWidth() int                { return 1 }
+func (p rowPixelFormat) RowData(y int) []basics.Int8u { return buffer.RowU8(p.rbuf, y) }
+
+func TestRendererBaseCopyFromBuffer(t *testing.T) {
+	rbuf := buffer.NewRenderingBufferU8WithData(make([]basics.Int8u, 4*4), 4, 4, 4)
+	pf := rowPixelFormat{NewMockPixelFormat[string](4, 4), rbuf}
+	r := NewRendererBaseWithPixfmt[rowPixelFormat, string](pf)
+	r.ClipBox(0, 0, 2, 3)
+
+	src := buffer.NewRenderingBufferU8WithData([]basics.Int8u{1, 2, 3, 4, 5, 6}, 3, 2, 3)
+	r.CopyFromBuffer(src, nil, 1, 1)
+	want := []basics.Int8u{
+		0, 0, 0, 0,
+		0, 1, 2, 0, // clipped at x = 2
+		0, 4, 5, 0,
+		0, 0, 0, 0,
+	}
+	for i, v := range want {
+		if rbuf.Buf()[i] != v {
+			t.Fatalf("buffer = %v, want %v", rbuf.Buf(), want)
+		}
+	}
+
+	// Copying a buffer onto itself moves overlapping rows like memmove.
+	r.ResetClipping(true)
+	r.CopyFromBuffer(rbuf, &basics.RectI{X1: 0, Y1: 1, X2: 3, Y2: 2}, 0, 1)
+	if got := rbuf.Buf()[8:16]; got[1] != 1 || got[5] != 4 {
+		t.Fatalf("rows after overlapping copy = %v, want 1 2 then 4 5", got)
+	}
+}
+
 func TestRendererBaseCopyFromUsesRelativeDestinationOffset(t *testing.T) {
 	src := NewMockPixelFormat[string](5, 5)
 	dst := NewMockPixelFormat[string](8, 8)
@@ -5,6 +5,7 @@ package renderer
 import (
 	"github.com/MeKo-Christian/agg_go/internal/array"
 	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/buffer"
 )
 
 // RendererMClip provides multi-clipping renderer functionality for typed pixel formats.
@@ -280,6 +281,18 @@ func (r *RendererMClip[PF, C]) CopyFrom(src PixelFormat[C], rectSrcPtr *basics.R
 	}
 }
 
+// CopyFromBuffer copies the rows of a rendering buffer across all clipping
+// regions.
+func (r *RendererMClip[PF, C]) CopyFromBuffer(src *buffer.RenderingBufferU8, rectSrcPtr *basics.RectI, dx, dy int) {
+	r.FirstClipBox()
+	for {
+		r.ren.CopyFromBuffer(src, rectSrcPtr, dx, dy)
+		if !r.NextClipBox() {
+			break
+		}
+	}
+}
+
 // BlendFrom blends from a typed source across all clipping regions.
 func (r *RendererMClip[PF, C]) BlendFrom(src PixelFormat[C], rectSrcPtr *basics.RectI, dx, dy int, cover basics.Int8u) {
 	r.FirstClipBox()
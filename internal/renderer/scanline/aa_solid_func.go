@@ -0,0 +1,104 @@
+package scanline
+
+// SpanColorFunc returns the solid color of the span of length pixels that
+// starts at device pixel (x, y).
+type SpanColorFunc[C any] func(x, y, length int) C
+
+// RendererScanlineAASolidFunc is RendererScanlineAASolid with the color
+// chosen by a callback for every span instead of fixed. A callback that only
+// looks at y gives per-scanline colors, such as vertical ramps or CRT-style
+// scanline bands, without the span allocator and generator a gradient needs.
+type RendererScanlineAASolidFunc[BR BaseRendererInterface[C], C any] struct {
+	baseRenderer BR               // The base renderer
+	colorFunc    SpanColorFunc[C] // Color of each span
+}
+
+// NewRendererScanlineAASolidFunc creates a renderer painting through ren with
+// the colors returned by fn.
+func NewRendererScanlineAASolidFunc[BR BaseRendererInterface[C], C any](ren BR, fn SpanColorFunc[C]) *RendererScanlineAASolidFunc[BR, C] {
+	return &RendererScanlineAASolidFunc[BR, C]{
+		baseRenderer: ren,
+		colorFunc:    fn,
+	}
+}
+
+// Attach attaches a base renderer to this scanline renderer.
+func (r *RendererScanlineAASolidFunc[BR, C]) Attach(ren BR) {
+	r.baseRenderer = ren
+}
+
+// SetColorFunc sets the callback choosing the color of each span.
+func (r *RendererScanlineAASolidFunc[BR, C]) SetColorFunc(fn SpanColorFunc[C]) {
+	r.colorFunc = fn
+}
+
+// ColorFunc returns the callback choosing the color of each span.
+func (r *RendererScanlineAASolidFunc[BR, C]) ColorFunc() SpanColorFunc[C] {
+	return r.colorFunc
+}
+
+// SetColor paints every span in color, replacing the callback.
+func (r *RendererScanlineAASolidFunc[BR, C]) SetColor(color C) {
+	r.colorFunc = func(x, y, length int) C { return color }
+}
+
+// Prepare is called before rendering begins.
+// For solid color rendering, no preparation is needed.
+func (r *RendererScanlineAASolidFunc[BR, C]) Prepare() {
+	// Nothing to prepare for solid color rendering
+}
+
+// Render renders a single scanline with the callback's colors.
+func (r *RendererScanlineAASolidFunc[BR, C]) Render(sl ScanlineInterface) {
+	RenderScanlineAASolidFunc(sl, r.baseRenderer, r.colorFunc)
+}
+
+// BaseRenderer returns the underlying base renderer.
+func (r *RendererScanlineAASolidFunc[BR, C]) BaseRenderer() BR {
+	return r.baseRenderer
+}
+
+// RenderScanlineAASolidFunc renders a single anti-aliased scanline like
+// RenderScanlineAASolid, asking fn for the color of each span. Spans of
+// constant coverage are passed to fn with their full length.
+func RenderScanlineAASolidFunc[C any](sl ScanlineInterface, ren BaseRendererInterface[C], fn SpanColorFunc[C]) {
+	if fn == nil {
+		return
+	}
+	y := sl.Y()
+	numSpans := sl.NumSpans()
+	iter := sl.BeginIterator()
+
+	for i := 0; i < numSpans; i++ {
+		span := iter.GetSpan()
+		x := span.X
+
+		if span.Len > 0 {
+			ren.BlendSolidHspan(x, y, span.Len, fn(x, y, span.Len), span.Covers)
+		} else {
+			endX := x - span.Len - 1
+			ren.BlendHline(x, y, endX, fn(x, y, -span.Len), span.Covers[0])
+		}
+
+		if i < numSpans-1 {
+			iter.Next()
+		}
+	}
+}
+
+// RenderScanlinesAASolidFunc renders all anti-aliased scanlines from a
+// rasterizer like RenderScanlinesAASolid, asking fn for the color of each
+// span.
+func RenderScanlinesAASolidFunc[C any](ras RasterizerInterface, sl ScanlineInterface, ren BaseRendererInterface[C], fn SpanColorFunc[C]) {
+	if fn == nil || !ras.RewindScanlines() {
+		return
+	}
+
+	sl.Reset(ras.MinX(), ras.MaxX())
+	for ras.SweepScanline(sl) {
+		RenderScanlineAASolidFunc(sl, ren, fn)
+	}
+}
+
+// Ensure RendererScanlineAASolidFunc implements RendererInterface
+var _ RendererInterface[any] = (*RendererScanlineAASolidFunc[BaseRendererInterface[any], any])(nil)
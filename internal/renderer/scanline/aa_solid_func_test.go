@@ -0,0 +1,77 @@
+package scanline
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+func TestRendererScanlineAASolidFunc(t *testing.T) {
+	renderer := &MockBaseRenderer[string]{}
+	ramp := func(x, y, length int) string { return fmt.Sprintf("row%d", y) }
+
+	scanline1 := &MockScanline{
+		y:        0,
+		numSpans: 2,
+		spans: []SpanData{
+			{X: 0, Len: 2, Covers: []basics.Int8u{255, 128}},
+			{X: 4, Len: -3, Covers: []basics.Int8u{200}},
+		},
+	}
+	scanline2 := &MockScanline{
+		y:        1,
+		numSpans: 1,
+		spans:    []SpanData{{X: 2, Len: 3, Covers: []basics.Int8u{128, 128, 128}}},
+	}
+	rasterizer := &MockRasterizerWithScanlines{
+		MockRasterizer: MockRasterizer{minX: 0, maxX: 10},
+		scanlines:      []*MockScanline{scanline1, scanline2},
+	}
+
+	ren := NewRendererScanlineAASolidFunc(renderer, SpanColorFunc[string](ramp))
+	RenderScanlines[string](rasterizer, &MockScanline{}, ren)
+
+	if len(renderer.solidHspanCalls) != 2 || len(renderer.hlineCalls) != 1 {
+		t.Fatalf("got %d hspan and %d hline calls, want 2 and 1", len(renderer.solidHspanCalls), len(renderer.hlineCalls))
+	}
+	if c := renderer.solidHspanCalls[0].Color; c != "row0" {
+		t.Errorf("first span color = %q, want row0", c)
+	}
+	if c := renderer.hlineCalls[0]; c.Color != "row0" || c.X2 != 6 {
+		t.Errorf("solid span = %+v, want row0 up to x 6", c)
+	}
+	if c := renderer.solidHspanCalls[1].Color; c != "row1" {
+		t.Errorf("second scanline color = %q, want row1", c)
+	}
+
+	// SetColor replaces the callback with a constant.
+	ren.SetColor("red")
+	renderer.solidHspanCalls = nil
+	ren.Render(scanline2)
+	if c := renderer.solidHspanCalls[0].Color; c != "red" {
+		t.Errorf("color after SetColor = %q, want red", c)
+	}
+}
+
+func TestRenderScanlinesAASolidFuncSpanLength(t *testing.T) {
+	renderer := &MockBaseRenderer[int]{}
+	scanline := &MockScanline{
+		y:        3,
+		numSpans: 1,
+		spans:    []SpanData{{X: 5, Len: -4, Covers: []basics.Int8u{255}}},
+	}
+	rasterizer := &MockRasterizerWithScanlines{
+		MockRasterizer: MockRasterizer{minX: 0, maxX: 10},
+		scanlines:      []*MockScanline{scanline},
+	}
+
+	var got [3]int
+	RenderScanlinesAASolidFunc(rasterizer, &MockScanline{}, renderer, func(x, y, length int) int {
+		got = [3]int{x, y, length}
+		return 0
+	})
+	if got != [3]int{5, 3, 4} {
+		t.Errorf("callback got x, y, length = %v, want [5 3 4]", got)
+	}
+}
@@ -3,7 +3,8 @@
 // These renderers consume spans produced by a rasterizer/scanline pair and
 // write them through a base renderer into a pixel format. The package provides:
 //
-//   - anti-aliased solid-color scanline renderers
+//   - anti-aliased solid-color scanline renderers, with a fixed color or one
+//     chosen per span by a callback
 //   - anti-aliased generated-span renderers for gradients and image filters
 //   - binary scanline renderers
 //   - helper functions such as RenderScanlines and RenderAllPaths that match the
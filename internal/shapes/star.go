@@ -0,0 +1,76 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+// Star generates a closed star outline: points tips at the outer radius
+// alternating with as many inner vertices at the inner radius. With the
+// inner radius equal to the outer radius times cos(pi/points), the inner
+// vertices fall on the edges and the outline is a regular polygon; see
+// NewRegularPolygon. The first tip points up (towards negative y), rotated by
+// angle radians, and the vertices run in the same direction as Ellipse's.
+type Star struct {
+	x, y   float64 // Center
+	r1, r2 float64 // Outer and inner radius
+	angle  float64 // Rotation of the first tip from straight up
+	points uint32  // Number of tips
+	inner  bool    // Whether inner vertices are emitted
+	step   uint32  // Current step during vertex generation
+}
+
+// NewStar creates a star with the given number of tips, outer radius r1 and
+// inner radius r2, centered at (x, y). Fewer than 2 points produce no
+// vertices.
+func NewStar(x, y, r1, r2 float64, points uint32, angle float64) *Star {
+	return &Star{x: x, y: y, r1: r1, r2: r2, angle: angle, points: points, inner: true}
+}
+
+// NewRegularPolygon creates a regular polygon with the given number of
+// sides and circumradius r, centered at (x, y), with its first vertex at the
+// top. Fewer than 3 sides produce no vertices.
+func NewRegularPolygon(x, y, r float64, sides uint32, angle float64) *Star {
+	if sides < 3 {
+		sides = 0
+	}
+	return &Star{x: x, y: y, r1: r, r2: r, angle: angle, points: sides}
+}
+
+// Rewind resets vertex generation. The pathID parameter is ignored.
+func (s *Star) Rewind(pathID uint32) {
+	s.step = 0
+}
+
+// Vertex generates the next vertex of the outline.
+func (s *Star) Vertex(x, y *float64) basics.PathCommand {
+	if s.points < 2 {
+		return basics.PathCmdStop
+	}
+	n := s.points
+	if s.inner {
+		n *= 2
+	}
+	if s.step == n {
+		s.step++
+		return basics.PathCommand(uint32(basics.PathCmdEndPoly) | uint32(basics.PathFlagsClose) | uint32(basics.PathFlagsCCW))
+	}
+	if s.step > n {
+		return basics.PathCmdStop
+	}
+
+	r := s.r1
+	if s.inner && s.step%2 == 1 {
+		r = s.r2
+	}
+	a := s.angle - basics.Pi/2 + float64(s.step)*2*basics.Pi/float64(n)
+	*x = s.x + math.Cos(a)*r
+	*y = s.y + math.Sin(a)*r
+
+	s.step++
+	if s.step == 1 {
+		return basics.PathCmdMoveTo
+	}
+	return basics.PathCmdLineTo
+}
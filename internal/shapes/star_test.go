@@ -0,0 +1,71 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+type shapeVertex struct {
+	x, y float64
+	cmd  basics.PathCommand
+}
+
+func collectShape(vs interface {
+	Rewind(uint32)
+	Vertex(x, y *float64) basics.PathCommand
+},
+) []shapeVertex {
+	var out []shapeVertex
+	vs.Rewind(0)
+	for {
+		var x, y float64
+		cmd := vs.Vertex(&x, &y)
+		if basics.IsStop(cmd) {
+			return out
+		}
+		out = append(out, shapeVertex{x, y, cmd})
+	}
+}
+
+func TestStar(t *testing.T) {
+	out := collectShape(NewStar(10, 10, 8, 3, 5, 0))
+	if len(out) != 11 {
+		t.Fatalf("got %d vertices, want 10 and end-poly", len(out))
+	}
+	if out[0].cmd != basics.PathCmdMoveTo || math.Abs(out[0].x-10) > 1e-9 || math.Abs(out[0].y-2) > 1e-9 {
+		t.Errorf("first tip = %+v, want move-to (10, 2)", out[0])
+	}
+	for i, v := range out[:10] {
+		want := 8.0
+		if i%2 == 1 {
+			want = 3
+		}
+		if r := math.Hypot(v.x-10, v.y-10); math.Abs(r-want) > 1e-9 {
+			t.Errorf("vertex %d at radius %g, want %g", i, r, want)
+		}
+	}
+	if end := out[10].cmd; !basics.IsEndPoly(end) || !basics.IsClosed(uint32(end)) {
+		t.Errorf("last command = %v, want closed end-poly", end)
+	}
+	if out := collectShape(NewStar(0, 0, 1, 1, 1, 0)); len(out) != 0 {
+		t.Errorf("1-point star produced %d vertices", len(out))
+	}
+}
+
+func TestRegularPolygon(t *testing.T) {
+	out := collectShape(NewRegularPolygon(0, 0, 10, 4, math.Pi/4))
+	if len(out) != 5 {
+		t.Fatalf("got %d vertices, want 4 and end-poly", len(out))
+	}
+	// Rotated by 45 degrees the square is axis aligned.
+	for _, v := range out[:4] {
+		if math.Abs(math.Abs(v.x)-10/math.Sqrt2) > 1e-9 || math.Abs(math.Abs(v.y)-10/math.Sqrt2) > 1e-9 {
+			t.Errorf("vertex (%g, %g) not on the axis-aligned square", v.x, v.y)
+		}
+	}
+	if out := collectShape(NewRegularPolygon(0, 0, 10, 2, 0)); len(out) != 0 {
+		t.Errorf("2-sided polygon produced %d vertices", len(out))
+	}
+}
@@ -0,0 +1,87 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+// Superellipse generates a closed superellipse |x/rx|^e + |y/ry|^e = 1. An
+// exponent of 2 gives an ellipse, 4 a squircle, larger values approach a
+// rectangle and values below 1 give concave, astroid-like stars. The
+// vertex count adapts to the size and approximation scale like Ellipse.
+type Superellipse struct {
+	x, y   float64 // Center
+	rx, ry float64 // Radii
+	exp    float64 // Exponent
+	scale  float64 // Approximation scale factor
+	num    uint32  // Number of steps, a multiple of 4
+	step   uint32  // Current step during vertex generation
+}
+
+// NewSuperellipse creates a superellipse centered at (x, y). Exponents not
+// above 0 are treated as 2.
+func NewSuperellipse(x, y, rx, ry, exp float64) *Superellipse {
+	if !(exp > 0) {
+		exp = 2
+	}
+	s := &Superellipse{x: x, y: y, rx: rx, ry: ry, exp: exp, scale: 1}
+	s.calcNumSteps()
+	return s
+}
+
+// SetApproximationScale sets the approximation scale factor and recalculates
+// the number of steps.
+func (s *Superellipse) SetApproximationScale(scale float64) {
+	s.scale = scale
+	s.calcNumSteps()
+}
+
+// ApproximationScale returns the approximation scale factor.
+func (s *Superellipse) ApproximationScale() float64 {
+	return s.scale
+}
+
+// Rewind resets vertex generation. The pathID parameter is ignored.
+func (s *Superellipse) Rewind(pathID uint32) {
+	s.step = 0
+}
+
+// Vertex generates the next vertex of the outline, counter-clockwise in
+// y-up terms like Ellipse.
+func (s *Superellipse) Vertex(x, y *float64) basics.PathCommand {
+	if s.step == s.num {
+		s.step++
+		return basics.PathCommand(uint32(basics.PathCmdEndPoly) | uint32(basics.PathFlagsClose) | uint32(basics.PathFlagsCCW))
+	}
+	if s.step > s.num {
+		return basics.PathCmdStop
+	}
+
+	t := float64(s.step) / float64(s.num) * 2 * basics.Pi
+	p := 2 / s.exp
+	c, sn := math.Cos(t), math.Sin(t)
+	*x = s.x + math.Copysign(math.Pow(math.Abs(c), p), c)*s.rx
+	*y = s.y + math.Copysign(math.Pow(math.Abs(sn), p), sn)*s.ry
+
+	s.step++
+	if s.step == 1 {
+		return basics.PathCmdMoveTo
+	}
+	return basics.PathCmdLineTo
+}
+
+// calcNumSteps takes the step count of an ellipse of the same size and
+// doubles it for exponents other than 2, whose sharper bends need more
+// vertices. It is rounded up to a multiple of 4 to keep the outline
+// symmetric.
+func (s *Superellipse) calcNumSteps() {
+	ra := (math.Abs(s.rx) + math.Abs(s.ry)) * 0.5
+	da := math.Acos(ra/(ra+0.125/s.scale)) * 2
+	num := 2 * basics.Pi / da
+	if s.exp != 2 {
+		num *= 2
+	}
+	n := uint32(math.Ceil(math.Max(num, 8)))
+	s.num = (n + 3) &^ 3
+}
@@ -0,0 +1,31 @@
+package shapes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSuperellipse(t *testing.T) {
+	for _, exp := range []float64{1, 2, 4, 10} {
+		s := NewSuperellipse(0, 0, 20, 10, exp)
+		out := collectShape(s)
+		if n := len(out) - 1; n%4 != 0 || n < 8 {
+			t.Errorf("exp %g: %d vertices, want a multiple of 4", exp, n)
+		}
+		for _, v := range out[:len(out)-1] {
+			if f := math.Pow(math.Abs(v.x/20), exp) + math.Pow(math.Abs(v.y/10), exp); math.Abs(f-1) > 1e-9 {
+				t.Errorf("exp %g: vertex (%g, %g) off the curve: %g", exp, v.x, v.y, f)
+			}
+		}
+	}
+
+	coarse := len(collectShape(NewSuperellipse(0, 0, 50, 50, 4)))
+	s := NewSuperellipse(0, 0, 50, 50, 4)
+	s.SetApproximationScale(4)
+	if fine := len(collectShape(s)); fine <= coarse {
+		t.Errorf("approximation scale 4 gave %d vertices, scale 1 %d", fine, coarse)
+	}
+	if NewSuperellipse(0, 0, 1, 1, -3).exp != 2 {
+		t.Error("negative exponent not replaced by 2")
+	}
+}
@@ -3,17 +3,32 @@
 // by scanline renderers for anti-aliased rendering with varying colors.
 package span
 
+// spanAlign is the granularity the allocator grows in, as in AGG's
+// span_allocator, so slowly widening spans do not reallocate every scanline.
+const spanAlign = 256
+
+// SpanAllocatorStats reports how a SpanAllocator has been used since it was
+// created or its statistics were last reset.
+type SpanAllocatorStats struct {
+	Allocations int // calls to Allocate
+	Grows       int // reallocations of the buffer, by Allocate or Reserve
+	MaxSpan     int // longest span requested
+	Capacity    int // current buffer capacity in colors
+}
+
 // SpanAllocator provides basic span allocation functionality.
 // This is a simple implementation of the SpanAllocatorInterface
-// that allocates color arrays for scanline rendering.
+// that allocates color arrays for scanline rendering. The buffer is kept
+// between calls, so one allocator can serve any number of renders.
 type SpanAllocator[C SpanColorType] struct {
 	buffer []C // Reusable buffer for color allocation
+	stats  SpanAllocatorStats
 }
 
 // NewSpanAllocator creates a new span allocator.
 func NewSpanAllocator[C SpanColorType]() *SpanAllocator[C] {
 	return &SpanAllocator[C]{
-		buffer: make([]C, 0, 256), // Start with reasonable capacity
+		buffer: make([]C, 0, spanAlign), // Start with reasonable capacity
 	}
 }
 
@@ -21,18 +36,43 @@ func NewSpanAllocator[C SpanColorType]() *SpanAllocator[C] {
 // Returns a slice that can hold 'len' color values.
 // The returned slice is valid until the next call to Allocate.
 func (sa *SpanAllocator[C]) Allocate(length int) []C {
+	sa.stats.Allocations++
+	sa.stats.MaxSpan = max(sa.stats.MaxSpan, length)
+
 	// Ensure buffer has enough capacity
-	if cap(sa.buffer) < length {
-		sa.buffer = make([]C, length, length*2)
-	} else {
-		sa.buffer = sa.buffer[:length]
-	}
+	sa.Reserve(length)
+	sa.buffer = sa.buffer[:length]
 
 	// Clear the buffer (set all elements to zero value)
-	var zero C
-	for i := range sa.buffer {
-		sa.buffer[i] = zero
-	}
+	clear(sa.buffer)
 
 	return sa.buffer
 }
+
+// Reserve grows the buffer to hold at least n colors, rounded up to a
+// multiple of 256. Reserving the widest expected span up front, such as the
+// canvas width, avoids reallocations while rendering.
+func (sa *SpanAllocator[C]) Reserve(n int) {
+	if n <= cap(sa.buffer) {
+		return
+	}
+	sa.buffer = make([]C, 0, (n+spanAlign-1)/spanAlign*spanAlign)
+	sa.stats.Grows++
+}
+
+// Cap returns the number of colors the buffer holds without reallocating.
+func (sa *SpanAllocator[C]) Cap() int {
+	return cap(sa.buffer)
+}
+
+// Stats returns the usage statistics.
+func (sa *SpanAllocator[C]) Stats() SpanAllocatorStats {
+	s := sa.stats
+	s.Capacity = cap(sa.buffer)
+	return s
+}
+
+// ResetStats clears the statistics and keeps the buffer.
+func (sa *SpanAllocator[C]) ResetStats() {
+	sa.stats = SpanAllocatorStats{}
+}
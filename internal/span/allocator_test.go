@@ -59,3 +59,32 @@ func TestSpanAllocator_ZeroLength(t *testing.T) {
 		t.Errorf("Expected length 0, got %d", len(colors))
 	}
 }
+
+func TestSpanAllocator_ReserveAndStats(t *testing.T) {
+	alloc := NewSpanAllocator[color.RGBA8[color.Linear]]()
+
+	alloc.Reserve(300)
+	if alloc.Cap() != 512 {
+		t.Fatalf("Reserve(300) should round up to 512, got %d", alloc.Cap())
+	}
+	alloc.Reserve(100)
+	if alloc.Cap() != 512 {
+		t.Fatalf("Reserve should never shrink, got %d", alloc.Cap())
+	}
+
+	for _, n := range []int{10, 500, 40} {
+		alloc.Allocate(n)
+	}
+	alloc.Allocate(600)
+
+	s := alloc.Stats()
+	want := SpanAllocatorStats{Allocations: 4, Grows: 2, MaxSpan: 600, Capacity: 768}
+	if s != want {
+		t.Fatalf("stats = %+v, want %+v", s, want)
+	}
+
+	alloc.ResetStats()
+	if s := alloc.Stats(); s != (SpanAllocatorStats{Capacity: 768}) {
+		t.Fatalf("ResetStats should keep only the capacity, got %+v", s)
+	}
+}
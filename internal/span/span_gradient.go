@@ -302,6 +302,20 @@ func (g GradientConic) Calculate(x, y, d2 int) int {
 	return int(basics.URound(math.Abs(math.Atan2(float64(y), float64(x))) * float64(d2) / math.Pi))
 }
 
+// GradientSweep implements a full-turn sweep gradient: the angle from the
+// positive x axis, increasing towards positive y, mapped from 0..2π onto
+// 0..d2. Unlike GradientConic it is not mirrored across the x axis, which
+// matches CSS conic-gradient.
+type GradientSweep struct{}
+
+func (g GradientSweep) Calculate(x, y, d2 int) int {
+	a := math.Atan2(float64(y), float64(x))
+	if a < 0 {
+		a += 2 * math.Pi
+	}
+	return int(a * float64(d2) / (2 * math.Pi))
+}
+
 // Gradient wrapper adaptors for repeat and reflect modes
 
 // GradientRepeatAdaptor wraps a gradient function to repeat beyond the gradient range.
@@ -507,6 +521,18 @@ func NewLinearGradientFromLUT[InterpolatorT SpanInterpolatorInterface](
 		interpolator, gradientFunc, colorFunc, d1, d2)
 }
 
+// NewSweepGradientFromLUT creates a sweep gradient span generator using a
+// pre-built 256-entry color lookup table.
+func NewSweepGradientFromLUT[InterpolatorT SpanInterpolatorInterface](
+	interpolator InterpolatorT,
+	lut []color.RGBA8[color.Linear],
+	d1, d2 float64,
+) *SpanGradient[color.RGBA8[color.Linear], InterpolatorT, GradientSweep, *GradientPrebuiltColorRGBA8[color.Linear]] {
+	colorFunc := NewGradientPrebuiltColorRGBA8[color.Linear](lut)
+	return NewSpanGradient[color.RGBA8[color.Linear], InterpolatorT, GradientSweep, *GradientPrebuiltColorRGBA8[color.Linear]](
+		interpolator, GradientSweep{}, colorFunc, d1, d2)
+}
+
 // NewRadialGradientFromLUT creates a radial gradient span generator using a pre-built
 // 256-entry color lookup table, matching AGG's C++ gradient rendering path.
 func NewRadialGradientFromLUT[InterpolatorT SpanInterpolatorInterface](
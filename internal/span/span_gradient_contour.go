@@ -43,6 +43,9 @@ type GradientContour struct {
 	frame  int
 	d1     float64
 	d2     float64
+
+	// originX and originY are the path coordinates of buffer pixel (0, 0).
+	originX, originY float64
 }
 
 type contourGrayRenderer struct {
@@ -190,10 +193,14 @@ func (gc *GradientContour) ContourCreate(ps *path.PathStorage) []uint8 {
 	if ps == nil {
 		return nil
 	}
+	return gc.ContourCreateFrom(NewPathVertexAdapter(ps))
+}
 
-	// Create adapter and convert path to curves
-	adapter := NewPathVertexAdapter(ps)
-	convCurve := conv.NewConvCurve(adapter)
+// ContourCreateFrom is ContourCreate for any vertex source, such as a path
+// storage of another vertex container.
+func (gc *GradientContour) ContourCreateFrom(vs conv.VertexSource) []uint8 {
+	// Convert path to curves
+	convCurve := conv.NewConvCurve(vs)
 
 	// Get bounding rectangle
 	x1, y1, x2, y2, ok := boundingRectSingle(convCurve, 0)
@@ -214,6 +221,9 @@ func (gc *GradientContour) ContourCreate(ps *path.PathStorage) []uint8 {
 		bwBuffer[i] = 255
 	}
 
+	gc.originX = x1 - float64(gc.frame)
+	gc.originY = y1 - float64(gc.frame)
+
 	// Setup transformation matrix
 	mtx := transform.NewTransAffine()
 	mtx = mtx.Multiply(transform.NewTransAffineTranslation(-x1+float64(gc.frame), -y1+float64(gc.frame)))
@@ -401,6 +411,12 @@ func (gc *GradientContour) ContourHeight() int {
 	return gc.height
 }
 
+// ContourOrigin returns the path coordinates of the top-left pixel of the
+// contour buffer, the bounding box corner of the path minus the frame.
+func (gc *GradientContour) ContourOrigin() (x, y float64) {
+	return gc.originX, gc.originY
+}
+
 // SetD1 sets the start distance parameter.
 func (gc *GradientContour) SetD1(d float64) {
 	gc.d1 = d
@@ -99,6 +99,24 @@ func TestGradientShapeFunctions(t *testing.T) {
 			t.Errorf("Conic (0,1): got %d, want ~%d", result2, expected2)
 		}
 	})
+
+	t.Run("GradientSweep", func(t *testing.T) {
+		g := GradientSweep{}
+
+		// A full turn maps onto 0..d2 without mirroring.
+		cases := []struct{ x, y, want int }{
+			{1000, 0, 0},
+			{0, 1000, 25},
+			{-1000, 0, 50},
+			{0, -1000, 75},
+			{1000, -1, 99},
+		}
+		for _, c := range cases {
+			if got := g.Calculate(c.x, c.y, 100); absInt(got-c.want) > 1 {
+				t.Errorf("Sweep (%d,%d): got %d, want ~%d", c.x, c.y, got, c.want)
+			}
+		}
+	})
 }
 
 func TestGradientRadialFocus(t *testing.T) {
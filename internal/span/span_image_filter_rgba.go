@@ -98,6 +98,7 @@ func (sif *SpanImageFilterRGBABilinear[Source, Interpolator]) Generate(span []co
 	}
 
 	sif.base.interpolator.Begin(float64(x)+sif.base.FilterDxDbl(), float64(y)+sif.base.FilterDyDbl(), length)
+	orderType := sif.base.source.OrderType()
 
 	for i := 0; i < length; i++ {
 		xHr, yHr := sif.base.interpolator.Coordinates()
@@ -124,40 +125,40 @@ func (sif *SpanImageFilterRGBABilinear[Source, Interpolator]) Generate(span []co
 		fgPtr := sif.base.source.Span(xLr, yLr, 2)
 		weight := (image.ImageSubpixelScale - xHr) * (image.ImageSubpixelScale - yHr)
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Top-right sample
 		fgPtr = sif.base.source.NextX()
 		weight = xHr * (image.ImageSubpixelScale - yHr)
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Bottom-left sample
 		fgPtr = sif.base.source.NextY()
 		weight = (image.ImageSubpixelScale - xHr) * yHr
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Bottom-right sample
 		fgPtr = sif.base.source.NextX()
 		weight = xHr * yHr
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Downshift to get final values
@@ -286,19 +287,19 @@ func (sif *SpanImageFilterRGBABilinearClip[Source, Interpolator]) Generate(span
 			// Top-left sample
 			weight := (image.ImageSubpixelScale - xHr) * (image.ImageSubpixelScale - yHr)
 			if pixelOffset+3 < len(row) {
-				fg[0] += weight * int(row[pixelOffset])
-				fg[1] += weight * int(row[pixelOffset+1])
-				fg[2] += weight * int(row[pixelOffset+2])
-				fg[3] += weight * int(row[pixelOffset+3])
+				fg[0] += weight * int(row[pixelOffset+orderType.R])
+				fg[1] += weight * int(row[pixelOffset+orderType.G])
+				fg[2] += weight * int(row[pixelOffset+orderType.B])
+				fg[3] += weight * int(row[pixelOffset+orderType.A])
 			}
 
 			// Top-right sample
 			weight = xHr * (image.ImageSubpixelScale - yHr)
 			if pixelOffset+7 < len(row) {
-				fg[0] += weight * int(row[pixelOffset+4])
-				fg[1] += weight * int(row[pixelOffset+5])
-				fg[2] += weight * int(row[pixelOffset+6])
-				fg[3] += weight * int(row[pixelOffset+7])
+				fg[0] += weight * int(row[pixelOffset+4+orderType.R])
+				fg[1] += weight * int(row[pixelOffset+4+orderType.G])
+				fg[2] += weight * int(row[pixelOffset+4+orderType.B])
+				fg[3] += weight * int(row[pixelOffset+4+orderType.A])
 			}
 
 			// Bottom row samples
@@ -309,19 +310,19 @@ func (sif *SpanImageFilterRGBABilinearClip[Source, Interpolator]) Generate(span
 				// Bottom-left sample
 				weight = (image.ImageSubpixelScale - xHr) * yHr
 				if pixelOffset+3 < len(row) {
-					fg[0] += weight * int(row[pixelOffset])
-					fg[1] += weight * int(row[pixelOffset+1])
-					fg[2] += weight * int(row[pixelOffset+2])
-					fg[3] += weight * int(row[pixelOffset+3])
+					fg[0] += weight * int(row[pixelOffset+orderType.R])
+					fg[1] += weight * int(row[pixelOffset+orderType.G])
+					fg[2] += weight * int(row[pixelOffset+orderType.B])
+					fg[3] += weight * int(row[pixelOffset+orderType.A])
 				}
 
 				// Bottom-right sample
 				weight = xHr * yHr
 				if pixelOffset+7 < len(row) {
-					fg[0] += weight * int(row[pixelOffset+4])
-					fg[1] += weight * int(row[pixelOffset+5])
-					fg[2] += weight * int(row[pixelOffset+6])
-					fg[3] += weight * int(row[pixelOffset+7])
+					fg[0] += weight * int(row[pixelOffset+4+orderType.R])
+					fg[1] += weight * int(row[pixelOffset+4+orderType.G])
+					fg[2] += weight * int(row[pixelOffset+4+orderType.B])
+					fg[3] += weight * int(row[pixelOffset+4+orderType.A])
 				}
 			}
 
@@ -453,6 +454,7 @@ func (sif *SpanImageFilterRGBA2x2[Source, Interpolator]) Generate(span []color.R
 	}
 
 	sif.base.interpolator.Begin(float64(x)+sif.base.FilterDxDbl(), float64(y)+sif.base.FilterDyDbl(), length)
+	orderType := sif.base.source.OrderType()
 
 	// Get weight array from filter
 	weightArray := sif.base.filter.WeightArray()
@@ -483,10 +485,10 @@ func (sif *SpanImageFilterRGBA2x2[Source, Interpolator]) Generate(span []color.R
 			int(weightArray[yHr+image.ImageSubpixelScale+offset]) +
 			image.ImageFilterScale/2) >> image.ImageFilterShift
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Sample 2 (top-right)
@@ -495,10 +497,10 @@ func (sif *SpanImageFilterRGBA2x2[Source, Interpolator]) Generate(span []color.R
 			int(weightArray[yHr+image.ImageSubpixelScale+offset]) +
 			image.ImageFilterScale/2) >> image.ImageFilterShift
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Sample 3 (bottom-left)
@@ -507,10 +509,10 @@ func (sif *SpanImageFilterRGBA2x2[Source, Interpolator]) Generate(span []color.R
 			int(weightArray[yHr+offset]) +
 			image.ImageFilterScale/2) >> image.ImageFilterShift
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Sample 4 (bottom-right)
@@ -519,10 +521,10 @@ func (sif *SpanImageFilterRGBA2x2[Source, Interpolator]) Generate(span []color.R
 			int(weightArray[yHr+offset]) +
 			image.ImageFilterScale/2) >> image.ImageFilterShift
 		if len(fgPtr) >= 4 {
-			fg[0] += weight * int(fgPtr[0])
-			fg[1] += weight * int(fgPtr[1])
-			fg[2] += weight * int(fgPtr[2])
-			fg[3] += weight * int(fgPtr[3])
+			fg[0] += weight * int(fgPtr[orderType.R])
+			fg[1] += weight * int(fgPtr[orderType.G])
+			fg[2] += weight * int(fgPtr[orderType.B])
+			fg[3] += weight * int(fgPtr[orderType.A])
 		}
 
 		// Downshift results
@@ -588,6 +590,7 @@ func (sif *SpanImageFilterRGBA[Source, Interpolator]) Generate(span []color.RGBA
 	}
 
 	sif.base.interpolator.Begin(float64(x)+sif.base.FilterDxDbl(), float64(y)+sif.base.FilterDyDbl(), length)
+	orderType := sif.base.source.OrderType()
 
 	diameter := sif.base.filter.Diameter()
 	start := sif.base.filter.Start()
@@ -624,10 +627,10 @@ func (sif *SpanImageFilterRGBA[Source, Interpolator]) Generate(span []color.RGBA
 				weight := (int(weightY)*int(weightArray[xHr]) + image.ImageFilterScale/2) >> image.ImageFilterShift
 
 				if len(fgPtr) >= 4 {
-					fg[0] += weight * int(fgPtr[0])
-					fg[1] += weight * int(fgPtr[1])
-					fg[2] += weight * int(fgPtr[2])
-					fg[3] += weight * int(fgPtr[3])
+					fg[0] += weight * int(fgPtr[orderType.R])
+					fg[1] += weight * int(fgPtr[orderType.G])
+					fg[2] += weight * int(fgPtr[orderType.B])
+					fg[3] += weight * int(fgPtr[orderType.A])
 				}
 
 				xCount--
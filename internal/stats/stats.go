@@ -0,0 +1,83 @@
+// Package stats holds the optional counters of the rendering pipeline.
+//
+// Counting is off by default; the instrumented code paths then only pay for
+// one atomic load. The counters are global, so concurrent contexts add up.
+package stats
+
+import "sync/atomic"
+
+var (
+	enabled   atomic.Bool
+	cells     atomic.Uint64
+	scanlines atomic.Uint64
+	spans     atomic.Uint64
+	pixels    atomic.Uint64
+)
+
+// Snapshot is a copy of the pipeline counters.
+type Snapshot struct {
+	CellsAllocated uint64 `json:"cells_allocated"` // Rasterizer cells accumulated for sorted paths
+	ScanlinesSwept uint64 `json:"scanlines_swept"` // Non-empty scanlines produced by the rasterizers
+	SpansBlended   uint64 `json:"spans_blended"`   // Clipped spans written by renderer_base
+	PixelsTouched  uint64 `json:"pixels_touched"`  // Pixels covered by those spans
+}
+
+// Sub returns the counts accumulated since prev was taken.
+func (s Snapshot) Sub(prev Snapshot) Snapshot {
+	return Snapshot{
+		CellsAllocated: s.CellsAllocated - prev.CellsAllocated,
+		ScanlinesSwept: s.ScanlinesSwept - prev.ScanlinesSwept,
+		SpansBlended:   s.SpansBlended - prev.SpansBlended,
+		PixelsTouched:  s.PixelsTouched - prev.PixelsTouched,
+	}
+}
+
+// SetEnabled turns counting on or off. The counters keep their values.
+func SetEnabled(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether counting is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Read returns the current counters.
+func Read() Snapshot {
+	return Snapshot{
+		CellsAllocated: cells.Load(),
+		ScanlinesSwept: scanlines.Load(),
+		SpansBlended:   spans.Load(),
+		PixelsTouched:  pixels.Load(),
+	}
+}
+
+// Reset sets all counters to zero.
+func Reset() {
+	cells.Store(0)
+	scanlines.Store(0)
+	spans.Store(0)
+	pixels.Store(0)
+}
+
+// AddCells records n rasterizer cells.
+func AddCells(n int) {
+	if enabled.Load() {
+		cells.Add(uint64(n))
+	}
+}
+
+// AddScanline records one swept scanline.
+func AddScanline() {
+	if enabled.Load() {
+		scanlines.Add(1)
+	}
+}
+
+// AddSpan records one span of n pixels written to a pixel format.
+func AddSpan(n int) {
+	if enabled.Load() {
+		spans.Add(1)
+		pixels.Add(uint64(n))
+	}
+}
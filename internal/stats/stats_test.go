@@ -0,0 +1,39 @@
+package stats
+
+import "testing"
+
+func TestCounters(t *testing.T) {
+	defer SetEnabled(false)
+	Reset()
+
+	AddCells(5)
+	AddScanline()
+	AddSpan(7)
+	if got := Read(); got != (Snapshot{}) {
+		t.Fatalf("disabled counters changed: %+v", got)
+	}
+
+	SetEnabled(true)
+	if !Enabled() {
+		t.Fatal("Enabled() = false after SetEnabled(true)")
+	}
+	AddCells(5)
+	AddScanline()
+	AddSpan(7)
+	AddSpan(3)
+	want := Snapshot{CellsAllocated: 5, ScanlinesSwept: 1, SpansBlended: 2, PixelsTouched: 10}
+	before := Read()
+	if before != want {
+		t.Fatalf("Read() = %+v, want %+v", before, want)
+	}
+
+	AddSpan(4)
+	if d := Read().Sub(before); d != (Snapshot{SpansBlended: 1, PixelsTouched: 4}) {
+		t.Errorf("Sub() = %+v", d)
+	}
+
+	Reset()
+	if got := Read(); got != (Snapshot{}) {
+		t.Errorf("Read() after Reset = %+v", got)
+	}
+}
@@ -37,8 +37,14 @@ type VCGenDash struct {
 	closed        uint                      // Whether path is closed
 	status        DashStatus                // Current generator status
 	srcVertex     uint                      // Current source vertex index
+	dots          bool                      // Emit zero-length dashes as dots
 }
 
+// DotLength is the length of the segment emitted for a zero-length dash when
+// dots are enabled. It gives the stroker a direction for the caps while being
+// far below pixel resolution.
+const DotLength = 1e-6
+
 // NewVCGenDash creates a new dash vertex generator
 func NewVCGenDash() *VCGenDash {
 	return &VCGenDash{
@@ -80,6 +86,29 @@ func (d *VCGenDash) AddDash(dashLen, gapLen float64) {
 	}
 }
 
+// Dashes returns a copy of the dash array (dash and gap lengths alternating).
+func (d *VCGenDash) Dashes() []float64 {
+	return append([]float64(nil), d.dashes[:d.numDashes]...)
+}
+
+// PatternLength returns the length of one dash cycle.
+func (d *VCGenDash) PatternLength() float64 {
+	return d.totalDashLen
+}
+
+// SetDots sets whether zero-length dashes are emitted as DotLength segments
+// along the path, so round or square caps draw them as dots as in SVG. AGG's
+// vcgen_dash emits a degenerate segment that the stroker discards, which
+// remains the default.
+func (d *VCGenDash) SetDots(dots bool) {
+	d.dots = dots
+}
+
+// Dots reports whether zero-length dashes are emitted as dots.
+func (d *VCGenDash) Dots() bool {
+	return d.dots
+}
+
 // DashStart sets the dash start offset
 func (d *VCGenDash) DashStart(ds float64) {
 	d.dashStart = ds
@@ -95,6 +124,9 @@ func (d *VCGenDash) GetDashStart() float64 {
 func (d *VCGenDash) calcDashStart(ds float64) {
 	d.currDash = 0
 	d.currDashStart = 0.0
+	if d.totalDashLen <= 0 {
+		return
+	}
 	for ds > 0.0 {
 		if ds > d.dashes[d.currDash] {
 			ds -= d.dashes[d.currDash]
@@ -169,7 +201,8 @@ func (d *VCGenDash) Vertex() (x, y float64, cmd basics.PathCommand) {
 			d.Rewind(0)
 
 		case DashStatusReady:
-			if d.numDashes < 2 || d.srcVertices.Size() < 2 {
+			// A pattern without length would never advance along the path.
+			if d.numDashes < 2 || d.totalDashLen <= 0 || d.srcVertices.Size() < 2 {
 				cmd = basics.PathCmdStop
 				break
 			}
@@ -195,6 +228,7 @@ func (d *VCGenDash) Vertex() (x, y float64, cmd basics.PathCommand) {
 			}
 
 			if d.currRest > dashRest {
+				dot := d.dots && cmd == basics.PathCmdLineTo && d.dashes[d.currDash] == 0
 				d.currRest -= dashRest
 				d.currDash++
 				if d.currDash >= d.numDashes {
@@ -203,6 +237,10 @@ func (d *VCGenDash) Vertex() (x, y float64, cmd basics.PathCommand) {
 				d.currDashStart = 0.0
 				x = d.v2.X - (d.v2.X-d.v1.X)*d.currRest/d.v1.Dist
 				y = d.v2.Y - (d.v2.Y-d.v1.Y)*d.currRest/d.v1.Dist
+				if dot {
+					x += (d.v2.X - d.v1.X) * DotLength / d.v1.Dist
+					y += (d.v2.Y - d.v1.Y) * DotLength / d.v1.Dist
+				}
 			} else {
 				d.currDashStart += d.currRest
 				x = d.v2.X
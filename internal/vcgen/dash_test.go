@@ -276,6 +276,58 @@ func TestVCGenDashClosedPath(t *testing.T) {
 	}
 }
 
+// TestVCGenDashDots tests that zero-length dashes become short segments along
+// the path only when dots are enabled.
+func TestVCGenDashDots(t *testing.T) {
+	build := func(dots bool) []VCGenVertex {
+		dash := NewVCGenDash()
+		dash.AddDash(0, 10)
+		dash.SetDots(dots)
+		dash.AddVertex(0, 0, basics.PathCmdMoveTo)
+		dash.AddVertex(0, 30, basics.PathCmdLineTo)
+		return collectVCGenVertices(dash)
+	}
+
+	for _, v := range build(false) {
+		if v.cmd == basics.PathCmdLineTo && v.y != math.Trunc(v.y) {
+			t.Fatalf("default generator should emit degenerate dashes, got line_to at %v", v.y)
+		}
+	}
+
+	dots := 0
+	vertices := build(true)
+	for i, v := range vertices {
+		if v.cmd != basics.PathCmdLineTo {
+			continue
+		}
+		prev := vertices[i-1]
+		if v.x != prev.x || math.Abs(v.y-prev.y-DotLength) > 1e-12 {
+			t.Fatalf("dot %d spans (%v,%v)-(%v,%v), want DotLength along +Y", dots, prev.x, prev.y, v.x, v.y)
+		}
+		dots++
+	}
+	if dots != 3 {
+		t.Fatalf("expected 3 dots along a 30 unit line, got %d", dots)
+	}
+}
+
+// TestVCGenDashZeroLengthPattern tests that a pattern of zero total length
+// terminates instead of looping forever.
+func TestVCGenDashZeroLengthPattern(t *testing.T) {
+	dash := NewVCGenDash()
+	dash.AddDash(0, 0)
+	dash.DashStart(5)
+	dash.AddVertex(0, 0, basics.PathCmdMoveTo)
+	dash.AddVertex(10, 0, basics.PathCmdLineTo)
+
+	if vertices := collectVCGenVertices(dash); len(vertices) != 0 {
+		t.Fatalf("expected no output for a zero-length pattern, got %d vertices", len(vertices))
+	}
+	if got := dash.PatternLength(); got != 0 {
+		t.Fatalf("PatternLength() = %v, want 0", got)
+	}
+}
+
 // VCGenVertex represents a vertex from VCGenDash
 type VCGenVertex struct {
 	x, y float64
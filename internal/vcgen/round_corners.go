@@ -0,0 +1,200 @@
+package vcgen
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+// VCGenRoundCorners replaces every corner of a polyline or polygon with a
+// circular arc of a given radius, tangent to both edges. The arcs are emitted
+// as cubic Bézier curves (one per quarter turn at most), so the output keeps
+// its precision under scaling and is flattened later by conv_curve. The
+// radius is reduced at corners whose edges are too short to hold it: each
+// edge gives at most half its length to either corner. The end points of open
+// paths are left as they are.
+type VCGenRoundCorners struct {
+	radius float64
+	src    []basics.Point[float64]
+	closed bool
+
+	out    []roundCornersVertex
+	outIdx int
+	ready  bool
+}
+
+type roundCornersVertex struct {
+	x, y float64
+	cmd  basics.PathCommand
+}
+
+// roundCornersMinTurn is the smallest change of direction, in radians, that
+// counts as a corner; flatter vertices are passed through.
+const roundCornersMinTurn = 1e-6
+
+// NewVCGenRoundCorners creates a corner-rounding generator with radius 0,
+// which passes its input through.
+func NewVCGenRoundCorners() *VCGenRoundCorners {
+	return &VCGenRoundCorners{}
+}
+
+// SetRadius sets the corner radius. Negative values count as 0.
+func (g *VCGenRoundCorners) SetRadius(r float64) {
+	g.radius = math.Max(r, 0)
+}
+
+// Radius returns the corner radius.
+func (g *VCGenRoundCorners) Radius() float64 {
+	return g.radius
+}
+
+// RemoveAll clears the collected vertices.
+func (g *VCGenRoundCorners) RemoveAll() {
+	g.src = g.src[:0]
+	g.closed = false
+	g.ready = false
+}
+
+// AddVertex collects one vertex of the current sub-path. Consecutive
+// duplicate points are dropped.
+func (g *VCGenRoundCorners) AddVertex(x, y float64, cmd basics.PathCommand) {
+	g.ready = false
+	switch {
+	case basics.IsMoveTo(cmd):
+		g.src = append(g.src[:0], basics.Point[float64]{X: x, Y: y})
+	case basics.IsVertex(cmd):
+		if n := len(g.src); n > 0 && g.src[n-1].X == x && g.src[n-1].Y == y {
+			return
+		}
+		g.src = append(g.src, basics.Point[float64]{X: x, Y: y})
+	default:
+		g.closed = basics.IsClosed(uint32(cmd))
+	}
+}
+
+// PrepareSrc is called by ConvAdaptorVCGen before vertex generation starts.
+func (g *VCGenRoundCorners) PrepareSrc() {}
+
+// Rewind builds the rounded outline on the first call after new input and
+// restarts its output.
+func (g *VCGenRoundCorners) Rewind(_ uint) {
+	if !g.ready {
+		g.build()
+		g.ready = true
+	}
+	g.outIdx = 0
+}
+
+// Vertex returns the next vertex of the rounded outline.
+func (g *VCGenRoundCorners) Vertex() (x, y float64, cmd basics.PathCommand) {
+	if !g.ready {
+		g.Rewind(0)
+	}
+	if g.outIdx >= len(g.out) {
+		return 0, 0, basics.PathCmdStop
+	}
+	v := g.out[g.outIdx]
+	g.outIdx++
+	return v.x, v.y, v.cmd
+}
+
+func (g *VCGenRoundCorners) build() {
+	g.out = g.out[:0]
+	src := g.src
+	closed := g.closed
+	// A closed polygon often repeats its first point as its last.
+	if closed && len(src) > 1 && src[0] == src[len(src)-1] {
+		src = src[:len(src)-1]
+	}
+	n := len(src)
+	if n < 2 {
+		return
+	}
+	if n < 3 {
+		closed = false
+	}
+
+	emit := func(x, y float64, cmd basics.PathCommand) {
+		g.out = append(g.out, roundCornersVertex{x, y, cmd})
+	}
+
+	if !closed {
+		emit(src[0].X, src[0].Y, basics.PathCmdMoveTo)
+		for i := 1; i < n-1; i++ {
+			g.corner(src[i-1], src[i], src[i+1], emit)
+		}
+		emit(src[n-1].X, src[n-1].Y, basics.PathCmdLineTo)
+		emit(0, 0, basics.PathCmdEndPoly)
+		return
+	}
+
+	// Start at the end of the first corner's arc, so the outline closes
+	// exactly where it began.
+	start := len(g.out)
+	g.corner(src[n-1], src[0], src[1], emit)
+	last := g.out[len(g.out)-1]
+	g.out = g.out[:start]
+	emit(last.x, last.y, basics.PathCmdMoveTo)
+	for i := 1; i <= n; i++ {
+		g.corner(src[i-1], src[i%n], src[(i+1)%n], emit)
+	}
+	// A final line back to the start is implied by closing.
+	if g.out[len(g.out)-1].cmd == basics.PathCmdLineTo {
+		g.out = g.out[:len(g.out)-1]
+	}
+	emit(0, 0, basics.PathCmdEndPoly|basics.PathFlagClose)
+}
+
+// corner emits the path from the previous corner to the end of the rounded
+// corner at p, between a and b: a line to the first tangent point followed
+// by the arc, or just a line to p when there is nothing to round.
+func (g *VCGenRoundCorners) corner(a, p, b basics.Point[float64], emit func(x, y float64, cmd basics.PathCommand)) {
+	l1 := math.Hypot(a.X-p.X, a.Y-p.Y)
+	l2 := math.Hypot(b.X-p.X, b.Y-p.Y)
+	if g.radius <= 0 || l1 == 0 || l2 == 0 {
+		emit(p.X, p.Y, basics.PathCmdLineTo)
+		return
+	}
+	d1x, d1y := (a.X-p.X)/l1, (a.Y-p.Y)/l1
+	d2x, d2y := (b.X-p.X)/l2, (b.Y-p.Y)/l2
+
+	// theta is the interior angle between the edges, sweep the turn the arc
+	// makes.
+	theta := math.Acos(math.Max(-1, math.Min(1, d1x*d2x+d1y*d2y)))
+	sweep := math.Pi - theta
+	if sweep < roundCornersMinTurn || theta < roundCornersMinTurn {
+		emit(p.X, p.Y, basics.PathCmdLineTo)
+		return
+	}
+
+	half := math.Tan(theta / 2)
+	t := math.Min(g.radius/half, math.Min(l1, l2)/2)
+	r := t * half
+
+	t1x, t1y := p.X+d1x*t, p.Y+d1y*t
+	emit(t1x, t1y, basics.PathCmdLineTo)
+
+	// The center lies on the bisector; the arc runs around it from the
+	// first tangent point to the second, in pieces of at most a quarter turn.
+	bx, by := d1x+d2x, d1y+d2y
+	bl := math.Hypot(bx, by)
+	dist := r / math.Sin(theta/2)
+	cx, cy := p.X+bx/bl*dist, p.Y+by/bl*dist
+	a0 := math.Atan2(t1y-cy, t1x-cx)
+	if math.Remainder(math.Atan2(p.Y+d2y*t-cy, p.X+d2x*t-cx)-a0, 2*math.Pi) < 0 {
+		sweep = -sweep
+	}
+
+	steps := int(math.Ceil(math.Abs(sweep) / (math.Pi / 2)))
+	step := sweep / float64(steps)
+	k := 4.0 / 3.0 * math.Tan(step/4) * r
+	for i := 0; i < steps; i++ {
+		s0 := a0 + step*float64(i)
+		s1 := s0 + step
+		sin0, cos0 := math.Sincos(s0)
+		sin1, cos1 := math.Sincos(s1)
+		emit(cx+r*cos0-k*sin0, cy+r*sin0+k*cos0, basics.PathCmdCurve4)
+		emit(cx+r*cos1+k*sin1, cy+r*sin1-k*cos1, basics.PathCmdCurve4)
+		emit(cx+r*cos1, cy+r*sin1, basics.PathCmdCurve4)
+	}
+}
@@ -0,0 +1,99 @@
+package vcgen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+type roundCornersOut struct {
+	x, y float64
+	cmd  basics.PathCommand
+}
+
+func collectRoundCorners(g *VCGenRoundCorners) []roundCornersOut {
+	var out []roundCornersOut
+	g.Rewind(0)
+	for {
+		x, y, cmd := g.Vertex()
+		if basics.IsStop(cmd) {
+			return out
+		}
+		out = append(out, roundCornersOut{x, y, cmd})
+	}
+}
+
+func TestVCGenRoundCorners_Square(t *testing.T) {
+	g := NewVCGenRoundCorners()
+	g.SetRadius(2)
+	g.AddVertex(0, 0, basics.PathCmdMoveTo)
+	g.AddVertex(10, 0, basics.PathCmdLineTo)
+	g.AddVertex(10, 10, basics.PathCmdLineTo)
+	g.AddVertex(0, 10, basics.PathCmdLineTo)
+	g.AddVertex(0, 0, basics.PathCmdLineTo) // repeated start point
+	g.AddVertex(0, 0, basics.PathCmdEndPoly|basics.PathFlagClose)
+
+	out := collectRoundCorners(g)
+	if out[0].cmd != basics.PathCmdMoveTo || math.Abs(out[0].x-2) > 1e-9 || math.Abs(out[0].y) > 1e-9 {
+		t.Fatalf("start = %+v, want move-to (2, 0)", out[0])
+	}
+	last := out[len(out)-1]
+	if !basics.IsEndPoly(last.cmd) || !basics.IsClosed(uint32(last.cmd)) {
+		t.Errorf("last command = %v, want closed end-poly", last.cmd)
+	}
+
+	centers := [][2]float64{{2, 2}, {8, 2}, {8, 8}, {2, 8}}
+	curves, arcs := 0, 0
+	for _, v := range out {
+		if v.cmd != basics.PathCmdCurve4 {
+			continue
+		}
+		// Every third curve vertex ends an arc on a corner circle.
+		if curves++; curves%3 != 0 {
+			continue
+		}
+		arcs++
+		best := math.Inf(1)
+		for _, c := range centers {
+			best = math.Min(best, math.Abs(math.Hypot(v.x-c[0], v.y-c[1])-2))
+		}
+		if best > 1e-9 {
+			t.Errorf("arc end (%g, %g) not at radius 2 from a corner center", v.x, v.y)
+		}
+	}
+	if arcs != 4 {
+		t.Errorf("got %d arcs, want 4", arcs)
+	}
+}
+
+func TestVCGenRoundCorners_OpenAndClamped(t *testing.T) {
+	g := NewVCGenRoundCorners()
+	g.SetRadius(100)
+	g.AddVertex(0, 0, basics.PathCmdMoveTo)
+	g.AddVertex(4, 0, basics.PathCmdLineTo)
+	g.AddVertex(4, 4, basics.PathCmdLineTo)
+
+	out := collectRoundCorners(g)
+	if out[0].x != 0 || out[0].y != 0 || out[len(out)-2].x != 4 || out[len(out)-2].y != 4 {
+		t.Errorf("open end points moved: %+v", out)
+	}
+	// The radius shrinks to fit half of each edge.
+	if out[1].cmd != basics.PathCmdLineTo || math.Abs(out[1].x-2) > 1e-9 || out[1].y != 0 {
+		t.Errorf("tangent point = %+v, want line-to (2, 0)", out[1])
+	}
+	if end := out[4]; end.cmd != basics.PathCmdCurve4 || math.Abs(end.x-4) > 1e-9 || math.Abs(end.y-2) > 1e-9 {
+		t.Errorf("arc end = %+v, want (4, 2)", end)
+	}
+
+	g.RemoveAll()
+	g.SetRadius(0)
+	g.AddVertex(0, 0, basics.PathCmdMoveTo)
+	g.AddVertex(4, 0, basics.PathCmdLineTo)
+	g.AddVertex(4, 4, basics.PathCmdLineTo)
+	for _, v := range collectRoundCorners(g) {
+		if v.cmd == basics.PathCmdCurve4 {
+			t.Fatal("radius 0 produced curves")
+		}
+	}
+}
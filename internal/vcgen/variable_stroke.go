@@ -0,0 +1,367 @@
+package vcgen
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+// WidthFunc returns the stroke width at position t along a sub-path, where t
+// runs from 0 at the first vertex to 1 at the last (or back at the first one
+// for closed paths).
+type WidthFunc func(t float64) float64
+
+// variableStrokeSampleStep is the distance between width samples along a
+// segment when a WidthFunc is set, before the approximation scale is applied.
+const variableStrokeSampleStep = 2.0
+
+type widthVertex struct {
+	x, y, w float64
+}
+
+// VCGenVariableStroke is a stroke generator whose width varies along the
+// path. Widths come either from per-vertex values (for example pen pressure
+// samples) or from a WidthFunc evaluated along the path length, and the
+// generator emits a single fillable outline with round outer joins, suitable
+// for tapered ink-like strokes.
+//
+// The outline may self-overlap on sharp inner turns, so it must be filled
+// with the non-zero winding rule.
+type VCGenVariableStroke struct {
+	width       float64
+	widths      []float64
+	widthFunc   WidthFunc
+	lineCap     basics.LineCap
+	approxScale float64
+
+	src      []widthVertex
+	vertices int // source vertices added, including skipped coincident ones
+	closed   bool
+	out      []basics.PointD
+	contours []int // end index into out of each emitted contour
+	prepared bool
+
+	outVertex int
+	contour   int
+	endPoly   bool
+}
+
+// NewVCGenVariableStroke creates a variable-width stroke generator with a
+// constant width of 1 and butt caps.
+func NewVCGenVariableStroke() *VCGenVariableStroke {
+	return &VCGenVariableStroke{
+		width:       1.0,
+		lineCap:     basics.ButtCap,
+		approxScale: 1.0,
+	}
+}
+
+// SetWidth sets the width used when neither per-vertex widths nor a width
+// function are set.
+func (vs *VCGenVariableStroke) SetWidth(w float64) { vs.width = math.Max(w, 0) }
+
+// Width returns the constant fallback width.
+func (vs *VCGenVariableStroke) Width() float64 { return vs.width }
+
+// SetWidths sets per-vertex widths. widths[i] applies to the i-th vertex of
+// each sub-path; vertices past the end of the slice reuse the last value.
+// Per-vertex widths take precedence over a width function.
+func (vs *VCGenVariableStroke) SetWidths(widths []float64) {
+	vs.widths = append(vs.widths[:0], widths...)
+}
+
+// Widths returns the per-vertex widths.
+func (vs *VCGenVariableStroke) Widths() []float64 { return vs.widths }
+
+// SetWidthFunc sets a function giving the width along the path. Segments are
+// resampled so the outline follows the function between source vertices.
+func (vs *VCGenVariableStroke) SetWidthFunc(f WidthFunc) { vs.widthFunc = f }
+
+// SetLineCap sets the cap style used on open-path ends.
+func (vs *VCGenVariableStroke) SetLineCap(lc basics.LineCap) { vs.lineCap = lc }
+
+// LineCap returns the current cap style.
+func (vs *VCGenVariableStroke) LineCap() basics.LineCap { return vs.lineCap }
+
+// SetApproximationScale sets the scale used for round joins, round caps and
+// width-function sampling.
+func (vs *VCGenVariableStroke) SetApproximationScale(s float64) {
+	if s > 0 {
+		vs.approxScale = s
+	}
+}
+
+// ApproximationScale returns the current approximation scale.
+func (vs *VCGenVariableStroke) ApproximationScale() float64 { return vs.approxScale }
+
+// RemoveAll clears all vertices and resets the generator.
+func (vs *VCGenVariableStroke) RemoveAll() {
+	vs.src = vs.src[:0]
+	vs.vertices = 0
+	vs.closed = false
+	vs.prepared = false
+}
+
+// AddVertex adds a vertex to the current sub-path.
+func (vs *VCGenVariableStroke) AddVertex(x, y float64, cmd basics.PathCommand) {
+	vs.prepared = false
+	switch {
+	case basics.IsMoveTo(cmd):
+		if n := len(vs.src); n > 0 {
+			vs.src = vs.src[:n-1]
+			vs.vertices = len(vs.src)
+		}
+		vs.addSource(x, y)
+	case basics.IsVertex(cmd):
+		vs.addSource(x, y)
+	default:
+		vs.closed = basics.GetCloseFlag(uint32(cmd)) != 0
+	}
+}
+
+func (vs *VCGenVariableStroke) addSource(x, y float64) {
+	w := vs.width
+	if len(vs.widths) > 0 {
+		w = vs.widths[min(vs.vertices, len(vs.widths)-1)]
+	}
+	vs.vertices++
+	// Coincident vertices carry no direction; keep the first one and drop
+	// the width of the others with them.
+	if n := len(vs.src); n > 0 {
+		last := vs.src[n-1]
+		if basics.CalcDistance(last.x, last.y, x, y) <= basics.VertexDistEpsilon {
+			return
+		}
+	}
+	vs.src = append(vs.src, widthVertex{x: x, y: y, w: math.Max(w, 0)})
+}
+
+// PrepareSrc builds the outline for the accumulated sub-path.
+func (vs *VCGenVariableStroke) PrepareSrc() {
+	if vs.prepared {
+		return
+	}
+	vs.prepared = true
+	vs.out = vs.out[:0]
+	vs.contours = vs.contours[:0]
+
+	pts := vs.src
+	closed := vs.closed
+	if closed && len(pts) > 1 {
+		first, last := pts[0], pts[len(pts)-1]
+		if basics.CalcDistance(first.x, first.y, last.x, last.y) <= basics.VertexDistEpsilon {
+			pts = pts[:len(pts)-1]
+		}
+	}
+	if len(pts) < 3 {
+		closed = false
+	}
+	if len(vs.widths) == 0 && vs.widthFunc != nil {
+		pts = vs.resample(pts, closed)
+	}
+
+	switch {
+	case len(pts) == 0:
+	case len(pts) == 1:
+		// A lone point becomes a dot with round caps, as in VCGenStroke.
+		if vs.lineCap == basics.RoundCap && pts[0].w > 0 {
+			vs.addArc(pts[0].x, pts[0].y, pts[0].w/2, 0, 2*math.Pi)
+			vs.out = vs.out[:len(vs.out)-1]
+			vs.contours = append(vs.contours, len(vs.out))
+		}
+	case closed:
+		vs.buildClosed(pts)
+	default:
+		vs.buildOpen(pts)
+	}
+}
+
+// resample inserts vertices along each segment and assigns widths from the
+// width function by relative path length.
+func (vs *VCGenVariableStroke) resample(pts []widthVertex, closed bool) []widthVertex {
+	n := len(pts)
+	segs := n - 1
+	if closed {
+		segs = n
+	}
+	total := 0.0
+	for i := 0; i < segs; i++ {
+		a, b := pts[i], pts[(i+1)%n]
+		total += basics.CalcDistance(a.x, a.y, b.x, b.y)
+	}
+	step := variableStrokeSampleStep / vs.approxScale
+	widthAt := func(d float64) float64 {
+		if total <= 0 {
+			return math.Max(vs.widthFunc(0), 0)
+		}
+		return math.Max(vs.widthFunc(d/total), 0)
+	}
+
+	out := make([]widthVertex, 0, n+int(total/step)+1)
+	dist := 0.0
+	for i := 0; i < segs; i++ {
+		a, b := pts[i], pts[(i+1)%n]
+		l := basics.CalcDistance(a.x, a.y, b.x, b.y)
+		k := max(int(math.Ceil(l/step)), 1)
+		for j := 0; j < k; j++ {
+			f := float64(j) / float64(k)
+			out = append(out, widthVertex{
+				x: a.x + (b.x-a.x)*f,
+				y: a.y + (b.y-a.y)*f,
+				w: widthAt(dist + l*f),
+			})
+		}
+		dist += l
+	}
+	if !closed {
+		last := pts[n-1]
+		out = append(out, widthVertex{x: last.x, y: last.y, w: widthAt(total)})
+	}
+	return out
+}
+
+// direction returns the unit vector from a to b.
+func direction(a, b widthVertex) (float64, float64) {
+	l := basics.CalcDistance(a.x, a.y, b.x, b.y)
+	return (b.x - a.x) / l, (b.y - a.y) / l
+}
+
+func (vs *VCGenVariableStroke) buildOpen(pts []widthVertex) {
+	n := len(pts)
+	left := make([]basics.PointD, 0, n)
+	right := make([]basics.PointD, 0, n)
+
+	dx, dy := direction(pts[0], pts[1])
+	h := pts[0].w / 2
+	left = append(left, basics.PointD{X: pts[0].x - dy*h, Y: pts[0].y + dx*h})
+	right = append(right, basics.PointD{X: pts[0].x + dy*h, Y: pts[0].y - dx*h})
+	for i := 1; i < n-1; i++ {
+		left, right = vs.join(left, right, pts[i-1], pts[i], pts[i+1])
+	}
+	ex, ey := direction(pts[n-2], pts[n-1])
+	p := pts[n-1]
+	h = p.w / 2
+	left = append(left, basics.PointD{X: p.x - ey*h, Y: p.y + ex*h})
+	right = append(right, basics.PointD{X: p.x + ey*h, Y: p.y - ex*h})
+
+	vs.out = append(vs.out, left...)
+	vs.addCap(p, ex, ey)
+	for i := len(right) - 1; i >= 0; i-- {
+		vs.out = append(vs.out, right[i])
+	}
+	vs.addCap(pts[0], -dx, -dy)
+	vs.contours = append(vs.contours, len(vs.out))
+}
+
+func (vs *VCGenVariableStroke) buildClosed(pts []widthVertex) {
+	n := len(pts)
+	left := make([]basics.PointD, 0, n)
+	right := make([]basics.PointD, 0, n)
+	for i := 0; i < n; i++ {
+		left, right = vs.join(left, right, pts[(i+n-1)%n], pts[i], pts[(i+1)%n])
+	}
+	vs.out = append(vs.out, left...)
+	vs.contours = append(vs.contours, len(vs.out))
+	for i := len(right) - 1; i >= 0; i-- {
+		vs.out = append(vs.out, right[i])
+	}
+	vs.contours = append(vs.contours, len(vs.out))
+}
+
+// join appends the left and right offsets at vertex p between the segments
+// prev-p and p-next. The outer side gets a round join; the inner side keeps
+// both segment offsets and relies on non-zero filling.
+func (vs *VCGenVariableStroke) join(left, right []basics.PointD, prev, p, next widthVertex) ([]basics.PointD, []basics.PointD) {
+	d1x, d1y := direction(prev, p)
+	d2x, d2y := direction(p, next)
+	h := p.w / 2
+	turn := math.Atan2(d1x*d2y-d1y*d2x, d1x*d2x+d1y*d2y)
+
+	l1 := basics.PointD{X: p.x - d1y*h, Y: p.y + d1x*h}
+	l2 := basics.PointD{X: p.x - d2y*h, Y: p.y + d2x*h}
+	r1 := basics.PointD{X: p.x + d1y*h, Y: p.y - d1x*h}
+	r2 := basics.PointD{X: p.x + d2y*h, Y: p.y - d2x*h}
+
+	switch {
+	case math.Abs(turn) < 1e-9 || h == 0:
+		left = append(left, l1)
+		right = append(right, r1)
+	case turn < 0:
+		left = vs.appendArc(left, p.x, p.y, h, math.Atan2(d1x, -d1y), turn)
+		right = append(right, r1, r2)
+	default:
+		left = append(left, l1, l2)
+		right = vs.appendArc(right, p.x, p.y, h, math.Atan2(-d1x, d1y), turn)
+	}
+	return left, right
+}
+
+// addCap appends the cap at p for a path leaving p in direction (dx, dy),
+// between the left and right offsets already emitted around it.
+func (vs *VCGenVariableStroke) addCap(p widthVertex, dx, dy float64) {
+	h := p.w / 2
+	if h == 0 {
+		return
+	}
+	switch vs.lineCap {
+	case basics.SquareCap:
+		vs.out = append(vs.out,
+			basics.PointD{X: p.x - dy*h + dx*h, Y: p.y + dx*h + dy*h},
+			basics.PointD{X: p.x + dy*h + dx*h, Y: p.y - dx*h + dy*h})
+	case basics.RoundCap:
+		// Drop the arc end points; they coincide with the side offsets.
+		start := len(vs.out)
+		vs.out = vs.appendArc(vs.out, p.x, p.y, h, math.Atan2(dx, -dy), -math.Pi)
+		vs.out = append(vs.out[:start], vs.out[start+1:len(vs.out)-1]...)
+	}
+}
+
+func (vs *VCGenVariableStroke) addArc(x, y, r, a1, sweep float64) {
+	vs.out = vs.appendArc(vs.out, x, y, r, a1, sweep)
+}
+
+// appendArc appends points on the circle of radius r around (x, y) from
+// angle a1 through sweep radians, both end points included.
+func (vs *VCGenVariableStroke) appendArc(pts []basics.PointD, x, y, r, a1, sweep float64) []basics.PointD {
+	da := math.Acos(r/(r+0.125/vs.approxScale)) * 2
+	steps := max(int(math.Ceil(math.Abs(sweep)/da)), 1)
+	for i := 0; i <= steps; i++ {
+		a := a1 + sweep*float64(i)/float64(steps)
+		pts = append(pts, basics.PointD{X: x + math.Cos(a)*r, Y: y + math.Sin(a)*r})
+	}
+	return pts
+}
+
+// Rewind restarts emission of the generated outline.
+func (vs *VCGenVariableStroke) Rewind(pathID uint) {
+	vs.PrepareSrc()
+	vs.outVertex = 0
+	vs.contour = 0
+	vs.endPoly = false
+}
+
+// Vertex returns the next vertex of the outline.
+func (vs *VCGenVariableStroke) Vertex() (x, y float64, cmd basics.PathCommand) {
+	if vs.endPoly {
+		vs.endPoly = false
+		vs.contour++
+		return 0, 0, basics.PathCmdEndPoly | basics.PathFlagClose
+	}
+	if vs.contour >= len(vs.contours) {
+		return 0, 0, basics.PathCmdStop
+	}
+	start := 0
+	if vs.contour > 0 {
+		start = vs.contours[vs.contour-1]
+	}
+	cmd = basics.PathCmdLineTo
+	if vs.outVertex == start {
+		cmd = basics.PathCmdMoveTo
+	}
+	p := vs.out[vs.outVertex]
+	vs.outVertex++
+	if vs.outVertex == vs.contours[vs.contour] {
+		vs.endPoly = true
+	}
+	return p.X, p.Y, cmd
+}
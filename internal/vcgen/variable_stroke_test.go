@@ -0,0 +1,174 @@
+package vcgen
+
+import (
+	"math"
+	"testing"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+)
+
+type strokeContour []basics.PointD
+
+func collectVariableStroke(vs *VCGenVariableStroke) []strokeContour {
+	var contours []strokeContour
+	vs.Rewind(0)
+	for {
+		x, y, cmd := vs.Vertex()
+		switch {
+		case basics.IsStop(cmd):
+			return contours
+		case basics.IsMoveTo(cmd):
+			contours = append(contours, strokeContour{{X: x, Y: y}})
+		case basics.IsVertex(cmd):
+			contours[len(contours)-1] = append(contours[len(contours)-1], basics.PointD{X: x, Y: y})
+		}
+	}
+}
+
+func (c strokeContour) area() float64 {
+	a := 0.0
+	for i := range c {
+		p, q := c[i], c[(i+1)%len(c)]
+		a += p.X*q.Y - q.X*p.Y
+	}
+	return a / 2
+}
+
+func TestVCGenVariableStrokeConstantWidth(t *testing.T) {
+	vs := NewVCGenVariableStroke()
+	vs.SetWidth(4)
+	vs.AddVertex(0, 0, basics.PathCmdMoveTo)
+	vs.AddVertex(10, 0, basics.PathCmdLineTo)
+
+	contours := collectVariableStroke(vs)
+	if len(contours) != 1 || len(contours[0]) != 4 {
+		t.Fatalf("expected one 4-point contour, got %v", contours)
+	}
+	if a := math.Abs(contours[0].area()); math.Abs(a-40) > 1e-9 {
+		t.Errorf("area = %v, want 40", a)
+	}
+}
+
+func TestVCGenVariableStrokePerVertexWidths(t *testing.T) {
+	vs := NewVCGenVariableStroke()
+	vs.SetWidths([]float64{0, 10})
+	vs.AddVertex(0, 0, basics.PathCmdMoveTo)
+	vs.AddVertex(10, 0, basics.PathCmdLineTo)
+	vs.AddVertex(20, 0, basics.PathCmdLineTo) // reuses the last width
+
+	contours := collectVariableStroke(vs)
+	if len(contours) != 1 {
+		t.Fatalf("expected one contour, got %d", len(contours))
+	}
+	// A triangle from 0 to 10 plus a 10x10 band from 10 to 20.
+	if a := math.Abs(contours[0].area()); math.Abs(a-150) > 1e-6 {
+		t.Errorf("area = %v, want 150", a)
+	}
+}
+
+func TestVCGenVariableStrokeRepeatedPoint(t *testing.T) {
+	vs := NewVCGenVariableStroke()
+	vs.SetWidths([]float64{2, 6, 10})
+	vs.AddVertex(0, 0, basics.PathCmdMoveTo)
+	vs.AddVertex(0, 0, basics.PathCmdLineTo) // skipped along with width 6
+	vs.AddVertex(10, 0, basics.PathCmdLineTo)
+
+	contours := collectVariableStroke(vs)
+	if len(contours) != 1 {
+		t.Fatalf("expected one contour, got %d", len(contours))
+	}
+	// A trapezoid from width 2 to width 10.
+	if a := math.Abs(contours[0].area()); math.Abs(a-60) > 1e-6 {
+		t.Errorf("area = %v, want 60", a)
+	}
+}
+
+func TestVCGenVariableStrokeWidthFunc(t *testing.T) {
+	vs := NewVCGenVariableStroke()
+	vs.SetWidthFunc(func(t float64) float64 { return 8 * math.Sin(math.Pi*t) })
+	vs.AddVertex(0, 0, basics.PathCmdMoveTo)
+	vs.AddVertex(100, 0, basics.PathCmdLineTo)
+
+	contours := collectVariableStroke(vs)
+	if len(contours) != 1 {
+		t.Fatalf("expected one contour, got %d", len(contours))
+	}
+	// The integral of 8*sin(pi*x/100) over [0, 100] is 1600/pi.
+	want := 1600 / math.Pi
+	if a := math.Abs(contours[0].area()); math.Abs(a-want) > 1 {
+		t.Errorf("area = %v, want about %v", a, want)
+	}
+	maxY := 0.0
+	for _, p := range contours[0] {
+		maxY = math.Max(maxY, p.Y)
+	}
+	if math.Abs(maxY-4) > 0.01 {
+		t.Errorf("max half-width = %v, want 4", maxY)
+	}
+}
+
+func TestVCGenVariableStrokeCaps(t *testing.T) {
+	tests := []struct {
+		cap  basics.LineCap
+		want float64
+	}{
+		{basics.ButtCap, 40},
+		{basics.SquareCap, 56},
+		{basics.RoundCap, 40 + 4*math.Pi},
+	}
+	for _, tt := range tests {
+		vs := NewVCGenVariableStroke()
+		vs.SetWidth(4)
+		vs.SetLineCap(tt.cap)
+		vs.SetApproximationScale(10)
+		vs.AddVertex(0, 0, basics.PathCmdMoveTo)
+		vs.AddVertex(10, 0, basics.PathCmdLineTo)
+		a := math.Abs(collectVariableStroke(vs)[0].area())
+		if math.Abs(a-tt.want) > 0.1 {
+			t.Errorf("cap %v: area = %v, want %v", tt.cap, a, tt.want)
+		}
+	}
+}
+
+func TestVCGenVariableStrokeClosed(t *testing.T) {
+	vs := NewVCGenVariableStroke()
+	vs.SetWidth(2)
+	vs.SetApproximationScale(10)
+	vs.AddVertex(0, 0, basics.PathCmdMoveTo)
+	vs.AddVertex(10, 0, basics.PathCmdLineTo)
+	vs.AddVertex(10, 10, basics.PathCmdLineTo)
+	vs.AddVertex(0, 10, basics.PathCmdLineTo)
+	vs.AddVertex(0, 0, basics.PathCmdEndPoly|basics.PathFlagClose)
+
+	contours := collectVariableStroke(vs)
+	if len(contours) != 2 {
+		t.Fatalf("expected outer and inner contours, got %d", len(contours))
+	}
+	a0, a1 := contours[0].area(), contours[1].area()
+	if a0*a1 >= 0 {
+		t.Errorf("contours should wind in opposite directions: %v, %v", a0, a1)
+	}
+	// The outer contour is the square grown by 1 with rounded corners.
+	want := 100 + 40 + math.Pi
+	if a := math.Max(math.Abs(a0), math.Abs(a1)); math.Abs(a-want) > 0.1 {
+		t.Errorf("outer area = %v, want about %v", a, want)
+	}
+}
+
+func TestVCGenVariableStrokeDot(t *testing.T) {
+	vs := NewVCGenVariableStroke()
+	vs.SetWidth(4)
+	vs.AddVertex(5, 5, basics.PathCmdMoveTo)
+	if got := collectVariableStroke(vs); len(got) != 0 {
+		t.Errorf("butt-capped point should emit nothing, got %v", got)
+	}
+
+	vs.SetLineCap(basics.RoundCap)
+	vs.SetApproximationScale(10)
+	vs.RemoveAll()
+	vs.AddVertex(5, 5, basics.PathCmdMoveTo)
+	contours := collectVariableStroke(vs)
+	if len(contours) != 1 || math.Abs(math.Abs(contours[0].area())-4*math.Pi) > 0.1 {
+		t.Errorf("round-capped point should be a radius-2 disc, got %v", contours)
+	}
+}
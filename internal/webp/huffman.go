@@ -0,0 +1,146 @@
+package webp
+
+import "sort"
+
+// prefixCode is a canonical Huffman code as VP8L stores it.
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint16 // bit-reversed, ready for the LSB-first bit writer
+	// single marks a code with one used symbol, which VP8L decoders read
+	// with zero bits.
+	single bool
+}
+
+// newPrefixCode builds a code for the symbol histogram with code lengths of
+// at most maxLen bits.
+func newPrefixCode(hist []int, maxLen int) *prefixCode {
+	c := &prefixCode{lengths: huffmanLengths(hist, maxLen)}
+	used := 0
+	for _, l := range c.lengths {
+		if l != 0 {
+			used++
+		}
+	}
+	c.single = used <= 1
+	c.codes = canonicalCodes(c.lengths)
+	return c
+}
+
+// write emits the code of sym.
+func (c *prefixCode) write(bw *bitWriter, sym int) {
+	if !c.single {
+		bw.put(uint32(c.codes[sym]), uint(c.lengths[sym]))
+	}
+}
+
+// huffmanLengths returns Huffman code lengths for hist, limited to maxLen.
+// When the optimal tree is too deep, small counts are raised and the tree
+// rebuilt, as libwebp does. A single used symbol gets length 1.
+func huffmanLengths(hist []int, maxLen int) []uint8 {
+	lengths := make([]uint8, len(hist))
+	var leaves []leaf
+	for s, n := range hist {
+		if n > 0 {
+			leaves = append(leaves, leaf{n, s})
+		}
+	}
+	switch len(leaves) {
+	case 0:
+		return lengths
+	case 1:
+		lengths[leaves[0].sym] = 1
+		return lengths
+	}
+
+	for minCount := 1; ; minCount *= 2 {
+		items := make([]leaf, len(leaves))
+		for i, l := range leaves {
+			items[i] = leaf{max(l.count, minCount), l.sym}
+		}
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].count != items[j].count {
+				return items[i].count < items[j].count
+			}
+			return items[i].sym < items[j].sym
+		})
+		counts := make([]int, len(items))
+		for i, it := range items {
+			counts[i] = it.count
+		}
+		if depthsFit(counts, maxLen, func(i int, depth uint8) { lengths[items[i].sym] = depth }) {
+			return lengths
+		}
+	}
+}
+
+type leaf struct{ count, sym int }
+
+// depthsFit builds a Huffman tree over the ascending counts with the
+// two-queue method and reports the leaf depths through set when none
+// exceeds maxLen.
+func depthsFit(counts []int, maxLen int, set func(i int, depth uint8)) bool {
+	n := len(counts)
+	// Nodes 0..n-1 are leaves, n.. internal nodes in creation order.
+	weight := make([]int, 2*n-1)
+	parent := make([]int, 2*n-1)
+	copy(weight, counts)
+	leafNext, nodeNext, next := 0, n, n
+	pick := func() int {
+		if leafNext < n && (nodeNext >= next || weight[leafNext] <= weight[nodeNext]) {
+			leafNext++
+			return leafNext - 1
+		}
+		nodeNext++
+		return nodeNext - 1
+	}
+	for next < 2*n-1 {
+		a, b := pick(), pick()
+		weight[next] = weight[a] + weight[b]
+		parent[a], parent[b] = next, next
+		next++
+	}
+
+	depth := make([]int, 2*n-1)
+	for i := 2*n - 3; i >= 0; i-- { // the root, 2n-2, has depth 0
+		depth[i] = depth[parent[i]] + 1
+	}
+	for i := 0; i < n; i++ {
+		if depth[i] > maxLen {
+			return false
+		}
+	}
+	for i := 0; i < n; i++ {
+		set(i, uint8(depth[i]))
+	}
+	return true
+}
+
+// canonicalCodes assigns canonical codes to lengths, in symbol order within
+// each length, and reverses them for LSB-first output.
+func canonicalCodes(lengths []uint8) []uint16 {
+	var count [16]int
+	for _, l := range lengths {
+		count[l]++
+	}
+	count[0] = 0
+	var next [16]int
+	code := 0
+	for l := 1; l < 16; l++ {
+		code = (code + count[l-1]) << 1
+		next[l] = code
+	}
+	codes := make([]uint16, len(lengths))
+	for s, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		c := next[l]
+		next[l]++
+		var r uint16
+		for i := uint8(0); i < l; i++ {
+			r = r<<1 | uint16(c>>i&1)
+		}
+		codes[s] = r
+	}
+	return codes
+}
@@ -0,0 +1,291 @@
+// Package webp encodes lossless WebP (VP8L) images.
+//
+// The encoder keeps to a small subset of the format: no transforms and no
+// color cache, one set of prefix codes for the whole image, and LZ77
+// references only to the pixel on the left and the one above. That is
+// enough for screenshots and golden images, which are mostly flat areas
+// and repeated rows, at a fraction of the size of plain literals.
+package webp
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+const (
+	maxSize = 1 << 14
+
+	numLiterals     = 256
+	numLengthCodes  = 24
+	numDistCodes    = 40
+	maxCopyLength   = 4096
+	minCopyLength   = 3
+	maxCodeLength   = 15
+	maxCLCodeLength = 7
+
+	// Distance plane codes for the neighbours used, see the VP8L spec.
+	planeCodeAbove = 1
+	planeCodeLeft  = 2
+)
+
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// token is a literal pixel or, when length > 0, a copy of length pixels
+// from the given plane code.
+type token struct {
+	argb      uint32
+	length    int
+	planeCode int
+}
+
+// Encode writes width x height RGBA pixels, stride bytes per row, as a
+// lossless WebP file.
+func Encode(w io.Writer, pix []byte, width, height, stride int) error {
+	if width <= 0 || height <= 0 || width > maxSize || height > maxSize {
+		return errors.New("webp: image size must be 1 to 16384 pixels")
+	}
+
+	argb := make([]uint32, width*height)
+	alpha := false
+	for y := 0; y < height; y++ {
+		row := pix[y*stride:]
+		for x := 0; x < width; x++ {
+			r, g, b, a := row[x*4], row[x*4+1], row[x*4+2], row[x*4+3]
+			argb[y*width+x] = uint32(a)<<24 | uint32(r)<<16 | uint32(g)<<8 | uint32(b)
+			alpha = alpha || a != 255
+		}
+	}
+	tokens := tokenize(argb, width)
+
+	var green [numLiterals + numLengthCodes]int
+	var red, blue, alphaHist [numLiterals]int
+	var dist [numDistCodes]int
+	for _, t := range tokens {
+		if t.length == 0 {
+			green[t.argb>>8&0xff]++
+			red[t.argb>>16&0xff]++
+			blue[t.argb&0xff]++
+			alphaHist[t.argb>>24]++
+			continue
+		}
+		lc, _, _ := prefixEncode(t.length)
+		dc, _, _ := prefixEncode(t.planeCode)
+		green[numLiterals+lc]++
+		dist[dc]++
+	}
+
+	bw := &bitWriter{}
+	bw.put(0x2f, 8) // signature
+	bw.put(uint32(width-1), 14)
+	bw.put(uint32(height-1), 14)
+	bw.put(b2u(alpha), 1)
+	bw.put(0, 3) // version
+	bw.put(0, 1) // no transforms
+	bw.put(0, 1) // no color cache
+	bw.put(0, 1) // no meta prefix codes
+
+	codes := [5]*prefixCode{}
+	for i, hist := range [][]int{green[:], red[:], blue[:], alphaHist[:], dist[:]} {
+		codes[i] = writePrefixCode(bw, hist)
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[0].write(bw, int(t.argb>>8&0xff))
+			codes[1].write(bw, int(t.argb>>16&0xff))
+			codes[2].write(bw, int(t.argb&0xff))
+			codes[3].write(bw, int(t.argb>>24))
+			continue
+		}
+		lc, lbits, lextra := prefixEncode(t.length)
+		codes[0].write(bw, numLiterals+lc)
+		bw.put(uint32(lextra), uint(lbits))
+		dc, dbits, dextra := prefixEncode(t.planeCode)
+		codes[4].write(bw, dc)
+		bw.put(uint32(dextra), uint(dbits))
+	}
+	data := bw.bytes()
+
+	size := len(data) + len(data)&1
+	header := make([]byte, 0, 20)
+	header = append(header, "RIFF"...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(4+8+size))
+	header = append(header, "WEBPVP8L"...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if len(data)&1 != 0 {
+		data = append(data, 0)
+	}
+	_, err := w.Write(data)
+	return err
+}
+
+// tokenize turns the pixels into literals and greedy copies from the left
+// or upper neighbour.
+func tokenize(argb []uint32, width int) []token {
+	match := func(i, dist int) int {
+		if i < dist {
+			return 0
+		}
+		n := 0
+		for i+n < len(argb) && n < maxCopyLength && argb[i+n] == argb[i+n-dist] {
+			n++
+		}
+		return n
+	}
+
+	tokens := make([]token, 0, len(argb)/4)
+	for i := 0; i < len(argb); {
+		left, above := match(i, 1), match(i, width)
+		switch {
+		case above >= left && above >= minCopyLength:
+			tokens = append(tokens, token{length: above, planeCode: planeCodeAbove})
+			i += above
+		case left >= minCopyLength:
+			tokens = append(tokens, token{length: left, planeCode: planeCodeLeft})
+			i += left
+		default:
+			tokens = append(tokens, token{argb: argb[i]})
+			i++
+		}
+	}
+	return tokens
+}
+
+// prefixEncode splits a copy length or distance code (1 and up) into its
+// prefix symbol and extra bits.
+func prefixEncode(v int) (code, extraBits, extraValue int) {
+	d := v - 1
+	if d < 4 {
+		return d, 0, 0
+	}
+	hb := bits.Len(uint(d)) - 1
+	second := d >> (hb - 1) & 1
+	extraBits = hb - 1
+	return 2*hb + second, extraBits, d & (1<<extraBits - 1)
+}
+
+// writePrefixCode builds the prefix code for hist and stores it, as a
+// simple code when at most two 8-bit symbols are used.
+func writePrefixCode(bw *bitWriter, hist []int) *prefixCode {
+	var used []int
+	for s, n := range hist {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+
+	if len(used) <= 2 && (len(used) == 0 || used[len(used)-1] < 256) {
+		if len(used) == 0 {
+			used = []int{0}
+		}
+		bw.put(1, 1) // simple code
+		bw.put(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			bw.put(0, 1)
+			bw.put(uint32(used[0]), 1)
+		} else {
+			bw.put(1, 1)
+			bw.put(uint32(used[0]), 8)
+		}
+		if len(used) == 2 {
+			bw.put(uint32(used[1]), 8)
+		}
+		lengths := make([]uint8, len(hist))
+		for _, s := range used {
+			lengths[s] = 1
+		}
+		return &prefixCode{lengths: lengths, codes: canonicalCodes(lengths), single: len(used) == 1}
+	}
+
+	code := newPrefixCode(hist, maxCodeLength)
+
+	// Code lengths are themselves coded: literals 0-15, 17 for 3-10 zeros
+	// and 18 for 11-138 zeros.
+	type clToken struct{ sym, extra int }
+	var clTokens []clToken
+	var clHist [19]int
+	lengths := code.lengths
+	for i := 0; i < len(lengths); {
+		if lengths[i] != 0 {
+			clTokens = append(clTokens, clToken{int(lengths[i]), 0})
+			clHist[lengths[i]]++
+			i++
+			continue
+		}
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == 0 && run < 138 {
+			run++
+		}
+		switch {
+		case run >= 11:
+			clTokens = append(clTokens, clToken{18, run - 11})
+			clHist[18]++
+		case run >= 3:
+			clTokens = append(clTokens, clToken{17, run - 3})
+			clHist[17]++
+		default:
+			run = 1
+			clTokens = append(clTokens, clToken{0, 0})
+			clHist[0]++
+		}
+		i += run
+	}
+	cl := newPrefixCode(clHist[:], maxCLCodeLength)
+
+	n := len(codeLengthCodeOrder)
+	for n > 4 && cl.lengths[codeLengthCodeOrder[n-1]] == 0 {
+		n--
+	}
+	bw.put(0, 1) // normal code
+	bw.put(uint32(n-4), 4)
+	for _, s := range codeLengthCodeOrder[:n] {
+		bw.put(uint32(cl.lengths[s]), 3)
+	}
+	bw.put(0, 1) // code lengths for the whole alphabet
+	for _, t := range clTokens {
+		cl.write(bw, t.sym)
+		switch t.sym {
+		case 17:
+			bw.put(uint32(t.extra), 3)
+		case 18:
+			bw.put(uint32(t.extra), 7)
+		}
+	}
+	return code
+}
+
+// bitWriter packs bits LSB first, as VP8L reads them.
+type bitWriter struct {
+	buf []byte
+	acc uint64
+	n   uint
+}
+
+func (b *bitWriter) put(v uint32, n uint) {
+	b.acc |= uint64(v) << b.n
+	b.n += n
+	for b.n >= 8 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc >>= 8
+		b.n -= 8
+	}
+}
+
+func (b *bitWriter) bytes() []byte {
+	if b.n > 0 {
+		b.buf = append(b.buf, byte(b.acc))
+		b.acc, b.n = 0, 0
+	}
+	return b.buf
+}
+
+func b2u(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,327 @@
+package webp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"math/rand"
+	"testing"
+
+	xwebp "golang.org/x/image/webp"
+)
+
+// The decoder below reads back the subset of VP8L the encoder writes, by
+// the rules of the format specification rather than the encoder's tables.
+
+type bitReader struct {
+	data []byte
+	pos  uint
+}
+
+func (r *bitReader) read(n uint) uint32 {
+	var v uint32
+	for i := uint(0); i < n; i++ {
+		byteIdx := r.pos >> 3
+		if int(byteIdx) < len(r.data) {
+			v |= uint32(r.data[byteIdx]>>(r.pos&7)&1) << i
+		}
+		r.pos++
+	}
+	return v
+}
+
+type huffDecoder struct {
+	single  int // symbol of a zero-bit code, or -1
+	symbols map[[2]int]int
+}
+
+func newHuffDecoder(lengths []int) *huffDecoder {
+	d := &huffDecoder{single: -1, symbols: map[[2]int]int{}}
+	used := 0
+	for s, l := range lengths {
+		if l > 0 {
+			used++
+			d.single = s
+		}
+	}
+	if used == 1 {
+		return d
+	}
+	d.single = -1
+	var count [16]int
+	for _, l := range lengths {
+		count[l]++
+	}
+	count[0] = 0
+	var next [16]int
+	code := 0
+	for l := 1; l < 16; l++ {
+		code = (code + count[l-1]) << 1
+		next[l] = code
+	}
+	for s, l := range lengths {
+		if l > 0 {
+			d.symbols[[2]int{l, next[l]}] = s
+			next[l]++
+		}
+	}
+	return d
+}
+
+func (d *huffDecoder) read(r *bitReader) int {
+	if d.single >= 0 {
+		return d.single
+	}
+	code := 0
+	for l := 1; l <= 15; l++ {
+		code = code<<1 | int(r.read(1))
+		if s, ok := d.symbols[[2]int{l, code}]; ok {
+			return s
+		}
+	}
+	panic("invalid code")
+}
+
+func readPrefixCode(r *bitReader, size int) *huffDecoder {
+	lengths := make([]int, size)
+	if r.read(1) == 1 {
+		n := r.read(1) + 1
+		first := r.read(1)
+		lengths[r.read(1+7*uint(first))] = 1
+		if n == 2 {
+			lengths[r.read(8)] = 1
+		}
+		return newHuffDecoder(lengths)
+	}
+	order := []int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	clLengths := make([]int, 19)
+	n := int(r.read(4)) + 4
+	for i := 0; i < n; i++ {
+		clLengths[order[i]] = int(r.read(3))
+	}
+	cl := newHuffDecoder(clLengths)
+	if r.read(1) != 0 {
+		panic("max_symbol not expected")
+	}
+	prev := 8
+	for s := 0; s < size; {
+		c := cl.read(r)
+		switch {
+		case c < 16:
+			lengths[s] = c
+			s++
+			if c != 0 {
+				prev = c
+			}
+		default:
+			extra := []uint{2, 3, 7}[c-16]
+			repeat := int(r.read(extra)) + []int{3, 3, 11}[c-16]
+			v := 0
+			if c == 16 {
+				v = prev
+			}
+			for ; repeat > 0; repeat-- {
+				lengths[s] = v
+				s++
+			}
+		}
+	}
+	return newHuffDecoder(lengths)
+}
+
+func prefixValue(r *bitReader, code int) int {
+	if code < 4 {
+		return code + 1
+	}
+	extra := uint(code-2) >> 1
+	offset := (2 + code&1) << extra
+	return offset + int(r.read(extra)) + 1
+}
+
+func decode(data []byte) (pix []byte, width, height int, err error) {
+	if string(data[:4]) != "RIFF" || string(data[8:16]) != "WEBPVP8L" {
+		return nil, 0, 0, errors.New("bad header")
+	}
+	if int(binary.LittleEndian.Uint32(data[4:]))+8 != len(data) {
+		return nil, 0, 0, errors.New("bad RIFF size")
+	}
+	r := &bitReader{data: data[20:]}
+	if r.read(8) != 0x2f {
+		return nil, 0, 0, errors.New("bad signature")
+	}
+	width, height = int(r.read(14))+1, int(r.read(14))+1
+	r.read(1)
+	if r.read(3) != 0 || r.read(1) != 0 || r.read(1) != 0 || r.read(1) != 0 {
+		return nil, 0, 0, errors.New("unexpected feature")
+	}
+	green := readPrefixCode(r, 256+24)
+	red := readPrefixCode(r, 256)
+	blue := readPrefixCode(r, 256)
+	alpha := readPrefixCode(r, 256)
+	dist := readPrefixCode(r, 40)
+
+	argb := make([]uint32, width*height)
+	for i := 0; i < len(argb); {
+		g := green.read(r)
+		if g < 256 {
+			rr, bb, aa := red.read(r), blue.read(r), alpha.read(r)
+			argb[i] = uint32(aa)<<24 | uint32(rr)<<16 | uint32(g)<<8 | uint32(bb)
+			i++
+			continue
+		}
+		length := prefixValue(r, g-256)
+		planeCode := prefixValue(r, dist.read(r))
+		var d int
+		switch {
+		case planeCode > 120:
+			d = planeCode - 120
+		case planeCode == 1:
+			d = width
+		case planeCode == 2:
+			d = 1
+		default:
+			return nil, 0, 0, errors.New("unexpected plane code")
+		}
+		for ; length > 0; length-- {
+			argb[i] = argb[i-d]
+			i++
+		}
+	}
+	pix = make([]byte, 4*len(argb))
+	for i, c := range argb {
+		pix[4*i], pix[4*i+1], pix[4*i+2], pix[4*i+3] = byte(c>>16), byte(c>>8), byte(c), byte(c>>24)
+	}
+	return pix, width, height, nil
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	noise := func(w, h int) []byte {
+		pix := make([]byte, w*h*4)
+		rng.Read(pix)
+		return pix
+	}
+	flat := func(w, h int) []byte {
+		pix := make([]byte, w*h*4)
+		for i := range pix {
+			pix[i] = 0xff
+		}
+		return pix
+	}
+	stripes := func(w, h int) []byte {
+		pix := make([]byte, w*h*4)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				i := (y*w + x) * 4
+				pix[i], pix[i+1], pix[i+2], pix[i+3] = byte(x*7), byte(x/3), 40, 255
+			}
+		}
+		return pix
+	}
+
+	for _, tc := range []struct {
+		name string
+		w, h int
+		pix  func(w, h int) []byte
+	}{
+		{"single pixel", 1, 1, flat},
+		{"flat", 64, 40, flat},
+		{"noise", 37, 23, noise},
+		{"stripes", 300, 50, stripes},
+		{"tall", 1, 200, stripes},
+	} {
+		pix := tc.pix(tc.w, tc.h)
+		var buf bytes.Buffer
+		if err := Encode(&buf, pix, tc.w, tc.h, tc.w*4); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		got, w, h, err := decode(buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if w != tc.w || h != tc.h || !bytes.Equal(got, pix) {
+			t.Errorf("%s: round trip differs", tc.name)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, make([]byte, 64*64*4), 64, 64, 64*4); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() > 100 {
+		t.Errorf("flat 64x64 image takes %d bytes", buf.Len())
+	}
+	if Encode(&buf, nil, 0, 1, 0) == nil {
+		t.Error("empty image accepted")
+	}
+}
+
+// TestEncodeDecodesWithXImage checks the output against an independent
+// decoder, so a mistake shared by Encode and decode above cannot hide.
+func TestEncodeDecodesWithXImage(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	for _, tc := range []struct {
+		name string
+		w, h int
+	}{
+		{"single pixel", 1, 1},
+		{"square", 64, 64},
+		{"odd", 37, 23},
+		{"wide", 300, 5},
+	} {
+		pix := make([]byte, tc.w*tc.h*4)
+		for i := 0; i < len(pix); i += 4 {
+			// Mix repeated runs with noise so both literals and
+			// backward references are exercised.
+			if rng.Intn(3) == 0 && i > 0 {
+				copy(pix[i:i+4], pix[i-4:i])
+				continue
+			}
+			rng.Read(pix[i : i+4])
+		}
+		var buf bytes.Buffer
+		if err := Encode(&buf, pix, tc.w, tc.h, tc.w*4); err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		img, err := xwebp.Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: x/image/webp: %v", tc.name, err)
+		}
+		nrgba, ok := img.(*image.NRGBA)
+		if !ok {
+			t.Fatalf("%s: decoded %T, want *image.NRGBA", tc.name, img)
+		}
+		if b := nrgba.Bounds(); b.Dx() != tc.w || b.Dy() != tc.h {
+			t.Fatalf("%s: decoded %v, want %dx%d", tc.name, b, tc.w, tc.h)
+		}
+		for y := 0; y < tc.h; y++ {
+			row := nrgba.Pix[y*nrgba.Stride : y*nrgba.Stride+tc.w*4]
+			if !bytes.Equal(row, pix[y*tc.w*4:(y+1)*tc.w*4]) {
+				t.Errorf("%s: row %d differs", tc.name, y)
+				break
+			}
+		}
+	}
+}
+
+func TestHuffmanLengthsLimit(t *testing.T) {
+	// Fibonacci counts make the deepest possible tree.
+	hist := make([]int, 30)
+	a, b := 1, 1
+	for i := range hist {
+		hist[i] = a
+		a, b = b, a+b
+	}
+	lengths := huffmanLengths(hist, 15)
+	kraft := 0.0
+	for _, l := range lengths {
+		if l == 0 || l > 15 {
+			t.Fatalf("length %d out of range", l)
+		}
+		kraft += 1 / float64(uint(1)<<l)
+	}
+	if kraft != 1 {
+		t.Errorf("code is not complete, Kraft sum %v", kraft)
+	}
+}
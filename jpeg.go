@@ -0,0 +1,120 @@
+package agg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"io"
+	"os"
+)
+
+// DecodeJPEG reads a baseline or progressive JPEG into a new RGBA image,
+// turned upright according to its EXIF orientation, as photo viewers show
+// it.
+func DecodeJPEG(r io.Reader) (*Image, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	m, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return orientImage(rgbaImage(m), jpegOrientation(data)), nil
+}
+
+// LoadJPEG reads a JPEG file like DecodeJPEG.
+func LoadJPEG(filename string) (*Image, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeJPEG(f)
+}
+
+// rgbaImage converts m to an RGBA Image, through image/draw's fast paths for
+// the decoder's YCbCr and gray images.
+func rgbaImage(m image.Image) *Image {
+	b := m.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), m, b.Min, draw.Src)
+	return NewImage(dst.Pix, b.Dx(), b.Dy(), dst.Stride)
+}
+
+// jpegOrientation returns the EXIF orientation (1-8) of a JPEG stream, or 1
+// when it has none.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for p := 2; p+4 <= len(data); {
+		if data[p] != 0xFF {
+			return 1
+		}
+		marker := data[p+1]
+		if marker == 0xFF { // fill byte
+			p++
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 { // image data follows
+			return 1
+		}
+		n := int(binary.BigEndian.Uint16(data[p+2:]))
+		if n < 2 || p+2+n > len(data) {
+			return 1
+		}
+		seg := data[p+4 : p+2+n]
+		if marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return exifOrientation(seg[6:])
+		}
+		p += 2 + n
+	}
+	return 1
+}
+
+// exifOrientation reads the Orientation tag from the IFD0 of a TIFF
+// structure.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		e := ifd + 2 + 12*i
+		if e+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[e:]) == 0x0112 && order.Uint16(tiff[e+2:]) == 3 {
+			if o := int(order.Uint16(tiff[e+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			break
+		}
+	}
+	return 1
+}
+
+// orientImage returns src turned by EXIF orientation o, or src itself for
+// orientation 1.
+func orientImage(src *Image, o int) *Image {
+	if o <= 1 || o > 8 {
+		return src
+	}
+	return src.Orient(o)
+}
@@ -0,0 +1,35 @@
+package agg
+
+// MeasureDraw runs draw against ctx as a dry run and returns the device-pixel
+// bounds (X2 and Y2 exclusive) of everything it would have painted. No pixels
+// are written; the rasterizer computes the exact coverage, so transforms,
+// stroke joins, dashes, anti-aliased edges and the clip box are all taken into
+// account. The result serves layout, cache keys and damage regions; an empty
+// Rect means nothing would be visible.
+//
+// Fills, strokes, text, images, FillRects and Clear are measured. Blurs
+// report the whole clip box and flood fills the bounds of the region they
+// would fill. State changes made by draw persist as in normal drawing, so a
+// draw that is measured and then painted should restore what it changes.
+// Calls nest, and a panic in draw ends the dry run before it propagates.
+func (ctx *Context) MeasureDraw(draw func(*Context)) Rect {
+	return ctx.agg2d.MeasureDraw(func(*Agg2D) { draw(ctx) })
+}
+
+// MeasureDraw is the Agg2D counterpart of Context.MeasureDraw.
+func (a *Agg2D) MeasureDraw(draw func(*Agg2D)) Rect {
+	a.impl.BeginMeasure()
+	ended := false
+	defer func() {
+		if !ended {
+			a.impl.EndMeasure()
+		}
+	}()
+	draw(a)
+	r, ok := a.impl.EndMeasure()
+	ended = true
+	if !ok {
+		return Rect{}
+	}
+	return Rect{X1: r.X1, Y1: r.Y1, X2: r.X2, Y2: r.Y2}
+}
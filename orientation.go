@@ -0,0 +1,93 @@
+package agg
+
+// Lossless orientation changes. Every operation returns a new image in the
+// same format and alpha mode, with the pixels moved as whole units: nothing
+// is resampled, so applying the inverse operation restores the image
+// exactly.
+
+// FlipH returns img mirrored left to right.
+func (img *Image) FlipH() *Image { return img.Orient(2) }
+
+// FlipV returns img mirrored top to bottom.
+func (img *Image) FlipV() *Image { return img.Orient(4) }
+
+// Rotate90 returns img rotated 90 degrees clockwise. Width and height swap.
+func (img *Image) Rotate90() *Image { return img.Orient(6) }
+
+// Rotate180 returns img rotated by 180 degrees.
+func (img *Image) Rotate180() *Image { return img.Orient(3) }
+
+// Rotate270 returns img rotated 90 degrees counterclockwise. Width and
+// height swap.
+func (img *Image) Rotate270() *Image { return img.Orient(8) }
+
+// Transpose returns img mirrored along its main diagonal, so pixel (x, y)
+// moves to (y, x). Width and height swap.
+func (img *Image) Transpose() *Image { return img.Orient(5) }
+
+// Orient returns img turned upright for EXIF orientation o (1-8), the value
+// of the Orientation tag of camera JPEGs: 2 mirrors it, 3 rotates it by 180
+// degrees, 4 flips it, 5 transposes it, 6 rotates it 90 degrees clockwise, 7
+// transverses it and 8 rotates it 90 degrees counterclockwise. Orientation
+// 1, and values outside 1-8, return an unchanged copy. DecodeJPEG and
+// LoadImageFromFile apply the orientation of JPEG files already.
+func (img *Image) Orient(o int) *Image {
+	if img == nil || img.renBuf == nil {
+		return nil
+	}
+	if o < 1 || o > 8 {
+		o = 1
+	}
+	w, h := img.width, img.height
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := CreateImageWithFormat(dw, dh, img.format)
+	dst.alpha, dst.linear = img.alpha, img.linear
+
+	bpp, step := img.format.BytesPerPixel(), img.pixelStep()
+	ss, ds := img.renBuf.Stride(), dst.renBuf.Stride()
+	if o == 1 || o == 4 {
+		// The rows keep their pixels, so they are copied whole unless the
+		// source is a channel view with gaps between pixels.
+		for dy := 0; dy < dh; dy++ {
+			sy := dy
+			if o == 4 {
+				sy = h - 1 - dy
+			}
+			src, row := img.Data[sy*ss:], dst.Data[dy*ds:]
+			if step == bpp {
+				copy(row[:w*bpp], src)
+				continue
+			}
+			for x := 0; x < w; x++ {
+				copy(row[x*bpp:x*bpp+bpp], src[x*step:])
+			}
+		}
+		return dst
+	}
+
+	for dy := 0; dy < dh; dy++ {
+		row := dst.Data[dy*ds:]
+		for dx := 0; dx < dw; dx++ {
+			var sx, sy int
+			switch o {
+			case 2: // mirrored
+				sx, sy = w-1-dx, dy
+			case 3: // rotated 180
+				sx, sy = w-1-dx, h-1-dy
+			case 5: // transposed
+				sx, sy = dy, dx
+			case 6: // rotated 90 clockwise for display
+				sx, sy = dy, h-1-dx
+			case 7: // transversed
+				sx, sy = w-1-dy, h-1-dx
+			case 8: // rotated 90 counterclockwise for display
+				sx, sy = w-1-dy, dx
+			}
+			copy(row[dx*bpp:dx*bpp+bpp], img.Data[sy*ss+sx*step:])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,257 @@
+package agg
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/agg2d"
+)
+
+// Paint is a source of color for fills and strokes: a Color, a *SolidPaint,
+// a *GradientPaint, a *PatternPaint or an *ImagePaint. Install one with
+// Context.SetFillPaint or SetStrokePaint.
+//
+// Apart from a plain Color, every paint carries its own opacity and blend
+// mode, applied whenever it is drawn, on top of the master alpha. That keeps
+// "what to draw with" out of the context state: a translucent multiply
+// highlight stays one paint, whatever the context blend mode is.
+type Paint interface {
+	setFill(a *Agg2D)
+	setStroke(a *Agg2D)
+}
+
+// paintStyle is the opacity and blend mode shared by the paint types. The
+// zero value is opaque and inherits the context blend mode.
+type paintStyle struct {
+	transparency float64 // 1 - opacity, so the zero value is opaque
+	blend        BlendMode
+	ownBlend     bool
+}
+
+// SetOpacity sets the paint opacity, from 0 (invisible) to 1 (the default).
+// It multiplies the alpha of every color the paint produces.
+func (s *paintStyle) SetOpacity(opacity float64) {
+	s.transparency = 1 - math.Max(0, math.Min(1, opacity))
+}
+
+// Opacity returns the paint opacity.
+func (s *paintStyle) Opacity() float64 {
+	return 1 - s.transparency
+}
+
+// SetBlendMode sets the blend mode the paint is drawn with. BlendInherit,
+// the default, uses the context blend mode.
+func (s *paintStyle) SetBlendMode(mode BlendMode) {
+	s.blend, s.ownBlend = mode, mode != BlendInherit
+}
+
+// BlendMode returns the blend mode of the paint, or BlendInherit.
+func (s *paintStyle) BlendMode() BlendMode {
+	if !s.ownBlend {
+		return BlendInherit
+	}
+	return s.blend
+}
+
+func (s *paintStyle) applyFill(a *Agg2D) { a.impl.SetFillPaintStyle(s.Opacity(), s.BlendMode()) }
+func (s *paintStyle) applyLine(a *Agg2D) { a.impl.SetLinePaintStyle(s.Opacity(), s.BlendMode()) }
+
+func (c Color) setFill(a *Agg2D)   { a.FillColor(c) }
+func (c Color) setStroke(a *Agg2D) { a.LineColor(c) }
+
+// SolidPaint is a single color with its own opacity and blend mode.
+type SolidPaint struct {
+	color Color
+	paintStyle
+}
+
+// NewSolidPaint returns an opaque paint of color c.
+func NewSolidPaint(c Color) *SolidPaint {
+	return &SolidPaint{color: c}
+}
+
+// Color returns the paint color.
+func (p *SolidPaint) Color() Color {
+	return p.color
+}
+
+func (p *SolidPaint) setFill(a *Agg2D) {
+	a.FillColor(p.color)
+	p.applyFill(a)
+}
+
+func (p *SolidPaint) setStroke(a *Agg2D) {
+	a.LineColor(p.color)
+	p.applyLine(a)
+}
+
+func (g *GradientPaint) setFill(a *Agg2D) {
+	a.SetFillGradient(g)
+	g.applyFill(a)
+}
+
+func (g *GradientPaint) setStroke(a *Agg2D) {
+	a.SetLineGradient(g)
+	g.applyLine(a)
+}
+
+// PatternPaint repeats an image over the plane, like a CSS background or an
+// SVG pattern. A premultiplied 32-bit RGBA image, in any channel order, is
+// read at render time, so later changes to its pixels show up in subsequent
+// draws. Other images are copied when the pattern is created: Gray, RGB and
+// RGB565 images are expanded to RGBA and straight-alpha images are
+// premultiplied, so later changes to them do not show up; create a new
+// pattern instead. The mipmap levels are those of the image when the
+// pattern is created. A paint may be shared by several contexts.
+type PatternPaint struct {
+	img  *Image
+	impl *agg2d.PatternPaint
+	paintStyle
+}
+
+// NewPattern returns a pattern tiling img, with the top-left corner of a tile
+// at the user-space origin. Like the images drawn with DrawImage, img is
+// taken to hold premultiplied colors.
+func NewPattern(img *Image) *PatternPaint {
+	return &PatternPaint{img: img, impl: agg2d.NewPatternPaint(img.ToInternalImage())}
+}
+
+// Image returns the pattern tile.
+func (p *PatternPaint) Image() *Image {
+	return p.img
+}
+
+// SetTransform sets the pattern transform, like SVG patternTransform: it maps
+// pattern coordinates to user space, so the tiles can be rotated, scaled or
+// offset independently of the shape. A nil m resets it. The transform takes
+// effect the next time the paint is installed.
+func (p *PatternPaint) SetTransform(m *Transformations) {
+	p.impl.SetTransform(toInternalTransformations(m))
+}
+
+// Transform returns the pattern transform.
+func (p *PatternPaint) Transform() *Transformations {
+	return fromInternalTransformations(p.impl.Transform())
+}
+
+func (p *PatternPaint) setFill(a *Agg2D) {
+	a.SetFillPattern(p)
+	p.applyFill(a)
+}
+
+func (p *PatternPaint) setStroke(a *Agg2D) {
+	a.SetLinePattern(p)
+	p.applyLine(a)
+}
+
+// ImagePaint draws an image once, stretched over a rectangle, and leaves
+// everything outside that rectangle untouched. Filling any shape with it
+// clips the image to the shape.
+type ImagePaint struct {
+	img  *Image
+	impl *agg2d.PatternPaint
+	paintStyle
+}
+
+// NewImagePaint returns a paint showing img in the rectangle at (x, y) with
+// the given size in user coordinates. As with NewPattern, img is taken to
+// hold premultiplied colors.
+func NewImagePaint(img *Image, x, y, width, height float64) *ImagePaint {
+	return &ImagePaint{img: img, impl: agg2d.NewImagePaint(img.ToInternalImage(), x, y, x+width, y+height)}
+}
+
+// Image returns the painted image.
+func (p *ImagePaint) Image() *Image {
+	return p.img
+}
+
+// SetTransform sets a transform applied to the placed image before the
+// context transform. See PatternPaint.SetTransform.
+func (p *ImagePaint) SetTransform(m *Transformations) {
+	p.impl.SetTransform(toInternalTransformations(m))
+}
+
+// Transform returns the image transform.
+func (p *ImagePaint) Transform() *Transformations {
+	return fromInternalTransformations(p.impl.Transform())
+}
+
+func (p *ImagePaint) setFill(a *Agg2D) {
+	a.impl.SetFillPattern(p.impl)
+	p.applyFill(a)
+}
+
+func (p *ImagePaint) setStroke(a *Agg2D) {
+	a.impl.SetLinePattern(p.impl)
+	p.applyLine(a)
+}
+
+// SetFillPattern uses p for subsequent fills. The tiles are placed with the
+// transform current at the time of the call. A nil p switches back to a
+// solid fill.
+func (ctx *Context) SetFillPattern(p *PatternPaint) {
+	ctx.agg2d.SetFillPattern(p)
+}
+
+// SetStrokePattern uses p for subsequent strokes. See SetFillPattern.
+func (ctx *Context) SetStrokePattern(p *PatternPaint) {
+	ctx.agg2d.SetLinePattern(p)
+}
+
+// SetFillPaint uses p, with its opacity and blend mode, for subsequent
+// fills. Gradients, patterns and images are placed with the current
+// transform. A nil p switches back to a solid fill in the current fill
+// color.
+func (ctx *Context) SetFillPaint(p Paint) {
+	ctx.agg2d.SetFillPaint(p)
+}
+
+// SetStrokePaint uses p for subsequent strokes. See SetFillPaint.
+func (ctx *Context) SetStrokePaint(p Paint) {
+	ctx.agg2d.SetLinePaint(p)
+}
+
+// FillPathWith fills p with paint and blend mode for this one call. The
+// context's fill paint and blend mode are left as they were; the current path
+// is replaced by p. A nil paint uses the current fill paint, and BlendInherit
+// keeps the paint's own blend mode, falling back to the context one.
+//
+//	ctx.FillPathWith(shadow, agg.RGBA(0, 0, 0, 0.4), agg.BlendMultiply)
+func (ctx *Context) FillPathWith(p *Path, paint Paint, mode BlendMode) {
+	ctx.drawPathWith(p, paint, mode, FillOnly)
+}
+
+// StrokePathWith strokes p with paint and blend mode for this one call, with
+// the current line width and style. See FillPathWith.
+func (ctx *Context) StrokePathWith(p *Path, paint Paint, mode BlendMode) {
+	ctx.drawPathWith(p, paint, mode, StrokeOnly)
+}
+
+func (ctx *Context) drawPathWith(p *Path, paint Paint, mode BlendMode, flag DrawPathFlag) {
+	impl := ctx.agg2d.impl
+	saved := impl.PaintState()
+	defer impl.SetPaintState(saved)
+
+	if flag == FillOnly {
+		if paint != nil {
+			ctx.agg2d.SetFillPaint(paint)
+		}
+		if mode != BlendInherit {
+			opacity, _ := impl.FillPaintStyle()
+			impl.SetFillPaintStyle(opacity, mode)
+		}
+	} else {
+		if paint != nil {
+			ctx.agg2d.SetLinePaint(paint)
+		}
+		if mode != BlendInherit {
+			opacity, _ := impl.LinePaintStyle()
+			impl.SetLinePaintStyle(opacity, mode)
+		}
+	}
+
+	impl.ResetPath()
+	if p != nil {
+		impl.AppendPath(p.ps)
+	}
+	ctx.agg2d.DrawPath(flag)
+}
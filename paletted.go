@@ -0,0 +1,96 @@
+package agg
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"io"
+
+	"github.com/MeKo-Christian/agg_go/internal/quantize"
+)
+
+// PaletteOptions controls the reduction of an RGBA image to 8-bit indexed
+// color by ToPaletted and EncodeGIF.
+type PaletteOptions struct {
+	// Palette is a fixed palette of up to 256 colors. nil builds one from the
+	// image with median cut.
+	Palette []Color
+	// Colors is the size of the generated palette, 2 to 256. Zero means 256.
+	// It is ignored when Palette is set.
+	Colors int
+	// Dither spreads the quantization error with Floyd–Steinberg diffusion,
+	// trading flat areas for smoother gradients.
+	Dither bool
+}
+
+// ToPaletted converts the image to 8-bit indexed color, as needed for GIF
+// export or retro-style output. Pixels with alpha below 128 become
+// transparent: a generated palette then reserves index 0 for them, and a
+// fixed palette uses its first entry with zero alpha if it has one. Colors
+// are quantized unpremultiplied, so translucent pixels keep their hue, and
+// palette entries are color.NRGBA.
+func (img *Image) ToPaletted(opts PaletteOptions) (*image.Paletted, error) {
+	if img == nil || img.renBuf == nil {
+		return nil, errors.New("image or buffer is nil")
+	}
+	src := img.toNRGBA()
+	w, h := img.width, img.height
+
+	var pal []color.NRGBA
+	if opts.Palette != nil {
+		if len(opts.Palette) == 0 || len(opts.Palette) > 256 {
+			return nil, fmt.Errorf("palette has %d colors, want 1 to 256", len(opts.Palette))
+		}
+		pal = make([]color.NRGBA, len(opts.Palette))
+		for i, c := range opts.Palette {
+			pal[i] = color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+		}
+	} else {
+		n := opts.Colors
+		if n == 0 {
+			n = 256
+		}
+		if n < 2 || n > 256 {
+			return nil, fmt.Errorf("palette size %d out of range 2 to 256", n)
+		}
+		if hasTransparency(src.Pix) {
+			pal = append([]color.NRGBA{{}}, quantize.MedianCut(src.Pix, w, h, src.Stride, n-1)...)
+		} else {
+			pal = quantize.MedianCut(src.Pix, w, h, src.Stride, n)
+		}
+		if len(pal) == 0 {
+			pal = []color.NRGBA{{}}
+		}
+	}
+
+	out := image.NewPaletted(image.Rect(0, 0, w, h), nil)
+	out.Palette = make(color.Palette, len(pal))
+	for i, c := range pal {
+		out.Palette[i] = c
+	}
+	quantize.Map(out.Pix, out.Stride, src.Pix, w, h, src.Stride, pal, opts.Dither)
+	return out, nil
+}
+
+// EncodeGIF writes the image to w as a single-frame GIF, quantized with
+// opts; see ToPaletted.
+func (img *Image) EncodeGIF(w io.Writer, opts PaletteOptions) error {
+	p, err := img.ToPaletted(opts)
+	if err != nil {
+		return err
+	}
+	return gif.Encode(w, p, &gif.Options{NumColors: len(p.Palette)})
+}
+
+// hasTransparency reports whether any pixel of an RGBA buffer counts as
+// transparent for quantization.
+func hasTransparency(pix []uint8) bool {
+	for i := 3; i < len(pix); i += 4 {
+		if pix[i] < 128 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package agg
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/agg2d"
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+// StrokeOptions describes the stroke applied by StrokePath. Zero values pick
+// the Context defaults: width 1, butt caps, miter joins and miter limit 4.
+// Dashes follows the SVG stroke-dasharray rules of Context.SetDashPattern.
+type StrokeOptions = agg2d.StrokeOptions
+
+// PathCommand identifies the kind of a PathSegment.
+type PathCommand int
+
+const (
+	PathMoveTo PathCommand = iota
+	PathLineTo
+	PathQuadTo  // quadratic Bézier: control point, end point
+	PathCubicTo // cubic Bézier: two control points, end point
+	PathClose
+)
+
+// PathSegment is one command of a Path with its points.
+type PathSegment struct {
+	Cmd    PathCommand
+	Points []Point
+}
+
+// Path is a standalone vector path, independent of any Context. Paths can be
+// stroked into outlines with StrokePath, hit-tested with Contains, inspected
+// with Segments, and drawn by appending them to a Context's current path.
+type Path struct {
+	ps *path.PathStorageStl
+}
+
+// NewPath returns an empty path.
+func NewPath() *Path {
+	return &Path{ps: path.NewPathStorageStl()}
+}
+
+// Reset removes all segments.
+func (p *Path) Reset() { p.ps.RemoveAll() }
+
+// MoveTo starts a new sub-path at (x, y).
+func (p *Path) MoveTo(x, y float64) { p.ps.MoveTo(x, y) }
+
+// LineTo adds a straight segment to (x, y).
+func (p *Path) LineTo(x, y float64) { p.ps.LineTo(x, y) }
+
+// QuadricCurveTo adds a quadratic Bézier segment.
+func (p *Path) QuadricCurveTo(xCtrl, yCtrl, xTo, yTo float64) {
+	p.ps.Curve3(xCtrl, yCtrl, xTo, yTo)
+}
+
+// CubicCurveTo adds a cubic Bézier segment.
+func (p *Path) CubicCurveTo(xCtrl1, yCtrl1, xCtrl2, yCtrl2, xTo, yTo float64) {
+	p.ps.Curve4(xCtrl1, yCtrl1, xCtrl2, yCtrl2, xTo, yTo)
+}
+
+// ClosePath closes the current sub-path.
+func (p *Path) ClosePath() { p.ps.ClosePolygon(basics.PathFlagsNone) }
+
+// CloseSubpaths closes every open sub-path, as AGG's conv_close_polygon
+// does. Filling already treats open sub-paths as closed; closing them makes
+// strokes, outlines and exports agree with the fill.
+func (p *Path) CloseSubpaths() {
+	closed := path.NewPathStorageStl()
+	closed.ConcatPath(&closeSource{vs: conv.NewConvClosePolygon(path.NewPathStorageStlVertexSourceAdapter(p.ps))}, 0)
+	p.ps = closed
+}
+
+// FixOrientation reverses the sub-paths not wound in direction dir, so all
+// of them share one orientation, as AGG's arrange_orientations does.
+// Directions follow AGG's y-up convention used by AddEllipse; in y-down pixel
+// coordinates a CW path turns counter-clockwise on screen. Under the non-zero
+// rule, sub-paths of one orientation fill as their union, so holes close up;
+// fill with the even-odd rule to keep them.
+func (p *Path) FixOrientation(dir Direction) {
+	orientation := basics.PathFlagsCCW
+	if dir == CW {
+		orientation = basics.PathFlagsCW
+	}
+	p.ps.ArrangeOrientationsAllPaths(orientation)
+}
+
+// closeSource feeds conv_close_polygon output to PathStorage.ConcatPath.
+// Like AGG's, the converter closes already closed sub-paths a second time;
+// the repeated end-poly command is dropped.
+type closeSource struct {
+	vs      *conv.ConvClosePolygon
+	endPoly bool
+}
+
+func (s *closeSource) Rewind(pathID uint) {
+	s.vs.Rewind(pathID)
+	s.endPoly = false
+}
+
+func (s *closeSource) NextVertex() (x, y float64, cmd uint32) {
+	for {
+		x, y, c := s.vs.Vertex()
+		if basics.IsEndPoly(c) && s.endPoly {
+			continue
+		}
+		s.endPoly = basics.IsEndPoly(c)
+		return x, y, uint32(c)
+	}
+}
+
+// Segments returns the path as a list of commands with their points.
+func (p *Path) Segments() []PathSegment {
+	var segs []PathSegment
+	n := p.ps.TotalVertices()
+	for i := uint(0); i < n; i++ {
+		x, y, raw := p.ps.Vertex(i)
+		cmd := basics.PathCommand(raw)
+		pt := Point{X: x, Y: y}
+		switch {
+		case basics.IsMoveTo(cmd):
+			segs = append(segs, PathSegment{Cmd: PathMoveTo, Points: []Point{pt}})
+		case cmd == basics.PathCmdCurve3 && i+1 < n:
+			x2, y2, _ := p.ps.Vertex(i + 1)
+			segs = append(segs, PathSegment{Cmd: PathQuadTo, Points: []Point{pt, {X: x2, Y: y2}}})
+			i++
+		case cmd == basics.PathCmdCurve4 && i+2 < n:
+			x2, y2, _ := p.ps.Vertex(i + 1)
+			x3, y3, _ := p.ps.Vertex(i + 2)
+			segs = append(segs, PathSegment{Cmd: PathCubicTo, Points: []Point{pt, {X: x2, Y: y2}, {X: x3, Y: y3}}})
+			i += 2
+		case basics.IsVertex(cmd):
+			segs = append(segs, PathSegment{Cmd: PathLineTo, Points: []Point{pt}})
+		case basics.IsEndPoly(cmd) && basics.IsClosed(raw):
+			segs = append(segs, PathSegment{Cmd: PathClose})
+		}
+	}
+	return segs
+}
+
+// Contains reports whether (x, y) lies inside the path filled with the
+// non-zero winding rule, or the even-odd rule when evenOdd is set. Open
+// sub-paths are treated as closed, as when filling.
+func (p *Path) Contains(x, y float64, evenOdd bool) bool {
+	winding := 0
+	var startX, startY, lastX, lastY float64
+	open := false
+	edge := func(x1, y1, x2, y2 float64) {
+		if y1 <= y {
+			if y2 > y && (x2-x1)*(y-y1)-(x-x1)*(y2-y1) > 0 {
+				winding++
+			}
+		} else if y2 <= y && (x2-x1)*(y-y1)-(x-x1)*(y2-y1) < 0 {
+			winding--
+		}
+	}
+
+	src := conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(p.ps))
+	src.Rewind(0)
+	for {
+		vx, vy, cmd := src.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		switch {
+		case basics.IsMoveTo(cmd):
+			if open {
+				edge(lastX, lastY, startX, startY)
+			}
+			startX, startY, lastX, lastY = vx, vy, vx, vy
+			open = true
+		case basics.IsVertex(cmd):
+			edge(lastX, lastY, vx, vy)
+			lastX, lastY = vx, vy
+		}
+	}
+	if open {
+		edge(lastX, lastY, startX, startY)
+	}
+
+	if evenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}
+
+// HitTestPoints reports for every point whether the pixel containing it is
+// touched when p is filled with fillRule, the pixel-accurate counterpart of
+// Contains. The path is rasterized once and each scanline walked once for all
+// points on it, which suits picking many points, such as a pointer trail or
+// sample grid, against the same shape.
+func (p *Path) HitTestPoints(pts []Point, fillRule FillRule) []bool {
+	ipts := make([]basics.Point[float64], len(pts))
+	for i, pt := range pts {
+		ipts[i] = basics.Point[float64]{X: pt.X, Y: pt.Y}
+	}
+	hits := make([]bool, len(pts))
+	agg2d.HitTestPath(p.ps, fillRule == FillEvenOdd, ipts, hits)
+	return hits
+}
+
+// StrokePath returns the outline of p stroked with opts as a new path made of
+// closed polygons. Filling the result with the non-zero rule covers exactly
+// what stroking p would, so it can be hit-tested, exported or combined with
+// other paths.
+func StrokePath(p *Path, opts StrokeOptions) *Path {
+	return &Path{ps: agg2d.StrokeOutline(p.ps, opts)}
+}
+
+// TransformPath returns a copy of p with every vertex, curve control points
+// included, mapped through m. A nil m returns an unchanged copy.
+func TransformPath(p *Path, m *Transformations) *Path {
+	out := NewPath()
+	out.ps.ConcatPath(p.ps, 0)
+	if m == nil {
+		return out
+	}
+	for i := uint(0); i < out.ps.TotalVertices(); i++ {
+		x, y, cmd := out.ps.Vertex(i)
+		if basics.IsVertex(basics.PathCommand(cmd)) {
+			x, y = m.Transform(x, y)
+			out.ps.ModifyVertex(i, x, y)
+		}
+	}
+	return out
+}
+
+// DashPath returns p cut into dashes as a new path of open polylines, with
+// curves flattened. pattern and offset follow SVG stroke-dasharray and
+// stroke-dashoffset; an empty pattern returns p flattened.
+func DashPath(p *Path, pattern []float64, offset float64) *Path {
+	return &Path{ps: agg2d.DashOutline(p.ps, pattern, offset)}
+}
+
+// RoundCorners returns p with every corner rounded by a circular arc of
+// radius r, tangent to both edges, for tags, speech bubbles, stars and other
+// shapes beyond rectangles. Corners whose edges are too short for r get the
+// largest radius that fits, each edge giving at most half its length to
+// either end. The end points of open sub-paths stay sharp. Curves in p are
+// flattened first, so their smooth joins are left practically unchanged; the
+// arcs are stored as cubic curves.
+func RoundCorners(p *Path, r float64) *Path {
+	return &Path{ps: agg2d.RoundCornersOutline(p.ps, r)}
+}
+
+// Transformed returns p mapped through m; see TransformPath. Together with
+// Dashed, Rounded and Stroked it chains converters into a pipeline, each step
+// returning a new path:
+//
+//	outline := p.Transformed(m).Dashed([]float64{6, 3}).Stroked(opts)
+func (p *Path) Transformed(m *Transformations) *Path {
+	return TransformPath(p, m)
+}
+
+// Dashed returns p cut into dashes with a zero offset; see DashPath.
+func (p *Path) Dashed(pattern []float64) *Path {
+	return DashPath(p, pattern, 0)
+}
+
+// Rounded returns p with rounded corners; see RoundCorners.
+func (p *Path) Rounded(r float64) *Path {
+	return RoundCorners(p, r)
+}
+
+// Stroked returns the stroke outline of p; see StrokePath.
+func (p *Path) Stroked(opts StrokeOptions) *Path {
+	return StrokePath(p, opts)
+}
+
+// RenderMask rasterizes p into a width x height ImageGray8 coverage mask,
+// with 0 outside the path, 255 inside and anti-aliased edges. Path
+// coordinates are pixels. The mask can drive an alpha-mask adaptor, be
+// blurred, or serve as a stencil when compositing images.
+func RenderMask(p *Path, width, height int, fillRule FillRule) *Image {
+	width, height = max(width, 0), max(height, 0)
+	buf := agg2d.RenderMask(p.ps, width, height, fillRule == FillEvenOdd)
+	return NewGrayImage(buf, width, height, width)
+}
+
+// AppendPath adds the sub-paths of p to the current path, so they are drawn
+// by the next Fill or Stroke under the current transform.
+func (ctx *Context) AppendPath(p *Path) {
+	ctx.agg2d.impl.AppendPath(p.ps)
+}
@@ -0,0 +1,131 @@
+package agg
+
+import (
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+// measureSegment is one straight piece of a flattened path, starting at
+// distance start from the beginning of the path.
+type measureSegment struct {
+	x0, y0, x1, y1 float64
+	start, length  float64
+	// first marks the first segment of a sub-path.
+	first bool
+}
+
+// measure flattens p with the adaptive curve subdivision used for drawing
+// and returns its segments and total length. Closed sub-paths include their
+// closing segment; zero-length segments are dropped.
+func (p *Path) measure() ([]measureSegment, float64) {
+	var segs []measureSegment
+	total := 0.0
+	var startX, startY, lastX, lastY float64
+	first := false
+	add := func(x, y float64) {
+		l := math.Hypot(x-lastX, y-lastY)
+		if l > 0 {
+			segs = append(segs, measureSegment{x0: lastX, y0: lastY, x1: x, y1: y, start: total, length: l, first: first})
+			total += l
+			first = false
+		}
+		lastX, lastY = x, y
+	}
+
+	src := conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(p.ps))
+	src.Rewind(0)
+	for {
+		x, y, cmd := src.Vertex()
+		switch {
+		case basics.IsStop(cmd):
+			return segs, total
+		case basics.IsMoveTo(cmd):
+			startX, startY, lastX, lastY = x, y, x, y
+			first = true
+		case basics.IsVertex(cmd):
+			add(x, y)
+		case basics.IsEndPoly(cmd) && basics.IsClosed(uint32(cmd)):
+			add(startX, startY)
+		}
+	}
+}
+
+// Length returns the length of the path: the sum of its sub-paths, with
+// curves flattened as for drawing and closed sub-paths including the edge
+// back to their start.
+func (p *Path) Length() float64 {
+	_, total := p.measure()
+	return total
+}
+
+// PointAtLength returns the point at distance d along the path and the
+// direction of the path there, in radians as for math.Atan2. Distances
+// outside [0, Length()] are clamped to the ends. Sub-paths follow one
+// another without gaps, so the distance skips from the end of one to the
+// start of the next. An empty path gives (0, 0, 0).
+func (p *Path) PointAtLength(d float64) (x, y, angle float64) {
+	segs, total := p.measure()
+	if len(segs) == 0 {
+		if n := p.ps.TotalVertices(); n > 0 {
+			x, y, _ = p.ps.Vertex(0)
+		}
+		return x, y, 0
+	}
+	d = math.Max(0, math.Min(total, d))
+	s := segs[segmentAt(segs, d)]
+	t := (d - s.start) / s.length
+	return s.x0 + (s.x1-s.x0)*t, s.y0 + (s.y1-s.y0)*t, math.Atan2(s.y1-s.y0, s.x1-s.x0)
+}
+
+// Slice returns the part of the path between distances d0 and d1 as a new
+// path of straight segments, each piece of a sub-path starting with a
+// MoveTo. The distances are clamped to [0, Length()]; an empty path is
+// returned when d1 <= d0.
+func (p *Path) Slice(d0, d1 float64) *Path {
+	out := NewPath()
+	segs, total := p.measure()
+	d0, d1 = math.Max(0, d0), math.Min(total, d1)
+	if len(segs) == 0 || d1 <= d0 {
+		return out
+	}
+
+	point := func(s measureSegment, d float64) (float64, float64) {
+		t := (d - s.start) / s.length
+		return s.x0 + (s.x1-s.x0)*t, s.y0 + (s.y1-s.y0)*t
+	}
+	i := segmentAt(segs, d0)
+	out.MoveTo(point(segs[i], d0))
+	for ; i < len(segs); i++ {
+		s := segs[i]
+		if s.start >= d1 {
+			break
+		}
+		if s.first && s.start > d0 {
+			out.MoveTo(s.x0, s.y0)
+		}
+		if s.start+s.length > d1 {
+			out.LineTo(point(s, d1))
+			break
+		}
+		out.LineTo(s.x1, s.y1)
+	}
+	return out
+}
+
+// segmentAt returns the index of the segment containing distance d, which
+// must lie in [0, total].
+func segmentAt(segs []measureSegment, d float64) int {
+	lo, hi := 0, len(segs)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		if segs[mid].start <= d {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+	return lo
+}
@@ -0,0 +1,205 @@
+package agg
+
+import (
+	"errors"
+	"image"
+	imgcolor "image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+
+	"github.com/MeKo-Christian/agg_go/internal/color"
+)
+
+// AlphaMode tells how the colors of a 32-bit RGBA image, such as RGBA8 or
+// BGRA8, relate to its alpha.
+type AlphaMode int
+
+const (
+	// AlphaPremultiplied colors are scaled by alpha, the form image drawing
+	// and filtering expect. It is the default.
+	AlphaPremultiplied AlphaMode = iota
+	// AlphaStraight colors are stored unscaled, as in PNG files. Straight
+	// images are premultiplied on the fly when drawn.
+	AlphaStraight
+)
+
+// DecodeOptions configures DecodePNG.
+type DecodeOptions struct {
+	// Alpha selects how translucent pixels are stored. AlphaStraight keeps
+	// the file's colors exactly, for editing them; the default premultiplies
+	// them, so drawing the image needs no conversion.
+	Alpha AlphaMode
+	// ToLinear converts colors from the sRGB transfer curve to linear light
+	// and flags the image as linear, for images drawn in a linear working
+	// space. It is the inverse of EncodeOptions.FromLinear; encoding a
+	// linear image converts it back. Alpha is left as is.
+	ToLinear bool
+}
+
+// DecodePNG reads a PNG image into a new RGBA image according to opts.
+// Colors are converted with 16-bit precision before rounding to 8 bits, so
+// premultiplying and linearizing lose no more than the final rounding.
+func DecodePNG(r io.Reader, opts DecodeOptions) (*Image, error) {
+	m, err := png.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	if opts == (DecodeOptions{}) {
+		return NewImageFromStandardImage(m)
+	}
+
+	b := m.Bounds()
+	w, h := b.Dx(), b.Dy()
+	pix := make([]uint8, w*h*4)
+	to8 := func(v float64) uint8 { return uint8(math.Round(v * 255)) }
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := imgcolor.NRGBA64Model.Convert(m.At(b.Min.X+x, b.Min.Y+y)).(imgcolor.NRGBA64)
+			rgb := [3]float64{float64(c.R) / 65535, float64(c.G) / 65535, float64(c.B) / 65535}
+			a := float64(c.A) / 65535
+			p := pix[(y*w+x)*4:]
+			for i, v := range rgb {
+				if opts.ToLinear {
+					v = srgbToLinear(v)
+				}
+				if opts.Alpha == AlphaPremultiplied {
+					v *= a
+				}
+				p[i] = to8(v)
+			}
+			p[3] = to8(a)
+		}
+	}
+	img := NewImage(pix, w, h, w*4)
+	img.alpha = opts.Alpha
+	img.linear = opts.ToLinear
+	return img, nil
+}
+
+// LoadPNG reads a PNG file like DecodePNG.
+func LoadPNG(filename string, opts DecodeOptions) (*Image, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodePNG(f, opts)
+}
+
+// AlphaMode returns how the image's colors relate to its alpha.
+func (img *Image) AlphaMode() AlphaMode {
+	return img.alpha
+}
+
+// IsLinear reports whether the image's colors are in linear light rather
+// than on the sRGB transfer curve.
+func (img *Image) IsLinear() bool {
+	return img.linear
+}
+
+// SetLinear flags the image's colors as linear light or sRGB without
+// converting them.
+func (img *Image) SetLinear(linear bool) {
+	img.linear = linear
+}
+
+// Premultiply scales the colors of a straight-alpha 32-bit RGBA image by its
+// alpha in place and marks it premultiplied. Premultiplied images are left
+// alone.
+func (img *Image) Premultiply() error {
+	if err := img.checkAlphaFormat(); err != nil || img.alpha == AlphaPremultiplied {
+		return err
+	}
+	o, _ := img.format.channelOrder()
+	premultiplyPixels(img.Data, img.width, img.height, img.renBuf.Stride(), o)
+	img.alpha = AlphaPremultiplied
+	return nil
+}
+
+// Demultiply divides the colors of a premultiplied 32-bit RGBA image by its
+// alpha in place and marks it straight. Fully transparent pixels become
+// black, and colors of nearly transparent pixels lose precision.
+func (img *Image) Demultiply() error {
+	if err := img.checkAlphaFormat(); err != nil || img.alpha == AlphaStraight {
+		return err
+	}
+	o, _ := img.format.channelOrder()
+	demultiplyPixels(img.Data, img.width, img.height, img.renBuf.Stride(), o)
+	img.alpha = AlphaStraight
+	return nil
+}
+
+func (img *Image) checkAlphaFormat() error {
+	if img == nil {
+		return errors.New("image is nil")
+	}
+	if _, ok := img.format.channelOrder(); !ok {
+		return errors.New("alpha modes apply to 32-bit RGBA images")
+	}
+	return nil
+}
+
+// premultiplyPixels scales the colors of 4-byte pixels in order o by their
+// alpha.
+func premultiplyPixels(pix []uint8, width, height, stride int, o color.ColorOrder) {
+	for y := 0; y < height; y++ {
+		row := pix[y*stride:]
+		for x := 0; x < width*4; x += 4 {
+			a := row[x+o.A]
+			row[x+o.R] = color.RGBA8Multiply(row[x+o.R], a)
+			row[x+o.G] = color.RGBA8Multiply(row[x+o.G], a)
+			row[x+o.B] = color.RGBA8Multiply(row[x+o.B], a)
+		}
+	}
+}
+
+// demultiplyPixels divides the colors of 4-byte pixels in order o by their
+// alpha, rounding to nearest. Fully transparent pixels become black.
+func demultiplyPixels(pix []uint8, width, height, stride int, o color.ColorOrder) {
+	for y := 0; y < height; y++ {
+		row := pix[y*stride:]
+		for x := 0; x < width*4; x += 4 {
+			a := int(row[x+o.A])
+			for _, i := range [3]int{x + o.R, x + o.G, x + o.B} {
+				if a == 0 {
+					row[i] = 0
+				} else {
+					row[i] = uint8(min((int(row[i])*255+a/2)/a, 255))
+				}
+			}
+		}
+	}
+}
+
+// toNRGBA returns the image as straight-alpha RGBA, the form file formats
+// other than image.RGBA store.
+func (img *Image) toNRGBA() *image.NRGBA {
+	rgba := img.ToGoImage()
+	m := &image.NRGBA{Pix: rgba.Pix, Stride: rgba.Stride, Rect: rgba.Rect}
+	if img.alpha == AlphaStraight {
+		// Copy the stored colors rather than round-tripping them.
+		o, _ := img.format.channelOrder()
+		stride := img.renBuf.Stride()
+		for y := 0; y < img.height; y++ {
+			for x := 0; x < img.width; x++ {
+				src, dst := img.Data[y*stride+x*4:], m.Pix[y*m.Stride+x*4:]
+				dst[0], dst[1], dst[2], dst[3] = src[o.R], src[o.G], src[o.B], src[o.A]
+			}
+		}
+		return m
+	}
+	demultiplyPixels(m.Pix, img.width, img.height, m.Stride, color.OrderRGBA)
+	return m
+}
+
+// premultipliedData returns the pixels of a straight-alpha image
+// premultiplied, in a copy with the same stride.
+func (img *Image) premultipliedData() []uint8 {
+	pix := make([]uint8, len(img.Data))
+	copy(pix, img.Data)
+	o, _ := img.format.channelOrder()
+	premultiplyPixels(pix, img.width, img.height, img.renBuf.Stride(), o)
+	return pix
+}
@@ -0,0 +1,749 @@
+package agg
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/MeKo-Christian/agg_go/internal/agg2d"
+	"github.com/MeKo-Christian/agg_go/internal/transform"
+)
+
+// Recording captures drawing commands instead of rasterizing them.
+//
+// A Recording implements Canvas, mirroring the common Context drawing API
+// (paths, fills, strokes, shapes, text, images and transforms). The captured
+// display list can be replayed into a raster Context or Agg2D, optionally
+// under an extra transform, cached as an image, diffed against another
+// recording, or exported as a single-page PDF via WritePDF.
+type Recording struct {
+	width  int
+	height int
+
+	ops   []recOp
+	state recState
+	stack [][6]float64
+	path  []recSeg
+
+	version      uint64 // bumped whenever ops change
+	cache        *Context
+	cacheVersion uint64
+}
+
+type recOpKind int
+
+const (
+	recOpClear recOpKind = iota
+	recOpFill
+	recOpStroke
+	recOpText
+	recOpImage
+)
+
+type recSegKind int
+
+const (
+	recSegMove recSegKind = iota
+	recSegLine
+	recSegQuad
+	recSegCubic
+	recSegClose
+	recSegEllipse
+	recSegRoundedRect
+)
+
+// recSeg is one path element. Quad and cubic segments store their control
+// points first and the end point last; ellipses store cx, cy, rx, ry and
+// rounded rectangles x1, y1, x2, y2, radius.
+type recSeg struct {
+	kind recSegKind
+	pts  [6]float64
+}
+
+// recState is the graphics state snapshotted with each drawing command.
+type recState struct {
+	fillColor  Color
+	lineColor  Color
+	lineWidth  float64
+	lineCap    LineCap
+	lineJoin   LineJoin
+	miterLimit float64
+	dashes     []float64
+	dashOffset float64
+	evenOdd    bool
+	matrix     [6]float64
+
+	fontFile   string
+	fontHeight float64
+	fontBold   bool
+	fontItalic bool
+	fontCache  FontCacheType
+	fontAngle  float64
+}
+
+type recOp struct {
+	kind  recOpKind
+	state recState
+	path  []recSeg
+	color Color
+	text  string
+	x, y  float64
+	w, h  float64
+	img   *Image
+}
+
+// NewRecording creates an empty recording for a width x height page.
+//
+// The page size is used as the PDF media box; replaying into a raster target
+// of a different size simply clips or leaves unused space.
+func NewRecording(width, height int) *Recording {
+	r := &Recording{width: width, height: height}
+	r.resetState()
+	return r
+}
+
+func (r *Recording) resetState() {
+	r.state = recState{
+		fillColor:  Black,
+		lineColor:  Black,
+		lineWidth:  1.0,
+		lineCap:    CapRound,
+		lineJoin:   JoinRound,
+		miterLimit: 4.0,
+		matrix:     [6]float64{1, 0, 0, 1, 0, 0},
+	}
+	r.stack = r.stack[:0]
+	r.path = nil
+}
+
+// Width returns the recording page width.
+func (r *Recording) Width() int { return r.width }
+
+// Height returns the recording page height.
+func (r *Recording) Height() int { return r.height }
+
+// Len returns the number of recorded drawing commands.
+func (r *Recording) Len() int { return len(r.ops) }
+
+// Reset discards all recorded commands and restores the default state.
+func (r *Recording) Reset() {
+	r.ops = r.ops[:0]
+	r.version++
+	r.resetState()
+}
+
+func (r *Recording) push(op recOp) {
+	r.ops = append(r.ops, op)
+	r.version++
+}
+
+// snapshot returns a copy of the current state that later mutations cannot
+// affect.
+func (r *Recording) snapshot() recState {
+	s := r.state
+	if s.dashes != nil {
+		s.dashes = append([]float64(nil), s.dashes...)
+	}
+	return s
+}
+
+func (r *Recording) addPathOp(kind recOpKind, path []recSeg) {
+	if len(path) == 0 {
+		return
+	}
+	r.push(recOp{kind: kind, state: r.snapshot(), path: path})
+}
+
+// State
+
+// Clear records filling the whole page with color.
+func (r *Recording) Clear(color Color) {
+	r.path = nil
+	r.push(recOp{kind: recOpClear, color: color})
+}
+
+// SetColor sets both the fill and stroke colors.
+func (r *Recording) SetColor(color Color) {
+	r.state.fillColor = color
+	r.state.lineColor = color
+}
+
+// SetFillColor sets the fill color only.
+func (r *Recording) SetFillColor(color Color) { r.state.fillColor = color }
+
+// SetStrokeColor sets the stroke color only.
+func (r *Recording) SetStrokeColor(color Color) { r.state.lineColor = color }
+
+// SetLineWidth sets the stroke width.
+func (r *Recording) SetLineWidth(width float64) { r.state.lineWidth = width }
+
+// SetLineCap sets the stroke cap style.
+func (r *Recording) SetLineCap(lineCap LineCap) { r.state.lineCap = lineCap }
+
+// SetLineJoin sets the stroke join style.
+func (r *Recording) SetLineJoin(join LineJoin) { r.state.lineJoin = join }
+
+// SetMiterLimit sets the miter limit for miter joins.
+func (r *Recording) SetMiterLimit(limit float64) { r.state.miterLimit = limit }
+
+// SetDashPattern sets alternating dash and gap lengths with the SVG
+// stroke-dasharray semantics of Context.SetDashPattern: odd-length patterns
+// are repeated, and an empty, negative or all-zero pattern disables dashing.
+func (r *Recording) SetDashPattern(pattern []float64) {
+	r.state.dashes = slices.Clone(agg2d.SVGDashPattern(pattern))
+}
+
+// ClearDashes disables dashing.
+func (r *Recording) ClearDashes() { r.state.dashes = nil }
+
+// SetDashOffset sets the starting offset into the dash pattern.
+func (r *Recording) SetDashOffset(offset float64) { r.state.dashOffset = offset }
+
+// SetFillEvenOdd selects the even-odd (true) or non-zero (false) fill rule.
+func (r *Recording) SetFillEvenOdd(evenOdd bool) { r.state.evenOdd = evenOdd }
+
+// Transforms
+
+func (r *Recording) affine() *transform.TransAffine {
+	return transform.NewTransAffineFromArray(r.state.matrix)
+}
+
+func (r *Recording) setAffine(t *transform.TransAffine) {
+	r.state.matrix = t.ToArray()
+}
+
+// GetTransform returns the current transformation matrix.
+func (r *Recording) GetTransform() *Transformations {
+	return &Transformations{AffineMatrix: r.state.matrix}
+}
+
+// SetTransform replaces the current transformation matrix.
+func (r *Recording) SetTransform(tr *Transformations) {
+	if tr == nil {
+		return
+	}
+	r.state.matrix = tr.AffineMatrix
+}
+
+// ResetTransform resets the transformation matrix to identity.
+func (r *Recording) ResetTransform() {
+	r.state.matrix = [6]float64{1, 0, 0, 1, 0, 0}
+}
+
+// Transform multiplies the current transformation matrix with tr.
+func (r *Recording) Transform(tr *Transformations) {
+	if tr == nil {
+		return
+	}
+	r.setAffine(r.affine().Multiply(transform.NewTransAffineFromArray(tr.AffineMatrix)))
+}
+
+// Translate applies a translation.
+func (r *Recording) Translate(tx, ty float64) { r.setAffine(r.affine().Translate(tx, ty)) }
+
+// Rotate applies a rotation (angle in radians).
+func (r *Recording) Rotate(angle float64) { r.setAffine(r.affine().Rotate(angle)) }
+
+// Scale applies a scaling transformation.
+func (r *Recording) Scale(sx, sy float64) { r.setAffine(r.affine().ScaleXY(sx, sy)) }
+
+// Skew applies a skewing transformation (angles in radians).
+func (r *Recording) Skew(sx, sy float64) {
+	r.setAffine(r.affine().Multiply(transform.NewTransAffineFromValues(1, math.Tan(sy), math.Tan(sx), 1, 0, 0)))
+}
+
+// PushTransform saves the current transformation on the stack.
+func (r *Recording) PushTransform() {
+	r.stack = append(r.stack, r.state.matrix)
+}
+
+// PopTransform restores the last saved transformation from the stack.
+func (r *Recording) PopTransform() bool {
+	if len(r.stack) == 0 {
+		return false
+	}
+	r.state.matrix = r.stack[len(r.stack)-1]
+	r.stack = r.stack[:len(r.stack)-1]
+	return true
+}
+
+// Path construction
+
+// BeginPath clears the current path.
+func (r *Recording) BeginPath() { r.path = nil }
+
+// MoveTo starts a new subpath at x, y.
+func (r *Recording) MoveTo(x, y float64) {
+	r.path = append(r.path, recSeg{kind: recSegMove, pts: [6]float64{x, y}})
+}
+
+// LineTo appends a straight segment to x, y.
+func (r *Recording) LineTo(x, y float64) {
+	r.path = append(r.path, recSeg{kind: recSegLine, pts: [6]float64{x, y}})
+}
+
+// QuadricCurveTo appends a quadratic Bézier segment.
+func (r *Recording) QuadricCurveTo(xCtrl, yCtrl, xTo, yTo float64) {
+	r.path = append(r.path, recSeg{kind: recSegQuad, pts: [6]float64{xCtrl, yCtrl, xTo, yTo}})
+}
+
+// CubicCurveTo appends a cubic Bézier segment.
+func (r *Recording) CubicCurveTo(xCtrl1, yCtrl1, xCtrl2, yCtrl2, xTo, yTo float64) {
+	r.path = append(r.path, recSeg{kind: recSegCubic, pts: [6]float64{xCtrl1, yCtrl1, xCtrl2, yCtrl2, xTo, yTo}})
+}
+
+// ClosePath closes the current subpath.
+func (r *Recording) ClosePath() {
+	r.path = append(r.path, recSeg{kind: recSegClose})
+}
+
+// Fill records filling the current path with the current fill color.
+func (r *Recording) Fill() { r.addPathOp(recOpFill, r.path) }
+
+// Stroke records stroking the current path with the current stroke state.
+func (r *Recording) Stroke() { r.addPathOp(recOpStroke, r.path) }
+
+// Shapes
+
+func rectPath(x, y, width, height float64) []recSeg {
+	return []recSeg{
+		{kind: recSegMove, pts: [6]float64{x, y}},
+		{kind: recSegLine, pts: [6]float64{x + width, y}},
+		{kind: recSegLine, pts: [6]float64{x + width, y + height}},
+		{kind: recSegLine, pts: [6]float64{x, y + height}},
+		{kind: recSegClose},
+	}
+}
+
+func ellipsePath(cx, cy, rx, ry float64) []recSeg {
+	return []recSeg{{kind: recSegEllipse, pts: [6]float64{cx, cy, rx, ry}}}
+}
+
+// roundedRectPath builds a rounded rectangle from lines and cubic quarter arcs.
+func roundedRectPath(x, y, x2, y2, radius float64) []recSeg {
+	if x2 < x {
+		x, x2 = x2, x
+	}
+	if y2 < y {
+		y, y2 = y2, y
+	}
+	radius = math.Max(0, math.Min(radius, math.Min(x2-x, y2-y)/2))
+	if radius == 0 {
+		return rectPath(x, y, x2-x, y2-y)
+	}
+	k := radius * (1 - bezierArcKappa)
+	return []recSeg{
+		{kind: recSegMove, pts: [6]float64{x + radius, y}},
+		{kind: recSegLine, pts: [6]float64{x2 - radius, y}},
+		{kind: recSegCubic, pts: [6]float64{x2 - k, y, x2, y + k, x2, y + radius}},
+		{kind: recSegLine, pts: [6]float64{x2, y2 - radius}},
+		{kind: recSegCubic, pts: [6]float64{x2, y2 - k, x2 - k, y2, x2 - radius, y2}},
+		{kind: recSegLine, pts: [6]float64{x + radius, y2}},
+		{kind: recSegCubic, pts: [6]float64{x + k, y2, x, y2 - k, x, y2 - radius}},
+		{kind: recSegLine, pts: [6]float64{x, y + radius}},
+		{kind: recSegCubic, pts: [6]float64{x, y + k, x + k, y, x + radius, y}},
+		{kind: recSegClose},
+	}
+}
+
+// bezierArcKappa is the control-point distance for a cubic quarter circle.
+const bezierArcKappa = 0.5522847498307936
+
+// DrawLine records a stroked line.
+func (r *Recording) DrawLine(x1, y1, x2, y2 float64) {
+	r.addPathOp(recOpStroke, []recSeg{
+		{kind: recSegMove, pts: [6]float64{x1, y1}},
+		{kind: recSegLine, pts: [6]float64{x2, y2}},
+	})
+}
+
+// DrawRectangle records a stroked rectangle.
+func (r *Recording) DrawRectangle(x, y, width, height float64) {
+	r.addPathOp(recOpStroke, rectPath(x, y, width, height))
+}
+
+// FillRectangle records a filled rectangle.
+func (r *Recording) FillRectangle(x, y, width, height float64) {
+	r.addPathOp(recOpFill, rectPath(x, y, width, height))
+}
+
+// DrawCircle records a stroked circle.
+func (r *Recording) DrawCircle(cx, cy, radius float64) {
+	r.addPathOp(recOpStroke, ellipsePath(cx, cy, radius, radius))
+}
+
+// FillCircle records a filled circle.
+func (r *Recording) FillCircle(cx, cy, radius float64) {
+	r.addPathOp(recOpFill, ellipsePath(cx, cy, radius, radius))
+}
+
+// DrawEllipse records a stroked ellipse.
+func (r *Recording) DrawEllipse(cx, cy, rx, ry float64) {
+	r.addPathOp(recOpStroke, ellipsePath(cx, cy, rx, ry))
+}
+
+// FillEllipse records a filled ellipse.
+func (r *Recording) FillEllipse(cx, cy, rx, ry float64) {
+	r.addPathOp(recOpFill, ellipsePath(cx, cy, rx, ry))
+}
+
+// DrawRoundedRectangle records a stroked rounded rectangle.
+func (r *Recording) DrawRoundedRectangle(x, y, width, height, radius float64) {
+	r.addPathOp(recOpStroke, []recSeg{{kind: recSegRoundedRect, pts: [6]float64{x, y, x + width, y + height, radius}}})
+}
+
+// FillRoundedRectangle records a filled rounded rectangle.
+func (r *Recording) FillRoundedRectangle(x, y, width, height, radius float64) {
+	r.addPathOp(recOpFill, []recSeg{{kind: recSegRoundedRect, pts: [6]float64{x, y, x + width, y + height, radius}}})
+}
+
+// Text and images
+
+// Font records the font used by subsequent DrawText calls. The font file is
+// only loaded when the recording is replayed; PDF export substitutes the
+// standard Helvetica font at the same height.
+func (r *Recording) Font(fileName string, height float64, bold, italic bool, cacheType FontCacheType, angle float64) error {
+	if fileName == "" {
+		return errors.New("font file name is empty")
+	}
+	r.state.fontFile = fileName
+	r.state.fontHeight = height
+	r.state.fontBold = bold
+	r.state.fontItalic = italic
+	r.state.fontCache = cacheType
+	r.state.fontAngle = angle
+	return nil
+}
+
+// DrawText records text with its baseline starting at x, y.
+func (r *Recording) DrawText(text string, x, y float64) error {
+	if text == "" {
+		return errors.New("text is empty")
+	}
+	r.push(recOp{kind: recOpText, state: r.snapshot(), text: text, x: x, y: y})
+	return nil
+}
+
+// DrawImage records drawing img with its top-left corner at x, y.
+func (r *Recording) DrawImage(img *Image, x, y float64) error {
+	if img == nil {
+		return errors.New("image is nil")
+	}
+	return r.DrawImageScaled(img, x, y, float64(img.Width()), float64(img.Height()))
+}
+
+// DrawImageScaled records drawing img scaled into the given rectangle.
+//
+// The image pixels are copied, so later changes to img do not affect the
+// recording.
+func (r *Recording) DrawImageScaled(img *Image, x, y, width, height float64) error {
+	clone, err := CloneImage(img)
+	if err != nil {
+		return err
+	}
+	r.push(recOp{kind: recOpImage, state: r.snapshot(), img: clone, x: x, y: y, w: width, h: height})
+	return nil
+}
+
+// Replay
+
+// Replay draws all recorded commands into ctx.
+func (r *Recording) Replay(ctx *Context) error {
+	if ctx == nil {
+		return errors.New("context is nil")
+	}
+	return r.ReplayAgg2D(ctx.agg2d)
+}
+
+// ReplayTransformed draws all recorded commands into ctx with tr applied on
+// top of each command's own transformation, e.g. to place a scene inside a
+// larger layout or to render a thumbnail.
+//
+// With a non-nil tr, Clear commands fill the transformed page rectangle
+// instead of the whole target.
+func (r *Recording) ReplayTransformed(ctx *Context, tr *Transformations) error {
+	if ctx == nil {
+		return errors.New("context is nil")
+	}
+	return r.ReplayAgg2DTransformed(ctx.agg2d, tr)
+}
+
+// ReplayAgg2D draws all recorded commands into a.
+//
+// Each command sets the colors, stroke attributes and transformation it was
+// recorded with; the target's transformation is restored afterwards. Fonts
+// are loaded on demand and the first font error aborts the replay.
+func (r *Recording) ReplayAgg2D(a *Agg2D) error {
+	return r.ReplayAgg2DTransformed(a, nil)
+}
+
+// ReplayAgg2DTransformed is the Agg2D counterpart of ReplayTransformed.
+func (r *Recording) ReplayAgg2DTransformed(a *Agg2D, tr *Transformations) error {
+	if a == nil {
+		return errors.New("agg2d is nil")
+	}
+	saved := a.GetTransformations()
+	defer a.SetTransformations(saved)
+
+	var extra *transform.TransAffine
+	if tr != nil {
+		extra = transform.NewTransAffineFromArray(tr.AffineMatrix)
+	}
+
+	var font recState
+	for i := range r.ops {
+		op := &r.ops[i]
+		if op.kind == recOpClear {
+			if extra == nil {
+				a.ClearAll(op.color)
+				continue
+			}
+			a.SetTransformations(tr)
+			a.FillColor(op.color)
+			a.NoLine()
+			a.Rectangle(0, 0, float64(r.width), float64(r.height))
+			continue
+		}
+		applyRecState(a, &op.state, extra)
+
+		switch op.kind {
+		case recOpFill, recOpStroke:
+			a.ResetPath()
+			for _, seg := range op.path {
+				p := seg.pts
+				switch seg.kind {
+				case recSegMove:
+					a.MoveTo(p[0], p[1])
+				case recSegLine:
+					a.LineTo(p[0], p[1])
+				case recSegQuad:
+					a.QuadricCurveTo(p[0], p[1], p[2], p[3])
+				case recSegCubic:
+					a.CubicCurveTo(p[0], p[1], p[2], p[3], p[4], p[5])
+				case recSegClose:
+					a.ClosePolygon()
+				case recSegEllipse:
+					a.AddEllipse(p[0], p[1], p[2], p[3], CCW)
+				case recSegRoundedRect:
+					addRoundedRectPath(a, p[0], p[1], p[2], p[3], p[4])
+				}
+			}
+			if op.kind == recOpFill {
+				a.DrawPath(FillOnly)
+			} else {
+				a.DrawPath(StrokeOnly)
+			}
+		case recOpText:
+			s := &op.state
+			if s.fontFile != font.fontFile || s.fontHeight != font.fontHeight ||
+				s.fontBold != font.fontBold || s.fontItalic != font.fontItalic ||
+				s.fontCache != font.fontCache || s.fontAngle != font.fontAngle {
+				if s.fontFile != "" {
+					if err := a.Font(s.fontFile, s.fontHeight, s.fontBold, s.fontItalic, s.fontCache, s.fontAngle); err != nil {
+						return err
+					}
+				}
+				font = *s
+			}
+			a.Text(op.x, op.y, op.text, true, 0, 0)
+		case recOpImage:
+			if err := a.TransformImageSimple(op.img, op.x, op.y, op.x+op.w, op.y+op.h); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyRecState(a *Agg2D, s *recState, extra *transform.TransAffine) {
+	m := s.matrix
+	if extra != nil {
+		m = transform.NewTransAffineFromArray(m).Multiply(extra).ToArray()
+	}
+	a.SetTransformations(&Transformations{AffineMatrix: m})
+	a.FillColor(s.fillColor)
+	a.LineColor(s.lineColor)
+	a.LineWidth(s.lineWidth)
+	a.LineCap(s.lineCap)
+	a.LineJoin(s.lineJoin)
+	a.MiterLimit(s.miterLimit)
+	a.FillEvenOdd(s.evenOdd)
+	a.RemoveAllDashes()
+	for i := 0; i+1 < len(s.dashes); i += 2 {
+		a.AddDash(s.dashes[i], s.dashes[i+1])
+	}
+	a.DashStart(s.dashOffset)
+}
+
+// Image returns the recording rasterized at its page size.
+//
+// The result is cached and only re-rendered after new commands are recorded,
+// which makes a Recording usable as a retained-mode layer. The returned image
+// is owned by the recording and must not be modified.
+func (r *Recording) Image() (*Image, error) {
+	if r.width <= 0 || r.height <= 0 {
+		return nil, errors.New("recording has no page size")
+	}
+	if r.cache != nil && r.cacheVersion == r.version {
+		return r.cache.GetImage(), nil
+	}
+	if r.cache == nil {
+		r.cache = NewContext(r.width, r.height)
+	} else {
+		r.cache.Clear(Transparent)
+	}
+	if err := r.Replay(r.cache); err != nil {
+		return nil, err
+	}
+	r.cacheVersion = r.version
+	return r.cache.GetImage(), nil
+}
+
+// Thumbnail renders the recording scaled uniformly to fit a width x height
+// image, centered on a transparent background.
+func (r *Recording) Thumbnail(width, height int) (*Image, error) {
+	if r.width <= 0 || r.height <= 0 {
+		return nil, errors.New("recording has no page size")
+	}
+	ctx := NewContext(width, height)
+	ctx.Clear(Transparent)
+	s := math.Min(float64(width)/float64(r.width), float64(height)/float64(r.height))
+	tr := Scaling(s, s)
+	tr.Multiply(Translation((float64(width)-s*float64(r.width))/2, (float64(height)-s*float64(r.height))/2))
+	if err := r.ReplayTransformed(ctx, tr); err != nil {
+		return nil, err
+	}
+	return ctx.GetImage(), nil
+}
+
+// Diff compares r with other command by command and returns the device-space
+// rectangle covering every command that was added, removed or changed.
+// changed is false when both recordings draw the same scene.
+//
+// Text commands are treated as covering the whole page because their extent
+// depends on the font loaded at replay time.
+func (r *Recording) Diff(other *Recording) (x1, y1, x2, y2 float64, changed bool) {
+	x1, y1 = math.Inf(1), math.Inf(1)
+	x2, y2 = math.Inf(-1), math.Inf(-1)
+	union := func(rec *Recording, op *recOp) {
+		bx1, by1, bx2, by2 := rec.opBounds(op)
+		x1, y1 = math.Min(x1, bx1), math.Min(y1, by1)
+		x2, y2 = math.Max(x2, bx2), math.Max(y2, by2)
+		changed = true
+	}
+
+	n := len(r.ops)
+	if other != nil && len(other.ops) > n {
+		n = len(other.ops)
+	}
+	for i := 0; i < n; i++ {
+		var a, b *recOp
+		if i < len(r.ops) {
+			a = &r.ops[i]
+		}
+		if other != nil && i < len(other.ops) {
+			b = &other.ops[i]
+		}
+		if a != nil && b != nil && a.equal(b) {
+			continue
+		}
+		if a != nil {
+			union(r, a)
+		}
+		if b != nil {
+			union(other, b)
+		}
+	}
+	if !changed {
+		return 0, 0, 0, 0, false
+	}
+	return x1, y1, x2, y2, true
+}
+
+// opBounds returns a conservative device-space bounding box for op.
+func (r *Recording) opBounds(op *recOp) (x1, y1, x2, y2 float64) {
+	page := func() (float64, float64, float64, float64) {
+		return 0, 0, float64(r.width), float64(r.height)
+	}
+
+	var lx1, ly1, lx2, ly2 float64
+	switch op.kind {
+	case recOpClear, recOpText:
+		return page()
+	case recOpImage:
+		lx1, ly1, lx2, ly2 = op.x, op.y, op.x+op.w, op.y+op.h
+	case recOpFill, recOpStroke:
+		lx1, ly1 = math.Inf(1), math.Inf(1)
+		lx2, ly2 = math.Inf(-1), math.Inf(-1)
+		add := func(x, y float64) {
+			lx1, ly1 = math.Min(lx1, x), math.Min(ly1, y)
+			lx2, ly2 = math.Max(lx2, x), math.Max(ly2, y)
+		}
+		for _, seg := range op.path {
+			p := seg.pts
+			switch seg.kind {
+			case recSegMove, recSegLine:
+				add(p[0], p[1])
+			case recSegQuad:
+				add(p[0], p[1])
+				add(p[2], p[3])
+			case recSegCubic:
+				add(p[0], p[1])
+				add(p[2], p[3])
+				add(p[4], p[5])
+			case recSegEllipse:
+				add(p[0]-p[2], p[1]-p[3])
+				add(p[0]+p[2], p[1]+p[3])
+			case recSegRoundedRect:
+				add(p[0], p[1])
+				add(p[2], p[3])
+			}
+		}
+		if math.IsInf(lx1, 1) {
+			return 0, 0, 0, 0
+		}
+		if op.kind == recOpStroke {
+			pad := op.state.lineWidth / 2 * math.Max(op.state.miterLimit, 1)
+			lx1, ly1, lx2, ly2 = lx1-pad, ly1-pad, lx2+pad, ly2+pad
+		}
+	}
+
+	m := transform.NewTransAffineFromArray(op.state.matrix)
+	x1, y1 = math.Inf(1), math.Inf(1)
+	x2, y2 = math.Inf(-1), math.Inf(-1)
+	for _, c := range [4][2]float64{{lx1, ly1}, {lx2, ly1}, {lx2, ly2}, {lx1, ly2}} {
+		x, y := c[0], c[1]
+		m.Transform(&x, &y)
+		x1, y1 = math.Min(x1, x), math.Min(y1, y)
+		x2, y2 = math.Max(x2, x), math.Max(y2, y)
+	}
+	// One extra pixel covers anti-aliased edges.
+	return math.Floor(x1) - 1, math.Floor(y1) - 1, math.Ceil(x2) + 1, math.Ceil(y2) + 1
+}
+
+func (op *recOp) equal(other *recOp) bool {
+	if op.kind != other.kind || op.color != other.color || op.text != other.text ||
+		op.x != other.x || op.y != other.y || op.w != other.w || op.h != other.h {
+		return false
+	}
+	if !op.state.equal(&other.state) || !slices.Equal(op.path, other.path) {
+		return false
+	}
+	if (op.img == nil) != (other.img == nil) {
+		return false
+	}
+	return op.img == nil || (op.img.Width() == other.img.Width() &&
+		op.img.Height() == other.img.Height() && bytes.Equal(op.img.Data, other.img.Data))
+}
+
+func (s *recState) equal(other *recState) bool {
+	return s.fillColor == other.fillColor && s.lineColor == other.lineColor &&
+		s.lineWidth == other.lineWidth && s.lineCap == other.lineCap &&
+		s.lineJoin == other.lineJoin && s.miterLimit == other.miterLimit &&
+		slices.Equal(s.dashes, other.dashes) && s.dashOffset == other.dashOffset &&
+		s.evenOdd == other.evenOdd && s.matrix == other.matrix &&
+		s.fontFile == other.fontFile && s.fontHeight == other.fontHeight &&
+		s.fontBold == other.fontBold && s.fontItalic == other.fontItalic &&
+		s.fontCache == other.fontCache && s.fontAngle == other.fontAngle
+}
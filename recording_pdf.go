@@ -0,0 +1,369 @@
+package agg
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// SavePDF writes the recording as a single-page PDF file.
+func (r *Recording) SavePDF(filename string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := r.WritePDF(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WritePDF writes the recording as a single-page PDF document.
+//
+// One pixel maps to one PDF point and the page origin is moved to the top-left
+// corner, so coordinates match raster output. Paths, fills, strokes,
+// transforms and images are exported as vector/image operators; text uses the
+// standard Helvetica font because font files are not embedded.
+func (r *Recording) WritePDF(w io.Writer) error {
+	if r.width <= 0 || r.height <= 0 {
+		return fmt.Errorf("invalid page size %dx%d", r.width, r.height)
+	}
+	pw := &pdfWriter{}
+	return pw.write(w, r)
+}
+
+// pdfWriter accumulates PDF objects and tracks their byte offsets for the
+// cross-reference table.
+type pdfWriter struct {
+	buf     bytes.Buffer
+	offsets []int
+
+	content  bytes.Buffer
+	images   []*Image
+	alphas   map[[2]uint8]string
+	alphaSeq [][2]uint8
+}
+
+func (pw *pdfWriter) write(w io.Writer, r *Recording) error {
+	pw.alphas = make(map[[2]uint8]string)
+	pw.emitContent(r)
+
+	pw.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-5 are fixed; image XObjects (and their soft masks) follow.
+	const (
+		catalogID = 1
+		pagesID   = 2
+		pageID    = 3
+		contentID = 4
+		fontID    = 5
+	)
+	nextID := fontID + 1
+	imageIDs := make([]int, len(pw.images))
+	for i := range pw.images {
+		imageIDs[i] = nextID
+		nextID += 2 // image + soft mask
+	}
+
+	pw.object(catalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+	pw.object(pagesID, fmt.Sprintf("<< /Type /Pages /Kids [%d 0 R] /Count 1 >>", pageID))
+
+	var res bytes.Buffer
+	fmt.Fprintf(&res, "<< /Font << /F1 %d 0 R >>", fontID)
+	if len(pw.images) > 0 {
+		res.WriteString(" /XObject <<")
+		for i, id := range imageIDs {
+			fmt.Fprintf(&res, " /Im%d %d 0 R", i, id)
+		}
+		res.WriteString(" >>")
+	}
+	if len(pw.alphaSeq) > 0 {
+		res.WriteString(" /ExtGState <<")
+		for _, a := range pw.alphaSeq {
+			fmt.Fprintf(&res, " /%s << /ca %s /CA %s >>", pw.alphas[a],
+				pdfNum(float64(a[0])/255), pdfNum(float64(a[1])/255))
+		}
+		res.WriteString(" >>")
+	}
+	res.WriteString(" >>")
+
+	pw.object(pageID, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %d %d] /Resources %s /Contents %d 0 R >>",
+		pagesID, r.width, r.height, res.String(), contentID))
+
+	if err := pw.stream(contentID, "", pw.content.Bytes()); err != nil {
+		return err
+	}
+	pw.object(fontID, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i, img := range pw.images {
+		rgb, alpha := splitImageChannels(img)
+		id := imageIDs[i]
+		dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /SMask %d 0 R",
+			img.Width(), img.Height(), id+1)
+		if err := pw.stream(id, dict, rgb); err != nil {
+			return err
+		}
+		dict = fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8",
+			img.Width(), img.Height())
+		if err := pw.stream(id+1, dict, alpha); err != nil {
+			return err
+		}
+	}
+
+	xref := pw.buf.Len()
+	fmt.Fprintf(&pw.buf, "xref\n0 %d\n0000000000 65535 f \n", len(pw.offsets)+1)
+	for _, off := range pw.offsets {
+		fmt.Fprintf(&pw.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&pw.buf, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(pw.offsets)+1, catalogID, xref)
+
+	_, err := w.Write(pw.buf.Bytes())
+	return err
+}
+
+// object writes an indirect object. Objects must be written in ID order.
+func (pw *pdfWriter) object(id int, body string) {
+	pw.offsets = append(pw.offsets, pw.buf.Len())
+	fmt.Fprintf(&pw.buf, "%d 0 obj\n%s\nendobj\n", id, body)
+}
+
+// stream writes a Flate-compressed stream object with the extra dictionary
+// entries in dict.
+func (pw *pdfWriter) stream(id int, dict string, data []byte) error {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	pw.offsets = append(pw.offsets, pw.buf.Len())
+	fmt.Fprintf(&pw.buf, "%d 0 obj\n<< %s /Filter /FlateDecode /Length %d >>\nstream\n", id, dict, z.Len())
+	pw.buf.Write(z.Bytes())
+	pw.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+// emitContent translates the recorded commands into page content operators.
+func (pw *pdfWriter) emitContent(r *Recording) {
+	c := &pw.content
+	// Flip to a top-left origin so recorded coordinates can be used unchanged.
+	fmt.Fprintf(c, "1 0 0 -1 0 %d cm\n", r.height)
+
+	for i := range r.ops {
+		op := &r.ops[i]
+		s := &op.state
+		c.WriteString("q\n")
+		switch op.kind {
+		case recOpClear:
+			pw.setAlpha(op.color.A, op.color.A)
+			fmt.Fprintf(c, "%s rg 0 0 %d %d re f\n", pdfRGB(op.color), r.width, r.height)
+		case recOpFill, recOpStroke:
+			pw.setMatrix(s.matrix)
+			if op.kind == recOpFill {
+				pw.setAlpha(s.fillColor.A, 255)
+				fmt.Fprintf(c, "%s rg\n", pdfRGB(s.fillColor))
+			} else {
+				pw.setAlpha(255, s.lineColor.A)
+				pw.setStroke(s)
+			}
+			pw.emitPath(op.path)
+			switch {
+			case op.kind == recOpStroke:
+				c.WriteString("S\n")
+			case s.evenOdd:
+				c.WriteString("f*\n")
+			default:
+				c.WriteString("f\n")
+			}
+		case recOpText:
+			pw.setMatrix(s.matrix)
+			pw.setAlpha(s.fillColor.A, 255)
+			height := s.fontHeight
+			if height <= 0 {
+				height = 12
+			}
+			fmt.Fprintf(c, "%s rg BT /F1 %s Tf 1 0 0 -1 %s %s Tm %s Tj ET\n",
+				pdfRGB(s.fillColor), pdfNum(height), pdfNum(op.x), pdfNum(op.y), pdfString(op.text))
+		case recOpImage:
+			pw.setMatrix(s.matrix)
+			fmt.Fprintf(c, "%s 0 0 %s %s %s cm /Im%d Do\n",
+				pdfNum(op.w), pdfNum(-op.h), pdfNum(op.x), pdfNum(op.y+op.h), len(pw.images))
+			pw.images = append(pw.images, op.img)
+		}
+		c.WriteString("Q\n")
+	}
+}
+
+func (pw *pdfWriter) setMatrix(m [6]float64) {
+	if m == [6]float64{1, 0, 0, 1, 0, 0} {
+		return
+	}
+	fmt.Fprintf(&pw.content, "%s %s %s %s %s %s cm\n",
+		pdfNum(m[0]), pdfNum(m[1]), pdfNum(m[2]), pdfNum(m[3]), pdfNum(m[4]), pdfNum(m[5]))
+}
+
+// setAlpha selects an ExtGState carrying the fill and stroke opacity.
+func (pw *pdfWriter) setAlpha(fill, stroke uint8) {
+	if fill == 255 && stroke == 255 {
+		return
+	}
+	key := [2]uint8{fill, stroke}
+	name, ok := pw.alphas[key]
+	if !ok {
+		name = "GS" + strconv.Itoa(len(pw.alphaSeq))
+		pw.alphas[key] = name
+		pw.alphaSeq = append(pw.alphaSeq, key)
+	}
+	fmt.Fprintf(&pw.content, "/%s gs\n", name)
+}
+
+func (pw *pdfWriter) setStroke(s *recState) {
+	c := &pw.content
+	fmt.Fprintf(c, "%s RG %s w %d J %d j %s M\n",
+		pdfRGB(s.lineColor), pdfNum(s.lineWidth), pdfLineCap(s.lineCap), pdfLineJoin(s.lineJoin), pdfNum(s.miterLimit))
+	if len(s.dashes) > 0 {
+		c.WriteString("[")
+		for i, d := range s.dashes {
+			if i > 0 {
+				c.WriteByte(' ')
+			}
+			c.WriteString(pdfNum(d))
+		}
+		fmt.Fprintf(c, "] %s d\n", pdfNum(s.dashOffset))
+	}
+}
+
+func (pw *pdfWriter) emitPath(path []recSeg) {
+	c := &pw.content
+	var cx, cy float64 // current point, needed to elevate quadratic curves
+	for _, seg := range path {
+		p := seg.pts
+		switch seg.kind {
+		case recSegMove:
+			fmt.Fprintf(c, "%s %s m\n", pdfNum(p[0]), pdfNum(p[1]))
+			cx, cy = p[0], p[1]
+		case recSegLine:
+			fmt.Fprintf(c, "%s %s l\n", pdfNum(p[0]), pdfNum(p[1]))
+			cx, cy = p[0], p[1]
+		case recSegQuad:
+			c1x, c1y := cx+2.0/3.0*(p[0]-cx), cy+2.0/3.0*(p[1]-cy)
+			c2x, c2y := p[2]+2.0/3.0*(p[0]-p[2]), p[3]+2.0/3.0*(p[1]-p[3])
+			fmt.Fprintf(c, "%s %s %s %s %s %s c\n",
+				pdfNum(c1x), pdfNum(c1y), pdfNum(c2x), pdfNum(c2y), pdfNum(p[2]), pdfNum(p[3]))
+			cx, cy = p[2], p[3]
+		case recSegCubic:
+			fmt.Fprintf(c, "%s %s %s %s %s %s c\n",
+				pdfNum(p[0]), pdfNum(p[1]), pdfNum(p[2]), pdfNum(p[3]), pdfNum(p[4]), pdfNum(p[5]))
+			cx, cy = p[4], p[5]
+		case recSegClose:
+			c.WriteString("h\n")
+		case recSegRoundedRect:
+			pw.emitPath(roundedRectPath(p[0], p[1], p[2], p[3], p[4]))
+		case recSegEllipse:
+			x, y, rx, ry := p[0], p[1], p[2], p[3]
+			kx, ky := rx*bezierArcKappa, ry*bezierArcKappa
+			fmt.Fprintf(c, "%s %s m\n", pdfNum(x+rx), pdfNum(y))
+			fmt.Fprintf(c, "%s %s %s %s %s %s c\n", pdfNum(x+rx), pdfNum(y+ky), pdfNum(x+kx), pdfNum(y+ry), pdfNum(x), pdfNum(y+ry))
+			fmt.Fprintf(c, "%s %s %s %s %s %s c\n", pdfNum(x-kx), pdfNum(y+ry), pdfNum(x-rx), pdfNum(y+ky), pdfNum(x-rx), pdfNum(y))
+			fmt.Fprintf(c, "%s %s %s %s %s %s c\n", pdfNum(x-rx), pdfNum(y-ky), pdfNum(x-kx), pdfNum(y-ry), pdfNum(x), pdfNum(y-ry))
+			fmt.Fprintf(c, "%s %s %s %s %s %s c\n", pdfNum(x+kx), pdfNum(y-ry), pdfNum(x+rx), pdfNum(y-ky), pdfNum(x+rx), pdfNum(y))
+			c.WriteString("h\n")
+			cx, cy = x+rx, y
+		}
+	}
+}
+
+// splitImageChannels returns the packed RGB samples and the alpha channel of
+// img, row by row from the top.
+func splitImageChannels(img *Image) (rgb, alpha []byte) {
+	w, h := img.Width(), img.Height()
+	rgb = make([]byte, 0, w*h*3)
+	alpha = make([]byte, 0, w*h)
+	for y := 0; y < h; y++ {
+		row := img.renBuf.RowPtr(0, y, w*4)
+		for x := 0; x+3 < len(row); x += 4 {
+			rgb = append(rgb, row[x], row[x+1], row[x+2])
+			alpha = append(alpha, row[x+3])
+		}
+	}
+	return rgb, alpha
+}
+
+func pdfLineCap(c LineCap) int {
+	switch c {
+	case CapRound:
+		return 1
+	case CapSquare:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func pdfLineJoin(j LineJoin) int {
+	switch j {
+	case JoinRound:
+		return 1
+	case JoinBevel:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func pdfRGB(c Color) string {
+	return pdfNum(float64(c.R)/255) + " " + pdfNum(float64(c.G)/255) + " " + pdfNum(float64(c.B)/255)
+}
+
+// pdfNum formats v with at most four decimals, as PDF has no exponent syntax.
+func pdfNum(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	s = trimFloatZeros(s)
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+func trimFloatZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	for s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// pdfString encodes text as a PDF literal string in WinAnsiEncoding. Runes
+// outside Latin-1 are replaced with '?'.
+func pdfString(text string) string {
+	var b bytes.Buffer
+	b.WriteByte('(')
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r < 0x100:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
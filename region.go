@@ -0,0 +1,76 @@
+package agg
+
+import "errors"
+
+// GetPixel returns the color of device pixel (x, y) of the Context's image,
+// as stored (non-premultiplied RGBA). Pixels outside the image read as the
+// zero Color. Pixels of formats without alpha read as opaque.
+func (ctx *Context) GetPixel(x, y int) Color {
+	img := ctx.image
+	if x < 0 || y < 0 || x >= img.width || y >= img.height {
+		return Color{}
+	}
+	f, ok := img.targetFormat()
+	if !ok {
+		return Color{}
+	}
+	c := f.Decode(img.Data[y*img.renBuf.Stride()+x*f.BytesPerPixel():])
+	return Color{R: c[0], G: c[1], B: c[2], A: c[3]}
+}
+
+// ReadRegion copies the pixels of r (X2 and Y2 exclusive) into a new image in
+// the Context's pixel format. The rectangle is clipped to the Context's image
+// first, so the result may be smaller than r; nil is returned when nothing is
+// left.
+func (ctx *Context) ReadRegion(r Rect) *Image {
+	x1, y1, x2, y2, ok := clipRect(r, ctx.image.width, ctx.image.height)
+	if !ok {
+		return nil
+	}
+	out := CreateImageWithFormat(x2-x1, y2-y1, ctx.image.format)
+	copyPixels(out, 0, 0, ctx.image, x1, y1, x2-x1, y2-y1)
+	return out
+}
+
+// WriteRegion copies img into the Context's image with its top-left corner at
+// (r.X1, r.Y1), replacing the pixels rather than blending. At most r's size
+// and img's size are copied, clipped to the Context's image. It ignores the
+// clip box and the transform, so a ReadRegion/WriteRegion pair restores the
+// pixels exactly, as an undo buffer needs.
+func (ctx *Context) WriteRegion(r Rect, img *Image) error {
+	if img == nil {
+		return errors.New("image is nil")
+	}
+	if img.format != ctx.image.format || img.pixelStep() != img.format.BytesPerPixel() {
+		return errors.New("WriteRegion needs an image in the Context's pixel format")
+	}
+
+	w, h := min(r.Width(), img.width), min(r.Height(), img.height)
+	x1, y1, x2, y2, ok := clipRect(Rect{X1: r.X1, Y1: r.Y1, X2: r.X1 + w, Y2: r.Y1 + h}, ctx.image.width, ctx.image.height)
+	if !ok {
+		return nil
+	}
+	copyPixels(ctx.image, x1, y1, img, x1-r.X1, y1-r.Y1, x2-x1, y2-y1)
+	return nil
+}
+
+// clipRect clips r to a width x height image and reports whether anything is
+// left.
+func clipRect(r Rect, width, height int) (x1, y1, x2, y2 int, ok bool) {
+	x1, y1 = max(r.X1, 0), max(r.Y1, 0)
+	x2, y2 = min(r.X2, width), min(r.Y2, height)
+	return x1, y1, x2, y2, x1 < x2 && y1 < y2
+}
+
+// copyPixels copies a w x h block of pixels from (sx, sy) in src to (dx, dy)
+// in dst, two images of the same format. The block must lie inside both
+// images.
+func copyPixels(dst *Image, dx, dy int, src *Image, sx, sy, w, h int) {
+	dstStride, srcStride := dst.renBuf.Stride(), src.renBuf.Stride()
+	bpp := dst.format.BytesPerPixel()
+	for y := 0; y < h; y++ {
+		d := (dy+y)*dstStride + dx*bpp
+		s := (sy+y)*srcStride + sx*bpp
+		copy(dst.Data[d:d+w*bpp], src.Data[s:s+w*bpp])
+	}
+}
@@ -0,0 +1,67 @@
+package agg
+
+// ResizeMode selects what happens to the existing pixels when a Context is
+// resized.
+type ResizeMode int
+
+const (
+	// ResizeDiscard drops the old content; the resized buffer is transparent.
+	ResizeDiscard ResizeMode = iota
+	// ResizePreserve keeps the old pixels anchored at the top-left corner.
+	// Shrinking crops them, growing pads the new area with transparent pixels.
+	ResizePreserve
+	// ResizeScale stretches the old content to the new size using the
+	// context's current image filter and resampling settings.
+	ResizeScale
+)
+
+// Resize gives the context a new backing buffer of width x height pixels and
+// fills it according to mode. Drawing state such as the transform, colors,
+// stroke and text settings carries over; a clip box that covered the whole old
+// buffer grows or shrinks with it.
+//
+// The context always allocates the new buffer itself, also when it was created
+// over a caller's Image. Images returned by earlier GetImage calls keep the old
+// pixels. A hit region index set with SetHitRegions is not resized.
+func (ctx *Context) Resize(width, height int, mode ResizeMode) {
+	width, height = max(width, 0), max(height, 0)
+	old := ctx.image
+
+	format := ImageRGBA8
+	if old != nil {
+		format = old.format
+	}
+	img := CreateImageWithFormat(width, height, format)
+	buf, stride := img.Data, img.renBuf.Stride()
+
+	if old != nil && old.width > 0 && old.height > 0 && width > 0 && height > 0 {
+		switch mode {
+		case ResizePreserve:
+			n := min(width, old.width) * format.BytesPerPixel()
+			for y := range min(height, old.height) {
+				copy(buf[y*stride:y*stride+n], old.Data[y*old.renBuf.Stride():])
+			}
+		case ResizeScale:
+			scaler := NewAgg2D()
+			scaler.AttachImage(img)
+			scaler.ImageFilter(ctx.agg2d.GetImageFilter())
+			scaler.ImageResample(ctx.agg2d.GetImageResample())
+			_ = scaler.TransformImageSimple(old, 0, 0, float64(width), float64(height))
+		}
+	}
+
+	ctx.agg2d.reattach(buf, width, height, stride)
+	ctx.image = img
+	ctx.width = width
+	ctx.height = height
+}
+
+// reattach switches to a new buffer like Attach while keeping the drawing
+// state.
+func (a *Agg2D) reattach(buf []uint8, width, height, stride int) {
+	a.impl.Reattach(buf, width, height, stride)
+	a.attachedBuffer = buf
+	a.attachedWidth = width
+	a.attachedHeight = height
+	a.attachedStride = stride
+}
@@ -0,0 +1,96 @@
+package agg
+
+import ia "github.com/MeKo-Christian/agg_go/internal/agg2d"
+
+// RichText is a paragraph of text runs with their own font, size, color
+// and decoration, drawn on a common baseline with Context.DrawRichText.
+// The style setters apply to the runs added after them:
+//
+//	rt := agg.NewRichText().
+//		Font("DejaVuSans.ttf", 18).Add("Status: ").
+//		Color(agg.Red).Underline(true).Add("failed")
+//
+// Until a setter is called, runs use the font, size, fill color and
+// decoration of the context the paragraph is drawn on.
+type RichText struct {
+	runs  []ia.RichTextRun
+	style ia.RichTextRun
+}
+
+// NewRichText returns an empty paragraph.
+func NewRichText() *RichText { return &RichText{} }
+
+// Font sets the font file and height of the following runs. An empty name
+// or a zero size keeps the context's font or height.
+func (rt *RichText) Font(fileName string, size float64) *RichText {
+	rt.style.Font, rt.style.Size = fileName, size
+	return rt
+}
+
+// Size sets the font height of the following runs.
+func (rt *RichText) Size(size float64) *RichText {
+	rt.style.Size = size
+	return rt
+}
+
+// Color sets the fill color of the following runs.
+func (rt *RichText) Color(c Color) *RichText {
+	rt.style.Color = &ia.Color{c.R, c.G, c.B, c.A}
+	return rt
+}
+
+// Decoration sets the lines drawn along the following runs, in addition to
+// those set with SetTextDecoration.
+func (rt *RichText) Decoration(d TextDecoration) *RichText {
+	rt.style.Decoration = d
+	return rt
+}
+
+// Underline turns the underline of the following runs on or off.
+func (rt *RichText) Underline(on bool) *RichText {
+	if on {
+		rt.style.Decoration |= DecorationUnderline
+	} else {
+		rt.style.Decoration &^= DecorationUnderline
+	}
+	return rt
+}
+
+// Add appends text as a run with the current style.
+func (rt *RichText) Add(text string) *RichText {
+	if text != "" {
+		run := rt.style
+		run.Text = text
+		rt.runs = append(rt.runs, run)
+	}
+	return rt
+}
+
+// Len returns the number of runs.
+func (rt *RichText) Len() int { return len(rt.runs) }
+
+// DrawRichText renders the paragraph with its baseline starting at x, y.
+// The text alignment of the context applies to the paragraph as a whole,
+// and its font, fill and decoration are left unchanged. It returns an error
+// when a run's font cannot be loaded; an empty paragraph draws nothing.
+func (ctx *Context) DrawRichText(x, y float64, rt *RichText) error {
+	return ctx.agg2d.DrawRichText(x, y, rt)
+}
+
+// MeasureRichText returns the advance of the paragraph and the largest
+// ascent and descent of its runs. A nil or empty paragraph measures zero.
+func (ctx *Context) MeasureRichText(rt *RichText) (width, ascent, descent float64, err error) {
+	if rt == nil || len(rt.runs) == 0 {
+		return 0, 0, 0, nil
+	}
+	return ctx.agg2d.impl.MeasureRichText(rt.runs)
+}
+
+// DrawRichText renders the paragraph with its baseline starting at x, y;
+// see Context.DrawRichText. A nil or empty paragraph draws nothing.
+func (a *Agg2D) DrawRichText(x, y float64, rt *RichText) error {
+	if rt == nil || len(rt.runs) == 0 {
+		return nil
+	}
+	return a.impl.RichText(x, y, rt.runs)
+}
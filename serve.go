@@ -0,0 +1,165 @@
+package agg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServeFormat selects the encoding used by ServeImageWithOptions.
+type ServeFormat int
+
+const (
+	// ServeAuto picks JPEG when the request's Accept header prefers it over
+	// PNG, and PNG otherwise.
+	ServeAuto ServeFormat = iota
+	// ServePNG always encodes PNG.
+	ServePNG
+	// ServeJPEG always encodes JPEG.
+	ServeJPEG
+)
+
+// ServeOptions configures ServeImageWithOptions.
+type ServeOptions struct {
+	// Format selects the output encoding. The zero value negotiates it from
+	// the request's Accept header.
+	Format ServeFormat
+	// JPEGQuality is the JPEG quality (1-100). Zero uses jpeg.DefaultQuality.
+	JPEGQuality int
+	// MaxAge sets Cache-Control: public, max-age=... when positive. Zero sends
+	// no-cache so clients revalidate each time.
+	MaxAge time.Duration
+	// ETag enables a content hash ETag and answers matching If-None-Match
+	// requests with 304 Not Modified.
+	ETag bool
+}
+
+// ServeImage renders a width x height image with draw and writes it to w as
+// PNG.
+//
+// It is the minimal form of ServeImageWithOptions for handlers that do not
+// need format negotiation or caching headers.
+func ServeImage(w http.ResponseWriter, width, height int, draw func(*Context)) error {
+	return ServeImageWithOptions(w, nil, width, height, draw, ServeOptions{Format: ServePNG})
+}
+
+// ServeImageWithOptions renders a width x height image with draw and writes
+// it to w as PNG or JPEG according to opts.
+//
+// r may be nil, in which case ServeAuto falls back to PNG and conditional
+// requests are not evaluated. Encoding errors are reported with a 500 status
+// and returned to the caller.
+func ServeImageWithOptions(w http.ResponseWriter, r *http.Request, width, height int, draw func(*Context), opts ServeOptions) error {
+	if width <= 0 || height <= 0 {
+		http.Error(w, "invalid image size", http.StatusBadRequest)
+		return errors.New("invalid image size")
+	}
+
+	ctx := NewContext(width, height)
+	if draw != nil {
+		draw(ctx)
+	}
+	stdImg, err := ctx.GetImage().ToStandardImage()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	format := opts.Format
+	if format == ServeAuto {
+		format = negotiateServeFormat(r)
+	}
+
+	var buf bytes.Buffer
+	contentType := "image/png"
+	if format == ServeJPEG {
+		contentType = "image/jpeg"
+		quality := opts.JPEGQuality
+		if quality <= 0 {
+			quality = jpeg.DefaultQuality
+		}
+		err = jpeg.Encode(&buf, stdImg, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(&buf, stdImg)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return err
+	}
+
+	h := w.Header()
+	h.Set("Content-Type", contentType)
+	if opts.Format == ServeAuto {
+		h.Add("Vary", "Accept")
+	}
+	if opts.MaxAge > 0 {
+		h.Set("Cache-Control", "public, max-age="+strconv.Itoa(int(opts.MaxAge/time.Second)))
+	} else {
+		h.Set("Cache-Control", "no-cache")
+	}
+	if opts.ETag {
+		sum := sha256.Sum256(buf.Bytes())
+		etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+		h.Set("ETag", etag)
+		if r != nil && etagMatches(r.Header.Get("If-None-Match"), etag) {
+			w.WriteHeader(http.StatusNotModified)
+			return nil
+		}
+	}
+	h.Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(http.StatusOK)
+	if r != nil && r.Method == http.MethodHead {
+		return nil
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// negotiateServeFormat returns ServeJPEG when the Accept header gives
+// image/jpeg a higher quality value than image/png.
+func negotiateServeFormat(r *http.Request) ServeFormat {
+	if r == nil {
+		return ServePNG
+	}
+	pngQ, jpegQ := -1.0, -1.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		q := 1.0
+		for _, param := range fields[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && strings.EqualFold(k, "q") {
+				if f, err := strconv.ParseFloat(v, 64); err == nil {
+					q = f
+				}
+			}
+		}
+		switch mediaType {
+		case "image/png":
+			pngQ = max(pngQ, q)
+		case "image/jpeg", "image/jpg":
+			jpegQ = max(jpegQ, q)
+		}
+	}
+	if jpegQ > pngQ && jpegQ > 0 {
+		return ServeJPEG
+	}
+	return ServePNG
+}
+
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package agg
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/shapes"
+)
+
+// shapeSource is the vertex source interface of the internal shape
+// generators.
+type shapeSource interface {
+	Rewind(pathID uint32)
+	Vertex(x, y *float64) basics.PathCommand
+}
+
+// shapePath collects the vertices of vs into a new path.
+func shapePath(vs shapeSource) *Path {
+	p := NewPath()
+	vs.Rewind(0)
+	for {
+		var x, y float64
+		cmd := vs.Vertex(&x, &y)
+		switch {
+		case basics.IsStop(cmd):
+			return p
+		case basics.IsMoveTo(cmd):
+			p.MoveTo(x, y)
+		case basics.IsVertex(cmd):
+			p.LineTo(x, y)
+		case basics.IsEndPoly(cmd):
+			p.ClosePath()
+		}
+	}
+}
+
+// RegularPolygonPath returns a regular polygon with the given number of
+// sides, centered at (cx, cy) with its vertices on a circle of radius r. The
+// first vertex points straight up, rotated clockwise by rotation radians.
+// Fewer than 3 sides give an empty path.
+func RegularPolygonPath(cx, cy, r float64, sides int, rotation float64) *Path {
+	return shapePath(shapes.NewRegularPolygon(cx, cy, r, uint32(max(sides, 0)), rotation))
+}
+
+// StarPath returns a star with the given number of tips on a circle of
+// radius outer and the notches between them on a circle of radius inner,
+// centered at (cx, cy). The first tip points straight up, rotated clockwise
+// by rotation radians. Fewer than 2 points give an empty path.
+func StarPath(cx, cy, outer, inner float64, points int, rotation float64) *Path {
+	return shapePath(shapes.NewStar(cx, cy, outer, inner, uint32(max(points, 0)), rotation))
+}
+
+// SuperellipsePath returns the superellipse |x/rx|^n + |y/ry|^n = 1 centered
+// at (cx, cy). An exponent n of 2 is an ellipse, 4 a squircle as used for app
+// icons; larger values approach a rectangle, values below 1 curve inwards.
+func SuperellipsePath(cx, cy, rx, ry, n float64) *Path {
+	return shapePath(shapes.NewSuperellipse(cx, cy, rx, ry, n))
+}
+
+// drawShape replaces the current path with vs and draws it.
+func (ctx *Context) drawShape(vs shapeSource, flag DrawPathFlag) {
+	ctx.agg2d.ResetPath()
+	ctx.AppendPath(shapePath(vs))
+	ctx.agg2d.DrawPath(flag)
+}
+
+// superellipse returns a superellipse tessellated for the current transform.
+func (ctx *Context) superellipse(cx, cy, rx, ry, n float64) *shapes.Superellipse {
+	s := shapes.NewSuperellipse(cx, cy, rx, ry, n)
+	s.SetApproximationScale(ctx.agg2d.impl.EffectiveApproximationScale())
+	return s
+}
+
+// DrawRegularPolygon renders a stroked regular polygon immediately. See
+// RegularPolygonPath.
+func (ctx *Context) DrawRegularPolygon(cx, cy, r float64, sides int, rotation float64) {
+	ctx.drawShape(shapes.NewRegularPolygon(cx, cy, r, uint32(max(sides, 0)), rotation), StrokeOnly)
+}
+
+// FillRegularPolygon renders a filled regular polygon immediately. See
+// RegularPolygonPath.
+func (ctx *Context) FillRegularPolygon(cx, cy, r float64, sides int, rotation float64) {
+	ctx.drawShape(shapes.NewRegularPolygon(cx, cy, r, uint32(max(sides, 0)), rotation), FillOnly)
+}
+
+// DrawStar renders a stroked star immediately. See StarPath.
+func (ctx *Context) DrawStar(cx, cy, outer, inner float64, points int, rotation float64) {
+	ctx.drawShape(shapes.NewStar(cx, cy, outer, inner, uint32(max(points, 0)), rotation), StrokeOnly)
+}
+
+// FillStar renders a filled star immediately. See StarPath.
+func (ctx *Context) FillStar(cx, cy, outer, inner float64, points int, rotation float64) {
+	ctx.drawShape(shapes.NewStar(cx, cy, outer, inner, uint32(max(points, 0)), rotation), FillOnly)
+}
+
+// DrawSuperellipse renders a stroked superellipse immediately. See
+// SuperellipsePath.
+func (ctx *Context) DrawSuperellipse(cx, cy, rx, ry, n float64) {
+	ctx.drawShape(ctx.superellipse(cx, cy, rx, ry, n), StrokeOnly)
+}
+
+// FillSuperellipse renders a filled superellipse immediately. See
+// SuperellipsePath.
+func (ctx *Context) FillSuperellipse(cx, cy, rx, ry, n float64) {
+	ctx.drawShape(ctx.superellipse(cx, cy, rx, ry, n), FillOnly)
+}
@@ -0,0 +1,117 @@
+package agg
+
+import "bytes"
+
+// snapshotTile is the edge length in pixels of the square tiles a Snapshot
+// stores the framebuffer in.
+const snapshotTile = 64
+
+// Snapshot is a saved copy of a Context's pixels, taken with
+// Context.Snapshot and put back with Context.Restore.
+//
+// The pixels are kept in 64x64 tiles that are never modified after they are
+// written. A tile that did not change since the context's previous snapshot
+// is shared with it instead of copied, so an undo history of small edits costs
+// roughly the size of the edited areas. The zero Snapshot is empty.
+type Snapshot struct {
+	width, height int
+	format        ImageFormat
+	tiles         [][]byte // row-major, tight pixel rows of each tile
+}
+
+// Width returns the width of the snapshot in pixels.
+func (s Snapshot) Width() int { return s.width }
+
+// Height returns the height of the snapshot in pixels.
+func (s Snapshot) Height() int { return s.height }
+
+// Empty reports whether the snapshot holds no pixels.
+func (s Snapshot) Empty() bool { return len(s.tiles) == 0 }
+
+// tileGrid returns the number of tile columns and rows for the snapshot size.
+func (s Snapshot) tileGrid() (cols, rows int) {
+	return (s.width + snapshotTile - 1) / snapshotTile, (s.height + snapshotTile - 1) / snapshotTile
+}
+
+// tileBounds returns the pixel rectangle covered by tile i.
+func (s Snapshot) tileBounds(i int) (x0, y0, x1, y1 int) {
+	cols, _ := s.tileGrid()
+	x0, y0 = (i%cols)*snapshotTile, (i/cols)*snapshotTile
+	return x0, y0, min(x0+snapshotTile, s.width), min(y0+snapshotTile, s.height)
+}
+
+// Snapshot saves the current pixels of the context. Tiles equal to those of
+// the previous snapshot taken from this context are shared with it.
+func (ctx *Context) Snapshot() Snapshot {
+	img := ctx.image
+	s := Snapshot{width: ctx.width, height: ctx.height}
+	if img == nil || s.width <= 0 || s.height <= 0 {
+		return Snapshot{}
+	}
+	s.format = img.format
+	bpp := img.format.BytesPerPixel()
+	prev := ctx.lastSnapshot
+	reuse := prev.width == s.width && prev.height == s.height && prev.format == s.format
+
+	stride := img.renBuf.Stride()
+	cols, rows := s.tileGrid()
+	s.tiles = make([][]byte, cols*rows)
+	for i := range s.tiles {
+		x0, y0, x1, y1 := s.tileBounds(i)
+		n := (x1 - x0) * bpp
+		if reuse && tileEqual(prev.tiles[i], img.Data, stride, x0*bpp, y0, y1, n) {
+			s.tiles[i] = prev.tiles[i]
+			continue
+		}
+		tile := make([]byte, n*(y1-y0))
+		for y := y0; y < y1; y++ {
+			off := y*stride + x0*bpp
+			copy(tile[(y-y0)*n:], img.Data[off:off+n])
+		}
+		s.tiles[i] = tile
+	}
+	ctx.lastSnapshot = s
+	return s
+}
+
+// Restore puts the pixels of s back into the context. Only tiles that differ
+// from the current contents are written. If s has a different size than the
+// context, the context is resized to it first (see Resize). Drawing state such
+// as the transform and colors is not part of a snapshot and stays as it is.
+// Restoring an empty snapshot, or one taken from a context of another pixel
+// format, does nothing.
+func (ctx *Context) Restore(s Snapshot) {
+	if s.Empty() || s.format != ctx.image.format {
+		return
+	}
+	if s.width != ctx.width || s.height != ctx.height {
+		ctx.Resize(s.width, s.height, ResizeDiscard)
+	}
+	img := ctx.image
+	stride := img.renBuf.Stride()
+	bpp := img.format.BytesPerPixel()
+	for i, tile := range s.tiles {
+		x0, y0, x1, y1 := s.tileBounds(i)
+		n := (x1 - x0) * bpp
+		if tileEqual(tile, img.Data, stride, x0*bpp, y0, y1, n) {
+			continue
+		}
+		for y := y0; y < y1; y++ {
+			off := y*stride + x0*bpp
+			copy(img.Data[off:off+n], tile[(y-y0)*n:])
+		}
+	}
+	ctx.lastSnapshot = s
+}
+
+// tileEqual reports whether tile holds the same pixels as rows y0..y1-1 of
+// buf, n bytes each starting at byte offset col.
+func tileEqual(tile, buf []byte, stride, col, y0, y1, n int) bool {
+	for y := y0; y < y1; y++ {
+		off := y*stride + col
+		if !bytes.Equal(tile[(y-y0)*n:(y-y0+1)*n], buf[off:off+n]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package agg
+
+import (
+	"github.com/MeKo-Christian/agg_go/internal/color"
+	"github.com/MeKo-Christian/agg_go/internal/span"
+)
+
+// SpanAllocator is the scratch buffer gradient, pattern and image fills
+// generate their colors into, one span at a time. The buffer persists
+// across renders and only grows, in steps of 256 colors; Reserve sizes it
+// ahead of time and Stats shows how it has been used.
+type SpanAllocator = span.SpanAllocator[color.RGBA8[color.Linear]]
+
+// SpanAllocatorStats reports the allocations, reallocations, longest span
+// and capacity of a SpanAllocator.
+type SpanAllocatorStats = span.SpanAllocatorStats
+
+// NewSpanAllocator returns an empty span allocator.
+func NewSpanAllocator() *SpanAllocator {
+	return span.NewSpanAllocator[color.RGBA8[color.Linear]]()
+}
+
+// SpanAllocator returns the span allocator of the renderer.
+func (a *Agg2D) SpanAllocator() *SpanAllocator {
+	return a.impl.SpanAllocator()
+}
+
+// SetSpanAllocator makes the renderer use sa; a nil sa installs a fresh one.
+// An allocator must not be shared by renderers drawing concurrently.
+func (a *Agg2D) SetSpanAllocator(sa *SpanAllocator) {
+	a.impl.SetSpanAllocator(sa)
+}
+
+// SpanAllocator returns the span allocator of the context, e.g. to Reserve
+// the canvas width before a span-heavy frame or to read its Stats.
+func (ctx *Context) SpanAllocator() *SpanAllocator {
+	return ctx.agg2d.SpanAllocator()
+}
+
+// SetSpanAllocator makes the context use sa for gradient, pattern and image
+// spans. Contexts rendering on the same goroutine may share one allocator;
+// a nil sa installs a fresh one.
+func (ctx *Context) SetSpanAllocator(sa *SpanAllocator) {
+	ctx.agg2d.SetSpanAllocator(sa)
+}
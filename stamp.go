@@ -0,0 +1,134 @@
+package agg
+
+import (
+	"errors"
+	"math"
+
+	"github.com/MeKo-Christian/agg_go/internal/basics"
+	"github.com/MeKo-Christian/agg_go/internal/conv"
+	"github.com/MeKo-Christian/agg_go/internal/path"
+)
+
+// StampAlign selects how stamps placed by StampAlongPath are rotated.
+type StampAlign int
+
+const (
+	// StampTangent turns each stamp so its x axis follows the path direction.
+	StampTangent StampAlign = iota
+	// StampUpright keeps every stamp unrotated.
+	StampUpright
+)
+
+// Stamp is one position found by PathStamps: a point on the path and the
+// angle of the path tangent there, in radians.
+type Stamp struct {
+	X, Y  float64
+	Angle float64
+}
+
+// PathStamps returns points spaced spacing apart along p, measured by arc
+// length with curves flattened. Every sub-path starts a new run with a stamp
+// at its first point; closed sub-paths include the closing edge and do not
+// repeat a stamp at their start. A spacing <= 0 returns nil.
+func PathStamps(p *Path, spacing float64) []Stamp {
+	if p == nil || spacing <= 0 {
+		return nil
+	}
+	var (
+		stamps           []Stamp
+		startX, startY   float64
+		lastX, lastY     float64
+		next, walked     float64 // arc length of the next stamp and so far
+		open, havePoints bool
+		runStart         int // index of the first stamp of the sub-path
+	)
+	edge := func(x2, y2 float64, closing bool) {
+		dx, dy := x2-lastX, y2-lastY
+		l := math.Hypot(dx, dy)
+		if l == 0 {
+			return
+		}
+		angle := math.Atan2(dy, dx)
+		if !havePoints {
+			// The first stamp of a run takes the direction of the first edge.
+			stamps = append(stamps, Stamp{X: lastX, Y: lastY, Angle: angle})
+			havePoints = true
+			next = spacing
+		}
+		end := walked + l
+		for next < end || (next == end && !closing) {
+			t := (next - walked) / l
+			stamps = append(stamps, Stamp{X: lastX + dx*t, Y: lastY + dy*t, Angle: angle})
+			next += spacing
+		}
+		walked = end
+		lastX, lastY = x2, y2
+	}
+
+	src := conv.NewConvCurve(path.NewPathStorageStlVertexSourceAdapter(p.ps))
+	src.Rewind(0)
+	for {
+		x, y, cmd := src.Vertex()
+		if basics.IsStop(cmd) {
+			break
+		}
+		switch {
+		case basics.IsMoveTo(cmd):
+			startX, startY, lastX, lastY = x, y, x, y
+			walked, next = 0, 0
+			open, havePoints = true, false
+			runStart = len(stamps)
+		case basics.IsVertex(cmd):
+			edge(x, y, false)
+		case basics.IsEndPoly(cmd) && basics.IsClosed(uint32(cmd)) && open:
+			edge(startX, startY, true)
+			// A sub-path drawn back to its start before closing may have put
+			// its last stamp on the first one.
+			if n := len(stamps) - 1; n > runStart && stamps[n].X == startX && stamps[n].Y == startY {
+				stamps = stamps[:n]
+			}
+			open = false
+		}
+	}
+	return stamps
+}
+
+// stampMatrix returns the transform placing a stamp's origin at s.
+func stampMatrix(s Stamp, align StampAlign, ox, oy float64) *Transformations {
+	c, sn := 1.0, 0.0
+	if align == StampTangent {
+		c, sn = math.Cos(s.Angle), math.Sin(s.Angle)
+	}
+	return NewTransformationsFromValues(c, sn, -sn, c, s.X-(c*ox-sn*oy), s.Y-(sn*ox+c*oy))
+}
+
+// StampAlongPath fills a copy of marker at every spacing units along p, as
+// for map symbols or decorative borders. The marker's origin is placed on the
+// path and, with StampTangent, its x axis points along the path. All copies
+// are filled in one pass with the current fill settings and transform,
+// replacing the current path.
+func (ctx *Context) StampAlongPath(p, marker *Path, spacing float64, align StampAlign) {
+	if marker == nil {
+		return
+	}
+	ctx.agg2d.ResetPath()
+	for _, s := range PathStamps(p, spacing) {
+		ctx.AppendPath(TransformPath(marker, stampMatrix(s, align, 0, 0)))
+	}
+	ctx.agg2d.DrawPath(FillOnly)
+}
+
+// StampImageAlongPath draws img at every spacing units along p, centered on
+// the path and, with StampTangent, rotated so its x axis follows the path.
+func (ctx *Context) StampImageAlongPath(p *Path, img *Image, spacing float64, align StampAlign) error {
+	if img == nil {
+		return errors.New("image is nil")
+	}
+	ox, oy := float64(img.Width())/2, float64(img.Height())/2
+	for _, s := range PathStamps(p, spacing) {
+		if err := ctx.DrawImageTransformed(img, stampMatrix(s, align, ox, oy)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
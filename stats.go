@@ -0,0 +1,35 @@
+package agg
+
+import "github.com/MeKo-Christian/agg_go/internal/stats"
+
+// RenderStats is a snapshot of the rendering pipeline counters: rasterizer
+// cells, swept scanlines, and the spans and pixels written by the renderers.
+// Use Sub to get the counts of a single frame.
+type RenderStats = stats.Snapshot
+
+// EnableStats starts counting in the rendering pipeline. Counting is off by
+// default; the counters are shared by all contexts.
+func EnableStats() {
+	stats.SetEnabled(true)
+}
+
+// DisableStats stops counting. The counters keep their values.
+func DisableStats() {
+	stats.SetEnabled(false)
+}
+
+// StatsEnabled reports whether the pipeline counters are running.
+func StatsEnabled() bool {
+	return stats.Enabled()
+}
+
+// Stats returns the current pipeline counters.
+func Stats() RenderStats {
+	return stats.Read()
+}
+
+// ResetStats sets the pipeline counters to zero, typically at the start of
+// a frame.
+func ResetStats() {
+	stats.Reset()
+}
@@ -42,6 +42,12 @@ func (ctx *Context) SetMiterLimitDegrees(degrees float64) {
 
 // Inner miter controls (for inner corners)
 
+// SetInnerJoin sets how the inside of corners is joined.
+func (ctx *Context) SetInnerJoin(join InnerJoin) { ctx.agg2d.impl.InnerJoin(join) }
+
+// GetInnerJoin returns the current inner join style.
+func (ctx *Context) GetInnerJoin() InnerJoin { return ctx.agg2d.impl.GetInnerJoin() }
+
 // SetInnerMiterLimit sets the inner miter limit.
 func (ctx *Context) SetInnerMiterLimit(limit float64) { ctx.agg2d.impl.InnerMiterLimit(limit) }
 
@@ -55,17 +61,11 @@ func (ctx *Context) AddDash(dashLength, gapLength float64) {
 	ctx.agg2d.impl.AddDash(dashLength, gapLength)
 }
 
-// SetDashPattern sets a complete dash pattern.
+// SetDashPattern sets a complete dash pattern with SVG stroke-dasharray
+// semantics: odd-length patterns are repeated, and an empty pattern, a
+// negative value or an all-zero pattern gives a solid stroke.
 func (ctx *Context) SetDashPattern(pattern []float64) {
-	ctx.ClearDashes()
-	for i := 0; i < len(pattern)-1; i += 2 {
-		dashLen := pattern[i]
-		gapLen := dashLen
-		if i+1 < len(pattern) {
-			gapLen = pattern[i+1]
-		}
-		ctx.agg2d.impl.AddDash(dashLen, gapLen)
-	}
+	ctx.agg2d.impl.SetDashPattern(pattern)
 }
 
 // ClearDashes removes all dash patterns, returning to solid lines.
@@ -77,6 +77,35 @@ func (ctx *Context) SetDashOffset(offset float64) { ctx.agg2d.impl.DashStart(off
 // GetDashOffset returns the current dash offset.
 func (ctx *Context) GetDashOffset() float64 { return ctx.agg2d.impl.GetDashStart() }
 
+// AdvanceDashOffset moves the dash offset by delta, wrapped into one pattern
+// length, for marching-ants animation.
+func (ctx *Context) AdvanceDashOffset(delta float64) { ctx.agg2d.impl.AdvanceDashStart(delta) }
+
+// GetDashPatternLength returns the length of one dash cycle.
+func (ctx *Context) GetDashPatternLength() float64 { return ctx.agg2d.impl.DashPatternLength() }
+
+// SetDashCap sets the cap drawn at each dash end, overriding the line cap for
+// dashed strokes. With CapRound, zero-length dashes draw round dots.
+func (ctx *Context) SetDashCap(lineCap LineCap) { ctx.agg2d.impl.DashCap(lineCap) }
+
+// ClearDashCap makes dashes use the line cap again.
+func (ctx *Context) ClearDashCap() { ctx.agg2d.impl.ClearDashCap() }
+
+// Variable-width strokes
+
+// StrokeWithWidths strokes the current path with per-vertex widths, such as
+// pen pressure samples. widths[i] applies to the i-th vertex of each sub-path;
+// later vertices reuse the last width. The line cap shapes both ends.
+func (ctx *Context) StrokeWithWidths(widths []float64) {
+	ctx.agg2d.impl.StrokeWithWidths(widths)
+}
+
+// StrokeWithWidthFunc strokes the current path with the width f(t) at each
+// relative position t in [0, 1] along every sub-path, e.g. for tapered ink.
+func (ctx *Context) StrokeWithWidthFunc(f func(t float64) float64) {
+	ctx.agg2d.impl.StrokeWithWidthFunc(f)
+}
+
 // Path shortening
 
 // SetPathShorten sets the path shortening distance.
@@ -93,6 +122,12 @@ func (ctx *Context) SetApproximationScale(scale float64) { ctx.agg2d.impl.Approx
 // GetApproximationScale returns the current approximation scale.
 func (ctx *Context) GetApproximationScale() float64 { return ctx.agg2d.impl.GetApproximationScale() }
 
+// GetEffectiveApproximationScale returns the scale curves are flattened with:
+// the approximation scale times the largest stretch of the current transform.
+func (ctx *Context) GetEffectiveApproximationScale() float64 {
+	return ctx.agg2d.impl.EffectiveApproximationScale()
+}
+
 // Convenience methods for common stroke styles
 
 // SetStrokeStyle sets multiple stroke properties at once.
@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestBlurClipRegion blurs a hard edge through the clip box and checks that
+// only the pixels inside the clip box change.
+func TestBlurClipRegion(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		blur func(ctx *agg.Context)
+	}{
+		{"gaussian", func(ctx *agg.Context) { ctx.GaussianBlur(2) }},
+		{"motion", func(ctx *agg.Context) { ctx.MotionBlur(0, 8) }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := agg.NewContext(40, 20)
+			ctx.Clear(agg.White)
+			ctx.SetColor(agg.Black)
+			ctx.FillRectangle(20, 0, 20, 20)
+
+			img := ctx.GetImage()
+			red := func(x, y int) uint8 { return img.Data[y*img.Stride()+x*4] }
+
+			ctx.GetAgg2D().ClipBox(10, 0, 29, 9)
+			tc.blur(ctx)
+
+			if v := red(19, 5); v == 255 || v == 0 {
+				t.Errorf("pixel left of the edge = %d, want blurred gray", v)
+			}
+			if v := red(20, 5); v == 255 || v == 0 {
+				t.Errorf("pixel right of the edge = %d, want blurred gray", v)
+			}
+			if v := red(19, 15); v != 255 {
+				t.Errorf("pixel below the clip box = %d, want untouched white", v)
+			}
+			if v := red(20, 15); v != 0 {
+				t.Errorf("pixel below the clip box = %d, want untouched black", v)
+			}
+		})
+	}
+
+	img := agg.CreateImageFromColor(8, 8, agg.White)
+	img.GaussianBlur(3)
+	if img.Data[0] != 255 {
+		t.Errorf("blurring a flat image changed it: %d", img.Data[0])
+	}
+
+	// Formats with 8-bit channels are blurred, whatever their channel order.
+	for _, format := range []agg.ImageFormat{agg.ImageBGRA8, agg.ImageRGB8, agg.ImageGray8} {
+		img := agg.CreateImageWithFormat(9, 1, format)
+		bpp := format.BytesPerPixel()
+		for i := range bpp {
+			img.Data[4*bpp+i] = 255
+		}
+		img.GaussianBlur(1)
+		if v := img.Data[3*bpp]; v == 0 || v == 255 {
+			t.Errorf("format %d: pixel next to a bright one = %d, want blurred", format, v)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package integration
+
+import (
+	"math"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+func TestBSplineInterpolatesPoints(t *testing.T) {
+	x := []float64{0, 0.25, 0.5, 0.75, 1}
+	y := []float64{0, 0.6, 0.2, 0.9, 1}
+	s, err := agg.NewBSpline(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range x {
+		if got := s.Get(x[i]); math.Abs(got-y[i]) > 1e-9 {
+			t.Errorf("Get(%v) = %v, want %v", x[i], got, y[i])
+		}
+	}
+
+	// GetStateful agrees with Get when sweeping, e.g. to fill a LUT.
+	for i := 0; i <= 255; i++ {
+		v := float64(i) / 255
+		if a, b := s.Get(v), s.GetStateful(v); math.Abs(a-b) > 1e-12 {
+			t.Fatalf("x=%v: Get %v, GetStateful %v", v, a, b)
+		}
+	}
+
+	// Built point by point it is the same curve.
+	inc := &agg.BSpline{}
+	inc.Init(len(x))
+	for i := range x {
+		inc.AddPoint(x[i], y[i])
+	}
+	inc.Prepare()
+	if math.Abs(inc.Get(0.6)-s.Get(0.6)) > 1e-12 {
+		t.Errorf("incremental spline differs: %v vs %v", inc.Get(0.6), s.Get(0.6))
+	}
+}
+
+func TestBSplineLengthMismatch(t *testing.T) {
+	if s, err := agg.NewBSpline([]float64{0, 0.5, 1}, []float64{0, 1}); err == nil || s != nil {
+		t.Errorf("NewBSpline with 3 x and 2 y values = %v, %v, want an error", s, err)
+	}
+}
@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// pngChunks returns the chunk types and data of a PNG stream in order.
+func pngChunks(t *testing.T, data []byte) (types []string, chunks map[string][]byte) {
+	t.Helper()
+	chunks = make(map[string][]byte)
+	for p := 8; p+8 <= len(data); {
+		n := int(binary.BigEndian.Uint32(data[p:]))
+		typ := string(data[p+4 : p+8])
+		types = append(types, typ)
+		chunks[typ] = data[p+8 : p+8+n]
+		p += 12 + n
+	}
+	return types, chunks
+}
+
+func TestEncodePNGWithProfile(t *testing.T) {
+	img := agg.CreateImageFromColor(8, 8, agg.NewColor(200, 100, 50, 255))
+
+	var buf bytes.Buffer
+	if err := img.EncodePNG(&buf, agg.EncodeOptions{Profile: agg.SRGBProfile()}); err != nil {
+		t.Fatal(err)
+	}
+	types, chunks := pngChunks(t, buf.Bytes())
+	if len(types) < 5 || types[0] != "IHDR" || types[1] != "sRGB" || types[2] != "gAMA" || types[3] != "cHRM" {
+		t.Errorf("chunk order = %v", types)
+	}
+	if _, ok := chunks["iCCP"]; ok {
+		t.Error("sRGB output has an iCCP chunk too")
+	}
+	decoded, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, g, b, _ := decoded.At(3, 3).RGBA(); r>>8 != 200 || g>>8 != 100 || b>>8 != 50 {
+		t.Errorf("decoded pixel = %d %d %d", r>>8, g>>8, b>>8)
+	}
+
+	custom, err := agg.NewColorProfile("custom", agg.SRGBProfile().Data())
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+	if err := img.EncodePNG(&buf, agg.EncodeOptions{Profile: custom}); err != nil {
+		t.Fatal(err)
+	}
+	_, chunks = pngChunks(t, buf.Bytes())
+	iccp, ok := chunks["iCCP"]
+	if !ok {
+		t.Fatal("no iCCP chunk")
+	}
+	name, rest, _ := bytes.Cut(iccp, []byte{0})
+	if string(name) != "custom" || rest[0] != 0 {
+		t.Errorf("iCCP header = %q, method %d", name, rest[0])
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(rest[1:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile, _ := io.ReadAll(zr)
+	if !bytes.Equal(profile, custom.Data()) {
+		t.Error("embedded profile differs")
+	}
+
+	if _, err := agg.NewColorProfile("bad", []byte("not a profile")); err == nil {
+		t.Error("NewColorProfile accepted garbage")
+	}
+}
+
+func TestEncodeJPEGWithProfile(t *testing.T) {
+	img := agg.CreateImageFromColor(16, 16, agg.NewColor(20, 120, 220, 255))
+	var buf bytes.Buffer
+	if err := img.EncodeJPEG(&buf, agg.EncodeOptions{Profile: agg.SRGBProfile(), Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if data[2] != 0xFF || data[3] != 0xE2 || string(data[6:18]) != "ICC_PROFILE\x00" {
+		t.Fatalf("no APP2 ICC segment after SOI: % x", data[:18])
+	}
+	n := int(binary.BigEndian.Uint16(data[4:]))
+	if data[18] != 1 || data[19] != 1 || !bytes.Equal(data[20:4+n], agg.SRGBProfile().Data()) {
+		t.Error("ICC segment does not hold the profile")
+	}
+	if _, err := jpeg.Decode(bytes.NewReader(data)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEncodeJPEGRejectsUnsupportedOptions(t *testing.T) {
+	img := agg.CreateImageFromColor(16, 16, agg.NewColor(20, 120, 220, 255))
+	for _, opts := range []agg.EncodeOptions{
+		{Progressive: true},
+		{Subsampling: agg.Subsample422},
+		{Subsampling: agg.Subsample444},
+	} {
+		var buf bytes.Buffer
+		if err := img.EncodeJPEG(&buf, opts); err == nil || buf.Len() != 0 {
+			t.Errorf("%+v: err %v after writing %d bytes, want an error and no output", opts, err, buf.Len())
+		}
+	}
+}
+
+func TestEncodeFromLinear(t *testing.T) {
+	img := agg.CreateImageFromColor(2, 2, agg.NewColor(0, 128, 255, 255))
+	var buf bytes.Buffer
+	if err := img.EncodePNG(&buf, agg.EncodeOptions{FromLinear: true}); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := decoded.(*image.RGBA).RGBAAt(0, 0)
+	if c.R != 0 || c.G < 186 || c.G > 189 || c.B != 255 || c.A != 255 {
+		t.Errorf("converted pixel = %v, want 0, ~188, 255, 255", c)
+	}
+}
+
+func TestEncodeFromLinearTranslucent(t *testing.T) {
+	ctx := agg.NewContext(2, 2)
+	ctx.Clear(agg.Transparent)
+	ctx.SetColor(agg.NewColor(200, 100, 0, 128))
+	ctx.FillRectangle(0, 0, 2, 2)
+	var buf bytes.Buffer
+	if err := ctx.GetImage().EncodePNG(&buf, agg.EncodeOptions{FromLinear: true}); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The curve maps the straight colors: linear 200 and 100 are sRGB 229
+	// and 168, whatever the alpha.
+	c := color.NRGBAModel.Convert(decoded.At(0, 0)).(color.NRGBA)
+	if c.R < 227 || c.R > 231 || c.G < 166 || c.G > 170 || c.B != 0 || c.A != 128 {
+		t.Errorf("converted pixel = %v, want about 229, 168, 0, 128", c)
+	}
+}
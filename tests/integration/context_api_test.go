@@ -1,6 +1,12 @@
 package integration
 
 import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/gif"
+	"math"
 	"testing"
 
 	agg "github.com/MeKo-Christian/agg_go"
@@ -44,3 +50,918 @@ func TestContextAPIFillRectangle(t *testing.T) {
 			outside[0], outside[1], outside[2], outside[3])
 	}
 }
+
+// TestContextAPIDrawTextBitmapFallback checks that text renders without a
+// loaded font and that DrawTextOptions alignment and font apply per call.
+func TestContextAPIDrawTextBitmapFallback(t *testing.T) {
+	inkBounds := func(img *agg.Image) (minX, maxX int) {
+		minX, maxX = img.Width(), -1
+		for y := 0; y < img.Height(); y++ {
+			for x := 0; x < img.Width(); x++ {
+				if img.Data[(y*img.Width()+x)*4] < 128 {
+					minX, maxX = min(minX, x), max(maxX, x)
+				}
+			}
+		}
+		return minX, maxX
+	}
+
+	ctx := agg.NewContext(100, 30)
+	ctx.Clear(agg.White)
+	ctx.SetColor(agg.Black)
+	if err := ctx.DrawText("Hi", 10, 20); err != nil {
+		t.Fatal(err)
+	}
+	if minX, maxX := inkBounds(ctx.GetImage()); maxX < 0 || minX < 10 || maxX >= 26 {
+		t.Fatalf("default bitmap text ink = %d..%d, want inside 10..25", minX, maxX)
+	}
+
+	ctx.Clear(agg.White)
+	width := ctx.GetTextWidth("Hello")
+	if err := ctx.DrawTextWithOptions(50, 20, "Hello", agg.DrawTextOptions{AlignX: agg.AlignCenter}); err != nil {
+		t.Fatal(err)
+	}
+	if minX, maxX := inkBounds(ctx.GetImage()); float64(minX) < 50-width/2 || float64(maxX) > 50+width/2 {
+		t.Errorf("centered text ink = %d..%d, want within %g of x=50", minX, maxX, width/2)
+	}
+
+	ctx.Clear(agg.White)
+	if err := ctx.DrawTextWithOptions(10, 20, "Hello", agg.DrawTextOptions{BitmapFont: agg.BitmapFontGSE4x6}); err != nil {
+		t.Fatal(err)
+	}
+	if _, maxX := inkBounds(ctx.GetImage()); maxX >= 10+5*4 {
+		t.Errorf("GSE4x6 text should be at most 20 px wide, ink ends at %d", maxX)
+	}
+	if got := ctx.GetTextWidth("Hello"); got != width {
+		t.Errorf("per-call font leaked into the context: width %g, want %g", got, width)
+	}
+}
+
+// TestContextAPITextDecoration tests underline and line-through placement
+// with the bitmap fallback font.
+func TestContextAPITextDecoration(t *testing.T) {
+	inkRows := func(img *agg.Image, x int) []int {
+		var rows []int
+		for y := 0; y < img.Height(); y++ {
+			if img.Data[(y*img.Width()+x)*4] < 128 {
+				rows = append(rows, y)
+			}
+		}
+		return rows
+	}
+
+	ctx := agg.NewContext(40, 30)
+	ctx.Clear(agg.White)
+	ctx.SetColor(agg.Black)
+	_ = ctx.DrawText("HH", 2, 20)
+	// The gap between the two Hs has no ink without decorations.
+	plain := inkRows(ctx.GetImage(), 3)
+	if len(plain) == 0 || len(inkRows(ctx.GetImage(), 9)) != 0 {
+		t.Fatalf("unexpected plain text ink: column 3 %v", plain)
+	}
+	base := plain[len(plain)-1]
+
+	ctx.Clear(agg.White)
+	ctx.SetUnderline(true)
+	if ctx.GetTextDecoration() != agg.DecorationUnderline {
+		t.Fatalf("decoration = %v", ctx.GetTextDecoration())
+	}
+	_ = ctx.DrawTextWithOptions(2, 20, "HH", agg.DrawTextOptions{Decoration: agg.DecorationLineThrough})
+	ctx.SetUnderline(false)
+
+	rows := inkRows(ctx.GetImage(), 9)
+	if len(rows) != 2 || rows[1] != base+2 || rows[0] >= base || rows[0] <= plain[0] {
+		t.Errorf("decoration rows %v, want a line through rows %d..%d and one at %d", rows, plain[0], base, base+2)
+	}
+	if ctx.GetTextDecoration() != agg.DecorationNone {
+		t.Errorf("per-call decoration leaked: %v", ctx.GetTextDecoration())
+	}
+	if m := ctx.TextDecorationMetrics(); m.Thickness != 1 {
+		t.Errorf("bitmap decoration thickness = %v, want 1", m.Thickness)
+	}
+}
+
+// TestContextAPIDrawTextStyledNeedsOutlines tests that styled text refuses
+// the bitmap fallback font, which has no glyph outlines.
+func TestContextAPIDrawTextStyledNeedsOutlines(t *testing.T) {
+	ctx := agg.NewContext(60, 20)
+	ctx.Clear(agg.White)
+	style := agg.TextStyle{Fill: agg.Black, Stroke: agg.Red, StrokeWidth: 1}
+	if err := ctx.DrawTextStyled(5, 15, "Hi", style); err == nil {
+		t.Fatal("DrawTextStyled drew with a bitmap font")
+	}
+	for i, v := range ctx.GetImage().Data {
+		if v != 255 {
+			t.Fatalf("byte %d changed to %d", i, v)
+		}
+	}
+}
+
+// TestContextAPIPaints tests that SetFillPaint and SetStrokePaint accept
+// colors, gradients and image patterns.
+func TestContextAPIPaints(t *testing.T) {
+	width, height := 20, 20
+	ctx := agg.NewContext(width, height)
+	ctx.Clear(agg.White)
+
+	// A 2x1 tile: red then blue.
+	tile := agg.NewImage([]uint8{255, 0, 0, 255, 0, 0, 255, 255}, 2, 1, 2*4)
+	ctx.SetFillPaint(agg.NewPattern(tile))
+	if got := ctx.GetFillGradientType(); got != agg.PatternGradient {
+		t.Fatalf("fill paint type = %d, want PatternGradient", got)
+	}
+	ctx.FillRectangle(0, 0, 20, 10)
+
+	img := ctx.GetImage()
+	stride := width * 4
+	if p := getPixel(img.Data, stride, 4, 5); p[0] != 255 || p[2] != 0 {
+		t.Errorf("pattern pixel (4,5) = %v, want red", p)
+	}
+	if p := getPixel(img.Data, stride, 5, 5); p[0] != 0 || p[2] != 255 {
+		t.Errorf("pattern pixel (5,5) = %v, want blue", p)
+	}
+
+	ctx.SetStrokePaint(agg.NewConicGradient(10, 15, 0,
+		agg.GradientStop{Position: 0, Color: agg.Black},
+		agg.GradientStop{Position: 1, Color: agg.White}))
+	if got := ctx.GetStrokeGradientType(); got != agg.ConicGradient {
+		t.Fatalf("stroke paint type = %d, want ConicGradient", got)
+	}
+	ctx.SetLineWidth(4)
+	ctx.DrawLine(0, 15, 20, 15)
+	if p := getPixel(img.Data, stride, 18, 15); p[0] < 40 || p[0] > 90 {
+		t.Errorf("conic stroke at 3 o'clock = %v, want ~64", p)
+	}
+
+	ctx.SetFillPaint(agg.Green)
+	if got := ctx.GetFillGradientType(); got != agg.SolidGradient {
+		t.Errorf("fill paint type after a color = %d, want SolidGradient", got)
+	}
+	ctx.SetStrokePaint(nil)
+	if got := ctx.GetStrokeGradientType(); got != agg.SolidGradient {
+		t.Errorf("stroke paint type after nil = %d, want SolidGradient", got)
+	}
+}
+
+// TestContextAPIPaintStyle tests that paints keep their own opacity and
+// blend mode, independent of the context state.
+func TestContextAPIPaintStyle(t *testing.T) {
+	width, height := 20, 20
+	ctx := agg.NewContext(width, height)
+	ctx.Clear(agg.White)
+
+	solid := agg.NewSolidPaint(agg.Black)
+	solid.SetOpacity(0.5)
+	ctx.SetFillPaint(solid)
+	ctx.FillRectangle(0, 0, 10, 10)
+
+	multiply := agg.NewSolidPaint(agg.NewColor(255, 0, 0, 255))
+	multiply.SetBlendMode(agg.BlendMultiply)
+	ctx.SetFillPaint(multiply)
+	ctx.FillRectangle(0, 0, 20, 10)
+
+	img := ctx.GetImage()
+	stride := width * 4
+	// Red multiplied over 50% gray keeps only the gray's red channel.
+	if p := getPixel(img.Data, stride, 5, 5); p[0] < 120 || p[0] > 135 || p[1] > 5 {
+		t.Errorf("multiply over gray = %v, want (~128, 0, 0)", p)
+	}
+	if p := getPixel(img.Data, stride, 15, 5); p[0] != 255 || p[1] > 5 {
+		t.Errorf("multiply over white = %v, want red", p)
+	}
+	if ctx.GetBlendMode() != agg.BlendAlpha {
+		t.Errorf("context blend mode = %d, want BlendAlpha", ctx.GetBlendMode())
+	}
+	if multiply.BlendMode() != agg.BlendMultiply || solid.BlendMode() != agg.BlendInherit {
+		t.Errorf("paint blend modes = %d, %d", multiply.BlendMode(), solid.BlendMode())
+	}
+
+	// An image paint is clipped to the filled shape.
+	tile := agg.NewImage([]uint8{0, 0, 255, 255}, 1, 1, 4)
+	ctx.SetFillPaint(agg.NewImagePaint(tile, 0, 12, 10, 8))
+	ctx.FillRectangle(5, 10, 15, 10)
+	if p := getPixel(img.Data, stride, 7, 15); p[2] != 255 || p[0] != 0 {
+		t.Errorf("image paint pixel = %v, want blue", p)
+	}
+	if p := getPixel(img.Data, stride, 15, 15); p[0] != 255 || p[1] != 255 {
+		t.Errorf("pixel right of the image = %v, want white", p)
+	}
+}
+
+// TestContextAPIFillAndStroke tests that the combined helpers match filling
+// and then stroking the same outline, with the stroke over the fill.
+func TestContextAPIFillAndStroke(t *testing.T) {
+	width, height := 40, 40
+	setup := func() *agg.Context {
+		ctx := agg.NewContext(width, height)
+		ctx.Clear(agg.White)
+		ctx.SetFillPaint(agg.NewColor(0, 0, 255, 255))
+		ctx.SetStrokePaint(agg.NewColor(255, 0, 0, 128))
+		ctx.SetLineWidth(4)
+		return ctx
+	}
+
+	shapes := []struct {
+		name     string
+		combined func(*agg.Context)
+		separate func(*agg.Context)
+	}{
+		{"rectangle",
+			func(c *agg.Context) { c.FillAndStrokeRectangle(8, 8, 24, 24) },
+			func(c *agg.Context) { c.FillRectangle(8, 8, 24, 24); c.DrawRectangle(8, 8, 24, 24) }},
+		{"ellipse",
+			func(c *agg.Context) { c.FillAndStrokeEllipse(20, 20, 12, 8) },
+			func(c *agg.Context) { c.FillEllipse(20, 20, 12, 8); c.DrawEllipse(20, 20, 12, 8) }},
+		{"circle",
+			func(c *agg.Context) { c.FillAndStrokeCircle(20, 20, 12) },
+			func(c *agg.Context) { c.FillCircle(20, 20, 12); c.DrawCircle(20, 20, 12) }},
+		{"rounded rectangle",
+			func(c *agg.Context) { c.FillAndStrokeRoundedRectangle(8, 8, 24, 24, 6) },
+			func(c *agg.Context) { c.FillRoundedRectangle(8, 8, 24, 24, 6); c.DrawRoundedRectangle(8, 8, 24, 24, 6) }},
+	}
+	for _, s := range shapes {
+		combined, separate := setup(), setup()
+		s.combined(combined)
+		s.separate(separate)
+		got, want := combined.GetImage().Data, separate.GetImage().Data
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: pixel byte %d = %d, want %d", s.name, i, got[i], want[i])
+				break
+			}
+		}
+	}
+
+	ctx := setup()
+	ctx.FillAndStrokeRectangle(8, 8, 24, 24)
+	stride := width * 4
+	data := ctx.GetImage().Data
+	// Outer half of the stroke: red over white; inner half: red over blue.
+	if p := getPixel(data, stride, 20, 6); p[0] != 255 || p[1] < 120 || p[1] > 135 {
+		t.Errorf("outer stroke half = %v, want pink", p)
+	}
+	if p := getPixel(data, stride, 20, 9); p[0] < 120 || p[0] > 135 || p[1] != 0 || p[2] < 120 || p[2] > 135 {
+		t.Errorf("inner stroke half = %v, want purple", p)
+	}
+}
+
+// TestContextAPIUnits tests that SetUnits scales coordinates and stroke
+// widths and survives ResetTransform.
+func TestContextAPIUnits(t *testing.T) {
+	ctx := agg.NewContext(100, 100)
+	ctx.Clear(agg.White)
+	ctx.SetUnits(agg.Millimeters, 254) // 10 pixels per millimeter
+	if s := ctx.UnitScale(); math.Abs(s-10) > 1e-12 {
+		t.Fatalf("UnitScale = %v, want 10", s)
+	}
+	if w, h := ctx.PageSize(); math.Abs(w-10) > 1e-12 || math.Abs(h-10) > 1e-12 {
+		t.Errorf("PageSize = %v x %v, want 10 x 10", w, h)
+	}
+	if u, dpi := ctx.GetUnits(); u != agg.Millimeters || dpi != 254 {
+		t.Errorf("GetUnits = %v, %v", u, dpi)
+	}
+
+	ctx.Translate(50, 50) // replaced by ResetTransform
+	ctx.ResetTransform()
+	ctx.SetColor(agg.Blue)
+	ctx.FillRectangle(1, 1, 2, 2)
+	ctx.SetColor(agg.Red)
+	ctx.SetStrokeWidth(0.4)
+	ctx.DrawLine(5, 6, 9, 6)
+
+	stride := 100 * 4
+	data := ctx.GetImage().Data
+	for _, tc := range []struct {
+		x, y int
+		want [4]uint8
+	}{
+		{15, 15, [4]uint8{0, 0, 255, 255}},
+		{29, 29, [4]uint8{0, 0, 255, 255}},
+		{31, 31, [4]uint8{255, 255, 255, 255}},
+		{70, 58, [4]uint8{255, 0, 0, 255}},
+		{70, 61, [4]uint8{255, 0, 0, 255}},
+		{70, 63, [4]uint8{255, 255, 255, 255}},
+	} {
+		p := getPixel(data, stride, tc.x, tc.y)
+		if [4]uint8{p[0], p[1], p[2], p[3]} != tc.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", tc.x, tc.y, p, tc.want)
+		}
+	}
+
+	ctx.SetUnits(agg.Pixels, 300)
+	if s := ctx.UnitScale(); s != 1 {
+		t.Errorf("UnitScale after switching to pixels = %v", s)
+	}
+	if x, _ := ctx.WorldToScreen(5, 5); x != 5 {
+		t.Errorf("transform not reset with pixels, x = %v", x)
+	}
+	if pt := agg.Points.PerInch(); pt != 72 {
+		t.Errorf("Points.PerInch = %v", pt)
+	}
+
+	ctx.SetUnits(agg.Unit(99), 300)
+	if u, dpi := ctx.GetUnits(); u != agg.Pixels || dpi != 0 {
+		t.Errorf("GetUnits after an unknown unit = %v, %v, want px, 0", u, dpi)
+	}
+	if s := ctx.UnitScale(); s != 1 {
+		t.Errorf("UnitScale after an unknown unit = %v", s)
+	}
+}
+
+// TestAttachRGBA checks rendering straight into a standard library image,
+// including a sub-image with a wider stride.
+func TestAttachRGBA(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	ctx := agg.NewContextForRGBA(dst)
+	ctx.Clear(agg.White)
+	ctx.SetColor(agg.Red)
+	ctx.FillRectangle(0, 0, 10, 10)
+	if got := dst.RGBAAt(5, 5); got != (color.RGBA{255, 0, 0, 255}) {
+		t.Fatalf("pixel (5,5) = %v, want red", got)
+	}
+
+	sub := dst.SubImage(image.Rect(20, 10, 30, 20)).(*image.RGBA)
+	a := agg.NewAgg2D()
+	a.AttachRGBA(sub)
+	a.ClearAll(agg.Blue)
+	if got := dst.RGBAAt(25, 15); got != (color.RGBA{0, 0, 255, 255}) {
+		t.Errorf("sub-image pixel = %v, want blue", got)
+	}
+	if got := dst.RGBAAt(19, 15); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("pixel left of the sub-image = %v, want white", got)
+	}
+	if got := dst.RGBAAt(25, 20); got != (color.RGBA{255, 255, 255, 255}) {
+		t.Errorf("pixel below the sub-image = %v, want white", got)
+	}
+
+	// Half-transparent red over a transparent pixel gives premultiplied red.
+	clearImg := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	ctx = agg.NewContextForRGBA(clearImg)
+	ctx.SetColor(agg.NewColor(255, 0, 0, 128))
+	ctx.FillRectangle(0, 0, 4, 4)
+	if got := clearImg.RGBAAt(1, 1); got.R != got.A || got.A < 127 || got.A > 129 {
+		t.Errorf("blended pixel = %v, want premultiplied half red", got)
+	}
+}
+
+// TestContextResize checks the three resize modes and that drawing state
+// survives a resize.
+func TestContextResize(t *testing.T) {
+	red := func() *agg.Context {
+		ctx := agg.NewContext(10, 10)
+		ctx.Clear(agg.Red)
+		return ctx
+	}
+
+	ctx := red()
+	ctx.Resize(20, 16, agg.ResizeDiscard)
+	img := ctx.GetImage()
+	if ctx.Width() != 20 || ctx.Height() != 16 || img.Width() != 20 || img.Height() != 16 {
+		t.Fatalf("size after resize = %dx%d (image %dx%d)", ctx.Width(), ctx.Height(), img.Width(), img.Height())
+	}
+	if p := getPixel(img.Data, 20*4, 5, 5); p[3] != 0 {
+		t.Errorf("discard kept content: %v", p)
+	}
+
+	ctx = red()
+	ctx.Resize(20, 16, agg.ResizePreserve)
+	img = ctx.GetImage()
+	if p := getPixel(img.Data, 20*4, 9, 9); p[0] != 255 || p[3] != 255 {
+		t.Errorf("preserve lost old pixel: %v", p)
+	}
+	if p := getPixel(img.Data, 20*4, 15, 12); p[3] != 0 {
+		t.Errorf("preserve padding not transparent: %v", p)
+	}
+
+	ctx = red()
+	ctx.Resize(6, 4, agg.ResizePreserve)
+	img = ctx.GetImage()
+	if p := getPixel(img.Data, 6*4, 5, 3); p[0] != 255 || p[3] != 255 {
+		t.Errorf("preserve crop lost pixel: %v", p)
+	}
+
+	ctx = agg.NewContext(10, 10)
+	ctx.SetColor(agg.Blue)
+	ctx.FillRectangle(0, 0, 5, 10)
+	ctx.Resize(20, 20, agg.ResizeScale)
+	img = ctx.GetImage()
+	if p := getPixel(img.Data, 20*4, 4, 10); p[2] < 200 || p[3] < 200 {
+		t.Errorf("scaled left half not blue: %v", p)
+	}
+	if p := getPixel(img.Data, 20*4, 15, 10); p[3] > 50 {
+		t.Errorf("scaled right half not transparent: %v", p)
+	}
+
+	// State: transform and fill color carry over, and the full-buffer clip
+	// grows with the context.
+	ctx = agg.NewContext(10, 10)
+	ctx.Translate(10, 10)
+	ctx.SetColor(agg.Green)
+	ctx.Resize(30, 30, agg.ResizeDiscard)
+	ctx.FillRectangle(0, 0, 10, 10)
+	img = ctx.GetImage()
+	if p := getPixel(img.Data, 30*4, 15, 15); p[1] < 100 || p[3] != 255 {
+		t.Errorf("state not kept across resize: %v", p)
+	}
+	if p := getPixel(img.Data, 30*4, 5, 5); p[3] != 0 {
+		t.Errorf("transform lost across resize: %v", p)
+	}
+}
+
+// TestContextAPIFillPathWith checks that per-call paints and blend modes do
+// not leak into the context state.
+func TestContextAPIFillPathWith(t *testing.T) {
+	ctx := agg.NewContext(20, 10)
+	ctx.Clear(agg.NewColor(200, 100, 50, 255))
+	ctx.SetColor(agg.Green)
+
+	rect := agg.NewPath()
+	rect.MoveTo(0, 0)
+	rect.LineTo(10, 0)
+	rect.LineTo(10, 10)
+	rect.LineTo(0, 10)
+	rect.ClosePath()
+	ctx.FillPathWith(rect, agg.NewColor(128, 128, 128, 255), agg.BlendMultiply)
+
+	img := ctx.GetImage()
+	p := getPixel(img.Data, 20*4, 5, 5)
+	if math.Abs(float64(p[0])-100) > 2 || math.Abs(float64(p[1])-50) > 2 || math.Abs(float64(p[2])-25) > 2 {
+		t.Errorf("multiplied pixel = %v, want about [100 50 25]", p)
+	}
+	if mode := ctx.GetBlendMode(); mode != agg.BlendAlpha {
+		t.Errorf("context blend mode changed to %v", mode)
+	}
+
+	// The context fill color and blend mode are unchanged afterwards.
+	ctx.FillRectangle(10, 0, 10, 10)
+	if p := getPixel(img.Data, 20*4, 15, 5); p[0] != 0 || p[1] != 255 || p[2] != 0 {
+		t.Errorf("later fill = %v, want plain green", p)
+	}
+
+	ctx.Clear(agg.White)
+	ctx.StrokePathWith(rect, agg.Red, agg.BlendInherit)
+	if p := getPixel(img.Data, 20*4, 0, 5); p[1] > 200 {
+		t.Errorf("stroke with red not drawn: %v", p)
+	}
+}
+
+// TestToPaletted checks indexed-color conversion and the GIF round trip.
+func TestToPaletted(t *testing.T) {
+	ctx := agg.NewContext(32, 16)
+	ctx.SetColor(agg.Red)
+	ctx.FillRectangle(0, 0, 16, 16)
+	ctx.SetColor(agg.Blue)
+	ctx.FillRectangle(16, 0, 16, 8)
+
+	p, err := ctx.GetImage().ToPaletted(agg.PaletteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Palette) != 3 {
+		t.Fatalf("palette = %v, want transparent, red and blue", p.Palette)
+	}
+	if _, _, _, a := p.At(20, 12).RGBA(); a != 0 {
+		t.Errorf("unpainted pixel not transparent")
+	}
+	if r, g, b, _ := p.At(4, 4).RGBA(); r>>8 != 255 || g != 0 || b != 0 {
+		t.Errorf("red area = %v", p.At(4, 4))
+	}
+
+	fixed := []agg.Color{agg.Black, agg.White}
+	p, err = ctx.GetImage().ToPaletted(agg.PaletteOptions{Palette: fixed, Dither: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Palette) != 2 {
+		t.Errorf("fixed palette size = %d, want 2", len(p.Palette))
+	}
+	if _, err := ctx.GetImage().ToPaletted(agg.PaletteOptions{Colors: 1}); err == nil {
+		t.Error("palette size 1 accepted")
+	}
+
+	var buf bytes.Buffer
+	if err := ctx.GetImage().EncodeGIF(&buf, agg.PaletteOptions{Colors: 16}); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := gif.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, _, b, _ := decoded.At(20, 4).RGBA(); r != 0 || b>>8 != 255 {
+		t.Errorf("decoded blue area = %v", decoded.At(20, 4))
+	}
+
+	// Translucent pixels are quantized by their straight color.
+	ctx = agg.NewContext(8, 8)
+	ctx.SetColor(agg.NewColor(255, 0, 0, 200))
+	ctx.FillRectangle(0, 0, 8, 8)
+	p, err = ctx.GetImage().ToPaletted(agg.PaletteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := color.NRGBAModel.Convert(p.At(4, 4)).(color.NRGBA); c.R < 254 || c.G != 0 || c.B != 0 {
+		t.Errorf("translucent red quantized to %v", c)
+	}
+	half := agg.NewColor(255, 0, 0, 128)
+	p, err = ctx.GetImage().ToPaletted(agg.PaletteOptions{Palette: []agg.Color{half}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := p.Palette[0].(color.NRGBA); !ok || c != (color.NRGBA{255, 0, 0, 128}) {
+		t.Errorf("fixed palette entry = %#v, want straight NRGBA", p.Palette[0])
+	}
+}
+
+func TestBGRAImageAndAlphaView(t *testing.T) {
+	buf := make([]uint8, 16*16*4)
+	bgra := agg.NewBGRAImage(buf, 16, 16, 16*4)
+	ctx := agg.NewContextForImage(bgra)
+	ctx.SetColor(agg.Red)
+	ctx.FillRectangle(0, 0, 8, 16)
+
+	if px := getPixel(buf, 16*4, 4, 4); px[0] != 0 || px[1] != 0 || px[2] != 255 || px[3] != 255 {
+		t.Errorf("BGRA bytes = %v, want [0 0 255 255]", px)
+	}
+	if c := bgra.ToGoImage().RGBAAt(4, 4); c != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("ToGoImage = %v, want red", c)
+	}
+
+	dst := agg.NewContext(16, 16)
+	if err := dst.DrawImage(bgra, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if px := getPixel(dst.GetImage().Data, 16*4, 4, 4); px[0] != 255 || px[2] != 0 || px[3] != 255 {
+		t.Errorf("BGRA drawn into RGBA = %v, want red", px)
+	}
+
+	alpha := bgra.AlphaView()
+	if alpha == nil || alpha.Format() != agg.ImageGray8 {
+		t.Fatal("AlphaView did not return a Gray8 image")
+	}
+	std, err := alpha.ToStandardImage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gray := std.(*image.Gray)
+	if gray.GrayAt(4, 4).Y != 255 || gray.GrayAt(12, 4).Y != 0 {
+		t.Errorf("alpha view = %d, %d, want 255, 0", gray.GrayAt(4, 4).Y, gray.GrayAt(12, 4).Y)
+	}
+
+	// The view shares memory with the image.
+	ctx.FillRectangle(8, 0, 16, 16)
+	std, _ = alpha.ToStandardImage()
+	if y := std.(*image.Gray).GrayAt(12, 4).Y; y != 255 {
+		t.Errorf("alpha view after drawing = %d, want 255", y)
+	}
+
+	if err := dst.GetImage().ApplyMask(alpha); err != nil {
+		t.Fatal(err)
+	}
+	if agg.NewGrayImage(make([]uint8, 4), 2, 2, 2).AlphaView() != nil {
+		t.Error("AlphaView of a gray image")
+	}
+}
+
+func TestARGBImages(t *testing.T) {
+	ctx, err := agg.NewContextWithFormat(16, 16, agg.ImageARGB8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx.SetColor(agg.Red)
+	ctx.FillRectangle(0, 0, 8, 16)
+
+	argb := ctx.GetImage()
+	if px := getPixel(argb.Data, 16*4, 4, 4); px[0] != 255 || px[1] != 255 || px[2] != 0 || px[3] != 0 {
+		t.Errorf("ARGB bytes = %v, want [255 255 0 0]", px)
+	}
+	if c := argb.ToGoImage().RGBAAt(4, 4); c != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("ToGoImage = %v, want red", c)
+	}
+	if alpha := argb.AlphaView(); alpha == nil || alpha.ToGoImage().RGBAAt(12, 4).R != 0 {
+		t.Error("AlphaView of an ARGB image does not read alpha")
+	}
+
+	// Blending converts between orders.
+	dst := agg.NewContext(16, 16)
+	if err := dst.GetAgg2D().BlendImageSimple(argb, 0, 0, 255); err != nil {
+		t.Fatal(err)
+	}
+	if px := getPixel(dst.GetImage().Data, 16*4, 4, 4); px[0] != 255 || px[2] != 0 || px[3] != 255 {
+		t.Errorf("ARGB blended into RGBA = %v, want red", px)
+	}
+
+	abgr := agg.CreateImageWithFormat(2, 2, agg.ImageABGR8)
+	if err := abgr.SetChannel(agg.ChannelAlpha|agg.ChannelBlue, 200); err != nil {
+		t.Fatal(err)
+	}
+	if px := abgr.Data[:4]; px[0] != 200 || px[1] != 200 || px[3] != 0 {
+		t.Errorf("ABGR SetChannel bytes = %v, want [200 200 0 0]", px)
+	}
+
+	switch f := agg.NativeARGB32(); f {
+	case agg.ImageBGRA8, agg.ImageARGB8:
+		var v [4]uint8
+		binary.NativeEndian.PutUint32(v[:], 0x80402010) // A, R, G, B
+		img := agg.NewImageWithFormat(v[:], 1, 1, 4, f)
+		if c := img.ToGoImage().RGBAAt(0, 0); c != (color.RGBA{0x40, 0x20, 0x10, 0x80}) {
+			t.Errorf("native ARGB32 pixel = %v, want {64 32 16 128}", c)
+		}
+	default:
+		t.Errorf("NativeARGB32() = %d", f)
+	}
+}
+
+func TestContourAndImageGradients(t *testing.T) {
+	square := agg.NewPath()
+	square.MoveTo(4, 4)
+	square.LineTo(28, 4)
+	square.LineTo(28, 28)
+	square.LineTo(4, 28)
+	square.ClosePath()
+
+	ctx := agg.NewContext(32, 32)
+	g := agg.NewContourGradient(square, agg.GradientStop{Position: 0, Color: agg.Black}, agg.GradientStop{Position: 1, Color: agg.White})
+	if g.Type() != agg.ContourGradient {
+		t.Fatalf("Type() = %d, want ContourGradient", g.Type())
+	}
+	ctx.SetFillGradient(g)
+	ctx.BeginPath()
+	ctx.AppendPath(square)
+	ctx.Fill()
+	img := ctx.GetImage()
+	if edge, center := getPixel(img.Data, 32*4, 5, 16)[0], getPixel(img.Data, 32*4, 16, 16)[0]; edge >= center {
+		t.Errorf("contour gradient edge %d, center %d: want brighter center", edge, center)
+	}
+
+	// A nil path is an empty outline.
+	ctx.SetFillGradient(agg.NewContourGradient(nil, agg.GradientStop{Position: 0, Color: agg.Black}))
+	ctx.FillRectangle(0, 0, 32, 32)
+
+	// Half the RGBA mask is opaque; the gradient follows its alpha.
+	mask := agg.NewContext(32, 32)
+	mask.SetColor(agg.White)
+	mask.FillRectangle(16, 0, 16, 32)
+	ctx.SetFillGradient(agg.NewImageGradient(mask.GetImage(), 0, 0, agg.GradientStop{Position: 0, Color: agg.Red}, agg.GradientStop{Position: 1, Color: agg.Blue}))
+	ctx.FillRectangle(0, 0, 32, 32)
+	if left, right := getPixel(img.Data, 32*4, 4, 4), getPixel(img.Data, 32*4, 24, 4); left[0] != 255 || right[2] != 255 {
+		t.Errorf("image gradient = %v, %v, want red then blue", left, right)
+	}
+}
+
+func TestContextShapePrimitives(t *testing.T) {
+	ctx := agg.NewContext(64, 64)
+	ctx.SetColor(agg.Red)
+	ctx.FillStar(32, 32, 30, 12, 5, 0)
+	img := ctx.GetImage()
+	if getPixel(img.Data, 64*4, 32, 32)[3] != 255 || getPixel(img.Data, 64*4, 32, 5)[3] == 0 {
+		t.Error("star center or top tip not filled")
+	}
+	// Between the two upper tips lies a notch.
+	if a := getPixel(img.Data, 64*4, 44, 8)[3]; a != 0 {
+		t.Errorf("star notch alpha = %d, want 0", a)
+	}
+
+	ctx.Clear(agg.Transparent)
+	ctx.FillSuperellipse(32, 32, 20, 20, 8)
+	// A squircle with a high exponent covers far more of the corner than a
+	// circle would.
+	if a := getPixel(img.Data, 64*4, 48, 48)[3]; a != 255 {
+		t.Errorf("superellipse corner alpha = %d, want 255", a)
+	}
+
+	hexagon := agg.RegularPolygonPath(0, 0, 10, 6, 0)
+	if !hexagon.Contains(0, -9.5, false) || hexagon.Contains(9.5, 0, false) {
+		t.Error("hexagon should have a vertex at the top and an edge at the side")
+	}
+	if agg.StarPath(0, 0, 10, 4, 5, 0).Length() == 0 || agg.SuperellipsePath(0, 0, 5, 5, 4).Length() == 0 {
+		t.Error("empty star or superellipse path")
+	}
+}
+
+func TestChannelMaskAndSetChannel(t *testing.T) {
+	ctx := agg.NewContext(16, 16)
+	ctx.Clear(agg.Transparent)
+
+	// Paint a shape into the alpha channel only, then color everything
+	// without touching that alpha.
+	ctx.SetChannelMask(agg.ChannelAlpha)
+	ctx.SetColor(agg.White)
+	ctx.FillRectangle(0, 0, 8, 16)
+	ctx.SetChannelMask(agg.ChannelRGB)
+	ctx.Clear(agg.Red)
+	ctx.SetChannelMask(agg.ChannelAll)
+
+	img := ctx.GetImage()
+	if px := getPixel(img.Data, 16*4, 4, 4); px != [4]uint8{255, 0, 0, 255} {
+		t.Errorf("masked inside = %v, want opaque red", px)
+	}
+	if px := getPixel(img.Data, 16*4, 12, 4); px != [4]uint8{255, 0, 0, 0} {
+		t.Errorf("masked outside = %v, want red with alpha 0", px)
+	}
+
+	mask := agg.CreateGrayImage(16, 16)
+	for y := 0; y < 16; y++ {
+		for x := 8; x < 16; x++ {
+			mask.Data[y*16+x] = 128
+		}
+	}
+	if err := img.SetChannel(agg.ChannelGreen|agg.ChannelBlue, 64); err != nil {
+		t.Fatal(err)
+	}
+	if err := img.SetChannelFromImage(agg.ChannelAlpha, mask); err != nil {
+		t.Fatal(err)
+	}
+	if px := getPixel(img.Data, 16*4, 4, 4); px != [4]uint8{255, 64, 64, 0} {
+		t.Errorf("assembled left = %v, want [255 64 64 0]", px)
+	}
+	if px := getPixel(img.Data, 16*4, 12, 4); px != [4]uint8{255, 64, 64, 128} {
+		t.Errorf("assembled right = %v, want [255 64 64 128]", px)
+	}
+	if err := img.SetChannelFromImage(agg.ChannelAlpha, agg.CreateGrayImage(8, 8)); err == nil {
+		t.Error("SetChannelFromImage accepted a source of another size")
+	}
+}
+
+func TestImageDownscaleAndMipmaps(t *testing.T) {
+	checker := agg.CreateImage(64, 64)
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			v := uint8(0)
+			if (x+y)%2 == 0 {
+				v = 255
+			}
+			i := y*64*4 + x*4
+			checker.Data[i], checker.Data[i+1], checker.Data[i+2], checker.Data[i+3] = v, v, v, 255
+		}
+	}
+
+	small := checker.Downscale(4)
+	if small.Width() != 16 || small.Height() != 16 {
+		t.Fatalf("Downscale(4) size = %dx%d, want 16x16", small.Width(), small.Height())
+	}
+	if px := getPixel(small.Data, 16*4, 3, 3); px != [4]uint8{128, 128, 128, 255} {
+		t.Errorf("downscaled pixel = %v, want mid gray", px)
+	}
+
+	draw := func() [4]uint8 {
+		// Offset by half a source pixel so samples hit source pixel centers.
+		ctx := agg.NewContext(8, 8)
+		if err := ctx.DrawImageScaled(checker, -1.0/16, -1.0/16, 8, 8); err != nil {
+			t.Fatal(err)
+		}
+		return getPixel(ctx.GetImage().Data, 8*4, 3, 3)
+	}
+
+	// Without mipmaps every sample lands on one color of the checkerboard.
+	if px := draw(); px[0] != 0 && px[0] != 255 {
+		t.Errorf("without mipmaps = %v, want black or white", px)
+	}
+	if n := len(checker.GenerateMipmaps()); n != 6 {
+		t.Errorf("GenerateMipmaps built %d levels, want 6", n)
+	}
+	if px := draw(); px[0] < 120 || px[0] > 136 {
+		t.Errorf("with mipmaps = %v, want mid gray", px)
+	}
+}
+
+func TestNewContextWithFormat(t *testing.T) {
+	if _, err := agg.NewContextWithFormat(8, 8, agg.ImageGray16); err == nil {
+		t.Error("Gray16 context did not fail")
+	}
+
+	tests := []struct {
+		format agg.ImageFormat
+		red    agg.Color // pure red as stored by the format
+		pixel  []uint8   // bytes of a pure red pixel
+	}{
+		{agg.ImageBGRA8, agg.Color{R: 255, A: 255}, []uint8{0, 0, 255, 255}},
+		{agg.ImageRGB8, agg.Color{R: 255, A: 255}, []uint8{255, 0, 0}},
+		{agg.ImageGray8, agg.Color{R: 76, G: 76, B: 76, A: 255}, []uint8{76}},
+		{agg.ImageRGB565, agg.Color{R: 255, A: 255}, []uint8{0x00, 0xF8}},
+	}
+	for _, tt := range tests {
+		ctx, err := agg.NewContextWithFormat(16, 16, tt.format)
+		if err != nil {
+			t.Fatalf("format %d: %v", tt.format, err)
+		}
+		img := ctx.GetImage()
+		bpp := tt.format.BytesPerPixel()
+		if img.Format() != tt.format || img.Stride() != 16*bpp {
+			t.Fatalf("format %d: image format %d, stride %d", tt.format, img.Format(), img.Stride())
+		}
+
+		ctx.Clear(agg.Black)
+		ctx.SetColor(agg.Red)
+		ctx.FillRectangle(0, 0, 8, 16)
+		ctx.FillCircle(12, 8, 3)
+
+		if got := ctx.GetPixel(4, 4); got != tt.red {
+			t.Errorf("format %d: GetPixel = %v, want %v", tt.format, got, tt.red)
+		}
+		if got := img.Data[4*img.Stride()+4*bpp:][:bpp]; !bytes.Equal(got, tt.pixel) {
+			t.Errorf("format %d: pixel bytes = %v, want %v", tt.format, got, tt.pixel)
+		}
+		if got := ctx.GetPixel(12, 4); got.R != 0 && tt.format != agg.ImageGray8 {
+			t.Errorf("format %d: pixel outside the shapes = %v, want black", tt.format, got)
+		}
+		// The circle edge is blended with the black background.
+		if edge := ctx.GetPixel(9, 8); edge.R == 0 || edge == tt.red {
+			t.Errorf("format %d: circle edge = %v, want partly red", tt.format, edge)
+		}
+
+		s := ctx.Snapshot()
+		region := ctx.ReadRegion(agg.Rect{X1: 0, Y1: 0, X2: 4, Y2: 4})
+		ctx.Clear(agg.White)
+		ctx.Restore(s)
+		if got := ctx.GetPixel(4, 4); got != tt.red {
+			t.Errorf("format %d: restored pixel = %v, want %v", tt.format, got, tt.red)
+		}
+		if region.Format() != tt.format {
+			t.Errorf("format %d: region format = %d", tt.format, region.Format())
+		}
+		if err := ctx.WriteRegion(agg.Rect{X1: 12, Y1: 12, X2: 16, Y2: 16}, region); err != nil {
+			t.Errorf("format %d: WriteRegion: %v", tt.format, err)
+		} else if got := ctx.GetPixel(13, 13); got != tt.red {
+			t.Errorf("format %d: written pixel = %v, want %v", tt.format, got, tt.red)
+		}
+
+		if c := img.ToGoImage().RGBAAt(4, 4); c.R != tt.red.R || c.A != 255 {
+			t.Errorf("format %d: ToGoImage = %v", tt.format, c)
+		}
+	}
+}
+
+func TestCellBlockLimit(t *testing.T) {
+	ctx := agg.NewContext(1024, 1024)
+	if got := ctx.GetCellBlockLimit(); got != agg.DefaultCellBlockLimit {
+		t.Fatalf("default limit %d, want %d", got, agg.DefaultCellBlockLimit)
+	}
+	ctx.SetCellBlockLimit(1)
+	var hit uint32
+	ctx.SetOverflowHandler(func(limit uint32) { hit = limit })
+	ctx.SetColor(agg.Black)
+	// A zigzag crossing every row 40 times needs about 40000 cells, more
+	// than one block of 4096.
+	zigzag := func() {
+		ctx.BeginPath()
+		ctx.MoveTo(0, 0)
+		for i := 1; i <= 40; i++ {
+			ctx.LineTo(float64(i)*25, float64(i%2)*1023)
+		}
+		ctx.Fill()
+	}
+	zigzag()
+	if hit != 1 {
+		t.Fatalf("overflow handler got limit %d, want 1", hit)
+	}
+
+	hit = 0
+	ctx.SetCellBlockLimit(0)
+	zigzag()
+	if hit != 0 || ctx.GetCellBlockLimit() != agg.DefaultCellBlockLimit {
+		t.Errorf("limit 0 did not restore the default: overflow %d, limit %d", hit, ctx.GetCellBlockLimit())
+	}
+}
+
+// TestDrawImageTransformedCorners checks that DrawImageTransformed maps each
+// corner of the image to its transformed position: the destination
+// parallelogram is given by the images of the top left, top right and bottom
+// right corners.
+func TestDrawImageTransformedCorners(t *testing.T) {
+	const w, h = 8, 4
+	data := make([]uint8, w*h*4)
+	for y := range h {
+		for x := range w {
+			c := []uint8{255, 0, 0, 255}
+			if x >= w/2 {
+				c = []uint8{0, 0, 255, 255}
+			}
+			copy(data[(y*w+x)*4:], c)
+		}
+	}
+	img := agg.NewImage(data, w, h, w*4)
+
+	ctx := agg.NewContext(32, 32)
+	ctx.Clear(agg.White)
+	// Scale by 2 and translate to (10, 20): the image covers [10, 26) x [20, 28).
+	if err := ctx.DrawImageTransformed(img, agg.NewTransformationsFromValues(2, 0, 0, 2, 10, 20)); err != nil {
+		t.Fatal(err)
+	}
+	pixels := ctx.GetImage().Data
+	for _, tt := range []struct {
+		x, y int
+		want [4]uint8
+	}{
+		{12, 21, [4]uint8{255, 0, 0, 255}},
+		{12, 26, [4]uint8{255, 0, 0, 255}},
+		{23, 21, [4]uint8{0, 0, 255, 255}},
+		{23, 26, [4]uint8{0, 0, 255, 255}},
+		{8, 24, [4]uint8{255, 255, 255, 255}},
+		{28, 24, [4]uint8{255, 255, 255, 255}},
+		{18, 30, [4]uint8{255, 255, 255, 255}},
+	} {
+		if px := getPixel(pixels, 32*4, tt.x, tt.y); px != tt.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", tt.x, tt.y, px, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package integration
+
+import (
+	"sync"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestContextPoolConcurrentRendering renders from several goroutines and
+// checks that every render sees a freshly reset context.
+func TestContextPoolConcurrentRendering(t *testing.T) {
+	pool := agg.NewContextPool(16, 16, 3)
+	if pool.Size() != 3 {
+		t.Fatalf("Size = %d, want 3", pool.Size())
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- pool.Do(func(ctx *agg.Context) error {
+				img := ctx.GetImage()
+				if p := getPixel(img.Data, 16*4, 8, 8); p[3] != 0 {
+					t.Errorf("pooled context not cleared, got RGBA%v", p)
+				}
+				if !ctx.GetTransform().IsIdentity() {
+					t.Error("pooled context transform not reset")
+				}
+				ctx.Translate(float64(i%4), 0)
+				ctx.SetColor(agg.Red)
+				ctx.FillRectangle(0, 0, 16, 16)
+				if p := getPixel(img.Data, 16*4, 8, 8); p[0] != 255 || p[3] != 255 {
+					t.Errorf("render %d: expected red, got RGBA%v", i, p)
+				}
+				return nil
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+	}
+}
+
+// TestContextPoolResetsState checks that settings changed by one user of a
+// pooled Context do not reach the next one.
+func TestContextPoolResetsState(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		change func(ctx *agg.Context)
+		reset  func(ctx *agg.Context) bool
+	}{
+		{
+			"gradient dithering",
+			func(ctx *agg.Context) { ctx.SetGradientDithering(true) },
+			func(ctx *agg.Context) bool { return !ctx.GetGradientDithering() },
+		},
+		{
+			"hit regions",
+			func(ctx *agg.Context) {
+				ctx.SetHitRegions(agg.NewHitRegionIndex(8, 8))
+				ctx.SetHitID(3)
+			},
+			func(ctx *agg.Context) bool { return ctx.GetHitRegions() == nil && ctx.GetHitID() == 0 },
+		},
+		{
+			"text decoration",
+			func(ctx *agg.Context) { ctx.SetTextDecoration(agg.DecorationUnderline) },
+			func(ctx *agg.Context) bool { return ctx.GetTextDecoration() == agg.DecorationNone },
+		},
+		{
+			"image edge mode",
+			func(ctx *agg.Context) { ctx.SetImageEdge(agg.EdgeRepeat) },
+			func(ctx *agg.Context) bool { return ctx.GetImageEdge() == agg.EdgeClamp },
+		},
+		{
+			"image background color",
+			func(ctx *agg.Context) { ctx.SetImageBackgroundColor(agg.Red) },
+			func(ctx *agg.Context) bool { return ctx.GetImageBackgroundColor() == agg.Transparent },
+		},
+		{
+			"auto close",
+			func(ctx *agg.Context) { ctx.SetAutoClose(false) },
+			func(ctx *agg.Context) bool { return ctx.GetAutoClose() },
+		},
+		{
+			"approximation scale",
+			func(ctx *agg.Context) { ctx.SetApproximationScale(4) },
+			func(ctx *agg.Context) bool { return ctx.GetApproximationScale() == 1 },
+		},
+		{
+			"dashes",
+			func(ctx *agg.Context) {
+				ctx.SetDashPattern([]float64{4, 2})
+				ctx.SetDashOffset(1)
+			},
+			func(ctx *agg.Context) bool { return ctx.GetDashPatternLength() == 0 && ctx.GetDashOffset() == 0 },
+		},
+		{
+			"master alpha and channel mask",
+			func(ctx *agg.Context) {
+				ctx.SetMasterAlpha(0.5)
+				ctx.SetChannelMask(agg.ChannelAlpha)
+			},
+			func(ctx *agg.Context) bool {
+				return ctx.GetMasterAlpha() == 1 && ctx.GetChannelMask() == agg.ChannelAll
+			},
+		},
+		{
+			"transform stack",
+			func(ctx *agg.Context) { ctx.PushTransform() },
+			func(ctx *agg.Context) bool { return !ctx.PopTransform() },
+		},
+		{
+			"units",
+			func(ctx *agg.Context) { ctx.SetUnits(agg.Millimeters, 96) },
+			func(ctx *agg.Context) bool {
+				unit, _ := ctx.GetUnits()
+				return unit == agg.Pixels
+			},
+		},
+		{
+			"fill color",
+			func(ctx *agg.Context) { ctx.SetColor(agg.Red) },
+			func(ctx *agg.Context) bool { return ctx.GetAgg2D().GetFillColor() == agg.Black },
+		},
+	} {
+		pool := agg.NewContextPool(8, 8, 1)
+		ctx, err := pool.Get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tc.change(ctx)
+		pool.Put(ctx)
+		if ctx, err = pool.Get(); err != nil {
+			t.Fatal(err)
+		}
+		if !tc.reset(ctx) {
+			t.Errorf("%s survived a Put/Get round trip", tc.name)
+		}
+		pool.Put(ctx)
+	}
+}
+
+// TestContextPoolSetFontReadsFile checks that SetFont reads the shared font
+// file up front.
+func TestContextPoolSetFontReadsFile(t *testing.T) {
+	pool := agg.NewContextPool(8, 8, 1)
+	if err := pool.SetFont("testdata/missing.ttf", 12, false, false, agg.RasterFontCache, 0); err == nil {
+		t.Error("SetFont accepted a missing font file")
+	}
+	ctx, err := pool.Get()
+	if err != nil {
+		t.Fatalf("Get after a failed SetFont: %v", err)
+	}
+	pool.Put(ctx)
+}
@@ -0,0 +1,51 @@
+package integration
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestConvolvePredefinedKernels runs the predefined kernels over a gray
+// square on black and checks their characteristic responses.
+func TestConvolvePredefinedKernels(t *testing.T) {
+	newImage := func() *agg.Image {
+		img := agg.CreateImageFromColor(20, 20, agg.Black)
+		ctx := agg.NewContextForImage(img)
+		ctx.SetColor(agg.NewColor(128, 128, 128, 255))
+		ctx.FillRectangle(5, 5, 10, 10)
+		return img
+	}
+	red := func(img *agg.Image, x, y int) uint8 { return img.Data[y*img.Stride()+x*4] }
+
+	img := newImage()
+	if err := img.Convolve(agg.KernelEdgeDetect, 1, 0); err != nil {
+		t.Fatal(err)
+	}
+	if red(img, 10, 10) != 0 || red(img, 1, 1) != 0 || red(img, 5, 10) != 255 {
+		t.Errorf("edge detect: inside %d, outside %d, edge %d", red(img, 10, 10), red(img, 1, 1), red(img, 5, 10))
+	}
+	if a := img.Data[10*img.Stride()+10*4+3]; a != 255 {
+		t.Errorf("alpha = %d, want kept at 255", a)
+	}
+
+	img = newImage()
+	if err := img.Convolve(agg.KernelSharpen, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if red(img, 10, 10) != 128 || red(img, 5, 10) != 255 || red(img, 4, 10) != 0 {
+		t.Errorf("sharpen: inside %d, edge %d, outside %d", red(img, 10, 10), red(img, 5, 10), red(img, 4, 10))
+	}
+
+	img = newImage()
+	if err := img.Convolve(agg.KernelEmboss, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if red(img, 5, 10) == red(img, 14, 10) {
+		t.Errorf("emboss: opposite edges both %d, want lit and shaded", red(img, 5, 10))
+	}
+
+	if err := img.Convolve(make([]float64, 4), 1, 0); err == nil {
+		t.Error("expected an error for an even kernel")
+	}
+}
@@ -0,0 +1,46 @@
+package integration
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestFloodFillRegion fills the inside of a stroked frame and checks that
+// the fill stops at the frame and honors the clip box.
+func TestFloodFillRegion(t *testing.T) {
+	ctx := agg.NewContext(30, 30)
+	ctx.Clear(agg.White)
+	ctx.SetColor(agg.Black)
+	ctx.GetAgg2D().NoFill()
+	ctx.SetLineWidth(2)
+	ctx.DrawRectangle(5, 5, 20, 20)
+
+	red := agg.Color{R: 255, A: 255}
+	img := ctx.GetImage()
+	at := func(x, y int) agg.Color {
+		i := y*img.Stride() + x*4
+		return agg.Color{R: img.Data[i], G: img.Data[i+1], B: img.Data[i+2], A: img.Data[i+3]}
+	}
+
+	if n := img.FloodFill(15, 15, red, 0); n != 18*18 {
+		t.Errorf("filled %d pixels, want %d", n, 18*18)
+	}
+	if at(15, 15) != red || at(6, 6) != red {
+		t.Errorf("inside = %v, %v, want red", at(15, 15), at(6, 6))
+	}
+	if at(2, 2) != agg.White || at(5, 15) == red {
+		t.Errorf("fill leaked past the frame: %v, %v", at(2, 2), at(5, 15))
+	}
+
+	// Refilling with a color inside the tolerance must terminate.
+	if n := img.FloodFill(15, 15, agg.Color{R: 250, A: 255}, 10); n != 18*18 {
+		t.Errorf("refill covered %d pixels, want %d", n, 18*18)
+	}
+
+	ctx.GetAgg2D().ClipBox(0, 0, 9, 29)
+	blue := agg.Color{B: 255, A: 255}
+	if n := ctx.FloodFill(0, 0, blue, 0); n == 0 || at(0, 0) != blue || at(9, 0) != blue || at(10, 0) == blue {
+		t.Errorf("clipped fill: %d pixels, row 0 = %v %v %v", n, at(0, 0), at(9, 0), at(10, 0))
+	}
+}
@@ -0,0 +1,25 @@
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+func TestOpenFontErrors(t *testing.T) {
+	if _, err := agg.OpenFont(filepath.Join(t.TempDir(), "missing.ttf"), 16); err == nil {
+		t.Error("OpenFont accepted a missing file")
+	}
+	if _, err := agg.OpenFont("any.ttf", 0); err == nil {
+		t.Error("OpenFont accepted a zero height")
+	}
+
+	var f agg.Font
+	if p, m := f.GlyphPath('A'); p != nil || m != (agg.GlyphMetrics{}) {
+		t.Errorf("closed font returned a glyph: %v %+v", p, m)
+	}
+	if err := f.Close(); err != nil {
+		t.Error(err)
+	}
+}
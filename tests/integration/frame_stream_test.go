@@ -0,0 +1,70 @@
+package integration
+
+import (
+	"bytes"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestFrameStreamerPackedFormats checks the byte order of the packed raw
+// video formats for a single red pixel.
+func TestFrameStreamerPackedFormats(t *testing.T) {
+	ctx := agg.NewContext(2, 2)
+	ctx.Clear(agg.Red)
+
+	tests := []struct {
+		format agg.FrameFormat
+		pixel  []byte
+	}{
+		{agg.FrameRGBA, []byte{255, 0, 0, 255}},
+		{agg.FrameBGRA, []byte{0, 0, 255, 255}},
+		{agg.FrameRGB24, []byte{255, 0, 0}},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		s := agg.NewFrameStreamer(&out, tt.format)
+		if err := s.WriteContext(ctx); err != nil {
+			t.Fatalf("%s: WriteContext failed: %v", tt.format.PixFmt(), err)
+		}
+		if out.Len() != tt.format.FrameSize(2, 2) {
+			t.Fatalf("%s: wrote %d bytes, want %d", tt.format.PixFmt(), out.Len(), tt.format.FrameSize(2, 2))
+		}
+		if got := out.Bytes()[:len(tt.pixel)]; !bytes.Equal(got, tt.pixel) {
+			t.Errorf("%s: first pixel = %v, want %v", tt.format.PixFmt(), got, tt.pixel)
+		}
+	}
+}
+
+// TestFrameStreamerFlipAndSize checks Y-flipping and the fixed stream size.
+func TestFrameStreamerFlipAndSize(t *testing.T) {
+	ctx := agg.NewContext(1, 2)
+	ctx.Clear(agg.Black)
+	img := ctx.GetImage()
+	img.Data[0] = 200 // top row red channel
+
+	var out bytes.Buffer
+	s := agg.NewFrameStreamer(&out, agg.FrameRGB24)
+	s.SetFlipY(true)
+	if err := s.WriteFrame(img); err != nil {
+		t.Fatalf("WriteFrame failed: %v", err)
+	}
+	if got := out.Bytes(); got[0] != 0 || got[3] != 200 {
+		t.Errorf("flipped frame = %v, expected top row last", got)
+	}
+
+	if err := s.WriteContext(agg.NewContext(2, 2)); err == nil {
+		t.Error("expected error for frame size mismatch")
+	}
+	if s.FrameCount() != 1 {
+		t.Errorf("FrameCount = %d, want 1", s.FrameCount())
+	}
+
+	var yuv bytes.Buffer
+	if err := agg.NewFrameStreamer(&yuv, agg.FrameYUV420P).WriteFrame(img); err != nil {
+		t.Fatalf("YUV420P WriteFrame failed: %v", err)
+	}
+	if yuv.Len() != 1*2+2 {
+		t.Errorf("YUV420P frame size = %d, want 4", yuv.Len())
+	}
+}
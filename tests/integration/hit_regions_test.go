@@ -0,0 +1,94 @@
+package integration
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestHitRegions checks picking order, untagged drawing, strokes and
+// transformed shapes.
+func TestHitRegions(t *testing.T) {
+	ctx := agg.NewContext(100, 100)
+	hits := agg.NewHitRegionIndex(ctx.Width(), ctx.Height())
+	ctx.SetHitRegions(hits)
+
+	ctx.SetHitID(1)
+	ctx.FillRectangle(10, 10, 50, 50)
+	ctx.SetHitID(2)
+	ctx.FillCircle(60, 60, 20)
+	ctx.SetHitID(0)
+	ctx.FillRectangle(0, 90, 100, 10) // not recorded
+
+	ctx.SetHitID(3)
+	ctx.SetStrokeWidth(4)
+	ctx.DrawLine(80, 5, 95, 5)
+
+	ctx.PushTransform()
+	ctx.Translate(80, 30)
+	ctx.SetHitID(4)
+	ctx.FillRectangle(0, 0, 10, 10)
+	ctx.PopTransform()
+	ctx.SetHitID(0)
+
+	for _, tc := range []struct {
+		x, y float64
+		id   int
+	}{
+		{20, 20, 1},
+		{55, 55, 2}, // the circle is drawn over the rectangle
+		{72, 72, 2},
+		{5, 5, 0},
+		{50, 95, 0},
+		{88, 5.5, 3},
+		{88, 12, 0},
+		{85, 35, 4},
+		{-1, 20, 0},
+		{20, 100, 0},
+	} {
+		id, ok := hits.TopShapeAt(tc.x, tc.y)
+		if id != tc.id || ok != (tc.id != 0) {
+			t.Errorf("TopShapeAt(%v, %v) = %d, %v, want %d", tc.x, tc.y, id, ok, tc.id)
+		}
+	}
+
+	if r, ok := hits.Bounds(1); !ok || r != agg.NewRect(10, 10, 60, 60) {
+		t.Errorf("Bounds(1) = %v, %v", r, ok)
+	}
+	if _, ok := hits.Bounds(5); ok {
+		t.Error("Bounds of an unknown ID reported ok")
+	}
+
+	hits.Clear()
+	if _, ok := hits.TopShapeAt(20, 20); ok {
+		t.Error("shape still found after Clear")
+	}
+}
+
+// TestHitRegionsFillRects checks that batched rectangles, blended directly
+// or rasterized, are recorded like single fills.
+func TestHitRegionsFillRects(t *testing.T) {
+	ctx := agg.NewContext(100, 100)
+	hits := agg.NewHitRegionIndex(ctx.Width(), ctx.Height())
+	ctx.SetHitRegions(hits)
+
+	ctx.SetHitID(7)
+	ctx.FillRects([]agg.RectColor{
+		{X: 10, Y: 10, Width: 20, Height: 20, Color: agg.Red},
+		{X: 50, Y: 50, Width: 20, Height: 20, Radius: 4, Color: agg.Blue},
+	})
+	ctx.SetHitID(0)
+
+	for _, tc := range []struct {
+		x, y float64
+		id   int
+	}{
+		{15, 15, 7},
+		{60, 60, 7},
+		{40, 40, 0},
+	} {
+		if id, _ := hits.TopShapeAt(tc.x, tc.y); id != tc.id {
+			t.Errorf("TopShapeAt(%v, %v) = %d, want %d", tc.x, tc.y, id, tc.id)
+		}
+	}
+}
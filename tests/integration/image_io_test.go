@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"bytes"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+	"golang.org/x/image/webp"
+)
+
+func TestImageSaveTo(t *testing.T) {
+	ctx := agg.NewContext(40, 30)
+	ctx.Clear(agg.NewColor(255, 255, 255, 255))
+	ctx.SetColor(agg.NewColor(200, 40, 20, 255))
+	ctx.FillCircle(20, 15, 10)
+	img := ctx.GetImage()
+
+	dir := t.TempDir()
+	for _, name := range []string{"out.png", "out.qoi", "out.JPG", "out.webp"} {
+		path := filepath.Join(dir, name)
+		if err := img.SaveTo(path); err != nil {
+			t.Fatalf("SaveTo(%s): %v", name, err)
+		}
+		if fi, err := os.Stat(path); err != nil || fi.Size() == 0 {
+			t.Fatalf("%s not written", name)
+		}
+	}
+	if err := img.SaveTo(filepath.Join(dir, "out.tiff")); err == nil {
+		t.Error("unknown extension accepted")
+	}
+
+	// PNG and QOI are lossless and loadable.
+	for _, name := range []string{"out.png", "out.qoi"} {
+		got, err := agg.LoadImageFromFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("load %s: %v", name, err)
+		}
+		if got.Width() != 40 || got.Height() != 30 || !bytes.Equal(got.ToGoImage().Pix, img.ToGoImage().Pix) {
+			t.Errorf("%s round trip differs", name)
+		}
+	}
+
+	webp, err := os.ReadFile(filepath.Join(dir, "out.webp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(webp[:4]) != "RIFF" || string(webp[8:16]) != "WEBPVP8L" {
+		t.Errorf("WebP header = %q", webp[:16])
+	}
+}
+
+func TestDecodeQOI(t *testing.T) {
+	img := agg.CreateGrayImage(3, 2)
+	img.Data[4] = 128
+	var buf bytes.Buffer
+	if err := img.EncodeQOI(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got, err := agg.DecodeQOI(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Format() != agg.ImageRGBA8 || !bytes.Equal(got.Data[16:20], []byte{128, 128, 128, 255}) {
+		t.Errorf("gray pixel decoded as %v", got.Data[16:20])
+	}
+	if _, err := agg.DecodeQOI(bytes.NewReader([]byte("not an image"))); err == nil {
+		t.Error("invalid data accepted")
+	}
+}
+
+func TestEncodeTranslucentStraightAlpha(t *testing.T) {
+	ctx := agg.NewContext(4, 4)
+	ctx.Clear(agg.Transparent)
+	ctx.SetColor(agg.NewColor(255, 0, 0, 128))
+	ctx.FillRectangle(0, 0, 4, 4)
+	img := ctx.GetImage()
+	if got := [4]uint8(img.Data[:4]); got != [4]uint8{128, 0, 0, 128} {
+		t.Fatalf("drawn pixel = %v, want premultiplied", got)
+	}
+	want := color.NRGBA{255, 0, 0, 128}
+
+	var buf bytes.Buffer
+	if err := img.EncodeWebP(&buf); err != nil {
+		t.Fatal(err)
+	}
+	m, err := webp.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := color.NRGBAModel.Convert(m.At(1, 1)).(color.NRGBA); c != want {
+		t.Errorf("WebP pixel = %v, want %v", c, want)
+	}
+
+	buf.Reset()
+	if err := img.EncodeQOI(&buf); err != nil {
+		t.Fatal(err)
+	}
+	// QOI stores the straight color right after the 14-byte header, as a
+	// QOI_OP_RGBA chunk.
+	if got := buf.Bytes()[14:19]; !bytes.Equal(got, []byte{0xff, 255, 0, 0, 128}) {
+		t.Errorf("QOI first chunk = %v, want straight red", got)
+	}
+	got, err := agg.DecodeQOI(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Data, img.Data) {
+		t.Errorf("QOI round trip = %v, want %v", got.Data[:4], img.Data[:4])
+	}
+}
@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// withOrientation inserts an EXIF APP1 segment with the given orientation
+// after the SOI marker of a JPEG stream.
+func withOrientation(jpg []byte, orientation uint16, order binary.AppendByteOrder) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	if order == binary.LittleEndian {
+		tiff = []byte("II\x2a\x00\x08\x00\x00\x00")
+	}
+	tiff = order.AppendUint16(tiff, 1)      // one entry
+	tiff = order.AppendUint16(tiff, 0x0112) // Orientation
+	tiff = order.AppendUint16(tiff, 3)      // SHORT
+	tiff = order.AppendUint32(tiff, 1)
+	tiff = order.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0) // value padding, next IFD
+	seg := append([]byte("Exif\x00\x00"), tiff...)
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, 0xFF, 0xE1)
+	out = binary.BigEndian.AppendUint16(out, uint16(2+len(seg)))
+	out = append(out, seg...)
+	return append(out, jpg[2:]...)
+}
+
+func TestDecodeJPEGOrientation(t *testing.T) {
+	// 16x8: red on the left, blue on the right.
+	src := agg.CreateImage(16, 8)
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			c := agg.NewColor(255, 0, 0, 255)
+			if x >= 8 {
+				c = agg.NewColor(0, 0, 255, 255)
+			}
+			i := y*src.Stride() + x*4
+			src.Data[i], src.Data[i+1], src.Data[i+2], src.Data[i+3] = c.R, c.G, c.B, c.A
+		}
+	}
+	var buf bytes.Buffer
+	if err := src.EncodeJPEG(&buf, agg.EncodeOptions{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+
+	isRed := func(img *agg.Image, x, y int) bool {
+		i := y*img.Stride() + x*4
+		return img.Data[i] > 200 && img.Data[i+2] < 60
+	}
+	isBlue := func(img *agg.Image, x, y int) bool {
+		i := y*img.Stride() + x*4
+		return img.Data[i] < 60 && img.Data[i+2] > 200
+	}
+
+	for _, tc := range []struct {
+		orientation  uint16
+		order        binary.AppendByteOrder
+		w, h         int
+		redX, redY   int
+		blueX, blueY int
+	}{
+		{1, binary.BigEndian, 16, 8, 2, 4, 13, 4},
+		{3, binary.LittleEndian, 16, 8, 13, 4, 2, 4},
+		{6, binary.BigEndian, 8, 16, 4, 2, 4, 13},
+		{8, binary.LittleEndian, 8, 16, 4, 13, 4, 2},
+	} {
+		img, err := agg.DecodeJPEG(bytes.NewReader(withOrientation(buf.Bytes(), tc.orientation, tc.order)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Width() != tc.w || img.Height() != tc.h {
+			t.Errorf("orientation %d: size %dx%d, want %dx%d", tc.orientation, img.Width(), img.Height(), tc.w, tc.h)
+			continue
+		}
+		if !isRed(img, tc.redX, tc.redY) || !isBlue(img, tc.blueX, tc.blueY) {
+			t.Errorf("orientation %d: wrong pixel placement", tc.orientation)
+		}
+	}
+
+	// LoadImageFromFile applies the orientation as well.
+	path := filepath.Join(t.TempDir(), "photo.jpg")
+	if err := os.WriteFile(path, withOrientation(buf.Bytes(), 6, binary.BigEndian), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	img, err := agg.LoadImageFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width() != 8 || img.Height() != 16 || !isRed(img, 4, 2) {
+		t.Errorf("LoadImageFromFile ignored the orientation: %dx%d", img.Width(), img.Height())
+	}
+}
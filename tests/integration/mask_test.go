@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestRenderMaskMatchesContains checks that the Gray8 mask is opaque where
+// the path contains a point and empty where it does not.
+func TestRenderMaskMatchesContains(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(4, 4)
+	p.CubicCurveTo(40, 0, 40, 40, 4, 36)
+	p.ClosePath()
+
+	mask := agg.RenderMask(p, 40, 40, agg.FillNonZero)
+	if mask.Format() != agg.ImageGray8 || mask.Stride() != 40 {
+		t.Fatalf("mask format %v, stride %d; want Gray8, 40", mask.Format(), mask.Stride())
+	}
+	for _, pt := range []agg.Point{{X: 10.5, Y: 20.5}, {X: 25.5, Y: 20.5}, {X: 2.5, Y: 20.5}, {X: 38.5, Y: 2.5}} {
+		v := mask.Data[int(pt.Y)*40+int(pt.X)]
+		if p.Contains(pt.X, pt.Y, false) && v != 255 {
+			t.Errorf("mask at %v = %d, want 255", pt, v)
+		}
+		if !p.Contains(pt.X, pt.Y, false) && v != 0 {
+			t.Errorf("mask at %v = %d, want 0", pt, v)
+		}
+	}
+
+	std, err := mask.ToStandardImage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, ok := std.(*image.Gray); !ok || g.GrayAt(10, 20).Y != 255 {
+		t.Errorf("ToStandardImage = %T, want *image.Gray with the mask values", std)
+	}
+}
+
+// TestApplyMask checks alpha multiplication, inversion and offsets.
+func TestApplyMask(t *testing.T) {
+	mask := agg.CreateGrayImage(2, 1)
+	mask.Data[0], mask.Data[1] = 255, 128
+
+	img := agg.CreateImageFromColor(3, 1, agg.Color{R: 10, G: 20, B: 30, A: 200})
+	if err := img.ApplyMask(mask); err != nil {
+		t.Fatal(err)
+	}
+	if got := []uint8{img.Data[3], img.Data[7], img.Data[11]}; got[0] != 200 || got[1] != 100 || got[2] != 0 {
+		t.Errorf("masked alpha = %v, want [200 100 0]", got)
+	}
+	if img.Data[4] != 10 || img.Data[5] != 20 || img.Data[6] != 30 {
+		t.Errorf("color channels changed: %v", img.Data[4:7])
+	}
+
+	img = agg.CreateImageFromColor(3, 1, agg.Color{A: 255})
+	if err := img.ApplyMaskWithOptions(mask, agg.MaskOptions{Invert: true, OffsetX: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if got := []uint8{img.Data[3], img.Data[7], img.Data[11]}; got[0] != 255 || got[1] != 0 || got[2] != 127 {
+		t.Errorf("inverted, offset alpha = %v, want [255 0 127]", got)
+	}
+
+	if err := mask.ApplyMask(img); err == nil {
+		t.Error("ApplyMask on a Gray8 image should fail")
+	}
+}
+
+// TestGenerateSDF checks the distance encoding around a rendered disc.
+func TestGenerateSDF(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(10, 20)
+	for i := 1; i <= 64; i++ {
+		a := float64(i) * 2 * math.Pi / 64
+		p.LineTo(20+10*math.Cos(a+math.Pi), 20+10*math.Sin(a+math.Pi))
+	}
+	p.ClosePath()
+	mask := agg.RenderMask(p, 40, 40, agg.FillNonZero)
+
+	sdf := agg.GenerateSDF(mask, 4)
+	at := func(x, y int) uint8 { return sdf.Data[y*40+x] }
+	if at(20, 20) != 255 || at(0, 0) != 0 {
+		t.Errorf("center %d, corner %d; want 255, 0", at(20, 20), at(0, 0))
+	}
+	// Two pixels inside and outside the edge at x=30.
+	if v := at(27, 20); v < 180 || v > 215 {
+		t.Errorf("inside near edge = %d, want ~192", v)
+	}
+	if v := at(31, 20); v < 80 || v > 112 {
+		t.Errorf("outside near edge = %d, want ~96", v)
+	}
+
+	sdf16 := agg.GenerateSDFWithFormat(mask, 4, agg.ImageGray16)
+	if d := int(sdf16.Data[2*(20*40+27)]) - int(at(27, 20)); sdf16.Format() != agg.ImageGray16 || d < -1 || d > 1 {
+		t.Errorf("Gray16 output should match Gray8 in the high byte")
+	}
+}
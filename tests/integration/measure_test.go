@@ -0,0 +1,86 @@
+package integration
+
+import (
+	"bytes"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestMeasureDrawBounds checks that dry runs report the device bounds of
+// their coverage and leave the pixels alone.
+func TestMeasureDrawBounds(t *testing.T) {
+	ctx := agg.NewContext(200, 200)
+	ctx.Clear(agg.White)
+	before := bytes.Clone(ctx.GetImage().Data)
+
+	img := agg.NewImage(make([]uint8, 5*5*4), 5, 5, 5*4)
+
+	tests := []struct {
+		name string
+		draw func(*agg.Context)
+		want agg.Rect
+	}{
+		{"fill", func(c *agg.Context) { c.FillRectangle(10, 20, 30, 40) }, agg.Rect{X1: 10, Y1: 20, X2: 40, Y2: 60}},
+		{"stroke", func(c *agg.Context) {
+			c.SetLineWidth(4)
+			c.SetLineCap(agg.CapButt)
+			c.DrawLine(50, 50, 90, 50)
+		}, agg.Rect{X1: 50, Y1: 48, X2: 90, Y2: 52}},
+		{"transformed", func(c *agg.Context) {
+			c.PushTransform()
+			c.Translate(100, 100)
+			c.FillRectangle(0, 0, 10, 10)
+			c.PopTransform()
+		}, agg.Rect{X1: 100, Y1: 100, X2: 110, Y2: 110}},
+		{"image", func(c *agg.Context) { _ = c.DrawImage(img, 120, 130) }, agg.Rect{X1: 120, Y1: 130, X2: 125, Y2: 135}},
+		{"clear", func(c *agg.Context) { c.Clear(agg.Black) }, agg.Rect{X2: 200, Y2: 200}},
+		{"clipped", func(c *agg.Context) { c.FillRectangle(190, 190, 50, 50) }, agg.Rect{X1: 190, Y1: 190, X2: 200, Y2: 200}},
+		{"blur", func(c *agg.Context) {
+			c.GetAgg2D().ClipBox(20, 30, 59, 69)
+			c.GaussianBlur(2)
+			c.GetAgg2D().ClipBox(0, 0, 199, 199)
+		}, agg.Rect{X1: 20, Y1: 30, X2: 60, Y2: 70}},
+		{"flood fill", func(c *agg.Context) {
+			c.GetAgg2D().ClipBox(0, 0, 49, 9)
+			c.FloodFill(5, 5, agg.Black, 0)
+			c.GetAgg2D().ClipBox(0, 0, 199, 199)
+		}, agg.Rect{X2: 50, Y2: 10}},
+		{"empty", func(c *agg.Context) {}, agg.Rect{}},
+	}
+	for _, tt := range tests {
+		ctx.SetColor(agg.Red)
+		ctx.SetLineWidth(1)
+		if got := ctx.MeasureDraw(tt.draw); got != tt.want {
+			t.Errorf("%s: MeasureDraw = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+	if !bytes.Equal(ctx.GetImage().Data, before) {
+		t.Error("MeasureDraw wrote pixels")
+	}
+
+	if r := ctx.MeasureDraw(func(c *agg.Context) { _ = c.DrawText("Hello", 20, 150) }); r.Width() <= 0 || r.Height() <= 0 {
+		t.Errorf("text should have non-empty bounds, got %+v", r)
+	}
+
+	// Nested dry runs add their bounds to the enclosing one.
+	var inner agg.Rect
+	outer := ctx.MeasureDraw(func(c *agg.Context) {
+		c.FillRectangle(10, 10, 10, 10)
+		inner = c.MeasureDraw(func(c *agg.Context) { c.FillRectangle(50, 60, 10, 10) })
+	})
+	if inner != (agg.Rect{X1: 50, Y1: 60, X2: 60, Y2: 70}) || outer != (agg.Rect{X1: 10, Y1: 10, X2: 60, Y2: 70}) {
+		t.Errorf("nested bounds: inner %+v, outer %+v", inner, outer)
+	}
+
+	// A panic in draw ends the dry run, so drawing paints again below.
+	func() {
+		defer func() { _ = recover() }()
+		ctx.MeasureDraw(func(*agg.Context) { panic("draw failed") })
+	}()
+
+	ctx.FillRectangle(10, 10, 10, 10)
+	if bytes.Equal(ctx.GetImage().Data, before) {
+		t.Error("drawing after MeasureDraw should paint again")
+	}
+}
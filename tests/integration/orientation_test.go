@@ -0,0 +1,63 @@
+package integration
+
+import (
+	"bytes"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// gray3x2 returns a 3x2 Gray8 image with pixel values
+//
+//	1 2 3
+//	4 5 6
+func gray3x2() *agg.Image {
+	return agg.NewGrayImage([]uint8{1, 2, 3, 4, 5, 6}, 3, 2, 3)
+}
+
+func TestImageOrientation(t *testing.T) {
+	tests := []struct {
+		name string
+		op   func(*agg.Image) *agg.Image
+		w, h int
+		want []uint8
+	}{
+		{"FlipH", (*agg.Image).FlipH, 3, 2, []uint8{3, 2, 1, 6, 5, 4}},
+		{"FlipV", (*agg.Image).FlipV, 3, 2, []uint8{4, 5, 6, 1, 2, 3}},
+		{"Rotate90", (*agg.Image).Rotate90, 2, 3, []uint8{4, 1, 5, 2, 6, 3}},
+		{"Rotate180", (*agg.Image).Rotate180, 3, 2, []uint8{6, 5, 4, 3, 2, 1}},
+		{"Rotate270", (*agg.Image).Rotate270, 2, 3, []uint8{3, 6, 2, 5, 1, 4}},
+		{"Transpose", (*agg.Image).Transpose, 2, 3, []uint8{1, 4, 2, 5, 3, 6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.op(gray3x2())
+			if got.Width() != tt.w || got.Height() != tt.h || got.Format() != agg.ImageGray8 {
+				t.Fatalf("got %dx%d %v, want %dx%d Gray8", got.Width(), got.Height(), got.Format(), tt.w, tt.h)
+			}
+			if !bytes.Equal(got.Data, tt.want) {
+				t.Errorf("pixels = %v, want %v", got.Data, tt.want)
+			}
+		})
+	}
+
+	// Inverse operations restore RGBA images exactly.
+	img := agg.CreateImage(5, 3)
+	for i := range img.Data {
+		img.Data[i] = uint8(i * 7)
+	}
+	if got := img.Rotate90().Rotate270(); !bytes.Equal(got.Data, img.Data) {
+		t.Error("Rotate270 does not undo Rotate90")
+	}
+	if got := img.Transpose().Transpose(); !bytes.Equal(got.Data, img.Data) {
+		t.Error("Transpose is not its own inverse")
+	}
+	if got := img.Orient(1); &got.Data[0] == &img.Data[0] || !bytes.Equal(got.Data, img.Data) {
+		t.Error("Orient(1) does not return a copy")
+	}
+
+	// Channel views are read with their pixel step.
+	if got := img.AlphaView().FlipH(); got.Data[0] != img.Data[4*4+3] {
+		t.Errorf("flipped alpha view starts with %v, want %v", got.Data[0], img.Data[4*4+3])
+	}
+}
@@ -0,0 +1,122 @@
+package integration
+
+import (
+	"math"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+func near(a, b, eps float64) bool {
+	return math.Abs(a-b) <= eps
+}
+
+// TestPathLength checks polylines, closed sub-paths and flattened curves.
+func TestPathLength(t *testing.T) {
+	p := agg.NewPath()
+	if l := p.Length(); l != 0 {
+		t.Fatalf("empty path length = %v", l)
+	}
+
+	addSquare(p, 0, 0, 10, 10)
+	if l := p.Length(); !near(l, 30, 1e-9) {
+		t.Errorf("open square length = %v, want 30", l)
+	}
+	p.ClosePath()
+	if l := p.Length(); !near(l, 40, 1e-9) {
+		t.Errorf("closed square length = %v, want 40", l)
+	}
+	p.MoveTo(100, 0)
+	p.LineTo(100, 5)
+	if l := p.Length(); !near(l, 45, 1e-9) {
+		t.Errorf("two sub-paths length = %v, want 45", l)
+	}
+
+	// A quarter circle of radius 100 as a cubic Bézier.
+	const k = 0.5522847498
+	arc := agg.NewPath()
+	arc.MoveTo(100, 0)
+	arc.CubicCurveTo(100, 100*k, 100*k, 100, 0, 100)
+	if l, want := arc.Length(), math.Pi*50; !near(l, want, 0.1) {
+		t.Errorf("quarter circle length = %v, want %v", l, want)
+	}
+}
+
+// TestPathPointAtLength checks positions, tangents, clamping and the jump
+// between sub-paths.
+func TestPathPointAtLength(t *testing.T) {
+	p := agg.NewPath()
+	addSquare(p, 0, 0, 10, 10)
+	p.ClosePath()
+	p.MoveTo(100, 0)
+	p.LineTo(100, 5)
+
+	for _, tc := range []struct {
+		d, x, y, angle float64
+	}{
+		{-5, 0, 0, 0},
+		{5, 5, 0, 0},
+		{15, 10, 5, math.Pi / 2},
+		{25, 5, 10, math.Pi},
+		{35, 0, 5, -math.Pi / 2},
+		{42, 100, 2, math.Pi / 2},
+		{99, 100, 5, math.Pi / 2},
+	} {
+		x, y, a := p.PointAtLength(tc.d)
+		if !near(x, tc.x, 1e-9) || !near(y, tc.y, 1e-9) || !near(a, tc.angle, 1e-9) {
+			t.Errorf("PointAtLength(%v) = (%v, %v, %v), want (%v, %v, %v)", tc.d, x, y, a, tc.x, tc.y, tc.angle)
+		}
+	}
+
+	single := agg.NewPath()
+	single.MoveTo(3, 4)
+	if x, y, a := single.PointAtLength(1); x != 3 || y != 4 || a != 0 {
+		t.Errorf("single point PointAtLength = (%v, %v, %v)", x, y, a)
+	}
+}
+
+// TestPathSlice checks that slices have the requested length and split
+// across sub-paths.
+func TestPathSlice(t *testing.T) {
+	p := agg.NewPath()
+	addSquare(p, 0, 0, 10, 10)
+	p.ClosePath()
+	p.MoveTo(100, 0)
+	p.LineTo(100, 5)
+
+	s := p.Slice(5, 15)
+	if l := s.Length(); !near(l, 10, 1e-9) {
+		t.Errorf("slice length = %v, want 10", l)
+	}
+	segs := s.Segments()
+	want := []agg.Point{{X: 5, Y: 0}, {X: 10, Y: 0}, {X: 10, Y: 5}}
+	if len(segs) != len(want) {
+		t.Fatalf("slice has %d segments, want %d", len(segs), len(want))
+	}
+	for i, seg := range segs {
+		if seg.Points[0] != want[i] {
+			t.Errorf("slice point %d = %v, want %v", i, seg.Points[0], want[i])
+		}
+	}
+
+	s = p.Slice(35, 42)
+	if l := s.Length(); !near(l, 7, 1e-9) {
+		t.Errorf("cross sub-path slice length = %v, want 7", l)
+	}
+	moves := 0
+	for _, seg := range s.Segments() {
+		if seg.Cmd == agg.PathMoveTo {
+			moves++
+		}
+	}
+	if moves != 2 {
+		t.Errorf("cross sub-path slice has %d sub-paths, want 2", moves)
+	}
+
+	if s := p.Slice(20, 10); s.Length() != 0 || len(s.Segments()) != 0 {
+		t.Errorf("reversed slice is not empty")
+	}
+	if l := p.Slice(-10, 1000).Length(); !near(l, 45, 1e-9) {
+		t.Errorf("clamped slice length = %v, want 45", l)
+	}
+}
@@ -0,0 +1,105 @@
+package integration
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// subpathAreas returns the signed shoelace area of each sub-path; positive
+// areas are CCW in AGG's y-up convention.
+func subpathAreas(p *agg.Path) []float64 {
+	var areas []float64
+	var pts []agg.Point
+	flush := func() {
+		if len(pts) < 3 {
+			pts = pts[:0]
+			return
+		}
+		a := 0.0
+		for i, p1 := range pts {
+			p2 := pts[(i+1)%len(pts)]
+			a += p1.X*p2.Y - p1.Y*p2.X
+		}
+		areas = append(areas, a/2)
+		pts = pts[:0]
+	}
+	for _, seg := range p.Segments() {
+		switch seg.Cmd {
+		case agg.PathMoveTo:
+			flush()
+			pts = append(pts, seg.Points[0])
+		case agg.PathLineTo:
+			pts = append(pts, seg.Points[0])
+		}
+	}
+	flush()
+	return areas
+}
+
+func addSquare(p *agg.Path, x1, y1, x2, y2 float64) {
+	p.MoveTo(x1, y1)
+	p.LineTo(x2, y1)
+	p.LineTo(x2, y2)
+	p.LineTo(x1, y2)
+}
+
+// TestPathCloseSubpaths checks that open sub-paths get closed and closed ones
+// are left alone.
+func TestPathCloseSubpaths(t *testing.T) {
+	p := agg.NewPath()
+	addSquare(p, 0, 0, 10, 10)
+	addSquare(p, 20, 0, 30, 10)
+	p.ClosePath()
+	p.MoveTo(40, 0)
+	p.LineTo(50, 10)
+	p.LineTo(40, 10)
+
+	p.CloseSubpaths()
+
+	closes, moves := 0, 0
+	for _, seg := range p.Segments() {
+		switch seg.Cmd {
+		case agg.PathClose:
+			closes++
+		case agg.PathMoveTo:
+			moves++
+		}
+	}
+	if moves != 3 || closes != 3 {
+		t.Fatalf("got %d sub-paths with %d closes, want 3 and 3", moves, closes)
+	}
+}
+
+// TestPathFixOrientation checks that all sub-paths end up with the requested
+// winding, which turns a non-zero hole into filled area.
+func TestPathFixOrientation(t *testing.T) {
+	p := agg.NewPath()
+	addSquare(p, 0, 0, 100, 100)
+	p.ClosePath()
+	// Hole wound the other way
+	p.MoveTo(25, 25)
+	p.LineTo(25, 75)
+	p.LineTo(75, 75)
+	p.LineTo(75, 25)
+	p.ClosePath()
+
+	if p.Contains(50, 50, false) {
+		t.Fatal("oppositely wound hole should be empty under non-zero")
+	}
+
+	for _, dir := range []agg.Direction{agg.CW, agg.CCW} {
+		p.FixOrientation(dir)
+		for i, a := range subpathAreas(p) {
+			if (dir == agg.CW) != (a < 0) {
+				t.Errorf("direction %v: sub-path %d has area %v", dir, i, a)
+			}
+		}
+		if !p.Contains(50, 50, false) {
+			t.Errorf("direction %v: same-wound sub-paths should fill the hole under non-zero", dir)
+		}
+		if p.Contains(50, 50, true) {
+			t.Errorf("direction %v: even-odd should keep the hole", dir)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package integration
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// translucentPNG encodes a 2x2 PNG of one straight-alpha color.
+func translucentPNG(t *testing.T, c color.NRGBA) []byte {
+	t.Helper()
+	m := image.NewNRGBA(image.Rect(0, 0, 2, 2))
+	for i := 0; i < len(m.Pix); i += 4 {
+		m.Pix[i], m.Pix[i+1], m.Pix[i+2], m.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, m); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodePNGAlphaModes(t *testing.T) {
+	data := translucentPNG(t, color.NRGBA{200, 100, 50, 128})
+	px := func(img *agg.Image) [4]uint8 { return [4]uint8(img.Data[:4]) }
+
+	pre, err := agg.DecodePNG(bytes.NewReader(data), agg.DecodeOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := px(pre), [4]uint8{100, 50, 25, 128}; got != want || pre.AlphaMode() != agg.AlphaPremultiplied {
+		t.Errorf("premultiplied = %v (mode %d), want %v", got, pre.AlphaMode(), want)
+	}
+
+	straight, err := agg.DecodePNG(bytes.NewReader(data), agg.DecodeOptions{Alpha: agg.AlphaStraight})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := px(straight), [4]uint8{200, 100, 50, 128}; got != want || straight.AlphaMode() != agg.AlphaStraight {
+		t.Errorf("straight = %v (mode %d), want %v", got, straight.AlphaMode(), want)
+	}
+
+	// Straight images are premultiplied when drawn, so both land alike.
+	draw := func(img *agg.Image) [4]uint8 {
+		ctx := agg.NewContext(4, 4)
+		ctx.Clear(agg.White)
+		if err := ctx.DrawImage(img, 0, 0); err != nil {
+			t.Fatal(err)
+		}
+		return [4]uint8(ctx.GetImage().Data[4*4+4:][:4])
+	}
+	a, b := draw(pre), draw(straight)
+	for i := range a {
+		if d := int(a[i]) - int(b[i]); d < -1 || d > 1 {
+			t.Errorf("drawn straight %v differs from premultiplied %v", b, a)
+			break
+		}
+	}
+
+	// Straight images encode as NRGBA and round-trip exactly.
+	var buf bytes.Buffer
+	if err := straight.EncodePNG(&buf, agg.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := color.NRGBAModel.Convert(m.At(0, 0)).(color.NRGBA); c != (color.NRGBA{200, 100, 50, 128}) {
+		t.Errorf("re-encoded straight pixel = %v", c)
+	}
+
+	// image.RGBA is premultiplied, whatever the source mode.
+	if got := [4]uint8(straight.ToGoImage().Pix[:4]); got != [4]uint8{100, 50, 25, 128} {
+		t.Errorf("ToGoImage of straight = %v, want premultiplied", got)
+	}
+
+	if err := straight.Premultiply(); err != nil || px(straight) != [4]uint8{100, 50, 25, 128} {
+		t.Errorf("Premultiply = %v, %v", px(straight), err)
+	}
+	if err := straight.Demultiply(); err != nil || px(straight) != [4]uint8{199, 100, 50, 128} {
+		t.Errorf("Demultiply = %v, %v", px(straight), err)
+	}
+}
+
+func TestDecodePNGToLinear(t *testing.T) {
+	data := translucentPNG(t, color.NRGBA{200, 100, 50, 255})
+	img, err := agg.DecodePNG(bytes.NewReader(data), agg.DecodeOptions{ToLinear: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := [4]uint8(img.Data[:4]), [4]uint8{147, 32, 8, 255}; got != want || !img.IsLinear() {
+		t.Errorf("linear = %v (linear %v), want %v", got, img.IsLinear(), want)
+	}
+
+	// Encoding a linear image converts it back to sRGB.
+	var buf bytes.Buffer
+	if err := img.EncodePNG(&buf, agg.EncodeOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	m, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, _, _, _ := m.At(0, 0).RGBA()
+	if r8 := int(r >> 8); r8 < 199 || r8 > 201 {
+		t.Errorf("re-encoded red = %d, want about 200", r8)
+	}
+}
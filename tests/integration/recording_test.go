@@ -0,0 +1,214 @@
+package integration
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestRecordingReplayMatchesImmediate checks that replaying a recording
+// produces the same pixels as drawing directly into a Context.
+func TestRecordingReplayMatchesImmediate(t *testing.T) {
+	const w, h = 40, 40
+
+	direct := agg.NewContext(w, h)
+	direct.Clear(agg.White)
+	direct.SetColor(agg.Red)
+	direct.FillRectangle(5, 5, 20, 10)
+	direct.Translate(10, 0)
+	direct.SetColor(agg.Blue)
+	direct.FillCircle(20, 28, 6)
+
+	rec := agg.NewRecording(w, h)
+	rec.Clear(agg.White)
+	rec.SetColor(agg.Red)
+	rec.FillRectangle(5, 5, 20, 10)
+	rec.Translate(10, 0)
+	rec.SetColor(agg.Blue)
+	rec.FillCircle(20, 28, 6)
+	if rec.Len() != 3 {
+		t.Fatalf("Len = %d, want 3", rec.Len())
+	}
+
+	replayed := agg.NewContext(w, h)
+	if err := rec.Replay(replayed); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !bytes.Equal(direct.GetImage().Data, replayed.GetImage().Data) {
+		t.Error("replayed pixels differ from immediate rendering")
+	}
+	if !replayed.GetTransform().IsIdentity() {
+		t.Error("Replay should restore the target transform")
+	}
+}
+
+// TestRecordingWritePDF checks the basic structure of the exported PDF.
+func TestRecordingWritePDF(t *testing.T) {
+	rec := agg.NewRecording(100, 50)
+	rec.SetColor(agg.NewColor(255, 0, 0, 128))
+	rec.FillRoundedRectangle(10, 10, 80, 30, 5)
+	rec.SetDashPattern([]float64{4, 2})
+	rec.DrawLine(0, 0, 100, 50)
+	if err := rec.DrawText("Hello (PDF)", 10, 45); err != nil {
+		t.Fatalf("DrawText failed: %v", err)
+	}
+	if err := rec.DrawImage(agg.CreateImage(4, 4), 0, 0); err != nil {
+		t.Fatalf("DrawImage failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := rec.WritePDF(&out); err != nil {
+		t.Fatalf("WritePDF failed: %v", err)
+	}
+	pdf := out.String()
+	for _, want := range []string{"%PDF-1.4", "/MediaBox [0 0 100 50]", "/ExtGState", "/Im0", "/SMask", "startxref", "%%EOF"} {
+		if !strings.Contains(pdf, want) {
+			t.Errorf("PDF output missing %q", want)
+		}
+	}
+}
+
+func drawBadge(c agg.Canvas, col agg.Color) {
+	c.SetColor(col)
+	c.FillRoundedRectangle(10, 10, 60, 20, 4)
+	c.SetColor(agg.Black)
+	c.DrawLine(10, 40, 70, 40)
+}
+
+// TestRecordingCanvasAndCache checks Canvas-based drawing, cached
+// rasterization and transformed replay.
+func TestRecordingCanvasAndCache(t *testing.T) {
+	rec := agg.NewRecording(80, 50)
+	drawBadge(rec, agg.Green)
+
+	img1, err := rec.Image()
+	if err != nil {
+		t.Fatalf("Image failed: %v", err)
+	}
+	direct := agg.NewContext(80, 50)
+	drawBadge(direct, agg.Green)
+	if !bytes.Equal(img1.Data, direct.GetImage().Data) {
+		t.Error("cached image differs from direct Canvas rendering")
+	}
+
+	first := append([]byte(nil), img1.Data...)
+	img2, _ := rec.Image()
+	if img2 != img1 || !bytes.Equal(img2.Data, first) {
+		t.Error("Image should reuse the cache when nothing changed")
+	}
+	rec.FillCircle(75, 45, 3)
+	img3, _ := rec.Image()
+	if bytes.Equal(img3.Data, first) {
+		t.Error("Image should re-render after recording new commands")
+	}
+
+	ctx := agg.NewContext(160, 100)
+	if err := rec.ReplayTransformed(ctx, agg.Translation(80, 50)); err != nil {
+		t.Fatalf("ReplayTransformed failed: %v", err)
+	}
+	img := ctx.GetImage()
+	if p := getPixel(img.Data, 160*4, 120, 70); p[1] < 100 || p[3] == 0 {
+		t.Errorf("translated badge missing, got RGBA%v", p)
+	}
+	if p := getPixel(img.Data, 160*4, 40, 20); p[3] != 0 {
+		t.Errorf("untranslated area should stay empty, got RGBA%v", p)
+	}
+
+	thumb, err := rec.Thumbnail(40, 40)
+	if err != nil || thumb.Width() != 40 || thumb.Height() != 40 {
+		t.Fatalf("Thumbnail failed: %v", err)
+	}
+}
+
+// TestRecordingDiff checks that Diff reports the region of changed commands.
+func TestRecordingDiff(t *testing.T) {
+	a := agg.NewRecording(100, 100)
+	b := agg.NewRecording(100, 100)
+	drawBadge(a, agg.Red)
+	drawBadge(b, agg.Red)
+	if _, _, _, _, changed := a.Diff(b); changed {
+		t.Fatal("identical recordings should not differ")
+	}
+
+	b.FillRectangle(50, 60, 10, 10)
+	x1, y1, x2, y2, changed := a.Diff(b)
+	if !changed {
+		t.Fatal("expected a difference after adding a command")
+	}
+	if x1 > 50 || y1 > 60 || x2 < 60 || y2 < 70 || x1 < 45 || x2 > 65 {
+		t.Errorf("unexpected damage rect (%g,%g)-(%g,%g)", x1, y1, x2, y2)
+	}
+}
+
+// TestRenderBandedMatchesReplay checks that streaming a recording in bands
+// emits every row once, in order, with the pixels of a full replay.
+func TestRenderBandedMatchesReplay(t *testing.T) {
+	const w, h = 50, 37
+
+	// No Clear, so every band has to start out transparent.
+	rec := agg.NewRecording(w, h)
+	rec.SetColor(agg.Red)
+	rec.FillCircle(25, 18, 15)
+	rec.SetColor(agg.Blue)
+	rec.SetLineWidth(3)
+	rec.DrawLine(0, 0, 50, 37)
+
+	full := agg.NewContext(w, h)
+	if err := rec.Replay(full); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	want := full.GetImage().Data
+
+	for _, band := range []int{1, 8, 37, 0} {
+		got := make([]byte, 0, len(want))
+		next := 0
+		err := agg.RenderBanded(rec, band, func(y int, row []byte) error {
+			if y != next {
+				t.Fatalf("band %d: got row %d, want %d", band, y, next)
+			}
+			next++
+			got = append(got, row...)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("band %d: RenderBanded failed: %v", band, err)
+		}
+		if next != h {
+			t.Fatalf("band %d: emitted %d rows, want %d", band, next, h)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("band %d: pixels differ from a full replay", band)
+		}
+	}
+}
+
+// TestRecordingOddDashPattern checks that odd-length dash patterns replay as
+// Context.SetDashPattern draws them, repeated to an even length.
+func TestRecordingOddDashPattern(t *testing.T) {
+	const w, h = 60, 10
+	pattern := []float64{6, 3, 2}
+
+	direct := agg.NewContext(w, h)
+	direct.Clear(agg.White)
+	direct.SetColor(agg.Black)
+	direct.SetLineWidth(2)
+	direct.SetDashPattern(pattern)
+	direct.DrawLine(0, 5, 60, 5)
+
+	rec := agg.NewRecording(w, h)
+	rec.Clear(agg.White)
+	rec.SetColor(agg.Black)
+	rec.SetLineWidth(2)
+	rec.SetDashPattern(pattern)
+	rec.DrawLine(0, 5, 60, 5)
+
+	replayed := agg.NewContext(w, h)
+	if err := rec.Replay(replayed); err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if !bytes.Equal(direct.GetImage().Data, replayed.GetImage().Data) {
+		t.Error("replayed odd dash pattern differs from immediate rendering")
+	}
+}
@@ -0,0 +1,60 @@
+package integration
+
+import (
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+// TestReadWriteRegion reads back pixels, saves a region, draws over it and
+// restores it.
+func TestReadWriteRegion(t *testing.T) {
+	ctx := agg.NewContext(20, 20)
+	ctx.Clear(agg.White)
+	red := agg.Color{R: 255, A: 255}
+	ctx.GetImage().FloodFill(0, 0, red, 0)
+	ctx.GetImage().Data[(3*20+4)*4+1] = 7 // mark pixel (4, 3)
+
+	if got := ctx.GetPixel(4, 3); got != (agg.Color{R: 255, G: 7, A: 255}) {
+		t.Errorf("GetPixel(4, 3) = %v", got)
+	}
+	if got := ctx.GetPixel(-1, 25); got != (agg.Color{}) {
+		t.Errorf("GetPixel outside = %v, want zero", got)
+	}
+
+	saved := ctx.ReadRegion(agg.NewRect(2, 2, 8, 6))
+	if saved == nil || saved.Width() != 6 || saved.Height() != 4 {
+		t.Fatalf("ReadRegion returned %v", saved)
+	}
+	if c := saved.Data[(1*6+2)*4+1]; c != 7 {
+		t.Errorf("region pixel (2, 1) green = %d, want 7", c)
+	}
+	if r := ctx.ReadRegion(agg.NewRect(15, 15, 40, 40)); r == nil || r.Width() != 5 || r.Height() != 5 {
+		t.Errorf("clipped ReadRegion returned %v", r)
+	}
+	if r := ctx.ReadRegion(agg.NewRect(30, 30, 40, 40)); r != nil {
+		t.Errorf("ReadRegion outside the image = %v, want nil", r)
+	}
+
+	ctx.GetImage().FloodFill(0, 0, agg.Black, 255)
+	if err := ctx.WriteRegion(agg.NewRect(2, 2, 8, 6), saved); err != nil {
+		t.Fatal(err)
+	}
+	if got := ctx.GetPixel(4, 3); got != (agg.Color{R: 255, G: 7, A: 255}) {
+		t.Errorf("restored pixel = %v", got)
+	}
+	if ctx.GetPixel(2, 2) != red || ctx.GetPixel(8, 3) != agg.Black || ctx.GetPixel(4, 6) != agg.Black {
+		t.Errorf("WriteRegion wrote outside the rectangle")
+	}
+
+	// Writing partly off the image clips instead of failing.
+	if err := ctx.WriteRegion(agg.NewRect(15, -1, 21, 3), saved); err != nil {
+		t.Fatal(err)
+	}
+	if ctx.GetPixel(17, 0) != (agg.Color{R: 255, G: 7, A: 255}) || ctx.GetPixel(17, 1) != red {
+		t.Errorf("clipped WriteRegion: %v %v", ctx.GetPixel(17, 0), ctx.GetPixel(17, 1))
+	}
+	if err := ctx.WriteRegion(agg.NewRect(0, 0, 1, 1), nil); err == nil {
+		t.Error("WriteRegion(nil) returned no error")
+	}
+}
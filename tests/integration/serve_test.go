@@ -0,0 +1,69 @@
+package integration
+
+import (
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	agg "github.com/MeKo-Christian/agg_go"
+)
+
+func drawRedSquare(ctx *agg.Context) {
+	ctx.Clear(agg.White)
+	ctx.SetColor(agg.Red)
+	ctx.FillRectangle(2, 2, 6, 6)
+}
+
+// TestServeImagePNG checks the plain PNG response.
+func TestServeImagePNG(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := agg.ServeImage(rec, 10, 10, drawRedSquare); err != nil {
+		t.Fatalf("ServeImage failed: %v", err)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("Content-Type = %q, want image/png", ct)
+	}
+	img, err := png.Decode(rec.Body)
+	if err != nil {
+		t.Fatalf("response is not a PNG: %v", err)
+	}
+	if r, _, _, _ := img.At(5, 5).RGBA(); r>>8 != 255 {
+		t.Errorf("expected red pixel at (5,5), got %v", img.At(5, 5))
+	}
+}
+
+// TestServeImageNegotiationAndCaching checks Accept negotiation, cache
+// headers and conditional requests.
+func TestServeImageNegotiationAndCaching(t *testing.T) {
+	opts := agg.ServeOptions{MaxAge: time.Hour, ETag: true}
+
+	req := httptest.NewRequest(http.MethodGet, "/chart", nil)
+	req.Header.Set("Accept", "image/png;q=0.5, image/jpeg")
+	rec := httptest.NewRecorder()
+	if err := agg.ServeImageWithOptions(rec, req, 10, 10, drawRedSquare, opts); err != nil {
+		t.Fatalf("ServeImageWithOptions failed: %v", err)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/jpeg" {
+		t.Errorf("Content-Type = %q, want image/jpeg", ct)
+	}
+	if cc := rec.Header().Get("Cache-Control"); cc != "public, max-age=3600" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+	etag := rec.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/chart", nil)
+	req.Header.Set("Accept", "image/png;q=0.5, image/jpeg")
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	if err := agg.ServeImageWithOptions(rec, req, 10, 10, drawRedSquare, opts); err != nil {
+		t.Fatalf("conditional request failed: %v", err)
+	}
+	if rec.Code != http.StatusNotModified || rec.Body.Len() != 0 {
+		t.Errorf("expected empty 304, got %d with %d bytes", rec.Code, rec.Body.Len())
+	}
+}
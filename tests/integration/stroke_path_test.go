@@ -0,0 +1,376 @@
+package integration
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	agg "github.com/MeKo-Christian/agg_go"
+	"github.com/MeKo-Christian/agg_go/internal/primitives"
+)
+
+// TestStrokePathHitTest checks that the stroke outline contains points on the
+// stroke and excludes points off it.
+func TestStrokePathHitTest(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(10, 10)
+	p.LineTo(90, 10)
+	p.QuadricCurveTo(90, 90, 10, 90)
+
+	outline := agg.StrokePath(p, agg.StrokeOptions{Width: 6, LineCap: agg.CapRound})
+
+	for _, pt := range []agg.Point{{X: 50, Y: 12}, {X: 50, Y: 8}, {X: 8, Y: 10}, {X: 90, Y: 30}} {
+		if !outline.Contains(pt.X, pt.Y, false) {
+			t.Errorf("point %v should be on the stroke", pt)
+		}
+	}
+	for _, pt := range []agg.Point{{X: 50, Y: 20}, {X: 50, Y: 50}, {X: 3, Y: 10}} {
+		if outline.Contains(pt.X, pt.Y, false) {
+			t.Errorf("point %v should be off the stroke", pt)
+		}
+	}
+
+	for _, seg := range outline.Segments() {
+		switch seg.Cmd {
+		case agg.PathMoveTo, agg.PathLineTo, agg.PathClose:
+		default:
+			t.Fatalf("outline should be polygonal, got command %v", seg.Cmd)
+		}
+	}
+}
+
+// TestStrokePathDashes checks that dashed outlines split into one polygon per
+// dash.
+func TestStrokePathDashes(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(100, 0)
+
+	outline := agg.StrokePath(p, agg.StrokeOptions{Width: 2, Dashes: []float64{10}})
+	moves := 0
+	for _, seg := range outline.Segments() {
+		if seg.Cmd == agg.PathMoveTo {
+			moves++
+		}
+	}
+	if moves != 5 {
+		t.Fatalf("expected 5 dashes, got %d", moves)
+	}
+	if outline.Contains(15, 0, false) || !outline.Contains(5, 0, false) {
+		t.Error("dash gaps should not be part of the outline")
+	}
+}
+
+// TestStrokePathFillMatchesStroke checks that filling the outline renders the
+// same pixels as stroking the original path.
+func TestStrokePathFillMatchesStroke(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(10, 30)
+	p.LineTo(30, 5)
+	p.LineTo(50, 30)
+
+	stroked := agg.NewContext(60, 40)
+	stroked.Clear(agg.White)
+	stroked.SetColor(agg.Black)
+	stroked.SetLineWidth(4)
+	stroked.BeginPath()
+	stroked.AppendPath(p)
+	stroked.Stroke()
+
+	filled := agg.NewContext(60, 40)
+	filled.Clear(agg.White)
+	filled.SetColor(agg.Black)
+	filled.BeginPath()
+	filled.AppendPath(agg.StrokePath(p, agg.StrokeOptions{
+		Width: 4, LineCap: stroked.GetAgg2D().GetLineCap(), LineJoin: stroked.GetAgg2D().GetLineJoin(),
+	}))
+	filled.Fill()
+
+	if !bytes.Equal(stroked.GetImage().Data, filled.GetImage().Data) {
+		t.Error("filled stroke outline differs from the rendered stroke")
+	}
+}
+
+// TestStrokePathShorten checks that Shorten trims only the end of an open
+// path, and that dashed strokes are shortened once rather than per dash.
+func TestStrokePathShorten(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(100, 0)
+
+	outline := agg.StrokePath(p, agg.StrokeOptions{Width: 2, Shorten: 20})
+	if !outline.Contains(1, 0, false) || !outline.Contains(79, 0, false) {
+		t.Error("shortened stroke should keep the start of the path")
+	}
+	if outline.Contains(81, 0, false) {
+		t.Error("shortened stroke should leave a gap at the end")
+	}
+
+	dashed := agg.StrokePath(p, agg.StrokeOptions{Width: 2, Dashes: []float64{10}, Shorten: 20})
+	moves := 0
+	for _, seg := range dashed.Segments() {
+		if seg.Cmd == agg.PathMoveTo {
+			moves++
+		}
+	}
+	if moves != 4 {
+		t.Fatalf("expected 4 dashes on the shortened path, got %d", moves)
+	}
+	if !dashed.Contains(9, 0, false) || !dashed.Contains(61, 0, false) {
+		t.Error("dashes should keep their full length")
+	}
+}
+
+// TestShortenPath checks that ShortenPath drops and moves end vertices.
+func TestShortenPath(t *testing.T) {
+	vs := agg.NewVertexSequence()
+	vs.Add(agg.VertexDist{X: 0, Y: 0})
+	vs.Add(agg.VertexDist{X: 10, Y: 0})
+	vs.Add(agg.VertexDist{X: 10, Y: 0})
+	vs.Add(agg.VertexDist{X: 20, Y: 0})
+	vs.Close(false)
+	if vs.Size() != 3 {
+		t.Fatalf("coincident vertex should be dropped, got %d vertices", vs.Size())
+	}
+
+	agg.ShortenPath(vs, 15, false)
+	if vs.Size() != 2 {
+		t.Fatalf("expected 2 vertices, got %d", vs.Size())
+	}
+	if last := vs.Get(1); last.X != 5 || last.Y != 0 {
+		t.Errorf("expected the path to end at (5,0), got (%g,%g)", last.X, last.Y)
+	}
+}
+
+// TestPathPipeline checks that transformed, dashed and stroked steps chain
+// and that each returns a new path.
+func TestPathPipeline(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(100, 0)
+
+	moved := p.Transformed(agg.NewTransformationsFromValues(1, 0, 0, 1, 10, 20))
+	if segs := p.Segments(); segs[1].Points[0] != (agg.Point{X: 100, Y: 0}) {
+		t.Fatalf("source path changed: %+v", segs)
+	}
+	if segs := moved.Segments(); segs[0].Points[0] != (agg.Point{X: 10, Y: 20}) || segs[1].Points[0] != (agg.Point{X: 110, Y: 20}) {
+		t.Fatalf("unexpected transformed path: %+v", segs)
+	}
+
+	dashed := moved.Dashed([]float64{10})
+	moves := 0
+	for _, seg := range dashed.Segments() {
+		if seg.Cmd == agg.PathMoveTo {
+			moves++
+		}
+	}
+	if moves != 5 {
+		t.Fatalf("expected 5 dashes, got %d", moves)
+	}
+
+	outline := dashed.Stroked(agg.StrokeOptions{Width: 2})
+	if !outline.Contains(15, 20, false) || outline.Contains(25, 20, false) || outline.Contains(15, 0, false) {
+		t.Error("pipeline outline should cover only the transformed dashes")
+	}
+}
+
+// TestPathStamps checks arc-length stamp placement on open and closed paths
+// and the rendering of stamped markers.
+func TestPathStamps(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(0, 0)
+	p.LineTo(30, 0)
+	p.LineTo(30, 20)
+	stamps := agg.PathStamps(p, 10)
+	if len(stamps) != 6 {
+		t.Fatalf("open path: %d stamps, want 6: %v", len(stamps), stamps)
+	}
+	if s := stamps[3]; s.X != 30 || s.Y != 0 || s.Angle != 0 {
+		t.Errorf("corner stamp = %+v", s)
+	}
+	if s := stamps[5]; s.X != 30 || s.Y != 20 || math.Abs(s.Angle-math.Pi/2) > 1e-9 {
+		t.Errorf("last stamp = %+v", s)
+	}
+
+	sq := agg.NewPath()
+	sq.MoveTo(0, 0)
+	sq.LineTo(20, 0)
+	sq.LineTo(20, 20)
+	sq.LineTo(0, 20)
+	sq.ClosePath()
+	if n := len(agg.PathStamps(sq, 10)); n != 8 {
+		t.Errorf("closed square: %d stamps, want 8", n)
+	}
+	sq.Reset()
+	sq.MoveTo(0, 0)
+	sq.LineTo(20, 0)
+	sq.LineTo(0, 0)
+	sq.ClosePath()
+	if n := len(agg.PathStamps(sq, 10)); n != 4 {
+		t.Errorf("path closed at its start: %d stamps, want 4", n)
+	}
+	if agg.PathStamps(p, 0) != nil {
+		t.Error("zero spacing should give no stamps")
+	}
+
+	// A 4x4 square marker centered on its origin, stamped along a line.
+	marker := agg.NewPath()
+	marker.MoveTo(-2, -2)
+	marker.LineTo(2, -2)
+	marker.LineTo(2, 2)
+	marker.LineTo(-2, 2)
+	marker.ClosePath()
+	line := agg.NewPath()
+	line.MoveTo(10, 10)
+	line.LineTo(50, 10)
+
+	ctx := agg.NewContext(60, 20)
+	ctx.SetColor(agg.Red)
+	ctx.StampAlongPath(line, marker, 20, agg.StampTangent)
+	img := ctx.GetImage()
+	for _, x := range []int{10, 30, 49} {
+		if p := getPixel(img.Data, 60*4, x, 10); p[3] != 255 {
+			t.Errorf("no marker at x=%d: %v", x, p)
+		}
+	}
+	if p := getPixel(img.Data, 60*4, 20, 10); p[3] != 0 {
+		t.Errorf("paint between markers: %v", p)
+	}
+
+	stampImg := agg.CreateImageFromColor(4, 4, agg.Blue)
+	ctx.Clear(agg.Transparent)
+	if err := ctx.StampImageAlongPath(line, stampImg, 20, agg.StampUpright); err != nil {
+		t.Fatal(err)
+	}
+	if p := getPixel(img.Data, 60*4, 30, 10); p[2] < 200 {
+		t.Errorf("no image stamp at x=30: %v", p)
+	}
+}
+
+// TestPathHitTestPoints checks batch hit testing against Contains.
+func TestPathHitTestPoints(t *testing.T) {
+	p := agg.NewPath()
+	p.MoveTo(5, 5)
+	p.LineTo(45, 5)
+	p.LineTo(45, 45)
+	p.LineTo(5, 45)
+	p.ClosePath()
+	p.MoveTo(15, 15)
+	p.LineTo(35, 15)
+	p.LineTo(35, 35)
+	p.LineTo(15, 35)
+	p.ClosePath()
+
+	pts := []agg.Point{{X: 10.5, Y: 10.5}, {X: 25.5, Y: 25.5}, {X: 40.5, Y: 20.5}, {X: 60, Y: 10}, {X: -3, Y: 25}, {X: 10.5, Y: 40.5}}
+	for _, rule := range []agg.FillRule{agg.FillNonZero, agg.FillEvenOdd} {
+		hits := p.HitTestPoints(pts, rule)
+		for i, pt := range pts {
+			if want := p.Contains(pt.X, pt.Y, rule == agg.FillEvenOdd); hits[i] != want {
+				t.Errorf("rule %v point %v: hit %v, want %v", rule, pt, hits[i], want)
+			}
+		}
+	}
+}
+
+func TestRoundCorners(t *testing.T) {
+	square := agg.NewPath()
+	square.MoveTo(0, 0)
+	square.LineTo(20, 0)
+	square.LineTo(20, 20)
+	square.LineTo(0, 20)
+	square.ClosePath()
+
+	rounded := agg.RoundCorners(square, 5)
+	if rounded.Contains(0.5, 0.5, false) || !rounded.Contains(10, 10, false) || !rounded.Contains(19.5, 10, false) {
+		t.Error("rounded square: corners should be cut, edges kept")
+	}
+	// Four straight edges of 10 plus a full circle of radius 5.
+	want := 40 + 2*math.Pi*5
+	if l := square.Rounded(5).Length(); math.Abs(l-want) > 0.5 {
+		t.Errorf("Length() = %g, want ~%g", l, want)
+	}
+	if l := agg.RoundCorners(square, 0).Length(); math.Abs(l-80) > 1e-9 {
+		t.Errorf("radius 0 Length() = %g, want 80", l)
+	}
+}
+
+func TestPixelAccurateLines(t *testing.T) {
+	ctx := agg.NewContext(32, 32)
+	ctx.Clear(agg.White)
+	ctx.SetPixelAccurateLines(true)
+	ctx.SetColor(agg.Black)
+	ctx.SetLineWidth(1)
+
+	// A polyline: the shared vertex is drawn once, the end point included.
+	ctx.BeginPath()
+	ctx.MoveTo(2, 3)
+	ctx.LineTo(25, 11)
+	ctx.LineTo(7, 29.5)
+	ctx.Stroke()
+
+	want := map[[2]int]bool{}
+	plot := func(x, y int) { want[[2]int{x, y}] = true }
+	primitives.BresenhamLine(2, 3, 25, 11, false, plot)
+	primitives.BresenhamLine(25, 11, 7, 29, true, plot)
+
+	data := ctx.GetImage().Data
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			px := getPixel(data, 32*4, x, y)
+			if want[[2]int{x, y}] {
+				if px != [4]uint8{0, 0, 0, 255} {
+					t.Errorf("line pixel (%d,%d) = %v, want black", x, y, px)
+				}
+			} else if px != [4]uint8{255, 255, 255, 255} {
+				t.Errorf("pixel (%d,%d) = %v, want white", x, y, px)
+			}
+		}
+	}
+
+	// Wider strokes stay anti-aliased.
+	ctx.Clear(agg.White)
+	ctx.SetLineWidth(3)
+	ctx.DrawLine(2, 16.3, 30, 16.3)
+	if px := getPixel(data, 32*4, 10, 14); px[0] == 0 || px[0] == 255 {
+		t.Errorf("wide stroke edge = %v, want partial coverage", px)
+	}
+}
+
+func TestDensityMap(t *testing.T) {
+	p := agg.NewPath()
+	for i := 0; i < 100; i++ {
+		x := float64(i % 4)
+		p.MoveTo(x, 0)
+		p.LineTo(x+8, 0)
+		p.LineTo(x+8, 8)
+		p.LineTo(x, 8)
+		p.ClosePath()
+	}
+	d := agg.NewDensityMap(16, 16)
+	d.Add(p)
+	d.Add(p)
+
+	if m := d.Max(); m != 200 {
+		t.Errorf("Max = %v, want 200", m)
+	}
+	if v := d.Values[4*16+0]; v != 50 {
+		t.Errorf("value at (0,4) = %v, want 50", v)
+	}
+	if v := d.Values[12*16+12]; v != 0 {
+		t.Errorf("value outside = %v, want 0", v)
+	}
+
+	g := agg.NewLinearGradient(0, 0, 1, 0,
+		agg.GradientStop{Position: 0, Color: agg.Transparent},
+		agg.GradientStop{Position: 1, Color: agg.Red})
+	img := d.ToImage(g, 0)
+	if px := getPixel(img.Data, 16*4, 5, 4); px[0] < 250 || px[3] < 250 {
+		t.Errorf("densest pixel = %v, want red", px)
+	}
+	if px := getPixel(img.Data, 16*4, 0, 4); px[3] < 60 || px[3] > 68 {
+		t.Errorf("quarter density pixel = %v, want alpha near 64", px)
+	}
+	if px := getPixel(img.Data, 16*4, 12, 12); px[3] != 0 {
+		t.Errorf("empty pixel = %v, want transparent", px)
+	}
+}
@@ -0,0 +1,6 @@
+//go:build !subpixel16
+
+package framework
+
+// subpixelTolerance is the comparison tolerance against the references.
+const subpixelTolerance = 0
@@ -0,0 +1,8 @@
+//go:build subpixel16
+
+package framework
+
+// subpixelTolerance is the comparison tolerance against the references.
+// With 16 subpixel bits, edge coverage rounds differently from the 8-bit
+// references by up to three levels.
+const subpixelTolerance = 3
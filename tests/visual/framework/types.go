@@ -74,10 +74,12 @@ type ComparisonOptions struct {
 }
 
 // DefaultComparisonOptions returns sensible defaults for image comparison.
+// The references are rendered with AGG's 8 subpixel bits; builds with the
+// subpixel16 tag compare within subpixelTolerance.
 func DefaultComparisonOptions() ComparisonOptions {
 	return ComparisonOptions{
-		ExactMatch:         true,
-		Tolerance:          0,
+		ExactMatch:         subpixelTolerance == 0,
+		Tolerance:          subpixelTolerance,
 		MaxDifferentPixels: 0,
 		MaxDifferentRatio:  0,
 		GenerateDiffImage:  true,
@@ -4,6 +4,9 @@ import (
 	"errors"
 
 	ia "github.com/MeKo-Christian/agg_go/internal/agg2d"
+	"github.com/MeKo-Christian/agg_go/internal/font/shaping"
+	"github.com/MeKo-Christian/agg_go/internal/fonts"
+	"github.com/MeKo-Christian/agg_go/internal/glyph"
 )
 
 // FontCacheType defines font caching modes (re-exported from internal).
@@ -16,16 +19,127 @@ const (
 	VectorFontCache FontCacheType = ia.VectorFontCache
 )
 
-// Font loads a font with full configuration.
+// GlyphRendering selects how FreeType glyphs are produced and drawn
+// (re-exported from internal).
+type GlyphRendering = ia.GlyphRendering
+
+const (
+	// GlyphGray8 draws 8-bit anti-aliased glyph bitmaps.
+	GlyphGray8 GlyphRendering = ia.GlyphGray8
+	// GlyphMono draws 1-bit glyph bitmaps without anti-aliasing.
+	GlyphMono GlyphRendering = ia.GlyphMono
+	// GlyphLCD draws LCD subpixel coverage.
+	GlyphLCD GlyphRendering = ia.GlyphLCD
+	// GlyphOutline rasterizes glyph outlines like VectorFontCache.
+	GlyphOutline GlyphRendering = ia.GlyphOutline
+)
+
+// HintingMode selects how glyph outlines are grid-fitted (re-exported from
+// internal).
+type HintingMode = ia.HintingMode
+
+const (
+	// HintingNative uses the font's own hinting instructions.
+	HintingNative = ia.HintingNative
+	// HintingAuto uses FreeType's auto-hinter.
+	HintingAuto = ia.HintingAuto
+	// HintingNone disables hinting.
+	HintingNone = ia.HintingNone
+)
+
+// SubpixelOrder is the colour stripe order used by GlyphLCD (re-exported from
+// internal).
+type SubpixelOrder = ia.SubpixelOrder
+
+const (
+	// SubpixelRGB is for panels with red-green-blue stripes.
+	SubpixelRGB = ia.SubpixelRGB
+	// SubpixelBGR is for panels with blue-green-red stripes.
+	SubpixelBGR = ia.SubpixelBGR
+)
+
+// FontOptions configures FontWithOptions (re-exported from internal).
+type FontOptions = ia.FontOptions
+
+// GlyphRunCacheStats reports glyph-run cache usage (re-exported from internal).
+type GlyphRunCacheStats = ia.GlyphRunCacheStats
+
+// DefaultGlyphRunCacheSize is the default number of cached glyph runs.
+const DefaultGlyphRunCacheSize = ia.DefaultGlyphRunCacheSize
+
+// TextShaper orders and selects the glyphs drawn for a string (re-exported
+// from internal). Install one with SetTextShaper.
+type TextShaper = shaping.TextShaper
+
+// ShapedText is the output of a TextShaper (re-exported from internal).
+type ShapedText = shaping.ShapedText
+
+// ShapedGlyph is one glyph of a ShapedText (re-exported from internal).
+type ShapedGlyph = shaping.ShapedGlyph
+
+// ShapingFont describes the font passed to a TextShaper (re-exported from
+// internal).
+type ShapingFont = shaping.Font
+
+// SimpleShaper is the default TextShaper. It reorders right-to-left runs
+// (Hebrew, Arabic) and applies Arabic joining via presentation forms.
+type SimpleShaper = shaping.SimpleShaper
+
+// TextDecoration selects the lines drawn along text (re-exported from
+// internal). Combine the flags with |.
+type TextDecoration = ia.TextDecoration
+
+const (
+	// DecorationNone draws plain text.
+	DecorationNone = ia.DecorationNone
+	// DecorationUnderline draws a line below the baseline.
+	DecorationUnderline = ia.DecorationUnderline
+	// DecorationOverline draws a line above the ascent.
+	DecorationOverline = ia.DecorationOverline
+	// DecorationLineThrough strikes through the middle of the lower case.
+	DecorationLineThrough = ia.DecorationLineThrough
+)
+
+// DecorationMetrics places the decoration lines of a font (re-exported from
+// internal).
+type DecorationMetrics = ia.DecorationMetrics
+
+// TextLayout is the caret and selection geometry of one laid-out string
+// (re-exported from internal); see Context.TextLayout.
+type TextLayout = ia.TextLayout
+
+// TextCluster is one cluster of a TextLayout (re-exported from internal).
+type TextCluster = ia.TextCluster
+
+// TextBox is a box in TextLayout coordinates (re-exported from internal).
+type TextBox = ia.TextBox
+
+// Font loads a font with full configuration. Glyph options set by an
+// earlier FontWithOptions call are reset to their defaults.
 func (ctx *Context) Font(fileName string, height float64, bold, italic bool, cacheType FontCacheType, angle float64) error {
 	return ctx.agg2d.impl.Font(fileName, height, bold, italic, cacheType, angle)
 }
 
+// FontWithOptions loads a font with explicit glyph rendering and hinting
+// options.
+func (ctx *Context) FontWithOptions(fileName string, height float64, opts FontOptions) error {
+	return ctx.agg2d.FontWithOptions(fileName, height, opts)
+}
+
 // LoadFont loads a font from a file with default settings.
 func (ctx *Context) LoadFont(fontFile string) error {
 	return ctx.Font(fontFile, 12.0, false, false, RasterFontCache, 0.0)
 }
 
+// AddFallbackFont appends a font used for characters missing from the current
+// font, e.g. a CJK or emoji font behind a Latin one.
+func (ctx *Context) AddFallbackFont(fontFile string) error {
+	return ctx.agg2d.AddFallbackFont(fontFile)
+}
+
+// ClearFallbackFonts removes all fallback fonts.
+func (ctx *Context) ClearFallbackFonts() { ctx.agg2d.ClearFallbackFonts() }
+
 // FontHeight returns the current font height.
 func (ctx *Context) FontHeight() float64 { return ctx.agg2d.impl.FontHeight() }
 
@@ -47,12 +161,39 @@ func (ctx *Context) TextHints(hints bool) { ctx.agg2d.impl.TextHints(hints) }
 // GetTextHints returns current hinting state.
 func (ctx *Context) GetTextHints() bool { return ctx.agg2d.impl.GetTextHints() }
 
+// TextKerning enables/disables pair kerning (enabled by default).
+func (ctx *Context) TextKerning(kerning bool) { ctx.agg2d.impl.TextKerning(kerning) }
+
+// GetTextKerning returns current kerning state.
+func (ctx *Context) GetTextKerning() bool { return ctx.agg2d.impl.GetTextKerning() }
+
+// TextLigatures enables/disables standard ligatures (disabled by default).
+func (ctx *Context) TextLigatures(ligatures bool) { ctx.agg2d.impl.TextLigatures(ligatures) }
+
+// GetTextLigatures returns current ligature state.
+func (ctx *Context) GetTextLigatures() bool { return ctx.agg2d.impl.GetTextLigatures() }
+
+// SetTextShaper installs the shaper used for text layout; nil restores
+// SimpleShaper.
+func (ctx *Context) SetTextShaper(shaper TextShaper) { ctx.agg2d.SetTextShaper(shaper) }
+
+// SetGlyphRunCacheSize sets how many laid-out strings are cached, so labels
+// drawn every frame skip the font engine. Zero disables the cache.
+func (ctx *Context) SetGlyphRunCacheSize(n int) { ctx.agg2d.SetGlyphRunCacheSize(n) }
+
+// GlyphRunCacheStats returns usage statistics of the glyph-run cache.
+func (ctx *Context) GlyphRunCacheStats() GlyphRunCacheStats { return ctx.agg2d.GlyphRunCacheStats() }
+
+// ClearGlyphRunCache drops all cached glyph runs and resets the statistics.
+func (ctx *Context) ClearGlyphRunCache() { ctx.agg2d.ClearGlyphRunCache() }
+
 // SetTextAlignment configures horizontal and vertical alignment for text.
 func (ctx *Context) SetTextAlignment(alignX, alignY TextAlignment) {
 	ctx.agg2d.impl.TextAlignment(int(alignX), int(alignY))
 }
 
-// DrawText renders text at the specified position.
+// DrawText renders text at the specified position. Until a font file is
+// loaded it draws with the embedded bitmap font chosen by SetBitmapFont.
 func (ctx *Context) DrawText(text string, x, y float64) error {
 	if text == "" {
 		return errors.New("text is empty")
@@ -79,6 +220,81 @@ func (ctx *Context) DrawTextAligned(text string, x, y float64, alignment TextAli
 	return nil
 }
 
+// BitmapFont is an embedded raster font in AGG's glyph_raster_bin format.
+// Text falls back to a bitmap font until a font file is loaded.
+type BitmapFont []byte
+
+// Embedded bitmap fonts. BitmapFontGSE8x16 is the default fallback.
+var (
+	BitmapFontGSE4x6        BitmapFont = fonts.GSE4x6
+	BitmapFontGSE5x7        BitmapFont = fonts.GSE5x7
+	BitmapFontGSE6x12       BitmapFont = fonts.GSE6x12
+	BitmapFontGSE7x11       BitmapFont = fonts.GSE7x11
+	BitmapFontGSE7x11Bold   BitmapFont = fonts.GSE7x11Bold
+	BitmapFontGSE8x16       BitmapFont = fonts.GSE8x16
+	BitmapFontGSE8x16Bold   BitmapFont = fonts.GSE8x16Bold
+	BitmapFontMCS5x10Mono   BitmapFont = fonts.MCS5x10Mono
+	BitmapFontVerdana12     BitmapFont = fonts.Verdana12
+	BitmapFontVerdana12Bold BitmapFont = fonts.Verdana12Bold
+	BitmapFontVerdana16     BitmapFont = fonts.Verdana16
+)
+
+// Height returns the glyph cell height in pixels.
+func (f BitmapFont) Height() float64 {
+	if len(f) == 0 {
+		return 0
+	}
+	return float64(f[0])
+}
+
+// Width returns the advance of text in pixels. Characters outside the font
+// are skipped.
+func (f BitmapFont) Width(text string) float64 {
+	return glyph.NewGlyphRasterBin(f).Width(text)
+}
+
+// SetBitmapFont selects the bitmap font used while no font file is loaded.
+// nil restores BitmapFontGSE8x16.
+func (ctx *Context) SetBitmapFont(font BitmapFont) { ctx.agg2d.impl.BitmapFont(font) }
+
+// DrawTextOptions configures a single DrawTextWithOptions call. Zero values
+// keep left/baseline alignment and the context's bitmap font.
+type DrawTextOptions struct {
+	// BitmapFont overrides the fallback font for this call. It has no effect
+	// once a font file is loaded.
+	BitmapFont BitmapFont
+	AlignX     TextAlignment
+	AlignY     TextAlignment
+	// Decoration adds lines to those set with SetTextDecoration.
+	Decoration TextDecoration
+}
+
+// DrawTextWithOptions renders text with its baseline starting at x, y in the
+// current fill color. Without a loaded font it uses an embedded bitmap font,
+// so text works out of the box; after Font or LoadFont succeeds the same call
+// renders through the font engine.
+func (ctx *Context) DrawTextWithOptions(x, y float64, text string, opts DrawTextOptions) error {
+	if text == "" {
+		return errors.New("text is empty")
+	}
+	impl := ctx.agg2d.impl
+	alignX, alignY := impl.GetTextAlignment()
+	impl.TextAlignment(opts.AlignX, opts.AlignY)
+	defer impl.TextAlignment(alignX, alignY)
+	if opts.Decoration != DecorationNone {
+		prev := impl.GetTextDecoration()
+		impl.SetTextDecoration(prev | opts.Decoration)
+		defer impl.SetTextDecoration(prev)
+	}
+	if opts.BitmapFont != nil {
+		prev := impl.GetBitmapFont()
+		impl.BitmapFont(opts.BitmapFont)
+		defer impl.BitmapFont(prev)
+	}
+	impl.Text(x, y, text, true, 0, 0)
+	return nil
+}
+
 // FillText renders filled text (same as DrawText for AGG path-based rendering).
 func (ctx *Context) FillText(text string, x, y float64) error { return ctx.DrawText(text, x, y) }
 
@@ -91,6 +307,50 @@ func (ctx *Context) StrokeText(text string, x, y float64) error {
 	return nil
 }
 
+// TextStyle paints a DrawTextStyled call. A nil paint skips that part.
+type TextStyle struct {
+	Fill   Paint
+	Stroke Paint
+	// StrokeWidth is the outline width in user units; zero skips the
+	// stroke. The outline is centered on the glyph edges and drawn over the
+	// fill, so half of it covers the glyph.
+	StrokeWidth float64
+}
+
+// DrawTextStyled renders text with its baseline starting at x, y as vector
+// glyph outlines, filled and then stroked with the paints of style, through
+// the same pipeline as any other shape. The text alignment and the stroke
+// joins of the context apply; its fill and stroke paints and line width are
+// left unchanged, while the current path is replaced by the glyph outlines.
+//
+// Glyph outlines need a font file loaded with VectorFontCache; bitmap and
+// raster fonts return an error without drawing.
+func (ctx *Context) DrawTextStyled(x, y float64, text string, style TextStyle) error {
+	if text == "" {
+		return errors.New("text is empty")
+	}
+	impl := ctx.agg2d.impl
+	if !impl.TextOutline(x, y, text, false) {
+		return errors.New("styled text needs an outline font loaded with VectorFontCache")
+	}
+
+	paints, width := impl.PaintState(), impl.GetLineWidth()
+	defer func() {
+		impl.SetPaintState(paints)
+		impl.LineWidth(width)
+	}()
+	if style.Fill != nil {
+		ctx.agg2d.SetFillPaint(style.Fill)
+		ctx.agg2d.DrawPath(FillOnly)
+	}
+	if style.Stroke != nil && style.StrokeWidth > 0 {
+		ctx.agg2d.SetLinePaint(style.Stroke)
+		impl.LineWidth(style.StrokeWidth)
+		ctx.agg2d.DrawPath(StrokeOnly)
+	}
+	return nil
+}
+
 // MeasureText returns width and height of the text with current font settings.
 func (ctx *Context) MeasureText(text string) (width, height float64) {
 	width = ctx.agg2d.impl.TextWidth(text)
@@ -102,6 +362,12 @@ func (ctx *Context) MeasureText(text string) (width, height float64) {
 	return width, ascent + descent
 }
 
+// TextLayout lays out text with the current font settings and returns its
+// cluster boxes, caret positions and hit testing, for building text editors
+// without shaping text again. Coordinates are relative to the point the text
+// would be drawn at left-aligned, with indices as byte offsets into text.
+func (ctx *Context) TextLayout(text string) *TextLayout { return ctx.agg2d.impl.TextLayout(text) }
+
 // GetTextWidth returns the width of the text.
 func (ctx *Context) GetTextWidth(text string) float64 { return ctx.agg2d.impl.TextWidth(text) }
 
@@ -145,8 +411,32 @@ func (ctx *Context) SetItalic(italic bool) {
 	}
 }
 
-// SetUnderline is currently a placeholder for future underline support.
-func (ctx *Context) SetUnderline(u bool) {}
+// SetUnderline turns the underline of subsequent text on or off, keeping
+// the other decorations.
+func (ctx *Context) SetUnderline(u bool) {
+	d := ctx.GetTextDecoration() &^ DecorationUnderline
+	if u {
+		d |= DecorationUnderline
+	}
+	ctx.SetTextDecoration(d)
+}
+
+// SetTextDecoration selects the lines drawn with subsequent text. The lines
+// span the advance of the string and are painted with the fill paint, like
+// the glyphs, following the transform and text rotation. With the bitmap
+// fallback fonts they are one pixel thick and pixel-aligned.
+func (ctx *Context) SetTextDecoration(d TextDecoration) { ctx.agg2d.impl.SetTextDecoration(d) }
+
+// GetTextDecoration returns the lines drawn with text.
+func (ctx *Context) GetTextDecoration() TextDecoration { return ctx.agg2d.impl.GetTextDecoration() }
+
+// TextDecorationMetrics returns the positions, as distances above the
+// baseline, and thickness of the decoration lines of the current font.
+// Outline fonts supply the underline from the font file; the rest is
+// derived from the font height and x-height.
+func (ctx *Context) TextDecorationMetrics() DecorationMetrics {
+	return ctx.agg2d.impl.TextDecorationMetrics()
+}
 
 // DrawTextCentered draws text centered on x.
 func (ctx *Context) DrawTextCentered(text string, x, y float64) error {
@@ -0,0 +1,16 @@
+//go:build harfbuzz
+
+package agg
+
+import "github.com/MeKo-Christian/agg_go/internal/font/shaping"
+
+// HarfBuzzShaper is a TextShaper backed by HarfBuzz, available with the
+// harfbuzz build tag. It handles OpenType shaping for complex scripts such as
+// Arabic, Hebrew and the Indic scripts.
+type HarfBuzzShaper = shaping.HarfBuzzShaper
+
+// NewHarfBuzzShaper creates a HarfBuzz-backed shaper. Call Close to release
+// the loaded font faces.
+func NewHarfBuzzShaper() *HarfBuzzShaper {
+	return shaping.NewHarfBuzzShaper()
+}
@@ -45,9 +45,13 @@ func (ctx *Context) SetTransform(tr *Transformations) {
 	ctx.agg2d.impl.SetTransformations(toInternalTransformations(tr))
 }
 
-// ResetTransform resets the transformation matrix to identity.
+// ResetTransform resets the transformation matrix to identity, or to the
+// unit scale set with SetUnits.
 func (ctx *Context) ResetTransform() {
 	ctx.agg2d.ResetTransformations()
+	if s := ctx.UnitScale(); s != 1 {
+		ctx.agg2d.impl.UniformScale(s)
+	}
 }
 
 // Transform multiplies the current transformation matrix with another.
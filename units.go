@@ -0,0 +1,97 @@
+package agg
+
+// Unit is a length unit for Context coordinates, see SetUnits.
+type Unit int
+
+const (
+	// Pixels are device pixels, the default.
+	Pixels Unit = iota
+	// Points are typographic points, 1/72 inch, as used by PDF.
+	Points
+	// Millimeters are 1/25.4 inch.
+	Millimeters
+	// Centimeters are 1/2.54 inch.
+	Centimeters
+	// Inches are 1 inch.
+	Inches
+)
+
+// PerInch returns how many u make an inch, or 0 for Pixels, whose size
+// depends on the resolution, and for unknown units.
+func (u Unit) PerInch() float64 {
+	switch u {
+	case Points:
+		return 72
+	case Millimeters:
+		return 25.4
+	case Centimeters:
+		return 2.54
+	case Inches:
+		return 1
+	}
+	return 0
+}
+
+// String returns the unit name.
+func (u Unit) String() string {
+	switch u {
+	case Points:
+		return "pt"
+	case Millimeters:
+		return "mm"
+	case Centimeters:
+		return "cm"
+	case Inches:
+		return "in"
+	}
+	return "px"
+}
+
+// SetUnits makes user coordinates measure unit at dpi device pixels per
+// inch, so a page laid out in millimeters renders at the same physical size
+// at any resolution:
+//
+//	ctx := agg.NewContext(2480, 3508) // A4 at 300 dpi
+//	ctx.SetUnits(agg.Millimeters, 300)
+//	ctx.SetStrokeWidth(0.25)
+//	ctx.DrawRectangle(20, 20, 170, 257)
+//
+// The unit scale sits beneath the user transform: SetUnits replaces the
+// current transform with it, and ResetTransform returns to it instead of
+// the identity. GetTransform and SetTransform work on the full matrix, unit
+// scale included. Since stroke widths, dashes and vector text are in user
+// coordinates they scale along; raster font heights are converted when the
+// font is loaded, so load fonts after SetUnits. The embedded bitmap fonts
+// have a fixed pixel size.
+//
+// Pixels, an unknown unit, or a dpi of zero or less, switches back to
+// device pixels.
+func (ctx *Context) SetUnits(unit Unit, dpi float64) {
+	if unit.PerInch() == 0 || dpi <= 0 {
+		unit, dpi = Pixels, 0
+	}
+	ctx.unit, ctx.dpi = unit, dpi
+	ctx.ResetTransform()
+}
+
+// GetUnits returns the unit and resolution set with SetUnits. The
+// resolution is 0 for Pixels.
+func (ctx *Context) GetUnits() (unit Unit, dpi float64) {
+	return ctx.unit, ctx.dpi
+}
+
+// UnitScale returns the number of device pixels per user unit before the
+// user transform: dpi divided by the unit's PerInch, or 1 for Pixels.
+func (ctx *Context) UnitScale() float64 {
+	perInch := ctx.unit.PerInch()
+	if perInch == 0 {
+		return 1
+	}
+	return ctx.dpi / perInch
+}
+
+// PageSize returns the size of the image in the current units.
+func (ctx *Context) PageSize() (width, height float64) {
+	s := ctx.UnitScale()
+	return float64(ctx.width) / s, float64(ctx.height) / s
+}
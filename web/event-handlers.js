@@ -21,6 +21,15 @@ export function setupEventHandlers(
     .getElementById("renderBtn")
     .addEventListener("click", renderSelectedDemo);
 
+  // "h" toggles the debug overlay (frame rate, frame times, render time).
+  let showHUD = false;
+  document.addEventListener("keydown", (e) => {
+    if (e.key !== "h" || e.target instanceof HTMLInputElement) return;
+    showHUD = !showHUD;
+    setShowHUD(showHUD);
+    renderSelectedDemo();
+  });
+
   // aa controls
   document.getElementById("zoomSlider").addEventListener("input", () => {
     const val = parseFloat(document.getElementById("zoomSlider").value);
@@ -1108,21 +1117,6 @@ export function setupEventHandlers(
     renderSelectedDemo();
   });
 
-  // Mouse wheel zoom for flash_rasterizer2
-  canvas.addEventListener(
-    "wheel",
-    (e) => {
-      if (selector.value !== "flash_rasterizer2") return;
-      e.preventDefault();
-      const rect = canvas.getBoundingClientRect();
-      const mx = (e.clientX - rect.left) * (canvas.width / rect.width);
-      const my = (e.clientY - rect.top) * (canvas.height / rect.height);
-      applyFlash2Wheel(mx, my, e.deltaY);
-      renderSelectedDemo();
-    },
-    { passive: false },
-  );
-
   // gpc_test controls
   document.getElementById("gpcSceneSelector").addEventListener("change", () => {
     const val = parseInt(document.getElementById("gpcSceneSelector").value, 10);
@@ -1183,6 +1177,40 @@ export function setupEventHandlers(
       renderSelectedDemo();
     });
 
+  // Canvas-space position of a mouse event or touch point.
+  const canvasPoint = (p) => {
+    const rect = canvas.getBoundingClientRect();
+    return [
+      (p.clientX - rect.left) * (canvas.width / rect.width),
+      (p.clientY - rect.top) * (canvas.height / rect.height),
+    ];
+  };
+
+  // Wheel zoom for demos that support it. Browsers report pixels or lines;
+  // Go expects notches with positive values scrolling away from the user.
+  canvas.addEventListener(
+    "wheel",
+    (e) => {
+      const [x, y] = canvasPoint(e);
+      const scale =
+        e.deltaMode === WheelEvent.DOM_DELTA_PIXEL ? 1 / 100 : 1 / 3;
+      if (
+        onMouseWheel(selector.value, x, y, e.deltaX * scale, -e.deltaY * scale)
+      ) {
+        e.preventDefault();
+        renderSelectedDemo();
+      }
+    },
+    { passive: false },
+  );
+
+  canvas.addEventListener("dblclick", (e) => {
+    const [x, y] = canvasPoint(e);
+    if (onDoubleClick(selector.value, x, y)) {
+      renderSelectedDemo();
+    }
+  });
+
   // Mouse events for draggable-point demos
   let isDragging = false;
 
@@ -1236,6 +1264,18 @@ export function setupEventHandlers(
     "touchstart",
     (e) => {
       e.preventDefault();
+      for (const t of e.changedTouches) {
+        const [tx, ty] = canvasPoint(t);
+        onTouchDown(selector.value, t.identifier, tx, ty);
+      }
+      if (e.touches.length > 1) {
+        // A second finger turns the drag into a pinch.
+        if (isDragging) {
+          isDragging = false;
+          onMouseUp(selector.value);
+        }
+        return;
+      }
       const touch = e.touches[0];
       const rect = canvas.getBoundingClientRect();
       const x = (touch.clientX - rect.left) * (canvas.width / rect.width);
@@ -1257,8 +1297,16 @@ export function setupEventHandlers(
   canvas.addEventListener(
     "touchmove",
     (e) => {
-      if (!isDragging) return;
       e.preventDefault();
+      let pinched = false;
+      for (const t of e.changedTouches) {
+        const [tx, ty] = canvasPoint(t);
+        pinched = onTouchMove(selector.value, t.identifier, tx, ty) || pinched;
+      }
+      if (pinched) {
+        renderSelectedDemo();
+      }
+      if (!isDragging) return;
       const touch = e.touches[0];
       const rect = canvas.getBoundingClientRect();
       const x = (touch.clientX - rect.left) * (canvas.width / rect.width);
@@ -1279,6 +1327,9 @@ export function setupEventHandlers(
   canvas.addEventListener(
     "touchend",
     (e) => {
+      for (const t of e.changedTouches) {
+        onTouchUp(selector.value, t.identifier);
+      }
       if (!isDragging) return;
       e.preventDefault();
       isDragging = false;
@@ -1289,7 +1340,10 @@ export function setupEventHandlers(
     { passive: false },
   );
 
-  canvas.addEventListener("touchcancel", () => {
+  canvas.addEventListener("touchcancel", (e) => {
+    for (const t of e.changedTouches) {
+      onTouchUp(selector.value, t.identifier);
+    }
     if (!isDragging) return;
     isDragging = false;
     onMouseUp(selector.value);
@@ -1297,10 +1351,13 @@ export function setupEventHandlers(
     persistDemoParams(selector.value);
   });
 
-  // Animation loop for demos that need it (gouraud_mesh, trans_curve)
-  function animate() {
+  // Animated demos, and the lion for live mouse and keyboard control, run in
+  // Go's requestAnimationFrame loop. Go handles the canvas events itself
+  // while the loop runs, so the handlers above do not see them.
+  function syncAnimation() {
     const demoType = selector.value;
     if (
+      demoType === "lion" ||
       demoType === "gouraud_mesh" ||
       (demoType === "trans_curve" &&
         document.getElementById("transCurveAnimate").checked) ||
@@ -1311,9 +1368,11 @@ export function setupEventHandlers(
         document.getElementById("molViewAutoRotate").checked) ||
       demoType === "distortions"
     ) {
-      renderSelectedDemo();
+      startAnimation(demoType);
+    } else {
+      stopAnimation();
     }
-    requestAnimationFrame(animate);
   }
-  requestAnimationFrame(animate);
+  document.addEventListener("change", syncAnimation);
+  syncAnimation();
 }
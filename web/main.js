@@ -10,8 +10,6 @@ const go = new Go();
 let wasmInstance;
 let canvas;
 let ctx;
-let imageData;
-let pixels;
 
 window.onerror = function (message, source, lineno, colno, error) {
   console.error("Global JS Error:", message, "at", source, ":", lineno);
@@ -37,6 +35,10 @@ async function init() {
       updateStatus("WASM Error: " + err.message);
     });
 
+    // Let Go hand frames over as a view of its own memory instead of
+    // copying every frame into a JavaScript buffer.
+    useSharedFrames(wasmInstance.exports.mem);
+
     initTheme();
 
     // Hide loading screen
@@ -49,9 +51,6 @@ async function init() {
     canvas.height = dims.height;
     ctx = canvas.getContext("2d");
 
-    imageData = ctx.createImageData(dims.width, dims.height);
-    pixels = new Uint8ClampedArray(dims.width * dims.height * 4);
-
     // Restore state from URL params
     const params = getURLParams();
     const selector = document.getElementById("demoSelector");
@@ -83,9 +82,8 @@ function renderSelectedDemo() {
     demoDescriptions[demoType] || "";
 
   try {
-    renderDemo(demoType, pixels);
-    imageData.data.set(pixels);
-    ctx.putImageData(imageData, 0, 0);
+    renderDemo(demoType);
+    ctx.putImageData(getFrameImageData(), 0, 0);
     updateDemoReadouts(demoType);
     updateStatus("Rendered " + demoType);
   } catch (err) {